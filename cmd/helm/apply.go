@@ -0,0 +1,182 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/releasespec"
+	"k8s.io/helm/pkg/renderutil"
+)
+
+const applyDesc = `
+This command converges the releases of the cluster to a spec of multiple
+releases: it installs the releases that don't exist, upgrades the releases that
+do, and deletes the releases marked 'installed: false'.
+
+A spec lists the releases with their chart, chart version, namespace,
+environment and values. The values are value files, relative to the spec, or
+inline values, later values taking precedence:
+
+	releases:
+	- name: database
+	  chart: stable/postgresql
+	  version: 8.1.0
+	  namespace: data
+	  values:
+	  - values/database.yaml
+	  - persistence:
+	      size: 20Gi
+	- name: web
+	  chart: ./charts/web
+	  environment: environments/prod.yaml
+	  needs: [database]
+	  wait: true
+	  timeout: 600
+	- name: legacy
+	  chart: stable/nginx
+	  installed: false
+
+A release is applied as soon as the releases it 'needs' are ready: the
+releases needed by other releases are always applied with '--wait', so that a
+release only starts once its dependencies are healthy. The releases are deleted
+before the releases they need. The releases that don't depend on each other are
+applied concurrently, up to '--concurrency' at once. When a release fails, the
+releases that depend on it are skipped. The needs must not form a cycle.
+
+Use '--wait' to wait for every release, and not only the needed ones.
+
+Use '--dry-run' to simulate the installs, upgrades and deletes.
+`
+
+type applyCmd struct {
+	file        string
+	concurrency int
+	dryRun      bool
+	wait        bool
+	verify      bool
+	keyring     string
+	devel       bool
+
+	out    io.Writer
+	client helm.Interface
+}
+
+func newApplyCmd(c helm.Interface, out io.Writer) *cobra.Command {
+	ac := &applyCmd{
+		out:    out,
+		client: c,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "apply -f SPEC",
+		Short:   "Install, upgrade and delete releases to converge them to a multi-release spec",
+		Long:    applyDesc,
+		PreRunE: func(_ *cobra.Command, _ []string) error { return setupConnection() },
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				return errors.New("command 'apply' doesn't support arguments, set the spec with -f")
+			}
+			if ac.file == "" {
+				return errors.New("the spec is required, set it with -f")
+			}
+			ac.client = ensureHelmClient(ac.client)
+			return ac.run()
+		},
+	}
+
+	f := cmd.Flags()
+	settings.AddFlagsTLS(f)
+	f.StringVarP(&ac.file, "file", "f", "", "The spec of the releases")
+	f.IntVar(&ac.concurrency, "concurrency", 0, "The maximum number of releases applied at once. Defaults to no limit")
+	f.BoolVar(&ac.dryRun, "dry-run", false, "Simulate the installs, upgrades and deletes")
+	f.BoolVar(&ac.wait, "wait", false, "Wait until the resources of every release are ready, and not only those of the releases needed by other releases")
+	f.BoolVar(&ac.verify, "verify", false, "Verify the packages before installing them")
+	f.StringVar(&ac.keyring, "keyring", defaultKeyring(), "Location of public keys used for verification")
+	f.BoolVar(&ac.devel, "devel", false, "Use development versions of the charts without a version, too")
+
+	// set defaults from environment
+	settings.InitTLS(f)
+
+	return cmd
+}
+
+func (a *applyCmd) run() error {
+	spec, err := releasespec.Load(a.file)
+	if err != nil {
+		return err
+	}
+
+	applier := &releasespec.Applier{
+		Client:      a.client,
+		LoadChart:   a.loadChart,
+		Namespace:   defaultNamespace(),
+		Concurrency: a.concurrency,
+		DryRun:      a.dryRun,
+		Wait:        a.wait,
+		Report: func(res *releasespec.Result) {
+			if res.Err != nil {
+				fmt.Fprintf(a.out, "%s: %s failed: %s\n", res.Release.Name, res.Action, res.Err)
+				return
+			}
+			fmt.Fprintf(a.out, "%s: %s\n", res.Release.Name, res.Action)
+		},
+	}
+	_, err = applier.Apply(spec)
+	return err
+}
+
+// loadChart locates and loads the chart of the release r. Chart paths are
+// relative to the spec.
+func (a *applyCmd) loadChart(r *releasespec.Release) (*chart.Chart, error) {
+	name := r.Chart
+	if strings.HasPrefix(name, ".") {
+		name = filepath.Join(filepath.Dir(a.file), name)
+	}
+	version := r.Version
+	if version == "" && a.devel {
+		version = ">0.0.0-0"
+	}
+	cp, err := locateChartPath("", "", "", name, version, a.verify, a.keyring, "", "", "")
+	if err != nil {
+		return nil, err
+	}
+	ch, err := chartutil.LoadWithEnvValuesFile(cp, r.Environment)
+	if err != nil {
+		return nil, err
+	}
+	if chartutil.IsLibraryChart(ch) {
+		return nil, fmt.Errorf("library chart %s is not installable", ch.Metadata.Name)
+	}
+	if req, err := chartutil.LoadRequirements(ch); err == nil {
+		if err := renderutil.CheckDependencies(ch, req); err != nil {
+			return nil, err
+		}
+	} else if err != chartutil.ErrRequirementsNotFound {
+		return nil, fmt.Errorf("cannot load requirements: %v", err)
+	}
+	return ch, nil
+}
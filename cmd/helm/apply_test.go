@@ -0,0 +1,55 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"io"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/release"
+)
+
+func TestApplyCmd(t *testing.T) {
+	tests := []releaseCase{
+		{
+			name:  "apply a spec",
+			flags: []string{"-f", "testdata/releases.yaml", "--concurrency", "1"},
+			rels: []*release.Release{
+				helm.ReleaseMock(&helm.MockReleaseOptions{Name: "aeneas"}),
+				helm.ReleaseMock(&helm.MockReleaseOptions{Name: "legacy"}),
+			},
+			expected: "^legacy: delete\naeneas: upgrade\nvirgil: install\n$",
+		},
+		{
+			name:     "apply without a spec",
+			err:      true,
+			expected: "",
+		},
+		{
+			name:     "apply a missing spec",
+			flags:    []string{"-f", "testdata/missing.yaml"},
+			err:      true,
+			expected: "",
+		},
+	}
+	runReleaseCases(t, tests, func(c *helm.FakeClient, out io.Writer) *cobra.Command {
+		return newApplyCmd(c, out)
+	})
+}
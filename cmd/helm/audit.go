@@ -0,0 +1,179 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/ghodss/yaml"
+	"github.com/gosuri/uitable"
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/timeconv"
+)
+
+var auditHelp = `
+Audit prints the operations recorded in the audit log of a release, the most
+recent first.
+
+Tiller records every install, upgrade, rollback and delete of a release, with
+the client that requested it, the chart and the digest of the supplied values,
+when it is started with an audit sink that can be read back:
+
+    $ tiller --audit-sink=configmap
+
+The audit log is printed as a formatted table, e.g:
+
+    $ helm audit angry-bird --max=2
+    TIME                    	OPERATION	REVISION	USER 	CHART       	RESULT 	VALUES DIGEST
+    Mon Oct 3 10:15:13 2016 	upgrade  	2       	alice	alpine-0.1.0	success	sha256:1c2f...
+    Mon Oct 3 10:12:01 2016 	install  	1       	alice	alpine-0.1.0	success	sha256:9a4b...
+`
+
+type auditInfo struct {
+	Time          string `json:"time"`
+	Operation     string `json:"operation"`
+	Revision      int32  `json:"revision,omitempty"`
+	Namespace     string `json:"namespace,omitempty"`
+	User          string `json:"user"`
+	ClientVersion string `json:"clientVersion,omitempty"`
+	Chart         string `json:"chart,omitempty"`
+	ValuesDigest  string `json:"valuesDigest,omitempty"`
+	Success       bool   `json:"success"`
+	Error         string `json:"error,omitempty"`
+}
+
+type auditCmd struct {
+	max          int32
+	rls          string
+	out          io.Writer
+	helmc        helm.Interface
+	colWidth     uint
+	outputFormat string
+}
+
+func newAuditCmd(c helm.Interface, w io.Writer) *cobra.Command {
+	aud := &auditCmd{out: w, helmc: c}
+
+	cmd := &cobra.Command{
+		Use:     "audit [flags] RELEASE_NAME",
+		Long:    auditHelp,
+		Short:   "Fetch the audit log of a release",
+		PreRunE: func(_ *cobra.Command, _ []string) error { return setupConnection() },
+		RunE: func(cmd *cobra.Command, args []string) error {
+			switch {
+			case len(args) == 0:
+				return errReleaseRequired
+			case aud.helmc == nil:
+				aud.helmc = newClient()
+			}
+			aud.rls = args[0]
+			return aud.run()
+		},
+	}
+
+	f := cmd.Flags()
+	settings.AddFlagsTLS(f)
+	f.Int32Var(&aud.max, "max", 0, "Maximum number of records to print, the most recent first. 0 prints all the records")
+	f.UintVar(&aud.colWidth, "col-width", 60, "Specifies the max column width of output")
+	f.StringVarP(&aud.outputFormat, "output", "o", "table", "Prints the output in the specified format (json|table|yaml)")
+
+	// set defaults from environment
+	settings.InitTLS(f)
+
+	return cmd
+}
+
+func (cmd *auditCmd) run() error {
+	res, err := cmd.helmc.ReleaseAudit(cmd.rls, helm.AuditMax(cmd.max))
+	if err != nil {
+		return prettyError(err)
+	}
+	if len(res.Records) == 0 {
+		fmt.Fprintf(cmd.out, "No audit records for %s\n", cmd.rls)
+		return nil
+	}
+
+	records := getAuditInfo(res.Records)
+
+	var out []byte
+	var formattingError error
+
+	switch cmd.outputFormat {
+	case "yaml":
+		out, formattingError = yaml.Marshal(records)
+	case "json":
+		out, formattingError = json.Marshal(records)
+	case "table":
+		out = formatAuditAsTable(records, cmd.colWidth)
+	default:
+		return fmt.Errorf("unknown output format %q", cmd.outputFormat)
+	}
+
+	if formattingError != nil {
+		return prettyError(formattingError)
+	}
+
+	fmt.Fprintln(cmd.out, string(out))
+	return nil
+}
+
+func getAuditInfo(records []*services.AuditRecord) []auditInfo {
+	infos := make([]auditInfo, 0, len(records))
+	for _, r := range records {
+		chart := r.Chart
+		if chart != "" && r.ChartVersion != "" {
+			chart = fmt.Sprintf("%s-%s", r.Chart, r.ChartVersion)
+		}
+		infos = append(infos, auditInfo{
+			Time:          timeconv.String(r.Time),
+			Operation:     r.Operation,
+			Revision:      r.Revision,
+			Namespace:     r.Namespace,
+			User:          r.User,
+			ClientVersion: r.ClientVersion,
+			Chart:         chart,
+			ValuesDigest:  r.ValuesDigest,
+			Success:       r.Success,
+			Error:         r.Error,
+		})
+	}
+	return infos
+}
+
+func formatAuditAsTable(records []auditInfo, colWidth uint) []byte {
+	tbl := uitable.New()
+
+	tbl.MaxColWidth = colWidth
+	tbl.AddRow("TIME", "OPERATION", "REVISION", "USER", "CHART", "RESULT", "VALUES DIGEST")
+	for _, r := range records {
+		result := "success"
+		if !r.Success {
+			result = "failed: " + r.Error
+		}
+		revision := "-"
+		if r.Revision > 0 {
+			revision = fmt.Sprint(r.Revision)
+		}
+		tbl.AddRow(r.Time, r.Operation, revision, r.User, r.Chart, result, r.ValuesDigest)
+	}
+	return tbl.Bytes()
+}
@@ -0,0 +1,71 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"io"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/helm"
+	rls "k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/timeconv"
+)
+
+func TestAuditCmd(t *testing.T) {
+	now := timeconv.Now()
+	records := []*rls.AuditRecord{
+		{Time: now, Release: "angry-bird", Operation: "install", Revision: 1, User: "alice", Chart: "foo", ChartVersion: "0.1.0", ValuesDigest: "sha256:abc", Success: true},
+		{Time: now, Release: "other", Operation: "install", Revision: 1, User: "bob", Chart: "bar", ChartVersion: "1.0.0", Success: true},
+		{Time: now, Release: "angry-bird", Operation: "upgrade", User: "bob", Chart: "foo", ChartVersion: "0.2.0", Error: "timed out"},
+	}
+
+	tests := []releaseCase{
+		{
+			name:     "get audit log for release",
+			args:     []string{"angry-bird"},
+			expected: "TIME.*\tOPERATION\tREVISION\tUSER \tCHART    \tRESULT           \tVALUES DIGEST\n.*\tupgrade  \t-       \tbob  \tfoo-0.2.0\tfailed: timed out\t             \n.*\tinstall  \t1       \talice\tfoo-0.1.0\tsuccess          \tsha256:abc   \n",
+		},
+		{
+			name:     "get audit log with max limit set",
+			args:     []string{"angry-bird"},
+			flags:    []string{"--max", "1"},
+			expected: "TIME.*\n.*\tupgrade  \t-.*\n$",
+		},
+		{
+			name:     "get audit log with json output format",
+			args:     []string{"angry-bird"},
+			flags:    []string{"--max", "1", "--output", "json"},
+			expected: `\[{"time":".*","operation":"upgrade","user":"bob","chart":"foo-0.2.0","success":false,"error":"timed out"}\]` + "\n",
+		},
+		{
+			name:     "get audit log of release without records",
+			args:     []string{"happy-bird"},
+			expected: "No audit records for happy-bird\n",
+		},
+		{
+			name: "get audit log without release",
+			err:  true,
+		},
+	}
+
+	runReleaseCases(t, tests, func(c *helm.FakeClient, out io.Writer) *cobra.Command {
+		c.AuditRecords = records
+		return newAuditCmd(c, out)
+	})
+}
@@ -17,14 +17,22 @@ package main
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
+	"os"
+	"sort"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/repo"
 )
 
 const completionDesc = `
-Generate autocompletions script for Helm for the specified shell (bash or zsh).
+Generate autocompletions script for Helm for the specified shell (bash, zsh or fish).
 
 This command can generate shell autocompletions. e.g.
 
@@ -33,12 +41,22 @@ This command can generate shell autocompletions. e.g.
 Can be sourced as such
 
 	$ source <(helm completion bash)
+
+or, for fish
+
+	$ helm completion fish | source
+
+Besides the commands and the flags, the release names are completed from
+Tiller, the chart names and versions from the cached indexes of the
+repositories, and the '--environment' values files from the environments/
+directory of the chart.
 `
 
 var (
 	completionShells = map[string]func(out io.Writer, cmd *cobra.Command) error{
 		"bash": runCompletionBash,
 		"zsh":  runCompletionZsh,
+		"fish": runCompletionFish,
 	}
 )
 
@@ -50,17 +68,133 @@ func newCompletionCmd(out io.Writer) *cobra.Command {
 
 	cmd := &cobra.Command{
 		Use:   "completion SHELL",
-		Short: "Generate autocompletions script for the specified shell (bash or zsh)",
+		Short: "Generate autocompletions script for the specified shell (bash, zsh or fish)",
 		Long:  completionDesc,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runCompletion(out, cmd, args)
 		},
 		ValidArgs: shells,
 	}
+	cmd.AddCommand(newCompletionListCmd(out))
 
 	return cmd
 }
 
+// newCompletionListCmd returns the hidden command listing the candidates of
+// the dynamic completions, called by the completion scripts.
+func newCompletionListCmd(out io.Writer) *cobra.Command {
+	return &cobra.Command{
+		Use:    "__list charts | versions CHART | environments CHART",
+		Short:  "List the candidates of a dynamic completion",
+		Hidden: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			candidates, err := completionCandidates(args)
+			if err != nil {
+				return err
+			}
+			for _, c := range candidates {
+				fmt.Fprintln(out, c)
+			}
+			return nil
+		},
+	}
+}
+
+// completionCandidates returns the candidates of the dynamic completion of
+// args: the charts of the repositories, the versions of a chart of a
+// repository, or the environment values files of a chart.
+func completionCandidates(args []string) ([]string, error) {
+	if len(args) == 0 {
+		return nil, errors.New("completion kind not specified")
+	}
+	switch args[0] {
+	case "charts":
+		return completeCharts()
+	case "versions", "environments":
+		if len(args) != 2 {
+			return nil, fmt.Errorf("the %s completion requires a chart", args[0])
+		}
+		if args[0] == "versions" {
+			return completeChartVersions(args[1])
+		}
+		return completeEnvironments(args[1])
+	default:
+		return nil, fmt.Errorf("unknown completion kind %q", args[0])
+	}
+}
+
+// completeCharts returns the charts of the cached indexes of the
+// repositories, like stable/mariadb.
+func completeCharts() ([]string, error) {
+	rf, err := repo.LoadRepositoriesFile(settings.Home.RepositoryFile())
+	if err != nil {
+		return nil, err
+	}
+	var charts []string
+	for _, r := range rf.Repositories {
+		ind, err := repo.LoadIndexFile(settings.Home.CacheIndex(r.Name))
+		if err != nil {
+			continue
+		}
+		for name := range ind.Entries {
+			charts = append(charts, r.Name+"/"+name)
+		}
+	}
+	sort.Strings(charts)
+	return charts, nil
+}
+
+// completeChartVersions returns the versions of the chart of a repository,
+// like stable/mariadb, in the cached index of the repository, newest first.
+func completeChartVersions(chart string) ([]string, error) {
+	parts := strings.SplitN(chart, "/", 2)
+	if len(parts) != 2 {
+		return nil, nil
+	}
+	ind, err := repo.LoadIndexFile(settings.Home.CacheIndex(parts[0]))
+	if err != nil {
+		return nil, nil
+	}
+	var versions []string
+	for _, cv := range ind.Entries[parts[1]] {
+		versions = append(versions, cv.Version)
+	}
+	return versions, nil
+}
+
+// completeEnvironments returns the environment values files of the
+// environments directory of the local chart, a directory or an archive.
+func completeEnvironments(chart string) ([]string, error) {
+	if _, err := os.Stat(chart); err != nil {
+		return nil, nil
+	}
+	c, err := chartutil.Load(chart)
+	if err != nil {
+		return nil, nil
+	}
+	return chartEnvironments(c), nil
+}
+
+// setChartFlagCompletions sets the dynamic completions of the --version and
+// --environment flags of the commands taking a chart argument, to the
+// versions and the environments of the chart.
+func setChartFlagCompletions(cmd *cobra.Command) {
+	if strings.Contains(strings.ToLower(cmd.Use), "chart") {
+		completions := map[string]string{
+			"version":     "__helm_list_chart_versions",
+			"environment": "__helm_list_environments",
+		}
+		cmd.Flags().VisitAll(func(f *pflag.Flag) {
+			if fn, ok := completions[f.Name]; ok {
+				cmd.Flags().SetAnnotation(f.Name, cobra.BashCompCustom, []string{fn})
+			}
+		})
+	}
+	for _, c := range cmd.Commands() {
+		setChartFlagCompletions(c)
+	}
+}
+
 func runCompletion(out io.Writer, cmd *cobra.Command, args []string) error {
 	if len(args) == 0 {
 		return fmt.Errorf("shell not specified")
@@ -223,3 +357,174 @@ __helm_bash_source <(__helm_convert_bash_to_zsh)
 	out.Write([]byte(zshTail))
 	return nil
 }
+
+// fishCompletionFuncs are the functions of the fish completions, and the
+// completions of the arguments of the commands.
+const fishCompletionFuncs = `# fish completion for helm
+
+function __helm_args
+    set -l tokens (commandline -opc)
+    set -e tokens[1]
+    for t in $tokens
+        switch $t
+            case '-*'
+            case '*'
+                echo $t
+        end
+    end
+end
+
+# __helm_arg_of N COMMAND... checks that the command line is COMMAND followed
+# by N arguments.
+function __helm_arg_of
+    set -l n $argv[1]
+    set -e argv[1]
+    set -l args (__helm_args)
+    test (count $args) -eq (math (count $argv) + $n); or return 1
+    test (count $argv) -eq 0; and return 0
+    test "$args[1..(count $argv)]" = "$argv"
+end
+
+# __helm_in_command COMMAND... checks that the command line starts with
+# COMMAND.
+function __helm_in_command
+    set -l args (__helm_args)
+    test (count $args) -ge (count $argv); or return 1
+    test "$args[1..(count $argv)]" = "$argv"
+end
+
+function __helm_override_flags
+    set -l next
+    for t in (commandline -opc)
+        if test -n "$next"
+            echo $next
+            echo $t
+            set next
+            continue
+        end
+        switch $t
+            case '--kubeconfig=*' '--kube-context=*' '--host=*' '--tiller-namespace=*' '--home=*'
+                echo $t
+            case --kubeconfig --kube-context --host --tiller-namespace --home
+                set next $t
+        end
+    end
+end
+
+function __helm_binary_name
+    set -l tokens (commandline -opc)
+    echo $tokens[1]
+end
+
+function __helm_chart_arg
+    set -l chart
+    for a in (__helm_args)
+        if string match -q -- '*/*' $a; or string match -q -- '*.tgz' $a; or test -e $a
+            set chart $a
+        end
+    end
+    echo $chart
+end
+
+function __helm_list_releases
+    set -l helm (__helm_binary_name)
+    $helm list (__helm_override_flags) -a -q -m 1000 2>/dev/null
+end
+
+function __helm_list_repos
+    set -l helm (__helm_binary_name)
+    $helm repo list (__helm_override_flags) 2>/dev/null | tail -n +2 | cut -f1
+end
+
+function __helm_list_plugins
+    set -l helm (__helm_binary_name)
+    $helm plugin list (__helm_override_flags) 2>/dev/null | tail -n +2 | cut -f1
+end
+
+function __helm_list_charts
+    set -l helm (__helm_binary_name)
+    $helm completion __list charts (__helm_override_flags) 2>/dev/null
+end
+
+function __helm_list_chart_versions
+    set -l helm (__helm_binary_name)
+    set -l chart (__helm_chart_arg)
+    test -n "$chart"; and $helm completion __list versions $chart (__helm_override_flags) 2>/dev/null
+end
+
+function __helm_list_environments
+    set -l helm (__helm_binary_name)
+    set -l chart (__helm_chart_arg)
+    test -n "$chart"; and $helm completion __list environments $chart (__helm_override_flags) 2>/dev/null
+end
+
+complete -c helm -f -n '__helm_arg_of 0 delete; or __helm_arg_of 0 history; or __helm_arg_of 0 status; or __helm_arg_of 0 test; or __helm_arg_of 0 upgrade; or __helm_arg_of 0 rollback; or __helm_arg_of 0 get; or __helm_arg_of 0 get hooks; or __helm_arg_of 0 get manifest; or __helm_arg_of 0 get notes; or __helm_arg_of 0 get values; or __helm_arg_of 0 diff upgrade' -a '(__helm_list_releases)'
+complete -c helm -n '__helm_arg_of 0 install; or __helm_arg_of 0 template; or __helm_arg_of 0 fetch; or __helm_arg_of 0 inspect; or __helm_arg_of 0 inspect chart; or __helm_arg_of 0 inspect readme; or __helm_arg_of 0 inspect values; or __helm_arg_of 1 upgrade; or __helm_arg_of 1 diff upgrade' -a '(__helm_list_charts)'
+complete -c helm -f -n '__helm_arg_of 0 repo remove; or __helm_arg_of 0 repo update' -a '(__helm_list_repos)'
+complete -c helm -f -n '__helm_arg_of 0 plugin remove; or __helm_arg_of 0 plugin update' -a '(__helm_list_plugins)'
+`
+
+func runCompletionFish(out io.Writer, cmd *cobra.Command) error {
+	fmt.Fprint(out, fishCompletionFuncs)
+	root := cmd.Root()
+	root.PersistentFlags().VisitAll(func(f *pflag.Flag) {
+		writeFishFlagCompletion(out, "", f)
+	})
+	writeFishCompletions(out, root, nil)
+	return nil
+}
+
+// writeFishCompletions writes the fish completions of the subcommands and of
+// the flags of the command cmd, of path path.
+func writeFishCompletions(out io.Writer, cmd *cobra.Command, path []string) {
+	args := strings.Join(path, " ")
+	for _, c := range cmd.Commands() {
+		if !c.IsAvailableCommand() {
+			continue
+		}
+		cond := strings.TrimSpace("__helm_arg_of 0 " + args)
+		fmt.Fprintf(out, "complete -c helm -f -n %s -a %s -d %s\n", fishQuote(cond), c.Name(), fishQuote(c.Short))
+		writeFishCompletions(out, c, append(path, c.Name()))
+	}
+	if len(path) == 0 {
+		return
+	}
+	cond := "__helm_in_command " + args
+	if cmd.HasAvailableSubCommands() {
+		cond = "__helm_arg_of 0 " + args
+	}
+	cmd.NonInheritedFlags().VisitAll(func(f *pflag.Flag) {
+		if cmd.Root().PersistentFlags().Lookup(f.Name) == nil {
+			writeFishFlagCompletion(out, cond, f)
+		}
+	})
+}
+
+// writeFishFlagCompletion writes the fish completion of the flag f, under the
+// condition cond.
+func writeFishFlagCompletion(out io.Writer, cond string, f *pflag.Flag) {
+	if f.Hidden || f.Deprecated != "" {
+		return
+	}
+	line := "complete -c helm"
+	if cond != "" {
+		line += " -n " + fishQuote(cond)
+	}
+	line += " -l " + f.Name
+	if f.Shorthand != "" && f.ShorthandDeprecated == "" {
+		line += " -s " + f.Shorthand
+	}
+	if f.Value.Type() != "bool" && f.NoOptDefVal == "" {
+		line += " -r"
+		if fn, ok := f.Annotations[cobra.BashCompCustom]; ok && len(fn) > 0 {
+			line += " -f -a " + fishQuote("("+fn[0]+")")
+		}
+	}
+	line += " -d " + fishQuote(f.Usage)
+	fmt.Fprintln(out, line)
+}
+
+// fishQuote quotes s for fish.
+func fishQuote(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, "'", `\'`).Replace(s) + "'"
+}
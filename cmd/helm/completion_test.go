@@ -0,0 +1,102 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestCompletionList(t *testing.T) {
+	cleanup := resetEnv()
+	defer cleanup()
+
+	settings.Home = "testdata/helmhome"
+
+	tests := []struct {
+		name   string
+		args   []string
+		expect string
+		err    bool
+	}{
+		{
+			name:   "charts",
+			args:   []string{"charts"},
+			expect: "testing/alpine\ntesting/mariadb\n",
+		},
+		{
+			name:   "versions",
+			args:   []string{"versions", "testing/alpine"},
+			expect: "0.2.0\n0.1.0\n",
+		},
+		{
+			name: "versions of an unknown chart",
+			args: []string{"versions", "testing/whale"},
+		},
+		{
+			name:   "environments",
+			args:   []string{"environments", "testdata/testcharts/matrix"},
+			expect: "environments/broken.yaml\nenvironments/prod.yaml\nenvironments/staging.yaml\n",
+		},
+		{
+			name: "environments without a chart",
+			args: []string{"environments"},
+			err:  true,
+		},
+		{
+			name: "unknown kind",
+			args: []string{"releases"},
+			err:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			cmd := newCompletionListCmd(&buf)
+			if err := cmd.RunE(cmd, tt.args); (err != nil) != tt.err {
+				t.Fatalf("Expected an error to be %v, got %v", tt.err, err)
+			}
+			if buf.String() != tt.expect {
+				t.Errorf("Expected %q, got %q", tt.expect, buf.String())
+			}
+		})
+	}
+}
+
+func TestCompletionFish(t *testing.T) {
+	cleanup := resetEnv()
+	defer cleanup()
+
+	var buf bytes.Buffer
+	if err := runCompletionFish(&buf, newRootCmd(nil)); err != nil {
+		t.Fatal(err)
+	}
+	for _, expect := range []string{
+		"complete -c helm -f -n '__helm_arg_of 0' -a install -d 'Install a chart archive'",
+		"complete -c helm -f -n '__helm_arg_of 0 repo' -a add -d 'Add a chart repository'",
+		"complete -c helm -n '__helm_in_command install' -l version -r -f -a '(__helm_list_chart_versions)'",
+		"complete -c helm -n '__helm_in_command install' -l environment -r -f -a '(__helm_list_environments)'",
+		"complete -c helm -n '__helm_in_command install' -l values -s f -r",
+		"complete -c helm -l kube-context -r",
+	} {
+		if !strings.Contains(buf.String(), expect) {
+			t.Errorf("Expected %q in the fish completions", expect)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/kustomize"
+)
+
+const convertDesc = `
+This command converts a kustomize tree, with a base and overlays, to a chart
+whose environments are the overlays.
+
+The tree is expected to look like this:
+
+	myapp/
+	  |- base/               # The kustomization of the base and its resources
+	  |- overlays/prod/      # The kustomization of an overlay, using ../../base
+	  |- overlays/staging/
+
+'helm convert myapp' creates the chart myapp, where the resources of the base
+are templates, and the name prefix and suffix, the namespace, the common labels
+and annotations, the images and the replicas of the kustomizations are values:
+
+	myapp/
+	  |- Chart.yaml
+	  |- values.yaml         # The values of the base
+	  |- templates/          # The resources of the base
+	  |- environments/       # The values of every overlay, e.g. prod.yaml
+
+The chart is installed in an environment with '--environment':
+
+	$ helm install ./myapp --environment environments/prod.yaml
+
+The resources that are only in overlays, the patches and the generators are not
+converted: a warning lists them, to be ported to the chart by hand.
+`
+
+type convertCmd struct {
+	dir  string
+	name string
+	dest string
+	out  io.Writer
+}
+
+func newConvertCmd(out io.Writer) *cobra.Command {
+	cc := &convertCmd{out: out}
+
+	cmd := &cobra.Command{
+		Use:   "convert [flags] KUSTOMIZE_DIR",
+		Short: "Convert a kustomize tree to a chart with an environment per overlay",
+		Long:  convertDesc,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return errors.New("the kustomize directory is required")
+			}
+			if len(args) > 1 {
+				return errors.New("command 'convert' doesn't support multiple arguments")
+			}
+			cc.dir = args[0]
+			return cc.run()
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVar(&cc.name, "name", "", "The name of the chart. Defaults to the name of the kustomize directory")
+	f.StringVarP(&cc.dest, "destination", "d", ".", "Location to write the chart")
+	return cmd
+}
+
+func (c *convertCmd) run() error {
+	dir, err := filepath.Abs(c.dir)
+	if err != nil {
+		return err
+	}
+	if c.name == "" {
+		c.name = filepath.Base(dir)
+	}
+	cdir := filepath.Join(c.dest, c.name)
+	if _, err := os.Stat(cdir); err == nil {
+		return fmt.Errorf("%s already exists", cdir)
+	}
+
+	conv, err := kustomize.Convert(dir, c.name)
+	if err != nil {
+		return err
+	}
+	for _, f := range conv.Files {
+		name := filepath.Join(cdir, filepath.FromSlash(f.Name))
+		if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(name, f.Data, 0644); err != nil {
+			return err
+		}
+	}
+	for _, w := range conv.Warnings {
+		warning("%s", w)
+	}
+
+	fmt.Fprintf(c.out, "Converted %s to the chart %s\n", c.dir, cdir)
+	if len(conv.Environments) > 0 {
+		fmt.Fprintf(c.out, "Environments: %s\n", strings.Join(conv.Environments, ", "))
+	}
+	return nil
+}
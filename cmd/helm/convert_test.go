@@ -0,0 +1,61 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"k8s.io/helm/pkg/chartutil"
+)
+
+func TestConvertCmd(t *testing.T) {
+	tdir, err := ioutil.TempDir("", "helm-convert-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tdir)
+
+	var buf bytes.Buffer
+	cmd := newConvertCmd(&buf)
+	cmd.ParseFlags([]string{"--destination", tdir, "--name", "web"})
+	if err := cmd.RunE(cmd, []string{"../../pkg/kustomize/testdata/app"}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "Environments: prod, staging") {
+		t.Errorf("Expected the environments to be listed, got %q", buf.String())
+	}
+
+	c, err := chartutil.LoadDirWithEnvValuesFiles(filepath.Join(tdir, "web"), "environments/prod.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Metadata.Name != "web" {
+		t.Errorf("Expected the chart web, got %q", c.Metadata.Name)
+	}
+	if !strings.Contains(c.Values.Raw, "namespace: production") {
+		t.Errorf("Expected the values of the prod environment, got\n%s", c.Values.Raw)
+	}
+
+	if err := cmd.RunE(cmd, []string{"../../pkg/kustomize/testdata/app"}); err == nil {
+		t.Error("Expected converting to an existing chart to fail")
+	}
+}
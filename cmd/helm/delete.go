@@ -20,18 +20,39 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sort"
 
+	"github.com/ghodss/yaml"
+	"github.com/gosuri/uitable"
 	"github.com/spf13/cobra"
 
 	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/hooks"
+	"k8s.io/helm/pkg/kube"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/releaseutil"
+	"k8s.io/helm/pkg/tiller"
 )
 
 const deleteDesc = `
 This command takes a release name, and then deletes the release from Kubernetes.
 It removes all of the resources associated with the last release of the chart.
 
-Use the '--dry-run' flag to see which releases will be deleted without actually
-deleting them.
+Use the '--dry-run' flag to review the deletion without deleting anything. It
+prints, from the stored manifest of the release, the kind, name and namespace of
+every resource that would be deleted or kept, and the hooks that would run.
+
+The '--cascade' flag sets how the dependents of the deleted resources, like the
+pods of a deployment, are deleted: 'background' (the default) deletes them after
+the resources, 'foreground' deletes them before the resources, and 'orphan'
+leaves them in the cluster.
+
+Resources annotated with 'helm.sh/resource-policy: keep' are not deleted. They
+are listed when the release is deleted, and in the status of the deleted release.
+
+The experimental '--no-tiller' flag deletes a release installed with
+'--no-tiller', whose records are stored as Secrets in the namespace set by
+'--namespace'.
 `
 
 type deleteCmd struct {
@@ -41,6 +62,9 @@ type deleteCmd struct {
 	purge        bool
 	timeout      int64
 	description  string
+	cascade      string
+	noTiller     bool
+	namespace    string
 
 	out    io.Writer
 	client helm.Interface
@@ -58,7 +82,7 @@ func newDeleteCmd(c helm.Interface, out io.Writer) *cobra.Command {
 		SuggestFor: []string{"remove", "rm"},
 		Short:      "Given a release name, delete the release from Kubernetes",
 		Long:       deleteDesc,
-		PreRunE:    func(_ *cobra.Command, _ []string) error { return setupConnection() },
+		PreRunE:    func(_ *cobra.Command, _ []string) error { return setupReleaseConnection(del.noTiller, del.namespace) },
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) == 0 {
 				return errors.New("command 'delete' requires a release name")
@@ -67,6 +91,12 @@ func newDeleteCmd(c helm.Interface, out io.Writer) *cobra.Command {
 
 			for i := 0; i < len(args); i++ {
 				del.name = args[i]
+				if del.dryRun {
+					if err := del.plan(); err != nil {
+						return err
+					}
+					continue
+				}
 				if err := del.run(); err != nil {
 					return err
 				}
@@ -79,11 +109,14 @@ func newDeleteCmd(c helm.Interface, out io.Writer) *cobra.Command {
 
 	f := cmd.Flags()
 	settings.AddFlagsTLS(f)
-	f.BoolVar(&del.dryRun, "dry-run", false, "Simulate a delete")
+	f.BoolVar(&del.dryRun, "dry-run", false, "Print the resources that would be deleted and the hooks that would run, without deleting anything")
 	f.BoolVar(&del.disableHooks, "no-hooks", false, "Prevent hooks from running during deletion")
 	f.BoolVar(&del.purge, "purge", false, "Remove the release from the store and make its name free for later use")
 	f.Int64Var(&del.timeout, "timeout", 300, "Time in seconds to wait for any individual Kubernetes operation (like Jobs for hooks)")
 	f.StringVar(&del.description, "description", "", "Specify a description for the release")
+	f.BoolVar(&del.noTiller, "no-tiller", false, noTillerUsage)
+	f.StringVar(&del.namespace, "namespace", "", "Namespace of the release, with --no-tiller. Defaults to the current kube config namespace")
+	f.StringVar(&del.cascade, "cascade", "background", "Propagation policy of the deletion of the dependents of the resources: background, foreground or orphan")
 
 	// set defaults from environment
 	settings.InitTLS(f)
@@ -93,11 +126,11 @@ func newDeleteCmd(c helm.Interface, out io.Writer) *cobra.Command {
 
 func (d *deleteCmd) run() error {
 	opts := []helm.DeleteOption{
-		helm.DeleteDryRun(d.dryRun),
 		helm.DeleteDisableHooks(d.disableHooks),
 		helm.DeletePurge(d.purge),
 		helm.DeleteTimeout(d.timeout),
 		helm.DeleteDescription(d.description),
+		helm.DeleteCascade(d.cascade),
 	}
 	res, err := d.client.DeleteRelease(d.name, opts...)
 	if res != nil && res.Info != "" {
@@ -106,3 +139,103 @@ func (d *deleteCmd) run() error {
 
 	return prettyError(err)
 }
+
+// plan prints the resources of the release that a deletion would delete and
+// keep, in the order they would be deleted, and the hooks it would run.
+func (d *deleteCmd) plan() error {
+	res, err := d.client.ReleaseContent(d.name)
+	if err != nil {
+		return prettyError(err)
+	}
+	rel := res.Release
+
+	var deleted, kept []tiller.Manifest
+	for name, content := range releaseutil.SplitManifests(rel.Manifest) {
+		var head releaseutil.SimpleHead
+		if err := yaml.Unmarshal([]byte(content), &head); err != nil || head.Kind == "" || head.Metadata == nil {
+			continue
+		}
+		m := tiller.Manifest{Name: name, Content: content, Head: &head}
+		if kube.ResourcePolicyIsKeep(head.Metadata.Annotations) {
+			kept = append(kept, m)
+		} else {
+			deleted = append(deleted, m)
+		}
+	}
+
+	fmt.Fprintf(d.out, "RELEASE: %s\n", rel.Name)
+	fmt.Fprintf(d.out, "NAMESPACE: %s\n", rel.Namespace)
+	if d.purge {
+		fmt.Fprintln(d.out, "The release record would be purged.")
+	}
+	fmt.Fprintf(d.out, "\nRESOURCES TO DELETE:\n%s\n", planTable(tiller.SortByUninstallOrder(deleted), rel.Namespace))
+	if len(kept) > 0 {
+		fmt.Fprintf(d.out, "\nRESOURCES TO KEEP (%s):\n%s\n", kube.ResourcePolicyAnno, planTable(tiller.SortByUninstallOrder(kept), rel.Namespace))
+	}
+
+	if d.disableHooks {
+		fmt.Fprintln(d.out, "\nHOOKS TO RUN: none, hooks are disabled")
+		return nil
+	}
+	table := uitable.New()
+	table.AddRow("EVENT", "KIND", "NAME", "NAMESPACE")
+	for _, event := range []release.Hook_Event{release.Hook_PRE_DELETE, release.Hook_POST_DELETE} {
+		for _, h := range deleteHooks(rel.Hooks, event) {
+			table.AddRow(hookEventName(event), h.Kind, h.Name, manifestNamespace(h.Manifest, rel.Namespace))
+		}
+	}
+	fmt.Fprintf(d.out, "\nHOOKS TO RUN:\n%s\n", table)
+	return nil
+}
+
+// planTable lists the kind, name and namespace of manifests.
+func planTable(manifests []tiller.Manifest, namespace string) *uitable.Table {
+	table := uitable.New()
+	table.AddRow("KIND", "NAME", "NAMESPACE")
+	for _, m := range manifests {
+		ns := namespace
+		if m.Head.Metadata.Namespace != "" {
+			ns = m.Head.Metadata.Namespace
+		}
+		table.AddRow(m.Head.Kind, m.Head.Metadata.Name, ns)
+	}
+	return table
+}
+
+// deleteHooks returns the hooks of an event in the order Tiller runs them:
+// by weight, then by name.
+func deleteHooks(hooks []*release.Hook, event release.Hook_Event) []*release.Hook {
+	var matched []*release.Hook
+	for _, h := range hooks {
+		for _, e := range h.Events {
+			if e == event {
+				matched = append(matched, h)
+				break
+			}
+		}
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		if matched[i].Weight != matched[j].Weight {
+			return matched[i].Weight < matched[j].Weight
+		}
+		return matched[i].Name < matched[j].Name
+	})
+	return matched
+}
+
+func hookEventName(event release.Hook_Event) string {
+	if event == release.Hook_PRE_DELETE {
+		return hooks.PreDelete
+	}
+	return hooks.PostDelete
+}
+
+// manifestNamespace returns the namespace of a manifest, or namespace if it
+// does not set one.
+func manifestNamespace(manifest, namespace string) string {
+	var head releaseutil.SimpleHead
+	if err := yaml.Unmarshal([]byte(manifest), &head); err == nil && head.Metadata != nil && head.Metadata.Namespace != "" {
+		return head.Metadata.Namespace
+	}
+	return namespace
+}
@@ -27,6 +27,20 @@ import (
 )
 
 func TestDelete(t *testing.T) {
+	withDeleteHooks := helm.ReleaseMock(&helm.MockReleaseOptions{Name: "aeneas"})
+	withDeleteHooks.Manifest = helm.MockManifest + `---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+  namespace: shared
+  annotations:
+    "helm.sh/resource-policy": keep
+`
+	withDeleteHooks.Hooks = append(withDeleteHooks.Hooks,
+		&release.Hook{Name: "cleanup", Kind: "Job", Events: []release.Hook_Event{release.Hook_POST_DELETE}},
+		&release.Hook{Name: "backup", Kind: "Job", Events: []release.Hook_Event{release.Hook_PRE_DELETE}},
+	)
 
 	tests := []releaseCase{
 		{
@@ -69,6 +83,34 @@ func TestDelete(t *testing.T) {
 			resp:     helm.ReleaseMock(&helm.MockReleaseOptions{Name: "aeneas"}),
 			rels:     []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "aeneas"})},
 		},
+		{
+			name:     "dry run",
+			args:     []string{"aeneas"},
+			flags:    []string{"--dry-run"},
+			expected: `RESOURCES TO DELETE:\nKIND\s+NAME\s+NAMESPACE\s*\nSecret\s+fixture\s+default\s*\n\nRESOURCES TO KEEP \(helm.sh/resource-policy\):\nKIND\s+NAME\s+NAMESPACE\s*\nConfigMap\s+settings\s+shared\s*\n\nHOOKS TO RUN:\nEVENT\s+KIND\s+NAME\s+NAMESPACE\s*\npre-delete\s+Job\s+backup\s+default\s*\npost-delete\s+Job\s+cleanup\s+default`,
+			rels:     []*release.Release{withDeleteHooks},
+		},
+		{
+			name:     "dry run without hooks",
+			args:     []string{"aeneas"},
+			flags:    []string{"--dry-run", "--no-hooks"},
+			expected: "HOOKS TO RUN: none, hooks are disabled",
+			rels:     []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "aeneas"})},
+		},
+		{
+			name:  "dry run of a missing release",
+			args:  []string{"aeneas"},
+			flags: []string{"--dry-run"},
+			err:   true,
+		},
+		{
+			name:     "delete with cascade",
+			args:     []string{"aeneas"},
+			flags:    []string{"--cascade", "orphan"},
+			expected: "",
+			resp:     helm.ReleaseMock(&helm.MockReleaseOptions{Name: "aeneas"}),
+			rels:     []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "aeneas"})},
+		},
 		{
 			name: "delete without release",
 			args: []string{},
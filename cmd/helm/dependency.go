@@ -62,6 +62,10 @@ appending '/index.yaml' to the URL, it should be able to retrieve the chart
 repository's index. Note: 'repository' can be an alias. The alias must start
 with 'alias:' or '@'.
 
+The 'repository' '@*' looks for the chart in all the repositories added with
+'helm repo add'. The highest version satisfying the 'version' range in any of
+them is used, and the URL of its repository is written to the lock file.
+
 Starting from 2.2.0, repository can be defined as the path to the directory of
 the dependency charts stored locally. The path should start with a prefix of
 "file://". For example,
@@ -75,6 +79,29 @@ the dependency charts stored locally. The path should start with a prefix of
 If the dependency chart is retrieved locally, it is not required to have the
 repository added to helm by "helm repo add". Version matching is also supported
 for this case.
+
+The path is relative to the directory of the chart, so charts of a monorepo
+can depend on their sibling charts. A chart loaded from its directory, e.g. by
+'helm template' or 'helm install', loads the local dependencies that are not
+in its charts/ directory from their path. 'helm dependency update' archives
+them into charts/ and records the digest of their content in the lock file;
+'helm dependency build' fails if their content changed since.
+
+Dependencies can also be cloned from a git repository by prefixing its
+https://, http:// or ssh:// URL with "git+". The "ref" query parameter selects
+the branch, tag or commit and the "path" query parameter the directory of the
+chart in the repository:
+
+    # requirements.yaml
+    dependencies:
+    - name: foo
+      version: "^1.2.0"
+      repository: "git+https://github.com/example/charts?ref=v1.2.3&path=charts/foo"
+
+'helm dependency update' shallow clones the repository with the git command,
+archives the chart into charts/ and records the digest of its content in the
+lock file; 'helm dependency build' fails if the content at the ref changed
+since.
 `
 
 const dependencyListDesc = `
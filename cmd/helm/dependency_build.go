@@ -20,6 +20,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/downloader"
 	"k8s.io/helm/pkg/getter"
 	"k8s.io/helm/pkg/helm/helmpath"
@@ -42,6 +43,7 @@ type dependencyBuildCmd struct {
 	verify    bool
 	keyring   string
 	helmhome  helmpath.Home
+	symlinks  symlinkPolicy
 }
 
 func newDependencyBuildCmd(out io.Writer) *cobra.Command {
@@ -65,6 +67,7 @@ func newDependencyBuildCmd(out io.Writer) *cobra.Command {
 	f := cmd.Flags()
 	f.BoolVar(&dbc.verify, "verify", false, "Verify the packages against signatures")
 	f.StringVar(&dbc.keyring, "keyring", defaultKeyring(), "Keyring containing public keys")
+	addSymlinksFlag(f, &dbc.symlinks)
 
 	return cmd
 }
@@ -76,6 +79,7 @@ func (d *dependencyBuildCmd) run() error {
 		HelmHome:  d.helmhome,
 		Keyring:   d.keyring,
 		Getters:   getter.All(settings),
+		Symlinks:  chartutil.SymlinkPolicy(d.symlinks),
 	}
 	if d.verify {
 		man.Verify = downloader.VerifyIfPossible
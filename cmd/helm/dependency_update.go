@@ -20,6 +20,7 @@ import (
 	"path/filepath"
 
 	"github.com/spf13/cobra"
+	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/downloader"
 	"k8s.io/helm/pkg/getter"
 	"k8s.io/helm/pkg/helm/helmpath"
@@ -33,7 +34,9 @@ are present in 'charts/' and are at an acceptable version. It will pull down
 the latest charts that satisfy the dependencies, and clean up old dependencies.
 
 On successful update, this will generate a lock file that can be used to
-rebuild the requirements to an exact version.
+rebuild the requirements to an exact version. The lock file records the digest
+of every downloaded chart archive, and 'helm dependency build' fails if a
+downloaded archive does not match it.
 
 Dependencies are not required to be represented in 'requirements.yaml'. For that
 reason, an update command will not remove charts unless they are (a) present
@@ -48,6 +51,8 @@ type dependencyUpdateCmd struct {
 	verify      bool
 	keyring     string
 	skipRefresh bool
+	refresh     bool
+	symlinks    symlinkPolicy
 }
 
 // newDependencyUpdateCmd creates a new dependency update command.
@@ -81,6 +86,8 @@ func newDependencyUpdateCmd(out io.Writer) *cobra.Command {
 	f.BoolVar(&duc.verify, "verify", false, "Verify the packages against signatures")
 	f.StringVar(&duc.keyring, "keyring", defaultKeyring(), "Keyring containing public keys")
 	f.BoolVar(&duc.skipRefresh, "skip-refresh", false, "Do not refresh the local repository cache")
+	f.BoolVar(&duc.refresh, "refresh", false, "Download the repository indexes even if the cached indexes are up to date")
+	addSymlinksFlag(f, &duc.symlinks)
 
 	return cmd
 }
@@ -93,7 +100,9 @@ func (d *dependencyUpdateCmd) run() error {
 		HelmHome:   d.helmhome,
 		Keyring:    d.keyring,
 		SkipUpdate: d.skipRefresh,
+		Refresh:    d.refresh,
 		Getters:    getter.All(settings),
+		Symlinks:   chartutil.SymlinkPolicy(d.symlinks),
 	}
 	if d.verify {
 		man.Verify = downloader.VerifyAlways
@@ -98,6 +98,18 @@ func TestDependencyUpdateCmd(t *testing.T) {
 		t.Errorf("Failed hash match: expected %s, got %s", hash, h)
 	}
 
+	ch, err := chartutil.LoadDir(duc.chartpath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lock, err := chartutil.LoadRequirementsLock(ch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := lock.Dependencies[0]; d.Name != "reqtest" || d.Digest != "sha256:"+hash {
+		t.Errorf("Expected the lock of reqtest with digest sha256:%s, got %s with digest %s", hash, d.Name, d.Digest)
+	}
+
 	// Now change the dependencies and update. This verifies that on update,
 	// old dependencies are cleansed and new dependencies are added.
 	reqfile := &chartutil.Requirements{
@@ -0,0 +1,43 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+)
+
+const devDesc = `
+This command consists of multiple subcommands to help developing charts
+locally.
+
+Example usage:
+    $ helm dev serve mychart
+`
+
+func newDevCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dev [FLAGS] serve [ARGS]",
+		Short: "Tools for developing charts locally",
+		Long:  devDesc,
+	}
+
+	cmd.AddCommand(newDevServeCmd(out))
+
+	return cmd
+}
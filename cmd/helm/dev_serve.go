@@ -0,0 +1,252 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/manifest"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/renderutil"
+	"k8s.io/helm/pkg/strvals"
+	"k8s.io/helm/pkg/tiller"
+	"k8s.io/helm/pkg/timeconv"
+)
+
+const devServeDesc = `
+This command starts a local HTTP server that renders the chart CHART on every
+request, for tools showing the rendered manifests while developing a chart.
+
+The chart is loaded again on every request, so changes of the chart are
+rendered without restarting the server. The templates are rendered like
+'helm template' renders them, with the options set by the query of the request
+to '/render':
+
+    environment   environment values file of the chart, e.g. values-prod.yaml
+    set           value to set, like --set (can be repeated)
+    set-string    STRING value to set, like --set-string (can be repeated)
+    name          release name
+    namespace     release namespace
+
+The body of a POST request is a values file in YAML or JSON, to which the
+values of the query are added.
+
+    $ helm dev serve mychart
+    $ curl 'http://127.0.0.1:8880/render?environment=values-prod.yaml&set=image.tag=1.2.0'
+
+The response is a JSON object with the rendered manifests, sorted by kind, and
+the notes of the chart. If the chart cannot be rendered, the response has the
+status 422 and the JSON object has the error.
+`
+
+type devServeCmd struct {
+	out              io.Writer
+	chartPath        string
+	address          string
+	releaseName      string
+	namespace        string
+	kubeVersion      string
+	apiVersions      []string
+	isolateTemplates bool
+}
+
+func newDevServeCmd(out io.Writer) *cobra.Command {
+	s := &devServeCmd{out: out}
+	cmd := &cobra.Command{
+		Use:   "serve [flags] CHART",
+		Short: "Start a local http server rendering a chart",
+		Long:  devServeDesc,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkArgsLength(len(args), "chart path"); err != nil {
+				return err
+			}
+			s.chartPath = args[0]
+			return s.run()
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVar(&s.address, "address", "127.0.0.1:8880", "Address to listen on")
+	f.StringVarP(&s.releaseName, "name", "n", "release-name", "Default release name")
+	f.StringVar(&s.namespace, "namespace", "", "Default namespace of the release")
+	f.StringVar(&s.kubeVersion, "kube-version", defaultKubeVersion, "Kubernetes version used as Capabilities.KubeVersion.Major/Minor")
+	f.StringArrayVarP(&s.apiVersions, "api-versions", "a", []string{}, "Kubernetes api versions used for Capabilities.APIVersions")
+	f.BoolVar(&s.isolateTemplates, "isolate-templates", false, "Scope named templates to the chart defining them")
+
+	return cmd
+}
+
+func (s *devServeCmd) run() error {
+	chartPath, err := filepath.Abs(s.chartPath)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(chartPath); err != nil {
+		return err
+	}
+	s.chartPath = chartPath
+	if s.namespace == "" {
+		s.namespace = defaultNamespace()
+	}
+
+	fmt.Fprintf(s.out, "Rendering %s on http://%s/render\n", s.chartPath, s.address)
+	return http.ListenAndServe(s.address, s)
+}
+
+// devRenderResponse is the response of the dev server.
+type devRenderResponse struct {
+	Manifests []devManifest `json:"manifests,omitempty"`
+	Notes     string        `json:"notes,omitempty"`
+	Error     string        `json:"error,omitempty"`
+}
+
+// devManifest is a rendered template.
+type devManifest struct {
+	Name    string `json:"name"`
+	Kind    string `json:"kind,omitempty"`
+	Content string `json:"content"`
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (s *devServeCmd) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/render" {
+		writeDevResponse(w, http.StatusNotFound, &devRenderResponse{Error: fmt.Sprintf("%s not found", r.URL.Path)})
+		return
+	}
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		writeDevResponse(w, http.StatusMethodNotAllowed, &devRenderResponse{Error: fmt.Sprintf("method %s not allowed", r.Method)})
+		return
+	}
+
+	config, err := devValues(r)
+	if err != nil {
+		writeDevResponse(w, http.StatusBadRequest, &devRenderResponse{Error: err.Error()})
+		return
+	}
+	resp, err := s.render(r, config)
+	if err != nil {
+		info("Render of %s failed: %s", r.URL, err)
+		writeDevResponse(w, http.StatusUnprocessableEntity, &devRenderResponse{Error: err.Error()})
+		return
+	}
+	writeDevResponse(w, http.StatusOK, resp)
+}
+
+// devValues returns the values of the request: the values file of the body,
+// if any, and the values set by the query.
+func devValues(r *http.Request) (*chart.Config, error) {
+	base := map[string]interface{}{}
+	if r.Method == http.MethodPost {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return nil, err
+		}
+		if err := yaml.Unmarshal(body, &base); err != nil {
+			return nil, fmt.Errorf("failed to parse values: %s", err)
+		}
+		if base == nil {
+			base = map[string]interface{}{}
+		}
+	}
+
+	query := r.URL.Query()
+	for _, value := range query["set"] {
+		if err := strvals.ParseInto(value, base); err != nil {
+			return nil, fmt.Errorf("failed parsing set data: %s", err)
+		}
+	}
+	for _, value := range query["set-string"] {
+		if err := strvals.ParseIntoString(value, base); err != nil {
+			return nil, fmt.Errorf("failed parsing set-string data: %s", err)
+		}
+	}
+
+	raw, err := yaml.Marshal(base)
+	if err != nil {
+		return nil, err
+	}
+	return &chart.Config{Raw: string(raw), Values: map[string]*chart.Value{}}, nil
+}
+
+// render loads the chart and renders it with config.
+func (s *devServeCmd) render(r *http.Request, config *chart.Config) (*devRenderResponse, error) {
+	query := r.URL.Query()
+	name, namespace := s.releaseName, s.namespace
+	if n := query.Get("name"); n != "" {
+		name = n
+	}
+	if ns := query.Get("namespace"); ns != "" {
+		namespace = ns
+	}
+	env := query.Get("environment")
+	c, err := chartutil.LoadWithEnvValuesFile(s.chartPath, env)
+	if err != nil {
+		return nil, err
+	}
+	rendered, err := renderutil.Render(c, config, renderutil.Options{
+		ReleaseOptions: chartutil.ReleaseOptions{
+			Name:        name,
+			IsInstall:   true,
+			Time:        timeconv.Now(),
+			Namespace:   namespace,
+			Environment: env,
+		},
+		KubeVersion:      s.kubeVersion,
+		APIVersions:      s.apiVersions,
+		IsolateTemplates: s.isolateTemplates,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &devRenderResponse{Manifests: []devManifest{}}
+	for _, m := range tiller.SortByKind(manifest.SplitManifests(rendered)) {
+		b := filepath.Base(m.Name)
+		if strings.HasPrefix(b, "_") || whitespaceRegex.MatchString(m.Content) {
+			continue
+		}
+		if b == "NOTES.txt" {
+			if m.Name == c.Metadata.Name+"/templates/NOTES.txt" {
+				resp.Notes = m.Content
+			}
+			continue
+		}
+		dm := devManifest{Name: m.Name, Content: m.Content}
+		if m.Head != nil {
+			dm.Kind = m.Head.Kind
+		}
+		resp.Manifests = append(resp.Manifests, dm)
+	}
+	return resp, nil
+}
+
+func writeDevResponse(w http.ResponseWriter, status int, resp *devRenderResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(resp)
+}
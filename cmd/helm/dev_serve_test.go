@@ -0,0 +1,91 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDevServe(t *testing.T) {
+	dir, err := ioutil.TempDir("", "helm-dev-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"Chart.yaml":          "name: moby\nversion: 0.1.0\n",
+		"values.yaml":         "tag: \"1.0\"\nname: moby\n",
+		"values-prod.yaml":    "tag: \"2.0\"\n",
+		"templates/cm.yaml":   "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{ required \"name is required\" .Values.name }}\n  namespace: {{ .Release.Namespace }}\ndata:\n  tag: {{ .Values.tag | quote }}\n",
+		"templates/NOTES.txt": "Installed {{ .Release.Name }}",
+	}
+	for name, data := range files {
+		if err := os.MkdirAll(filepath.Join(dir, filepath.Dir(name)), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	s := &devServeCmd{chartPath: dir, releaseName: "release-name", namespace: "default", kubeVersion: defaultKubeVersion}
+
+	tests := []struct {
+		name   string
+		method string
+		url    string
+		body   string
+		status int
+		expect []string
+	}{
+		{"defaults", "GET", "/render", "", http.StatusOK, []string{`tag: \"1.0\"`, `"kind":"ConfigMap"`, `"notes":"Installed release-name"`, "namespace: default"}},
+		{"environment", "GET", "/render?environment=values-prod.yaml", "", http.StatusOK, []string{`tag: \"2.0\"`}},
+		{"set", "GET", "/render?environment=values-prod.yaml&set-string=tag=3.0&name=foo&namespace=bar", "", http.StatusOK, []string{`tag: \"3.0\"`, "Installed foo", "namespace: bar"}},
+		{"values body", "POST", "/render?set=name=other", `{"tag": "4.0"}`, http.StatusOK, []string{`tag: \"4.0\"`, "name: other"}},
+		{"render error", "GET", "/render?set=name=null", "", http.StatusUnprocessableEntity, []string{`"error":`, "name is required"}},
+		{"bad values", "POST", "/render", "{", http.StatusBadRequest, []string{"failed to parse values"}},
+		{"bad set", "GET", "/render?set=a", "", http.StatusBadRequest, []string{"failed parsing set data"}},
+		{"not found", "GET", "/", "", http.StatusNotFound, []string{"/ not found"}},
+		{"method", "DELETE", "/render", "", http.StatusMethodNotAllowed, []string{"method DELETE not allowed"}},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.url, strings.NewReader(tt.body)))
+		if rec.Code != tt.status {
+			t.Errorf("%s: expected status %d, got %d: %s", tt.name, tt.status, rec.Code, rec.Body)
+		}
+		if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+			t.Errorf("%s: expected JSON, got %q", tt.name, ct)
+		}
+		var resp devRenderResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Errorf("%s: invalid JSON %q: %s", tt.name, rec.Body, err)
+		}
+		for _, e := range tt.expect {
+			if !strings.Contains(rec.Body.String(), e) {
+				t.Errorf("%s: expected %q in %s", tt.name, e, rec.Body)
+			}
+		}
+	}
+}
@@ -0,0 +1,324 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/releaseutil"
+	"k8s.io/helm/pkg/renderutil"
+)
+
+const diffUpgradeDesc = `
+This command renders the upgrade of a release to a chart, like
+'helm upgrade --dry-run', and prints the changes it would make to the
+resources of the release, as a unified diff of every resource added, removed
+or changed since the deployed revision.
+
+The chart and its values are given as to 'helm upgrade'. The hooks are not
+compared.
+
+The values of the secrets are printed in the diff, unless '--suppress-secrets'
+is given. The values of the secrets are then replaced by their size, and
+marked when they change.
+With '--mask-secrets', they are replaced by a placeholder with the SHA-256
+digest of their content instead, so that the diff shows the values which
+change and can be shared in a review without leaking them.
+`
+
+type diffUpgradeCmd struct {
+	release         string
+	chart           string
+	out             io.Writer
+	client          helm.Interface
+	version         string
+	devel           bool
+	verify          bool
+	keyring         string
+	valueFiles      valueFiles
+	values          []string
+	stringValues    []string
+	fileValues      []string
+	envValuesFile   string
+	expandEnv       []string
+	resetValues     bool
+	reuseValues     bool
+	reuseStrategy   string
+	suppressSecrets bool
+	maskSecrets     bool
+	context         int
+
+	repoURL  string
+	username string
+	password string
+	certFile string
+	keyFile  string
+	caFile   string
+}
+
+func newDiffUpgradeCmd(client helm.Interface, out io.Writer) *cobra.Command {
+	diff := &diffUpgradeCmd{
+		out:    out,
+		client: client,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "upgrade [RELEASE] [CHART]",
+		Short:   "Show the changes an upgrade would make to a release",
+		Long:    diffUpgradeDesc,
+		PreRunE: func(_ *cobra.Command, _ []string) error { return setupConnection() },
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkArgsLength(len(args), "release name", "chart path"); err != nil {
+				return err
+			}
+
+			if diff.version == "" && diff.devel {
+				debug("setting version to >0.0.0-0")
+				diff.version = ">0.0.0-0"
+			}
+
+			diff.release = args[0]
+			diff.chart = args[1]
+			diff.client = ensureHelmClient(diff.client)
+
+			return diff.run()
+		},
+	}
+
+	f := cmd.Flags()
+	settings.AddFlagsTLS(f)
+	f.VarP(&diff.valueFiles, "values", "f", "Specify values in a YAML file or a URL(can specify multiple)")
+	f.StringArrayVar(&diff.values, "set", []string{}, "Set values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
+	f.StringArrayVar(&diff.stringValues, "set-string", []string{}, "Set STRING values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
+	f.StringArrayVar(&diff.fileValues, "set-file", []string{}, "Set values from respective files specified via the command line (can specify multiple or separate values with commas: key1=path1,key2=path2)")
+	f.StringVar(&diff.envValuesFile, "environment", "", "Use an environment values file inside the chart and the subcharts")
+	f.StringArrayVar(&diff.expandEnv, "expand-env", []string{}, "Expand the environment variables matching a pattern, like CI_*, referenced as ${NAME} or ${NAME:-default} in the values files and the values of the chart. Can be specified multiple times")
+	f.BoolVar(&diff.resetValues, "reset-values", false, "When upgrading, reset the values to the ones built into the chart")
+	f.BoolVar(&diff.reuseValues, "reuse-values", false, "When upgrading, reuse the last release's values and merge in any overrides from the command line via --set and -f. If '--reset-values' is specified, this is ignored.")
+	f.StringVar(&diff.reuseStrategy, "reuse-values-strategy", "", "How '--reuse-values' combines the last release's values with the new values: merge, replace or deep. Defaults to merge")
+	f.BoolVar(&diff.suppressSecrets, "suppress-secrets", false, "Replace the values of the secrets by their size in the diff")
+	f.BoolVar(&diff.maskSecrets, "mask-secrets", false, "Replace the values of the secrets by a placeholder with the SHA-256 digest of their content in the diff")
+	f.IntVar(&diff.context, "context", diffContext, "Number of unchanged lines printed around the changed lines")
+	f.BoolVar(&diff.verify, "verify", false, "Verify the provenance of the chart before upgrading")
+	f.StringVar(&diff.keyring, "keyring", defaultKeyring(), "Path to the keyring that contains public signing keys")
+	f.StringVar(&diff.version, "version", "", "Specify the exact chart version to use. If this is not specified, the latest version is used")
+	f.BoolVar(&diff.devel, "devel", false, "Use development versions, too. Equivalent to version '>0.0.0-0'. If --version is set, this is ignored.")
+	f.StringVar(&diff.repoURL, "repo", "", "Chart repository url where to locate the requested chart")
+	f.StringVar(&diff.username, "username", "", "Chart repository username where to locate the requested chart")
+	f.StringVar(&diff.password, "password", "", "Chart repository password where to locate the requested chart")
+	f.StringVar(&diff.certFile, "cert-file", "", "Identify HTTPS client using this SSL certificate file")
+	f.StringVar(&diff.keyFile, "key-file", "", "Identify HTTPS client using this SSL key file")
+	f.StringVar(&diff.caFile, "ca-file", "", "Verify certificates of HTTPS-enabled servers using this CA bundle")
+
+	// set defaults from environment
+	settings.InitTLS(f)
+
+	return cmd
+}
+
+func (d *diffUpgradeCmd) run() error {
+	if d.reuseStrategy != "" && !d.reuseValues {
+		return errors.New("--reuse-values-strategy requires --reuse-values")
+	}
+
+	chartPath, err := locateChartPath(d.repoURL, d.username, d.password, d.chart, d.version, d.verify, d.keyring, d.certFile, d.keyFile, d.caFile)
+	if err != nil {
+		return err
+	}
+
+	rawVals, err := vals(d.valueFiles, d.values, d.stringValues, d.fileValues, d.expandEnv, d.certFile, d.keyFile, d.caFile)
+	if err != nil {
+		return err
+	}
+
+	// Check chart requirements to make sure all dependencies are present in /charts
+	ch, err := chartutil.LoadWithEnvValuesFile(chartPath, d.envValuesFile)
+	if err != nil {
+		return prettyError(err)
+	}
+	if chartutil.IsLibraryChart(ch) {
+		return fmt.Errorf("library chart %s is not installable", ch.Metadata.Name)
+	}
+	if req, err := chartutil.LoadRequirements(ch); err == nil {
+		if err := renderutil.CheckDependencies(ch, req); err != nil {
+			return err
+		}
+	} else if err != chartutil.ErrRequirementsNotFound {
+		return fmt.Errorf("cannot load requirements: %v", err)
+	}
+	if err := chartutil.ExpandChartEnv(ch, d.expandEnv); err != nil {
+		return err
+	}
+
+	current, err := d.client.ReleaseContent(d.release)
+	if err != nil {
+		return prettyError(err)
+	}
+
+	proposed, err := d.client.UpdateReleaseFromChart(
+		d.release,
+		ch,
+		helm.UpdateValueOverrides(rawVals),
+		helm.UpgradeDryRun(true),
+		helm.ResetValues(d.resetValues),
+		helm.ReuseValues(d.reuseValues),
+		helm.ReuseValuesStrategy(d.reuseStrategy),
+		helm.UpgradeEnvironment(d.envValuesFile))
+	if err != nil {
+		return prettyError(err)
+	}
+
+	namespace := current.Release.Namespace
+	d.writeDiff(manifestResources(current.Release.Manifest, namespace), manifestResources(proposed.Release.Manifest, namespace))
+	return nil
+}
+
+// writeDiff writes the unified diff of every resource added, removed or
+// changed from the current resources to the proposed resources.
+func (d *diffUpgradeCmd) writeDiff(current, proposed map[string]manifestResource) {
+	keys := make([]string, 0, len(current)+len(proposed))
+	for k := range current {
+		keys = append(keys, k)
+	}
+	for k := range proposed {
+		if _, ok := current[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		from, inCurrent := current[k]
+		to, inProposed := proposed[k]
+		if inCurrent && inProposed && from.content == to.content {
+			continue
+		}
+
+		change, res := "changed", to
+		switch {
+		case !inCurrent:
+			change = "been added"
+		case !inProposed:
+			change, res = "been removed", from
+		}
+		a, b := from.content, to.content
+		if d.maskSecrets && res.kind == "Secret" {
+			a, b = maskManifestSecrets(a), maskManifestSecrets(b)
+		} else if d.suppressSecrets && res.kind == "Secret" {
+			a, b = maskSecrets(a, b)
+		}
+		fmt.Fprintf(d.out, "%s (%s) has %s:\n%s\n", k, res.apiVersion, change, unifiedDiff(a, b, d.context))
+	}
+}
+
+// manifestResource is a resource of the manifest of a release.
+type manifestResource struct {
+	apiVersion string
+	kind       string
+	content    string
+}
+
+// manifestResources returns the resources of a manifest by their namespace,
+// name and kind, e.g. "default, web, Deployment". The resources without a
+// namespace are in namespace.
+func manifestResources(manifest, namespace string) map[string]manifestResource {
+	resources := map[string]manifestResource{}
+	for _, doc := range releaseutil.SplitManifests(manifest) {
+		var head struct {
+			APIVersion string `json:"apiVersion"`
+			Kind       string `json:"kind"`
+			Metadata   struct {
+				Name      string `json:"name"`
+				Namespace string `json:"namespace"`
+			} `json:"metadata"`
+		}
+		if err := yaml.Unmarshal([]byte(doc), &head); err != nil || head.Kind == "" {
+			continue
+		}
+		ns := head.Metadata.Namespace
+		if ns == "" {
+			ns = namespace
+		}
+		key := fmt.Sprintf("%s, %s, %s", ns, head.Metadata.Name, head.Kind)
+		resources[key] = manifestResource{
+			apiVersion: head.APIVersion,
+			kind:       head.Kind,
+			content:    trimLines(doc),
+		}
+	}
+	return resources
+}
+
+// maskSecrets replaces the values of the data and stringData of the current
+// and proposed manifests of a secret by their size. The proposed values
+// which differ from the current ones are marked as changed, so that the diff
+// shows the keys added, removed and changed without their values.
+func maskSecrets(current, proposed string) (string, string) {
+	currentValues := map[string]string{}
+	return maskSecret(current, nil, currentValues), maskSecret(proposed, currentValues, nil)
+}
+
+// maskSecret returns the manifest of a secret with the values of its data and
+// stringData replaced by their size, marked as changed if they differ from
+// the previous values. The values are recorded in values if it is not nil.
+// A manifest which cannot be parsed is suppressed entirely.
+func maskSecret(manifest string, previous, values map[string]string) string {
+	if manifest == "" {
+		return ""
+	}
+	var secret map[string]interface{}
+	if err := yaml.Unmarshal([]byte(manifest), &secret); err != nil {
+		return "# the secret cannot be parsed, so its manifest is suppressed\n"
+	}
+	for _, section := range []string{"data", "stringData"} {
+		table, ok := secret[section].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for k, v := range table {
+			value := fmt.Sprint(v)
+			if section == "data" {
+				if decoded, err := base64.StdEncoding.DecodeString(value); err == nil {
+					value = string(decoded)
+				}
+			}
+			path := section + "." + k
+			if values != nil {
+				values[path] = value
+			}
+			mask := fmt.Sprintf("REDACTED # (%d bytes)", len(value))
+			if prev, ok := previous[path]; ok && prev != value {
+				mask = fmt.Sprintf("REDACTED # (%d bytes, changed)", len(value))
+			}
+			table[k] = mask
+		}
+	}
+	b, err := yaml.Marshal(secret)
+	if err != nil {
+		return "# the secret cannot be parsed, so its manifest is suppressed\n"
+	}
+	return string(b)
+}
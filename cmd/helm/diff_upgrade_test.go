@@ -0,0 +1,166 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/proto/hapi/release"
+)
+
+func TestDiffUpgradeCmd(t *testing.T) {
+	chartPath := "testdata/testcharts/alpine"
+	ch, err := chartutil.Load(chartPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		flags    []string
+		expected string
+		contains string
+		err      bool
+	}{
+		{
+			name:     "no changes",
+			expected: "",
+		},
+		{
+			name:  "changed value",
+			flags: []string{"--set", "restartPolicy=Always", "--context", "1"},
+			expected: `default, funny-bunny-my-alpine, Pod (v1) has changed:
+@@ -24,3 +24,3 @@
+   # more conventional syntax: Never
+-  restartPolicy: Never
++  restartPolicy: Always
+   containers:
+
+`,
+		},
+		{
+			name:  "renamed resource",
+			flags: []string{"--set", "Name=my-pine", "--context", "0"},
+			expected: `default, funny-bunny-my-alpine, Pod (v1) has been removed:
+@@ -1,29 +0,0 @@
+-# Source: alpine/templates/alpine-pod.yaml
+`,
+			contains: `default, funny-bunny-my-pine, Pod (v1) has been added:
+@@ -0,0 +1,29 @@
++# Source: alpine/templates/alpine-pod.yaml
+`,
+		},
+		{
+			name:  "reuse values strategy without reuse values",
+			flags: []string{"--reuse-values-strategy", "deep"},
+			err:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rel := helm.ReleaseMock(&helm.MockReleaseOptions{Name: "funny-bunny", Chart: ch, Namespace: "default"})
+			rel.Config = &chart.Config{Raw: "test:\n  Name: gopher\n"}
+			if err := helm.RenderReleaseMock(rel, false); err != nil {
+				t.Fatal(err)
+			}
+			c := &helm.FakeClient{Rels: []*release.Release{rel}, RenderManifests: true}
+
+			var buf bytes.Buffer
+			cmd := newDiffUpgradeCmd(c, &buf)
+			// The fake client renders the upgrade with the supplied values only.
+			flags := append([]string{"--set", "test.Name=gopher"}, tt.flags...)
+			if err := cmd.ParseFlags(flags); err != nil {
+				t.Fatal(err)
+			}
+			err := cmd.RunE(cmd, []string{"funny-bunny", chartPath})
+			if (err != nil) != tt.err {
+				t.Fatalf("expected error %t, got '%v'", tt.err, err)
+			}
+			if tt.err {
+				return
+			}
+			if !strings.HasPrefix(buf.String(), tt.expected) {
+				t.Errorf("expected\n%s\ngot\n%s", tt.expected, buf.String())
+			}
+			if !strings.Contains(buf.String(), tt.contains) {
+				t.Errorf("expected to contain\n%s\ngot\n%s", tt.contains, buf.String())
+			}
+			if tt.expected == "" && buf.Len() > 0 {
+				t.Errorf("expected no changes, got\n%s", buf.String())
+			}
+		})
+	}
+}
+
+func TestMaskSecrets(t *testing.T) {
+	current := `apiVersion: v1
+kind: Secret
+metadata:
+  name: db
+data:
+  password: c2VjcmV0
+  user: YWRtaW4=
+`
+	proposed := `apiVersion: v1
+kind: Secret
+metadata:
+  name: db
+data:
+  password: bmV3LXNlY3JldA==
+  user: YWRtaW4=
+stringData:
+  token: abc
+`
+	a, b := maskSecrets(current, proposed)
+	expectCurrent := `apiVersion: v1
+data:
+  password: 'REDACTED # (6 bytes)'
+  user: 'REDACTED # (5 bytes)'
+kind: Secret
+metadata:
+  name: db
+`
+	expectProposed := `apiVersion: v1
+data:
+  password: 'REDACTED # (10 bytes, changed)'
+  user: 'REDACTED # (5 bytes)'
+kind: Secret
+metadata:
+  name: db
+stringData:
+  token: 'REDACTED # (3 bytes)'
+`
+	if a != expectCurrent {
+		t.Errorf("expected current\n%s\ngot\n%s", expectCurrent, a)
+	}
+	if b != expectProposed {
+		t.Errorf("expected proposed\n%s\ngot\n%s", expectProposed, b)
+	}
+	if strings.Contains(a+b, "c2VjcmV0") || strings.Contains(a+b, "abc") {
+		t.Errorf("expected the values of the secret to be masked, got\n%s\n%s", a, b)
+	}
+
+	if _, b := maskSecrets("", proposed); !strings.Contains(b, "'REDACTED # (10 bytes)'") {
+		t.Errorf("expected the values of an added secret not to be marked as changed, got\n%s", b)
+	}
+}
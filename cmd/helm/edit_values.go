@@ -0,0 +1,168 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+const editValuesHeader = `# Please edit the values of the release %q below. The leading comments
+# are ignored, and an empty file cancels the upgrade. If the values are
+# invalid, this file is reopened with the error.
+#
+`
+
+// editReleaseValues opens the computed values of the release, with the
+// values of the command line rawVals merged in, in the editor of the user,
+// until they are valid for the chart ch. It prints the changes of the values
+// and returns the edited values, or nil if the upgrade is cancelled.
+func (u *upgradeCmd) editReleaseValues(ch *chart.Chart, rawVals []byte) ([]byte, error) {
+	res, err := u.client.ReleaseContent(u.release)
+	if err != nil {
+		return nil, prettyError(err)
+	}
+	current, err := chartutil.CoalesceValues(res.Release.Chart, res.Release.Config)
+	if err != nil {
+		return nil, err
+	}
+	y, err := current.YAML()
+	if err != nil {
+		return nil, err
+	}
+	doc, err := chartutil.ReadValuesDocument([]byte(y))
+	if err != nil {
+		return nil, err
+	}
+	overrides, err := chartutil.ReadValues(rawVals)
+	if err != nil {
+		return nil, err
+	}
+	if err := doc.MergeValues(overrides); err != nil {
+		return nil, err
+	}
+	values, err := doc.YAML()
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := ioutil.TempFile("", "helm-edit-*.yaml")
+	if err != nil {
+		return nil, err
+	}
+	f.Close()
+	defer os.Remove(f.Name())
+
+	header := fmt.Sprintf(editValuesHeader, u.release)
+	var invalid error
+	for {
+		content := header + values
+		if invalid != nil {
+			content = fmt.Sprintf("# Error: %s\n#\n%s", strings.Replace(invalid.Error(), "\n", "\n# ", -1), content)
+		}
+		if err := ioutil.WriteFile(f.Name(), []byte(content), 0600); err != nil {
+			return nil, err
+		}
+		if err := runEditor(f.Name()); err != nil {
+			return nil, err
+		}
+		data, err := ioutil.ReadFile(f.Name())
+		if err != nil {
+			return nil, err
+		}
+		if string(data) == content && invalid != nil {
+			return nil, fmt.Errorf("the values are not changed, and are invalid: %s", invalid)
+		}
+		values = stripLeadingComments(string(data))
+		if strings.TrimSpace(values) == "" {
+			fmt.Fprintln(u.out, "Edit cancelled, the values are empty.")
+			return nil, nil
+		}
+
+		edited, err := chartutil.ReadValues([]byte(values))
+		if err == nil {
+			err = validateEditedValues(ch, values)
+		}
+		if err != nil {
+			invalid = err
+			continue
+		}
+		changes := chartutil.DiffValues(current, edited)
+		if len(changes) == 0 {
+			fmt.Fprintln(u.out, "Edit cancelled, no changes made.")
+			return nil, nil
+		}
+		fmt.Fprintln(u.out, "VALUES CHANGES:")
+		writeValueChanges(u.out, changes)
+		return []byte(values), nil
+	}
+}
+
+// validateEditedValues validates the edited values against the values.cue
+// schemas of the chart ch and of its subcharts.
+func validateEditedValues(ch *chart.Chart, values string) error {
+	vals, err := chartutil.CoalesceValues(ch, &chart.Config{Raw: values})
+	if err != nil {
+		return err
+	}
+	_, err = chartutil.ValidateValuesSchema(ch, vals)
+	return err
+}
+
+// stripLeadingComments returns the YAML document y without its leading
+// comment lines, which hold the instructions and the errors of the editor.
+func stripLeadingComments(y string) string {
+	for strings.HasPrefix(y, "#") {
+		i := strings.IndexByte(y, '\n')
+		if i < 0 {
+			return ""
+		}
+		y = y[i+1:]
+	}
+	return y
+}
+
+// runEditor opens the file name in the editor of the user: $HELM_EDITOR,
+// $EDITOR, or vi.
+func runEditor(name string) error {
+	editor := os.Getenv("HELM_EDITOR")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	args := strings.Fields(editor)
+	if len(args) == 0 {
+		return errors.New("no editor specified")
+	}
+	cmd := exec.Command(args[0], append(args[1:], name)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("the editor %s failed: %s", editor, err)
+	}
+	return nil
+}
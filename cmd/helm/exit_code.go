@@ -0,0 +1,102 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"strings"
+
+	"k8s.io/helm/pkg/storage/lock"
+)
+
+// The exit codes of helm, which tell apart the failures a CI pipeline may
+// want to handle differently. The errors without a more specific exit code,
+// and the plugins' own exit codes, keep exiting with their code.
+const (
+	// exitCodeError is the exit code of the errors without a more specific
+	// exit code.
+	exitCodeError = 1
+	// exitCodeChartNotFound is the exit code of a chart which can't be found
+	// or downloaded.
+	exitCodeChartNotFound = 3
+	// exitCodeRenderError is the exit code of a chart whose templates fail to
+	// parse or render.
+	exitCodeRenderError = 4
+	// exitCodeValidationFailure is the exit code of a chart failing lint, a
+	// policy or the validation of its rendered manifests.
+	exitCodeValidationFailure = 5
+	// exitCodeSchemaViolation is the exit code of values which violate the
+	// values schema of a chart.
+	exitCodeSchemaViolation = 6
+	// exitCodeTimeout is the exit code of a timeout waiting for the resources
+	// of a release to be ready.
+	exitCodeTimeout = 7
+	// exitCodeReleaseLocked is the exit code of a release locked by another
+	// operation.
+	exitCodeReleaseLocked = 8
+)
+
+// exitError is an error which exits helm with its code.
+type exitError struct {
+	error
+	code int
+}
+
+func (e exitError) Unwrap() error {
+	return e.error
+}
+
+// withExitCode returns err, exiting helm with code, or nil if err is nil.
+func withExitCode(code int, err error) error {
+	if err == nil {
+		return nil
+	}
+	return exitError{err, code}
+}
+
+// exitMessages are the messages of the errors reported by Tiller, which only
+// reach the client as text, with their exit codes. The first match wins.
+var exitMessages = []struct {
+	message string
+	code    int
+}{
+	{"is locked by operation", exitCodeReleaseLocked},
+	{"timed out waiting for the condition", exitCodeTimeout},
+	{"values don't meet the specifications of the schema", exitCodeSchemaViolation},
+	{"parse error in", exitCodeRenderError},
+	{"render error in", exitCodeRenderError},
+	{"rendering template failed", exitCodeRenderError},
+}
+
+// exitCode returns the exit code of helm failing with err.
+func exitCode(err error) int {
+	switch e := err.(type) {
+	case pluginError:
+		return e.code
+	case exitError:
+		return e.code
+	}
+	if lock.IsLocked(err) {
+		return exitCodeReleaseLocked
+	}
+	msg := err.Error()
+	for _, m := range exitMessages {
+		if strings.Contains(msg, m.message) {
+			return m.code
+		}
+	}
+	return exitCodeError
+}
@@ -0,0 +1,72 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"k8s.io/helm/pkg/storage/lock"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		expect int
+	}{
+		{"generic", errors.New("release foo not found"), exitCodeError},
+		{"plugin", pluginError{errors.New("plugin failed"), 42}, 42},
+		{"explicit", withExitCode(exitCodeValidationFailure, errors.New("1 chart(s) linted, 1 chart(s) failed")), exitCodeValidationFailure},
+		{"locked", &lock.LockedError{Release: "foo", Operation: "upgrade", Since: time.Now()}, exitCodeReleaseLocked},
+		{"locked by tiller", prettyError(status.Error(codes.Unknown, `release "foo" is locked by operation upgrade started at 2019-10-01T00:00:00Z`)), exitCodeReleaseLocked},
+		{"timeout", prettyError(status.Error(codes.Unknown, "release foo failed: timed out waiting for the condition")), exitCodeTimeout},
+		{"schema", fmt.Errorf("values don't meet the specifications of the schema of chart foo:\n- replicas: Invalid type"), exitCodeSchemaViolation},
+		{"parse error", errors.New(`parse error in "foo/templates/service.yaml": template: foo/templates/service.yaml:3: unexpected "}" in operand`), exitCodeRenderError},
+		{"render error", prettyError(status.Error(codes.Unknown, `render error in "foo/templates/deployment.yaml": template: foo/templates/deployment.yaml:5:12: executing "foo/templates/deployment.yaml" at <.Values.image.tag>: nil pointer evaluating interface {}.tag`)), exitCodeRenderError},
+	}
+	for _, tt := range tests {
+		if code := exitCode(tt.err); code != tt.expect {
+			t.Errorf("%s: expected the exit code %d, got %d", tt.name, tt.expect, code)
+		}
+	}
+}
+
+func TestWithExitCode(t *testing.T) {
+	if err := withExitCode(exitCodeTimeout, nil); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	err := errors.New("timed out")
+	if wrapped := withExitCode(exitCodeTimeout, err); wrapped.Error() != err.Error() || !errors.Is(wrapped, err) {
+		t.Errorf("expected the error to be wrapped, got %v", wrapped)
+	}
+}
+
+func TestLocateChartPathNotFound(t *testing.T) {
+	_, err := locateChartPath("", "", "", "./testdata/testcharts/nonexistent", "", false, "", "", "", "")
+	if err == nil {
+		t.Fatal("expected an error locating a missing chart")
+	}
+	if code := exitCode(err); code != exitCodeChartNotFound {
+		t.Errorf("expected the exit code %d, got %d", exitCodeChartNotFound, code)
+	}
+}
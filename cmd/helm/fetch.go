@@ -17,11 +17,14 @@ limitations under the License.
 package main
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
+	"text/template"
 
 	"github.com/spf13/cobra"
 	"k8s.io/helm/pkg/chartutil"
@@ -38,7 +41,12 @@ also be used to perform cryptographic verification of a chart without installing
 the chart.
 
 There are options for unpacking the chart after download. This will create a
-directory for the chart and uncompress into that directory.
+directory for the chart and uncompress into that directory. The --untardir
+flag is a template of the chart metadata, e.g. '{{ .Name }}-{{ .Version }}'
+unpacks every chart into a directory of its name and version.
+
+The digest of the downloaded archive is verified against the digest of the
+chart in the repository index, if the index has one.
 
 If the --verify flag is specified, the requested chart MUST have a provenance
 file, and MUST pass the verification process. Failure in any part of this will
@@ -97,7 +105,7 @@ func newFetchCmd(out io.Writer) *cobra.Command {
 
 	f := cmd.Flags()
 	f.BoolVar(&fch.untar, "untar", false, "If set to true, will untar the chart after downloading it")
-	f.StringVar(&fch.untardir, "untardir", ".", "If untar is specified, this flag specifies the name of the directory into which the chart is expanded")
+	f.StringVar(&fch.untardir, "untardir", ".", "If untar is specified, this flag specifies the name of the directory into which the chart is expanded. It is a template of the chart metadata, e.g. '{{ .Name }}-{{ .Version }}'")
 	f.BoolVar(&fch.verify, "verify", false, "Verify the package against its signature")
 	f.BoolVar(&fch.verifyLater, "prov", false, "Fetch the provenance file, but don't perform verification")
 	f.StringVar(&fch.version, "version", "", "Specific version of a chart. Without this, the latest version is fetched")
@@ -151,10 +159,11 @@ func (f *fetchCmd) run() error {
 		f.chartRef = chartURL
 	}
 
-	saved, v, err := c.DownloadTo(f.chartRef, f.version, dest)
+	saved, digest, v, err := downloader.FetchChart(&c, f.chartRef, f.version, dest)
 	if err != nil {
 		return err
 	}
+	debug("Fetched %s with digest sha256:%s", filepath.Base(saved), digest)
 
 	if f.verify {
 		fmt.Fprintf(f.out, "Verification: %v\n", v)
@@ -162,7 +171,10 @@ func (f *fetchCmd) run() error {
 
 	// After verification, untar the chart into the requested directory.
 	if f.untar {
-		ud := f.untardir
+		ud, err := untarDir(f.untardir, saved)
+		if err != nil {
+			return fmt.Errorf("Failed to untar: %s", err)
+		}
 		if !filepath.IsAbs(ud) {
 			ud = filepath.Join(f.destdir, ud)
 		}
@@ -180,6 +192,27 @@ func (f *fetchCmd) run() error {
 	return nil
 }
 
+// untarDir expands the template of the directory into which a chart is
+// expanded with the metadata of the chart.
+func untarDir(dir, archive string) (string, error) {
+	if !strings.Contains(dir, "{{") {
+		return dir, nil
+	}
+	ch, err := chartutil.LoadFile(archive)
+	if err != nil {
+		return "", err
+	}
+	t, err := template.New("untardir").Parse(dir)
+	if err != nil {
+		return "", err
+	}
+	var b bytes.Buffer
+	if err := t.Execute(&b, ch.Metadata); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
 // defaultKeyring returns the expanded path to the default keyring.
 func defaultKeyring() string {
 	return os.ExpandEnv("$HOME/.gnupg/pubring.gpg")
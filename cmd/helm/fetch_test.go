@@ -98,6 +98,20 @@ func TestFetchCmd(t *testing.T) {
 			expectFile: "./signtest",
 			expectDir:  true,
 		},
+		{
+			name:       "Fetch and untar into a templated directory",
+			chart:      "test/signtest",
+			flags:      []string{"--untar", "--untardir", "{{ .Name }}-{{ .Version }}"},
+			expectFile: "./signtest-0.1.0/signtest",
+			expectDir:  true,
+		},
+		{
+			name:       "Fail untar into an invalid templated directory",
+			chart:      "test/signtest",
+			flags:      []string{"--untar", "--untardir", "{{ .NoSuchField }}"},
+			failExpect: "Failed to untar",
+			fail:       true,
+		},
 		{
 			name:         "Fetch, verify, untar",
 			chart:        "test/signtest",
@@ -31,13 +31,18 @@ This command fetches the generated manifest for a given release.
 A manifest is a YAML-encoded representation of the Kubernetes resources that
 were generated from this release's chart(s). If a chart is dependent on other
 charts, those resources will also be included in the manifest.
+
+To share the manifest without leaking secrets, '--mask-secrets' replaces the
+values of the data and stringData of the secrets by a placeholder with the
+SHA-256 digest of their content.
 `
 
 type getManifestCmd struct {
-	release string
-	out     io.Writer
-	client  helm.Interface
-	version int32
+	release     string
+	out         io.Writer
+	client      helm.Interface
+	version     int32
+	maskSecrets bool
 }
 
 func newGetManifestCmd(client helm.Interface, out io.Writer) *cobra.Command {
@@ -63,6 +68,7 @@ func newGetManifestCmd(client helm.Interface, out io.Writer) *cobra.Command {
 	f := cmd.Flags()
 	settings.AddFlagsTLS(f)
 	f.Int32Var(&get.version, "revision", 0, "Get the named release with revision")
+	f.BoolVar(&get.maskSecrets, "mask-secrets", false, "Replace the values of the data and stringData of the secrets by a placeholder with the SHA-256 digest of their content")
 
 	// set defaults from environment
 	settings.InitTLS(f)
@@ -76,6 +82,10 @@ func (g *getManifestCmd) run() error {
 	if err != nil {
 		return prettyError(err)
 	}
-	fmt.Fprintln(g.out, res.Release.Manifest)
+	manifest := res.Release.Manifest
+	if g.maskSecrets {
+		manifest = maskManifestSecrets(manifest)
+	}
+	fmt.Fprintln(g.out, manifest)
 	return nil
 }
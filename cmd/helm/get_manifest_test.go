@@ -27,6 +27,9 @@ import (
 )
 
 func TestGetManifest(t *testing.T) {
+	secret := helm.ReleaseMock(&helm.MockReleaseOptions{Name: "vesta"})
+	secret.Manifest = "apiVersion: v1\nkind: Secret\nmetadata:\n  name: db\ndata:\n  password: c2VjcmV0\n"
+
 	tests := []releaseCase{
 		{
 			name:     "get manifest with release",
@@ -35,6 +38,14 @@ func TestGetManifest(t *testing.T) {
 			resp:     helm.ReleaseMock(&helm.MockReleaseOptions{Name: "juno"}),
 			rels:     []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "juno"})},
 		},
+		{
+			name:     "get manifest with masked secrets",
+			args:     []string{"vesta"},
+			flags:    []string{"--mask-secrets"},
+			expected: "data:\n  password: REDACTED sha256:2bb80d537b1da3e3\n",
+			resp:     secret,
+			rels:     []*release.Release{secret},
+		},
 		{
 			name: "get manifest without args",
 			args: []string{},
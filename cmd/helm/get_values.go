@@ -18,6 +18,7 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 
@@ -29,6 +30,13 @@ import (
 
 var getValuesHelp = `
 This command downloads a values file for a given release.
+
+To show the differences of the values between two revisions of the release,
+specify '--revision' twice. The user-supplied values are compared, and the
+computed values too with '--all'. Every added, removed or changed value is
+printed with its path, as with '--set', or as JSON with '--output json':
+
+	$ helm get values my-release --revision 2 --revision 3 --all
 `
 
 type getValuesCmd struct {
@@ -36,7 +44,7 @@ type getValuesCmd struct {
 	allValues bool
 	out       io.Writer
 	client    helm.Interface
-	version   int32
+	versions  []int
 	output    string
 }
 
@@ -62,7 +70,7 @@ func newGetValuesCmd(client helm.Interface, out io.Writer) *cobra.Command {
 
 	f := cmd.Flags()
 	settings.AddFlagsTLS(f)
-	f.Int32Var(&get.version, "revision", 0, "Get the named release with revision")
+	f.IntSliceVar(&get.versions, "revision", []int{}, "Get the named release with revision. Specify it twice to show the differences of the values between two revisions")
 	f.BoolVarP(&get.allValues, "all", "a", false, "Dump all (computed) values")
 	f.StringVar(&get.output, "output", "yaml", "Output the specified format (json or yaml)")
 
@@ -74,22 +82,28 @@ func newGetValuesCmd(client helm.Interface, out io.Writer) *cobra.Command {
 
 // getValues implements 'helm get values'
 func (g *getValuesCmd) run() error {
-	res, err := g.client.ReleaseContent(g.release, helm.ContentReleaseVersion(g.version))
-	if err != nil {
-		return prettyError(err)
+	switch len(g.versions) {
+	case 0:
+		return g.show(0)
+	case 1:
+		return g.show(int32(g.versions[0]))
+	case 2:
+		return g.diff(int32(g.versions[0]), int32(g.versions[1]))
+	default:
+		return errors.New("--revision can be specified at most twice")
 	}
+}
 
-	values, err := chartutil.ReadValues([]byte(res.Release.Config.Raw))
+// show prints the values of the revision version of the release.
+func (g *getValuesCmd) show(version int32) error {
+	values, computed, err := g.values(version)
 	if err != nil {
 		return err
 	}
 
-	// If the user wants all values, compute the values and return.
+	// If the user wants all values, return the computed values.
 	if g.allValues {
-		values, err = chartutil.CoalesceValues(res.Release.Chart, res.Release.Config)
-		if err != nil {
-			return err
-		}
+		values = computed
 	}
 
 	result, err := formatValues(g.output, values)
@@ -100,6 +114,102 @@ func (g *getValuesCmd) run() error {
 	return nil
 }
 
+// values returns the user-supplied values of the revision version of the
+// release, and the computed values if --all is set.
+func (g *getValuesCmd) values(version int32) (values, computed chartutil.Values, err error) {
+	res, err := g.client.ReleaseContent(g.release, helm.ContentReleaseVersion(version))
+	if err != nil {
+		return nil, nil, prettyError(err)
+	}
+
+	values, err = chartutil.ReadValues([]byte(res.Release.Config.Raw))
+	if err != nil {
+		return nil, nil, err
+	}
+	if g.allValues {
+		computed, err = chartutil.CoalesceValues(res.Release.Chart, res.Release.Config)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return values, computed, nil
+}
+
+// valuesDiff is the difference of the values between two revisions.
+type valuesDiff struct {
+	From         int32                   `json:"from"`
+	To           int32                   `json:"to"`
+	UserSupplied []chartutil.ValueChange `json:"userSupplied"`
+	Computed     []chartutil.ValueChange `json:"computed,omitempty"`
+}
+
+// diff prints the differences of the values between the revisions from and
+// to of the release.
+func (g *getValuesCmd) diff(from, to int32) error {
+	fromValues, fromComputed, err := g.values(from)
+	if err != nil {
+		return err
+	}
+	toValues, toComputed, err := g.values(to)
+	if err != nil {
+		return err
+	}
+
+	d := valuesDiff{
+		From:         from,
+		To:           to,
+		UserSupplied: chartutil.DiffValues(fromValues, toValues),
+	}
+	if g.allValues {
+		d.Computed = chartutil.DiffValues(fromComputed, toComputed)
+	}
+
+	switch g.output {
+	case "", "yaml":
+		fmt.Fprintf(g.out, "REVISION %d -> %d\n", from, to)
+		fmt.Fprintln(g.out, "USER-SUPPLIED VALUES:")
+		writeValueChanges(g.out, d.UserSupplied)
+		if g.allValues {
+			fmt.Fprintln(g.out, "COMPUTED VALUES:")
+			writeValueChanges(g.out, d.Computed)
+		}
+		return nil
+	case "json":
+		return encodeJSON(g.out, d)
+	default:
+		return fmt.Errorf("Unknown output format %q", g.output)
+	}
+}
+
+// writeValueChanges writes one line per change: the added values prefixed
+// with '+', the removed ones with '-' and the changed ones with '~'.
+func writeValueChanges(out io.Writer, changes []chartutil.ValueChange) {
+	if len(changes) == 0 {
+		fmt.Fprintln(out, "  (no changes)")
+		return
+	}
+	for _, c := range changes {
+		switch c.Kind {
+		case chartutil.ValueAdded:
+			fmt.Fprintf(out, "+ %s: %s\n", c.Path, formatValue(c.New))
+		case chartutil.ValueRemoved:
+			fmt.Fprintf(out, "- %s: %s\n", c.Path, formatValue(c.Old))
+		default:
+			fmt.Fprintf(out, "~ %s: %s -> %s\n", c.Path, formatValue(c.Old), formatValue(c.New))
+		}
+	}
+}
+
+// formatValue formats the leaf v of a values tree as JSON, so that strings
+// are quoted.
+func formatValue(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
 func formatValues(format string, values chartutil.Values) (string, error) {
 	switch format {
 	case "", "yaml":
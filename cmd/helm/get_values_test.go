@@ -36,6 +36,15 @@ func TestGetValuesCmd(t *testing.T) {
 		},
 		Config: &chart.Config{Raw: `foo: "bar"`},
 	})
+	releaseWithValuesV2 := helm.ReleaseMock(&helm.MockReleaseOptions{
+		Name:    "thomas-guide",
+		Version: 2,
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{Name: "thomas-guide-chart-name"},
+			Values:   &chart.Config{Raw: `foo2: "bar2"`},
+		},
+		Config: &chart.Config{Raw: `{foo: "baz", replicas: 2}`},
+	})
 
 	tests := []releaseCase{
 		{
@@ -61,6 +70,38 @@ func TestGetValuesCmd(t *testing.T) {
 			expected: "{\"foo\":\"bar\",\"foo2\":\"bar2\"}",
 			rels:     []*release.Release{releaseWithValues},
 		},
+		{
+			name:     "get values diff between revisions",
+			resp:     releaseWithValues,
+			args:     []string{"thomas-guide"},
+			flags:    []string{"--revision", "1", "--revision", "2"},
+			expected: "REVISION 1 -> 2\nUSER-SUPPLIED VALUES:\n~ foo: \"bar\" -> \"baz\"\n\\+ replicas: 2\n$",
+			rels:     []*release.Release{releaseWithValues, releaseWithValuesV2},
+		},
+		{
+			name:     "get all values diff between revisions",
+			resp:     releaseWithValues,
+			args:     []string{"thomas-guide"},
+			flags:    []string{"--revision", "2", "--revision", "1", "--all"},
+			expected: "USER-SUPPLIED VALUES:\n~ foo: \"baz\" -> \"bar\"\n- replicas: 2\nCOMPUTED VALUES:\n~ foo: \"baz\" -> \"bar\"\n- replicas: 2\n$",
+			rels:     []*release.Release{releaseWithValues, releaseWithValuesV2},
+		},
+		{
+			name:     "get values diff between revisions with json format",
+			resp:     releaseWithValues,
+			args:     []string{"thomas-guide"},
+			flags:    []string{"--revision", "1", "--revision", "1", "--output", "json"},
+			expected: `{"from":1,"to":1,"userSupplied":\[\]}`,
+			rels:     []*release.Release{releaseWithValues, releaseWithValuesV2},
+		},
+		{
+			name:  "get values with three revisions",
+			resp:  releaseWithValues,
+			args:  []string{"thomas-guide"},
+			flags: []string{"--revision", "1", "--revision", "2", "--revision", "3"},
+			rels:  []*release.Release{releaseWithValues, releaseWithValuesV2},
+			err:   true,
+		},
 		{
 			name: "get values requires release name arg",
 			err:  true,
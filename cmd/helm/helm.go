@@ -26,6 +26,7 @@ import (
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc/grpclog"
 	"google.golang.org/grpc/status"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 
@@ -113,15 +114,80 @@ __helm_list_plugins()
     fi
 }
 
+__helm_list_charts()
+{
+    __helm_debug "${FUNCNAME[0]}: c is $c words[c] is ${words[c]}"
+    local out
+    # Complete the local charts too
+    COMPREPLY=( $( compgen -f -- "$cur" ) )
+    if out=$(eval $(__helm_binary_name) completion __list charts $(__helm_override_flags) 2>/dev/null); then
+        COMPREPLY+=( $( compgen -W "${out[*]}" -- "$cur" ) )
+    fi
+}
+
+# __helm_chart_arg prints the chart argument of the command line: the last
+# argument that is a chart of a repository, an archive or a local path.
+__helm_chart_arg()
+{
+    local w chart
+    for w in "${words[@]:1:$((c-1))}"; do
+        case "${w}" in
+            -*)
+                ;;
+            */* | *.tgz)
+                chart="${w}"
+                ;;
+            *)
+                if [ -e "${w}" ]; then
+                    chart="${w}"
+                fi
+                ;;
+        esac
+    done
+    echo "${chart}"
+}
+
+__helm_list_chart_versions()
+{
+    __helm_debug "${FUNCNAME[0]}: c is $c words[c] is ${words[c]}"
+    local out chart
+    chart=$(__helm_chart_arg)
+    if [ -n "${chart}" ] && out=$(eval $(__helm_binary_name) completion __list versions "${chart}" $(__helm_override_flags) 2>/dev/null); then
+        COMPREPLY=( $( compgen -W "${out[*]}" -- "$cur" ) )
+    fi
+}
+
+__helm_list_environments()
+{
+    __helm_debug "${FUNCNAME[0]}: c is $c words[c] is ${words[c]}"
+    local out chart
+    chart=$(__helm_chart_arg)
+    if [ -n "${chart}" ] && out=$(eval $(__helm_binary_name) completion __list environments "${chart}" $(__helm_override_flags) 2>/dev/null); then
+        COMPREPLY=( $( compgen -W "${out[*]}" -- "$cur" ) )
+    fi
+}
+
 __helm_custom_func()
 {
     __helm_debug "${FUNCNAME[0]}: c is $c words[@] is ${words[@]}"
     case ${last_command} in
         helm_delete | helm_history | helm_status | helm_test |\
-        helm_upgrade | helm_rollback | helm_get_*)
+        helm_rollback | helm_get_*)
             __helm_list_releases
             return
             ;;
+        helm_upgrade | helm_diff_upgrade)
+            if [[ ${#nouns[@]} -eq 0 ]]; then
+                __helm_list_releases
+            else
+                __helm_list_charts
+            fi
+            return
+            ;;
+        helm_install | helm_template | helm_fetch | helm_inspect | helm_inspect_*)
+            __helm_list_charts
+            return
+            ;;
         helm_repo_remove | helm_repo_update)
             __helm_list_repos
             return
@@ -162,6 +228,7 @@ Environment:
 
 - $HELM_HOME:           Set an alternative location for Helm files. By default, these are stored in ~/.helm
 - $HELM_HOST:           Set an alternative Tiller host. The format is host:port
+- $HELM_LOG_FORMAT:     Set the format of the log messages written to stderr, text or json (default "text")
 - $HELM_NO_PLUGINS:     Disable plugins. Set HELM_NO_PLUGINS=1 to disable plugins.
 - $TILLER_NAMESPACE:    Set an alternative Tiller namespace (default "kube-system")
 - $KUBECONFIG:          Set an alternative Kubernetes configuration file (default "~/.kube/config")
@@ -173,6 +240,16 @@ Environment:
 - $HELM_TLS_HOSTNAME:   The hostname or IP address used to verify the Tiller server certificate (default "127.0.0.1")
 - $HELM_KEY_PASSPHRASE: Set HELM_KEY_PASSPHRASE to the passphrase of your PGP private key. If set, you will not be prompted for the passphrase while signing helm charts
 
+Exit codes:
+
+- 1: The command failed
+- 3: The chart could not be found or downloaded
+- 4: The templates of the chart failed to parse or render
+- 5: The chart failed lint, a policy or the validation of its manifests
+- 6: The values violate the values schema of the chart
+- 7: Timed out waiting for the resources of the release to be ready
+- 8: The release is locked by another operation
+
 `
 
 func newRootCmd(args []string) *cobra.Command {
@@ -181,7 +258,7 @@ func newRootCmd(args []string) *cobra.Command {
 		Short:        "The Helm package manager for Kubernetes.",
 		Long:         globalUsage,
 		SilenceUsage: true,
-		PersistentPreRun: func(*cobra.Command, []string) {
+		PersistentPreRunE: func(*cobra.Command, []string) error {
 			if settings.TLSCaCertFile == helm_env.DefaultTLSCaCert || settings.TLSCaCertFile == "" {
 				settings.TLSCaCertFile = settings.Home.TLSCaCert()
 			} else {
@@ -197,6 +274,7 @@ func newRootCmd(args []string) *cobra.Command {
 			} else {
 				settings.TLSKeyFile = os.ExpandEnv(settings.TLSKeyFile)
 			}
+			return initLogging()
 		},
 		PersistentPostRun: func(*cobra.Command, []string) {
 			teardown()
@@ -211,8 +289,10 @@ func newRootCmd(args []string) *cobra.Command {
 
 	cmd.AddCommand(
 		// chart commands
+		newConvertCmd(out),
 		newCreateCmd(out),
 		newDependencyCmd(out),
+		newDevCmd(out),
 		newFetchCmd(out),
 		newInspectCmd(out),
 		newLintCmd(out),
@@ -220,14 +300,22 @@ func newRootCmd(args []string) *cobra.Command {
 		newRepoCmd(out),
 		newSearchCmd(out),
 		newServeCmd(out),
+		newUnittestCmd(out),
 		newVerifyCmd(out),
 
 		// release commands
+		newApplyCmd(nil, out),
+		newAuditCmd(nil, out),
 		newDeleteCmd(nil, out),
+		newDiffCmd(nil, out),
 		newGetCmd(nil, out),
 		newHistoryCmd(nil, out),
+		newHistoryExportCmd(nil, out),
+		newHistoryImportCmd(nil, out),
+		newHistoryPruneCmd(nil, out),
 		newInstallCmd(nil, out),
 		newListCmd(nil, out),
+		newReleasesCmd(nil, out),
 		newRollbackCmd(nil, out),
 		newStatusCmd(nil, out),
 		newUpgradeCmd(nil, out),
@@ -254,6 +342,9 @@ func newRootCmd(args []string) *cobra.Command {
 	// set defaults from environment
 	settings.Init(flags)
 
+	// Complete the versions and the environments of the charts
+	setChartFlagCompletions(cmd)
+
 	// Find and add plugins
 	loadPlugins(cmd, out)
 
@@ -268,12 +359,7 @@ func init() {
 func main() {
 	cmd := newRootCmd(os.Args[1:])
 	if err := cmd.Execute(); err != nil {
-		switch e := err.(type) {
-		case pluginError:
-			os.Exit(e.code)
-		default:
-			os.Exit(1)
-		}
+		os.Exit(exitCode(err))
 	}
 }
 
@@ -289,7 +375,7 @@ func setupConnection() error {
 			return err
 		}
 
-		tillerTunnel, err = portforwarder.New(settings.TillerNamespace, client, config)
+		tillerTunnel, err = portforwarder.NewWithTransport(settings.TillerNamespace, client, config, settings.TillerTunnel, debugWriter{})
 		if err != nil {
 			return err
 		}
@@ -305,10 +391,21 @@ func setupConnection() error {
 	return nil
 }
 
+// debugWriter writes the lines written to it as debug messages.
+type debugWriter struct{}
+
+func (debugWriter) Write(p []byte) (int, error) {
+	debug("%s", strings.TrimSuffix(string(p), "\n"))
+	return len(p), nil
+}
+
 func teardown() {
 	if tillerTunnel != nil {
 		tillerTunnel.Close()
 	}
+	if localTiller != nil {
+		localTiller.Stop()
+	}
 }
 
 func checkArgsLength(argsReceived int, requiredArgs ...string) error {
@@ -346,6 +443,15 @@ func configForContext(context string, kubeconfig string) (*rest.Config, error) {
 	return config, nil
 }
 
+// kubeClientGetter returns the REST client getter of the kubeconfig context
+// selected by the global flags.
+func kubeClientGetter() genericclioptions.RESTClientGetter {
+	flags := genericclioptions.NewConfigFlags(true)
+	flags.Context = &settings.KubeContext
+	flags.KubeConfig = &settings.KubeConfig
+	return flags
+}
+
 // getKubeClient creates a Kubernetes config and client for a given kubeconfig context.
 func getKubeClient(context string, kubeconfig string) (*rest.Config, kubernetes.Interface, error) {
 	config, err := configForContext(context, kubeconfig)
@@ -368,7 +474,16 @@ func ensureHelmClient(h helm.Interface) helm.Interface {
 }
 
 func newClient() helm.Interface {
-	options := []helm.Option{helm.Host(settings.TillerHost), helm.ConnectTimeout(settings.TillerConnectionTimeout)}
+	options := []helm.Option{
+		helm.Host(settings.TillerHost),
+		helm.ConnectTimeout(settings.TillerConnectionTimeout),
+		helm.CallTimeout(settings.TillerCallTimeout),
+		helm.Retries(settings.TillerRetries),
+		helm.KeepaliveTime(settings.TillerKeepalive),
+	}
+	if localTillerListener != nil {
+		options = append(options, helm.Dialer(dialLocalTiller))
+	}
 
 	if settings.TLSVerify || settings.TLSEnable {
 		debug("Host=%q, Key=%q, Cert=%q, CA=%q\n", settings.TLSServerName, settings.TLSKeyFile, settings.TLSCertFile, settings.TLSCaCertFile)
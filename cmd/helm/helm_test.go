@@ -255,9 +255,13 @@ func TestTLSFlags(t *testing.T) {
 			settings: environment.EnvSettings{
 				TillerHost:              "",
 				TillerConnectionTimeout: 300,
+				TillerRetries:           3,
+				TillerKeepalive:         30,
+				TillerTunnel:            "auto",
 				TillerNamespace:         "kube-system",
 				Home:                    home,
 				Debug:                   false,
+				LogFormat:               "text",
 				KubeContext:             "",
 				KubeConfig:              "",
 				TLSEnable:               false,
@@ -274,9 +278,13 @@ func TestTLSFlags(t *testing.T) {
 			settings: environment.EnvSettings{
 				TillerHost:              "",
 				TillerConnectionTimeout: 300,
+				TillerRetries:           3,
+				TillerKeepalive:         30,
+				TillerTunnel:            "auto",
 				TillerNamespace:         "kube-system",
 				Home:                    home,
 				Debug:                   false,
+				LogFormat:               "text",
 				KubeContext:             "",
 				KubeConfig:              "",
 				TLSEnable:               true,
@@ -293,9 +301,13 @@ func TestTLSFlags(t *testing.T) {
 			settings: environment.EnvSettings{
 				TillerHost:              "",
 				TillerConnectionTimeout: 300,
+				TillerRetries:           3,
+				TillerKeepalive:         30,
+				TillerTunnel:            "auto",
 				TillerNamespace:         "kube-system",
 				Home:                    home,
 				Debug:                   false,
+				LogFormat:               "text",
 				KubeContext:             "",
 				KubeConfig:              "",
 				TLSEnable:               false,
@@ -312,9 +324,13 @@ func TestTLSFlags(t *testing.T) {
 			settings: environment.EnvSettings{
 				TillerHost:              "",
 				TillerConnectionTimeout: 300,
+				TillerRetries:           3,
+				TillerKeepalive:         30,
+				TillerTunnel:            "auto",
 				TillerNamespace:         "kube-system",
 				Home:                    home,
 				Debug:                   false,
+				LogFormat:               "text",
 				KubeContext:             "",
 				KubeConfig:              "",
 				TLSEnable:               false,
@@ -331,9 +347,13 @@ func TestTLSFlags(t *testing.T) {
 			settings: environment.EnvSettings{
 				TillerHost:              "",
 				TillerConnectionTimeout: 300,
+				TillerRetries:           3,
+				TillerKeepalive:         30,
+				TillerTunnel:            "auto",
 				TillerNamespace:         "kube-system",
 				Home:                    home,
 				Debug:                   false,
+				LogFormat:               "text",
 				KubeContext:             "",
 				KubeConfig:              "",
 				TLSEnable:               false,
@@ -350,9 +370,13 @@ func TestTLSFlags(t *testing.T) {
 			settings: environment.EnvSettings{
 				TillerHost:              "",
 				TillerConnectionTimeout: 300,
+				TillerRetries:           3,
+				TillerKeepalive:         30,
+				TillerTunnel:            "auto",
 				TillerNamespace:         "kube-system",
 				Home:                    home,
 				Debug:                   false,
+				LogFormat:               "text",
 				KubeContext:             "",
 				KubeConfig:              "",
 				TLSEnable:               false,
@@ -369,9 +393,13 @@ func TestTLSFlags(t *testing.T) {
 			settings: environment.EnvSettings{
 				TillerHost:              "",
 				TillerConnectionTimeout: 300,
+				TillerRetries:           3,
+				TillerKeepalive:         30,
+				TillerTunnel:            "auto",
 				TillerNamespace:         "kube-system",
 				Home:                    home,
 				Debug:                   false,
+				LogFormat:               "text",
 				KubeContext:             "",
 				KubeConfig:              "",
 				TLSEnable:               false,
@@ -389,9 +417,13 @@ func TestTLSFlags(t *testing.T) {
 			settings: environment.EnvSettings{
 				TillerHost:              "",
 				TillerConnectionTimeout: 300,
+				TillerRetries:           3,
+				TillerKeepalive:         30,
+				TillerTunnel:            "auto",
 				TillerNamespace:         "kube-system",
 				Home:                    home,
 				Debug:                   false,
+				LogFormat:               "text",
 				KubeContext:             "",
 				KubeConfig:              "",
 				TLSEnable:               true,
@@ -409,9 +441,13 @@ func TestTLSFlags(t *testing.T) {
 			settings: environment.EnvSettings{
 				TillerHost:              "",
 				TillerConnectionTimeout: 300,
+				TillerRetries:           3,
+				TillerKeepalive:         30,
+				TillerTunnel:            "auto",
 				TillerNamespace:         "kube-system",
 				Home:                    home,
 				Debug:                   false,
+				LogFormat:               "text",
 				KubeContext:             "",
 				KubeConfig:              "",
 				TLSEnable:               false,
@@ -429,9 +465,13 @@ func TestTLSFlags(t *testing.T) {
 			settings: environment.EnvSettings{
 				TillerHost:              "",
 				TillerConnectionTimeout: 300,
+				TillerRetries:           3,
+				TillerKeepalive:         30,
+				TillerTunnel:            "auto",
 				TillerNamespace:         "kube-system",
 				Home:                    home,
 				Debug:                   false,
+				LogFormat:               "text",
 				KubeContext:             "",
 				KubeConfig:              "",
 				TLSEnable:               false,
@@ -449,9 +489,13 @@ func TestTLSFlags(t *testing.T) {
 			settings: environment.EnvSettings{
 				TillerHost:              "",
 				TillerConnectionTimeout: 300,
+				TillerRetries:           3,
+				TillerKeepalive:         30,
+				TillerTunnel:            "auto",
 				TillerNamespace:         "kube-system",
 				Home:                    home,
 				Debug:                   false,
+				LogFormat:               "text",
 				KubeContext:             "",
 				KubeConfig:              "",
 				TLSEnable:               false,
@@ -469,9 +513,13 @@ func TestTLSFlags(t *testing.T) {
 			settings: environment.EnvSettings{
 				TillerHost:              "",
 				TillerConnectionTimeout: 300,
+				TillerRetries:           3,
+				TillerKeepalive:         30,
+				TillerTunnel:            "auto",
 				TillerNamespace:         "kube-system",
 				Home:                    home,
 				Debug:                   false,
+				LogFormat:               "text",
 				KubeContext:             "",
 				KubeConfig:              "",
 				TLSEnable:               false,
@@ -489,9 +537,13 @@ func TestTLSFlags(t *testing.T) {
 			settings: environment.EnvSettings{
 				TillerHost:              "",
 				TillerConnectionTimeout: 300,
+				TillerRetries:           3,
+				TillerKeepalive:         30,
+				TillerTunnel:            "auto",
 				TillerNamespace:         "kube-system",
 				Home:                    home,
 				Debug:                   false,
+				LogFormat:               "text",
 				KubeContext:             "",
 				KubeConfig:              "",
 				TLSEnable:               false,
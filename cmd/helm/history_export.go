@@ -0,0 +1,114 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/releaseutil"
+)
+
+const historyExportHelp = `
+This command exports every revision of a release to a gzipped tarball.
+
+Each revision is stored with its manifest, values and hooks, so the archive
+can be imported into another Tiller with 'helm import-history' without losing
+the ability to roll back.
+
+    $ helm export-history angry-bird --file angry-bird.tgz
+`
+
+type historyExportCmd struct {
+	release string
+	file    string
+	out     io.Writer
+	client  helm.Interface
+}
+
+func newHistoryExportCmd(client helm.Interface, out io.Writer) *cobra.Command {
+	e := &historyExportCmd{
+		out:    out,
+		client: client,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "export-history [flags] RELEASE_NAME",
+		Short:   "Export the revision history of a release to a tarball",
+		Long:    historyExportHelp,
+		PreRunE: func(_ *cobra.Command, _ []string) error { return setupConnection() },
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return errReleaseRequired
+			}
+			e.release = args[0]
+			e.client = ensureHelmClient(e.client)
+			return e.run()
+		},
+	}
+
+	f := cmd.Flags()
+	settings.AddFlagsTLS(f)
+	f.StringVar(&e.file, "file", "", "Path of the archive to write. Defaults to RELEASE_NAME-history.tgz")
+
+	// set defaults from environment
+	settings.InitTLS(f)
+
+	return cmd
+}
+
+func (e *historyExportCmd) run() error {
+	r, err := e.client.ReleaseHistory(e.release, helm.WithMaxHistory(math.MaxInt32))
+	if err != nil {
+		return prettyError(err)
+	}
+	if len(r.Releases) == 0 {
+		return fmt.Errorf("release %q has no revisions", e.release)
+	}
+
+	if e.file == "" {
+		e.file = e.release + "-history.tgz"
+	}
+	// The archive is written to a temporary file renamed into place, so that
+	// a failed export does not leave a truncated archive behind.
+	f, err := ioutil.TempFile(filepath.Dir(e.file), "."+filepath.Base(e.file)+"-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if err := releaseutil.WriteHistoryArchive(f, r.Releases); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(f.Name(), e.file); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(e.out, "Exported %d revision(s) of %s to %s\n", len(r.Releases), e.release, e.file)
+	return nil
+}
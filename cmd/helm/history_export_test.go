@@ -0,0 +1,84 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"k8s.io/helm/pkg/helm"
+	rpb "k8s.io/helm/pkg/proto/hapi/release"
+)
+
+func TestHistoryExportImport(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "helm-history-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	archive := filepath.Join(tmp, "angry-bird.tgz")
+
+	src := &helm.FakeClient{Rels: []*rpb.Release{
+		helm.ReleaseMock(&helm.MockReleaseOptions{Name: "angry-bird", Version: 2}),
+		helm.ReleaseMock(&helm.MockReleaseOptions{Name: "angry-bird", Version: 1, StatusCode: rpb.Status_SUPERSEDED}),
+	}}
+
+	var buf bytes.Buffer
+	exp := newHistoryExportCmd(src, &buf)
+	exp.ParseFlags([]string{"--file", archive})
+	if err := exp.RunE(exp, []string{"angry-bird"}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "Exported 2 revision(s) of angry-bird") {
+		t.Errorf("unexpected output: %q", buf.String())
+	}
+
+	dst := &helm.FakeClient{}
+	buf.Reset()
+	imp := newHistoryImportCmd(dst, &buf)
+	imp.ParseFlags([]string{"--namespace", "migrated"})
+	if err := imp.RunE(imp, []string{archive}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "Imported 2 revision(s) of angry-bird into namespace migrated") {
+		t.Errorf("unexpected output: %q", buf.String())
+	}
+	if len(dst.Rels) != 2 {
+		t.Fatalf("expected 2 imported revisions, got %d", len(dst.Rels))
+	}
+
+	// importing the same history twice must fail
+	imp = newHistoryImportCmd(dst, &buf)
+	if err := imp.RunE(imp, []string{archive}); err == nil {
+		t.Error("expected error importing an existing release")
+	}
+}
+
+func TestHistoryExport_NoRelease(t *testing.T) {
+	var buf bytes.Buffer
+	cmd := newHistoryExportCmd(&helm.FakeClient{}, &buf)
+	if err := cmd.RunE(cmd, []string{"missing"}); err == nil {
+		t.Error("expected error exporting a release without revisions")
+	}
+	if err := cmd.RunE(cmd, nil); err != errReleaseRequired {
+		t.Errorf("expected %v, got %v", errReleaseRequired, err)
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/releaseutil"
+)
+
+const historyImportHelp = `
+This command imports a release history archive created by 'helm export-history'.
+
+Only the release records are stored by Tiller; the resources of the release
+are not created. To move a release between clusters, import its history and
+then run 'helm rollback' to the latest revision in the target cluster.
+
+    $ helm import-history angry-bird.tgz --namespace migrated
+`
+
+type historyImportCmd struct {
+	file      string
+	namespace string
+	name      string
+	out       io.Writer
+	client    helm.Interface
+}
+
+func newHistoryImportCmd(client helm.Interface, out io.Writer) *cobra.Command {
+	i := &historyImportCmd{
+		out:    out,
+		client: client,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "import-history [flags] FILE",
+		Short:   "Import a release history archive",
+		Long:    historyImportHelp,
+		PreRunE: func(_ *cobra.Command, _ []string) error { return setupConnection() },
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return errors.New("history archive is required")
+			}
+			i.file = args[0]
+			i.client = ensureHelmClient(i.client)
+			return i.run()
+		},
+	}
+
+	f := cmd.Flags()
+	settings.AddFlagsTLS(f)
+	f.StringVar(&i.namespace, "namespace", "", "Namespace to record for the imported revisions. Defaults to the exported namespace")
+	f.StringVar(&i.name, "name", "", "Release name to import the revisions as. Defaults to the exported name")
+
+	// set defaults from environment
+	settings.InitTLS(f)
+
+	return cmd
+}
+
+func (i *historyImportCmd) run() error {
+	f, err := os.Open(i.file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	rels, err := releaseutil.ReadHistoryArchive(f)
+	if err != nil {
+		return fmt.Errorf("cannot read history archive %s: %s", i.file, err)
+	}
+
+	res, err := i.client.ImportReleaseHistory(rels, helm.ImportNamespace(i.namespace), helm.ImportReleaseName(i.name))
+	if err != nil {
+		return prettyError(err)
+	}
+
+	rel := res.GetRelease()
+	fmt.Fprintf(i.out, "Imported %d revision(s) of %s into namespace %s\n", len(rels), rel.GetName(), rel.GetNamespace())
+	return nil
+}
@@ -0,0 +1,122 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/timeconv"
+)
+
+const historyPruneHelp = `
+This command removes old revisions from the history of a release.
+
+Revisions are removed when there are more than '--max' revisions, when they
+were deployed longer than '--max-age' ago, or, oldest first, while the stored
+history is larger than '--max-size'. The size of the history is the size of the
+records Tiller stores, compressed and encrypted as they are written to the
+storage backend. The latest revision and the revision that is currently
+deployed are always kept.
+
+    $ helm prune-history angry-bird --max 10 --max-age 720h
+`
+
+type historyPruneCmd struct {
+	name    string
+	max     int32
+	maxAge  time.Duration
+	maxSize string
+	dryRun  bool
+	out     io.Writer
+	client  helm.Interface
+}
+
+func newHistoryPruneCmd(client helm.Interface, out io.Writer) *cobra.Command {
+	p := &historyPruneCmd{
+		out:    out,
+		client: client,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "prune-history [flags] RELEASE_NAME",
+		Short:   "Remove old revisions from a release history",
+		Long:    historyPruneHelp,
+		PreRunE: func(_ *cobra.Command, _ []string) error { return setupConnection() },
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return errReleaseRequired
+			}
+			p.name = args[0]
+			p.client = ensureHelmClient(p.client)
+			return p.run()
+		},
+	}
+
+	f := cmd.Flags()
+	settings.AddFlagsTLS(f)
+	f.Int32Var(&p.max, "max", 0, "Maximum number of revisions to keep")
+	f.DurationVar(&p.maxAge, "max-age", 0, "Remove revisions deployed longer than this ago, e.g. 720h")
+	f.StringVar(&p.maxSize, "max-size", "", "Maximum size of the stored history, e.g. 10Mi")
+	f.BoolVar(&p.dryRun, "dry-run", false, "Show the revisions that would be removed")
+
+	// set defaults from environment
+	settings.InitTLS(f)
+
+	return cmd
+}
+
+func (p *historyPruneCmd) run() error {
+	var maxBytes int64
+	if p.maxSize != "" {
+		q, err := resource.ParseQuantity(p.maxSize)
+		if err != nil {
+			return fmt.Errorf("invalid --max-size %q: %s", p.maxSize, err)
+		}
+		maxBytes = q.Value()
+	}
+	if p.max <= 0 && p.maxAge <= 0 && maxBytes <= 0 {
+		return errors.New("at least one of --max, --max-age or --max-size is required")
+	}
+
+	res, err := p.client.PruneReleaseHistory(p.name,
+		helm.PruneMaxHistory(p.max),
+		helm.PruneMaxAge(p.maxAge),
+		helm.PruneMaxBytes(maxBytes),
+		helm.PruneDryRun(p.dryRun),
+	)
+	if err != nil {
+		return prettyError(err)
+	}
+
+	verb := "Pruned"
+	if p.dryRun {
+		verb = "Would prune"
+	}
+	for _, rel := range res.GetReleases() {
+		fmt.Fprintf(p.out, "%s revision %d (%s, updated %s)\n", verb, rel.Version,
+			rel.GetInfo().GetStatus().GetCode(), timeconv.String(rel.GetInfo().GetLastDeployed()))
+	}
+	fmt.Fprintf(p.out, "%s %d revision(s) of %s\n", verb, len(res.GetReleases()), p.name)
+	return nil
+}
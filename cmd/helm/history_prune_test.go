@@ -0,0 +1,79 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"io"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/helm"
+	rpb "k8s.io/helm/pkg/proto/hapi/release"
+)
+
+func TestHistoryPruneCmd(t *testing.T) {
+	mk := func(vers int32, code rpb.Status_Code) *rpb.Release {
+		return helm.ReleaseMock(&helm.MockReleaseOptions{Name: "angry-bird", Version: vers, StatusCode: code})
+	}
+	releases := func() []*rpb.Release {
+		return []*rpb.Release{
+			mk(4, rpb.Status_DEPLOYED),
+			mk(3, rpb.Status_SUPERSEDED),
+			mk(2, rpb.Status_SUPERSEDED),
+			mk(1, rpb.Status_SUPERSEDED),
+		}
+	}
+
+	tests := []releaseCase{
+		{
+			name:     "prune with max history",
+			args:     []string{"angry-bird"},
+			flags:    []string{"--max", "2"},
+			rels:     releases(),
+			expected: "Pruned revision 1 \\(SUPERSEDED, updated .*\\)\nPruned revision 2 \\(SUPERSEDED, updated .*\\)\nPruned 2 revision\\(s\\) of angry-bird\n",
+		},
+		{
+			name:     "prune dry run",
+			args:     []string{"angry-bird"},
+			flags:    []string{"--max", "3", "--dry-run"},
+			rels:     releases(),
+			expected: "Would prune revision 1 .*\nWould prune 1 revision\\(s\\) of angry-bird\n",
+		},
+		{
+			name: "prune without limits",
+			args: []string{"angry-bird"},
+			rels: releases(),
+			err:  true,
+		},
+		{
+			name:  "prune with invalid size",
+			args:  []string{"angry-bird"},
+			flags: []string{"--max-size", "lots"},
+			rels:  releases(),
+			err:   true,
+		},
+		{
+			name: "prune without release",
+			err:  true,
+		},
+	}
+
+	runReleaseCases(t, tests, func(c *helm.FakeClient, out io.Writer) *cobra.Command {
+		return newHistoryPruneCmd(c, out)
+	})
+}
@@ -22,9 +22,12 @@ import (
 	"github.com/golang/protobuf/ptypes/any"
 	"github.com/spf13/cobra"
 	"io"
+	"sort"
 	"strings"
 
 	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/downloader"
+	"k8s.io/helm/pkg/proto/hapi/chart"
 )
 
 const (
@@ -37,6 +40,14 @@ Inspect prints the contents of the Chart.yaml file and the values.yaml file.
 	inspectValuesDesc = `
 This command inspects a chart (directory, file, or URL) and displays the contents
 of the values.yaml file
+
+With '--environment', it displays the values the chart is deployed with in
+that environment instead: the values.yaml file of the chart merged with the
+environment values file, and the values of the subcharts, merged with their own
+environment values files, under their names. Use '--output json' to display
+them as JSON:
+
+	$ helm inspect values mychart --environment values-prod.yaml
 `
 	inspectChartDesc = `
 This command inspects a chart (directory, file, or URL) and displays the contents
@@ -45,6 +56,18 @@ of the Charts.yaml file
 	readmeChartDesc = `
 This command inspects a chart (directory, file, or URL) and displays the contents
 of the README file
+
+With '--render', a Markdown README is rendered for the terminal: the headings,
+the emphasis, the code and the lists are highlighted with ANSI escape codes.
+`
+	provenanceChartDesc = `
+This command verifies the provenance file of a packaged chart (file or URL)
+and displays the verified provenance: the signer of the chart, its hash and,
+if the chart was packaged with 'helm package --attest', the builder which
+packaged it and the source repository and the commit it was packaged from.
+
+With '--attestation-policy', the attestation must meet the policy of the file,
+as with 'helm verify'.
 `
 )
 
@@ -59,6 +82,15 @@ type inspectCmd struct {
 	username  string
 	password  string
 	devel     bool
+	// envValuesFile is the environment values file the values are
+	// displayed for, and format the format they are displayed in.
+	envValuesFile string
+	format        string
+	// render renders the Markdown of the README for the terminal.
+	render bool
+	// attestationPolicy is the file of the policy the attestation of the
+	// provenance must meet.
+	attestationPolicy string
 
 	certFile string
 	keyFile  string
@@ -66,14 +98,13 @@ type inspectCmd struct {
 }
 
 const (
-	chartOnly  = "chart"
-	valuesOnly = "values"
-	readmeOnly = "readme"
-	all        = "all"
+	chartOnly      = "chart"
+	valuesOnly     = "values"
+	readmeOnly     = "readme"
+	provenanceOnly = "provenance"
+	all            = "all"
 )
 
-var readmeFileNames = []string{"readme.md", "readme.txt", "readme"}
-
 func newInspectCmd(out io.Writer) *cobra.Command {
 	insp := &inspectCmd{
 		out:    out,
@@ -81,9 +112,10 @@ func newInspectCmd(out io.Writer) *cobra.Command {
 	}
 
 	inspectCommand := &cobra.Command{
-		Use:   "inspect [CHART]",
-		Short: "Inspect a chart",
-		Long:  inspectDesc,
+		Use:     "inspect [CHART]",
+		Aliases: []string{"show"},
+		Short:   "Inspect a chart",
+		Long:    inspectDesc,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := checkArgsLength(len(args), "chart name"); err != nil {
 				return err
@@ -143,7 +175,24 @@ func newInspectCmd(out io.Writer) *cobra.Command {
 		},
 	}
 
-	cmds := []*cobra.Command{inspectCommand, readmeSubCmd, valuesSubCmd, chartSubCmd}
+	provenanceSubCmd := &cobra.Command{
+		Use:   "provenance [CHART]",
+		Short: "shows the verified provenance of a chart",
+		Long:  provenanceChartDesc,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			insp.output = provenanceOnly
+			insp.verify = true
+			if err := checkArgsLength(len(args), "chart name"); err != nil {
+				return err
+			}
+			if err := insp.prepare(args[0]); err != nil {
+				return err
+			}
+			return insp.run()
+		},
+	}
+
+	cmds := []*cobra.Command{inspectCommand, readmeSubCmd, valuesSubCmd, chartSubCmd, provenanceSubCmd}
 	vflag := "verify"
 	vdesc := "Verify the provenance data for this chart"
 	for _, subCmd := range cmds {
@@ -174,12 +223,14 @@ func newInspectCmd(out io.Writer) *cobra.Command {
 	inspectCommand.Flags().StringVar(&insp.username, username, "", usernamedesc)
 	valuesSubCmd.Flags().StringVar(&insp.username, username, "", usernamedesc)
 	chartSubCmd.Flags().StringVar(&insp.username, username, "", usernamedesc)
+	provenanceSubCmd.Flags().StringVar(&insp.username, username, "", usernamedesc)
 
 	password := "password"
 	passworddesc := "Chart repository password where to locate the requested chart"
 	inspectCommand.Flags().StringVar(&insp.password, password, "", passworddesc)
 	valuesSubCmd.Flags().StringVar(&insp.password, password, "", passworddesc)
 	chartSubCmd.Flags().StringVar(&insp.password, password, "", passworddesc)
+	provenanceSubCmd.Flags().StringVar(&insp.password, password, "", passworddesc)
 
 	develFlag := "devel"
 	develDesc := "Use development versions, too. Equivalent to version '>0.0.0-0'. If --version is set, this is ignored."
@@ -205,6 +256,14 @@ func newInspectCmd(out io.Writer) *cobra.Command {
 		subCmd.Flags().StringVar(&insp.caFile, caFile, "", caFiledesc)
 	}
 
+	readmeSubCmd.Flags().BoolVar(&insp.render, "render", false, "Render the Markdown of the README for the terminal")
+
+	valuesSubCmd.Flags().StringVar(&insp.envValuesFile, "environment", "", "Display the values of the chart and the subcharts merged with an environment values file inside them")
+	valuesSubCmd.Flags().StringVarP(&insp.format, outputFlag, "o", string(outputYAML), fmt.Sprintf("Prints the values of the environment in the specified format. Allowed values: %s, %s", outputYAML, outputJSON))
+
+	provenanceSubCmd.Flags().StringVar(&insp.attestationPolicy, "attestation-policy", "", "Check the attestation of the build provenance of the chart against the policy of this file")
+	provenanceSubCmd.Flags().StringVarP(&insp.format, outputFlag, "o", string(outputYAML), fmt.Sprintf("Prints the provenance in the specified format. Allowed values: %s, %s", outputYAML, outputJSON))
+
 	for _, subCmd := range cmds[1:] {
 		inspectCommand.AddCommand(subCmd)
 	}
@@ -229,6 +288,12 @@ func (i *inspectCmd) prepare(chart string) error {
 }
 
 func (i *inspectCmd) run() error {
+	if i.output == valuesOnly && i.envValuesFile != "" {
+		return i.runEnvironmentValues()
+	}
+	if i.output == provenanceOnly {
+		return i.runProvenance()
+	}
 	chrt, err := chartutil.Load(i.chartpath)
 	if err != nil {
 		return err
@@ -253,32 +318,102 @@ func (i *inspectCmd) run() error {
 		if i.output == all {
 			fmt.Fprintln(i.out, "---")
 		}
-		readme := findReadme(chrt.Files)
+		readme := chartutil.Readme(chrt)
 		if readme == nil {
 			return nil
 		}
+		if i.render && strings.HasSuffix(strings.ToLower(readme.TypeUrl), ".md") {
+			fmt.Fprint(i.out, renderMarkdown(string(readme.Value)))
+			return nil
+		}
 		fmt.Fprintln(i.out, string(readme.Value))
 	}
 	return nil
 }
 
-func findReadme(files []*any.Any) (file *any.Any) {
-	for _, file := range files {
-		if containsString(readmeFileNames, strings.ToLower(file.TypeUrl), nil) {
-			return file
-		}
+// runEnvironmentValues displays the values the chart is deployed with in the
+// environment of the environment values file.
+func (i *inspectCmd) runEnvironmentValues() error {
+	// The environment values files are files of the chart when the chart is
+	// loaded without environment.
+	chrt, err := chartutil.Load(i.chartpath)
+	if err != nil {
+		return err
 	}
-	return nil
+	if !hasFile(chrt.Files, i.envValuesFile) {
+		return fmt.Errorf("environment values file %s not found in chart %s", i.envValuesFile, chrt.Metadata.Name)
+	}
+
+	chrt, err = chartutil.LoadWithEnvValuesFile(i.chartpath, i.envValuesFile)
+	if err != nil {
+		return err
+	}
+	config := &chart.Config{Raw: "", Values: map[string]*chart.Value{}}
+	if err := chartutil.ProcessRequirementsEnabled(chrt, config); err != nil {
+		return err
+	}
+	if err := chartutil.ProcessRequirementsImportValues(chrt); err != nil {
+		return err
+	}
+	vals, err := chartutil.CoalesceValues(chrt, config)
+	if err != nil {
+		return err
+	}
+
+	switch outputFormat(i.format) {
+	case outputYAML:
+		return encodeYAML(i.out, vals)
+	case outputJSON:
+		return encodeJSON(i.out, vals)
+	}
+	return fmt.Errorf("unsupported format %s", i.format)
 }
 
-// containsString checks if a given slice of strings contains the provided string.
-// If a modifier func is provided, it is called with the slice item before the comparison.
-func containsString(slice []string, s string, modifier func(s string) string) bool {
-	for _, item := range slice {
-		if item == s {
-			return true
-		}
-		if modifier != nil && modifier(item) == s {
+// provenanceInfo is the verified provenance of a chart archive.
+type provenanceInfo struct {
+	SignedBy     []string `json:"signedBy"`
+	FileName     string   `json:"fileName"`
+	FileHash     string   `json:"fileHash"`
+	Builder      string   `json:"builder,omitempty"`
+	BuildType    string   `json:"buildType,omitempty"`
+	SourceRepo   string   `json:"sourceRepo,omitempty"`
+	SourceCommit string   `json:"sourceCommit,omitempty"`
+}
+
+// runProvenance displays the verified provenance of the chart archive.
+func (i *inspectCmd) runProvenance() error {
+	ver, err := downloader.VerifyChart(i.chartpath, i.keyring)
+	if err != nil {
+		return err
+	}
+	if err := checkAttestationPolicy(i.attestationPolicy, ver); err != nil {
+		return err
+	}
+
+	info := provenanceInfo{FileName: ver.FileName, FileHash: ver.FileHash}
+	for name := range ver.SignedBy.Identities {
+		info.SignedBy = append(info.SignedBy, name)
+	}
+	sort.Strings(info.SignedBy)
+	if att := ver.Attestation; att != nil {
+		info.Builder = att.BuilderID()
+		info.BuildType = att.Predicate.BuildType
+		info.SourceRepo = att.SourceRepo()
+		info.SourceCommit = att.SourceCommit()
+	}
+
+	switch outputFormat(i.format) {
+	case outputYAML:
+		return encodeYAML(i.out, info)
+	case outputJSON:
+		return encodeJSON(i.out, info)
+	}
+	return fmt.Errorf("unsupported format %s", i.format)
+}
+
+func hasFile(files []*any.Any, name string) bool {
+	for _, f := range files {
+		if f.TypeUrl == name {
 			return true
 		}
 	}
@@ -82,6 +82,108 @@ func TestInspect(t *testing.T) {
 	}
 }
 
+func TestInspectEnvironmentValues(t *testing.T) {
+	tests := []struct {
+		name        string
+		env         string
+		format      string
+		expect      string
+		expectedErr string
+	}{
+		{
+			name:   "yaml",
+			env:    "values-prod.yaml",
+			format: "yaml",
+			expect: `backend:
+  global: {}
+  logLevel: warn
+  port: 8080
+  replicas: 1
+image:
+  repository: nginx
+  tag: "2.0"
+replicas: 3
+
+`,
+		},
+		{
+			name:   "json",
+			env:    "values-prod.yaml",
+			format: "json",
+			expect: `{"backend":{"global":{},"logLevel":"warn","port":8080,"replicas":1},"image":{"repository":"nginx","tag":"2.0"},"replicas":3}` + "\n",
+		},
+		{
+			name:        "missing environment",
+			env:         "values-staging.yaml",
+			format:      "yaml",
+			expectedErr: "environment values file values-staging.yaml not found in chart environments",
+		},
+		{
+			name:        "unsupported format",
+			env:         "values-prod.yaml",
+			format:      "table",
+			expectedErr: "unsupported format table",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := bytes.NewBuffer(nil)
+			insp := &inspectCmd{
+				chartpath:     "testdata/testcharts/environments",
+				output:        valuesOnly,
+				envValuesFile: tt.env,
+				format:        tt.format,
+				out:           b,
+			}
+			err := insp.run()
+			if tt.expectedErr != "" {
+				if err == nil || err.Error() != tt.expectedErr {
+					t.Errorf("expected error %q, got %v", tt.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if b.String() != tt.expect {
+				t.Errorf("Expected\n%s\nGot\n%s", tt.expect, b.String())
+			}
+		})
+	}
+}
+
+func TestInspectProvenance(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	insp := &inspectCmd{
+		chartpath: "testdata/testcharts/signtest-0.1.0.tgz",
+		output:    provenanceOnly,
+		keyring:   "testdata/helm-test-key.pub",
+		format:    string(outputYAML),
+		out:       b,
+	}
+	if err := insp.run(); err != nil {
+		t.Fatal(err)
+	}
+	for _, expect := range []string{
+		"fileName: signtest-0.1.0.tgz",
+		"fileHash: sha256:",
+		"Helm Testing",
+	} {
+		if !strings.Contains(b.String(), expect) {
+			t.Errorf("Expected %q in\n%s", expect, b.String())
+		}
+	}
+	if strings.Contains(b.String(), "builder:") {
+		t.Errorf("Expected no attestation in\n%s", b.String())
+	}
+
+	insp.attestationPolicy = "testdata/attestation-policy.yaml"
+	if err := insp.run(); err == nil {
+		t.Error("Expected a chart without attestation to fail the attestation policy")
+	}
+}
+
 func TestInspectPreReleaseChart(t *testing.T) {
 	hh, err := tempHelmHome(t)
 	if err != nil {
@@ -38,7 +38,10 @@ import (
 	"k8s.io/helm/pkg/getter"
 	"k8s.io/helm/pkg/helm"
 	"k8s.io/helm/pkg/kube"
+	"k8s.io/helm/pkg/plugin"
+	"k8s.io/helm/pkg/proto/hapi/chart"
 	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/proto/hapi/services"
 	"k8s.io/helm/pkg/renderutil"
 	"k8s.io/helm/pkg/repo"
 	"k8s.io/helm/pkg/strvals"
@@ -69,6 +72,12 @@ or
 or
     $ helm install --set-file multiline_text=path/to/textfile
 
+A directory, or a glob pattern, given to '--set-file' sets a map of the names
+of the files it holds or matches to their contents, e.g. to load a
+certificate bundle. The contents of binary files are encoded in base64:
+
+	$ helm install --set-file 'tls.certs=certs/*.crt' --set-file dashboards=dashboards/ ./grafana
+
 You can specify the '--values'/'-f' flag multiple times. The priority will be given to the
 last (right-most) file specified. For example, if both myvalues.yaml and override.yaml
 contained a key called 'Test', the value set in override.yaml would take precedence:
@@ -81,6 +90,9 @@ set for a key called 'foo', the 'newbar' value would take precedence:
 
 	$ helm install --set foo=bar --set foo=newbar ./redis
 
+A null value deletes the default value of a key. To log every default value
+deleted by a null value, with the file or the '--set' value setting it to
+null, use '--log-null-deletes'. To fail instead, use '--no-null-deletes'.
 
 To check the generated manifests of a release without installing the chart,
 the '--debug' and '--dry-run' flags can be combined. This will still require a
@@ -109,11 +121,18 @@ version of that chart unless you also supply a version number with the
 
 To see the list of chart repositories, use 'helm repo list'. To search for
 charts in a repository, use 'helm search'.
+
+The experimental '--no-tiller' flag installs the release without Tiller: the
+chart is rendered by the helm client, the manifests are applied with the
+credentials of the kubeconfig, and the release records are stored as Secrets in
+the namespace of the release. Upgrade and delete such a release with
+'--no-tiller' too.
 `
 
 type installCmd struct {
 	name           string
 	namespace      string
+	noTiller       bool
 	valueFiles     valueFiles
 	chartPath      string
 	dryRun         bool
@@ -127,6 +146,9 @@ type installCmd struct {
 	values         []string
 	stringValues   []string
 	fileValues     []string
+	envValuesFile  string
+	expandEnv      []string
+	symlinks       symlinkPolicy
 	nameTemplate   string
 	version        string
 	timeout        int64
@@ -139,11 +161,15 @@ type installCmd struct {
 	depUp          bool
 	subNotes       bool
 	description    string
+	logNullDeletes bool
+	noNullDeletes  bool
+	policyDir      string
 
 	certFile string
 	keyFile  string
 	caFile   string
 	output   string
+	summary  bool
 }
 
 type valueFiles []string
@@ -173,7 +199,7 @@ func newInstallCmd(c helm.Interface, out io.Writer) *cobra.Command {
 		Use:     "install [CHART]",
 		Short:   "Install a chart archive",
 		Long:    installDesc,
-		PreRunE: func(_ *cobra.Command, _ []string) error { return setupConnection() },
+		PreRunE: func(_ *cobra.Command, _ []string) error { return setupReleaseConnection(inst.noTiller, inst.namespace) },
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := checkArgsLength(len(args), "chart name"); err != nil {
 				return err
@@ -203,6 +229,7 @@ func newInstallCmd(c helm.Interface, out io.Writer) *cobra.Command {
 	f.VarP(&inst.valueFiles, "values", "f", "Specify values in a YAML file or a URL(can specify multiple)")
 	f.StringVarP(&inst.name, "name", "n", "", "The release name. If unspecified, it will autogenerate one for you")
 	f.StringVar(&inst.namespace, "namespace", "", "Namespace to install the release into. Defaults to the current kube config namespace.")
+	f.BoolVar(&inst.noTiller, "no-tiller", false, noTillerUsage)
 	f.BoolVar(&inst.dryRun, "dry-run", false, "Simulate an install")
 	f.BoolVar(&inst.disableHooks, "no-hooks", false, "Prevent hooks from running during install")
 	f.BoolVar(&inst.disableCRDHook, "no-crd-hook", false, "Prevent CRD hooks from running, but run other hooks")
@@ -215,7 +242,7 @@ func newInstallCmd(c helm.Interface, out io.Writer) *cobra.Command {
 	f.StringVar(&inst.keyring, "keyring", defaultKeyring(), "Location of public keys used for verification")
 	f.StringVar(&inst.version, "version", "", "Specify the exact chart version to install. If this is not specified, the latest version is installed")
 	f.Int64Var(&inst.timeout, "timeout", 300, "Time in seconds to wait for any individual Kubernetes operation (like Jobs for hooks)")
-	f.BoolVar(&inst.wait, "wait", false, "If set, will wait until all Pods, PVCs, Services, and minimum number of Pods of a Deployment are in a ready state before marking the release as successful. It will wait for as long as --timeout")
+	f.BoolVar(&inst.wait, "wait", false, "If set, will wait until all Pods, PVCs, Services, and minimum number of Pods of a Deployment are in a ready state before marking the release as successful. It will wait for as long as --timeout, reporting the resources which are not ready yet")
 	f.BoolVar(&inst.atomic, "atomic", false, "If set, installation process purges chart on fail, also sets --wait flag")
 	f.StringVar(&inst.repoURL, "repo", "", "Chart repository url where to locate the requested chart")
 	f.StringVar(&inst.username, "username", "", "Chart repository username where to locate the requested chart")
@@ -225,8 +252,15 @@ func newInstallCmd(c helm.Interface, out io.Writer) *cobra.Command {
 	f.StringVar(&inst.caFile, "ca-file", "", "Verify certificates of HTTPS-enabled servers using this CA bundle")
 	f.BoolVar(&inst.devel, "devel", false, "Use development versions, too. Equivalent to version '>0.0.0-0'. If --version is set, this is ignored.")
 	f.BoolVar(&inst.depUp, "dep-up", false, "Run helm dependency update before installing the chart")
-	f.BoolVar(&inst.subNotes, "render-subchart-notes", false, "Render subchart notes along with the parent")
+	f.BoolVar(&inst.subNotes, "render-subchart-notes", false, "Render subchart notes along with the parent, as the environment values file does with a '# helm:subchart-notes' comment")
+	f.StringVar(&inst.envValuesFile, "environment", "", "Use an environment values file inside the chart and the subcharts")
+	f.StringArrayVar(&inst.expandEnv, "expand-env", []string{}, "Expand the environment variables matching a pattern, like CI_*, referenced as ${NAME} or ${NAME:-default} in the values files and the values of the chart. Can be specified multiple times")
+	addSymlinksFlag(f, &inst.symlinks)
 	f.StringVar(&inst.description, "description", "", "Specify a description for the release")
+	f.BoolVar(&inst.logNullDeletes, "log-null-deletes", false, "Log every default value deleted by a null value, with the file setting it to null")
+	f.BoolVar(&inst.noNullDeletes, "no-null-deletes", false, "Fail instead of deleting default values set to null")
+	f.StringVar(&inst.policyDir, "policy-dir", "", "Render the chart and fail before installing it if the manifests or the values violate the Rego policies of the .rego files of this directory")
+	f.BoolVar(&inst.summary, "summary", false, "Print a summary of the release, with its resources, hooks, notes and warnings, instead of its status. The summary is also printed by --dry-run")
 	bindOutputFlag(cmd, &inst.output)
 
 	// set defaults from environment
@@ -242,10 +276,13 @@ func (i *installCmd) run() error {
 		i.namespace = defaultNamespace()
 	}
 
-	rawVals, err := vals(i.valueFiles, i.values, i.stringValues, i.fileValues, i.certFile, i.keyFile, i.caFile)
+	rawVals, err := vals(i.valueFiles, i.values, i.stringValues, i.fileValues, i.expandEnv, i.certFile, i.keyFile, i.caFile)
 	if err != nil {
 		return err
 	}
+	if rawVals, err = runCommandHooks(plugin.PreInstall, rawVals); err != nil {
+		return err
+	}
 
 	// If template is specified, try to run the template.
 	if i.nameTemplate != "" {
@@ -262,10 +299,17 @@ func (i *installCmd) run() error {
 	}
 
 	// Check chart requirements to make sure all dependencies are present in /charts
-	chartRequested, err := chartutil.Load(i.chartPath)
+	chartRequested, err := chartutil.LoadWithEnvValuesFile(i.chartPath, i.envValuesFile, i.symlinks.loadOption())
 	if err != nil {
 		return prettyError(err)
 	}
+	if chartutil.IsLibraryChart(chartRequested) {
+		return fmt.Errorf("library chart %s is not installable", chartRequested.Metadata.Name)
+	}
+	subNotes, err := subchartNotes(i.chartPath, i.envValuesFile, i.subNotes, i.symlinks.loadOption())
+	if err != nil {
+		return err
+	}
 
 	if req, err := chartutil.LoadRequirements(chartRequested); err == nil {
 		// If checkDependencies returns an error, we have unfulfilled dependencies.
@@ -280,13 +324,14 @@ func (i *installCmd) run() error {
 					Keyring:    defaultKeyring(),
 					SkipUpdate: false,
 					Getters:    getter.All(settings),
+					Symlinks:   chartutil.SymlinkPolicy(i.symlinks),
 				}
 				if err := man.Update(); err != nil {
 					return prettyError(err)
 				}
 
 				// Update all dependencies which are present in /charts.
-				chartRequested, err = chartutil.Load(i.chartPath)
+				chartRequested, err = chartutil.LoadWithEnvValuesFile(i.chartPath, i.envValuesFile, i.symlinks.loadOption())
 				if err != nil {
 					return prettyError(err)
 				}
@@ -299,6 +344,24 @@ func (i *installCmd) run() error {
 		return fmt.Errorf("cannot load requirements: %v", err)
 	}
 
+	if err := chartutil.ExpandChartEnv(chartRequested, i.expandEnv); err != nil {
+		return err
+	}
+
+	if err := checkNullDeletes(chartRequested, &chart.Config{Raw: string(rawVals)}, i.valueFiles, i.values, i.logNullDeletes, i.noNullDeletes); err != nil {
+		return err
+	}
+	if i.policyDir != "" {
+		if err := checkInstallPolicies(i.policyDir, chartRequested, &chart.Config{Raw: string(rawVals)}, i.name, i.namespace, i.envValuesFile); err != nil {
+			return err
+		}
+	}
+
+	// Without a name, the release can't be found before Tiller returns it.
+	stopProgress := func() {}
+	if i.wait && !i.dryRun && i.name != "" {
+		stopProgress = reportWaitProgress(i.client, i.name)
+	}
 	res, err := i.client.InstallReleaseFromChart(
 		chartRequested,
 		i.namespace,
@@ -308,13 +371,15 @@ func (i *installCmd) run() error {
 		helm.InstallReuseName(i.replace),
 		helm.InstallDisableHooks(i.disableHooks),
 		helm.InstallDisableCRDHook(i.disableCRDHook),
-		helm.InstallSubNotes(i.subNotes),
+		helm.InstallSubNotes(subNotes),
 		helm.InstallTimeout(i.timeout),
 		helm.InstallWait(i.wait),
+		helm.InstallEnvironment(i.envValuesFile),
 		helm.InstallDescription(i.description))
+	stopProgress()
 	if err != nil {
 		if i.atomic {
-			fmt.Fprintf(os.Stdout, "INSTALL FAILED\nPURGING CHART\nError: %v\n", prettyError(err))
+			info("INSTALL FAILED\nPURGING CHART\nError: %v", prettyError(err))
 			deleteSideEffects := &deleteCmd{
 				name:         i.name,
 				disableHooks: i.disableHooks,
@@ -328,7 +393,7 @@ func (i *installCmd) run() error {
 			if err := deleteSideEffects.run(); err != nil {
 				return err
 			}
-			fmt.Fprintf(os.Stdout, "Successfully purged a chart!\n")
+			info("Successfully purged a chart!")
 		}
 		return prettyError(err)
 	}
@@ -343,26 +408,37 @@ func (i *installCmd) run() error {
 	}
 
 	// If this is a dry run, we can't display status.
-	if i.dryRun {
+	if i.dryRun && !i.summary {
 		// This is special casing to avoid breaking backward compatibility:
-		if res.Release.Info.Description != "Dry run complete" {
-			fmt.Fprintf(os.Stdout, "WARNING: %s\n", res.Release.Info.Description)
+		if rel.Info.Description != "Dry run complete" {
+			fmt.Fprintf(os.Stdout, "WARNING: %s\n", rel.Info.Description)
 		}
 		return nil
 	}
 
-	// Print the status like status command does
-	status, err := i.client.ReleaseStatus(rel.Name)
-	if err != nil {
-		return prettyError(err)
+	var status *services.GetReleaseStatusResponse
+	if !i.dryRun {
+		if status, err = i.client.ReleaseStatus(rel.Name); err != nil {
+			return prettyError(err)
+		}
+	}
+	if !i.summary {
+		// Print the status like status command does
+		return write(i.out, &statusWriter{status}, outputFormat(i.output))
 	}
 
-	return write(i.out, &statusWriter{status}, outputFormat(i.output))
+	summary := newSummaryWriter(rel, status)
+	if i.dryRun && rel.Info.Description != "Dry run complete" {
+		summary.warn("%s", rel.Info.Description)
+	}
+	return write(i.out, summary, outputFormat(i.output))
 }
 
 // vals merges values from files specified via -f/--values and
-// directly via --set or --set-string or --set-file, marshaling them to YAML
-func vals(valueFiles valueFiles, values []string, stringValues []string, fileValues []string, CertFile, KeyFile, CAFile string) ([]byte, error) {
+// directly via --set or --set-string or --set-file, marshaling them to YAML.
+// The environment variables of expandEnv, and of the expand-env directive of
+// each file, are expanded in the files.
+func vals(valueFiles valueFiles, values []string, stringValues []string, fileValues []string, expandEnv []string, CertFile, KeyFile, CAFile string) ([]byte, error) {
 	base := map[string]interface{}{}
 
 	// User specified a values files via -f/--values
@@ -384,6 +460,10 @@ func vals(valueFiles valueFiles, values []string, stringValues []string, fileVal
 		if err := yaml.Unmarshal(bytes, &currentMap); err != nil {
 			return []byte{}, fmt.Errorf("failed to parse %s: %s", filePath, err)
 		}
+		allow := append(append([]string{}, expandEnv...), chartutil.ExpandEnvAllowlist(bytes)...)
+		if err := chartutil.ExpandEnv(currentMap, allow); err != nil {
+			return []byte{}, fmt.Errorf("failed to expand %s: %s", filePath, err)
+		}
 		// Merge with the previous map
 		base = chartutil.MergeValues(base, currentMap)
 	}
@@ -405,8 +485,7 @@ func vals(valueFiles valueFiles, values []string, stringValues []string, fileVal
 	// User specified a value via --set-file
 	for _, value := range fileValues {
 		reader := func(rs []rune) (interface{}, error) {
-			bytes, err := readFile(string(rs), CertFile, KeyFile, CAFile)
-			return string(bytes), err
+			return readSetFile(string(rs), CertFile, KeyFile, CAFile)
 		}
 		if err := strvals.ParseIntoFile(value, base, reader); err != nil {
 			return []byte{}, fmt.Errorf("failed parsing --set-file data: %s", err)
@@ -424,7 +503,7 @@ func (i *installCmd) printRelease(rel *release.Release) {
 	// TODO: Switch to text/template like everything else.
 	fmt.Fprintf(i.out, "NAME:   %s\n", rel.Name)
 	if settings.Debug {
-		printRelease(i.out, rel)
+		debugRelease(i.out, rel)
 	}
 }
 
@@ -459,7 +538,7 @@ func locateChartPath(repoURL, username, password, name, version string, verify b
 		return abs, nil
 	}
 	if filepath.IsAbs(name) || strings.HasPrefix(name, ".") {
-		return name, fmt.Errorf("path %q not found", name)
+		return name, withExitCode(exitCodeChartNotFound, fmt.Errorf("path %q not found", name))
 	}
 
 	crepo := filepath.Join(settings.Home.Repository(), name)
@@ -482,7 +561,7 @@ func locateChartPath(repoURL, username, password, name, version string, verify b
 		chartURL, err := repo.FindChartInAuthRepoURL(repoURL, username, password, name, version,
 			certFile, keyFile, caFile, getter.All(settings))
 		if err != nil {
-			return "", err
+			return "", withExitCode(exitCodeChartNotFound, err)
 		}
 		name = chartURL
 	}
@@ -500,10 +579,10 @@ func locateChartPath(repoURL, username, password, name, version string, verify b
 		debug("Fetched %s to %s\n", name, filename)
 		return lname, nil
 	} else if settings.Debug {
-		return filename, err
+		return filename, withExitCode(exitCodeChartNotFound, err)
 	}
 
-	return filename, fmt.Errorf("failed to download %q (hint: running `helm repo update` may help)", name)
+	return filename, withExitCode(exitCodeChartNotFound, fmt.Errorf("failed to download %q (hint: running `helm repo update` may help)", name))
 }
 
 func generateName(nameTemplate string) (string, error) {
@@ -155,6 +155,19 @@ func TestInstall(t *testing.T) {
 			args:  []string{"testdata/testcharts/signtest-0.1.0.tgz"},
 			flags: strings.Split("--verify --keyring testdata/helm-test-key.pub", " "),
 		},
+		// Install, library chart
+		{
+			name: "install library chart",
+			args: []string{"testdata/testcharts/uselib/charts/common"},
+			err:  true,
+		},
+		{
+			name:     "install chart with a library dependency",
+			args:     []string{"testdata/testcharts/uselib"},
+			flags:    strings.Split("--name uselib", " "),
+			expected: "uselib",
+			resp:     helm.ReleaseMock(&helm.MockReleaseOptions{Name: "uselib"}),
+		},
 		// Install, chart with missing dependencies in /charts
 		{
 			name: "install chart with missing dependencies",
@@ -193,6 +206,19 @@ func TestInstall(t *testing.T) {
 			err:   true,
 		},
 		// Install, using --output json
+		{
+			name:     "install with policies",
+			args:     []string{"testdata/testcharts/alpine"},
+			flags:    strings.Split("--name virgil --set test.Name=gopher --policy-dir testdata/policies", " "),
+			resp:     helm.ReleaseMock(&helm.MockReleaseOptions{Name: "virgil"}),
+			expected: "virgil",
+		},
+		{
+			name:  "install violating policies",
+			args:  []string{"testdata/testcharts/alpine"},
+			flags: strings.Split("--name virgil --set test.Name=root,restartPolicy=Always --policy-dir testdata/policies", " "),
+			err:   true,
+		},
 		{
 			name:     "install using output json",
 			args:     []string{"testdata/testcharts/alpine"},
@@ -208,6 +234,21 @@ func TestInstall(t *testing.T) {
 			resp:     helm.ReleaseMock(&helm.MockReleaseOptions{Name: "virgil"}),
 			expected: "info:\n  Description: Release mock\n  first_deployed:\n    seconds: 242085845\n  last_deployed:\n    seconds: 242085845\n  status:\n    code: 1\nname: virgil\nnamespace: default\n",
 		},
+		// Install, printing the summary
+		{
+			name:     "install using summary and output json",
+			args:     []string{"testdata/testcharts/alpine"},
+			flags:    strings.Split("--name virgil --summary --output json", " "),
+			resp:     helm.ReleaseMock(&helm.MockReleaseOptions{Name: "virgil"}),
+			expected: regexp.QuoteMeta(`{"name":"virgil","namespace":"default","revision":1,"status":"DEPLOYED","chart":"alpine-0.1.0","appVersion":"3.3","lastDeployed":"1977-09-02T22:04:05Z","description":"Release mock","resources":[{"kind":"v1/Secret","names":["fixture"]}]`),
+		},
+		{
+			name:     "install using summary and output yaml",
+			args:     []string{"testdata/testcharts/alpine"},
+			flags:    strings.Split("--name virgil --summary --output yaml", " "),
+			resp:     helm.ReleaseMock(&helm.MockReleaseOptions{Name: "virgil"}),
+			expected: "lastDeployed: \"1977-09-02T22:04:05Z\"\nname: virgil\nnamespace: default\nresources:\n- kind: v1/Secret\n  names:\n  - fixture\nrevision: 1\nstatus: DEPLOYED\n",
+		},
 	}
 
 	runReleaseCases(t, tests, func(c *helm.FakeClient, out io.Writer) *cobra.Command {
@@ -343,4 +384,17 @@ func TestMergeValues(t *testing.T) {
 	if !equal {
 		t.Errorf("Expected a map with different keys to merge properly with another map. Expected: %v, got %v", expectedMap, testMap)
 	}
+
+	// Tables decoded from YAML are merged.
+	testMap = chartutil.MergeValues(chartutil.Values{
+		"image": map[string]interface{}{"repository": "nginx", "tag": "1.0"},
+	}, chartutil.Values{
+		"image": map[string]interface{}{"tag": "2.0"},
+	})
+	expectedMap = chartutil.Values{
+		"image": map[string]interface{}{"repository": "nginx", "tag": "2.0"},
+	}
+	if !reflect.DeepEqual(testMap, expectedMap) {
+		t.Errorf("Expected tables decoded from YAML to be merged. Expected: %v, got %v", expectedMap, testMap)
+	}
 }
@@ -30,6 +30,8 @@ import (
 
 	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/lint"
+	"k8s.io/helm/pkg/lint/fix"
+	"k8s.io/helm/pkg/lint/rules"
 	"k8s.io/helm/pkg/lint/support"
 	"k8s.io/helm/pkg/strvals"
 )
@@ -41,6 +43,55 @@ the chart is well-formed.
 If the linter encounters things that will cause the chart to fail installation,
 it will emit [ERROR] messages. If it encounters issues that break with convention
 or recommendation, it will emit [WARNING] messages.
+
+The environment values files of the chart, the YAML files next to values.yaml
+whose name starts with 'values' like 'values-prod.yaml', are compared with
+values.yaml: a [WARNING] is emitted for every value whose type differs, like a
+string in values.yaml and a number in values-prod.yaml, as it commonly breaks
+the templates in only one environment.
+
+With '--fix', the mechanical issues of a chart directory are corrected in place
+before it is linted, and every correction is printed as a [FIXED] message:
+
+- a missing apiVersion or name in Chart.yaml is set, from the dependencies of
+  the chart and the name of its directory, and apiVersion is moved to the top;
+- an icon URL without scheme is given the https scheme;
+- values.yaml is reindented by two spaces, keeping its comments;
+- the label helpers are included with 'include' and 'nindent' instead of
+  'template' and 'indent';
+- a missing trailing newline is added to the templates.
+
+Packaged charts are not fixed.
+
+With '--rules', the custom rules of an organization, like required labels or
+resource limits, are run with the other rules. The rules are loaded from Go
+plugins, built with 'go build -buildmode=plugin' and exporting a function
+'Rules' of type 'func() []support.Rule', or from programs run as
+'<program> lint'. A program reads the chart on its standard input, in JSON,
+with its metadata, values, templates, and the manifests rendered with
+values.yaml and with every environment values file, and prints its messages on
+its standard output:
+
+	[{"severity": "error", "path": "templates/deployment.yaml", "message": "no memory limit"}]
+
+'--rules' takes a file or a directory of rules, and can be specified multiple
+times.
+
+With '--policy', the Chart.yaml of the chart is checked against the metadata
+policy of an organization, a YAML file like:
+
+	requireMaintainers: true
+	requireMaintainerEmail: true
+	requireHome: true
+	requiredAnnotations:
+	- example.com/team
+	prereleases:
+	- rc.*
+	licenses:
+	- Apache-2.0
+
+and every violation is an [ERROR]. The license of a chart is stated by its
+'helm.sh/license' annotation. 'helm package --policy' checks the same policy.
 `
 
 type lintCmd struct {
@@ -50,6 +101,10 @@ type lintCmd struct {
 	fValues    []string
 	namespace  string
 	strict     bool
+	fix        bool
+	ruleFiles  []string
+	policy     string
+	symlinks   symlinkPolicy
 	paths      []string
 	out        io.Writer
 }
@@ -77,6 +132,10 @@ func newLintCmd(out io.Writer) *cobra.Command {
 	cmd.Flags().StringArrayVar(&l.fValues, "set-file", []string{}, "Set values from respective files specified via the command line (can specify multiple or separate values with commas: key1=path1,key2=path2)")
 	cmd.Flags().StringVar(&l.namespace, "namespace", "default", "Namespace to put the release into")
 	cmd.Flags().BoolVar(&l.strict, "strict", false, "Fail on lint warnings")
+	cmd.Flags().StringArrayVar(&l.ruleFiles, "rules", []string{}, "Run the custom lint rules of a Go plugin, a program or a directory of them (can specify multiple)")
+	cmd.Flags().StringVar(&l.policy, "policy", "", "Check the Chart.yaml of the chart against the metadata policy of this file")
+	cmd.Flags().BoolVar(&l.fix, "fix", false, "Correct the mechanical issues of the chart in place before linting it")
+	addSymlinksFlag(cmd.Flags(), &l.symlinks)
 
 	return cmd
 }
@@ -97,10 +156,31 @@ func (l *lintCmd) run() error {
 		return err
 	}
 
+	var custom []support.Rule
+	for _, file := range l.ruleFiles {
+		r, err := rules.LoadRules(file)
+		if err != nil {
+			return err
+		}
+		custom = append(custom, r...)
+	}
+	if l.policy != "" {
+		policy, err := chartutil.LoadMetadataPolicy(l.policy)
+		if err != nil {
+			return err
+		}
+		custom = append(custom, &rules.PolicyRule{Policy: policy})
+	}
+
 	var total int
 	var failures int
 	for _, path := range l.paths {
-		if linter, err := lintChart(path, rvals, l.namespace, l.strict); err != nil {
+		if l.fix {
+			if err := fixChart(path); err != nil {
+				return err
+			}
+		}
+		if linter, err := lintChart(path, rvals, l.namespace, l.strict, custom, l.symlinks.loadOption()); err != nil {
 			fmt.Println("==> Skipping", path)
 			fmt.Println(err)
 			if err == errLintNoChart {
@@ -127,7 +207,7 @@ func (l *lintCmd) run() error {
 
 	msg := fmt.Sprintf("%d chart(s) linted", total)
 	if failures > 0 {
-		return fmt.Errorf("%s, %d chart(s) failed", msg, failures)
+		return withExitCode(exitCodeValidationFailure, fmt.Errorf("%s, %d chart(s) failed", msg, failures))
 	}
 
 	fmt.Fprintf(l.out, "%s, no failures\n", msg)
@@ -135,7 +215,34 @@ func (l *lintCmd) run() error {
 	return nil
 }
 
-func lintChart(path string, vals []byte, namespace string, strict bool) (support.Linter, error) {
+// fixChart corrects the mechanical issues of the chart directory path, and
+// prints the fixes.
+func fixChart(path string) error {
+	if strings.HasSuffix(path, ".tgz") {
+		fmt.Println("==> Not fixing", path, "as it is a packaged chart")
+		return nil
+	}
+	if _, err := os.Stat(filepath.Join(path, "Chart.yaml")); err != nil {
+		// Reported by the linter.
+		return nil
+	}
+
+	fmt.Println("==> Fixing", path)
+	fixes, err := fix.All(path)
+	for _, f := range fixes {
+		fmt.Println(f)
+	}
+	if err != nil {
+		return fmt.Errorf("fixing %s: %s", path, err)
+	}
+	if len(fixes) == 0 {
+		fmt.Println("Nothing to fix")
+	}
+	fmt.Println("")
+	return nil
+}
+
+func lintChart(path string, vals []byte, namespace string, strict bool, custom []support.Rule, opts ...chartutil.LoadOption) (support.Linter, error) {
 	var chartPath string
 	linter := support.Linter{}
 
@@ -174,7 +281,7 @@ func lintChart(path string, vals []byte, namespace string, strict bool) (support
 		return linter, errLintNoChart
 	}
 
-	return lint.All(chartPath, vals, namespace, strict), nil
+	return lint.AllWithRules(chartPath, vals, namespace, strict, custom, opts...), nil
 }
 
 // vals merges values from files specified via -f/--values and
@@ -197,6 +304,9 @@ func (l *lintCmd) vals() ([]byte, error) {
 		if err := yaml.Unmarshal(bytes, &currentMap); err != nil {
 			return []byte{}, fmt.Errorf("failed to parse %s: %s", filePath, err)
 		}
+		if err := chartutil.ExpandEnv(currentMap, chartutil.ExpandEnvAllowlist(bytes)); err != nil {
+			return []byte{}, fmt.Errorf("failed to expand %s: %s", filePath, err)
+		}
 		// Merge with the previous map
 		base = chartutil.MergeValues(base, currentMap)
 	}
@@ -60,7 +60,7 @@ func TestLintChart(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := lintChart(tt.chartPath, values, namespace, strict)
+			_, err := lintChart(tt.chartPath, values, namespace, strict, nil)
 			switch {
 			case err != nil && !tt.err:
 				t.Errorf("%s", err)
@@ -21,12 +21,14 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
 	"github.com/ghodss/yaml"
 	"github.com/gosuri/uitable"
 	"github.com/spf13/cobra"
 
 	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/chart"
 	"k8s.io/helm/pkg/proto/hapi/release"
 	"k8s.io/helm/pkg/proto/hapi/services"
 	"k8s.io/helm/pkg/timeconv"
@@ -53,16 +55,37 @@ Only items that match the filter will be returned.
 If no results are found, 'helm list' will exit 0, but with no output (or in
 the case of no '-q' flag, only headers).
 
+To show the environment values file the releases were deployed with, use
+'--output wide'.
+
+The releases can be filtered by Tiller by the name of their chart with
+'--chart', by the app version of their chart with '--app-version', by their
+environment values file with '--environment' and by the time they were last
+deployed with '--deployed-after'. The time is either an RFC 3339 time or a
+duration before now:
+
+	$ helm list --environment values-prod.yaml --output wide
+	$ helm list --chart mariadb --deployed-after 24h
+
 By default, up to 256 items may be returned. To limit this, use the '--max' flag.
-Setting '--max' to 0 will not return all results. Rather, it will return the
-server's default, which may be much higher than 256. Pairing the '--max'
-flag with the '--offset' flag allows you to page through results.
+Setting '--max' to 0 returns all results. The releases are fetched from Tiller
+in pages of '--page-size' releases, so that long listings do not have to be
+held in memory by Tiller and by the client at once. When more results are
+available, the name of the next release is printed as 'next', and pairing the
+'--max' flag with the '--offset' flag set to that name allows you to page
+through results:
+
+	$ helm list --max 2
+	next: maudlin-arachnid
+	...
+	$ helm list --max 2 --offset maudlin-arachnid
 `
 
 type listCmd struct {
 	filter      string
 	short       bool
 	limit       int
+	pageSize    int
 	offset      string
 	byDate      bool
 	sortDesc    bool
@@ -79,6 +102,11 @@ type listCmd struct {
 	colWidth    uint
 	output      string
 	byChartName bool
+	environment string
+	chartName   string
+	appVersion  string
+	// deployedAfter is an RFC 3339 time or a duration before now.
+	deployedAfter string
 }
 
 type listResult struct {
@@ -87,13 +115,14 @@ type listResult struct {
 }
 
 type listRelease struct {
-	Name       string
-	Revision   int32
-	Updated    string
-	Status     string
-	Chart      string
-	AppVersion string
-	Namespace  string
+	Name        string
+	Revision    int32
+	Updated     string
+	Status      string
+	Chart       string
+	AppVersion  string
+	Namespace   string
+	Environment string `json:",omitempty"`
 }
 
 func newListCmd(client helm.Interface, out io.Writer) *cobra.Command {
@@ -124,7 +153,8 @@ func newListCmd(client helm.Interface, out io.Writer) *cobra.Command {
 	f.BoolVarP(&list.short, "short", "q", false, "Output short (quiet) listing format")
 	f.BoolVarP(&list.byDate, "date", "d", false, "Sort by release date")
 	f.BoolVarP(&list.sortDesc, "reverse", "r", false, "Reverse the sort order")
-	f.IntVarP(&list.limit, "max", "m", 256, "Maximum number of releases to fetch")
+	f.IntVarP(&list.limit, "max", "m", 256, "Maximum number of releases to fetch, 0 for all of them")
+	f.IntVar(&list.pageSize, "page-size", helm.DefaultListPageSize, "Number of releases fetched from Tiller per call")
 	f.StringVarP(&list.offset, "offset", "o", "", "Next release name in the list, used to offset from start value")
 	f.BoolVarP(&list.all, "all", "a", false, "Show all releases, not just the ones marked DEPLOYED")
 	f.BoolVar(&list.deleted, "deleted", false, "Show deleted releases")
@@ -134,7 +164,11 @@ func newListCmd(client helm.Interface, out io.Writer) *cobra.Command {
 	f.BoolVar(&list.pending, "pending", false, "Show pending releases")
 	f.StringVar(&list.namespace, "namespace", "", "Show releases within a specific namespace")
 	f.UintVar(&list.colWidth, "col-width", 60, "Specifies the max column width of output")
-	f.StringVar(&list.output, "output", "", "Output the specified format (json or yaml)")
+	f.StringVar(&list.output, "output", "", "Output the specified format (json, yaml or wide)")
+	f.StringVar(&list.environment, "environment", "", "Show releases deployed with a specific environment values file")
+	f.StringVar(&list.chartName, "chart", "", "Show releases of a specific chart")
+	f.StringVar(&list.appVersion, "app-version", "", "Show releases of a specific app version")
+	f.StringVar(&list.deployedAfter, "deployed-after", "", "Show releases last deployed after an RFC 3339 time, or a duration before now like 24h")
 	f.BoolVarP(&list.byChartName, "chart-name", "c", false, "Sort by chart name")
 
 	// TODO: Do we want this as a feature of 'helm list'?
@@ -162,26 +196,39 @@ func (l *listCmd) run() error {
 
 	stats := l.statusCodes()
 
-	res, err := l.client.ListReleases(
-		helm.ReleaseListLimit(l.limit),
+	opts := []helm.ReleaseListOption{
 		helm.ReleaseListOffset(l.offset),
 		helm.ReleaseListFilter(l.filter),
 		helm.ReleaseListSort(int32(sortBy)),
 		helm.ReleaseListOrder(int32(sortOrder)),
 		helm.ReleaseListStatuses(stats),
 		helm.ReleaseListNamespace(l.namespace),
-	)
-
-	if err != nil {
-		return prettyError(err)
+		helm.ReleaseListChartName(l.chartName),
+		helm.ReleaseListAppVersion(l.appVersion),
+		helm.ReleaseListEnvironment(l.environment),
 	}
-	if res == nil {
-		return nil
+	if l.deployedAfter != "" {
+		t, err := parseDeployedAfter(l.deployedAfter, time.Now())
+		if err != nil {
+			return err
+		}
+		opts = append(opts, helm.ReleaseListDeployedAfter(t))
 	}
 
-	rels := filterList(res.GetReleases())
+	pageSize := l.pageSize
+	if l.limit > 0 && l.limit < pageSize {
+		pageSize = l.limit
+	}
+	var rels []*release.Release
+	it := helm.NewReleaseIterator(l.client, pageSize, opts...)
+	for (l.limit <= 0 || len(rels) < l.limit) && it.Next() {
+		rels = append(rels, listStub(it.Release()))
+	}
+	if err := it.Err(); err != nil {
+		return prettyError(err)
+	}
 
-	result := getListResult(rels, res.Next)
+	result := getListResult(filterList(rels), it.Continue())
 
 	output, err := formatResult(l.output, l.short, result, l.colWidth)
 
@@ -193,6 +240,22 @@ func (l *listCmd) run() error {
 	return nil
 }
 
+// listStub returns a copy of r with only the fields printed by list, so that
+// the manifests, hooks and values of long listings are not kept in memory.
+func listStub(r *release.Release) *release.Release {
+	stub := &release.Release{
+		Name:        r.GetName(),
+		Version:     r.GetVersion(),
+		Namespace:   r.GetNamespace(),
+		Environment: r.GetEnvironment(),
+		Info:        r.GetInfo(),
+	}
+	if md := r.GetChart().GetMetadata(); md != nil {
+		stub.Chart = &chart.Chart{Metadata: md}
+	}
+	return stub
+}
+
 // filterList returns a list scrubbed of old releases.
 func filterList(rels []*release.Release) []*release.Release {
 	idx := map[string]int32{}
@@ -217,6 +280,18 @@ func filterList(rels []*release.Release) []*release.Release {
 	return uniq
 }
 
+// parseDeployedAfter parses s, an RFC 3339 time or a duration before now.
+func parseDeployedAfter(s string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --deployed-after %q: must be an RFC 3339 time like 2019-10-31T10:00:00Z or a duration like 24h", s)
+	}
+	return now.Add(-d), nil
+}
+
 // statusCodes gets the list of status codes that are to be included in the results.
 func (l *listCmd) statusCodes() []release.Status_Code {
 	if l.all {
@@ -268,13 +343,14 @@ func getListResult(rels []*release.Release, next string) listResult {
 		}
 
 		lr := listRelease{
-			Name:       r.GetName(),
-			Revision:   r.GetVersion(),
-			Updated:    t,
-			Status:     r.GetInfo().GetStatus().GetCode().String(),
-			Chart:      fmt.Sprintf("%s-%s", md.GetName(), md.GetVersion()),
-			AppVersion: md.GetAppVersion(),
-			Namespace:  r.GetNamespace(),
+			Name:        r.GetName(),
+			Revision:    r.GetVersion(),
+			Updated:     t,
+			Status:      r.GetInfo().GetStatus().GetCode().String(),
+			Chart:       fmt.Sprintf("%s-%s", md.GetName(), md.GetVersion()),
+			AppVersion:  md.GetAppVersion(),
+			Namespace:   r.GetNamespace(),
+			Environment: r.GetEnvironment(),
 		}
 		listReleases = append(listReleases, lr)
 	}
@@ -308,11 +384,11 @@ func formatResult(format string, short bool, result listResult, colWidth uint) (
 	}
 
 	switch format {
-	case "":
+	case "", "wide":
 		if short {
 			output = formatTextShort(shortResult)
 		} else {
-			output = formatText(result, colWidth)
+			output = formatText(result, colWidth, format == "wide")
 		}
 	case "json":
 		o, e := json.Marshal(finalResult)
@@ -334,7 +410,9 @@ func formatResult(format string, short bool, result listResult, colWidth uint) (
 	return output, err
 }
 
-func formatText(result listResult, colWidth uint) string {
+// formatText formats the releases as a table. If wide is set, it includes their
+// environment.
+func formatText(result listResult, colWidth uint, wide bool) string {
 	nextOutput := ""
 	if result.Next != "" {
 		nextOutput = fmt.Sprintf("\tnext: %s\n", result.Next)
@@ -342,9 +420,17 @@ func formatText(result listResult, colWidth uint) string {
 
 	table := uitable.New()
 	table.MaxColWidth = colWidth
-	table.AddRow("NAME", "REVISION", "UPDATED", "STATUS", "CHART", "APP VERSION", "NAMESPACE")
+	if wide {
+		table.AddRow("NAME", "REVISION", "UPDATED", "STATUS", "CHART", "APP VERSION", "NAMESPACE", "ENVIRONMENT")
+	} else {
+		table.AddRow("NAME", "REVISION", "UPDATED", "STATUS", "CHART", "APP VERSION", "NAMESPACE")
+	}
 	for _, lr := range result.Releases {
-		table.AddRow(lr.Name, lr.Revision, lr.Updated, lr.Status, lr.Chart, lr.AppVersion, lr.Namespace)
+		if wide {
+			table.AddRow(lr.Name, lr.Revision, lr.Updated, lr.Status, lr.Chart, lr.AppVersion, lr.Namespace, lr.Environment)
+		} else {
+			table.AddRow(lr.Name, lr.Revision, lr.Updated, lr.Status, lr.Chart, lr.AppVersion, lr.Namespace)
+		}
 	}
 
 	return fmt.Sprintf("%s%s", nextOutput, table.String())
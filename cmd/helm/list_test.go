@@ -20,6 +20,7 @@ import (
 	"io"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -195,6 +196,58 @@ Releases: []
 			// See note on previous test.
 			expected: "thomas-guide",
 		},
+		{
+			name:  "environment defined",
+			flags: []string{"-q", "--environment", "values-prod.yaml"},
+			rels: []*release.Release{
+				helm.ReleaseMock(&helm.MockReleaseOptions{Name: "thomas-guide", Environment: "values-prod.yaml"}),
+				helm.ReleaseMock(&helm.MockReleaseOptions{Name: "atlas-guide", Environment: "values-staging.yaml"}),
+				helm.ReleaseMock(&helm.MockReleaseOptions{Name: "crazy-maps"}),
+			},
+			expected: "^thomas-guide\n$",
+		},
+		{
+			name:  "chart and app version defined",
+			flags: []string{"-q", "--chart", "foo", "--app-version", "1.0"},
+			rels: []*release.Release{
+				helm.ReleaseMock(&helm.MockReleaseOptions{Name: "thomas-guide", Chart: &chart.Chart{Metadata: &chart.Metadata{Name: "foo", AppVersion: "1.0"}}}),
+				helm.ReleaseMock(&helm.MockReleaseOptions{Name: "atlas-guide", Chart: &chart.Chart{Metadata: &chart.Metadata{Name: "foo", AppVersion: "2.0"}}}),
+				helm.ReleaseMock(&helm.MockReleaseOptions{Name: "crazy-maps"}),
+			},
+			expected: "^thomas-guide\n$",
+		},
+		{
+			name:  "deployed after defined",
+			flags: []string{"-q", "--deployed-after", "1977-09-02T22:04:04Z"},
+			rels: []*release.Release{
+				helm.ReleaseMock(&helm.MockReleaseOptions{Name: "thomas-guide"}),
+			},
+			expected: "^thomas-guide\n$",
+		},
+		{
+			name:  "deployed after the releases",
+			flags: []string{"-q", "--deployed-after", "1977-09-02T22:04:06Z"},
+			rels: []*release.Release{
+				helm.ReleaseMock(&helm.MockReleaseOptions{Name: "thomas-guide"}),
+			},
+			expected: "^\n$",
+		},
+		{
+			name:  "invalid deployed after",
+			flags: []string{"--deployed-after", "yesterday"},
+			rels: []*release.Release{
+				helm.ReleaseMock(&helm.MockReleaseOptions{Name: "thomas-guide"}),
+			},
+			err: true,
+		},
+		{
+			name:  "with wide output",
+			flags: []string{"--output", "wide"},
+			rels: []*release.Release{
+				helm.ReleaseMock(&helm.MockReleaseOptions{Name: "thomas-guide", Environment: "values-prod.yaml"}),
+			},
+			expected: `NAMESPACE\s+ENVIRONMENT\s*\nthomas-guide\s.*default\s+values-prod.yaml`,
+		},
 		{
 			name:  "with a pending release, multiple flags",
 			flags: []string{"--all", "-q"},
@@ -215,6 +268,36 @@ Releases: []
 			},
 			expected: "thomas-guide\nwild-idea\ncrazy-maps",
 		},
+		{
+			name:  "with offset",
+			flags: []string{"-q", "--max", "1", "--offset", "atlas-guide"},
+			rels: []*release.Release{
+				helm.ReleaseMock(&helm.MockReleaseOptions{Name: "thomas-guide"}),
+				helm.ReleaseMock(&helm.MockReleaseOptions{Name: "atlas-guide"}),
+				helm.ReleaseMock(&helm.MockReleaseOptions{Name: "crazy-maps"}),
+			},
+			expected: "^atlas-guide\n$",
+		},
+		{
+			name:  "all releases in pages",
+			flags: []string{"-q", "--max", "0", "--page-size", "1"},
+			rels: []*release.Release{
+				helm.ReleaseMock(&helm.MockReleaseOptions{Name: "thomas-guide"}),
+				helm.ReleaseMock(&helm.MockReleaseOptions{Name: "atlas-guide"}),
+				helm.ReleaseMock(&helm.MockReleaseOptions{Name: "crazy-maps"}),
+			},
+			expected: "^thomas-guide\natlas-guide\ncrazy-maps\n$",
+		},
+		{
+			name:  "next of a page",
+			flags: []string{"--max", "2", "--page-size", "1"},
+			rels: []*release.Release{
+				helm.ReleaseMock(&helm.MockReleaseOptions{Name: "thomas-guide"}),
+				helm.ReleaseMock(&helm.MockReleaseOptions{Name: "atlas-guide"}),
+				helm.ReleaseMock(&helm.MockReleaseOptions{Name: "crazy-maps"}),
+			},
+			expected: "^\tnext: crazy-maps\nNAME.*\nthomas-guide.*\natlas-guide.*\n$",
+		},
 		{
 			name: "with old releases",
 			rels: []*release.Release{
@@ -229,3 +312,28 @@ Releases: []
 		return newListCmd(c, out)
 	})
 }
+
+func TestParseDeployedAfter(t *testing.T) {
+	now := time.Date(2019, 10, 31, 10, 0, 0, 0, time.UTC)
+	for _, tt := range []struct {
+		in     string
+		expect time.Time
+	}{
+		{"2019-10-30T08:00:00Z", time.Date(2019, 10, 30, 8, 0, 0, 0, time.UTC)},
+		{"24h", time.Date(2019, 10, 30, 10, 0, 0, 0, time.UTC)},
+		{"90m", time.Date(2019, 10, 31, 8, 30, 0, 0, time.UTC)},
+	} {
+		got, err := parseDeployedAfter(tt.in, now)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", tt.in, err)
+			continue
+		}
+		if !got.Equal(tt.expect) {
+			t.Errorf("%s: expected %s, got %s", tt.in, tt.expect, got)
+		}
+	}
+
+	if _, err := parseDeployedAfter("yesterday", now); err == nil {
+		t.Error("expected an error for an invalid time")
+	}
+}
@@ -0,0 +1,125 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"errors"
+	"net"
+	"os"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+
+	"k8s.io/helm/pkg/engine"
+	"k8s.io/helm/pkg/kube"
+	"k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/storage"
+	"k8s.io/helm/pkg/storage/driver"
+	"k8s.io/helm/pkg/storage/lock"
+	"k8s.io/helm/pkg/tiller"
+	"k8s.io/helm/pkg/tiller/environment"
+)
+
+const noTillerUsage = "Experimental: render and apply the release from the helm client, storing the release records as Secrets in the namespace of the release, without Tiller"
+
+const (
+	// localTillerHost is the address of the local release server. It is only
+	// a name: the connections are made in memory by localTillerListener.
+	localTillerHost = "local-tiller"
+	// localTillerBufferSize is the size of the in-memory connection buffers.
+	localTillerBufferSize = 1024 * 1024
+)
+
+var (
+	// localTiller is the release server started in the helm process by
+	// --no-tiller.
+	localTiller *grpc.Server
+	// localTillerListener accepts the in-memory connections of the helm
+	// client to localTiller, so that no other process can connect to it.
+	localTillerListener *bufconn.Listener
+)
+
+// setupLocalTiller starts a release server in the helm process and points the
+// helm client to it. The server renders the charts and applies the manifests
+// with the credentials of the kubeconfig, and stores the release records as
+// Secrets and their locks as Leases in namespace, so that no Tiller has to run
+// in the cluster.
+func setupLocalTiller(namespace string) error {
+	if settings.TLSEnable || settings.TLSVerify {
+		return errors.New("--no-tiller cannot be used with --tls or --tls-verify")
+	}
+	_, client, err := getKubeClient(settings.KubeContext, settings.KubeConfig)
+	if err != nil {
+		return err
+	}
+
+	secrets := driver.NewSecrets(client.CoreV1().Secrets(namespace))
+	secrets.Log = debug
+	env := environment.New()
+	env.Releases = storage.Init(secrets)
+	env.Releases.Log = debug
+	holder, err := os.Hostname()
+	if err != nil {
+		return err
+	}
+	leases := lock.NewLeases(client.CoordinationV1().Leases(namespace), "helm/"+holder)
+	leases.Log = debug
+	env.Locks = leases
+	kubeClient := kube.New(kubeClientGetter())
+	kubeClient.Log = debug
+	env.KubeClient = kubeClient
+	if e, ok := env.EngineYard[environment.GoTplEngine].(*engine.Engine); ok {
+		e.Lookup = kubeClient.Lookup
+	}
+
+	healthSrv := health.NewServer()
+	healthSrv.SetServingStatus("Tiller", healthpb.HealthCheckResponse_SERVING)
+	srv := tiller.NewServer()
+	healthpb.RegisterHealthServer(srv, healthSrv)
+	svc := tiller.NewReleaseServer(env, client, false)
+	svc.Log = debug
+	services.RegisterReleaseServiceServer(srv, svc)
+
+	lstn := bufconn.Listen(localTillerBufferSize)
+	go srv.Serve(lstn)
+	localTiller = srv
+	localTillerListener = lstn
+
+	settings.TillerHost = localTillerHost
+	debug("Started a local release server, storing the releases in namespace %q", namespace)
+	return nil
+}
+
+// dialLocalTiller connects to the local release server.
+func dialLocalTiller(string, time.Duration) (net.Conn, error) {
+	return localTillerListener.Dial()
+}
+
+// setupReleaseConnection connects the helm client to Tiller, or to a local
+// release server storing the releases in namespace if noTiller is set.
+func setupReleaseConnection(noTiller bool, namespace string) error {
+	if !noTiller {
+		return setupConnection()
+	}
+	if namespace == "" {
+		namespace = defaultNamespace()
+	}
+	return setupLocalTiller(namespace)
+}
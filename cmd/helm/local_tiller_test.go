@@ -0,0 +1,33 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"testing"
+)
+
+func TestSetupLocalTillerWithTLS(t *testing.T) {
+	defer func(enable bool) { settings.TLSEnable = enable }(settings.TLSEnable)
+	settings.TLSEnable = true
+
+	if err := setupReleaseConnection(true, "default"); err == nil {
+		t.Fatal("Expected --no-tiller with --tls to fail")
+	}
+	if localTiller != nil || localTillerListener != nil {
+		t.Error("Expected no local release server to be started")
+	}
+}
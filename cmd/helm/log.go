@@ -0,0 +1,118 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"time"
+)
+
+// Formats of the messages written to stderr, selected with --log-format.
+const (
+	logFormatText = "text"
+	logFormatJSON = "json"
+)
+
+// Levels of the log events.
+const (
+	levelDebug   = "debug"
+	levelInfo    = "info"
+	levelWarning = "warning"
+)
+
+// logEvent is a message written to stderr with --log-format json, one event
+// per line.
+type logEvent struct {
+	Time    string `json:"time"`
+	Level   string `json:"level"`
+	Message string `json:"msg"`
+}
+
+// logOut is where log messages are written. It is replaced in tests.
+var logOut io.Writer = os.Stderr
+
+// initLogging validates the log format and routes the messages of the
+// standard logger, such as the warnings emitted when loading charts.
+func initLogging() error {
+	switch settings.LogFormat {
+	case logFormatText:
+	case logFormatJSON:
+		log.SetFlags(0)
+		log.SetOutput(eventWriter{})
+	default:
+		return fmt.Errorf("unknown log format %q, must be %s or %s", settings.LogFormat, logFormatText, logFormatJSON)
+	}
+	return nil
+}
+
+func jsonLogging() bool {
+	return settings.LogFormat == logFormatJSON
+}
+
+func writeEvent(level, msg string) {
+	e := logEvent{
+		Time:    time.Now().UTC().Format(time.RFC3339),
+		Level:   level,
+		Message: strings.TrimSpace(msg),
+	}
+	b, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	fmt.Fprintln(logOut, string(b))
+}
+
+// info writes a progress message to stderr.
+func info(format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	if jsonLogging() {
+		writeEvent(levelInfo, msg)
+		return
+	}
+	fmt.Fprintln(logOut, msg)
+}
+
+// warning writes a warning to stderr.
+func warning(format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	if jsonLogging() {
+		writeEvent(levelWarning, msg)
+		return
+	}
+	fmt.Fprintf(logOut, "WARNING: %s\n", msg)
+}
+
+// eventWriter turns the lines written by the standard logger into events.
+// Lines starting with "Warning:" are warnings.
+type eventWriter struct{}
+
+func (eventWriter) Write(p []byte) (int, error) {
+	for _, line := range strings.Split(strings.TrimRight(string(p), "\n"), "\n") {
+		level := levelInfo
+		if strings.HasPrefix(strings.ToLower(line), "warning:") {
+			level = levelWarning
+			line = line[len("warning:"):]
+		}
+		writeEvent(level, line)
+	}
+	return len(p), nil
+}
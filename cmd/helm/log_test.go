@@ -0,0 +1,93 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"log"
+	"os"
+	"testing"
+)
+
+func TestLogFormatJSON(t *testing.T) {
+	defer func(format string) {
+		settings.LogFormat = format
+		logOut = os.Stderr
+		log.SetFlags(log.LstdFlags)
+		log.SetOutput(os.Stderr)
+	}(settings.LogFormat)
+
+	var buf bytes.Buffer
+	logOut = &buf
+	settings.LogFormat = logFormatJSON
+	if err := initLogging(); err != nil {
+		t.Fatal(err)
+	}
+
+	info("Running %s cmd", "template")
+	warning("Namespace %q doesn't match", "foo")
+	log.Printf("Warning: Condition path 'a.enabled' for chart b returned non-bool value")
+
+	expect := []logEvent{
+		{Level: levelInfo, Message: "Running template cmd"},
+		{Level: levelWarning, Message: `Namespace "foo" doesn't match`},
+		{Level: levelWarning, Message: "Condition path 'a.enabled' for chart b returned non-bool value"},
+	}
+	scanner := bufio.NewScanner(&buf)
+	for i := 0; scanner.Scan(); i++ {
+		if i >= len(expect) {
+			t.Fatalf("unexpected event %s", scanner.Text())
+		}
+		var e logEvent
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			t.Fatalf("event %d is not JSON: %s", i, err)
+		}
+		if e.Time == "" {
+			t.Errorf("expected event %d to have a time", i)
+		}
+		if e.Level != expect[i].Level || e.Message != expect[i].Message {
+			t.Errorf("expected %s %q, got %s %q", expect[i].Level, expect[i].Message, e.Level, e.Message)
+		}
+	}
+}
+
+func TestLogFormatText(t *testing.T) {
+	defer func(format string) {
+		settings.LogFormat = format
+		logOut = os.Stderr
+	}(settings.LogFormat)
+
+	var buf bytes.Buffer
+	logOut = &buf
+	settings.LogFormat = logFormatText
+	if err := initLogging(); err != nil {
+		t.Fatal(err)
+	}
+
+	info("Running template cmd")
+	warning("no requirements")
+	if expect := "Running template cmd\nWARNING: no requirements\n"; buf.String() != expect {
+		t.Errorf("expected %q, got %q", expect, buf.String())
+	}
+
+	settings.LogFormat = "xml"
+	if err := initLogging(); err == nil {
+		t.Error("expected error for unknown log format")
+	}
+}
@@ -0,0 +1,79 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"regexp"
+	"strings"
+)
+
+// The ANSI escape codes of the Markdown rendered for the terminal.
+const (
+	ansiReset     = "\x1b[0m"
+	ansiBold      = "\x1b[1m"
+	ansiUnderline = "\x1b[4m"
+	ansiCyan      = "\x1b[36m"
+)
+
+var (
+	markdownHeading = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	markdownBullet  = regexp.MustCompile(`^(\s*)[-*+]\s+(.*)$`)
+	markdownCode    = regexp.MustCompile("`([^`]+)`")
+	markdownStrong  = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+	markdownLink    = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+)
+
+// renderMarkdown renders the Markdown md for the terminal. It is no Markdown
+// parser: it highlights the headings, the code blocks, the inline code, the
+// strong emphasis and the links, turns the list markers into bullets, and
+// leaves the rest as it is.
+func renderMarkdown(md string) string {
+	var b strings.Builder
+	code := false
+	for _, line := range strings.Split(strings.TrimRight(md, "\n"), "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			code = !code
+			continue
+		}
+		switch {
+		case code:
+			b.WriteString("    " + ansiCyan + line + ansiReset)
+		case markdownHeading.MatchString(line):
+			m := markdownHeading.FindStringSubmatch(line)
+			style := ansiBold
+			if len(m[1]) == 1 {
+				style += ansiUnderline
+			}
+			b.WriteString(style + m[2] + ansiReset)
+		case markdownBullet.MatchString(line):
+			m := markdownBullet.FindStringSubmatch(line)
+			b.WriteString(m[1] + "• " + renderMarkdownInline(m[2]))
+		default:
+			b.WriteString(renderMarkdownInline(line))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// renderMarkdownInline renders the inline code, the strong emphasis and the
+// links of the Markdown line.
+func renderMarkdownInline(line string) string {
+	line = markdownCode.ReplaceAllString(line, ansiCyan+"$1"+ansiReset)
+	line = markdownStrong.ReplaceAllString(line, ansiBold+"$1$2"+ansiReset)
+	return markdownLink.ReplaceAllString(line, ansiUnderline+"$1"+ansiReset+" ($2)")
+}
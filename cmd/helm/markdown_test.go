@@ -0,0 +1,56 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	md := "# Mychart\n\n## Installing\n\nRun **helm install** with `--wait`:\n\n```console\n$ helm install mychart\n```\n\n- see [the docs](https://helm.sh)\n  * nested\n"
+	expect := ansiBold + ansiUnderline + "Mychart" + ansiReset + "\n" +
+		"\n" +
+		ansiBold + "Installing" + ansiReset + "\n" +
+		"\n" +
+		"Run " + ansiBold + "helm install" + ansiReset + " with " + ansiCyan + "--wait" + ansiReset + ":\n" +
+		"\n" +
+		"    " + ansiCyan + "$ helm install mychart" + ansiReset + "\n" +
+		"\n" +
+		"• see " + ansiUnderline + "the docs" + ansiReset + " (https://helm.sh)\n" +
+		"  • nested\n"
+	if got := renderMarkdown(md); got != expect {
+		t.Errorf("Expected\n%q\ngot\n%q", expect, got)
+	}
+}
+
+func TestInspectReadmeRender(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	insp := &inspectCmd{
+		chartpath: "testdata/testcharts/alpine",
+		output:    readmeOnly,
+		render:    true,
+		out:       b,
+	}
+	if err := insp.run(); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(b.String(), "generated using the command "+ansiCyan+"helm create alpine"+ansiReset) {
+		t.Errorf("Expected the README to be rendered, got\n%s", b.String())
+	}
+}
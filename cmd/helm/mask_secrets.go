@@ -0,0 +1,139 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// documentSeparatorRegex matches the separators of the documents of a
+// manifest.
+var documentSeparatorRegex = regexp.MustCompile(`(?m)^---[ \t]*$`)
+
+// secretKindRegex matches the kind of a secret, to recognize the secrets
+// which cannot be parsed.
+var secretKindRegex = regexp.MustCompile(`(?m)^kind:[ \t]*["']?Secret["']?[ \t]*$`)
+
+// maskedSecretManifest replaces the manifest of a secret which cannot be
+// parsed, so that its values cannot leak.
+const maskedSecretManifest = "# the secret cannot be parsed, so its manifest is masked"
+
+// maskManifestSecrets returns the manifest with the values of the data and
+// stringData of its secrets replaced by a placeholder with the digest of
+// their content, like "REDACTED sha256:2bb80d537b1da3e3". The values of data
+// are decoded before they are digested, so that a value has the same digest
+// in data and in stringData. The other resources, the separators and the
+// comments between the documents are left alone.
+func maskManifestSecrets(manifest string) string {
+	var b strings.Builder
+	start := 0
+	for _, sep := range documentSeparatorRegex.FindAllStringIndex(manifest, -1) {
+		b.WriteString(maskDocumentSecret(manifest[start:sep[0]]))
+		b.WriteString(manifest[sep[0]:sep[1]])
+		start = sep[1]
+	}
+	b.WriteString(maskDocumentSecret(manifest[start:]))
+	return b.String()
+}
+
+// maskDocumentSecret masks the values of the document doc of a manifest if it
+// is a secret. The blank lines around the document are kept.
+func maskDocumentSecret(doc string) string {
+	if !strings.Contains(doc, "Secret") {
+		return doc
+	}
+	body := strings.TrimSpace(doc)
+	prefix := doc[:strings.Index(doc, body)]
+	suffix := doc[len(prefix)+len(body):]
+
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(body), &node); err != nil {
+		if secretKindRegex.MatchString(body) {
+			return prefix + maskedSecretManifest + suffix
+		}
+		return doc
+	}
+	if node.Kind != yaml.DocumentNode || len(node.Content) == 0 {
+		return doc
+	}
+	resource := node.Content[0]
+	if resource.Kind != yaml.MappingNode || mappingValue(resource, "kind") == nil || mappingValue(resource, "kind").Value != "Secret" {
+		return doc
+	}
+
+	masked := false
+	for _, section := range []string{"data", "stringData"} {
+		table := mappingValue(resource, section)
+		if table == nil || table.Kind != yaml.MappingNode {
+			continue
+		}
+		for i := 1; i < len(table.Content); i += 2 {
+			maskSecretValue(table.Content[i], section == "data")
+			masked = true
+		}
+	}
+	if !masked {
+		return doc
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&node); err != nil {
+		return prefix + maskedSecretManifest + suffix
+	}
+	if err := enc.Close(); err != nil {
+		return prefix + maskedSecretManifest + suffix
+	}
+	return prefix + strings.TrimSpace(buf.String()) + suffix
+}
+
+// maskSecretValue replaces the value n of a secret by its placeholder. The
+// value is decoded from base64 first if encoded is true.
+func maskSecretValue(n *yaml.Node, encoded bool) {
+	value := []byte(n.Value)
+	if encoded {
+		if decoded, err := base64.StdEncoding.DecodeString(n.Value); err == nil {
+			value = decoded
+		}
+	}
+	sum := sha256.Sum256(value)
+	*n = yaml.Node{
+		Kind:        yaml.ScalarNode,
+		Tag:         "!!str",
+		Value:       "REDACTED sha256:" + hex.EncodeToString(sum[:8]),
+		LineComment: n.LineComment,
+	}
+}
+
+// mappingValue returns the value of the key of the mapping node m, or nil if
+// it has no such key.
+func mappingValue(m *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	return nil
+}
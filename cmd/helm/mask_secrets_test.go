@@ -0,0 +1,72 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMaskManifestSecrets(t *testing.T) {
+	manifest := `# Source: chart/templates/secret.yaml
+apiVersion: v1
+kind: Secret
+metadata:
+  name: db # the database credentials
+data:
+  password: c2VjcmV0
+stringData:
+  token: secret
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: db
+data:
+  password: c2VjcmV0
+`
+	expect := `# Source: chart/templates/secret.yaml
+apiVersion: v1
+kind: Secret
+metadata:
+  name: db # the database credentials
+data:
+  password: REDACTED sha256:2bb80d537b1da3e3
+stringData:
+  token: REDACTED sha256:2bb80d537b1da3e3
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: db
+data:
+  password: c2VjcmV0
+`
+	if masked := maskManifestSecrets(manifest); masked != expect {
+		t.Errorf("Expected\n%s\ngot\n%s", expect, masked)
+	}
+
+	unparsable := "apiVersion: v1\nkind: Secret\ndata:\n  password: c2VjcmV0\n  - oops\n"
+	if masked := maskManifestSecrets(unparsable); strings.Contains(masked, "c2VjcmV0") {
+		t.Errorf("Expected the secret which cannot be parsed to be masked, got\n%s", masked)
+	}
+
+	empty := "apiVersion: v1\nkind: Secret\nmetadata:\n  name: fixture\n"
+	if masked := maskManifestSecrets(empty); masked != empty {
+		t.Errorf("Expected a secret without values to be left alone, got\n%s", masked)
+	}
+}
@@ -0,0 +1,37 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"k8s.io/helm/pkg/chartutil"
+)
+
+// subchartNotes returns whether the notes of the subcharts of the chart at
+// chartPath are rendered: with subNotes, or when the environment values file
+// env of the chart has the subchart-notes directive.
+func subchartNotes(chartPath, env string, subNotes bool, opts ...chartutil.LoadOption) (bool, error) {
+	if subNotes || env == "" {
+		return subNotes, nil
+	}
+	// The environment values files are files of the chart when the chart is
+	// loaded without environment.
+	c, err := chartutil.Load(chartPath, opts...)
+	if err != nil {
+		return false, err
+	}
+	return chartutil.EnvironmentSubchartNotes(c, env), nil
+}
@@ -0,0 +1,98 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/strvals"
+)
+
+// checkNullDeletes logs the default values of the chart deleted by null
+// values of config, or fails instead if strict is set. The null values of the
+// -f files and of --set are attributed to them.
+func checkNullDeletes(c *chart.Chart, config *chart.Config, valueFiles, values []string, logDeletes, strict bool) error {
+	if !logDeletes && !strict {
+		return nil
+	}
+
+	// The requirements are processed on a copy, as the chart is processed
+	// again when it is rendered.
+	c = proto.Clone(c).(*chart.Chart)
+	if err := chartutil.ProcessRequirementsEnabled(c, config); err != nil {
+		return err
+	}
+	if err := chartutil.ProcessRequirementsImportValues(c); err != nil {
+		return err
+	}
+	deletes, err := chartutil.NullDeletes(c, config)
+	if err != nil {
+		return err
+	}
+
+	var msgs []string
+	for _, d := range deletes {
+		origin := d.Origin
+		if origin == "" {
+			origin = suppliedNullOrigin(d.Path, valueFiles, values)
+		}
+		msg := fmt.Sprintf("%s: the default value of chart %s is deleted by a null value in %s", d.Path, d.Chart, origin)
+		if !strict {
+			warning("%s", msg)
+		}
+		msgs = append(msgs, msg)
+	}
+	if strict && len(msgs) > 0 {
+		return fmt.Errorf("default values are deleted by null values:\n%s", strings.Join(msgs, "\n"))
+	}
+	return nil
+}
+
+// suppliedNullOrigin returns the --set value or the -f file setting the value
+// at path p to null, in the order of precedence of the values.
+func suppliedNullOrigin(p string, valueFiles, values []string) string {
+	for i := len(values) - 1; i >= 0; i-- {
+		v, err := strvals.Parse(values[i])
+		if err != nil {
+			continue
+		}
+		if isNullAt(v, p) {
+			return fmt.Sprintf("--set %s", values[i])
+		}
+	}
+	for i := len(valueFiles) - 1; i >= 0; i-- {
+		// Only local files are read again.
+		v, err := chartutil.ReadValuesFile(valueFiles[i])
+		if err != nil {
+			continue
+		}
+		if isNullAt(v, p) {
+			return valueFiles[i]
+		}
+	}
+	return "the supplied values"
+}
+
+func isNullAt(v map[string]interface{}, p string) bool {
+	val, ok := chartutil.Flatten(v)[p]
+	return ok && val == nil
+}
@@ -22,13 +22,16 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strings"
 	"syscall"
 
 	"github.com/Masterminds/semver"
 	"github.com/spf13/cobra"
 	"golang.org/x/crypto/ssh/terminal"
 
+	"k8s.io/helm/pkg/chartedit"
 	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/downloader"
 	"k8s.io/helm/pkg/getter"
@@ -48,6 +51,34 @@ If no path is given, this will look in the present working directory for a
 Chart.yaml file, and (if found) build the current directory into a chart.
 
 Versioned chart archives are used by Helm package repositories.
+
+With '--show-ignored', the files of the chart directory which are not
+packaged are listed with the reason why, such as the .helmignore pattern
+which matches them, followed by the files of the chart archive and their
+size.
+
+With '--attest', the provenance file of a signed package also contains an
+in-toto attestation of the SLSA provenance of the package: the builder which
+packaged it, and the source repository and the commit it was packaged from.
+'helm verify --attestation-policy' checks them:
+
+	$ helm package --sign --key ci --attest --builder-id https://ci.example.com \
+	    --source-repo git+https://github.com/example/charts --source-commit $GIT_SHA mychart
+
+The version of the package can be bumped from the version of Chart.yaml with
+'--version-bump patch', 'minor' or 'major', and the short SHA of the git commit
+of the chart directory can be added to it as build metadata with '--git-sha',
+like 1.3.0+4f3a2b1. The Chart.yaml of the package has the version and the
+appVersion set by '--version', '--version-bump', '--git-sha' and
+'--app-version', but the chart directory is left alone unless '--write' is
+set, which writes them to its Chart.yaml, without the build metadata, keeping
+its comments:
+
+	$ helm package --version-bump minor --app-version 2.1.0 --git-sha --write mychart
+
+With '--policy', the chart is only packaged if its Chart.yaml, with the version
+and the appVersion set by the flags above, meets the metadata policy of the
+file. See 'helm lint --help' for the format of the policy.
 `
 
 type packageCmd struct {
@@ -57,9 +88,20 @@ type packageCmd struct {
 	key              string
 	keyring          string
 	version          string
+	versionBump      string
+	gitSHA           bool
 	appVersion       string
+	write            bool
 	destination      string
 	dependencyUpdate bool
+	showIgnored      bool
+	symlinks         symlinkPolicy
+	attest           bool
+	builderID        string
+	sourceRepo       string
+	sourceCommit     string
+	policyFile       string
+	policy           *chartutil.MetadataPolicy
 
 	out  io.Writer
 	home helmpath.Home
@@ -85,6 +127,27 @@ func newPackageCmd(out io.Writer) *cobra.Command {
 					return errors.New("--keyring is required for signing a package")
 				}
 			}
+			if pkg.attest {
+				if !pkg.sign {
+					return errors.New("--sign is required for attesting a package")
+				}
+				if pkg.builderID == "" {
+					return errors.New("--builder-id is required for attesting a package")
+				}
+			}
+			if pkg.version != "" && pkg.versionBump != "" {
+				return errors.New("--version and --version-bump cannot be used together")
+			}
+			if pkg.write && pkg.version == "" && pkg.versionBump == "" && pkg.appVersion == "" {
+				return errors.New("--write requires --version, --version-bump or --app-version")
+			}
+			if pkg.policyFile != "" {
+				policy, err := chartutil.LoadMetadataPolicy(pkg.policyFile)
+				if err != nil {
+					return err
+				}
+				pkg.policy = policy
+			}
 			for i := 0; i < len(args); i++ {
 				pkg.path = args[i]
 				if err := pkg.run(); err != nil {
@@ -101,9 +164,19 @@ func newPackageCmd(out io.Writer) *cobra.Command {
 	f.StringVar(&pkg.key, "key", "", "Name of the key to use when signing. Used if --sign is true")
 	f.StringVar(&pkg.keyring, "keyring", defaultKeyring(), "Location of a public keyring")
 	f.StringVar(&pkg.version, "version", "", "Set the version on the chart to this semver version")
+	f.StringVar(&pkg.versionBump, "version-bump", "", "Bump the version of the chart: patch, minor or major")
+	f.BoolVar(&pkg.gitSHA, "git-sha", false, "Add the short SHA of the git commit of the chart directory to the version as build metadata")
 	f.StringVar(&pkg.appVersion, "app-version", "", "Set the appVersion on the chart to this version")
+	f.BoolVar(&pkg.write, "write", false, "Write the version and the appVersion of the package to the Chart.yaml of the chart directory")
 	f.StringVarP(&pkg.destination, "destination", "d", ".", "Location to write the chart.")
 	f.BoolVarP(&pkg.dependencyUpdate, "dependency-update", "u", false, `Update dependencies from "requirements.yaml" to dir "charts/" before packaging`)
+	f.BoolVar(&pkg.attest, "attest", false, "Add an attestation of the build provenance of the package to its provenance file. Requires --sign")
+	f.StringVar(&pkg.builderID, "builder-id", "", "ID of the builder of the package in its attestation, like the URI of the CI pipeline")
+	f.StringVar(&pkg.sourceRepo, "source-repo", "", "URI of the source repository of the chart in the attestation of the package")
+	f.StringVar(&pkg.sourceCommit, "source-commit", "", "Commit of the source repository the package is built from in its attestation")
+	f.StringVar(&pkg.policyFile, "policy", "", "Only package the chart if its Chart.yaml meets the metadata policy of this file")
+	f.BoolVar(&pkg.showIgnored, "show-ignored", false, "List the files which are not packaged and why, and the files of the chart archive with their size")
+	addSymlinksFlag(f, &pkg.symlinks)
 
 	return cmd
 }
@@ -122,6 +195,7 @@ func (p *packageCmd) run() error {
 			Keyring:   p.keyring,
 			Getters:   getter.All(settings),
 			Debug:     settings.Debug,
+			Symlinks:  chartutil.SymlinkPolicy(p.symlinks),
 		}
 
 		if err := downloadManager.Update(); err != nil {
@@ -129,7 +203,11 @@ func (p *packageCmd) run() error {
 		}
 	}
 
-	ch, err := chartutil.LoadDir(path)
+	var ignored []ignoredFile
+	report := chartutil.ReportIgnored(func(name, reason string) {
+		ignored = append(ignored, ignoredFile{name: name, reason: reason})
+	})
+	ch, err := chartutil.LoadDir(path, report, p.symlinks.loadOption())
 	if err != nil {
 		return err
 	}
@@ -142,11 +220,41 @@ func (p *packageCmd) run() error {
 		debug("Setting version to %s", p.version)
 	}
 
+	if p.versionBump != "" {
+		version, err := chartedit.NextVersion(ch.Metadata.Version, chartedit.BumpStrategy(p.versionBump))
+		if err != nil {
+			return err
+		}
+		ch.Metadata.Version = version
+		debug("Bumping version to %s", version)
+	}
+
+	// The version written to the chart directory, without build metadata.
+	sourceVersion := ch.Metadata.Version
+	if p.gitSHA {
+		sha, err := gitShortSHA(path)
+		if err != nil {
+			return err
+		}
+		version, err := chartedit.WithBuildMetadata(ch.Metadata.Version, sha)
+		if err != nil {
+			return err
+		}
+		ch.Metadata.Version = version
+		debug("Setting version to %s", version)
+	}
+
 	if p.appVersion != "" {
 		ch.Metadata.AppVersion = p.appVersion
 		debug("Setting appVersion to %s", p.appVersion)
 	}
 
+	if p.policy != nil {
+		if err := p.policy.Validate(ch.Metadata); err != nil {
+			return withExitCode(exitCodeValidationFailure, err)
+		}
+	}
+
 	if filepath.Base(path) != ch.Metadata.Name {
 		return fmt.Errorf("directory name (%s) and Chart.yaml name (%s) must match", filepath.Base(path), ch.Metadata.Name)
 	}
@@ -180,6 +288,20 @@ func (p *packageCmd) run() error {
 		return fmt.Errorf("Failed to save: %s", err)
 	}
 
+	if p.write {
+		if err := p.writeChartfile(path, sourceVersion); err != nil {
+			return err
+		}
+	}
+
+	if p.showIgnored {
+		entries, err := readArchiveManifest(name)
+		if err != nil {
+			return err
+		}
+		printPackageReport(p.out, ignored, entries)
+	}
+
 	// Save to $HELM_HOME/local directory. This is second, because we don't want
 	// the case where we saved here, but didn't save to the default destination.
 	if p.save {
@@ -208,6 +330,45 @@ func setVersion(ch *chart.Chart, ver string) error {
 	return nil
 }
 
+// writeChartfile writes the version, if it was set or bumped, and the
+// appVersion, if it was set, to the Chart.yaml of the chart directory path.
+func (p *packageCmd) writeChartfile(path, version string) error {
+	c, err := chartedit.Open(path)
+	if err != nil {
+		return err
+	}
+	if p.version != "" || p.versionBump != "" {
+		if err := c.SetVersion(version); err != nil {
+			return err
+		}
+	}
+	if p.appVersion != "" {
+		if err := c.SetAppVersion(p.appVersion); err != nil {
+			return err
+		}
+	}
+	if err := c.Save(); err != nil {
+		return err
+	}
+	fmt.Fprintf(p.out, "Updated %s\n", filepath.Join(path, chartutil.ChartfileName))
+	return nil
+}
+
+// gitShortSHA returns the short SHA of the HEAD commit of the git repository
+// of the directory dir.
+func gitShortSHA(dir string) (string, error) {
+	cmd := exec.Command("git", "-C", dir, "rev-parse", "--short", "HEAD")
+	out, err := cmd.Output()
+	if err != nil {
+		msg := err.Error()
+		if ee, ok := err.(*exec.ExitError); ok && len(ee.Stderr) > 0 {
+			msg = strings.TrimSpace(string(ee.Stderr))
+		}
+		return "", fmt.Errorf("cannot get the git commit of %s: %s", dir, msg)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
 func (p *packageCmd) clearsign(filename string) error {
 	// Load keyring
 	signer, err := provenance.NewFromKeyring(p.keyring, p.key)
@@ -219,7 +380,19 @@ func (p *packageCmd) clearsign(filename string) error {
 		return err
 	}
 
-	sig, err := signer.ClearSign(filename)
+	var att *provenance.Attestation
+	if p.attest {
+		att, err = provenance.NewAttestation(filename, provenance.BuildInfo{
+			BuilderID:    p.builderID,
+			SourceRepo:   p.sourceRepo,
+			SourceCommit: p.sourceCommit,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	sig, err := signer.ClearSignWithAttestation(filename, att)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,95 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/gosuri/uitable"
+)
+
+// ignoredFile is a file of a chart directory which is not packaged.
+type ignoredFile struct {
+	name   string
+	reason string
+}
+
+// archiveEntry is a file of a chart archive.
+type archiveEntry struct {
+	name string
+	size int64
+}
+
+// readArchiveManifest returns the files of the chart archive filename.
+func readArchiveManifest(filename string) ([]archiveEntry, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	var entries []archiveEntry
+	tr := tar.NewReader(zr)
+	for {
+		hd, err := tr.Next()
+		if err == io.EOF {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if hd.Typeflag == tar.TypeReg {
+			entries = append(entries, archiveEntry{name: hd.Name, size: hd.Size})
+		}
+	}
+}
+
+// printPackageReport prints the files of the chart directory which are not
+// packaged, and why, and the files of the chart archive with their size.
+func printPackageReport(out io.Writer, ignored []ignoredFile, entries []archiveEntry) {
+	if len(ignored) == 0 {
+		fmt.Fprintln(out, "No files were ignored.")
+	} else {
+		tbl := uitable.New()
+		tbl.AddRow("IGNORED", "REASON")
+		for _, f := range ignored {
+			tbl.AddRow(f.name, f.reason)
+		}
+		fmt.Fprintln(out, tbl)
+	}
+	fmt.Fprintln(out)
+
+	var total int64
+	tbl := uitable.New()
+	tbl.AddRow("FILE", "SIZE")
+	for _, e := range entries {
+		tbl.AddRow(e.name, e.size)
+		total += e.size
+	}
+	fmt.Fprintln(out, tbl)
+	fmt.Fprintf(out, "%d files, %d bytes\n", len(entries), total)
+}
@@ -0,0 +1,49 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+)
+
+func TestPrintPackageReport(t *testing.T) {
+	var buf bytes.Buffer
+	ignored := []ignoredFile{
+		{name: "docs/", reason: `matches the .helmignore pattern "docs/"`},
+		{name: "templates/scratch.yaml", reason: `matches the .helmignore pattern "scratch.yaml"`},
+	}
+	entries := []archiveEntry{
+		{name: "mychart/Chart.yaml", size: 100},
+		{name: "mychart/templates/service.yaml", size: 250},
+	}
+	printPackageReport(&buf, ignored, entries)
+
+	out := buf.String()
+	for _, expect := range []string{
+		`IGNORED\s+REASON`,
+		`docs/\s+matches the .helmignore pattern "docs/"`,
+		`templates/scratch.yaml\s+matches the .helmignore pattern "scratch.yaml"`,
+		`mychart/templates/service.yaml\s+250`,
+		`2 files, 350 bytes`,
+	} {
+		if !regexp.MustCompile(expect).MatchString(out) {
+			t.Errorf("Expected %q in the report, got\n%s", expect, out)
+		}
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"regexp"
 	"runtime"
@@ -125,6 +126,62 @@ func TestPackage(t *testing.T) {
 			expect:  "",
 			hasfile: "alpine-0.1.0.tgz",
 		},
+		{
+			name:   "package --attest, no --sign",
+			args:   []string{"testdata/testcharts/alpine"},
+			flags:  map[string]string{"attest": "1", "builder-id": "https://ci.example.com"},
+			expect: "--sign is required for attesting a package",
+			err:    true,
+		},
+		{
+			name:   "package --sign --attest, no --builder-id",
+			args:   []string{"testdata/testcharts/alpine"},
+			flags:  map[string]string{"sign": "1", "keyring": "testdata/helm-test-key.secret", "key": "helm-test", "attest": "1"},
+			expect: "--builder-id is required for attesting a package",
+			err:    true,
+		},
+		{
+			name:    "package --sign --attest --builder-id=ID testdata/testcharts/alpine",
+			args:    []string{"testdata/testcharts/alpine"},
+			flags:   map[string]string{"sign": "1", "keyring": "testdata/helm-test-key.secret", "key": "helm-test", "attest": "1", "builder-id": "https://ci.example.com", "source-repo": "git+https://github.com/example/charts", "source-commit": "4f3a2b1c"},
+			expect:  "",
+			hasfile: "alpine-0.1.0.tgz",
+		},
+		{
+			name:    "package --show-ignored testdata/testcharts/alpine",
+			args:    []string{"testdata/testcharts/alpine"},
+			flags:   map[string]string{"show-ignored": "1"},
+			expect:  `(?s)No files were ignored\..*alpine/Chart.yaml.*\d+ files, \d+ bytes`,
+			hasfile: "alpine-0.1.0.tgz",
+		},
+		{
+			name:   "package --version --version-bump",
+			args:   []string{"testdata/testcharts/alpine"},
+			flags:  map[string]string{"version": "1.0.0", "version-bump": "patch"},
+			expect: "--version and --version-bump cannot be used together",
+			err:    true,
+		},
+		{
+			name:   "package --write, no version",
+			args:   []string{"testdata/testcharts/alpine"},
+			flags:  map[string]string{"write": "1"},
+			expect: "--write requires --version, --version-bump or --app-version",
+			err:    true,
+		},
+		{
+			name:    "package --version-bump patch testdata/testcharts/alpine",
+			args:    []string{"testdata/testcharts/alpine"},
+			flags:   map[string]string{"version-bump": "patch"},
+			expect:  "",
+			hasfile: "alpine-0.1.1.tgz",
+		},
+		{
+			name:   "package --policy testdata/testcharts/alpine",
+			args:   []string{"testdata/testcharts/alpine"},
+			flags:  map[string]string{"policy": "testdata/metadata-policy.yaml"},
+			expect: `chart metadata \(Chart.yaml\) violates the policy: the chart must have a maintainer$`,
+			err:    true,
+		},
 		{
 			name:    "package testdata/testcharts/chart-missing-deps",
 			args:    []string{"testdata/testcharts/chart-missing-deps"},
@@ -170,6 +227,9 @@ func TestPackage(t *testing.T) {
 		if v, ok := tt.flags["keyring"]; ok && len(v) > 0 {
 			tt.flags["keyring"] = filepath.Join(origDir, v)
 		}
+		if v, ok := tt.flags["policy"]; ok {
+			tt.flags["policy"] = filepath.Join(origDir, v)
+		}
 
 		setFlags(c, tt.flags)
 		re := regexp.MustCompile(tt.expect)
@@ -254,6 +314,80 @@ func TestSetAppVersion(t *testing.T) {
 	}
 }
 
+func TestPackageVersionBumpWrite(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	tmp, err := ioutil.TempDir("", "helm-package-version-bump-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	thome, err := tempHelmHome(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cleanup := resetEnv()
+	defer func() {
+		os.RemoveAll(tmp)
+		os.RemoveAll(thome.String())
+		cleanup()
+	}()
+	settings.Home = helmpath.Home(thome)
+
+	chartDir := filepath.Join(tmp, "mychart")
+	if err := os.Mkdir(chartDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	chartfile := filepath.Join(chartDir, "Chart.yaml")
+	if err := ioutil.WriteFile(chartfile, []byte("# My chart\napiVersion: v1\nname: mychart\nversion: 1.2.3 # released by CI\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// The chart is in a git repository.
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"add", "mychart/Chart.yaml"},
+		{"-c", "user.name=helm", "-c", "user.email=helm@example.com", "commit", "-q", "-m", "mychart"},
+	} {
+		if out, err := exec.Command("git", append([]string{"-C", tmp}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %s", args, out)
+		}
+	}
+	sha, err := gitShortSHA(chartDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := newPackageCmd(&bytes.Buffer{})
+	setFlags(c, map[string]string{
+		"destination":  tmp,
+		"save":         "0",
+		"version-bump": "minor",
+		"git-sha":      "1",
+		"app-version":  "2.0",
+		"write":        "1",
+	})
+	if err := c.RunE(c, []string{chartDir}); err != nil {
+		t.Fatal(err)
+	}
+
+	ch, err := chartutil.Load(filepath.Join(tmp, "mychart-1.3.0+"+sha+".tgz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ch.Metadata.Version != "1.3.0+"+sha || ch.Metadata.AppVersion != "2.0" {
+		t.Errorf("Expected the version 1.3.0+%s and the appVersion 2.0, got %s and %s", sha, ch.Metadata.Version, ch.Metadata.AppVersion)
+	}
+
+	data, err := ioutil.ReadFile(chartfile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := "# My chart\napiVersion: v1\nname: mychart\nversion: 1.3.0 # released by CI\nappVersion: \"2.0\"\n"
+	if string(data) != expect {
+		t.Errorf("Expected the Chart.yaml\n%s\ngot\n%s", expect, data)
+	}
+}
+
 func setFlags(cmd *cobra.Command, flags map[string]string) {
 	dest := cmd.Flags()
 	for f, v := range flags {
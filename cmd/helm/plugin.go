@@ -70,3 +70,17 @@ func runHook(p *plugin.Plugin, event string) error {
 	}
 	return nil
 }
+
+// runCommandHooks runs the hooks of the plugins for the event of a core
+// command on data, and returns what the hooks wrote. The hooks are not run if
+// plugins are disabled with HELM_NO_PLUGINS.
+func runCommandHooks(event string, data []byte) ([]byte, error) {
+	if os.Getenv("HELM_NO_PLUGINS") == "1" {
+		return data, nil
+	}
+	plugins, err := findPlugins(settings.PluginDirs())
+	if err != nil {
+		return nil, err
+	}
+	return plugin.RunCommandHooks(plugins, settings, event, data)
+}
@@ -0,0 +1,61 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/policy"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/renderutil"
+	"k8s.io/helm/pkg/timeconv"
+)
+
+// checkPolicies evaluates the policies of dir against the rendered templates
+// of the chart and against its values merged with config.
+func checkPolicies(dir string, c *chart.Chart, config *chart.Config, rendered map[string]string) error {
+	engine, err := policy.Load(dir)
+	if err != nil {
+		return err
+	}
+	values, err := chartutil.CoalesceValues(c, config)
+	if err != nil {
+		return err
+	}
+	return withExitCode(exitCodeValidationFailure, engine.Check(rendered, values.AsMap()))
+}
+
+// checkInstallPolicies renders the chart as it is installed in namespace with
+// the environment env and evaluates the policies of dir against it, before the
+// chart is sent to Tiller.
+func checkInstallPolicies(dir string, c *chart.Chart, config *chart.Config, name, namespace, env string) error {
+	if name == "" {
+		name = "release-name"
+	}
+	rendered, err := renderutil.Render(c, config, renderutil.Options{
+		ReleaseOptions: chartutil.ReleaseOptions{
+			Name:        name,
+			IsInstall:   true,
+			Time:        timeconv.Now(),
+			Namespace:   namespace,
+			Environment: env,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return checkPolicies(dir, c, config, rendered)
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package main
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -29,6 +30,7 @@ import (
 
 	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/redact"
 	"k8s.io/helm/pkg/timeconv"
 )
 
@@ -46,7 +48,7 @@ var printReleaseTemplate = `REVISION: {{.Release.Version}}
 RELEASED: {{.ReleaseDate}}
 CHART: {{.Release.Chart.Metadata.Name}}-{{.Release.Chart.Metadata.Version}}
 USER-SUPPLIED VALUES:
-{{.Release.Config.Raw}}
+{{.UserValues}}
 COMPUTED VALUES:
 {{.ComputedValues}}
 HOOKS:
@@ -60,6 +62,12 @@ MANIFEST:
 `
 
 func printRelease(out io.Writer, rel *release.Release) error {
+	return printRedactedRelease(out, rel, nil)
+}
+
+// printRedactedRelease prints info about a release with the sensitive values
+// of redactor masked.
+func printRedactedRelease(out io.Writer, rel *release.Release, redactor *redact.Redactor) error {
 	if rel == nil {
 		return nil
 	}
@@ -68,17 +76,52 @@ func printRelease(out io.Writer, rel *release.Release) error {
 	if err != nil {
 		return err
 	}
-	cfgStr, err := cfg.YAML()
+	cfgStr, err := redactor.Values(cfg).YAML()
 	if err != nil {
 		return err
 	}
+	userValues := rel.Config.GetRaw()
+	if redactor != nil {
+		user, err := chartutil.ReadValues([]byte(userValues))
+		if err != nil {
+			return err
+		}
+		if userValues, err = redactor.Values(user).YAML(); err != nil {
+			return err
+		}
+	}
 
 	data := map[string]interface{}{
 		"Release":        rel,
+		"UserValues":     userValues,
 		"ComputedValues": cfgStr,
 		"ReleaseDate":    timeconv.Format(rel.Info.LastDeployed, time.ANSIC),
 	}
-	return tpl(printReleaseTemplate, data, out)
+	if redactor == nil {
+		return tpl(printReleaseTemplate, data, out)
+	}
+	var buf bytes.Buffer
+	if err := tpl(printReleaseTemplate, data, &buf); err != nil {
+		return err
+	}
+	_, err = io.WriteString(out, redactor.String(buf.String()))
+	return err
+}
+
+// debugRelease prints info about a release as verbose output, with the
+// sensitive values of the chart masked. With --log-format json it is written
+// to stderr as a debug event instead of out.
+func debugRelease(out io.Writer, rel *release.Release) error {
+	redactor := redact.New(rel.GetChart(), rel.GetConfig())
+	if !jsonLogging() {
+		return printRedactedRelease(out, rel, redactor)
+	}
+	var buf bytes.Buffer
+	if err := printRedactedRelease(&buf, rel, redactor); err != nil {
+		return err
+	}
+	writeEvent(levelDebug, buf.String())
+	return nil
 }
 
 func tpl(t string, vals interface{}, out io.Writer) error {
@@ -91,6 +134,10 @@ func tpl(t string, vals interface{}, out io.Writer) error {
 
 func debug(format string, args ...interface{}) {
 	if settings.Debug {
+		if jsonLogging() {
+			writeEvent(levelDebug, fmt.Sprintf(format, args...))
+			return
+		}
 		format = fmt.Sprintf("[debug] %s\n", format)
 		fmt.Printf(format, args...)
 	}
@@ -0,0 +1,63 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/redact"
+)
+
+func TestDebugReleaseRedactsSensitiveValues(t *testing.T) {
+	rel := helm.ReleaseMock(&helm.MockReleaseOptions{
+		Name: "vault",
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{
+				Name:        "vault",
+				Version:     "0.1.0",
+				Annotations: map[string]string{redact.Annotation: "auth.token"},
+			},
+			Values: &chart.Config{Raw: "auth:\n  token: default-token\n"},
+		},
+		Config: &chart.Config{Raw: "auth:\n  token: s3cr3t-token\n"},
+	})
+	rel.Manifest = "apiVersion: v1\nkind: Secret\nstringData:\n  token: s3cr3t-token\n"
+
+	var buf bytes.Buffer
+	if err := debugRelease(&buf, rel); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	if strings.Contains(out, "s3cr3t-token") {
+		t.Errorf("Expected the sensitive values to be masked, got\n%s", out)
+	}
+	if strings.Count(out, "token: '[REDACTED]'") != 2 || !strings.Contains(out, "token: [REDACTED]") {
+		t.Errorf("Expected the values and the manifest to be masked, got\n%s", out)
+	}
+
+	buf.Reset()
+	if err := printRelease(&buf, rel); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "s3cr3t-token") {
+		t.Errorf("Expected printRelease not to mask the values, got\n%s", buf.String())
+	}
+}
@@ -0,0 +1,45 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/helm"
+)
+
+const releasesDesc = `
+This command consists of multiple subcommands to analyze the releases
+deployed by Tiller.
+
+Example usage:
+    $ helm releases scan --kube-version 1.16
+`
+
+func newReleasesCmd(client helm.Interface, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "releases [FLAGS] scan [ARGS]",
+		Short: "Analyze the deployed releases",
+		Long:  releasesDesc,
+	}
+
+	cmd.AddCommand(newReleasesScanCmd(client, out))
+
+	return cmd
+}
@@ -0,0 +1,227 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver"
+	"github.com/gosuri/uitable"
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/deprecation"
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/release"
+)
+
+const releasesScanDesc = `
+This command scans the manifests and hooks of the deployed releases for
+Kubernetes APIs that are deprecated or removed in a Kubernetes version, and
+prints the resources using them with the API version to use instead.
+
+The Kubernetes version is the version of the cluster, unless '--kube-version'
+is given, e.g. to check the releases before upgrading the cluster:
+
+	$ helm releases scan --kube-version 1.16
+	RELEASE	NAMESPACE	KIND      	NAME	API VERSION       	STATUS            	REPLACEMENT
+	web    	default  	Deployment	web 	extensions/v1beta1	removed in 1.16   	apps/v1
+	web    	default  	Ingress   	web 	extensions/v1beta1	deprecated in 1.14	networking.k8s.io/v1beta1
+
+All the deployed releases are scanned, unless releases are given as
+arguments. The command fails if a release uses an API removed in the
+Kubernetes version.
+`
+
+type releasesScanCmd struct {
+	releases    []string
+	namespace   string
+	kubeVersion string
+	output      string
+	out         io.Writer
+	client      helm.Interface
+}
+
+func newReleasesScanCmd(client helm.Interface, out io.Writer) *cobra.Command {
+	scan := &releasesScanCmd{
+		out:    out,
+		client: client,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "scan [flags] [RELEASE...]",
+		Short:   "Find the deprecated Kubernetes APIs used by the deployed releases",
+		Long:    releasesScanDesc,
+		PreRunE: func(_ *cobra.Command, _ []string) error { return setupConnection() },
+		RunE: func(cmd *cobra.Command, args []string) error {
+			scan.releases = args
+			scan.client = ensureHelmClient(scan.client)
+			return scan.run()
+		},
+	}
+
+	f := cmd.Flags()
+	settings.AddFlagsTLS(f)
+	f.StringVar(&scan.namespace, "namespace", "", "Scan only the releases of this namespace")
+	f.StringVar(&scan.kubeVersion, "kube-version", "", "Kubernetes version the APIs are checked against. Defaults to the version of the cluster")
+	bindOutputFlag(cmd, &scan.output)
+
+	// set defaults from environment
+	settings.InitTLS(f)
+
+	return cmd
+}
+
+// releaseFinding is a resource of a release using a deprecated API.
+type releaseFinding struct {
+	Release     string `json:"release"`
+	Namespace   string `json:"namespace"`
+	Kind        string `json:"kind"`
+	Name        string `json:"name"`
+	APIVersion  string `json:"apiVersion"`
+	Removed     bool   `json:"removed"`
+	Since       string `json:"since"`
+	Replacement string `json:"replacement"`
+}
+
+func (s *releasesScanCmd) run() error {
+	kubeVersion := s.kubeVersion
+	if kubeVersion == "" {
+		v, err := getK8sVersion()
+		if err != nil {
+			return fmt.Errorf("cannot get the version of the cluster, use --kube-version: %s", err)
+		}
+		kubeVersion = fmt.Sprintf("%s.%s", v.Major, strings.TrimRight(v.Minor, "+"))
+	}
+	version, err := semver.NewVersion(kubeVersion)
+	if err != nil {
+		return fmt.Errorf("could not parse a kubernetes version: %v", err)
+	}
+
+	rels, err := s.deployedReleases()
+	if err != nil {
+		return err
+	}
+
+	var findings []releaseFinding
+	removed := 0
+	for _, r := range rels {
+		manifests := []string{r.Manifest}
+		for _, h := range r.Hooks {
+			manifests = append(manifests, h.Manifest)
+		}
+		for _, m := range manifests {
+			for _, f := range deprecation.CheckManifest(r.Name, m, version) {
+				rf := releaseFinding{
+					Release:     r.Name,
+					Namespace:   r.Namespace,
+					Kind:        f.API.Kind,
+					Name:        f.Name,
+					APIVersion:  f.API.APIVersion,
+					Removed:     f.Removed,
+					Since:       f.API.DeprecatedIn,
+					Replacement: f.API.Replacement,
+				}
+				if f.Removed {
+					rf.Since = f.API.RemovedIn
+					removed++
+				}
+				findings = append(findings, rf)
+			}
+		}
+	}
+
+	if err := write(s.out, &releaseFindingsWriter{findings}, outputFormat(s.output)); err != nil {
+		return err
+	}
+	if removed > 0 {
+		return fmt.Errorf("%d resource(s) use APIs removed in Kubernetes %d.%d", removed, version.Major(), version.Minor())
+	}
+	return nil
+}
+
+// deployedReleases returns the deployed releases given as arguments, or all
+// of them.
+func (s *releasesScanCmd) deployedReleases() ([]*release.Release, error) {
+	wanted := map[string]bool{}
+	for _, name := range s.releases {
+		wanted[name] = true
+	}
+
+	var rels []*release.Release
+	offset := ""
+	for {
+		res, err := s.client.ListReleases(
+			helm.ReleaseListOffset(offset),
+			helm.ReleaseListStatuses([]release.Status_Code{release.Status_DEPLOYED}),
+			helm.ReleaseListNamespace(s.namespace),
+		)
+		if err != nil {
+			return nil, prettyError(err)
+		}
+		if res == nil {
+			break
+		}
+		for _, r := range res.GetReleases() {
+			if len(wanted) == 0 || wanted[r.Name] {
+				rels = append(rels, r)
+				delete(wanted, r.Name)
+			}
+		}
+		if res.Next == "" {
+			break
+		}
+		offset = res.Next
+	}
+
+	if len(wanted) > 0 {
+		var missing []string
+		for name := range wanted {
+			missing = append(missing, name)
+		}
+		sort.Strings(missing)
+		return nil, fmt.Errorf("releases not deployed: %s", strings.Join(missing, ", "))
+	}
+	return rels, nil
+}
+
+type releaseFindingsWriter struct {
+	findings []releaseFinding
+}
+
+func (w *releaseFindingsWriter) WriteTable(out io.Writer) error {
+	table := uitable.New()
+	table.AddRow("RELEASE", "NAMESPACE", "KIND", "NAME", "API VERSION", "STATUS", "REPLACEMENT")
+	for _, f := range w.findings {
+		status := "deprecated in " + f.Since
+		if f.Removed {
+			status = "removed in " + f.Since
+		}
+		table.AddRow(f.Release, f.Namespace, f.Kind, f.Name, f.APIVersion, status, f.Replacement)
+	}
+	return encodeTable(out, table)
+}
+
+func (w *releaseFindingsWriter) WriteJSON(out io.Writer) error {
+	return encodeJSON(out, w.findings)
+}
+
+func (w *releaseFindingsWriter) WriteYAML(out io.Writer) error {
+	return encodeYAML(out, w.findings)
+}
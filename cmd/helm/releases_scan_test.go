@@ -0,0 +1,77 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"io"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/release"
+)
+
+func TestReleasesScanCmd(t *testing.T) {
+	web := helm.ReleaseMock(&helm.MockReleaseOptions{Name: "web", Namespace: "shop"})
+	web.Manifest = `apiVersion: extensions/v1beta1
+kind: Deployment
+metadata:
+  name: web
+---
+apiVersion: extensions/v1beta1
+kind: Ingress
+metadata:
+  name: web`
+	web.Hooks = []*release.Hook{{Name: "migrate", Manifest: "apiVersion: batch/v1\nkind: Job\nmetadata:\n  name: migrate"}}
+	db := helm.ReleaseMock(&helm.MockReleaseOptions{Name: "db"})
+	db.Manifest = "apiVersion: apps/v1beta2\nkind: StatefulSet\nmetadata:\n  name: db"
+	rels := []*release.Release{web, db}
+
+	tests := []releaseCase{
+		{
+			name:     "deprecated APIs",
+			flags:    []string{"--kube-version", "1.15"},
+			rels:     rels,
+			expected: `RELEASE\s+NAMESPACE\s+KIND\s+NAME\s+API VERSION\s+STATUS\s+REPLACEMENT\s*\nweb\s+shop\s+Deployment\s+web\s+extensions/v1beta1\s+deprecated in 1.9\s+apps/v1\s*\nweb\s+shop\s+Ingress\s+web\s+extensions/v1beta1\s+deprecated in 1.14\s+networking.k8s.io/v1beta1\s*\ndb\s+default\s+StatefulSet\s+db\s+apps/v1beta2\s+deprecated in 1.9\s+apps/v1`,
+		},
+		{
+			name:     "removed APIs",
+			flags:    []string{"--kube-version", "v1.16.1"},
+			rels:     rels,
+			expected: `web\s+shop\s+Deployment\s+web\s+extensions/v1beta1\s+removed in 1.16\s+apps/v1`,
+			err:      true,
+		},
+		{
+			name:     "given release",
+			args:     []string{"db"},
+			flags:    []string{"--kube-version", "1.15", "--output", "json"},
+			rels:     rels,
+			expected: `^\[{"release":"db","namespace":"default","kind":"StatefulSet","name":"db","apiVersion":"apps/v1beta2","removed":false,"since":"1.9","replacement":"apps/v1"}\]\n$`,
+		},
+		{
+			name:  "release not deployed",
+			args:  []string{"db", "cache"},
+			flags: []string{"--kube-version", "1.15"},
+			rels:  rels,
+			err:   true,
+		},
+	}
+	runReleaseCases(t, tests, func(c *helm.FakeClient, out io.Writer) *cobra.Command {
+		return newReleasesScanCmd(c, out)
+	})
+}
@@ -30,11 +30,29 @@ import (
 	"github.com/gofrs/flock"
 	"github.com/spf13/cobra"
 
+	"k8s.io/helm/pkg/credentials"
 	"k8s.io/helm/pkg/getter"
 	"k8s.io/helm/pkg/helm/helmpath"
 	"k8s.io/helm/pkg/repo"
 )
 
+const repoAddDesc = `
+Add a chart repository to the repositories file.
+
+When a username and password are given and the docker-credential-helpers
+compatible helper of the keychain of the operating system is installed
+(osxkeychain on macOS, wincred on Windows and secretservice on Linux), they are
+saved in the keychain and only the name of the credential store is written to
+the repositories file. Without the helper, they are written to the repositories
+file with a warning. Use '--credentials-store' to select another helper, which
+must be installed, or '--plaintext-store' to keep the credentials in the
+repositories file.
+
+Without a username, '--credentials-store' reads the credentials that the helper
+already holds for the URL of the repository or for its host, e.g. the ones
+saved by 'docker login'. The helper runs once per server and command.
+`
+
 type repoAddCmd struct {
 	name     string
 	url      string
@@ -43,9 +61,14 @@ type repoAddCmd struct {
 	home     helmpath.Home
 	noupdate bool
 
+	credentialsStore string
+	plaintextStore   bool
+
 	certFile string
 	keyFile  string
 	caFile   string
+	proxy    string
+	timeout  int64
 
 	out io.Writer
 }
@@ -56,6 +79,7 @@ func newRepoAddCmd(out io.Writer) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "add [flags] [NAME] [URL]",
 		Short: "Add a chart repository",
+		Long:  repoAddDesc,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := checkArgsLength(len(args), "name for the chart repository", "the url of the chart repository"); err != nil {
 				return err
@@ -76,6 +100,10 @@ func newRepoAddCmd(out io.Writer) *cobra.Command {
 	f.StringVar(&add.certFile, "cert-file", "", "Identify HTTPS client using this SSL certificate file")
 	f.StringVar(&add.keyFile, "key-file", "", "Identify HTTPS client using this SSL key file")
 	f.StringVar(&add.caFile, "ca-file", "", "Verify certificates of HTTPS-enabled servers using this CA bundle")
+	f.StringVar(&add.proxy, "proxy", "", "URL of the proxy to the repository. Defaults to the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables")
+	f.Int64Var(&add.timeout, "timeout", 0, "Time in seconds to wait for a request to the repository. No limit if 0")
+	f.StringVar(&add.credentialsStore, "credentials-store", "", "Name of the docker-credential-helpers compatible store for the credentials. Defaults to the keychain of the operating system if its helper is installed")
+	f.BoolVar(&add.plaintextStore, "plaintext-store", false, "Save the credentials in plaintext in the repositories file")
 
 	return cmd
 }
@@ -91,7 +119,30 @@ func (a *repoAddCmd) run() error {
 		a.password = password
 	}
 
-	if err := addRepository(a.name, a.url, a.username, a.password, a.home, a.certFile, a.keyFile, a.caFile, a.noupdate); err != nil {
+	var store string
+	if a.username != "" || a.password != "" || a.credentialsStore != "" {
+		var err error
+		if store, err = credentialsStoreFor(a.credentialsStore, a.plaintextStore); err != nil {
+			return err
+		}
+	}
+
+	c := repo.Entry{
+		Name:     a.name,
+		URL:      a.url,
+		Username: a.username,
+		Password: a.password,
+		CertFile: a.certFile,
+		KeyFile:  a.keyFile,
+		CAFile:   a.caFile,
+		Proxy:    a.proxy,
+		Timeout:  a.timeout,
+	}
+	if a.username == "" && a.password == "" {
+		// The credentials are already in the store, e.g. for the host.
+		c.CredentialsStore = store
+	}
+	if err := addRepositoryEntry(c, a.home, a.noupdate, store); err != nil {
 		return err
 	}
 	fmt.Fprintf(a.out, "%q has been added to your repositories\n", a.name)
@@ -106,20 +157,41 @@ func readPassword() (string, error) {
 	return string(password), nil
 }
 
-func addRepository(name, url, username, password string, home helmpath.Home, certFile, keyFile, caFile string, noUpdate bool) error {
-	f, err := repo.LoadRepositoriesFile(home.RepositoryFile())
-	if err != nil {
-		return err
+// credentialsStoreFor returns the name of the credential store to save
+// repository credentials in, or an empty name if they are kept in plaintext.
+// Without a name, the keychain of the operating system is used if its helper
+// is installed, and the credentials are kept in plaintext otherwise.
+func credentialsStoreFor(name string, plaintext bool) (string, error) {
+	if plaintext {
+		return "", nil
+	}
+	if name == "" {
+		native := credentials.NativeStore()
+		if err := credentials.NewHelperStore(native).Available(); err != nil {
+			warning("%s: the credentials are saved in plaintext in the repositories file. Install the helper or select another one with --credentials-store to keep them out of it", err)
+			return "", nil
+		}
+		return native, nil
 	}
+	return requireCredentialsStore(name)
+}
 
-	if noUpdate && f.Has(name) {
-		return fmt.Errorf("repository name (%s) already exists, please specify a different name", name)
+// requireCredentialsStore returns the name of the credential store, the
+// keychain of the operating system if name is empty, and fails if its helper
+// is not installed.
+func requireCredentialsStore(name string) (string, error) {
+	if name == "" {
+		name = credentials.NativeStore()
+	}
+	if err := credentials.NewHelperStore(name).Available(); err != nil {
+		return "", fmt.Errorf("%s\nInstall the helper, select another one with --credentials-store or use --plaintext-store to save the credentials in the repositories file", err)
 	}
+	return name, nil
+}
 
-	cif := home.CacheIndex(name)
+func addRepository(name, url, username, password string, home helmpath.Home, certFile, keyFile, caFile string, noUpdate bool, credentialsStore string) error {
 	c := repo.Entry{
 		Name:     name,
-		Cache:    cif,
 		URL:      url,
 		Username: username,
 		Password: password,
@@ -127,6 +199,22 @@ func addRepository(name, url, username, password string, home helmpath.Home, cer
 		KeyFile:  keyFile,
 		CAFile:   caFile,
 	}
+	return addRepositoryEntry(c, home, noUpdate, credentialsStore)
+}
+
+// addRepositoryEntry adds the repository of the entry to the repositories
+// file once its index is downloaded.
+func addRepositoryEntry(c repo.Entry, home helmpath.Home, noUpdate bool, credentialsStore string) error {
+	f, err := repo.LoadRepositoriesFile(home.RepositoryFile())
+	if err != nil {
+		return err
+	}
+
+	if noUpdate && f.Has(c.Name) {
+		return fmt.Errorf("repository name (%s) already exists, please specify a different name", c.Name)
+	}
+
+	c.Cache = home.CacheIndex(c.Name)
 
 	r, err := repo.NewChartRepository(&c, getter.All(settings))
 	if err != nil {
@@ -134,7 +222,7 @@ func addRepository(name, url, username, password string, home helmpath.Home, cer
 	}
 
 	if err := r.DownloadIndexFile(home.Cache()); err != nil {
-		return fmt.Errorf("Looks like %q is not a valid chart repository or cannot be reached: %s", url, err.Error())
+		return fmt.Errorf("Looks like %q is not a valid chart repository or cannot be reached: %s", c.URL, err.Error())
 	}
 
 	repoFile := home.RepositoryFile()
@@ -158,6 +246,12 @@ func addRepository(name, url, username, password string, home helmpath.Home, cer
 		return err
 	}
 
+	if credentialsStore != "" {
+		if err := c.StoreCredentials(credentialsStore); err != nil {
+			return err
+		}
+	}
+
 	f.Update(&c)
 
 	return f.WriteFile(repoFile, 0644)
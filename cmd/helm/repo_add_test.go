@@ -19,6 +19,7 @@ package main
 import (
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"os/exec"
 	"strings"
@@ -66,6 +67,52 @@ func TestRepoAddCmd(t *testing.T) {
 	})
 }
 
+func TestRepoAddWithoutCredentialHelper(t *testing.T) {
+	srv, thome, err := repotest.NewTempServer("testdata/testserver/*.*")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cleanup := resetEnv()
+	defer func() {
+		srv.Stop()
+		os.RemoveAll(thome.String())
+		cleanup()
+	}()
+	if err := ensureTestHome(thome, t); err != nil {
+		t.Fatal(err)
+	}
+	settings.Home = thome
+
+	// No credential helper can be found.
+	defer os.Setenv("PATH", os.Getenv("PATH"))
+	os.Setenv("PATH", thome.String())
+
+	cmd := newRepoAddCmd(ioutil.Discard)
+	cmd.SetArgs([]string{testName, srv.URL(), "--username", "user", "--password", "s3cr3t"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := repo.LoadRepositoriesFile(thome.RepositoryFile())
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, ok := f.Get(testName)
+	if !ok {
+		t.Fatalf("%s was not added to %s", testName, thome.RepositoryFile())
+	}
+	if e.Username != "user" || e.Password != "s3cr3t" || e.CredentialsStore != "" {
+		t.Errorf("expected plaintext credentials, got %+v", e)
+	}
+
+	cmd = newRepoAddCmd(ioutil.Discard)
+	cmd.SetArgs([]string{testName, srv.URL(), "--username", "user", "--password", "s3cr3t", "--credentials-store", "missing"})
+	if err := cmd.Execute(); err == nil {
+		t.Error("expected an error for a credential store without helper")
+	}
+}
+
 func TestRepoAdd(t *testing.T) {
 	ts, thome, err := repotest.NewTempServer("testdata/testserver/*.*")
 	if err != nil {
@@ -85,7 +132,7 @@ func TestRepoAdd(t *testing.T) {
 
 	settings.Home = thome
 
-	if err := addRepository(testName, ts.URL(), "", "", hh, "", "", "", true); err != nil {
+	if err := addRepository(testName, ts.URL(), "", "", hh, "", "", "", true, ""); err != nil {
 		t.Error(err)
 	}
 
@@ -98,11 +145,11 @@ func TestRepoAdd(t *testing.T) {
 		t.Errorf("%s was not successfully inserted into %s", testName, hh.RepositoryFile())
 	}
 
-	if err := addRepository(testName, ts.URL(), "", "", hh, "", "", "", false); err != nil {
+	if err := addRepository(testName, ts.URL(), "", "", hh, "", "", "", false, ""); err != nil {
 		t.Errorf("Repository was not updated: %s", err)
 	}
 
-	if err := addRepository(testName, ts.URL(), "", "", hh, "", "", "", false); err != nil {
+	if err := addRepository(testName, ts.URL(), "", "", hh, "", "", "", false, ""); err != nil {
 		t.Errorf("Duplicate repository name was added")
 	}
 }
@@ -129,7 +176,7 @@ func TestRepoAddConcurrentGoRoutines(t *testing.T) {
 	for i := 0; i < 3; i++ {
 		go func(name string) {
 			defer wg.Done()
-			if err := addRepository(name, ts.URL(), "", "", settings.Home, "", "", "", true); err != nil {
+			if err := addRepository(name, ts.URL(), "", "", settings.Home, "", "", "", true, ""); err != nil {
 				t.Error(err)
 			}
 		}(fmt.Sprintf("%s-%d", testName, i))
@@ -207,7 +254,7 @@ func TestRepoAddConcurrentSubProcesses(t *testing.T) {
 		settings.Home = helmpath.Home(os.Getenv("HELM_HOME"))
 		repoName := s[0]
 		tsURL := s[1]
-		if err := addRepository(repoName, tsURL, "", "", settings.Home, "", "", "", true); err != nil {
+		if err := addRepository(repoName, tsURL, "", "", settings.Home, "", "", "", true, ""); err != nil {
 			t.Fatal(err)
 		}
 
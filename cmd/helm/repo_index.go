@@ -36,13 +36,25 @@ set an absolute URL to the charts, use '--url' flag.
 To merge the generated index with an existing index file, use the '--merge'
 flag. In this case, the charts found in the current directory will be merged
 into the existing index, with local charts taking priority over existing charts.
+
+To index a large repository, '--incremental' reuses the entries of the charts
+already in the existing index, or in the index.yaml of the directory if
+'--merge' is not given. Only the archives added or modified since they were
+indexed are read and digested.
+
+To shard the index, '--shard' writes the versions of every chart to their own
+file in the 'index' directory, e.g. 'index/mychart.yaml', and an index.yaml
+listing these files. Helm reads the shards when the repository is added or
+updated.
 `
 
 type repoIndexCmd struct {
-	dir   string
-	url   string
-	out   io.Writer
-	merge string
+	dir         string
+	url         string
+	out         io.Writer
+	merge       string
+	incremental bool
+	shard       bool
 }
 
 func newRepoIndexCmd(out io.Writer) *cobra.Command {
@@ -66,6 +78,8 @@ func newRepoIndexCmd(out io.Writer) *cobra.Command {
 	f := cmd.Flags()
 	f.StringVar(&index.url, "url", "", "URL of the chart repository")
 	f.StringVar(&index.merge, "merge", "", "Merge the generated index into the given index")
+	f.BoolVar(&index.incremental, "incremental", false, "Only read and digest the charts that are not in the existing index or were modified since")
+	f.BoolVar(&index.shard, "shard", false, "Write the versions of every chart to their own index file in the index directory")
 
 	return cmd
 }
@@ -76,13 +90,26 @@ func (i *repoIndexCmd) run() error {
 		return err
 	}
 
-	return index(path, i.url, i.merge)
+	return index(path, i.url, i.merge, i.incremental, i.shard)
 }
 
-func index(dir, url, mergeTo string) error {
+func index(dir, url, mergeTo string, incremental, shard bool) error {
 	out := filepath.Join(dir, "index.yaml")
 
-	i, err := repo.IndexDirectory(dir, url)
+	var indexed *repo.IndexFile
+	if incremental {
+		existing := out
+		if mergeTo != "" {
+			existing = mergeTo
+		}
+		if _, err := os.Stat(existing); err == nil {
+			if indexed, err = repo.LoadIndexFile(existing); err != nil {
+				return fmt.Errorf("Loading the existing index failed: %s", err)
+			}
+		}
+	}
+
+	i, err := repo.UpdateIndexDirectory(dir, url, indexed)
 	if err != nil {
 		return err
 	}
@@ -101,5 +128,8 @@ func index(dir, url, mergeTo string) error {
 		i.Merge(i2)
 	}
 	i.SortEntries()
+	if shard {
+		return i.WriteShards(dir, 0644)
+	}
 	return i.WriteFile(out, 0644)
 }
@@ -144,6 +144,55 @@ func TestRepoIndexCmd(t *testing.T) {
 	}
 }
 
+func TestRepoIndexCmdShard(t *testing.T) {
+	dir, err := ioutil.TempDir("", "helm-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, name := range []string{"compressedchart-0.1.0.tgz", "reqtest-0.1.0.tgz"} {
+		if err := linkOrCopy(filepath.Join("testdata/testcharts", name), filepath.Join(dir, name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	c := newRepoIndexCmd(bytes.NewBuffer(nil))
+	c.ParseFlags([]string{"--shard"})
+	if err := c.RunE(c, []string{dir}); err != nil {
+		t.Fatal(err)
+	}
+	for _, shard := range []string{"compressedchart.yaml", "reqtest.yaml"} {
+		if _, err := os.Stat(filepath.Join(dir, "index", shard)); err != nil {
+			t.Errorf("Expected the shard %s: %s", shard, err)
+		}
+	}
+
+	// Index a new version incrementally, reusing the sharded index.
+	if err := linkOrCopy("testdata/testcharts/compressedchart-0.2.0.tgz", filepath.Join(dir, "compressedchart-0.2.0.tgz")); err != nil {
+		t.Fatal(err)
+	}
+	c = newRepoIndexCmd(bytes.NewBuffer(nil))
+	c.ParseFlags([]string{"--incremental"})
+	if err := c.RunE(c, []string{dir}); err != nil {
+		t.Fatal(err)
+	}
+
+	index, err := repo.LoadIndexFile(filepath.Join(dir, "index.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(index.Shards) != 0 {
+		t.Errorf("expected an unsharded index, got shards %v", index.Shards)
+	}
+	if vs := index.Entries["compressedchart"]; len(vs) != 2 || vs[0].Version != "0.2.0" {
+		t.Errorf("expected the 2 versions of compressedchart, got %#v", vs)
+	}
+	if !index.Has("reqtest", "0.1.0") {
+		t.Errorf("expected reqtest 0.1.0, got %#v", index.Entries)
+	}
+}
+
 func linkOrCopy(old, new string) error {
 	if err := os.Link(old, new); err != nil {
 		return copyFile(old, new)
@@ -0,0 +1,122 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/helm/helmpath"
+	"k8s.io/helm/pkg/repo"
+)
+
+const repoMigrateCredentialsDesc = `
+Move the credentials of chart repositories between the repositories file and a
+credential store.
+
+By default the plaintext usernames and passwords found in the repositories file
+are saved in the keychain of the operating system and removed from the file.
+With '--plaintext-store' the credentials are read back from their credential
+store and written to the repositories file instead.
+
+If no repository names are given, all repositories are migrated.
+`
+
+type repoMigrateCredentialsCmd struct {
+	names            []string
+	credentialsStore string
+	plaintextStore   bool
+	home             helmpath.Home
+	out              io.Writer
+}
+
+func newRepoMigrateCredentialsCmd(out io.Writer) *cobra.Command {
+	m := &repoMigrateCredentialsCmd{out: out}
+
+	cmd := &cobra.Command{
+		Use:   "migrate-credentials [flags] [NAME...]",
+		Short: "Move chart repository credentials to or from a credential store",
+		Long:  repoMigrateCredentialsDesc,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			m.names = args
+			m.home = settings.Home
+			return m.run()
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVar(&m.credentialsStore, "credentials-store", "", "Name of the docker-credential-helpers compatible store for the credentials. Defaults to the keychain of the operating system")
+	f.BoolVar(&m.plaintextStore, "plaintext-store", false, "Move the credentials from their credential store into the repositories file")
+
+	return cmd
+}
+
+func (m *repoMigrateCredentialsCmd) run() error {
+	repoFile := m.home.RepositoryFile()
+	f, err := repo.LoadRepositoriesFile(repoFile)
+	if err != nil {
+		return err
+	}
+
+	entries := f.Repositories
+	if len(m.names) > 0 {
+		entries = nil
+		for _, name := range m.names {
+			e, ok := f.Get(name)
+			if !ok {
+				return fmt.Errorf("no repo named %q found", name)
+			}
+			entries = append(entries, e)
+		}
+	}
+
+	var store string
+	if !m.plaintextStore {
+		if store, err = requireCredentialsStore(m.credentialsStore); err != nil {
+			return err
+		}
+	}
+
+	migrated := 0
+	for _, e := range entries {
+		if m.plaintextStore {
+			if e.CredentialsStore == "" {
+				continue
+			}
+			if err := e.PlaintextCredentials(); err != nil {
+				return err
+			}
+		} else {
+			if e.Username == "" && e.Password == "" {
+				continue
+			}
+			if err := e.StoreCredentials(store); err != nil {
+				return err
+			}
+		}
+		migrated++
+		fmt.Fprintf(m.out, "Migrated credentials of %q\n", e.Name)
+	}
+
+	if migrated == 0 {
+		fmt.Fprintln(m.out, "No credentials to migrate")
+		return nil
+	}
+	return f.WriteFile(repoFile, 0644)
+}
@@ -0,0 +1,92 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"k8s.io/helm/pkg/helm/helmpath"
+	"k8s.io/helm/pkg/repo"
+	"k8s.io/helm/pkg/repo/repotest"
+)
+
+func TestRepoMigrateCredentials(t *testing.T) {
+	ts, thome, err := repotest.NewTempServer("testdata/testserver/*.*")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hh := helmpath.Home(thome)
+	cleanup := resetEnv()
+	defer func() {
+		ts.Stop()
+		os.RemoveAll(thome.String())
+		cleanup()
+	}()
+	if err := ensureTestHome(hh, t); err != nil {
+		t.Fatal(err)
+	}
+	settings.Home = thome
+
+	// docker-credential-test keeps a single credential in $HELM_TEST_CREDENTIALS
+	helpers, err := filepath.Abs("../../pkg/credentials/testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Setenv("PATH", os.Getenv("PATH"))
+	os.Setenv("PATH", helpers+string(os.PathListSeparator)+os.Getenv("PATH"))
+	os.Setenv("HELM_TEST_CREDENTIALS", filepath.Join(thome.String(), "credentials"))
+	defer os.Unsetenv("HELM_TEST_CREDENTIALS")
+
+	if err := addRepository(testName, ts.URL(), "user", "s3cr3t", hh, "", "", "", true, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	out := bytes.NewBuffer(nil)
+	cmd := newRepoMigrateCredentialsCmd(out)
+	cmd.SetArgs([]string{"--credentials-store", "test"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := repo.LoadRepositoriesFile(hh.RepositoryFile())
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, _ := f.Get(testName)
+	if e.Username != "" || e.Password != "" || e.CredentialsStore != "test" {
+		t.Errorf("expected credentials to be moved to the store, got %+v", e)
+	}
+
+	out.Reset()
+	cmd = newRepoMigrateCredentialsCmd(out)
+	cmd.SetArgs([]string{"--plaintext-store", testName})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	if f, err = repo.LoadRepositoriesFile(hh.RepositoryFile()); err != nil {
+		t.Fatal(err)
+	}
+	e, _ = f.Get(testName)
+	if e.Username != "user" || e.Password != "s3cr3t" || e.CredentialsStore != "" {
+		t.Errorf("expected plaintext credentials, got %+v", e)
+	}
+}
@@ -70,12 +70,17 @@ func removeRepoLine(out io.Writer, name string, home helmpath.Home) error {
 		return err
 	}
 
-	if !r.Remove(name) {
+	entry, ok := r.Get(name)
+	if !ok {
 		return fmt.Errorf("no repo named %q found", name)
 	}
+	r.Remove(name)
 	if err := r.WriteFile(repoFile, 0644); err != nil {
 		return err
 	}
+	if err := entry.EraseCredentials(); err != nil {
+		fmt.Fprintf(out, "WARNING: %s\n", err)
+	}
 
 	if err := removeRepoCache(name, home); err != nil {
 		return err
@@ -87,10 +92,12 @@ func removeRepoLine(out io.Writer, name string, home helmpath.Home) error {
 }
 
 func removeRepoCache(name string, home helmpath.Home) error {
-	if _, err := os.Stat(home.CacheIndex(name)); err == nil {
-		err = os.Remove(home.CacheIndex(name))
-		if err != nil {
-			return err
+	for _, f := range []string{home.CacheIndex(name), repo.CacheValidatorsFile(home.CacheIndex(name))} {
+		if _, err := os.Stat(f); err == nil {
+			err = os.Remove(f)
+			if err != nil {
+				return err
+			}
 		}
 	}
 	return nil
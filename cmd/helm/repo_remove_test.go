@@ -52,7 +52,7 @@ func TestRepoRemove(t *testing.T) {
 	if err := removeRepoLine(b, testName, hh); err == nil {
 		t.Errorf("Expected error removing %s, but did not get one.", testName)
 	}
-	if err := addRepository(testName, ts.URL(), "", "", hh, "", "", "", true); err != nil {
+	if err := addRepository(testName, ts.URL(), "", "", hh, "", "", "", true, ""); err != nil {
 		t.Error(err)
 	}
 
@@ -110,10 +110,10 @@ func TestRepoRemove_MultipleRepos(t *testing.T) {
 	repoFoo := testName + "foo"
 	repoBar := testName + "bar"
 
-	if err := addRepository(repoFoo, ts.URL(), "", "", hh, "", "", "", true); err != nil {
+	if err := addRepository(repoFoo, ts.URL(), "", "", hh, "", "", "", true, ""); err != nil {
 		t.Error(err)
 	}
-	if err := addRepository(repoBar, ts.URL(), "", "", hh, "", "", "", true); err != nil {
+	if err := addRepository(repoBar, ts.URL(), "", "", hh, "", "", "", true, ""); err != nil {
 		t.Error(err)
 	}
 
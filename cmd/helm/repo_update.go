@@ -43,17 +43,22 @@ You can specify the name of a repository you want to update.
 
 To update all the repositories, use 'helm repo update'.
 
+An index is only downloaded if the repository reports that it has changed
+since it was cached, using the ETag and Last-Modified headers of the server.
+Use '--refresh' to download the indexes regardless.
+
 `
 
 var errNoRepositories = errors.New("no repositories found. You must add one before updating")
 var errNoRepositoriesMatchingRepoName = errors.New("no repositories found matching the provided name. Verify if the repo exists")
 
 type repoUpdateCmd struct {
-	update func([]*repo.ChartRepository, io.Writer, helmpath.Home, bool) error
-	home   helmpath.Home
-	out    io.Writer
-	strict bool
-	name   string
+	update  func([]*repo.ChartRepository, io.Writer, helmpath.Home, bool) error
+	home    helmpath.Home
+	out     io.Writer
+	strict  bool
+	name    string
+	refresh bool
 }
 
 func newRepoUpdateCmd(out io.Writer) *cobra.Command {
@@ -77,6 +82,7 @@ func newRepoUpdateCmd(out io.Writer) *cobra.Command {
 
 	f := cmd.Flags()
 	f.BoolVar(&u.strict, "strict", false, "Fail on update warnings")
+	f.BoolVar(&u.refresh, "refresh", false, "Download the indexes even if the cached indexes are up to date")
 
 	return cmd
 }
@@ -96,6 +102,7 @@ func (u *repoUpdateCmd) run() error {
 		if err != nil {
 			return err
 		}
+		r.Refresh = u.refresh
 		if len(u.name) != 0 {
 			if cfg.Name == u.name {
 				repos = append(repos, r)
@@ -178,11 +178,11 @@ func TestUpdateRepo(t *testing.T) {
 
 	settings.Home = thome
 
-	if err := addRepository("repo1", ts.URL(), "", "", hh, "", "", "", true); err != nil {
+	if err := addRepository("repo1", ts.URL(), "", "", hh, "", "", "", true, ""); err != nil {
 		t.Error(err)
 	}
 
-	if err := addRepository("repo2", ts.URL(), "", "", hh, "", "", "", true); err != nil {
+	if err := addRepository("repo2", ts.URL(), "", "", hh, "", "", "", true, ""); err != nil {
 		t.Error(err)
 	}
 
@@ -17,6 +17,7 @@ limitations under the License.
 package main
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"strconv"
@@ -33,6 +34,16 @@ The first argument of the rollback command is the name of a release, and the
 second is a revision (version) number. To see revision numbers, run
 'helm history RELEASE'. If you'd like to rollback to the previous release use
 'helm rollback [RELEASE] 0'.
+
+To revert only the values, e.g. a bad override, '--to-values' deploys the
+chart of the current release with the values of the revision:
+
+	$ helm rollback --to-values my-release 3
+
+The values can be given in a file with '--values' instead, in which case the
+revision is omitted:
+
+	$ helm rollback --to-values my-release -f good-values.yaml
 `
 
 type rollbackCmd struct {
@@ -48,6 +59,8 @@ type rollbackCmd struct {
 	wait          bool
 	description   string
 	cleanupOnFail bool
+	toValues      bool
+	valueFiles    valueFiles
 }
 
 func newRollbackCmd(c helm.Interface, out io.Writer) *cobra.Command {
@@ -62,18 +75,26 @@ func newRollbackCmd(c helm.Interface, out io.Writer) *cobra.Command {
 		Long:    rollbackDesc,
 		PreRunE: func(_ *cobra.Command, _ []string) error { return setupConnection() },
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if err := checkArgsLength(len(args), "release name", "revision number"); err != nil {
+			if len(rollback.valueFiles) > 0 {
+				if !rollback.toValues {
+					return errors.New("--values requires --to-values")
+				}
+				if err := checkArgsLength(len(args), "release name"); err != nil {
+					return err
+				}
+			} else if err := checkArgsLength(len(args), "release name", "revision number"); err != nil {
 				return err
 			}
 
 			rollback.name = args[0]
 
-			v64, err := strconv.ParseInt(args[1], 10, 32)
-			if err != nil {
-				return fmt.Errorf("invalid revision number '%q': %s", args[1], err)
+			if len(args) > 1 {
+				v64, err := strconv.ParseInt(args[1], 10, 32)
+				if err != nil {
+					return fmt.Errorf("invalid revision number '%q': %s", args[1], err)
+				}
+				rollback.revision = int32(v64)
 			}
-
-			rollback.revision = int32(v64)
 			rollback.client = ensureHelmClient(rollback.client)
 			return rollback.run()
 		},
@@ -89,6 +110,8 @@ func newRollbackCmd(c helm.Interface, out io.Writer) *cobra.Command {
 	f.BoolVar(&rollback.wait, "wait", false, "If set, will wait until all Pods, PVCs, Services, and minimum number of Pods of a Deployment are in a ready state before marking the release as successful. It will wait for as long as --timeout")
 	f.StringVar(&rollback.description, "description", "", "Specify a description for the release")
 	f.BoolVar(&rollback.cleanupOnFail, "cleanup-on-fail", false, "Allow deletion of new resources created in this rollback when rollback failed")
+	f.BoolVar(&rollback.toValues, "to-values", false, "Deploy the chart of the current release with the values of the revision, instead of the revision")
+	f.VarP(&rollback.valueFiles, "values", "f", "Specify the values of --to-values in a YAML file or a URL (can specify multiple), instead of a revision")
 
 	// set defaults from environment
 	settings.InitTLS(f)
@@ -97,8 +120,7 @@ func newRollbackCmd(c helm.Interface, out io.Writer) *cobra.Command {
 }
 
 func (r *rollbackCmd) run() error {
-	_, err := r.client.RollbackRelease(
-		r.name,
+	opts := []helm.RollbackOption{
 		helm.RollbackDryRun(r.dryRun),
 		helm.RollbackRecreate(r.recreate),
 		helm.RollbackForce(r.force),
@@ -107,8 +129,18 @@ func (r *rollbackCmd) run() error {
 		helm.RollbackTimeout(r.timeout),
 		helm.RollbackWait(r.wait),
 		helm.RollbackDescription(r.description),
-		helm.RollbackCleanupOnFail(r.cleanupOnFail))
-	if err != nil {
+		helm.RollbackCleanupOnFail(r.cleanupOnFail),
+		helm.RollbackValuesOnly(r.toValues),
+	}
+	if len(r.valueFiles) > 0 {
+		rawVals, err := vals(r.valueFiles, nil, nil, nil, nil, "", "", "")
+		if err != nil {
+			return err
+		}
+		opts = append(opts, helm.RollbackValues(rawVals))
+	}
+
+	if _, err := r.client.RollbackRelease(r.name, opts...); err != nil {
 		return prettyError(err)
 	}
 
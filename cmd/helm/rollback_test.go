@@ -56,6 +56,24 @@ func TestRollbackCmd(t *testing.T) {
 			args: []string{"funny-honey"},
 			err:  true,
 		},
+		{
+			name:     "rollback the values of a release",
+			args:     []string{"funny-honey", "1"},
+			flags:    []string{"--to-values"},
+			expected: "Rollback was a success.",
+		},
+		{
+			name:     "rollback the values of a release to a values file",
+			args:     []string{"funny-honey"},
+			flags:    []string{"--to-values", "-f", "testdata/null-values.yaml"},
+			expected: "Rollback was a success.",
+		},
+		{
+			name:  "rollback a release to a values file without --to-values",
+			args:  []string{"funny-honey"},
+			flags: []string{"-f", "testdata/null-values.yaml"},
+			err:   true,
+		},
 	}
 
 	cmd := func(c *helm.FakeClient, out io.Writer) *cobra.Command {
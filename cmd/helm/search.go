@@ -19,6 +19,7 @@ package main
 import (
 	"fmt"
 	"io"
+	"regexp"
 	"strings"
 
 	"github.com/Masterminds/semver"
@@ -50,6 +51,20 @@ To search for charts using common keywords (such as "database" or
 or
 
     helm search key-value store
+
+The newest version of every chart matching a semantic version range is found
+with '--version'. The comparisons of a range are separated by commas or spaces,
+and alternative ranges by '||':
+
+    helm search nginx --version ">=1.2 <2"
+
+To only show the charts with a name matching a regular expression, use
+'--name-regexp':
+
+    helm search --name-regexp '^stable/(mysql|mariadb)$'
+
+The JSON and YAML output of '--output' includes the metadata of every chart
+version in the repository index, e.g. its URLs, digest and maintainers.
 `
 
 // searchMaxScore suggests that any score higher than this is not considered a match.
@@ -59,11 +74,12 @@ type searchCmd struct {
 	out      io.Writer
 	helmhome helmpath.Home
 
-	versions bool
-	regexp   bool
-	version  string
-	colWidth uint
-	output   string
+	versions   bool
+	regexp     bool
+	version    string
+	nameRegexp string
+	colWidth   uint
+	output     string
 }
 
 type chartElement struct {
@@ -71,6 +87,8 @@ type chartElement struct {
 	Version     string
 	AppVersion  string
 	Description string
+	// Chart is the entry of the chart version in the repository index.
+	Chart *repo.ChartVersion
 }
 
 func newSearchCmd(out io.Writer) *cobra.Command {
@@ -89,7 +107,8 @@ func newSearchCmd(out io.Writer) *cobra.Command {
 	f := cmd.Flags()
 	f.BoolVarP(&sc.regexp, "regexp", "r", false, "Use regular expressions for searching")
 	f.BoolVarP(&sc.versions, "versions", "l", false, "Show the long listing, with each version of each chart on its own line")
-	f.StringVarP(&sc.version, "version", "v", "", "Search using semantic versioning constraints")
+	f.StringVarP(&sc.version, "version", "v", "", "Search using semantic versioning constraints, e.g. '>=1.2 <2'")
+	f.StringVar(&sc.nameRegexp, "name-regexp", "", "Only show the charts with a name, e.g. stable/mysql, matching this regular expression")
 	f.UintVar(&sc.colWidth, "col-width", 60, "Specifies the max column width of output")
 	bindOutputFlag(cmd, &sc.output)
 
@@ -113,6 +132,10 @@ func (s *searchCmd) run(args []string) error {
 		}
 	}
 
+	if res, err = s.applyNameRegexp(res); err != nil {
+		return err
+	}
+
 	search.SortScore(res)
 	data, err := s.applyConstraint(res)
 	if err != nil {
@@ -127,7 +150,7 @@ func (s *searchCmd) applyConstraint(res []*search.Result) ([]*search.Result, err
 		return res, nil
 	}
 
-	constraint, err := semver.NewConstraint(s.version)
+	constraint, err := search.ParseConstraint(s.version)
 	if err != nil {
 		return res, fmt.Errorf("an invalid version/constraint format: %s", err)
 	}
@@ -150,6 +173,25 @@ func (s *searchCmd) applyConstraint(res []*search.Result) ([]*search.Result, err
 	return data, nil
 }
 
+func (s *searchCmd) applyNameRegexp(res []*search.Result) ([]*search.Result, error) {
+	if len(s.nameRegexp) == 0 {
+		return res, nil
+	}
+
+	re, err := regexp.Compile(s.nameRegexp)
+	if err != nil {
+		return res, fmt.Errorf("an invalid name regular expression: %s", err)
+	}
+
+	data := res[:0]
+	for _, r := range res {
+		if re.MatchString(r.Name) {
+			data = append(data, r)
+		}
+	}
+	return data, nil
+}
+
 func (s *searchCmd) buildIndex() (*search.Index, error) {
 	// Load the repositories.yaml
 	rf, err := repo.LoadRepositoriesFile(s.helmhome.RepositoryFile())
@@ -207,7 +249,7 @@ func (r *searchWriter) encodeByFormat(out io.Writer, format outputFormat) error
 	var chartList []chartElement
 
 	for _, r := range r.results {
-		chartList = append(chartList, chartElement{r.Name, r.Chart.Version, r.Chart.AppVersion, r.Chart.Description})
+		chartList = append(chartList, chartElement{r.Name, r.Chart.Version, r.Chart.AppVersion, r.Chart.Description, r.Chart})
 	}
 
 	switch format {
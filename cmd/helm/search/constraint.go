@@ -0,0 +1,64 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package search
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/Masterminds/semver"
+)
+
+// operatorRegex matches a comparison operator written apart from its version,
+// e.g. the ">=" of ">= 1.2".
+var operatorRegex = regexp.MustCompile(`^(=|!=|>|<|>=|=>|<=|=<|~|~>|\^)$`)
+
+// ParseConstraint parses a semantic version range. Besides the ranges of
+// semver.NewConstraint, it accepts comparisons separated by spaces, e.g.
+// ">=1.2 <2", which must all match like comparisons separated by commas.
+func ParseConstraint(expr string) (*semver.Constraints, error) {
+	var ors []string
+	for _, or := range strings.Split(expr, "||") {
+		var ands []string
+		for _, and := range strings.Split(or, ",") {
+			ands = append(ands, splitComparisons(and)...)
+		}
+		ors = append(ors, strings.Join(ands, ", "))
+	}
+	return semver.NewConstraint(strings.Join(ors, " || "))
+}
+
+// splitComparisons splits the comparisons separated by spaces, keeping the
+// operators with their versions and the hyphen ranges, e.g. "1.2 - 1.4",
+// whole.
+func splitComparisons(s string) []string {
+	var comparisons []string
+	fields := strings.Fields(s)
+	for i := 0; i < len(fields); i++ {
+		c := fields[i]
+		switch {
+		case operatorRegex.MatchString(c) && i+1 < len(fields):
+			c += fields[i+1]
+			i++
+		case i+2 < len(fields) && fields[i+1] == "-":
+			c += " - " + fields[i+2]
+			i += 2
+		}
+		comparisons = append(comparisons, c)
+	}
+	return comparisons
+}
@@ -20,6 +20,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/Masterminds/semver"
+
 	"k8s.io/helm/pkg/proto/hapi/chart"
 	"k8s.io/helm/pkg/repo"
 )
@@ -299,3 +301,46 @@ func TestCalcScore(t *testing.T) {
 		t.Errorf("Expected 3, got %d", r)
 	}
 }
+
+func TestParseConstraint(t *testing.T) {
+	tests := []struct {
+		expr    string
+		match   []string
+		nomatch []string
+	}{
+		{">=1.2 <2", []string{"1.2.0", "1.9.9"}, []string{"1.1.0", "2.0.0"}},
+		{">= 1.2 < 2", []string{"1.2.0"}, []string{"2.0.0"}},
+		{">= 0.1, < 0.2", []string{"0.1.5"}, []string{"0.2.0"}},
+		{"1.2 - 1.4 || >=3", []string{"1.3.0", "3.1.0"}, []string{"2.0.0"}},
+		{"^1.2 !=1.5.0", []string{"1.4.0"}, []string{"1.5.0", "2.0.0"}},
+	}
+	for _, tt := range tests {
+		c, err := ParseConstraint(tt.expr)
+		if err != nil {
+			t.Errorf("%q: %s", tt.expr, err)
+			continue
+		}
+		for _, v := range tt.match {
+			if !c.Check(mustVersion(t, v)) {
+				t.Errorf("Expected %q to match %s", tt.expr, v)
+			}
+		}
+		for _, v := range tt.nomatch {
+			if c.Check(mustVersion(t, v)) {
+				t.Errorf("Expected %q not to match %s", tt.expr, v)
+			}
+		}
+	}
+
+	if _, err := ParseConstraint(">=1.2 <"); err == nil {
+		t.Error("Expected an error for an incomplete range")
+	}
+}
+
+func mustVersion(t *testing.T, v string) *semver.Version {
+	version, err := semver.NewVersion(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return version
+}
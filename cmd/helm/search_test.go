@@ -101,13 +101,35 @@ func TestSearchCmd(t *testing.T) {
 			name:     "search for 'maria', expect one match output yaml",
 			args:     []string{"maria"},
 			flags:    strings.Split("--output yaml", " "),
-			expected: "- AppVersion: \"\"\n  Description: Chart for MariaDB\n  Name: testing/mariadb\n  Version: 0.3.0\n\n",
+			expected: "- AppVersion: \"\"\n  Chart:\n(    .*\n)*  Description: Chart for MariaDB\n  Name: testing/mariadb\n  Version: 0.3.0\n\n",
 		},
 		{
 			name:     "search for 'alpine', expect two matches output yaml",
 			args:     []string{"alpine"},
 			flags:    strings.Split("--output yaml", " "),
-			expected: "- AppVersion: 2.3.4\n  Description: Deploy a basic Alpine Linux pod\n  Name: testing/alpine\n  Version: 0.2.0\n\n",
+			expected: "- AppVersion: 2.3.4\n  Chart:\n(    .*\n)*  Description: Deploy a basic Alpine Linux pod\n  Name: testing/alpine\n  Version: 0.2.0\n\n",
+		},
+		{
+			name:     "search for 'maria', expect the index metadata in the json output",
+			args:     []string{"maria"},
+			flags:    strings.Split("--output json", " "),
+			expected: `"Chart":\{.*"home":"https://mariadb\.org"`,
+		},
+		{
+			name:     "search for 'alpine' with a space separated version range, expect one match with version 0.1.0",
+			args:     []string{"alpine"},
+			flags:    []string{"--version", ">=0.1 <0.2"},
+			expected: "NAME          \tCHART VERSION\tAPP VERSION\tDESCRIPTION                    \ntesting/alpine\t0.1.0        \t1.2.3      \tDeploy a basic Alpine Linux pod",
+		},
+		{
+			name:     "search all charts with a name regexp, expect one match",
+			flags:    []string{"--name-regexp", "^testing/maria"},
+			expected: "NAME           \tCHART VERSION\tAPP VERSION\tDESCRIPTION      \ntesting/mariadb\t0.3.0        \t           \tChart for MariaDB\n$",
+		},
+		{
+			name:  "search with an invalid name regexp, expect failure",
+			flags: []string{"--name-regexp", "maria["},
+			err:   true,
 		},
 	}
 
@@ -0,0 +1,114 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+// readSetFile reads the value of a --set-file flag. A directory, or a glob
+// pattern matching no file of its own name, is read as a map of the names of
+// the files it holds or matches to their contents. The subdirectories and
+// the hidden files of a directory are skipped.
+//
+// The contents of a file which is not UTF-8 text are encoded in base64.
+func readSetFile(filePath, certFile, keyFile, caFile string) (interface{}, error) {
+	fi, err := os.Stat(filePath)
+	switch {
+	case err == nil && fi.IsDir():
+		return readSetFileDir(filePath)
+	case os.IsNotExist(err) && !strings.Contains(filePath, "://") && strings.ContainsAny(filePath, "*?["):
+		return readSetFileGlob(filePath)
+	}
+	data, err := readFile(filePath, certFile, keyFile, caFile)
+	if err != nil {
+		return nil, err
+	}
+	return fileValue(data), nil
+}
+
+// readSetFileDir returns the map of the names of the files of the directory
+// dir to their contents.
+func readSetFileDir(dir string) (map[string]interface{}, error) {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	files := map[string]interface{}{}
+	for _, fi := range infos {
+		if !fi.Mode().IsRegular() || strings.HasPrefix(fi.Name(), ".") {
+			continue
+		}
+		data, err := ioutil.ReadFile(filepath.Join(dir, fi.Name()))
+		if err != nil {
+			return nil, err
+		}
+		files[fi.Name()] = fileValue(data)
+	}
+	return files, nil
+}
+
+// readSetFileGlob returns the map of the names of the files matching the
+// glob pattern to their contents. The files matched must have different
+// names.
+func readSetFileGlob(pattern string) (map[string]interface{}, error) {
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+	files := map[string]interface{}{}
+	paths := map[string]string{}
+	for _, path := range matches {
+		fi, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !fi.Mode().IsRegular() {
+			continue
+		}
+		name := filepath.Base(path)
+		if prev, ok := paths[name]; ok {
+			return nil, fmt.Errorf("%s and %s matched by %s have the same name", prev, path, pattern)
+		}
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		paths[name] = path
+		files[name] = fileValue(data)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no file matches %s", pattern)
+	}
+	return files, nil
+}
+
+// fileValue returns the contents of a file as a value: the text of a UTF-8
+// text file, or the contents of a binary file encoded in base64.
+func fileValue(data []byte) string {
+	if utf8.Valid(data) && bytes.IndexByte(data, 0) < 0 {
+		return string(data)
+	}
+	return base64.StdEncoding.EncodeToString(data)
+}
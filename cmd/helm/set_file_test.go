@@ -0,0 +1,82 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadSetFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "helm-set-file-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	certs := filepath.Join(dir, "certs")
+	for name, content := range map[string]string{
+		"certs/ca.crt":      "-----BEGIN CERTIFICATE-----\n",
+		"certs/key.der":     "\x30\x82\x00\xff",
+		"certs/.hidden":     "hidden",
+		"certs/sub/tls.crt": "sub",
+		"other/ca.crt":      "other",
+	} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expect := map[string]interface{}{
+		"ca.crt":  "-----BEGIN CERTIFICATE-----\n",
+		"key.der": "MIIA/w==",
+	}
+	v, err := readSetFile(certs, "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(v, expect) {
+		t.Errorf("Expected the directory to be read as %v, got %v", expect, v)
+	}
+
+	v, err = readSetFile(filepath.Join(certs, "*.crt"), "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expect := map[string]interface{}{"ca.crt": "-----BEGIN CERTIFICATE-----\n"}; !reflect.DeepEqual(v, expect) {
+		t.Errorf("Expected the glob to be read as %v, got %v", expect, v)
+	}
+
+	if v, err = readSetFile(filepath.Join(certs, "key.der"), "", "", ""); err != nil || v != "MIIA/w==" {
+		t.Errorf("Expected the binary file to be encoded in base64, got %v, %v", v, err)
+	}
+
+	if _, err = readSetFile(filepath.Join(dir, "*", "ca.crt"), "", "", ""); err == nil || !strings.Contains(err.Error(), "have the same name") {
+		t.Errorf("Expected the files of the same name to be rejected, got %v", err)
+	}
+	if _, err = readSetFile(filepath.Join(dir, "*.yaml"), "", "", ""); err == nil || !strings.Contains(err.Error(), "no file matches") {
+		t.Errorf("Expected a glob matching no file to fail, got %v", err)
+	}
+}
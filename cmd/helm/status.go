@@ -17,10 +17,12 @@ limitations under the License.
 package main
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"regexp"
 	"text/tabwriter"
+	"time"
 
 	"github.com/gosuri/uitable"
 	"github.com/gosuri/uitable/util/strutil"
@@ -41,6 +43,17 @@ The status consists of:
 - list of resources that this release consists of, sorted by kind
 - details on last test suite run, if applicable
 - additional notes provided by the chart
+
+With '--output json' or '--output yaml', the status also contains the
+inventory of the resources of the release: the API version, kind, name and
+namespace of each resource of the manifest, whether it exists in the cluster,
+and whether it is ready, as '--wait' waits for it.
+
+With '--watch', the command follows a release being installed or upgraded,
+for instance with '--wait' from another terminal: it writes a line each time
+the status of the release or the readiness of one of its resources changes,
+until the release is deployed, failed or deleted, and fails if the release
+failed. With '--output json', each change is written as a line of JSON.
 `
 
 type statusCmd struct {
@@ -49,6 +62,9 @@ type statusCmd struct {
 	client  helm.Interface
 	version int32
 	outfmt  string
+
+	watch         bool
+	watchInterval time.Duration
 }
 
 func newStatusCmd(client helm.Interface, out io.Writer) *cobra.Command {
@@ -77,6 +93,8 @@ func newStatusCmd(client helm.Interface, out io.Writer) *cobra.Command {
 	f := cmd.Flags()
 	settings.AddFlagsTLS(f)
 	f.Int32Var(&status.version, "revision", 0, "If set, display the status of the named release with revision")
+	f.BoolVar(&status.watch, "watch", false, "Write the changes of the status of the release and of its resources until the release is deployed, failed or deleted")
+	f.DurationVar(&status.watchInterval, "watch-interval", 2*time.Second, "How often the status of the release is checked with --watch")
 	bindOutputFlag(cmd, &status.outfmt)
 
 	// set defaults from environment
@@ -86,7 +104,14 @@ func newStatusCmd(client helm.Interface, out io.Writer) *cobra.Command {
 }
 
 func (s *statusCmd) run() error {
-	res, err := s.client.ReleaseStatus(s.release, helm.StatusReleaseVersion(s.version))
+	if s.watch {
+		if s.version != 0 {
+			return errors.New("--watch is not supported with --revision")
+		}
+		return s.watchStatus()
+	}
+	inventory := outputFormat(s.outfmt) != outputTable
+	res, err := s.client.ReleaseStatus(s.release, helm.StatusReleaseVersion(s.version), helm.StatusInventory(inventory))
 	if err != nil {
 		return prettyError(err)
 	}
@@ -120,6 +145,9 @@ func PrintStatus(out io.Writer, res *services.GetReleaseStatusResponse) {
 		fmt.Fprintf(out, "LAST DEPLOYED: %s\n", timeconv.String(res.Info.LastDeployed))
 	}
 	fmt.Fprintf(out, "NAMESPACE: %s\n", res.Namespace)
+	if res.Environment != "" {
+		fmt.Fprintf(out, "ENVIRONMENT: %s\n", res.Environment)
+	}
 	fmt.Fprintf(out, "STATUS: %s\n", res.Info.Status.Code)
 	fmt.Fprintf(out, "\n")
 	if len(res.Info.Status.Resources) > 0 {
@@ -137,6 +165,13 @@ func PrintStatus(out io.Writer, res *services.GetReleaseStatusResponse) {
 			formatTestResults(lastRun.Results))
 	}
 
+	if h := res.Info.Status.FailedHook; h != nil {
+		fmt.Fprintf(out, "FAILED HOOK: %s %s (%s) %s: %s\n\n", h.Event, h.Kind, h.Name, h.Path, h.Error)
+		if h.Logs != "" {
+			fmt.Fprintf(out, "HOOK LOGS:\n%s\n", h.Logs)
+		}
+	}
+
 	if len(res.Info.Status.Notes) > 0 {
 		fmt.Fprintf(out, "NOTES:\n%s\n", res.Info.Status.Notes)
 	}
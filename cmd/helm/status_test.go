@@ -125,6 +125,36 @@ func TestStatusCmd(t *testing.T) {
 				}),
 			},
 		},
+		{
+			name:     "get status of a release with a failed hook",
+			args:     []string{"flummoxed-chickadee"},
+			expected: outputWithStatus("FAILED\n\nFAILED HOOK: pre-install Job \\(db-migrate\\) templates/migrate.yaml: job failed: BackoffLimitExceeded\n\nHOOK LOGS:\n==> db-migrate-x7k2p <==\nmigration 3 failed\n\n"),
+			rels: []*release.Release{
+				releaseMockWithStatus(&release.Status{
+					Code: release.Status_FAILED,
+					FailedHook: &release.HookFailure{
+						Name:  "db-migrate",
+						Kind:  "Job",
+						Path:  "templates/migrate.yaml",
+						Event: "pre-install",
+						Error: "job failed: BackoffLimitExceeded",
+						Logs:  "==> db-migrate-x7k2p <==\nmigration 3 failed\n",
+					},
+				}),
+			},
+		},
+		{
+			name:     "get status of a release deployed with an environment",
+			args:     []string{"flummoxed-chickadee"},
+			expected: fmt.Sprintf("LAST DEPLOYED: %s\nNAMESPACE: \nENVIRONMENT: values-prod.yaml\nSTATUS: DEPLOYED\n\n", dateString),
+			rels: []*release.Release{
+				func() *release.Release {
+					rel := releaseMockWithStatus(&release.Status{Code: release.Status_DEPLOYED})
+					rel.Environment = "values-prod.yaml"
+					return rel
+				}(),
+			},
+		},
 	}
 
 	runReleaseCases(t, tests, func(c *helm.FakeClient, out io.Writer) *cobra.Command {
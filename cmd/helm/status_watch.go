@@ -0,0 +1,135 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/proto/hapi/services"
+)
+
+// statusEvent is an event of 'helm status --watch': a change of the status of
+// the release, or of the readiness of one of its resources.
+type statusEvent struct {
+	Time        string                   `json:"time"`
+	Type        string                   `json:"type"`
+	Release     string                   `json:"release"`
+	Status      string                   `json:"status,omitempty"`
+	Description string                   `json:"description,omitempty"`
+	Resource    *services.ResourceStatus `json:"resource,omitempty"`
+}
+
+// The types of the status events.
+const (
+	statusEventRelease  = "release"
+	statusEventResource = "resource"
+)
+
+// watchStatus polls the status of the release and writes the changes of its
+// status and of the readiness of its resources until the release is deployed,
+// failed or deleted. It fails if the release failed.
+func (s *statusCmd) watchStatus() error {
+	format := outputFormat(s.outfmt)
+	if format != outputTable && format != outputJSON {
+		return fmt.Errorf("--watch only supports the %s and %s output formats", outputTable, outputJSON)
+	}
+	var last *services.GetReleaseStatusResponse
+	for {
+		res, err := s.client.ReleaseStatus(s.release, helm.StatusInventory(true))
+		if err != nil {
+			return prettyError(err)
+		}
+		for _, e := range statusEvents(s.release, last, res) {
+			if err := writeStatusEvent(s.out, e, format); err != nil {
+				return err
+			}
+		}
+		last = res
+
+		switch res.GetInfo().GetStatus().GetCode() {
+		case release.Status_DEPLOYED, release.Status_DELETED:
+			return nil
+		case release.Status_FAILED:
+			return fmt.Errorf("release %s failed: %s", s.release, res.Info.Description)
+		}
+		time.Sleep(s.watchInterval)
+	}
+}
+
+// statusEvents returns the events of the change of the status of the release
+// name from last, which is nil at first, to res.
+func statusEvents(name string, last, res *services.GetReleaseStatusResponse) []*statusEvent {
+	now := time.Now().UTC().Format(time.RFC3339)
+	var events []*statusEvent
+	code := res.GetInfo().GetStatus().GetCode()
+	if last == nil || last.GetInfo().GetStatus().GetCode() != code {
+		e := &statusEvent{Time: now, Type: statusEventRelease, Release: name, Status: code.String()}
+		if code == release.Status_FAILED {
+			e.Description = res.Info.Description
+		}
+		events = append(events, e)
+	}
+
+	seen := map[string]*services.ResourceStatus{}
+	for _, r := range last.GetInventory() {
+		seen[resourceStatusKey(r)] = r
+	}
+	for _, r := range res.Inventory {
+		prev, ok := seen[resourceStatusKey(r)]
+		if ok && prev.Exists == r.Exists && prev.Ready == r.Ready && prev.Reason == r.Reason {
+			continue
+		}
+		events = append(events, &statusEvent{Time: now, Type: statusEventResource, Release: name, Resource: r})
+	}
+	return events
+}
+
+func resourceStatusKey(r *services.ResourceStatus) string {
+	return r.ApiVersion + "/" + r.Kind + "/" + r.Namespace + "/" + r.Name
+}
+
+// writeStatusEvent writes the event e to out, as a progress line, or as a line
+// of JSON.
+func writeStatusEvent(out io.Writer, e *statusEvent, format outputFormat) error {
+	if format == outputJSON {
+		return encodeJSON(out, e)
+	}
+	switch {
+	case e.Type == statusEventRelease && e.Description != "":
+		_, err := fmt.Fprintf(out, "%s: %s: %s\n", e.Release, e.Status, e.Description)
+		return err
+	case e.Type == statusEventRelease:
+		_, err := fmt.Fprintf(out, "%s: %s\n", e.Release, e.Status)
+		return err
+	}
+	r := e.Resource
+	state := "ready"
+	switch {
+	case !r.Exists:
+		state = "not found"
+	case !r.Ready && r.Reason != "":
+		state = "not ready: " + r.Reason
+	case !r.Ready:
+		state = "not ready"
+	}
+	_, err := fmt.Fprintf(out, "%s/%s (%s): %s\n", r.Kind, r.Name, r.Namespace, state)
+	return err
+}
@@ -0,0 +1,126 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/proto/hapi/services"
+)
+
+// watchedStatusClient returns its statuses in turn, and then the last one.
+type watchedStatusClient struct {
+	helm.FakeClient
+	statuses []*services.GetReleaseStatusResponse
+}
+
+func (c *watchedStatusClient) ReleaseStatus(name string, opts ...helm.StatusOption) (*services.GetReleaseStatusResponse, error) {
+	res := c.statuses[0]
+	if len(c.statuses) > 1 {
+		c.statuses = c.statuses[1:]
+	}
+	return res, nil
+}
+
+func watchedStatus(code release.Status_Code, description string, inventory ...*services.ResourceStatus) *services.GetReleaseStatusResponse {
+	return &services.GetReleaseStatusResponse{
+		Name:      "flummoxed-chickadee",
+		Namespace: "default",
+		Info: &release.Info{
+			Status:      &release.Status{Code: code},
+			Description: description,
+		},
+		Inventory: inventory,
+	}
+}
+
+func watchedResource(exists, ready bool, reason string) *services.ResourceStatus {
+	return &services.ResourceStatus{ApiVersion: "apps/v1", Kind: "Deployment", Name: "web", Namespace: "default", Exists: exists, Ready: ready, Reason: reason}
+}
+
+func TestStatusWatch(t *testing.T) {
+	client := &watchedStatusClient{statuses: []*services.GetReleaseStatusResponse{
+		watchedStatus(release.Status_PENDING_INSTALL, "", watchedResource(false, false, "")),
+		watchedStatus(release.Status_PENDING_INSTALL, "", watchedResource(true, false, "0 of 2 replicas available")),
+		watchedStatus(release.Status_PENDING_INSTALL, "", watchedResource(true, false, "0 of 2 replicas available")),
+		watchedStatus(release.Status_DEPLOYED, "Install complete", watchedResource(true, true, "")),
+	}}
+	var buf bytes.Buffer
+	s := &statusCmd{release: "flummoxed-chickadee", out: &buf, client: client, outfmt: string(outputTable), watch: true}
+	if err := s.run(); err != nil {
+		t.Fatal(err)
+	}
+	expect := `flummoxed-chickadee: PENDING_INSTALL
+Deployment/web (default): not found
+Deployment/web (default): not ready: 0 of 2 replicas available
+flummoxed-chickadee: DEPLOYED
+Deployment/web (default): ready
+`
+	if buf.String() != expect {
+		t.Errorf("Expected\n%s\ngot\n%s", expect, buf.String())
+	}
+}
+
+func TestStatusWatchFailed(t *testing.T) {
+	client := &watchedStatusClient{statuses: []*services.GetReleaseStatusResponse{
+		watchedStatus(release.Status_PENDING_UPGRADE, "", watchedResource(true, false, "ImagePullBackOff")),
+		watchedStatus(release.Status_FAILED, "Upgrade \"flummoxed-chickadee\" failed: timed out waiting for the condition", watchedResource(true, false, "ImagePullBackOff")),
+	}}
+	var buf bytes.Buffer
+	s := &statusCmd{release: "flummoxed-chickadee", out: &buf, client: client, outfmt: string(outputJSON), watch: true}
+	err := s.run()
+	if err == nil || !strings.Contains(err.Error(), "timed out waiting for the condition") {
+		t.Fatalf("Expected the release to fail, got %v", err)
+	}
+
+	var events []statusEvent
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var e statusEvent
+		if err := dec.Decode(&e); err != nil {
+			t.Fatal(err)
+		}
+		events = append(events, e)
+	}
+	if len(events) != 3 {
+		t.Fatalf("Expected 3 events, got %d", len(events))
+	}
+	if e := events[1]; e.Type != statusEventResource || e.Resource.Reason != "ImagePullBackOff" {
+		t.Errorf("Expected the resource not to be ready, got %+v", e)
+	}
+	if e := events[2]; e.Type != statusEventRelease || e.Status != "FAILED" || e.Description == "" {
+		t.Errorf("Expected the release to fail, got %+v", e)
+	}
+}
+
+func TestStatusWatchErrors(t *testing.T) {
+	client := &watchedStatusClient{statuses: []*services.GetReleaseStatusResponse{watchedStatus(release.Status_DEPLOYED, "")}}
+	for _, s := range []*statusCmd{
+		{release: "flummoxed-chickadee", client: client, outfmt: string(outputYAML), watch: true},
+		{release: "flummoxed-chickadee", client: client, outfmt: string(outputTable), watch: true, version: 2},
+	} {
+		s.out = &bytes.Buffer{}
+		if err := s.run(); err == nil {
+			t.Errorf("Expected an error watching with %s and revision %d", s.outfmt, s.version)
+		}
+	}
+}
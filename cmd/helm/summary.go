@@ -0,0 +1,114 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gosuri/uitable"
+
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/releaseutil"
+)
+
+// summaryWriter prints the summary of an installed or upgraded release.
+type summaryWriter struct {
+	summary *releaseutil.Summary
+	// resources is the live resource table reported by Tiller, if any.
+	resources string
+}
+
+// newSummaryWriter builds the summary of rel. The status, if not nil, provides
+// the live state of the resources and the rendered notes.
+func newSummaryWriter(rel *release.Release, status *services.GetReleaseStatusResponse) *summaryWriter {
+	w := &summaryWriter{summary: releaseutil.NewSummary(rel)}
+	if st := status.GetInfo().GetStatus(); st != nil {
+		w.resources = st.Resources
+		if st.Notes != "" {
+			w.summary.Notes = st.Notes
+		}
+	}
+	return w
+}
+
+// warn adds a warning to the summary.
+func (w *summaryWriter) warn(format string, args ...interface{}) {
+	w.summary.Warnings = append(w.summary.Warnings, fmt.Sprintf(format, args...))
+}
+
+func (w *summaryWriter) WriteTable(out io.Writer) error {
+	s := w.summary
+	if s.LastDeployed != nil {
+		fmt.Fprintf(out, "LAST DEPLOYED: %s\n", s.LastDeployed.Local().Format(time.ANSIC))
+	}
+	fmt.Fprintf(out, "NAMESPACE: %s\n", s.Namespace)
+	fmt.Fprintf(out, "STATUS: %s\n", s.Status)
+	fmt.Fprintf(out, "REVISION: %d\n", s.Revision)
+	if s.Chart != "" {
+		fmt.Fprintf(out, "CHART: %s\n", s.Chart)
+	}
+	fmt.Fprintf(out, "\n")
+
+	switch {
+	case w.resources != "":
+		re := regexp.MustCompile("  +")
+		tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', tabwriter.TabIndent)
+		fmt.Fprintf(tw, "RESOURCES:\n%s\n", re.ReplaceAllString(w.resources, "\t"))
+		tw.Flush()
+	case len(s.Resources) > 0:
+		fmt.Fprintf(out, "RESOURCES:\n")
+		for _, g := range s.Resources {
+			fmt.Fprintf(out, "==> %s\n%s\n\n", g.Kind, strings.Join(g.Names, "\n"))
+		}
+	}
+
+	if len(s.Hooks) > 0 {
+		tbl := uitable.New()
+		tbl.AddRow("NAME", "KIND", "EVENTS", "LAST RUN")
+		for _, h := range s.Hooks {
+			lastRun := "-"
+			if h.LastRun != nil {
+				lastRun = h.LastRun.Local().Format(time.ANSIC)
+			}
+			tbl.AddRow(h.Name, h.Kind, strings.Join(h.Events, ","), lastRun)
+		}
+		fmt.Fprintf(out, "HOOKS:\n%s\n\n", tbl)
+	}
+
+	if s.Notes != "" {
+		fmt.Fprintf(out, "NOTES:\n%s\n", s.Notes)
+	}
+
+	for _, warning := range s.Warnings {
+		fmt.Fprintf(out, "WARNING: %s\n", warning)
+	}
+	return nil
+}
+
+func (w *summaryWriter) WriteJSON(out io.Writer) error {
+	return encodeJSON(out, w.summary)
+}
+
+func (w *summaryWriter) WriteYAML(out io.Writer) error {
+	return encodeYAML(out, w.summary)
+}
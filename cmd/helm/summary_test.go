@@ -0,0 +1,74 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/proto/hapi/services"
+)
+
+func TestSummaryWriterTable(t *testing.T) {
+	rel := helm.ReleaseMock(&helm.MockReleaseOptions{Name: "virgil"})
+
+	tests := []struct {
+		name     string
+		status   *services.GetReleaseStatusResponse
+		warning  string
+		expected string
+	}{
+		{
+			name:     "grouped resources from manifest",
+			expected: `(?s)STATUS: DEPLOYED\nREVISION: 1\nCHART: foo-0.1.0-beta.1\n\nRESOURCES:\n==> v1/Secret\nfixture\n\nHOOKS:\nNAME\s+KIND\s+EVENTS\s+LAST RUN\s*\npre-install-hook\s+Job\s+PRE_INSTALL\s+`,
+		},
+		{
+			name: "live resources and notes from status",
+			status: &services.GetReleaseStatusResponse{
+				Info: &release.Info{Status: &release.Status{
+					Resources: "==> v1/Secret\nNAME     AGE\nfixture  1s\n",
+					Notes:     "Thanks for installing",
+				}},
+			},
+			expected: `(?s)RESOURCES:\n==> v1/Secret\nNAME\s+AGE\nfixture\s+1s\n.*NOTES:\nThanks for installing\n$`,
+		},
+		{
+			name:     "warnings",
+			warning:  "Dry run failed",
+			expected: `(?s)\nWARNING: Dry run failed\n$`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := newSummaryWriter(rel, tt.status)
+			if tt.warning != "" {
+				w.warn(tt.warning)
+			}
+			var buf bytes.Buffer
+			if err := w.WriteTable(&buf); err != nil {
+				t.Fatal(err)
+			}
+			if !regexp.MustCompile(tt.expected).Match(buf.Bytes()) {
+				t.Errorf("expected\n%q\ngot\n%q", tt.expected, buf.String())
+			}
+		})
+	}
+}
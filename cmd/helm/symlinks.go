@@ -0,0 +1,54 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"github.com/spf13/pflag"
+
+	"k8s.io/helm/pkg/chartutil"
+)
+
+// symlinkPolicy is the value of the --symlinks flag: how the symbolic links
+// of a chart directory are handled.
+type symlinkPolicy chartutil.SymlinkPolicy
+
+func (p *symlinkPolicy) String() string {
+	return chartutil.SymlinkPolicy(*p).String()
+}
+
+func (p *symlinkPolicy) Type() string {
+	return "string"
+}
+
+func (p *symlinkPolicy) Set(value string) error {
+	policy, err := chartutil.ParseSymlinkPolicy(value)
+	if err != nil {
+		return err
+	}
+	*p = symlinkPolicy(policy)
+	return nil
+}
+
+// loadOption returns the option to load a chart directory with the policy.
+func (p symlinkPolicy) loadOption() chartutil.LoadOption {
+	return chartutil.Symlinks(chartutil.SymlinkPolicy(p))
+}
+
+// addSymlinksFlag adds the --symlinks flag, setting p, to fs.
+func addSymlinksFlag(fs *pflag.FlagSet, p *symlinkPolicy) {
+	fs.Var(p, "symlinks", "How the symbolic links of a chart directory are handled: error-on-external, within-chart, ignore, or follow for trusted charts")
+}
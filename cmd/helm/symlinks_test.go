@@ -0,0 +1,44 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"testing"
+
+	"github.com/spf13/pflag"
+
+	"k8s.io/helm/pkg/chartutil"
+)
+
+func TestSymlinksFlag(t *testing.T) {
+	var p symlinkPolicy
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	addSymlinksFlag(fs, &p)
+
+	if chartutil.SymlinkPolicy(p) != chartutil.SymlinksErrorOnExternal {
+		t.Errorf("Expected the external symlinks to be an error by default, got %s", &p)
+	}
+	if err := fs.Parse([]string{"--symlinks", "within-chart"}); err != nil {
+		t.Fatal(err)
+	}
+	if chartutil.SymlinkPolicy(p) != chartutil.SymlinksWithinChart {
+		t.Errorf("Expected within-chart, got %s", &p)
+	}
+	if err := fs.Parse([]string{"--symlinks", "always"}); err == nil {
+		t.Error("Expected an error for an unknown policy")
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package main
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
@@ -24,23 +25,44 @@ import (
 	"path"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/ghodss/yaml"
+	"github.com/gosuri/uitable"
 	"github.com/spf13/cobra"
 
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/deprecation"
+	"k8s.io/helm/pkg/engine"
+	"k8s.io/helm/pkg/kube"
+	"k8s.io/helm/pkg/kustomize"
 	"k8s.io/helm/pkg/manifest"
+	"k8s.io/helm/pkg/plugin"
 	"k8s.io/helm/pkg/proto/hapi/chart"
 	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/redact"
+	"k8s.io/helm/pkg/releaseutil"
 	"k8s.io/helm/pkg/renderutil"
+	"k8s.io/helm/pkg/schema"
 	"k8s.io/helm/pkg/tiller"
 	"k8s.io/helm/pkg/timeconv"
 )
 
 const defaultDirectoryPermission = 0755
 
+// Layouts of the files written to --output-dir.
+const (
+	// layoutPerChart mirrors the template paths of the chart and its subcharts.
+	layoutPerChart = "per-chart"
+	// layoutPerKind groups the rendered resources in one directory per kind.
+	layoutPerKind = "per-kind"
+	// layoutFlat writes all files directly to the output directory.
+	layoutFlat = "flat"
+)
+
 var (
 	whitespaceRegex = regexp.MustCompile(`^\s*$`)
 
@@ -56,9 +78,143 @@ looked up or retrieved in-cluster will be faked locally. Additionally, none
 of the server-side testing of chart validity (e.g. whether an API is supported)
 is done.
 
+The 'lookup' template function returns an empty result unless
+'--enable-lookup' is set, in which case the requested resources are read from
+the cluster of the current kubeconfig context. The cluster is never modified.
+
+'--set-file' reads a value from a file, or a map of the names of the files of
+a directory, or of the files matching a glob pattern, to their contents. The
+contents of binary files are encoded in base64:
+
+	$ helm template mychart --set-file 'tls.certs=certs/*.crt' --set-file dashboards=dashboards/
+
+To list the functions available to templates, with their signature and
+whether they come from Sprig, Helm or the template engine, use
+'--list-functions'. The list is printed as a table, or as JSON or YAML with
+'--output':
+
+	$ helm template --list-functions --output json
+
+To find slow or expensive templates, '--trace-render' prints the render
+duration of every template, the named templates it included and the values it
+references to stderr.
+
+To find why a template of a subchart sees an unexpected value, '--debug-scope'
+prints the values the template is rendered with as .Values, after the globals
+and the values of the parent charts are merged and the aliases of the
+subcharts are resolved, as a comment after the source of every manifest:
+
+	$ helm template mychart --environment prod --debug-scope
+
+With '--strict-templates', a template referencing a value that is not set
+fails the rendering with the file and line of the reference and the path of
+the value, instead of rendering nothing. The values that are intentionally
+optional are allowed with '--strict-allow', or by the chart with the
+'helm.sh/optional-values' annotation of its Chart.yaml. The values under an
+allowed value are allowed too, and '*' matches any key:
+
+	$ helm template mychart --strict-templates --strict-allow ingress --strict-allow 'podAnnotations.*'
+
+To validate the rendered manifests without a cluster, '--schema-validate'
+checks them against the OpenAPI schema of the Kubernetes version of
+'--kube-version', bundled for Kubernetes 1.13 to 1.16. Unknown fields and
+fields of the wrong type are reported for every manifest. The schema of
+another version, or of a cluster with custom resources, can be given with
+'--schema-location':
+
+	$ kubectl get --raw /openapi/v2 > swagger.json
+	$ helm template mychart --schema-validate --schema-location swagger.json
+
+The manifests of kinds without a schema, e.g. custom resources not in the
+schema, are not validated.
+
+The rendered manifests using Kubernetes APIs deprecated in the Kubernetes
+version of '--kube-version' are printed as warnings, with the API version to
+use instead. The command fails if a manifest uses an API removed in that
+version. The APIs used by the deployed releases are found with
+'helm releases scan'.
+
+To develop a chart, '--watch' keeps running after rendering the templates. When
+a file of the chart, an environment values file, or a file passed with
+'--values' or '--set-file' is saved, only the templates affected by the change
+are rendered and printed again. With '--output-dir', only the files that
+changed are written, and the files of removed templates are removed:
+
+	$ helm template mychart --watch -f myvalues.yaml
+
+Charts with many templates can be rendered faster by rendering several
+templates in parallel with '--experimental-render-workers'. Each template is
+then rendered with its own copy of the values, so the changes a template makes
+to .Values, e.g. with 'set', are not seen by the other templates. Templates are
+rendered serially when tracing.
+
 To render just one template in a chart, use '-x':
 
 	$ helm template mychart -x templates/deployment.yaml
+
+When writing to '--output-dir', '--output-dir-layout' selects how the files
+are laid out: 'per-chart' (the default) mirrors the paths of the templates,
+'per-kind' creates one directory per resource kind and 'flat' writes all files
+to the output directory. With '--split-manifests', every rendered resource is
+written to its own file, named '<kind>_<name>.yaml':
+
+	$ helm template mychart --output-dir ./manifests --output-dir-layout flat --split-manifests
+
+To layer kustomize patches on top of the rendered chart, e.g. in a GitOps
+repository, '--as-kustomize' writes the rendered templates to a directory
+like '--output-dir' does, and a kustomization.yaml listing them as resources.
+The kustomization sets the release namespace as namespace, and the release name
+as the 'app.kubernetes.io/instance' common label. The hooks of the chart are
+listed like the other resources:
+
+	$ helm template mychart --name web --namespace prod --as-kustomize ./base
+
+To catch unintended changes of the rendered manifests, '--snapshot' writes
+them to a snapshot directory, one file per template, in a canonical form: the
+keys of every resource are sorted and comments are removed. The directory
+mirrors the rendered templates, so files of other templates are removed from
+it. Once the snapshot is committed, '--verify-snapshot' renders the templates
+again and fails, printing the differences of every template, if they differ
+from the snapshot. Use one snapshot directory per environment:
+
+	$ helm template mychart --environment values-prod.yaml --snapshot snapshots/prod
+	$ helm template mychart --environment values-prod.yaml --snapshot snapshots/prod --verify-snapshot
+
+To validate every environment of a chart at once, e.g. in CI,
+'--environment-matrix' renders the chart once per YAML file of its
+'environments/' directory, each to its own directory of '--output-dir',
+'--as-kustomize' or '--snapshot', named after the environment. A comma-separated
+list of environment values files can be given instead. A summary of the
+environments that failed to render is printed, and the command fails if any did:
+
+	$ helm template mychart --environment-matrix --output-dir ./manifests
+	$ helm template mychart --environment-matrix=environments/prod.yaml,environments/qa.yaml --snapshot snapshots
+
+The custom resource definitions of the 'crds/' directories of the chart and its
+subcharts are not templates, and are not printed unless '--include-crds' is set.
+They are then printed before the rendered templates, as they are installed
+first.
+
+A null value deletes the default value of a key, e.g. '--set image.tag=null'.
+To find which defaults are deleted, '--log-null-deletes' logs every default
+value deleted by a null value with the file or the '--set' value setting it to
+null. '--no-null-deletes' fails instead:
+
+	$ helm template mychart -f myvalues.yaml --no-null-deletes
+
+To audit the hooks of a chart before installing it, '--show-hooks' prints the
+hooks the chart declares with the given values, instead of the rendered
+templates. They are printed in the order Tiller runs them, with the events they
+run on, their weight and their delete policies. '--output' prints them as JSON
+or YAML:
+
+	$ helm template mychart --environment values-prod.yaml --show-hooks
+
+To share the rendered manifests without leaking secrets, '--mask-secrets'
+replaces the values of the data and stringData of the secrets by a placeholder
+with the SHA-256 digest of their content, like "REDACTED sha256:2bb80d537b1da3e3".
+A changed value has a different digest, so the masked manifests can still be
+diffed. Short or guessable values can be found from their digest.
 `
 
 type templateCmd struct {
@@ -70,14 +226,53 @@ type templateCmd struct {
 	stringValues     []string
 	fileValues       []string
 	envValuesFile    string
+	expandEnv        []string
+	symlinks         symlinkPolicy
 	nameTemplate     string
 	showNotes        bool
+	subNotes         bool
 	releaseName      string
 	releaseIsUpgrade bool
 	renderFiles      []string
 	kubeVersion      string
 	apiVersions      []string
 	outputDir        string
+	outputDirLayout  string
+	asKustomize      string
+	splitManifests   bool
+	isolateTemplates bool
+	enableLookup     bool
+	listFunctions    bool
+	output           string
+	traceRender      bool
+	debugScope       bool
+	strictTemplates  bool
+	strictAllow      []string
+	renderWorkers    int
+	policyDir        string
+	schemaValidate   bool
+	schemaLocation   string
+	watch            bool
+	snapshotDir      string
+	verifySnapshot   bool
+	includeCRDs      bool
+	logNullDeletes   bool
+	noNullDeletes    bool
+	showHooks        bool
+	maskSecrets      bool
+	envMatrix        string
+	// crds are the names of the files of the crds/ directories added to the
+	// rendered templates with --include-crds.
+	crds map[string]bool
+	// outputs are the files last written to output-dir, with their content.
+	outputs map[string]string
+	// scopes are the values of the rendered templates, with --debug-scope.
+	scopes map[string]chartutil.Values
+	// redactor masks the sensitive values of the chart in the scopes.
+	redactor *redact.Redactor
+	// renderSubNotes is whether the notes of the subcharts are shown in the
+	// environment being rendered.
+	renderSubNotes bool
 }
 
 func newTemplateCmd(out io.Writer) *cobra.Command {
@@ -96,6 +291,7 @@ func newTemplateCmd(out io.Writer) *cobra.Command {
 	cmd.SetOutput(out)
 	f := cmd.Flags()
 	f.BoolVar(&t.showNotes, "notes", false, "Show the computed NOTES.txt file as well")
+	f.BoolVar(&t.subNotes, "render-subchart-notes", false, "With --notes, show the NOTES.txt files of the subcharts as well, as the environment values file does with a '# helm:subchart-notes' comment")
 	f.StringVarP(&t.releaseName, "name", "n", "release-name", "Release name")
 	f.BoolVar(&t.releaseIsUpgrade, "is-upgrade", false, "Set .Release.IsUpgrade instead of .Release.IsInstall")
 	f.StringArrayVarP(&t.renderFiles, "execute", "x", []string{}, "Only execute the given templates")
@@ -105,19 +301,52 @@ func newTemplateCmd(out io.Writer) *cobra.Command {
 	f.StringArrayVar(&t.stringValues, "set-string", []string{}, "Set STRING values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
 	f.StringArrayVar(&t.fileValues, "set-file", []string{}, "Set values from respective files specified via the command line (can specify multiple or separate values with commas: key1=path1,key2=path2)")
 	f.StringVar(&t.envValuesFile, "environment", "", "Use an environment values file inside the chart and the subcharts")
+	f.StringVar(&t.envMatrix, "environment-matrix", "", "Render the chart once per environment values file of this comma-separated list, or of the environments directory of the chart if no list is given, to a directory per environment")
+	f.Lookup("environment-matrix").NoOptDefVal = matrixAllEnvironments
+	f.StringArrayVar(&t.expandEnv, "expand-env", []string{}, "Expand the environment variables matching a pattern, like CI_*, referenced as ${NAME} or ${NAME:-default} in the values files and the values of the chart. Can be specified multiple times")
+	addSymlinksFlag(f, &t.symlinks)
 	f.StringVar(&t.nameTemplate, "name-template", "", "Specify template used to name the release")
 	f.StringVar(&t.kubeVersion, "kube-version", defaultKubeVersion, "Kubernetes version used as Capabilities.KubeVersion.Major/Minor")
 	f.StringArrayVarP(&t.apiVersions, "api-versions", "a", []string{}, "Kubernetes api versions used for Capabilities.APIVersions")
 	f.StringVar(&t.outputDir, "output-dir", "", "Writes the executed templates to files in output-dir instead of stdout")
+	f.StringVar(&t.outputDirLayout, "output-dir-layout", layoutPerChart, "Layout of the files written to output-dir: per-chart, per-kind or flat")
+	f.StringVar(&t.asKustomize, "as-kustomize", "", "Writes the executed templates to files in this directory, with a kustomization.yaml listing them, to be used as a kustomize base")
+	f.BoolVar(&t.splitManifests, "split-manifests", false, "Write every resource to its own file in output-dir, named <kind>_<name>.yaml")
+	f.BoolVar(&t.isolateTemplates, "isolate-templates", false, "Scope named templates to the chart defining them. Templates of a subchart are included as \"<subchart>.<name>\"")
+	f.BoolVar(&t.enableLookup, "enable-lookup", false, "Read the resources requested by the lookup function from the cluster instead of returning empty results")
+	f.BoolVar(&t.listFunctions, "list-functions", false, "List the functions available to templates and exit")
+	f.BoolVar(&t.traceRender, "trace-render", false, "Print the render duration, included templates and values read of every template to stderr")
+	f.BoolVar(&t.debugScope, "debug-scope", false, "Print the values every template is rendered with, after the globals and the values of the parent charts are merged, as a comment after the source of its manifest")
+	f.BoolVar(&t.strictTemplates, "strict-templates", false, "Fail when a template references a value that is not set, instead of rendering nothing")
+	f.StringArrayVar(&t.strictAllow, "strict-allow", []string{}, "With --strict-templates, allow the templates to reference this value, and the values under it, without it being set, e.g. ingress or podAnnotations.*. Can be specified multiple times")
+	f.BoolVar(&t.watch, "watch", false, "Render the templates affected by changes of the chart or of the values files again when they are saved, until interrupted")
+	f.StringVar(&t.snapshotDir, "snapshot", "", "Write the rendered manifests in a canonical form to the snapshot directory instead of the output")
+	f.BoolVar(&t.verifySnapshot, "verify-snapshot", false, "Compare the rendered manifests with the snapshot directory and fail if they differ, instead of writing it")
+	f.BoolVar(&t.includeCRDs, "include-crds", false, "Include the CRDs of the crds/ directories of the chart and its subcharts, before the rendered templates")
+	f.BoolVar(&t.logNullDeletes, "log-null-deletes", false, "Log every default value deleted by a null value, with the file setting it to null")
+	f.BoolVar(&t.noNullDeletes, "no-null-deletes", false, "Fail instead of deleting default values set to null")
+	f.BoolVar(&t.showHooks, "show-hooks", false, "Print the hooks of the chart with their events, weight and delete policies, in the order they run, instead of the rendered templates")
+	f.BoolVar(&t.maskSecrets, "mask-secrets", false, "Replace the values of the data and stringData of the secrets by a placeholder with the SHA-256 digest of their content")
+	f.IntVar(&t.renderWorkers, "experimental-render-workers", 1, "Number of templates rendered in parallel. Experimental")
+	f.StringVar(&t.policyDir, "policy-dir", "", "Fail if the rendered manifests or the values violate the Rego policies of the .rego files of this directory")
+	f.BoolVar(&t.schemaValidate, "schema-validate", false, "Validate the rendered manifests against the bundled OpenAPI schema of --kube-version, without a cluster")
+	f.StringVar(&t.schemaLocation, "schema-location", "", "Validate against the OpenAPI schema of this file instead of the bundled schema, e.g. the output of 'kubectl get --raw /openapi/v2'. Requires --schema-validate")
+	bindOutputFlag(cmd, &t.output)
 
 	return cmd
 }
 
 func (t *templateCmd) run(cmd *cobra.Command, args []string) error {
-	println("Running template cmd")
+	info("Running template cmd")
+	if t.listFunctions {
+		return write(t.out, &functionsWriter{engine.Functions()}, outputFormat(t.output))
+	}
 	if len(args) < 1 {
 		return errors.New("chart is required")
 	}
+	if outputFormat(t.output) != outputTable && !t.showHooks {
+		return errors.New("--output is only supported with --list-functions and --show-hooks")
+	}
 	// verify chart path exists
 	if _, err := os.Stat(args[0]); err == nil {
 		if t.chartPath, err = filepath.Abs(args[0]); err != nil {
@@ -127,27 +356,68 @@ func (t *templateCmd) run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if t.asKustomize != "" {
+		if t.outputDir != "" {
+			return errors.New("--as-kustomize is not supported with --output-dir")
+		}
+		if err := os.MkdirAll(t.asKustomize, defaultDirectoryPermission); err != nil {
+			return err
+		}
+		t.outputDir = t.asKustomize
+	}
+
 	// verify that output-dir exists if provided
 	if t.outputDir != "" {
 		_, err := os.Stat(t.outputDir)
 		if os.IsNotExist(err) {
 			return fmt.Errorf("output-dir '%s' does not exist", t.outputDir)
 		}
+	} else if t.splitManifests || t.outputDirLayout != layoutPerChart {
+		return errors.New("--output-dir-layout and --split-manifests require --output-dir or --as-kustomize")
+	}
+	switch t.outputDirLayout {
+	case layoutPerChart, layoutPerKind, layoutFlat:
+	default:
+		return fmt.Errorf("unknown output-dir layout %q, must be one of %s, %s or %s", t.outputDirLayout, layoutPerChart, layoutPerKind, layoutFlat)
 	}
 
 	if t.namespace == "" {
 		t.namespace = defaultNamespace()
 	}
 
-	// get combined values and create config
-	rawVals, err := vals(t.valueFiles, t.values, t.stringValues, t.fileValues, "", "", "")
-	if err != nil {
-		return err
+	if t.watch && t.traceRender {
+		return errors.New("--trace-render is not supported with --watch")
+	}
+	if t.snapshotDir != "" && (t.watch || t.outputDir != "") {
+		return errors.New("--snapshot is not supported with --watch, --output-dir or --as-kustomize")
+	}
+	if t.verifySnapshot && t.snapshotDir == "" {
+		return errors.New("--verify-snapshot requires --snapshot")
+	}
+	if len(t.strictAllow) > 0 && !t.strictTemplates {
+		return errors.New("--strict-allow requires --strict-templates")
+	}
+	if t.debugScope && (t.snapshotDir != "" || t.showHooks) {
+		return errors.New("--debug-scope is not supported with --snapshot or --show-hooks")
+	}
+	if t.showHooks && (t.watch || t.snapshotDir != "" || t.outputDir != "") {
+		return errors.New("--show-hooks is not supported with --watch, --snapshot, --output-dir or --as-kustomize")
+	}
+	if t.schemaLocation != "" && !t.schemaValidate {
+		return errors.New("--schema-location requires --schema-validate")
+	}
+	if t.envMatrix != "" {
+		if t.envValuesFile != "" || t.watch || t.showHooks {
+			return errors.New("--environment-matrix is not supported with --environment, --watch or --show-hooks")
+		}
+		if t.outputDir == "" && t.snapshotDir == "" {
+			return errors.New("--environment-matrix requires --output-dir, --as-kustomize or --snapshot")
+		}
 	}
-	config := &chart.Config{Raw: string(rawVals), Values: map[string]*chart.Value{}}
 
 	// If template is specified, try to run the template.
 	if t.nameTemplate != "" {
+		var err error
 		t.releaseName, err = generateName(t.nameTemplate)
 		if err != nil {
 			return err
@@ -158,28 +428,88 @@ func (t *templateCmd) run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("release name %s is invalid: %s", t.releaseName, strings.Join(msgs, ";"))
 	}
 
-	// Check chart requirements to make sure all dependencies are present in /charts
-	c, err := chartutil.LoadWithEnvValuesFile(t.chartPath, t.envValuesFile)
+	if t.envMatrix != "" {
+		return t.renderMatrix()
+	}
+	return t.render()
+}
+
+// render renders the chart and writes the rendered templates.
+func (t *templateCmd) render() error {
+	c, config, err := t.load()
 	if err != nil {
-		return prettyError(err)
+		return err
+	}
+	if err := checkNullDeletes(c, config, t.valueFiles, t.values, t.logNullDeletes, t.noNullDeletes); err != nil {
+		return err
+	}
+	if t.showNotes {
+		if t.renderSubNotes, err = subchartNotes(t.chartPath, t.envValuesFile, t.subNotes, t.symlinks.loadOption()); err != nil {
+			return err
+		}
 	}
 
 	renderOpts := renderutil.Options{
 		ReleaseOptions: chartutil.ReleaseOptions{
-			Name:      t.releaseName,
-			IsInstall: !t.releaseIsUpgrade,
-			IsUpgrade: t.releaseIsUpgrade,
-			Time:      timeconv.Now(),
-			Namespace: t.namespace,
+			Name:        t.releaseName,
+			IsInstall:   !t.releaseIsUpgrade,
+			IsUpgrade:   t.releaseIsUpgrade,
+			Time:        timeconv.Now(),
+			Namespace:   t.namespace,
+			Environment: t.envValuesFile,
 		},
-		KubeVersion: t.kubeVersion,
-		APIVersions: t.apiVersions,
+		KubeVersion:      t.kubeVersion,
+		APIVersions:      t.apiVersions,
+		Strict:           t.strictTemplates,
+		Optional:         t.strictAllow,
+		IsolateTemplates: t.isolateTemplates,
+		Workers:          t.renderWorkers,
+	}
+	if t.enableLookup {
+		renderOpts.Lookup = kube.New(kubeClientGetter()).Lookup
 	}
+	if t.traceRender {
+		renderOpts.Trace = engine.NewRenderTrace()
+	}
+	if t.debugScope {
+		renderOpts.Scopes = map[string]chartutil.Values{}
+	}
+	t.scopes = renderOpts.Scopes
 
-	renderedTemplates, err := renderutil.Render(c, config, renderOpts)
-	if err != nil {
+	// The sensitive values of the chart are masked in the errors and in the
+	// scopes.
+	redactor := redact.New(c, config)
+	t.redactor = redactor
+	var renderedTemplates map[string]string
+	var renderer *renderutil.Renderer
+	if t.watch {
+		if renderer, err = renderutil.NewRenderer(c, config, renderOpts); err != nil {
+			return redactor.Error(err)
+		}
+		renderedTemplates = renderer.Rendered()
+	} else if renderedTemplates, err = renderutil.Render(c, config, renderOpts); err != nil {
+		return redactor.Error(err)
+	}
+	renderedTemplates = t.addCRDs(c, renderedTemplates)
+	if renderedTemplates, err = postTemplateHooks(renderedTemplates); err != nil {
 		return err
 	}
+	if t.policyDir != "" {
+		if err := checkPolicies(t.policyDir, c, config, renderedTemplates); err != nil {
+			return err
+		}
+	}
+	if err := t.validateSchema(renderedTemplates); err != nil {
+		return withExitCode(exitCodeValidationFailure, err)
+	}
+	if err := t.checkDeprecations(renderedTemplates); err != nil {
+		return err
+	}
+	if t.traceRender {
+		if err := writeRenderTrace(logOut, renderOpts.Trace); err != nil {
+			return err
+		}
+	}
 
 	if settings.Debug {
 		rel := &release.Release{
@@ -190,9 +520,452 @@ func (t *templateCmd) run(cmd *cobra.Command, args []string) error {
 			Namespace: t.namespace,
 			Info:      &release.Info{LastDeployed: timeconv.Timestamp(time.Now())},
 		}
-		printRelease(os.Stdout, rel)
+		debugRelease(os.Stdout, rel)
+	}
+
+	if t.showHooks {
+		return t.writeHooks(renderedTemplates)
+	}
+	if t.snapshotDir != "" {
+		if t.verifySnapshot {
+			return t.verifySnapshotDir(renderedTemplates)
+		}
+		return t.writeSnapshot(renderedTemplates)
+	}
+	if err := t.writeManifests(renderedTemplates, nil); err != nil {
+		return err
+	}
+	if t.watch {
+		return t.watchChart(renderer)
+	}
+	return nil
+}
+
+// renderMatrix renders the chart once per environment of
+// --environment-matrix, to a directory per environment of output-dir, of
+// --as-kustomize or of the snapshot directory, and prints a summary of the
+// environments that failed to render.
+func (t *templateCmd) renderMatrix() error {
+	envs, err := t.matrixEnvironments()
+	if err != nil {
+		return err
+	}
+
+	outputDir, asKustomize, snapshotDir := t.outputDir, t.asKustomize, t.snapshotDir
+	failures := map[string]error{}
+	for _, env := range envs {
+		name := matrixEnvironmentName(env)
+		t.envValuesFile = env
+		t.outputs = nil
+		if outputDir != "" {
+			t.outputDir = filepath.Join(outputDir, name)
+			if err := os.MkdirAll(t.outputDir, defaultDirectoryPermission); err != nil {
+				return err
+			}
+		}
+		if asKustomize != "" {
+			t.asKustomize = t.outputDir
+		}
+		if snapshotDir != "" {
+			t.snapshotDir = filepath.Join(snapshotDir, name)
+		}
+		if err := t.render(); err != nil {
+			failures[env] = err
+		}
+	}
+
+	table := uitable.New()
+	table.AddRow("ENVIRONMENT", "RESULT")
+	for _, env := range envs {
+		result := "rendered"
+		if _, ok := failures[env]; ok {
+			result = "failed"
+		}
+		table.AddRow(env, result)
+	}
+	if err := encodeTable(t.out, table); err != nil {
+		return err
+	}
+	if len(failures) == 0 {
+		return nil
+	}
+	for _, env := range envs {
+		if err, ok := failures[env]; ok {
+			fmt.Fprintf(t.out, "\n%s: %s\n", env, err)
+		}
+	}
+	return fmt.Errorf("%d of %d environments failed to render", len(failures), len(envs))
+}
+
+// matrixAllEnvironments is the value of --environment-matrix without a list,
+// rendering the environments of the environments directory of the chart.
+const matrixAllEnvironments = "environments/"
+
+// matrixEnvironments returns the environment values files of
+// --environment-matrix, checking that they are in the chart.
+func (t *templateCmd) matrixEnvironments() ([]string, error) {
+	c, err := chartutil.Load(t.chartPath, t.symlinks.loadOption())
+	if err != nil {
+		return nil, prettyError(err)
+	}
+	envs := chartEnvironments(c)
+	if t.envMatrix != matrixAllEnvironments {
+		files := map[string]bool{}
+		for _, f := range c.Files {
+			files[f.TypeUrl] = true
+		}
+		envs = nil
+		for _, env := range strings.Split(t.envMatrix, ",") {
+			if env = strings.TrimSpace(env); env == "" {
+				continue
+			}
+			if !files[env] {
+				return nil, fmt.Errorf("environment values file %s not found in the chart", env)
+			}
+			envs = append(envs, env)
+		}
+	}
+	if len(envs) == 0 {
+		return nil, errors.New("no environment to render")
+	}
+
+	names := map[string]string{}
+	for _, env := range envs {
+		name := matrixEnvironmentName(env)
+		if other, ok := names[name]; ok {
+			return nil, fmt.Errorf("the environments %s and %s would be rendered to the same directory %s", other, env, name)
+		}
+		names[name] = env
+	}
+	return envs, nil
+}
+
+// chartEnvironments returns the environment values files of the environments
+// directory of the chart c, sorted.
+func chartEnvironments(c *chart.Chart) []string {
+	var envs []string
+	for _, f := range c.Files {
+		if ext := path.Ext(f.TypeUrl); path.Dir(f.TypeUrl) == "environments" && (ext == ".yaml" || ext == ".yml") {
+			envs = append(envs, f.TypeUrl)
+		}
+	}
+	sort.Strings(envs)
+	return envs
+}
+
+// matrixEnvironmentName returns the name of the directory an environment is
+// rendered to by --environment-matrix, like prod for environments/prod.yaml.
+func matrixEnvironmentName(env string) string {
+	return strings.TrimSuffix(path.Base(env), path.Ext(env))
+}
+
+// load loads the chart and the values.
+func (t *templateCmd) load() (*chart.Chart, *chart.Config, error) {
+	// get combined values and create config
+	rawVals, err := vals(t.valueFiles, t.values, t.stringValues, t.fileValues, t.expandEnv, "", "", "")
+	if err != nil {
+		return nil, nil, err
+	}
+	config := &chart.Config{Raw: string(rawVals), Values: map[string]*chart.Value{}}
+
+	// Check chart requirements to make sure all dependencies are present in /charts
+	c, err := chartutil.LoadWithEnvValuesFile(t.chartPath, t.envValuesFile, t.symlinks.loadOption())
+	if err != nil {
+		return nil, nil, prettyError(err)
 	}
+	if err := chartutil.ExpandChartEnv(c, t.expandEnv); err != nil {
+		return nil, nil, err
+	}
+	return c, config, nil
+}
 
+// writeManifests writes the rendered templates to output-dir or to the
+// output. If only is not nil, only the templates it contains are written.
+func (t *templateCmd) writeManifests(renderedTemplates map[string]string, only map[string]bool) error {
+	manifestsToRender, err := t.selectManifests(renderedTemplates)
+	if err != nil {
+		return err
+	}
+
+	// files are the names of the files written to output-dir, and contents
+	// their contents. Resources written to the same file are appended to it.
+	var files []string
+	contents := map[string]string{}
+	for _, m := range t.crdsFirst(tiller.SortByKind(manifestsToRender)) {
+		data := m.Content
+		b := filepath.Base(m.Name)
+		if !t.showNotes && b == "NOTES.txt" {
+			continue
+		}
+		if !t.renderSubNotes && b == "NOTES.txt" && strings.Contains(m.Name, "/charts/") {
+			continue
+		}
+		if strings.HasPrefix(b, "_") {
+			continue
+		}
+		if only != nil && !only[m.Name] {
+			continue
+		}
+		if t.maskSecrets {
+			m.Content = maskManifestSecrets(m.Content)
+			data = m.Content
+		}
+
+		if t.outputDir != "" {
+			// blank template after execution
+			if whitespaceRegex.MatchString(data) {
+				continue
+			}
+			outputs, err := t.outputFiles(m)
+			if err != nil {
+				return err
+			}
+			for _, o := range outputs {
+				content := t.sourceHeader(m.Name) + o.content
+				if prev, ok := contents[o.name]; ok {
+					content = prev + "\n" + content
+				} else {
+					files = append(files, o.name)
+				}
+				contents[o.name] = content
+			}
+			continue
+		}
+		fmt.Fprint(t.out, t.sourceHeader(m.Name))
+		fmt.Fprintln(t.out, data)
+	}
+	if t.outputDir == "" {
+		return nil
+	}
+
+	// Only write the files that changed since they were last written, and
+	// remove the files that are no longer written.
+	for _, name := range files {
+		if prev, ok := t.outputs[name]; ok && prev == contents[name] {
+			continue
+		}
+		if err := writeToFile(t.outputDir, name, contents[name], t.out); err != nil {
+			return err
+		}
+	}
+	for name := range t.outputs {
+		if _, ok := contents[name]; !ok {
+			outfileName := filepath.Join(t.outputDir, name)
+			if err := os.Remove(outfileName); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			fmt.Fprintf(t.out, "removed %s\n", outfileName)
+		}
+	}
+	t.outputs = contents
+	if t.asKustomize != "" {
+		return t.writeKustomization(files)
+	}
+	return nil
+}
+
+// sourceHeader returns the header of the manifest of the template name, with
+// the values of the template with --debug-scope.
+func (t *templateCmd) sourceHeader(name string) string {
+	header := fmt.Sprintf("---\n# Source: %s\n", name)
+	vals, ok := t.scopes[name]
+	if !ok {
+		return header
+	}
+	if len(vals) == 0 {
+		return header + "# Scope: {}\n"
+	}
+	y, err := vals.YAML()
+	if err != nil {
+		return header + fmt.Sprintf("# Scope: %s\n", err)
+	}
+	var b strings.Builder
+	b.WriteString(header)
+	b.WriteString("# Scope:\n")
+	for _, line := range strings.Split(strings.TrimSuffix(t.redactor.String(y), "\n"), "\n") {
+		fmt.Fprintf(&b, "#   %s\n", line)
+	}
+	return b.String()
+}
+
+// writeKustomization writes the kustomization.yaml file of --as-kustomize,
+// listing the files written to it as resources, with the release name as
+// a common label and the release namespace as namespace.
+func (t *templateCmd) writeKustomization(files []string) error {
+	k := &kustomize.Kustomization{
+		Namespace:    t.namespace,
+		CommonLabels: map[string]string{"app.kubernetes.io/instance": t.releaseName},
+	}
+	for _, name := range files {
+		if path.Base(name) != "NOTES.txt" {
+			k.Resources = append(k.Resources, name)
+		}
+	}
+	sort.Strings(k.Resources)
+	if err := k.Write(t.asKustomize); err != nil {
+		return err
+	}
+	fmt.Fprintf(t.out, "wrote %s\n", filepath.Join(t.asKustomize, "kustomization.yaml"))
+	return nil
+}
+
+// writeHooks writes the hooks declared by the rendered templates.
+func (t *templateCmd) writeHooks(renderedTemplates map[string]string) error {
+	manifests := make(map[string]string, len(renderedTemplates))
+	for name, content := range renderedTemplates {
+		// The notes are not manifests.
+		if path.Base(name) != "NOTES.txt" {
+			manifests[name] = content
+		}
+	}
+	hs, err := tiller.ChartHooks(manifests)
+	if err != nil {
+		return err
+	}
+	if t.maskSecrets {
+		for i := range hs {
+			hs[i].Manifest = maskManifestSecrets(hs[i].Manifest)
+		}
+	}
+	return write(t.out, &hooksWriter{hs}, outputFormat(t.output))
+}
+
+// validateSchema validates the rendered manifests against the OpenAPI schema
+// of --schema-location, or against the bundled schema of --kube-version, if
+// --schema-validate is set.
+func (t *templateCmd) validateSchema(renderedTemplates map[string]string) error {
+	if !t.schemaValidate {
+		return nil
+	}
+	var v *schema.Validator
+	var err error
+	if t.schemaLocation != "" {
+		v, err = schema.Load(t.schemaLocation)
+	} else {
+		v, err = schema.New(t.kubeVersion)
+	}
+	if err != nil {
+		return err
+	}
+	return v.Validate(renderedTemplates)
+}
+
+// checkDeprecations warns about the rendered manifests using APIs deprecated
+// in --kube-version, and fails if any uses an API removed in it.
+func (t *templateCmd) checkDeprecations(renderedTemplates map[string]string) error {
+	kubeVersion := t.kubeVersion
+	if kubeVersion == "" {
+		kubeVersion = defaultKubeVersion
+	}
+	findings, err := deprecation.Check(renderedTemplates, kubeVersion)
+	if err != nil {
+		return err
+	}
+	var removed []string
+	for _, f := range findings {
+		if f.Removed {
+			removed = append(removed, "  "+f.String())
+		} else {
+			warning("%s", f)
+		}
+	}
+	if len(removed) > 0 {
+		return fmt.Errorf("%d manifest(s) use APIs removed in Kubernetes %s:\n%s", len(removed), kubeVersion, strings.Join(removed, "\n"))
+	}
+	return nil
+}
+
+// sourceRegex matches the header of a rendered template in the manifests
+// written by writeManifests.
+var sourceRegex = regexp.MustCompile(`(?m)^---\n# Source: (.*)\n`)
+
+// postTemplateHooks runs the post-template hooks of the plugins on the
+// rendered templates, and returns the templates the hooks wrote. The hooks
+// receive the templates as 'helm template' writes them, every template
+// preceded by a '# Source' comment with its name, and must write them in the
+// same way: an output with content outside of the templates, or with a
+// template written twice, is an error rather than being partly dropped.
+func postTemplateHooks(renderedTemplates map[string]string) (map[string]string, error) {
+	names := make([]string, 0, len(renderedTemplates))
+	for name := range renderedTemplates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var b bytes.Buffer
+	for _, name := range names {
+		fmt.Fprintf(&b, "---\n# Source: %s\n%s\n", name, renderedTemplates[name])
+	}
+
+	out, err := runCommandHooks(plugin.PostTemplate, b.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	if bytes.Equal(out, b.Bytes()) {
+		return renderedTemplates, nil
+	}
+
+	result := map[string]string{}
+	data := string(out)
+	matches := sourceRegex.FindAllStringSubmatchIndex(data, -1)
+	if len(matches) == 0 {
+		if strings.TrimSpace(data) != "" {
+			return nil, errors.New("the post-template hooks wrote manifests without '# Source: <template>' headers")
+		}
+		return result, nil
+	}
+	if strings.TrimSpace(data[:matches[0][0]]) != "" {
+		return nil, errors.New("the post-template hooks wrote manifests before the first '# Source: <template>' header")
+	}
+	for i, m := range matches {
+		end := len(data)
+		if i+1 < len(matches) {
+			end = matches[i+1][0]
+		}
+		name := data[m[2]:m[3]]
+		if _, ok := result[name]; ok {
+			return nil, fmt.Errorf("the post-template hooks wrote the template %s twice", name)
+		}
+		result[name] = strings.TrimSuffix(data[m[1]:end], "\n")
+	}
+	return result, nil
+}
+
+// addCRDs returns the rendered templates with the files of the crds/
+// directories of the chart c added, if --include-crds is set.
+func (t *templateCmd) addCRDs(c *chart.Chart, renderedTemplates map[string]string) map[string]string {
+	if !t.includeCRDs {
+		return renderedTemplates
+	}
+	// The rendered templates of a renderer are not modified.
+	result := make(map[string]string, len(renderedTemplates))
+	for name, content := range renderedTemplates {
+		result[name] = content
+	}
+	t.crds = map[string]bool{}
+	for _, crd := range chartutil.CRDs(c) {
+		result[crd.Name] = string(crd.Data)
+		t.crds[crd.Name] = true
+	}
+	return result
+}
+
+// crdsFirst moves the files of the crds/ directories before the templates,
+// as they are installed first.
+func (t *templateCmd) crdsFirst(manifests []manifest.Manifest) []manifest.Manifest {
+	var crds, others []manifest.Manifest
+	for _, m := range manifests {
+		if t.crds[m.Name] {
+			crds = append(crds, m)
+		} else {
+			others = append(others, m)
+		}
+	}
+	return append(crds, others...)
+}
+
+// selectManifests returns the rendered templates selected with --execute, or
+// all of them.
+func (t *templateCmd) selectManifests(renderedTemplates map[string]string) ([]manifest.Manifest, error) {
 	listManifests := manifest.SplitManifests(renderedTemplates)
 	var manifestsToRender []manifest.Manifest
 
@@ -204,7 +977,7 @@ func (t *templateCmd) run(cmd *cobra.Command, args []string) error {
 			if !filepath.IsAbs(f) {
 				newF, err := filepath.Abs(filepath.Join(t.chartPath, f))
 				if err != nil {
-					return fmt.Errorf("could not turn template path %s into absolute path: %s", f, err)
+					return nil, fmt.Errorf("could not turn template path %s into absolute path: %s", f, err)
 				}
 				f = newF
 			}
@@ -226,58 +999,148 @@ func (t *templateCmd) run(cmd *cobra.Command, args []string) error {
 				}
 			}
 			if missing {
-				return fmt.Errorf("could not find template %s in chart", f)
+				return nil, fmt.Errorf("could not find template %s in chart", f)
 			}
 		}
 	} else {
 		// no renderFiles provided, render all manifests in the chart
 		manifestsToRender = listManifests
 	}
+	return manifestsToRender, nil
+}
 
-	for _, m := range tiller.SortByKind(manifestsToRender) {
-		data := m.Content
-		b := filepath.Base(m.Name)
-		if !t.showNotes && b == "NOTES.txt" {
-			continue
+// functionsWriter prints the template functions available to charts.
+type functionsWriter struct {
+	functions []engine.Function
+}
+
+func (w *functionsWriter) WriteTable(out io.Writer) error {
+	table := uitable.New()
+	table.MaxColWidth = 80
+	table.AddRow("NAME", "SOURCE", "SIGNATURE", "DESCRIPTION")
+	for _, f := range w.functions {
+		table.AddRow(f.Name, f.Source, f.Signature, f.Description)
+	}
+	return encodeTable(out, table)
+}
+
+func (w *functionsWriter) WriteJSON(out io.Writer) error {
+	return encodeJSON(out, w.functions)
+}
+
+func (w *functionsWriter) WriteYAML(out io.Writer) error {
+	return encodeYAML(out, w.functions)
+}
+
+// hooksWriter prints the hooks of a chart. The table is the manifests of the
+// hooks, each preceded by comments with its events, weight and delete policies.
+type hooksWriter struct {
+	hooks []tiller.ChartHook
+}
+
+func (w *hooksWriter) WriteTable(out io.Writer) error {
+	for _, h := range w.hooks {
+		fmt.Fprintf(out, "---\n# Source: %s\n# Hook: %s\n# Weight: %g\n", h.Path, strings.Join(h.Events, ","), h.Weight)
+		if len(h.DeletePolicies) > 0 {
+			fmt.Fprintf(out, "# Delete policies: %s\n", strings.Join(h.DeletePolicies, ","))
 		}
-		if strings.HasPrefix(b, "_") {
+		fmt.Fprintln(out, h.Manifest)
+	}
+	return nil
+}
+
+func (w *hooksWriter) WriteJSON(out io.Writer) error {
+	return encodeJSON(out, w.hooks)
+}
+
+func (w *hooksWriter) WriteYAML(out io.Writer) error {
+	return encodeYAML(out, w.hooks)
+}
+
+// outputFile is a file written to output-dir.
+type outputFile struct {
+	// name is the path of the file relative to output-dir.
+	name    string
+	content string
+}
+
+// outputFiles returns the files the rendered template m is written to,
+// according to the layout of output-dir and whether manifests are split.
+// The kinds and names of the resources are part of the paths, so they must not
+// contain path separators or "..".
+func (t *templateCmd) outputFiles(m manifest.Manifest) ([]outputFile, error) {
+	if t.outputDirLayout == layoutPerChart && !t.splitManifests {
+		return []outputFile{{name: m.Name, content: m.Content}}, nil
+	}
+
+	var files []outputFile
+	docs := releaseutil.SplitManifests(m.Content)
+	for i := 0; i < len(docs); i++ {
+		doc := docs[fmt.Sprintf("manifest-%d", i)]
+		if isCommentOnly(doc) {
 			continue
 		}
 
-		if t.outputDir != "" {
-			// blank template after execution
-			if whitespaceRegex.MatchString(data) {
-				continue
+		kind, name := "unknown", strings.TrimSuffix(path.Base(m.Name), path.Ext(m.Name))
+		var head releaseutil.SimpleHead
+		if err := yaml.Unmarshal([]byte(doc), &head); err == nil && head.Kind != "" {
+			kind = strings.ToLower(head.Kind)
+			if head.Metadata != nil && head.Metadata.Name != "" {
+				name = head.Metadata.Name
 			}
-			err = writeToFile(t.outputDir, m.Name, data, t.out)
-			if err != nil {
-				return err
+		}
+		for _, s := range []string{kind, name} {
+			if strings.ContainsAny(s, `/\`) || strings.Contains(s, "..") {
+				return nil, fmt.Errorf("cannot write %s to output-dir: the kind or name %q contains a path separator or \"..\"", m.Name, s)
 			}
-			continue
 		}
-		fmt.Fprintf(t.out, "---\n# Source: %s\n", m.Name)
-		fmt.Fprintln(t.out, data)
+
+		dir := path.Dir(m.Name)
+		switch t.outputDirLayout {
+		case layoutPerKind:
+			dir = kind
+		case layoutFlat:
+			dir = ""
+		}
+		file := path.Base(m.Name)
+		if t.splitManifests {
+			file = kind + "_" + name + ".yaml"
+		}
+		files = append(files, outputFile{name: path.Join(dir, file), content: doc})
 	}
-	return nil
+	return files, nil
 }
 
-// write the <data> to <output-dir>/<name>
-func writeToFile(outputDir string, name string, data string, out io.Writer) error {
+// isCommentOnly returns true if the YAML document has no content but comments.
+func isCommentOnly(doc string) bool {
+	for _, line := range strings.Split(doc, "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+			return false
+		}
+	}
+	return true
+}
+
+// write the <data> to <output-dir>/<name>, or append it if appendData is true
+func writeToFile(outputDir string, name string, content string, out io.Writer) error {
 	outfileName := strings.Join([]string{outputDir, name}, string(filepath.Separator))
+	if rel, err := filepath.Rel(outputDir, filepath.Clean(outfileName)); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("cannot write %s outside of %s", name, outputDir)
+	}
 
 	err := ensureDirectoryForFile(outfileName)
 	if err != nil {
 		return err
 	}
 
-	f, err := os.Create(outfileName)
+	f, err := os.OpenFile(outfileName, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
 	if err != nil {
 		return err
 	}
 
 	defer f.Close()
 
-	_, err = f.WriteString(fmt.Sprintf("---\n# Source: %s\n%s", name, data))
+	_, err = f.WriteString(content)
 
 	if err != nil {
 		return err
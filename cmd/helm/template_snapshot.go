@@ -0,0 +1,348 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+
+	"k8s.io/helm/pkg/releaseutil"
+)
+
+// diffContext is the number of unchanged lines printed around the changed
+// lines of a template differing from the snapshot.
+const diffContext = 3
+
+// writeSnapshot writes the rendered templates to the snapshot directory in a
+// canonical form, one file per template. Only the files that changed are
+// written, and the files of templates that are no longer rendered are removed.
+func (t *templateCmd) writeSnapshot(renderedTemplates map[string]string) error {
+	files, err := t.snapshotFiles(renderedTemplates)
+	if err != nil {
+		return err
+	}
+	existing, err := readSnapshot(t.snapshotDir)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	for _, name := range sortedKeys(files) {
+		if prev, ok := existing[name]; ok && prev == files[name] {
+			continue
+		}
+		if err := writeToFile(t.snapshotDir, name, files[name], t.out); err != nil {
+			return err
+		}
+	}
+	for _, name := range sortedKeys(existing) {
+		if _, ok := files[name]; ok {
+			continue
+		}
+		snapshotFile := filepath.Join(t.snapshotDir, filepath.FromSlash(name))
+		if err := os.Remove(snapshotFile); err != nil {
+			return err
+		}
+		fmt.Fprintf(t.out, "removed %s\n", snapshotFile)
+		// Remove the directories left empty, the removal fails otherwise.
+		for dir := path.Dir(name); dir != "."; dir = path.Dir(dir) {
+			if os.Remove(filepath.Join(t.snapshotDir, filepath.FromSlash(dir))) != nil {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// verifySnapshotDir compares the rendered templates with the snapshot
+// directory. It prints the differences of every template that differs and
+// returns an error if any does.
+func (t *templateCmd) verifySnapshotDir(renderedTemplates map[string]string) error {
+	files, err := t.snapshotFiles(renderedTemplates)
+	if err != nil {
+		return err
+	}
+	existing, err := readSnapshot(t.snapshotDir)
+	if os.IsNotExist(err) {
+		return fmt.Errorf("snapshot '%s' does not exist", t.snapshotDir)
+	} else if err != nil {
+		return err
+	}
+
+	names := sortedKeys(files)
+	for name := range existing {
+		if _, ok := files[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	differ := 0
+	for _, name := range names {
+		prev, inSnapshot := existing[name]
+		current, rendered := files[name]
+		if inSnapshot && rendered && prev == current {
+			continue
+		}
+		differ++
+		from, to := "snapshot/"+name, "rendered/"+name
+		if !inSnapshot {
+			from = "/dev/null"
+		}
+		if !rendered {
+			to = "/dev/null"
+		}
+		fmt.Fprintf(t.out, "--- %s\n+++ %s\n%s", from, to, unifiedDiff(prev, current, diffContext))
+	}
+	if differ > 0 {
+		return fmt.Errorf("%d of %d manifests differ from snapshot '%s'", differ, len(names), t.snapshotDir)
+	}
+	fmt.Fprintf(t.out, "%d manifests match snapshot '%s'\n", len(names), t.snapshotDir)
+	return nil
+}
+
+// snapshotFiles returns the snapshot files of the rendered templates, by the
+// name of the template.
+func (t *templateCmd) snapshotFiles(renderedTemplates map[string]string) (map[string]string, error) {
+	manifests, err := t.selectManifests(renderedTemplates)
+	if err != nil {
+		return nil, err
+	}
+	files := map[string]string{}
+	for _, m := range manifests {
+		b := path.Base(m.Name)
+		if (!t.showNotes && b == "NOTES.txt") || strings.HasPrefix(b, "_") {
+			continue
+		}
+		if whitespaceRegex.MatchString(m.Content) {
+			continue
+		}
+		if b == "NOTES.txt" {
+			files[m.Name] = trimLines(m.Content)
+			continue
+		}
+		if content := canonicalManifest(m.Content); content != "" {
+			files[m.Name] = content
+		}
+	}
+	return files, nil
+}
+
+// canonicalManifest returns the canonical form of a rendered template: the
+// documents with only comments are removed, and the others are encoded again
+// with sorted keys and without comments. Documents that are not YAML objects
+// or lists are kept as they are, without trailing whitespace.
+func canonicalManifest(content string) string {
+	docs := releaseutil.SplitManifests(content)
+	var canonical []string
+	for i := 0; i < len(docs); i++ {
+		doc := docs[fmt.Sprintf("manifest-%d", i)]
+		if isCommentOnly(doc) {
+			continue
+		}
+		var v interface{}
+		if err := yaml.Unmarshal([]byte(doc), &v); err == nil {
+			switch v.(type) {
+			case map[string]interface{}, []interface{}:
+				if b, err := yaml.Marshal(v); err == nil {
+					canonical = append(canonical, string(b))
+					continue
+				}
+			}
+		}
+		canonical = append(canonical, trimLines(doc))
+	}
+	return strings.Join(canonical, "---\n")
+}
+
+// trimLines removes the trailing whitespace of every line of s, and ends it
+// with a newline.
+func trimLines(s string) string {
+	lines := strings.Split(strings.TrimRight(s, " \t\r\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t\r")
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// readSnapshot returns the files of the snapshot directory, by their slash
+// separated path relative to it.
+func readSnapshot(dir string) (map[string]string, error) {
+	if _, err := os.Stat(dir); err != nil {
+		return nil, err
+	}
+	files := map[string]string{}
+	err := filepath.Walk(dir, func(p string, fi os.FileInfo, err error) error {
+		if err != nil || !fi.Mode().IsRegular() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		data, err := ioutil.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = string(data)
+		return nil
+	})
+	return files, err
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// diffLine is a line of a diff: an unchanged (' '), removed ('-') or added
+// ('+') line.
+type diffLine struct {
+	op   byte
+	text string
+}
+
+// diffLines returns the lines of a shortest diff from a to b, computed from
+// their longest common subsequence.
+func diffLines(a, b []string) []diffLine {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var lines []diffLine
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			lines = append(lines, diffLine{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, diffLine{'-', a[i]})
+			i++
+		default:
+			lines = append(lines, diffLine{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		lines = append(lines, diffLine{'-', a[i]})
+	}
+	for ; j < len(b); j++ {
+		lines = append(lines, diffLine{'+', b[j]})
+	}
+	return lines
+}
+
+// unifiedDiff returns the hunks of the unified diff from a to b, with context
+// unchanged lines around the changes.
+func unifiedDiff(a, b string, context int) string {
+	lines := diffLines(splitLines(a), splitLines(b))
+
+	// aLine and bLine are the number of lines of a and b before every line.
+	aLine := make([]int, len(lines)+1)
+	bLine := make([]int, len(lines)+1)
+	for i, l := range lines {
+		aLine[i+1], bLine[i+1] = aLine[i], bLine[i]
+		if l.op != '+' {
+			aLine[i+1]++
+		}
+		if l.op != '-' {
+			bLine[i+1]++
+		}
+	}
+
+	var buf bytes.Buffer
+	for i := 0; i < len(lines); {
+		if lines[i].op == ' ' {
+			i++
+			continue
+		}
+		start := i - context
+		if start < 0 {
+			start = 0
+		}
+		// Extend the hunk over the changes separated by at most twice the
+		// context unchanged lines.
+		end := i
+		for end < len(lines) {
+			if lines[end].op != ' ' {
+				end++
+				continue
+			}
+			next := end
+			for next < len(lines) && lines[next].op == ' ' {
+				next++
+			}
+			if next == len(lines) || next-end > 2*context {
+				end += context
+				if end > len(lines) {
+					end = len(lines)
+				}
+				break
+			}
+			end = next
+		}
+
+		fmt.Fprintf(&buf, "@@ -%s +%s @@\n",
+			hunkRange(aLine[start], aLine[end]-aLine[start]),
+			hunkRange(bLine[start], bLine[end]-bLine[start]))
+		for _, l := range lines[start:end] {
+			fmt.Fprintf(&buf, "%c%s\n", l.op, l.text)
+		}
+		i = end
+	}
+	return buf.String()
+}
+
+// hunkRange formats the range of the lines of a hunk, after the before lines.
+func hunkRange(before, n int) string {
+	if n == 0 {
+		return fmt.Sprintf("%d,0", before)
+	}
+	return fmt.Sprintf("%d,%d", before+1, n)
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
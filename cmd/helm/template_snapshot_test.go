@@ -0,0 +1,132 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"k8s.io/helm/pkg/renderutil"
+)
+
+func renderWatchedChart(t *testing.T, tc *templateCmd) map[string]string {
+	c, config, err := tc.load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rendered, err := renderutil.Render(c, config, renderutil.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rendered
+}
+
+func TestTemplateCmdSnapshot(t *testing.T) {
+	out := bytes.NewBuffer(nil)
+	tc, dir := writeWatchedChart(t, out)
+	defer os.RemoveAll(dir)
+	tc.snapshotDir = filepath.Join(dir, "snapshot")
+	writeWatchedFile(t, dir, "snapshot/moby/templates/old/c.yaml", "c: 1\n")
+
+	if err := tc.verifySnapshotDir(renderWatchedChart(t, tc)); err == nil {
+		t.Fatal("expected the snapshot to differ")
+	}
+	if err := tc.writeSnapshot(renderWatchedChart(t, tc)); err != nil {
+		t.Fatal(err)
+	}
+	for name, expect := range map[string]string{"a.yaml": "a: 1\n", "b.yaml": "b: 1\n"} {
+		data, err := ioutil.ReadFile(filepath.Join(tc.snapshotDir, "moby", "templates", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != expect {
+			t.Errorf("expected %s to be %q, got %q", name, expect, data)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(tc.snapshotDir, "moby", "templates", "old")); !os.IsNotExist(err) {
+		t.Errorf("expected the snapshot of the removed template to be removed, got %v", err)
+	}
+
+	out.Reset()
+	if err := tc.verifySnapshotDir(renderWatchedChart(t, tc)); err != nil {
+		t.Fatal(err)
+	}
+	if expect := "2 manifests match snapshot"; !strings.Contains(out.String(), expect) {
+		t.Errorf("expected %q in %q", expect, out)
+	}
+
+	writeWatchedFile(t, dir, "values.yaml", "a: 2\nb: 1\n")
+	out.Reset()
+	err := tc.verifySnapshotDir(renderWatchedChart(t, tc))
+	if err == nil || !strings.Contains(err.Error(), "1 of 2 manifests differ") {
+		t.Errorf("expected 1 manifest to differ, got %v", err)
+	}
+	expect := "--- snapshot/moby/templates/a.yaml\n+++ rendered/moby/templates/a.yaml\n@@ -1,1 +1,1 @@\n-a: 1\n+a: 2\n"
+	if out.String() != expect {
+		t.Errorf("expected %q, got %q", expect, out)
+	}
+}
+
+func TestCanonicalManifest(t *testing.T) {
+	content := `
+# a comment only document
+---
+# Source: moby/templates/svc.yaml
+kind: Service
+apiVersion: v1
+metadata:
+    name:   moby   # the name
+---
+not yaml: [   
+`
+	expect := "apiVersion: v1\nkind: Service\nmetadata:\n  name: moby\n---\nnot yaml: [\n"
+	if got := canonicalManifest(content); got != expect {
+		t.Errorf("expected %q, got %q", expect, got)
+	}
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	a := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n"
+	b := "1\n2\nthree\n4\n5\n6\n7\n8\n9\n10\n11\n"
+	expect := `@@ -1,6 +1,6 @@
+ 1
+ 2
+-3
++three
+ 4
+ 5
+ 6
+@@ -9,4 +9,3 @@
+ 9
+ 10
+ 11
+-12
+`
+	if got := unifiedDiff(a, b, 3); got != expect {
+		t.Errorf("expected:\n%s\ngot:\n%s", expect, got)
+	}
+	if got := unifiedDiff("", "a\n", 3); got != "@@ -0,0 +1,1 @@\n+a\n" {
+		t.Errorf("unexpected diff of a new file: %q", got)
+	}
+	if got := unifiedDiff(a, a, 3); got != "" {
+		t.Errorf("expected no diff, got %q", got)
+	}
+}
@@ -19,16 +19,30 @@ package main
 import (
 	"bufio"
 	"bytes"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"reflect"
+	"regexp"
+	"runtime"
 	"strings"
 	"testing"
+
+	"k8s.io/helm/pkg/engine"
+	"k8s.io/helm/pkg/kustomize"
+	"k8s.io/helm/pkg/tiller"
 )
 
 var (
-	subchart1ChartPath = "./../../pkg/chartutil/testdata/subpop/charts/subchart1"
-	frobnitzChartPath  = "./../../pkg/chartutil/testdata/frobnitz"
+	subchart1ChartPath    = "./../../pkg/chartutil/testdata/subpop/charts/subchart1"
+	frobnitzChartPath     = "./../../pkg/chartutil/testdata/frobnitz"
+	uselibChartPath       = "testdata/testcharts/uselib"
+	crdsChartPath         = "testdata/testcharts/crds"
+	environmentsChartPath = "testdata/testcharts/environments"
+	hooksChartPath        = "testdata/testcharts/hooks"
+	matrixChartPath       = "testdata/testcharts/matrix"
 )
 
 func TestTemplateCmd(t *testing.T) {
@@ -182,6 +196,63 @@ func TestTemplateCmd(t *testing.T) {
 			expectKey:   "subchart1/templates/service.yaml",
 			expectValue: "kube-api-version/test: v1",
 		},
+		{
+			name:        "check_output_dir_layout_requires_output_dir",
+			desc:        "verify --output-dir-layout fails without --output-dir",
+			args:        []string{subchart1ChartPath, "--output-dir-layout", "flat"},
+			expectError: "require --output-dir",
+		},
+		{
+			name:        "check_unknown_output_dir_layout",
+			desc:        "verify --output-dir-layout fails on an unknown layout",
+			args:        []string{subchart1ChartPath, "--output-dir", ".", "--output-dir-layout", "nested"},
+			expectError: "unknown output-dir layout",
+		},
+		{
+			name:        "check_library_dependency",
+			desc:        "verify the named templates of a library chart are included",
+			args:        []string{uselibChartPath},
+			expectKey:   "uselib/templates/configmap.yaml",
+			expectValue: "app.kubernetes.io/name: uselib\n    app.kubernetes.io/instance: release-name",
+		},
+		{
+			name:        "check_library_dependency_not_rendered",
+			desc:        "verify the templates of a library chart are not rendered",
+			args:        []string{uselibChartPath, "-x", "charts/common/templates/configmap.yaml"},
+			expectError: "could not find template",
+		},
+		{
+			name:        "check_library_chart",
+			desc:        "verify a library chart cannot be rendered",
+			args:        []string{uselibChartPath + "/charts/common"},
+			expectError: "library chart common is not installable",
+		},
+		{
+			name:        "check_include_crds",
+			desc:        "verify the CRDs of the crds/ directories are included with --include-crds",
+			args:        []string{crdsChartPath, "--include-crds"},
+			expectKey:   "crds/charts/crontabs/crds/crontab.yaml",
+			expectValue: "{{ .Values }} is not rendered.\napiVersion: apiextensions.k8s.io/v1beta1\nkind: CustomResourceDefinition",
+		},
+		{
+			name:        "check_include_crds_execute",
+			desc:        "verify a CRD can be selected with -x",
+			args:        []string{crdsChartPath, "--include-crds", "-x", "crds/backup.yaml"},
+			expectKey:   "crds/crds/backup.yaml",
+			expectValue: "name: backups.example.com",
+		},
+		{
+			name:        "check_crds_not_included",
+			desc:        "verify the CRDs are not included without --include-crds",
+			args:        []string{crdsChartPath, "-x", "crds/backup.yaml"},
+			expectError: "could not find template",
+		},
+		{
+			name:        "check_verify_snapshot_requires_snapshot",
+			desc:        "verify --verify-snapshot fails without --snapshot",
+			args:        []string{subchart1ChartPath, "--verify-snapshot"},
+			expectError: "requires --snapshot",
+		},
 	}
 
 	for _, tt := range tests {
@@ -237,3 +308,654 @@ func TestTemplateCmd(t *testing.T) {
 		})
 	}
 }
+
+func TestTemplateCmdIncludeCRDsFirst(t *testing.T) {
+	out := bytes.NewBuffer(nil)
+	cmd := newTemplateCmd(out)
+	cmd.SetArgs([]string{crdsChartPath, "--include-crds"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	var sources []string
+	for _, line := range strings.Split(out.String(), "\n") {
+		if strings.HasPrefix(line, "# Source: ") {
+			sources = append(sources, strings.TrimPrefix(line, "# Source: "))
+		}
+	}
+	// The CRDs are printed before the templates, even before the namespaces.
+	expect := []string{
+		"crds/charts/crontabs/crds/crontab.yaml",
+		"crds/crds/backup.yaml",
+		"crds/templates/namespace.yaml",
+	}
+	if len(sources) < len(expect) || !reflect.DeepEqual(sources[:len(expect)], expect) {
+		t.Errorf("expected the output to start with %v, got %v", expect, sources)
+	}
+}
+
+func TestTemplateCmdNullDeletes(t *testing.T) {
+	defer func() { logOut = os.Stderr }()
+	logs := bytes.NewBuffer(nil)
+	logOut = logs
+
+	args := []string{environmentsChartPath, "-f", "testdata/null-values.yaml", "--set", "image.tag=null"}
+	expect := []string{
+		"image.tag: the default value of chart environments is deleted by a null value in --set image.tag=null",
+		"replicas: the default value of chart environments is deleted by a null value in testdata/null-values.yaml",
+	}
+
+	cmd := newTemplateCmd(bytes.NewBuffer(nil))
+	cmd.SetArgs(append(args, "--log-null-deletes"))
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range expect {
+		if !strings.Contains(logs.String(), e) {
+			t.Errorf("Expected %q in %q", e, logs.String())
+		}
+	}
+
+	logs.Reset()
+	cmd = newTemplateCmd(bytes.NewBuffer(nil))
+	cmd.SetArgs(append(args, "--no-null-deletes"))
+	err := cmd.Execute()
+	if err == nil {
+		t.Fatal("Expected an error with --no-null-deletes")
+	}
+	for _, e := range expect {
+		if !strings.Contains(err.Error(), e) {
+			t.Errorf("Expected %q in %q", e, err)
+		}
+	}
+	if strings.Contains(logs.String(), "WARNING") {
+		t.Errorf("Expected no warnings with --no-null-deletes, got %q", logs.String())
+	}
+}
+
+func TestTemplateCmdOutputDirLayout(t *testing.T) {
+	tests := []struct {
+		name   string
+		args   []string
+		expect []string
+	}{
+		{
+			name: "per-chart",
+			expect: []string{
+				"subchart1/templates/service.yaml",
+				"subchart1/charts/subcharta/templates/service.yaml",
+				"subchart1/charts/subchartb/templates/service.yaml",
+			},
+		},
+		{
+			name:   "per-chart split",
+			args:   []string{"--split-manifests"},
+			expect: []string{"subchart1/templates/service_subchart1.yaml", "subchart1/charts/subcharta/templates/service_subcharta.yaml"},
+		},
+		{
+			name:   "per-kind",
+			args:   []string{"--output-dir-layout", "per-kind"},
+			expect: []string{"service/service.yaml"},
+		},
+		{
+			name:   "per-kind split",
+			args:   []string{"--output-dir-layout", "per-kind", "--split-manifests"},
+			expect: []string{"service/service_subchart1.yaml", "service/service_subcharta.yaml", "service/service_subchartb.yaml"},
+		},
+		{
+			name:   "flat split",
+			args:   []string{"--output-dir-layout", "flat", "--split-manifests"},
+			expect: []string{"service_subchart1.yaml", "service_subcharta.yaml", "service_subchartb.yaml"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "helm-template-")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+
+			cmd := newTemplateCmd(ioutil.Discard)
+			cmd.SetArgs(append([]string{subchart1ChartPath, "--output-dir", dir}, tt.args...))
+			if err := cmd.Execute(); err != nil {
+				t.Fatal(err)
+			}
+
+			for _, name := range tt.expect {
+				data, err := ioutil.ReadFile(filepath.Join(dir, name))
+				if err != nil {
+					t.Errorf("expected file %s: %s", name, err)
+					continue
+				}
+				if !strings.HasPrefix(string(data), "---\n# Source: subchart1/") {
+					t.Errorf("expected source header in %s, got %q", name, data)
+				}
+			}
+		})
+	}
+
+	t.Run("resources of a kind are appended", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "helm-template-")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+
+		cmd := newTemplateCmd(ioutil.Discard)
+		cmd.SetArgs([]string{subchart1ChartPath, "--output-dir", dir, "--output-dir-layout", "per-kind"})
+		if err := cmd.Execute(); err != nil {
+			t.Fatal(err)
+		}
+		data, err := ioutil.ReadFile(filepath.Join(dir, "service", "service.yaml"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c := strings.Count(string(data), "kind: Service"); c != 3 {
+			t.Errorf("expected 3 services, got %d in %q", c, data)
+		}
+	})
+
+	t.Run("names cannot escape output-dir", func(t *testing.T) {
+		parent, err := ioutil.TempDir("", "helm-template-")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(parent)
+		dir := filepath.Join(parent, "out")
+		if err := os.Mkdir(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		cmd := newTemplateCmd(ioutil.Discard)
+		cmd.SetArgs([]string{"testdata/testcharts/alpine", "--output-dir", dir, "--output-dir-layout", "per-kind", "--split-manifests",
+			"--set", "Name=/../../../escape", "--set", "test.Name=x"})
+		if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "contains a path separator") {
+			t.Errorf("expected a path separator error, got %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(parent, "escape.yaml")); !os.IsNotExist(err) {
+			t.Errorf("expected no file outside of output-dir, got %v", err)
+		}
+	})
+}
+
+func TestTemplateCmdAsKustomize(t *testing.T) {
+	dir, err := ioutil.TempDir("", "helm-template-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	base := filepath.Join(dir, "base")
+
+	cmd := newTemplateCmd(ioutil.Discard)
+	cmd.SetArgs([]string{subchart1ChartPath, "--name", "web", "--namespace", "prod", "--as-kustomize", base, "--output-dir-layout", "flat", "--split-manifests"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	k, err := kustomize.LoadKustomization(base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if k.APIVersion != kustomize.KustomizationAPIVersion || k.Kind != "Kustomization" {
+		t.Errorf("Expected a %s Kustomization, got %s %s", kustomize.KustomizationAPIVersion, k.APIVersion, k.Kind)
+	}
+	if k.Namespace != "prod" {
+		t.Errorf("Expected the namespace prod, got %q", k.Namespace)
+	}
+	if l := k.CommonLabels["app.kubernetes.io/instance"]; l != "web" {
+		t.Errorf("Expected the release as the instance label, got %q", l)
+	}
+	for _, r := range []string{"service_subchart1.yaml", "service_subcharta.yaml", "service_subchartb.yaml"} {
+		found := false
+		for _, listed := range k.Resources {
+			found = found || listed == r
+		}
+		if !found {
+			t.Errorf("Expected %s in the resources %v", r, k.Resources)
+		}
+		if _, err := os.Stat(filepath.Join(base, r)); err != nil {
+			t.Errorf("Expected the resource file %s: %s", r, err)
+		}
+	}
+
+	cmd = newTemplateCmd(ioutil.Discard)
+	cmd.SetArgs([]string{subchart1ChartPath, "--as-kustomize", base, "--output-dir", dir})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "not supported with --output-dir") {
+		t.Errorf("Expected --as-kustomize to conflict with --output-dir, got %v", err)
+	}
+}
+
+func TestTemplateCmdEnvironmentMatrix(t *testing.T) {
+	dir, err := ioutil.TempDir("", "helm-template-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var buf bytes.Buffer
+	cmd := newTemplateCmd(&buf)
+	cmd.SetArgs([]string{matrixChartPath, "--name", "web", "--environment-matrix", "--output-dir", dir})
+	if err := cmd.Execute(); err == nil || err.Error() != "1 of 3 environments failed to render" {
+		t.Errorf("Expected the broken environment to fail, got %v", err)
+	}
+	for _, expect := range []string{
+		`environments/broken.yaml\s+failed\b`,
+		`environments/prod.yaml\s+rendered\b`,
+		`environments/staging.yaml\s+rendered\b`,
+		`\nenvironments/broken.yaml: .*image.tag is required`,
+	} {
+		if !regexp.MustCompile(expect).MatchString(buf.String()) {
+			t.Errorf("Expected the summary to match %q, got\n%s", expect, buf.String())
+		}
+	}
+	for env, expect := range map[string]string{
+		"prod":    "replicas: \"3\"\n  image: \"nginx:2.0\"",
+		"staging": "replicas: \"2\"\n  image: \"nginx:1.0\"",
+	} {
+		b, err := ioutil.ReadFile(filepath.Join(dir, env, "matrix", "templates", "configmap.yaml"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(b), expect) {
+			t.Errorf("Expected the %s environment to render %q, got\n%s", env, expect, b)
+		}
+	}
+
+	cmd = newTemplateCmd(ioutil.Discard)
+	cmd.SetArgs([]string{matrixChartPath, "--environment-matrix=environments/prod.yaml,environments/qa.yaml", "--output-dir", dir})
+	if err := cmd.Execute(); err == nil || err.Error() != "environment values file environments/qa.yaml not found in the chart" {
+		t.Errorf("Expected a missing environment to fail, got %v", err)
+	}
+
+	cmd = newTemplateCmd(ioutil.Discard)
+	cmd.SetArgs([]string{matrixChartPath, "--environment-matrix"})
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "requires --output-dir") {
+		t.Errorf("Expected --environment-matrix to require an output directory, got %v", err)
+	}
+}
+
+func TestTemplateCmdListFunctions(t *testing.T) {
+	out := bytes.NewBuffer(nil)
+	cmd := newTemplateCmd(out)
+	cmd.SetArgs([]string{"--list-functions"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	for _, expect := range []string{"NAME", "toYamlPretty", "func(string) string", "mergeOverwrite"} {
+		if !strings.Contains(out.String(), expect) {
+			t.Errorf("Expected %q in %q", expect, out.String())
+		}
+	}
+
+	out.Reset()
+	cmd = newTemplateCmd(out)
+	cmd.SetArgs([]string{"--list-functions", "--output", "json"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	var funcs []engine.Function
+	if err := json.Unmarshal(out.Bytes(), &funcs); err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range funcs {
+		if f.Name == "include" && (f.Source != engine.SourceEngine || f.Usage != "include NAME CONTEXT") {
+			t.Errorf("Unexpected include function %+v", f)
+		}
+	}
+
+	cmd = newTemplateCmd(out)
+	cmd.SetArgs([]string{subchart1ChartPath, "--output", "json"})
+	if err := cmd.Execute(); err == nil {
+		t.Error("Expected error for --output without --list-functions")
+	}
+}
+
+func TestTemplateCmdShowHooks(t *testing.T) {
+	out := bytes.NewBuffer(nil)
+	cmd := newTemplateCmd(out)
+	cmd.SetArgs([]string{hooksChartPath, "--show-hooks"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	expect := `---
+# Source: hooks/templates/backup-job.yaml
+# Hook: pre-upgrade,pre-delete
+# Weight: -5
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: release-name-backup
+`
+	if !strings.HasPrefix(out.String(), expect) {
+		t.Errorf("Expected\n%s\ngot\n%s", expect, out.String())
+	}
+	if strings.Contains(out.String(), "ConfigMap") || strings.Contains(out.String(), "migrate") {
+		t.Errorf("Expected only the backup hook, got\n%s", out.String())
+	}
+
+	out.Reset()
+	cmd = newTemplateCmd(out)
+	cmd.SetArgs([]string{hooksChartPath, "--show-hooks", "--environment", "values-prod.yaml", "--output", "json"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	var hooks []tiller.ChartHook
+	if err := json.Unmarshal(out.Bytes(), &hooks); err != nil {
+		t.Fatal(err)
+	}
+	if len(hooks) != 2 {
+		t.Fatalf("Expected 2 hooks, got %+v", hooks)
+	}
+	migrate := hooks[1]
+	if migrate.Name != "release-name-migrate" || migrate.Weight != 2.5 ||
+		!reflect.DeepEqual(migrate.Events, []string{"pre-install", "pre-upgrade"}) ||
+		!reflect.DeepEqual(migrate.DeletePolicies, []string{"before-hook-creation", "hook-succeeded"}) {
+		t.Errorf("Unexpected migrate hook %+v", migrate)
+	}
+
+	cmd = newTemplateCmd(out)
+	cmd.SetArgs([]string{hooksChartPath, "--show-hooks", "--watch"})
+	if err := cmd.Execute(); err == nil {
+		t.Error("Expected error for --show-hooks with --watch")
+	}
+}
+
+func TestTemplateCmdTraceRender(t *testing.T) {
+	defer func() { logOut = os.Stderr }()
+	trace := bytes.NewBuffer(nil)
+	logOut = trace
+
+	out := bytes.NewBuffer(nil)
+	cmd := newTemplateCmd(out)
+	cmd.SetArgs([]string{subchart1ChartPath, "--trace-render"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(out.String(), "DURATION") {
+		t.Error("Expected the trace not to be written with the manifests")
+	}
+	for _, expect := range []string{"TEMPLATE", "subchart1/templates/service.yaml", "Values.service.name"} {
+		if !strings.Contains(trace.String(), expect) {
+			t.Errorf("Expected %q in %q", expect, trace.String())
+		}
+	}
+}
+
+func TestTemplateCmdRenderWorkers(t *testing.T) {
+	render := func(args ...string) string {
+		out := bytes.NewBuffer(nil)
+		cmd := newTemplateCmd(out)
+		cmd.SetArgs(append([]string{subchart1ChartPath}, args...))
+		if err := cmd.Execute(); err != nil {
+			t.Fatal(err)
+		}
+		return out.String()
+	}
+
+	serial := render()
+	if parallel := render("--experimental-render-workers", "4"); parallel != serial {
+		t.Errorf("Expected parallel rendering to match serial rendering.\nserial:\n%s\nparallel:\n%s", serial, parallel)
+	}
+}
+
+func TestTemplateCmdPostTemplateHook(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("TODO: refactor this test to work on windows")
+	}
+
+	dir, err := ioutil.TempDir("", "helm-template-hooks-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cleanup := resetEnv()
+	defer cleanup()
+	defer os.Unsetenv("HELM_PLUGIN")
+	os.Setenv("HELM_PLUGIN", dir)
+	os.Unsetenv("HELM_NO_PLUGINS")
+
+	writePlugin := func(hook string) {
+		metadata := fmt.Sprintf("name: policy\nversion: 0.1.0\nhooks:\n  post-template: %q\n", hook)
+		if err := os.MkdirAll(filepath.Join(dir, "policy"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, "policy", "plugin.yaml"), []byte(metadata), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writePlugin("sed 's/restartPolicy: Never/restartPolicy: Always/'")
+	out := bytes.NewBuffer(nil)
+	cmd := newTemplateCmd(out)
+	cmd.SetArgs([]string{"testdata/testcharts/alpine", "--set", "test.Name=gopher"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "# Source: alpine/templates/alpine-pod.yaml") || !strings.Contains(out.String(), "restartPolicy: Always") {
+		t.Errorf("Expected the manifests written by the hook, got\n%s", out.String())
+	}
+
+	writePlugin("sed '/^# Source: /d'")
+	cmd = newTemplateCmd(bytes.NewBuffer(nil))
+	cmd.SetArgs([]string{"testdata/testcharts/alpine", "--set", "test.Name=gopher"})
+	err = cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "without '# Source: <template>' headers") {
+		t.Errorf("Expected the manifests without headers to be rejected, got %v", err)
+	}
+
+	writePlugin("echo 'kind: Namespace'; cat")
+	cmd = newTemplateCmd(bytes.NewBuffer(nil))
+	cmd.SetArgs([]string{"testdata/testcharts/alpine", "--set", "test.Name=gopher"})
+	err = cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "before the first '# Source: <template>' header") {
+		t.Errorf("Expected the manifests before the first header to be rejected, got %v", err)
+	}
+
+	writePlugin("echo 'pods must not run as root' >&2; exit 1")
+	cmd = newTemplateCmd(bytes.NewBuffer(nil))
+	cmd.SetArgs([]string{"testdata/testcharts/alpine", "--set", "test.Name=gopher"})
+	err = cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), `the post-template hook of plugin "policy" vetoed the command: pods must not run as root`) {
+		t.Errorf("Expected the hook to veto the command, got %v", err)
+	}
+}
+
+func TestTemplateCmdPolicyDir(t *testing.T) {
+	args := []string{"testdata/testcharts/alpine", "--policy-dir", "testdata/policies", "--set", "test.Name=gopher"}
+	cmd := newTemplateCmd(bytes.NewBuffer(nil))
+	cmd.SetArgs(args)
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd = newTemplateCmd(bytes.NewBuffer(nil))
+	cmd.SetArgs(append(args, "--set", "restartPolicy=Always"))
+	err := cmd.Execute()
+	expect := `1 policy violation(s):
+  alpine/templates/alpine-pod.yaml (Pod/release-name-my-alpine): pod release-name-my-alpine must not always restart`
+	if err == nil || err.Error() != expect {
+		t.Errorf("Expected\n%s\ngot\n%v", expect, err)
+	}
+}
+
+func TestTemplateCmdSchemaValidate(t *testing.T) {
+	args := []string{"testdata/testcharts/webapp", "--schema-validate"}
+	cmd := newTemplateCmd(bytes.NewBuffer(nil))
+	cmd.SetArgs(args)
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd = newTemplateCmd(bytes.NewBuffer(nil))
+	cmd.SetArgs(append(args, "--set", "port=http", "--kube-version", "1.16"))
+	err := cmd.Execute()
+	expect := `1 manifest(s) are not valid for Kubernetes 1.16:
+  webapp/templates/deployment.yaml (Deployment/release-name-web):
+    ValidationError(Deployment.spec.template.spec.containers[0].ports[0].containerPort): invalid type for io.k8s.api.core.v1.ContainerPort.containerPort: got "string", expected "integer"`
+	if err == nil || err.Error() != expect {
+		t.Errorf("Expected\n%s\ngot\n%v", expect, err)
+	}
+
+	cmd = newTemplateCmd(bytes.NewBuffer(nil))
+	cmd.SetArgs(append(args, "--kube-version", "1.9"))
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "no schema is bundled for Kubernetes 1.9") {
+		t.Errorf("Expected no bundled schema for 1.9, got %v", err)
+	}
+
+	cmd = newTemplateCmd(bytes.NewBuffer(nil))
+	cmd.SetArgs([]string{"testdata/testcharts/webapp", "--schema-location", "swagger.json"})
+	if err := cmd.Execute(); err == nil || err.Error() != "--schema-location requires --schema-validate" {
+		t.Errorf("Expected --schema-location to require --schema-validate, got %v", err)
+	}
+}
+
+func TestTemplateCmdDeprecatedAPIs(t *testing.T) {
+	defer func() { logOut = os.Stderr }()
+	logs := bytes.NewBuffer(nil)
+	logOut = logs
+
+	args := []string{"testdata/testcharts/webapp", "--set", "apiVersion=extensions/v1beta1"}
+	cmd := newTemplateCmd(bytes.NewBuffer(nil))
+	cmd.SetArgs(append(args, "--kube-version", "1.15"))
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	expect := "WARNING: webapp/templates/deployment.yaml (Deployment/release-name-web): extensions/v1beta1 is deprecated in Kubernetes 1.9, use apps/v1 instead\n"
+	if !strings.HasSuffix(logs.String(), expect) {
+		t.Errorf("Expected %q, got %q", expect, logs.String())
+	}
+
+	cmd = newTemplateCmd(bytes.NewBuffer(nil))
+	cmd.SetArgs(append(args, "--kube-version", "1.16"))
+	err := cmd.Execute()
+	expectErr := `1 manifest(s) use APIs removed in Kubernetes 1.16:
+  webapp/templates/deployment.yaml (Deployment/release-name-web): extensions/v1beta1 is removed in Kubernetes 1.16, use apps/v1 instead`
+	if err == nil || err.Error() != expectErr {
+		t.Errorf("Expected\n%s\ngot\n%v", expectErr, err)
+	}
+}
+
+func TestTemplateCmdExpandEnv(t *testing.T) {
+	os.Setenv("HELM_TEST_TAG", "abc123")
+	os.Setenv("HELM_TEST_REPLICAS", "5")
+	defer os.Unsetenv("HELM_TEST_TAG")
+	defer os.Unsetenv("HELM_TEST_REPLICAS")
+
+	// The file only allows HELM_TEST_TAG
+	cmd := newTemplateCmd(ioutil.Discard)
+	cmd.SetArgs([]string{matrixChartPath, "-f", "testdata/expand-env.yaml"})
+	expectErr := "failed to expand testdata/expand-env.yaml: replicas: the environment variable HELM_TEST_REPLICAS is not allowed to be expanded"
+	if err := cmd.Execute(); err == nil || err.Error() != expectErr {
+		t.Errorf("Expected\n%s\ngot\n%v", expectErr, err)
+	}
+
+	var buf bytes.Buffer
+	cmd = newTemplateCmd(&buf)
+	cmd.SetArgs([]string{matrixChartPath, "-f", "testdata/expand-env.yaml", "--expand-env", "HELM_TEST_REPLICAS"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if expect := "replicas: \"5\"\n  image: \"nginx:abc123\""; !strings.Contains(buf.String(), expect) {
+		t.Errorf("Expected %q, got\n%s", expect, buf.String())
+	}
+}
+
+func TestTemplateCmdTemplatedEnvironment(t *testing.T) {
+	var buf bytes.Buffer
+	cmd := newTemplateCmd(&buf)
+	cmd.SetArgs([]string{matrixChartPath, "--name", "canary", "--environment", "values-canary.yaml"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if expect := "replicas: \"1\"\n  image: \"nginx-canary:0.1.0-canary\""; !strings.Contains(buf.String(), expect) {
+		t.Errorf("Expected %q, got\n%s", expect, buf.String())
+	}
+}
+
+func TestTemplateSubchartNotes(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected bool
+	}{
+		{"notes of the chart only", []string{"--notes"}, false},
+		{"notes of the subcharts", []string{"--notes", "--render-subchart-notes"}, true},
+		{"notes of the subcharts in the environment", []string{"--notes", "--environment", "values-prod.yaml"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := bytes.NewBuffer(nil)
+			cmd := newTemplateCmd(out)
+			cmd.SetArgs(append([]string{"testdata/testcharts/subnotes"}, tt.args...))
+			if err := cmd.Execute(); err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(out.String(), "# Source: subnotes/templates/NOTES.txt\nThe umbrella is installed.") {
+				t.Errorf("Expected the notes of the chart, got\n%s", out.String())
+			}
+			sub := strings.Contains(out.String(), "# Source: subnotes/charts/web/templates/NOTES.txt\nThe web component listens on port 8080.")
+			if sub != tt.expected {
+				t.Errorf("Expected the notes of the subchart to be shown: %t, got\n%s", tt.expected, out.String())
+			}
+		})
+	}
+}
+
+func TestTemplateCmdDebugScope(t *testing.T) {
+	out := bytes.NewBuffer(nil)
+	cmd := newTemplateCmd(out)
+	cmd.SetArgs([]string{subchart1ChartPath, "--debug-scope", "--set", "global.env=prod,subcharta.service.name=httpd"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	expect := "# Source: subchart1/charts/subcharta/templates/service.yaml\n# Scope:\n"
+	if !strings.Contains(out.String(), expect) {
+		t.Fatalf("Expected %q in %q", expect, out.String())
+	}
+	scope := out.String()[strings.Index(out.String(), expect)+len(expect):]
+	scope = scope[:strings.Index(scope, "apiVersion:")]
+	for _, expect := range []string{"#   global:\n", "#     env: prod\n", "#   service:\n", "#     name: httpd\n"} {
+		if !strings.Contains(scope, expect) {
+			t.Errorf("Expected %q in the scope %q", expect, scope)
+		}
+	}
+	if strings.Contains(scope, "SC1data") {
+		t.Errorf("Expected the values of the parent chart not to be in the scope %q", scope)
+	}
+
+	cmd = newTemplateCmd(out)
+	cmd.SetArgs([]string{subchart1ChartPath, "--debug-scope", "--show-hooks"})
+	if err := cmd.Execute(); err == nil {
+		t.Error("Expected error for --debug-scope with --show-hooks")
+	}
+}
+
+func TestTemplateCmdStrictTemplates(t *testing.T) {
+	chartPath := "testdata/testcharts/alpine"
+	out := bytes.NewBuffer(nil)
+	cmd := newTemplateCmd(out)
+	cmd.SetArgs([]string{chartPath, "--strict-templates"})
+	err := cmd.Execute()
+	expect := "templates/alpine-pod.yaml:16:22: missing value test (.Values.test.Name)"
+	if err == nil || !strings.Contains(err.Error(), expect) {
+		t.Fatalf("Expected the error %q, got %v", expect, err)
+	}
+
+	out.Reset()
+	cmd = newTemplateCmd(out)
+	cmd.SetArgs([]string{chartPath, "--strict-templates", "--strict-allow", "test", "--strict-allow", "restartPolicy"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "restartPolicy: Never") {
+		t.Errorf("Expected the default of the optional value in %q", out.String())
+	}
+
+	cmd = newTemplateCmd(out)
+	cmd.SetArgs([]string{chartPath, "--strict-allow", "test"})
+	if err := cmd.Execute(); err == nil {
+		t.Error("Expected error for --strict-allow without --strict-templates")
+	}
+}
@@ -0,0 +1,70 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/gosuri/uitable"
+
+	"k8s.io/helm/pkg/engine"
+)
+
+// writeRenderTrace prints the render trace, slowest templates first. With
+// --log-format json the trace is printed as a JSON object.
+func writeRenderTrace(out io.Writer, trace *engine.RenderTrace) error {
+	if jsonLogging() {
+		return encodeJSON(out, trace)
+	}
+
+	templates := append([]*engine.TemplateTrace{}, trace.Templates...)
+	sort.SliceStable(templates, func(i, j int) bool { return templates[i].Duration > templates[j].Duration })
+	table := uitable.New()
+	table.MaxColWidth = 60
+	table.Wrap = true
+	table.AddRow("TEMPLATE", "DURATION", "DEPTH", "INCLUDES", "VALUES")
+	for _, t := range templates {
+		table.AddRow(t.Name, t.Duration, t.MaxDepth, strings.Join(t.Includes, ", "), strings.Join(t.Values, ", "))
+	}
+	if err := encodeTable(out, table); err != nil {
+		return err
+	}
+
+	if len(trace.Includes) == 0 {
+		return nil
+	}
+	includes := make([]*engine.IncludeTrace, 0, len(trace.Includes))
+	for _, it := range trace.Includes {
+		includes = append(includes, it)
+	}
+	sort.Slice(includes, func(i, j int) bool {
+		if includes[i].Duration == includes[j].Duration {
+			return includes[i].Name < includes[j].Name
+		}
+		return includes[i].Duration > includes[j].Duration
+	})
+	table = uitable.New()
+	table.AddRow("NAMED TEMPLATE", "CALLS", "DURATION", "RECURSIVE")
+	for _, it := range includes {
+		table.AddRow(it.Name, it.Calls, it.Duration, it.Recursive)
+	}
+	fmt.Fprintln(out)
+	return encodeTable(out, table)
+}
@@ -0,0 +1,123 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"k8s.io/helm/pkg/renderutil"
+)
+
+// watchInterval is how often the watched files are checked for changes with
+// --watch. The templates are rendered again once the files did not change for
+// an interval, so that saving several files renders them once.
+const watchInterval = 500 * time.Millisecond
+
+// watchChart renders the templates affected by changes of the watched files
+// again, until the command is interrupted.
+func (t *templateCmd) watchChart(r *renderutil.Renderer) error {
+	info("Watching %s for changes", t.chartPath)
+	last := t.modTimes()
+	pending := false
+	for {
+		time.Sleep(watchInterval)
+		current := t.modTimes()
+		if !reflect.DeepEqual(last, current) {
+			last = current
+			pending = true
+			continue
+		}
+		if pending {
+			pending = false
+			if err := t.rerender(r); err != nil {
+				warning("%s", err)
+			}
+		}
+	}
+}
+
+// rerender loads the chart and the values again and renders the templates
+// affected by the changes. Only the templates that were rendered again are
+// written to the output, and only the files that changed to output-dir.
+func (t *templateCmd) rerender(r *renderutil.Renderer) error {
+	c, config, err := t.load()
+	if err != nil {
+		return err
+	}
+	names, err := r.Update(c, config)
+	if err != nil {
+		return err
+	}
+	info("Rendered %d templates again", len(names))
+	rendered, err := postTemplateHooks(t.addCRDs(c, r.Rendered()))
+	if err != nil {
+		return err
+	}
+	if t.policyDir != "" {
+		if err := checkPolicies(t.policyDir, c, config, rendered); err != nil {
+			return err
+		}
+	}
+	if err := t.validateSchema(rendered); err != nil {
+		return err
+	}
+	if err := t.checkDeprecations(rendered); err != nil {
+		return err
+	}
+	if t.outputDir != "" {
+		return t.writeManifests(rendered, nil)
+	}
+	only := map[string]bool{}
+	for _, name := range names {
+		only[name] = true
+	}
+	for name := range t.crds {
+		only[name] = true
+	}
+	return t.writeManifests(rendered, only)
+}
+
+// modTimes returns the modification times of the watched files: the files of
+// the chart and its subcharts, including the environment values files, and
+// the local files passed with --values and --set-file.
+func (t *templateCmd) modTimes() map[string]time.Time {
+	times := map[string]time.Time{}
+	filepath.Walk(t.chartPath, func(path string, fi os.FileInfo, err error) error {
+		if err == nil && !fi.IsDir() {
+			times[path] = fi.ModTime()
+		}
+		return nil
+	})
+	files := append([]string{}, t.valueFiles...)
+	for _, set := range t.fileValues {
+		for _, kv := range strings.Split(set, ",") {
+			if i := strings.Index(kv, "="); i > 0 {
+				files = append(files, kv[i+1:])
+			}
+		}
+	}
+	for _, f := range files {
+		if fi, err := os.Stat(f); err == nil {
+			times[f] = fi.ModTime()
+		}
+	}
+	return times
+}
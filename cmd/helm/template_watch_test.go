@@ -0,0 +1,134 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"k8s.io/helm/pkg/renderutil"
+)
+
+// writeWatchedChart writes a chart with two templates and a values file to a
+// new directory, and returns a templateCmd rendering them.
+func writeWatchedChart(t *testing.T, out *bytes.Buffer) (*templateCmd, string) {
+	dir, err := ioutil.TempDir("", "helm-template-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"moby/Chart.yaml":       "name: moby\nversion: 0.1.0\n",
+		"moby/templates/a.yaml": "a: {{ .Values.a }}",
+		"moby/templates/b.yaml": "b: {{ .Values.b }}",
+		"values.yaml":           "a: 1\nb: 1\n",
+		"c.txt":                 "c",
+	}
+	for name, data := range files {
+		writeWatchedFile(t, dir, name, data)
+	}
+	return &templateCmd{
+		out:             out,
+		chartPath:       filepath.Join(dir, "moby"),
+		valueFiles:      valueFiles{filepath.Join(dir, "values.yaml")},
+		fileValues:      []string{"c=" + filepath.Join(dir, "c.txt")},
+		outputDirLayout: layoutPerChart,
+	}, dir
+}
+
+func writeWatchedFile(t *testing.T, dir, name, data string) {
+	if err := os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func newWatchedRenderer(t *testing.T, tc *templateCmd) *renderutil.Renderer {
+	c, config, err := tc.load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := renderutil.NewRenderer(c, config, renderutil.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tc.writeManifests(r.Rendered(), nil); err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func TestTemplateCmdRerender(t *testing.T) {
+	out := bytes.NewBuffer(nil)
+	tc, dir := writeWatchedChart(t, out)
+	defer os.RemoveAll(dir)
+	r := newWatchedRenderer(t, tc)
+
+	out.Reset()
+	writeWatchedFile(t, dir, "values.yaml", "a: 1\nb: 2\n")
+	if err := tc.rerender(r); err != nil {
+		t.Fatal(err)
+	}
+	if expect := "---\n# Source: moby/templates/b.yaml\nb: 2\n"; out.String() != expect {
+		t.Errorf("Expected only the changed template %q, got %q", expect, out.String())
+	}
+}
+
+func TestTemplateCmdRerenderOutputDir(t *testing.T) {
+	out := bytes.NewBuffer(nil)
+	tc, dir := writeWatchedChart(t, out)
+	defer os.RemoveAll(dir)
+	tc.outputDir = filepath.Join(dir, "out")
+	r := newWatchedRenderer(t, tc)
+
+	out.Reset()
+	writeWatchedFile(t, dir, "values.yaml", "a: 2\nb: 1\n")
+	if err := os.Remove(filepath.Join(dir, "moby/templates/b.yaml")); err != nil {
+		t.Fatal(err)
+	}
+	if err := tc.rerender(r); err != nil {
+		t.Fatal(err)
+	}
+
+	a := filepath.Join(tc.outputDir, "moby/templates/a.yaml")
+	b := filepath.Join(tc.outputDir, "moby/templates/b.yaml")
+	if expect := "wrote " + a + "\nremoved " + b + "\n"; out.String() != expect {
+		t.Errorf("Expected %q, got %q", expect, out.String())
+	}
+	if data, err := ioutil.ReadFile(a); err != nil || string(data) != "---\n# Source: moby/templates/a.yaml\na: 2" {
+		t.Errorf("Expected %s to be written again, got %q (%v)", a, data, err)
+	}
+	if _, err := os.Stat(b); !os.IsNotExist(err) {
+		t.Errorf("Expected %s to be removed", b)
+	}
+}
+
+func TestTemplateCmdModTimes(t *testing.T) {
+	tc, dir := writeWatchedChart(t, bytes.NewBuffer(nil))
+	defer os.RemoveAll(dir)
+
+	times := tc.modTimes()
+	for _, name := range []string{"moby/Chart.yaml", "moby/templates/a.yaml", "moby/templates/b.yaml", "values.yaml", "c.txt"} {
+		if _, ok := times[filepath.Join(dir, name)]; !ok {
+			t.Errorf("Expected %s to be watched, got %v", name, times)
+		}
+	}
+}
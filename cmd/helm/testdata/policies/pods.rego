@@ -0,0 +1,12 @@
+package helm
+
+deny[msg] {
+	input.manifest.kind == "Pod"
+	input.manifest.spec.restartPolicy == "Always"
+	msg := sprintf("pod %s must not always restart", [input.manifest.metadata.name])
+}
+
+deny_values[msg] {
+	input.values.test.Name == "root"
+	msg := "test.Name must not be root"
+}
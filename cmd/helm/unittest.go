@@ -0,0 +1,143 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/charttest"
+)
+
+const unittestDesc = `
+This command runs the unit tests of a chart.
+
+The tests are declared in the files of the 'tests/' directory of the chart
+named '*_test.yaml'. Every file is a suite of tests. A test renders the chart
+locally, with an environment values file and values, and asserts the rendered
+manifests of some templates:
+
+	suite: deployment
+	templates:
+	  - templates/deployment.yaml
+	tests:
+	  - it: uses the production tag
+	    environment: values-prod.yaml
+	    set:
+	      image.tag: "2.0"
+	    asserts:
+	      - isKind:
+	          of: Deployment
+	      - equal:
+	          path: spec.template.spec.containers[0].image
+	          value: nginx:2.0
+
+The assertions are equal, exists, matchRegex, contains, isKind, hasDocuments
+and failedTemplate. Every assertion can be inverted with 'not: true'. The
+assertions on the content of the manifests apply to every rendered document,
+unless 'documentIndex' selects one.
+
+To report the results to a CI system, '--junit' writes them to a JUnit XML
+file.
+`
+
+type unittestCmd struct {
+	out         io.Writer
+	chartPath   string
+	junitFile   string
+	kubeVersion string
+	apiVersions []string
+}
+
+func newUnittestCmd(out io.Writer) *cobra.Command {
+	u := &unittestCmd{out: out}
+	cmd := &cobra.Command{
+		Use:   "unittest [flags] CHART",
+		Short: "Run the unit tests of a chart",
+		Long:  unittestDesc,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkArgsLength(len(args), "chart path"); err != nil {
+				return err
+			}
+			u.chartPath = args[0]
+			return u.run()
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVar(&u.junitFile, "junit", "", "Write the results to a JUnit XML file")
+	f.StringVar(&u.kubeVersion, "kube-version", defaultKubeVersion, "Kubernetes version used as Capabilities.KubeVersion.Major/Minor")
+	f.StringArrayVarP(&u.apiVersions, "api-versions", "a", []string{}, "Kubernetes api versions used for Capabilities.APIVersions")
+
+	return cmd
+}
+
+func (u *unittestCmd) run() error {
+	result, err := charttest.Run(u.chartPath, charttest.Options{
+		KubeVersion: u.kubeVersion,
+		APIVersions: u.apiVersions,
+	})
+	if err != nil {
+		return err
+	}
+	if len(result.Suites) == 0 {
+		fmt.Fprintf(u.out, "No test suites found in %s/\n", charttest.TestsDir)
+		return nil
+	}
+
+	var suitesFailed, tests, testsFailed int
+	for _, s := range result.Suites {
+		status := "PASS"
+		if !s.Passed() {
+			status = "FAIL"
+			suitesFailed++
+		}
+		fmt.Fprintf(u.out, "%s  %s\t%s\n", status, s.Name, s.File)
+		for _, t := range s.Tests {
+			tests++
+			if t.Passed() {
+				continue
+			}
+			testsFailed++
+			fmt.Fprintf(u.out, "  - %s\n", t.Name)
+			for _, f := range t.Failures {
+				fmt.Fprintf(u.out, "      %s\n", f)
+			}
+		}
+	}
+	fmt.Fprintf(u.out, "\nSuites: %d passed, %d failed, %d total\n", len(result.Suites)-suitesFailed, suitesFailed, len(result.Suites))
+	fmt.Fprintf(u.out, "Tests:  %d passed, %d failed, %d total\n", tests-testsFailed, testsFailed, tests)
+
+	if u.junitFile != "" {
+		f, err := os.Create(u.junitFile)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		if err := result.WriteJUnit(f); err != nil {
+			return err
+		}
+	}
+
+	if testsFailed > 0 {
+		return fmt.Errorf("%d of %d tests failed", testsFailed, tests)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestUnittestCmd(t *testing.T) {
+	out := bytes.NewBuffer(nil)
+	cmd := newUnittestCmd(out)
+	cmd.SetArgs([]string{"./../../pkg/charttest/testdata/moby"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	expect := "PASS  config\ttests/config_test.yaml\nPASS  deployment\ttests/deployment_test.yaml\n\nSuites: 2 passed, 0 failed, 2 total\nTests:  5 passed, 0 failed, 5 total\n"
+	if out.String() != expect {
+		t.Errorf("Expected %q, got %q", expect, out.String())
+	}
+}
+
+func TestUnittestCmdFailing(t *testing.T) {
+	dir, err := ioutil.TempDir("", "helm-unittest-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	junit := filepath.Join(dir, "report.xml")
+
+	out := bytes.NewBuffer(nil)
+	cmd := newUnittestCmd(out)
+	cmd.SetOutput(ioutil.Discard)
+	cmd.SetArgs([]string{"./../../pkg/charttest/testdata/failing", "--junit", junit})
+	err = cmd.Execute()
+	if err == nil || err.Error() != "1 of 2 tests failed" {
+		t.Errorf("Expected the tests to fail, got %v", err)
+	}
+	for _, e := range []string{"FAIL  service\ttests/service_test.yaml", "  - uses port 8080\n      templates/service.yaml#0: equal spec.ports[0].port: expected 8080, got 80", "Tests:  1 passed, 1 failed, 2 total"} {
+		if !strings.Contains(out.String(), e) {
+			t.Errorf("Expected %q in %q", e, out.String())
+		}
+	}
+
+	report, err := ioutil.ReadFile(junit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(report), `<testsuite name="service" file="tests/service_test.yaml" tests="2" failures="1"`) {
+		t.Errorf("Unexpected JUnit report %s", report)
+	}
+}
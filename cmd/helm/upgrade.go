@@ -17,6 +17,7 @@ limitations under the License.
 package main
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"strings"
@@ -25,6 +26,7 @@ import (
 
 	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/plugin"
 	"k8s.io/helm/pkg/renderutil"
 	storageerrors "k8s.io/helm/pkg/storage/errors"
 )
@@ -47,6 +49,33 @@ To customize the chart values, use any of
 To edit or append to the existing customized values, add the
  '--reuse-values' flag, otherwise any existing customized values are ignored.
 
+'--reuse-values-strategy' selects how the existing customized values are combined
+with the new values, from the highest to the lowest precedence:
+ - 'merge' (the default): new values, existing customized values, then the values
+   computed for the last release. The default values of the new chart and of the
+   '--environment' file are ignored,
+ - 'replace': new values, which replace the top-level keys of the existing
+   customized values they set, then the default values of the chart,
+ - 'deep': new values, existing customized values, then the default values of the
+   chart. Tables are merged at every level.
+
+To use an environment values file inside the chart and the subcharts, like
+'values-prod.yaml', use '--environment'. The environment is recorded in the
+release, and a warning is printed if an upgrade switches the release to another
+environment.
+
+By default, the resources are patched with the differences between the previous
+manifest and the new one, so the changes made to the resources out of band are
+kept when the chart does not change the same fields. With '--three-way-merge',
+the resources are patched like 'kubectl apply' does, with a three-way merge of
+the previous manifest, the new manifest and the live objects: the fields set by
+the chart are restored, and the fields added out of band are kept.
+
+To edit the values computed for the release, with the values of the command line
+merged in, use '--edit-values'. The values are opened in $HELM_EDITOR, $EDITOR or vi,
+validated against the schema of the chart, and the changes are printed before the
+release is upgraded with them. Removing a value restores the default of the chart.
+
 If no chart value arguments are provided on the command line, any existing customized values are carried
 forward. If you want to revert to just the values provided in the chart, use the '--reset-values' flag.
 
@@ -91,6 +120,7 @@ type upgradeCmd struct {
 	dryRun        bool
 	recreate      bool
 	force         bool
+	forceCrds     bool
 	disableHooks  bool
 	valueFiles    valueFiles
 	values        []string
@@ -100,10 +130,16 @@ type upgradeCmd struct {
 	keyring       string
 	install       bool
 	namespace     string
+	noTiller      bool
 	version       string
 	timeout       int64
 	resetValues   bool
 	reuseValues   bool
+	reuseStrategy string
+	editValues    bool
+	envValuesFile string
+	expandEnv     []string
+	symlinks      symlinkPolicy
 	wait          bool
 	atomic        bool
 	repoURL       string
@@ -113,11 +149,13 @@ type upgradeCmd struct {
 	subNotes      bool
 	description   string
 	cleanupOnFail bool
+	threeWayMerge bool
 
 	certFile string
 	keyFile  string
 	caFile   string
 	output   string
+	summary  bool
 }
 
 func newUpgradeCmd(client helm.Interface, out io.Writer) *cobra.Command {
@@ -128,10 +166,12 @@ func newUpgradeCmd(client helm.Interface, out io.Writer) *cobra.Command {
 	}
 
 	cmd := &cobra.Command{
-		Use:     "upgrade [RELEASE] [CHART]",
-		Short:   "Upgrade a release",
-		Long:    upgradeDesc,
-		PreRunE: func(_ *cobra.Command, _ []string) error { return setupConnection() },
+		Use:   "upgrade [RELEASE] [CHART]",
+		Short: "Upgrade a release",
+		Long:  upgradeDesc,
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			return setupReleaseConnection(upgrade.noTiller, upgrade.namespace)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := checkArgsLength(len(args), "release name", "chart path"); err != nil {
 				return err
@@ -157,6 +197,7 @@ func newUpgradeCmd(client helm.Interface, out io.Writer) *cobra.Command {
 	f.BoolVar(&upgrade.dryRun, "dry-run", false, "Simulate an upgrade")
 	f.BoolVar(&upgrade.recreate, "recreate-pods", false, "Performs pods restart for the resource if applicable")
 	f.BoolVar(&upgrade.force, "force", false, "Force resource update through delete/recreate if needed")
+	f.BoolVar(&upgrade.forceCrds, "force-crds", false, "Create and update the CRDs of the crds/ directory of the chart, which are otherwise only created on install")
 	f.StringArrayVar(&upgrade.values, "set", []string{}, "Set values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
 	f.StringArrayVar(&upgrade.stringValues, "set-string", []string{}, "Set STRING values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
 	f.StringArrayVar(&upgrade.fileValues, "set-file", []string{}, "Set values from respective files specified via the command line (can specify multiple or separate values with commas: key1=path1,key2=path2)")
@@ -166,11 +207,17 @@ func newUpgradeCmd(client helm.Interface, out io.Writer) *cobra.Command {
 	f.StringVar(&upgrade.keyring, "keyring", defaultKeyring(), "Path to the keyring that contains public signing keys")
 	f.BoolVarP(&upgrade.install, "install", "i", false, "If a release by this name doesn't already exist, run an install")
 	f.StringVar(&upgrade.namespace, "namespace", "", "Namespace to install the release into (only used if --install is set). Defaults to the current kube config namespace")
+	f.BoolVar(&upgrade.noTiller, "no-tiller", false, noTillerUsage)
 	f.StringVar(&upgrade.version, "version", "", "Specify the exact chart version to use. If this is not specified, the latest version is used")
 	f.Int64Var(&upgrade.timeout, "timeout", 300, "Time in seconds to wait for any individual Kubernetes operation (like Jobs for hooks)")
 	f.BoolVar(&upgrade.resetValues, "reset-values", false, "When upgrading, reset the values to the ones built into the chart")
 	f.BoolVar(&upgrade.reuseValues, "reuse-values", false, "When upgrading, reuse the last release's values and merge in any overrides from the command line via --set and -f. If '--reset-values' is specified, this is ignored.")
-	f.BoolVar(&upgrade.wait, "wait", false, "If set, will wait until all Pods, PVCs, Services, and minimum number of Pods of a Deployment are in a ready state before marking the release as successful. It will wait for as long as --timeout")
+	f.StringVar(&upgrade.reuseStrategy, "reuse-values-strategy", "", "How '--reuse-values' combines the last release's values with the new values: merge, replace or deep. Defaults to merge")
+	f.BoolVar(&upgrade.editValues, "edit-values", false, "Edit the values computed for the release, with the values of the command line merged in, in $HELM_EDITOR or $EDITOR before upgrading")
+	f.StringVar(&upgrade.envValuesFile, "environment", "", "Use an environment values file inside the chart and the subcharts")
+	f.StringArrayVar(&upgrade.expandEnv, "expand-env", []string{}, "Expand the environment variables matching a pattern, like CI_*, referenced as ${NAME} or ${NAME:-default} in the values files and the values of the chart. Can be specified multiple times")
+	addSymlinksFlag(f, &upgrade.symlinks)
+	f.BoolVar(&upgrade.wait, "wait", false, "If set, will wait until all Pods, PVCs, Services, and minimum number of Pods of a Deployment are in a ready state before marking the release as successful. It will wait for as long as --timeout, reporting the resources which are not ready yet")
 	f.BoolVar(&upgrade.atomic, "atomic", false, "If set, upgrade process rolls back changes made in case of failed upgrade, also sets --wait flag")
 	f.StringVar(&upgrade.repoURL, "repo", "", "Chart repository url where to locate the requested chart")
 	f.StringVar(&upgrade.username, "username", "", "Chart repository username where to locate the requested chart")
@@ -179,9 +226,11 @@ func newUpgradeCmd(client helm.Interface, out io.Writer) *cobra.Command {
 	f.StringVar(&upgrade.keyFile, "key-file", "", "Identify HTTPS client using this SSL key file")
 	f.StringVar(&upgrade.caFile, "ca-file", "", "Verify certificates of HTTPS-enabled servers using this CA bundle")
 	f.BoolVar(&upgrade.devel, "devel", false, "Use development versions, too. Equivalent to version '>0.0.0-0'. If --version is set, this is ignored.")
-	f.BoolVar(&upgrade.subNotes, "render-subchart-notes", false, "Render subchart notes along with parent")
+	f.BoolVar(&upgrade.subNotes, "render-subchart-notes", false, "Render subchart notes along with parent, as the environment values file does with a '# helm:subchart-notes' comment")
 	f.StringVar(&upgrade.description, "description", "", "Specify the description to use for the upgrade, rather than the default")
 	f.BoolVar(&upgrade.cleanupOnFail, "cleanup-on-fail", false, "Allow deletion of new resources created in this upgrade when upgrade failed")
+	f.BoolVar(&upgrade.threeWayMerge, "three-way-merge", false, "Patch the resources with a three-way merge of the previous manifest, the new manifest and the live objects, like kubectl apply, restoring the fields of the chart changed out of band")
+	f.BoolVar(&upgrade.summary, "summary", false, "Print a summary of the release, with its resources, hooks, notes and warnings, instead of its status. The summary is also printed by --dry-run")
 	bindOutputFlag(cmd, &upgrade.output)
 
 	f.MarkDeprecated("disable-hooks", "Use --no-hooks instead")
@@ -193,6 +242,13 @@ func newUpgradeCmd(client helm.Interface, out io.Writer) *cobra.Command {
 }
 
 func (u *upgradeCmd) run() error {
+	if u.reuseStrategy != "" && !u.reuseValues {
+		return errors.New("--reuse-values-strategy requires --reuse-values")
+	}
+	if u.editValues && (u.reuseValues || u.resetValues) {
+		return errors.New("--edit-values cannot be used with --reuse-values or --reset-values")
+	}
+
 	chartPath, err := locateChartPath(u.repoURL, u.username, u.password, u.chart, u.version, u.verify, u.keyring, u.certFile, u.keyFile, u.caFile)
 	if err != nil {
 		return err
@@ -214,46 +270,63 @@ func (u *upgradeCmd) run() error {
 			}
 			previousReleaseNamespace := releaseHistory.Releases[0].Namespace
 			if previousReleaseNamespace != u.namespace {
-				fmt.Fprintf(u.out,
-					"WARNING: Namespace %q doesn't match with previous. Release will be deployed to %s\n",
+				warning("Namespace %q doesn't match with previous. Release will be deployed to %s",
 					u.namespace, previousReleaseNamespace,
 				)
 			}
 		}
 
 		if err != nil && strings.Contains(err.Error(), storageerrors.ErrReleaseNotFound(u.release).Error()) {
-			fmt.Fprintf(u.out, "Release %q does not exist. Installing it now.\n", u.release)
+			info("Release %q does not exist. Installing it now.", u.release)
 			ic := &installCmd{
-				chartPath:    chartPath,
-				client:       u.client,
-				out:          u.out,
-				name:         u.release,
-				valueFiles:   u.valueFiles,
-				dryRun:       u.dryRun,
-				verify:       u.verify,
-				disableHooks: u.disableHooks,
-				keyring:      u.keyring,
-				values:       u.values,
-				stringValues: u.stringValues,
-				fileValues:   u.fileValues,
-				namespace:    u.namespace,
-				timeout:      u.timeout,
-				wait:         u.wait,
-				description:  u.description,
-				atomic:       u.atomic,
+				chartPath:     chartPath,
+				client:        u.client,
+				out:           u.out,
+				name:          u.release,
+				valueFiles:    u.valueFiles,
+				dryRun:        u.dryRun,
+				verify:        u.verify,
+				disableHooks:  u.disableHooks,
+				keyring:       u.keyring,
+				values:        u.values,
+				stringValues:  u.stringValues,
+				fileValues:    u.fileValues,
+				envValuesFile: u.envValuesFile,
+				expandEnv:     u.expandEnv,
+				symlinks:      u.symlinks,
+				namespace:     u.namespace,
+				timeout:       u.timeout,
+				wait:          u.wait,
+				description:   u.description,
+				atomic:        u.atomic,
+				summary:       u.summary,
 			}
 			return ic.run()
 		}
 	}
 
-	rawVals, err := vals(u.valueFiles, u.values, u.stringValues, u.fileValues, u.certFile, u.keyFile, u.caFile)
+	if err == nil && len(releaseHistory.Releases) > 0 {
+		u.warnEnvironmentSwitch(releaseHistory.Releases[0].Environment)
+	}
+
+	rawVals, err := vals(u.valueFiles, u.values, u.stringValues, u.fileValues, u.expandEnv, u.certFile, u.keyFile, u.caFile)
 	if err != nil {
 		return err
 	}
+	if rawVals, err = runCommandHooks(plugin.PreUpgrade, rawVals); err != nil {
+		return err
+	}
 
 	// Check chart requirements to make sure all dependencies are present in /charts
-	ch, err := chartutil.Load(chartPath)
+	subNotes, err := subchartNotes(chartPath, u.envValuesFile, u.subNotes, u.symlinks.loadOption())
+	if err != nil {
+		return err
+	}
+	ch, err := chartutil.LoadWithEnvValuesFile(chartPath, u.envValuesFile, u.symlinks.loadOption())
 	if err == nil {
+		if chartutil.IsLibraryChart(ch) {
+			return fmt.Errorf("library chart %s is not installable", ch.Metadata.Name)
+		}
 		if req, err := chartutil.LoadRequirements(ch); err == nil {
 			if err := renderutil.CheckDependencies(ch, req); err != nil {
 				return err
@@ -261,10 +334,23 @@ func (u *upgradeCmd) run() error {
 		} else if err != chartutil.ErrRequirementsNotFound {
 			return fmt.Errorf("cannot load requirements: %v", err)
 		}
+		if err := chartutil.ExpandChartEnv(ch, u.expandEnv); err != nil {
+			return err
+		}
 	} else {
 		return prettyError(err)
 	}
 
+	if u.editValues {
+		if rawVals, err = u.editReleaseValues(ch, rawVals); err != nil || rawVals == nil {
+			return err
+		}
+	}
+
+	stopProgress := func() {}
+	if u.wait && !u.dryRun {
+		stopProgress = reportWaitProgress(u.client, u.release)
+	}
 	resp, err := u.client.UpdateReleaseFromChart(
 		u.release,
 		ch,
@@ -272,18 +358,23 @@ func (u *upgradeCmd) run() error {
 		helm.UpgradeDryRun(u.dryRun),
 		helm.UpgradeRecreate(u.recreate),
 		helm.UpgradeForce(u.force),
+		helm.UpgradeForceCrds(u.forceCrds),
 		helm.UpgradeDisableHooks(u.disableHooks),
 		helm.UpgradeTimeout(u.timeout),
 		helm.ResetValues(u.resetValues),
 		helm.ReuseValues(u.reuseValues),
-		helm.UpgradeSubNotes(u.subNotes),
+		helm.ReuseValuesStrategy(u.reuseStrategy),
+		helm.UpgradeSubNotes(subNotes),
 		helm.UpgradeWait(u.wait),
+		helm.UpgradeEnvironment(u.envValuesFile),
 		helm.UpgradeDescription(u.description),
-		helm.UpgradeCleanupOnFail(u.cleanupOnFail))
+		helm.UpgradeCleanupOnFail(u.cleanupOnFail),
+		helm.UpgradeThreeWayMerge(u.threeWayMerge))
+	stopProgress()
 	if err != nil {
-		fmt.Fprintf(u.out, "UPGRADE FAILED\nError: %v\n", prettyError(err))
+		info("UPGRADE FAILED\nError: %v", prettyError(err))
 		if u.atomic {
-			fmt.Fprint(u.out, "ROLLING BACK")
+			info("ROLLING BACK")
 			rollback := &rollbackCmd{
 				out:           u.out,
 				client:        u.client,
@@ -306,7 +397,7 @@ func (u *upgradeCmd) run() error {
 	}
 
 	if settings.Debug {
-		printRelease(u.out, resp.Release)
+		debugRelease(u.out, resp.Release)
 	}
 
 	if outputFormat(u.output) == outputTable {
@@ -318,5 +409,28 @@ func (u *upgradeCmd) run() error {
 		return prettyError(err)
 	}
 
-	return write(u.out, &statusWriter{status}, outputFormat(u.output))
+	if !u.summary {
+		return write(u.out, &statusWriter{status}, outputFormat(u.output))
+	}
+	return write(u.out, newSummaryWriter(resp.Release, status), outputFormat(u.output))
+}
+
+// warnEnvironmentSwitch warns if the release, last deployed with the
+// environment values file previous, is upgraded with another one.
+func (u *upgradeCmd) warnEnvironmentSwitch(previous string) {
+	if previous == u.envValuesFile {
+		return
+	}
+	warning("Release %q switches from %s to %s", u.release, environmentName(previous), environmentName(u.envValuesFile))
+	if u.reuseValues && !u.resetValues && (u.reuseStrategy == "" || u.reuseStrategy == "merge") {
+		warning("The values computed for the last release, including those of %s, are reused. Use '--reuse-values-strategy deep' to use the values of %s instead",
+			environmentName(previous), environmentName(u.envValuesFile))
+	}
+}
+
+func environmentName(envValuesFile string) string {
+	if envValuesFile == "" {
+		return "no environment"
+	}
+	return fmt.Sprintf("the environment %s", envValuesFile)
 }
@@ -17,10 +17,12 @@ limitations under the License.
 package main
 
 import (
+	"bytes"
 	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/spf13/cobra"
@@ -123,6 +125,22 @@ func TestUpgradeCmd(t *testing.T) {
 			expected: "Release \"funny-bunny\" has been upgraded.\n",
 			rels:     []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "funny-bunny", Version: 5, Chart: ch2})},
 		},
+		{
+			name:     "upgrade a release with --reuse-values-strategy",
+			args:     []string{"funny-bunny", chartPath},
+			flags:    []string{"--reuse-values", "--reuse-values-strategy", "deep"},
+			resp:     helm.ReleaseMock(&helm.MockReleaseOptions{Name: "funny-bunny", Version: 5, Chart: ch2}),
+			expected: "Release \"funny-bunny\" has been upgraded.\n",
+			rels:     []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "funny-bunny", Version: 5, Chart: ch2})},
+		},
+		{
+			name:  "upgrade a release with --reuse-values-strategy without --reuse-values",
+			args:  []string{"funny-bunny", chartPath},
+			flags: []string{"--reuse-values-strategy", "deep"},
+			resp:  helm.ReleaseMock(&helm.MockReleaseOptions{Name: "funny-bunny", Version: 5, Chart: ch2}),
+			rels:  []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "funny-bunny", Version: 5, Chart: ch2})},
+			err:   true,
+		},
 		{
 			name:     "install a release with 'upgrade --atomic'",
 			args:     []string{"funny-bunny", chartPath},
@@ -192,3 +210,118 @@ func TestUpgradeCmd(t *testing.T) {
 	runReleaseCases(t, tests, cmd)
 
 }
+
+func TestUpgradeEnvironmentSwitch(t *testing.T) {
+	defer func() { logOut = os.Stderr }()
+	logs := bytes.NewBuffer(nil)
+	logOut = logs
+
+	tests := []struct {
+		name   string
+		flags  []string
+		expect []string
+	}{
+		{
+			name:  "same environment",
+			flags: []string{"--environment", "values-prod.yaml"},
+		},
+		{
+			name:   "switched environment",
+			flags:  []string{"--environment", "values-staging.yaml"},
+			expect: []string{`Release "crazy-bunny" switches from the environment values-prod.yaml to the environment values-staging.yaml`},
+		},
+		{
+			name:  "no environment with reused values",
+			flags: []string{"--reuse-values"},
+			expect: []string{
+				`Release "crazy-bunny" switches from the environment values-prod.yaml to no environment`,
+				"The values computed for the last release, including those of the environment values-prod.yaml, are reused",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs.Reset()
+			rel := helm.ReleaseMock(&helm.MockReleaseOptions{Name: "crazy-bunny"})
+			rel.Environment = "values-prod.yaml"
+			c := &helm.FakeClient{Rels: []*release.Release{rel}}
+
+			cmd := newUpgradeCmd(c, bytes.NewBuffer(nil))
+			cmd.ParseFlags(tt.flags)
+			if err := cmd.RunE(cmd, []string{"crazy-bunny", environmentsChartPath}); err != nil {
+				t.Fatal(err)
+			}
+			for _, e := range tt.expect {
+				if !strings.Contains(logs.String(), e) {
+					t.Errorf("Expected %q in %q", e, logs.String())
+				}
+			}
+			if len(tt.expect) == 0 && strings.Contains(logs.String(), "WARNING") {
+				t.Errorf("Expected no warning, got %q", logs.String())
+			}
+		})
+	}
+}
+
+func TestUpgradeEditValues(t *testing.T) {
+	defer os.Unsetenv("HELM_EDITOR")
+
+	tests := []struct {
+		name   string
+		editor string
+		flags  []string
+		expect string
+		err    bool
+	}{
+		{
+			name:   "edited values",
+			editor: "sed -i s/value/edited/",
+			expect: "VALUES CHANGES:\n~ name: \"value\" -> \"edited\"\nRelease \"crazy-bunny\" has been upgraded.",
+		},
+		{
+			name:   "values of the command line",
+			editor: "true",
+			flags:  []string{"--set", "name=other"},
+			expect: "~ name: \"value\" -> \"other\"",
+		},
+		{
+			name:   "no changes",
+			editor: "true",
+			expect: "Edit cancelled, no changes made.",
+		},
+		{
+			name:   "empty values",
+			editor: "sed -i d",
+			expect: "Edit cancelled, the values are empty.",
+		},
+		{
+			name:   "invalid values",
+			editor: "sed -i s/name:/name/",
+			err:    true,
+		},
+		{
+			name:   "with --reuse-values",
+			editor: "true",
+			flags:  []string{"--reuse-values"},
+			err:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Setenv("HELM_EDITOR", tt.editor)
+			var buf bytes.Buffer
+			c := &helm.FakeClient{Rels: []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "crazy-bunny"})}}
+			cmd := newUpgradeCmd(c, &buf)
+			cmd.ParseFlags(append(tt.flags, "--edit-values"))
+			err := cmd.RunE(cmd, []string{"crazy-bunny", "testdata/testcharts/alpine"})
+			if (err != nil) != tt.err {
+				t.Fatalf("Expected an error to be %v, got %v", tt.err, err)
+			}
+			if !strings.Contains(buf.String(), tt.expect) {
+				t.Errorf("Expected %q in %q", tt.expect, buf.String())
+			}
+		})
+	}
+}
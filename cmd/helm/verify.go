@@ -17,11 +17,13 @@ package main
 
 import (
 	"errors"
+	"fmt"
 	"io"
 
 	"github.com/spf13/cobra"
 
 	"k8s.io/helm/pkg/downloader"
+	"k8s.io/helm/pkg/provenance"
 )
 
 const verifyDesc = `
@@ -33,11 +35,24 @@ tampered with, and was packaged by a trusted provider.
 This command can be used to verify a local chart. Several other commands provide
 '--verify' flags that run the same validation. To generate a signed package, use
 the 'helm package --sign' command.
+
+With '--attestation-policy', the provenance file must also contain an
+attestation of the build provenance of the chart, added by
+'helm package --attest', which meets the policy of the file:
+
+	builders:
+	- https://ci.example.com/*
+	sourceRepos:
+	- git+https://github.com/example/charts
+	requireCommit: true
+
+A pattern ending with '*' matches the values it is a prefix of.
 `
 
 type verifyCmd struct {
-	keyring   string
-	chartfile string
+	keyring           string
+	chartfile         string
+	attestationPolicy string
 
 	out io.Writer
 }
@@ -60,11 +75,31 @@ func newVerifyCmd(out io.Writer) *cobra.Command {
 
 	f := cmd.Flags()
 	f.StringVar(&vc.keyring, "keyring", defaultKeyring(), "Keyring containing public keys")
+	f.StringVar(&vc.attestationPolicy, "attestation-policy", "", "Check the attestation of the build provenance of the chart against the policy of this file")
 
 	return cmd
 }
 
 func (v *verifyCmd) run() error {
-	_, err := downloader.VerifyChart(v.chartfile, v.keyring)
-	return err
+	ver, err := downloader.VerifyChart(v.chartfile, v.keyring)
+	if err != nil {
+		return err
+	}
+	return checkAttestationPolicy(v.attestationPolicy, ver)
+}
+
+// checkAttestationPolicy checks the attestation of the verification ver
+// against the attestation policy of the file policyFile, if it is set.
+func checkAttestationPolicy(policyFile string, ver *provenance.Verification) error {
+	if policyFile == "" {
+		return nil
+	}
+	policy, err := provenance.LoadAttestationPolicy(policyFile)
+	if err != nil {
+		return err
+	}
+	if err := policy.Check(ver.Attestation); err != nil {
+		return withExitCode(exitCodeValidationFailure, fmt.Errorf("%s does not meet the attestation policy: %s", ver.FileName, err))
+	}
+	return nil
 }
@@ -70,6 +70,13 @@ func TestVerifyCmd(t *testing.T) {
 			expect: "",
 			err:    false,
 		},
+		{
+			name:   "verify requires an attestation with --attestation-policy",
+			args:   []string{"testdata/testcharts/signtest-0.1.0.tgz"},
+			flags:  []string{"--keyring", "testdata/helm-test-key.pub", "--attestation-policy", "testdata/attestation-policy.yaml"},
+			expect: "signtest-0.1.0.tgz does not meet the attestation policy: provenance does not contain an attestation",
+			err:    true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,84 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/proto/hapi/services"
+)
+
+// waitProgressInterval is how often install and upgrade report the resources
+// which are not ready yet while Tiller waits for them with --wait.
+var waitProgressInterval = 10 * time.Second
+
+// reportWaitProgress reports the resources of the release name which are not
+// ready yet, and why, every waitProgressInterval until the returned function
+// is called.
+func reportWaitProgress(client helm.Interface, name string) (stop func()) {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(waitProgressInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+			res, err := client.ReleaseStatus(name, helm.StatusInventory(true))
+			if err != nil {
+				debug("unable to get the status of %s: %s", name, prettyError(err))
+				continue
+			}
+			if msg := waitProgress(res); msg != "" {
+				info("%s", msg)
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+// waitProgress returns the resources of the pending release of status res
+// which are not ready, and why, or an empty string if the release is not
+// pending or its resources are all ready.
+func waitProgress(res *services.GetReleaseStatusResponse) string {
+	switch res.GetInfo().GetStatus().GetCode() {
+	case release.Status_PENDING_INSTALL, release.Status_PENDING_UPGRADE, release.Status_PENDING_ROLLBACK:
+	default:
+		return ""
+	}
+	var notReady []string
+	for _, r := range res.Inventory {
+		if r.Ready {
+			continue
+		}
+		reason := r.Reason
+		if reason == "" {
+			reason = "not ready"
+		}
+		notReady = append(notReady, fmt.Sprintf("  %s/%s (%s): %s", r.Kind, r.Name, r.Namespace, reason))
+	}
+	if len(notReady) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("Waiting for %d of %d resources to be ready:\n%s", len(notReady), len(res.Inventory), strings.Join(notReady, "\n"))
+}
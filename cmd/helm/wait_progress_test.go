@@ -0,0 +1,53 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"testing"
+
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/proto/hapi/services"
+)
+
+func TestWaitProgress(t *testing.T) {
+	inventory := []*services.ResourceStatus{
+		{ApiVersion: "v1", Kind: "Service", Name: "web", Namespace: "default", Exists: true, Ready: true},
+		{ApiVersion: "apps/v1", Kind: "Deployment", Name: "web", Namespace: "default", Exists: true, Reason: "0 of 2 replicas are ready; pod web-7d9f: container web is waiting: ImagePullBackOff"},
+		{ApiVersion: "v1", Kind: "PersistentVolumeClaim", Name: "data", Namespace: "default", Exists: true, Reason: "the claim is Pending"},
+	}
+	res := &services.GetReleaseStatusResponse{
+		Name:      "flummoxed-chickadee",
+		Info:      &release.Info{Status: &release.Status{Code: release.Status_PENDING_UPGRADE}},
+		Inventory: inventory,
+	}
+	expect := `Waiting for 2 of 3 resources to be ready:
+  Deployment/web (default): 0 of 2 replicas are ready; pod web-7d9f: container web is waiting: ImagePullBackOff
+  PersistentVolumeClaim/data (default): the claim is Pending`
+	if msg := waitProgress(res); msg != expect {
+		t.Errorf("Expected\n%s\ngot\n%s", expect, msg)
+	}
+
+	res.Info.Status.Code = release.Status_DEPLOYED
+	if msg := waitProgress(res); msg != "" {
+		t.Errorf("Expected no progress for a deployed release, got\n%s", msg)
+	}
+	res.Info.Status.Code = release.Status_PENDING_INSTALL
+	res.Inventory = inventory[:1]
+	if msg := waitProgress(res); msg != "" {
+		t.Errorf("Expected no progress once the resources are ready, got\n%s", msg)
+	}
+}
@@ -109,7 +109,7 @@ func (r *ReleaseModuleServiceServer) DeleteRelease(ctx context.Context, in *rudd
 		return resp, fmt.Errorf("Could not get apiVersions from Kubernetes: %v", err)
 	}
 
-	kept, errs := tiller.DeleteRelease(rel, vs, kubeClient)
+	kept, errs := tiller.DeleteRelease(rel, vs, kubeClient, "")
 	rel.Manifest = kept
 
 	allErrors := ""
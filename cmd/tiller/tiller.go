@@ -18,6 +18,7 @@ package main // import "k8s.io/helm/cmd/tiller"
 
 import (
 	"crypto/tls"
+	"encoding/base64"
 	"flag"
 	"fmt"
 	"io/ioutil"
@@ -36,16 +37,20 @@ import (
 	"google.golang.org/grpc/health"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/keepalive"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/klog"
 
 	// Import to initialize client auth plugins.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	"k8s.io/helm/pkg/engine"
 	"k8s.io/helm/pkg/kube"
 	"k8s.io/helm/pkg/proto/hapi/services"
 	"k8s.io/helm/pkg/storage"
 	"k8s.io/helm/pkg/storage/driver"
+	"k8s.io/helm/pkg/storage/lock"
 	"k8s.io/helm/pkg/tiller"
+	"k8s.io/helm/pkg/tiller/audit"
 	"k8s.io/helm/pkg/tiller/environment"
 	"k8s.io/helm/pkg/tlsutil"
 	"k8s.io/helm/pkg/version"
@@ -62,6 +67,9 @@ const (
 	tlsCertsEnvVar = "TILLER_TLS_CERTS"
 	// historyMaxEnvVar is the name of the env var for setting max history.
 	historyMaxEnvVar = "TILLER_HISTORY_MAX"
+	// storageEncryptionKeyEnvVar names the environment variable holding the
+	// base64 encoded 32 bytes key encrypting the stored releases.
+	storageEncryptionKeyEnvVar = "TILLER_STORAGE_ENCRYPTION_KEY"
 
 	storageMemory    = "memory"
 	storageConfigMap = "configmap"
@@ -81,10 +89,13 @@ var (
 	enableTracing = flag.Bool("trace", false, "enable rpc tracing")
 	store         = flag.String("storage", storageConfigMap, "storage driver to use. One of 'configmap', 'memory', 'sql' or 'secret'")
 
+	storagePageSize     = flag.Int64("storage-page-size", driver.DefaultListPageSize, "number of objects fetched per list request by the configmap and secret storage drivers, with 0 disabling pagination")
 	sqlDialect          = flag.String("sql-dialect", "postgres", "SQL dialect to use (only postgres is supported for now")
 	sqlConnectionString = flag.String("sql-connection-string", "", "SQL connection string to use")
+	storageKMSPlugin    = flag.String("storage-encryption-kms-plugin", "", "program encrypting the keys of the stored releases with a key management service. Overrides $"+storageEncryptionKeyEnvVar)
 
 	remoteReleaseModules = flag.Bool("experimental-release", false, "enable experimental release modules")
+	renderWorkers        = flag.Int("experimental-render-workers", 1, "number of templates rendered in parallel")
 
 	tlsEnable    = flag.Bool("tls", tlsEnableEnvVarDefault(), "enable TLS")
 	tlsVerify    = flag.Bool("tls-verify", tlsVerifyEnvVarDefault(), "enable TLS and verify remote certificate")
@@ -94,6 +105,13 @@ var (
 	maxHistory   = flag.Int("history-max", historyMaxFromEnv(), "maximum number of releases kept in release history, with 0 meaning no limit")
 	printVersion = flag.Bool("version", false, "print the version number")
 
+	maxHistoryAge  = flag.Duration("history-max-age", 0, "maximum age of the releases kept in release history after an upgrade, with 0 meaning no limit")
+	maxHistorySize = flag.String("history-max-size", "", "maximum size of the stored records of the release history of a release after an upgrade, e.g. 10Mi. Empty means no limit")
+	lockDuration   = flag.Duration("release-lock-duration", lock.DefaultLeaseDuration, "time after which the lock of a release held by a Tiller that stopped renewing it expires, at least 1s")
+
+	auditSink       = flag.String("audit-sink", "none", "where the operations changing releases are recorded. One of 'none', 'configmap', 'file:PATH' or 'webhook:URL'")
+	auditMaxRecords = flag.Int("audit-max-records", audit.DefaultMaxRecords, "number of audit records kept per release by the configmap audit sink")
+
 	// rootServer is the root gRPC server.
 	//
 	// Each gRPC service registers itself to this server during start().
@@ -135,18 +153,27 @@ func start() {
 		logger.Fatalf("Cannot initialize Kubernetes connection: %s", err)
 	}
 
+	encryption, err := storageEncryption()
+	if err != nil {
+		logger.Fatalf("Cannot initialize the storage encryption: %s", err)
+	}
+
 	switch *store {
 	case storageMemory:
 		env.Releases = storage.Init(driver.NewMemory())
 	case storageConfigMap:
 		cfgmaps := driver.NewConfigMaps(clientset.CoreV1().ConfigMaps(namespace()))
 		cfgmaps.Log = newLogger("storage/driver").Printf
+		cfgmaps.PageSize = *storagePageSize
+		cfgmaps.Encryption = encryption
 
 		env.Releases = storage.Init(cfgmaps)
 		env.Releases.Log = newLogger("storage").Printf
 	case storageSecret:
 		secrets := driver.NewSecrets(clientset.CoreV1().Secrets(namespace()))
 		secrets.Log = newLogger("storage/driver").Printf
+		secrets.PageSize = *storagePageSize
+		secrets.Encryption = encryption
 
 		env.Releases = storage.Init(secrets)
 		env.Releases.Log = newLogger("storage").Printf
@@ -159,6 +186,7 @@ func start() {
 		if err != nil {
 			logger.Fatalf("Cannot initialize SQL storage driver: %v", err)
 		}
+		sqlDriver.Encryption = encryption
 
 		env.Releases = storage.Init(sqlDriver)
 		env.Releases.Log = newLogger("storage").Printf
@@ -167,11 +195,50 @@ func start() {
 	if *maxHistory > 0 {
 		env.Releases.MaxHistory = *maxHistory
 	}
+	env.Releases.MaxHistoryAge = *maxHistoryAge
+	if *maxHistorySize != "" {
+		q, err := resource.ParseQuantity(*maxHistorySize)
+		if err != nil {
+			logger.Fatalf("Invalid --history-max-size %q: %s", *maxHistorySize, err)
+		}
+		env.Releases.MaxHistoryBytes = q.Value()
+	}
+
+	// The releases of a memory storage are only seen by this Tiller, the
+	// others can be shared by several Tillers of the namespace.
+	if *store != storageMemory {
+		holder, err := os.Hostname()
+		if err != nil {
+			logger.Fatalf("Cannot get the hostname: %s", err)
+		}
+		if *lockDuration < lock.MinLeaseDuration {
+			logger.Fatalf("Invalid --release-lock-duration %s: the duration must be at least %s", *lockDuration, lock.MinLeaseDuration)
+		}
+		leases := lock.NewLeases(clientset.CoordinationV1().Leases(namespace()), holder)
+		leases.Duration = *lockDuration
+		leases.Log = newLogger("storage/lock").Printf
+		env.Locks = leases
+	}
+
+	sink, err := audit.New(*auditSink, clientset.CoreV1().ConfigMaps(namespace()))
+	if err != nil {
+		logger.Fatalf("Cannot initialize the audit sink: %s", err)
+	}
+	if cfgmaps, ok := sink.(*audit.ConfigMaps); ok {
+		cfgmaps.MaxRecords = *auditMaxRecords
+	}
+	env.Audit = sink
 
 	kubeClient := kube.New(nil)
 	kubeClient.Log = newLogger("kube").Printf
 	env.KubeClient = kubeClient
 
+	if e, ok := env.EngineYard[environment.GoTplEngine].(*engine.Engine); ok {
+		e.Workers = *renderWorkers
+		// The lookups are read from the cluster with the credentials of Tiller.
+		e.Lookup = kubeClient.Lookup
+	}
+
 	if *tlsEnable || *tlsVerify {
 		opts := tlsutil.Options{CertFile: *certFile, KeyFile: *keyFile}
 		if *tlsVerify {
@@ -210,7 +277,13 @@ func start() {
 		logger.Printf("Probes listening on %s", *probeAddr)
 	}
 	logger.Printf("Storage driver is %s", env.Releases.Name())
+	if encryption != nil && *store != storageMemory {
+		logger.Printf("Stored releases are encrypted")
+	}
 	logger.Printf("Max history per release is %d", *maxHistory)
+	if env.Audit != nil {
+		logger.Printf("Audit sink is %s", env.Audit.Name())
+	}
 
 	if *enableTracing {
 		startTracing(traceAddr)
@@ -314,5 +387,27 @@ func historyMaxFromEnv() int {
 	return ret
 }
 
+// storageEncryption returns the Envelope encrypting the stored releases with
+// the KMS plugin of --storage-encryption-kms-plugin, or with the key of
+// $TILLER_STORAGE_ENCRYPTION_KEY, or nil if neither is set.
+func storageEncryption() (*driver.Envelope, error) {
+	if *storageKMSPlugin != "" {
+		return driver.NewEnvelope(&driver.KMSPlugin{Program: *storageKMSPlugin}), nil
+	}
+	val := os.Getenv(storageEncryptionKeyEnvVar)
+	if val == "" {
+		return nil, nil
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(val))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %s", storageEncryptionKeyEnvVar, err)
+	}
+	k, err := driver.NewLocalKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return driver.NewEnvelope(k), nil
+}
+
 func tlsEnableEnvVarDefault() bool { return os.Getenv(tlsEnableEnvVar) != "" }
 func tlsVerifyEnvVarDefault() bool { return os.Getenv(tlsVerifyEnvVar) != "" }
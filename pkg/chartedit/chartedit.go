@@ -0,0 +1,201 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package chartedit edits the files of a chart directory: it sets and deletes
+values, adds templates and bumps the version of the chart, for tools that
+rewrite charts, like release automation bots.
+
+The values.yaml and Chart.yaml files are edited as values documents, which
+preserves their comments, their anchors, the order of their keys and the
+style of their scalars:
+
+	c, err := chartedit.Open("mychart")
+	if err != nil {
+		return err
+	}
+	if err := c.SetValue("image.tag", "1.2.3"); err != nil {
+		return err
+	}
+	if _, err := c.BumpVersion(chartedit.BumpPatch); err != nil {
+		return err
+	}
+	return c.Save()
+*/
+package chartedit // import "k8s.io/helm/pkg/chartedit"
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/helm/pkg/chartutil"
+)
+
+// Chart is a chart directory being edited. The edits are written to the
+// directory by Save.
+type Chart struct {
+	dir string
+
+	chartfile *chartutil.ValuesDocument
+	values    *chartutil.ValuesDocument
+	templates map[string][]byte
+
+	chartfileChanged bool
+	valuesChanged    bool
+}
+
+// Open opens the chart directory dir for editing.
+func Open(dir string) (*Chart, error) {
+	chartfile, err := chartutil.ReadValuesDocumentFile(filepath.Join(dir, chartutil.ChartfileName))
+	if os.IsNotExist(err) {
+		return nil, chartutil.ErrMissingChartYAML
+	} else if err != nil {
+		return nil, err
+	}
+	values, err := chartutil.ReadValuesDocumentFile(filepath.Join(dir, chartutil.ValuesfileName))
+	if os.IsNotExist(err) {
+		values, err = chartutil.ReadValuesDocument(nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Chart{
+		dir:       dir,
+		chartfile: chartfile,
+		values:    values,
+		templates: map[string][]byte{},
+	}, nil
+}
+
+// SetValue sets the value at path in values.yaml to v, creating the maps and
+// appending to the lists of the path as needed. The path is a dotted path like
+// "image.tag" or "ingress.hosts[0].name", and dots in keys are escaped with a
+// backslash. The comments of a replaced value are kept.
+func (c *Chart) SetValue(path string, v interface{}) error {
+	if err := c.values.Set(path, v); err != nil {
+		return err
+	}
+	c.valuesChanged = true
+	return nil
+}
+
+// DeleteValue deletes the value at path in values.yaml, with its comments.
+// Deleting a value that is not set does nothing.
+func (c *Chart) DeleteValue(path string) error {
+	deleted, err := c.values.Delete(path)
+	if err != nil {
+		return err
+	}
+	if deleted {
+		c.valuesChanged = true
+	}
+	return nil
+}
+
+// AddTemplate adds the template name, like "templates/service.yaml", with the
+// content data. The template must not exist.
+func (c *Chart) AddTemplate(name string, data []byte) error {
+	n := path.Clean(filepath.ToSlash(name))
+	if !strings.HasPrefix(n, chartutil.TemplatesDir+"/") {
+		return fmt.Errorf("template %s is not in the %s/ directory", name, chartutil.TemplatesDir)
+	}
+	_, err := os.Stat(filepath.Join(c.dir, filepath.FromSlash(n)))
+	if _, ok := c.templates[n]; ok || err == nil {
+		return fmt.Errorf("template %s already exists", name)
+	}
+	c.templates[n] = data
+	return nil
+}
+
+// BumpVersion bumps the version of the chart in Chart.yaml with the
+// strategy s, and returns the new version.
+func (c *Chart) BumpVersion(s BumpStrategy) (string, error) {
+	v, ok, err := c.chartfile.Lookup("version")
+	if err != nil {
+		return "", err
+	}
+	if !ok || v == nil {
+		return "", fmt.Errorf("no chart version specified (%s)", chartutil.ChartfileName)
+	}
+	version, err := NextVersion(fmt.Sprint(v), s)
+	if err != nil {
+		return "", err
+	}
+	if err := c.SetVersion(version); err != nil {
+		return "", err
+	}
+	return version, nil
+}
+
+// SetVersion sets the version of the chart in Chart.yaml.
+func (c *Chart) SetVersion(version string) error {
+	return c.setChartfile("version", version)
+}
+
+// SetAppVersion sets the appVersion of the chart in Chart.yaml.
+func (c *Chart) SetAppVersion(appVersion string) error {
+	return c.setChartfile("appVersion", appVersion)
+}
+
+// setChartfile sets the field key of Chart.yaml to v.
+func (c *Chart) setChartfile(key, v string) error {
+	if err := c.chartfile.Set(key, v); err != nil {
+		return err
+	}
+	c.chartfileChanged = true
+	return nil
+}
+
+// Save writes the edits to the chart directory. The files that were not
+// edited are left alone.
+func (c *Chart) Save() error {
+	if c.chartfileChanged {
+		if err := writeDocument(filepath.Join(c.dir, chartutil.ChartfileName), c.chartfile); err != nil {
+			return err
+		}
+		c.chartfileChanged = false
+	}
+	if c.valuesChanged {
+		if err := writeDocument(filepath.Join(c.dir, chartutil.ValuesfileName), c.values); err != nil {
+			return err
+		}
+		c.valuesChanged = false
+	}
+	for name, data := range c.templates {
+		n := filepath.Join(c.dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(n), 0755); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(n, data, 0644); err != nil {
+			return err
+		}
+		delete(c.templates, name)
+	}
+	return nil
+}
+
+// writeDocument writes the document doc to the file name.
+func writeDocument(name string, doc *chartutil.ValuesDocument) error {
+	y, err := doc.YAML()
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(name, []byte(y), 0644)
+}
@@ -0,0 +1,228 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartedit
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/helm/pkg/chartutil"
+)
+
+const testChartfile = `# The whale chart
+apiVersion: v1
+name: moby
+version: 1.2.3 # bumped by the bot
+`
+
+const testValues = `# The image of the whale
+image:
+  repository: moby
+  # Pinned by the bot
+  tag: "1.0" # the current tag
+# The crew of the ship
+crew:
+- name: ahab
+  role: captain
+- name: ishmael
+harpoons: 3
+`
+
+func writeTestChart(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "helm-chartedit-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "Chart.yaml"), []byte(testChartfile), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "values.yaml"), []byte(testValues), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestEdit(t *testing.T) {
+	dir := writeTestChart(t)
+	defer os.RemoveAll(dir)
+
+	c, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for path, v := range map[string]interface{}{
+		"image.tag":        "1.1",
+		"crew[1].role":     "narrator",
+		"crew[2]":          map[string]string{"name": "starbuck"},
+		"ship.name":        "Pequod",
+		`annotations.a\.b`: true,
+	} {
+		if err := c.SetValue(path, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := c.DeleteValue("harpoons"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.DeleteValue("missing.value"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.AddTemplate("templates/ship.yaml", []byte("kind: Ship\n")); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := c.BumpVersion(BumpMinor); err != nil || v != "1.3.0" {
+		t.Fatalf("Expected the version 1.3.0, got %q (%v)", v, err)
+	}
+	if err := c.SetAppVersion("1.3"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, "values.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	values := string(data)
+	for _, comment := range []string{"# The image of the whale", "# Pinned by the bot", "# the current tag", "# The crew of the ship"} {
+		if !strings.Contains(values, comment) {
+			t.Errorf("Expected the comment %q to be kept, got\n%s", comment, values)
+		}
+	}
+	if strings.Index(values, "image:") > strings.Index(values, "crew:") {
+		t.Errorf("Expected the order of the keys to be kept, got\n%s", values)
+	}
+	if !strings.Contains(values, `tag: "1.1"`) {
+		t.Errorf("Expected the tag to stay quoted, got\n%s", values)
+	}
+
+	vals, err := chartutil.ReadValues(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := chartutil.Values{
+		"image": map[string]interface{}{"repository": "moby", "tag": "1.1"},
+		"crew": []interface{}{
+			map[string]interface{}{"name": "ahab", "role": "captain"},
+			map[string]interface{}{"name": "ishmael", "role": "narrator"},
+			map[string]interface{}{"name": "starbuck"},
+		},
+		"ship":        map[string]interface{}{"name": "Pequod"},
+		"annotations": map[string]interface{}{"a.b": true},
+	}
+	if !reflect.DeepEqual(vals, expect) {
+		t.Errorf("Expected the values %v, got %v", expect, vals)
+	}
+
+	data, err = ioutil.ReadFile(filepath.Join(dir, "Chart.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if chartfile := string(data); !strings.Contains(chartfile, "version: 1.3.0 # bumped by the bot") || !strings.Contains(chartfile, "# The whale chart") {
+		t.Errorf("Expected the version to be bumped in place, got\n%s", chartfile)
+	}
+	if chartfile := string(data); !strings.HasSuffix(chartfile, "appVersion: \"1.3\"\n") {
+		t.Errorf("Expected the appVersion to be set, got\n%s", chartfile)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "templates", "ship.yaml")); err != nil {
+		t.Errorf("Expected the template to be written: %s", err)
+	}
+}
+
+func TestEditErrors(t *testing.T) {
+	dir := writeTestChart(t)
+	defer os.RemoveAll(dir)
+
+	c, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for path, expect := range map[string]string{
+		"image.tag.major": "the parent of major is not a map",
+		"crew[5]":         "the index 5 is out of range",
+		"image[0]":        "the parent of [0] is not a list",
+		"image..tag":      "empty key",
+		"crew[0]name":     "key after an index",
+		"crew[x]":         `invalid index "x"`,
+	} {
+		if err := c.SetValue(path, "x"); err == nil || !strings.Contains(err.Error(), expect) {
+			t.Errorf("Expected %s to fail with %q, got %v", path, expect, err)
+		}
+	}
+	if err := c.AddTemplate("ship.yaml", nil); err == nil {
+		t.Error("Expected a template outside of templates/ to be rejected")
+	}
+	if err := c.AddTemplate("templates/ship.yaml", nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.AddTemplate("templates/ship.yaml", nil); err == nil {
+		t.Error("Expected an existing template to be rejected")
+	}
+	if _, err := Open(filepath.Join(dir, "missing")); err != chartutil.ErrMissingChartYAML {
+		t.Errorf("Expected ErrMissingChartYAML, got %v", err)
+	}
+}
+
+func TestNextVersion(t *testing.T) {
+	for _, tt := range []struct {
+		version  string
+		strategy BumpStrategy
+		expect   string
+	}{
+		{"1.2.3", BumpMajor, "2.0.0"},
+		{"1.2.3", BumpMinor, "1.3.0"},
+		{"1.2.3", BumpPatch, "1.2.4"},
+		{"1.2.3-rc.1+abc", BumpPatch, "1.2.4"},
+	} {
+		if v, err := NextVersion(tt.version, tt.strategy); err != nil || v != tt.expect {
+			t.Errorf("Expected %s bumped with %s to be %s, got %q (%v)", tt.version, tt.strategy, tt.expect, v, err)
+		}
+	}
+	if _, err := NextVersion("1.2.3", "build"); err == nil {
+		t.Error("Expected an unknown strategy to fail")
+	}
+	if _, err := NextVersion("latest", BumpPatch); err == nil {
+		t.Error("Expected an invalid version to fail")
+	}
+}
+
+func TestWithBuildMetadata(t *testing.T) {
+	for _, tt := range []struct {
+		version  string
+		metadata string
+		expect   string
+	}{
+		{"1.2.3", "4f3a2b1", "1.2.3+4f3a2b1"},
+		{"1.2.3-rc.1+abc", "4f3a2b1", "1.2.3-rc.1+4f3a2b1"},
+		{"1.2.3+abc", "", "1.2.3"},
+	} {
+		if v, err := WithBuildMetadata(tt.version, tt.metadata); err != nil || v != tt.expect {
+			t.Errorf("Expected %s with the build metadata %q to be %s, got %q (%v)", tt.version, tt.metadata, tt.expect, v, err)
+		}
+	}
+	if _, err := WithBuildMetadata("1.2.3", "feature/x"); err == nil {
+		t.Error("Expected invalid build metadata to fail")
+	}
+	if _, err := WithBuildMetadata("latest", "4f3a2b1"); err == nil {
+		t.Error("Expected an invalid version to fail")
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartedit
+
+import (
+	"fmt"
+
+	"github.com/Masterminds/semver"
+)
+
+// BumpStrategy is the part of a semantic version that a bump increments.
+type BumpStrategy string
+
+const (
+	// BumpMajor increments the major version, like 1.2.3 to 2.0.0.
+	BumpMajor BumpStrategy = "major"
+	// BumpMinor increments the minor version, like 1.2.3 to 1.3.0.
+	BumpMinor BumpStrategy = "minor"
+	// BumpPatch increments the patch version, like 1.2.3 to 1.2.4.
+	BumpPatch BumpStrategy = "patch"
+)
+
+// NextVersion returns the semantic version version bumped with the strategy
+// s. The prerelease and the build metadata of version are dropped.
+func NextVersion(version string, s BumpStrategy) (string, error) {
+	v, err := semver.NewVersion(version)
+	if err != nil {
+		return "", fmt.Errorf("cannot bump the version %q: %s", version, err)
+	}
+	// Drop the prerelease first, the patch version of a prerelease would
+	// not be incremented otherwise.
+	base, err := semver.NewVersion(fmt.Sprintf("%d.%d.%d", v.Major(), v.Minor(), v.Patch()))
+	if err != nil {
+		return "", err
+	}
+	var next semver.Version
+	switch s {
+	case BumpMajor:
+		next = base.IncMajor()
+	case BumpMinor:
+		next = base.IncMinor()
+	case BumpPatch:
+		next = base.IncPatch()
+	default:
+		return "", fmt.Errorf("unknown version bump strategy %q, expected %q, %q or %q", s, BumpMajor, BumpMinor, BumpPatch)
+	}
+	return next.String(), nil
+}
+
+// WithBuildMetadata returns the semantic version version with the build
+// metadata metadata, like 1.2.3+4f3a2b1, replacing its build metadata. The
+// build metadata is dropped if metadata is empty.
+func WithBuildMetadata(version, metadata string) (string, error) {
+	v, err := semver.NewVersion(version)
+	if err != nil {
+		return "", fmt.Errorf("cannot set the build metadata of the version %q: %s", version, err)
+	}
+	s := fmt.Sprintf("%d.%d.%d", v.Major(), v.Minor(), v.Patch())
+	if v.Prerelease() != "" {
+		s += "-" + v.Prerelease()
+	}
+	if metadata != "" {
+		s += "+" + metadata
+	}
+	if _, err := semver.NewVersion(s); err != nil {
+		return "", fmt.Errorf("invalid build metadata %q: %s", metadata, err)
+	}
+	return s, nil
+}
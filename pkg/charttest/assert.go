@@ -0,0 +1,307 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package charttest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Assertion asserts the manifests rendered by a test. Exactly one kind of
+// assertion must be set.
+//
+// Assertions on the content of the manifests are checked on every document
+// of the asserted templates, unless DocumentIndex is set.
+type Assertion struct {
+	// Not inverts the assertion.
+	Not bool `json:"not,omitempty"`
+	// DocumentIndex is the index of the only document asserted, counting
+	// the documents of all the asserted templates.
+	DocumentIndex *int `json:"documentIndex,omitempty"`
+
+	// Equal asserts that the value at Path is Value.
+	Equal *AssertionArgs `json:"equal,omitempty"`
+	// Exists asserts that there is a value at Path.
+	Exists *AssertionArgs `json:"exists,omitempty"`
+	// MatchRegex asserts that the string at Path matches Pattern.
+	MatchRegex *AssertionArgs `json:"matchRegex,omitempty"`
+	// Contains asserts that the list at Path contains Value.
+	Contains *AssertionArgs `json:"contains,omitempty"`
+	// IsKind asserts that the kind of the document is Of.
+	IsKind *AssertionArgs `json:"isKind,omitempty"`
+	// HasDocuments asserts that the templates rendered Count documents.
+	HasDocuments *AssertionArgs `json:"hasDocuments,omitempty"`
+	// FailedTemplate asserts that rendering fails with an error containing
+	// ErrorMessage.
+	FailedTemplate *AssertionArgs `json:"failedTemplate,omitempty"`
+}
+
+// AssertionArgs are the arguments of an assertion.
+type AssertionArgs struct {
+	// Path is the path of a value in a document, e.g. "spec.ports[0].port"
+	// or 'metadata.labels["app.kubernetes.io/name"]'.
+	Path         string      `json:"path,omitempty"`
+	Value        interface{} `json:"value,omitempty"`
+	Pattern      string      `json:"pattern,omitempty"`
+	Of           string      `json:"of,omitempty"`
+	Count        *int        `json:"count,omitempty"`
+	ErrorMessage string      `json:"errorMessage,omitempty"`
+}
+
+// document is a YAML document rendered by a template.
+type document struct {
+	// template is the template rendering it, e.g. "templates/service.yaml".
+	template string
+	// index is the index of the document in the template.
+	index   int
+	content interface{}
+}
+
+func (d document) String() string {
+	return fmt.Sprintf("%s#%d", d.template, d.index)
+}
+
+// kind returns the kind of the assertion and its arguments.
+func (a *Assertion) kind() (string, *AssertionArgs) {
+	var name string
+	var args *AssertionArgs
+	for k, v := range map[string]*AssertionArgs{
+		"equal":          a.Equal,
+		"exists":         a.Exists,
+		"matchRegex":     a.MatchRegex,
+		"contains":       a.Contains,
+		"isKind":         a.IsKind,
+		"hasDocuments":   a.HasDocuments,
+		"failedTemplate": a.FailedTemplate,
+	} {
+		if v != nil {
+			if name != "" {
+				return "", nil
+			}
+			name, args = k, v
+		}
+	}
+	return name, args
+}
+
+func (a *Assertion) validate() error {
+	kind, args := a.kind()
+	if kind == "" {
+		return errors.New("exactly one of equal, exists, matchRegex, contains, isKind, hasDocuments or failedTemplate must be set")
+	}
+	switch kind {
+	case "equal", "exists", "contains":
+		if _, err := parsePath(args.Path); err != nil {
+			return fmt.Errorf("%s: %s", kind, err)
+		}
+	case "matchRegex":
+		if _, err := parsePath(args.Path); err != nil {
+			return fmt.Errorf("%s: %s", kind, err)
+		}
+		if _, err := regexp.Compile(args.Pattern); err != nil {
+			return fmt.Errorf("%s: %s", kind, err)
+		}
+	case "isKind":
+		if args.Of == "" {
+			return errors.New("isKind: 'of' is required")
+		}
+	case "hasDocuments":
+		if args.Count == nil {
+			return errors.New("hasDocuments: 'count' is required")
+		}
+	}
+	return nil
+}
+
+// check checks the assertion on the rendered documents, or the error
+// rendering them, and returns the failures.
+func (a *Assertion) check(docs []document, renderErr error) []string {
+	kind, args := a.kind()
+	subject := kind
+	if a.Not {
+		subject = "not " + kind
+	}
+	if args.Path != "" {
+		subject += " " + args.Path
+	}
+
+	switch kind {
+	case "failedTemplate":
+		ok := renderErr != nil && strings.Contains(renderErr.Error(), args.ErrorMessage)
+		if ok == a.Not {
+			got := "no error"
+			if renderErr != nil {
+				got = strconv.Quote(renderErr.Error())
+			}
+			return []string{fmt.Sprintf("%s: expected an error containing %q, got %s", subject, args.ErrorMessage, got)}
+		}
+		return nil
+	}
+	if renderErr != nil {
+		return []string{fmt.Sprintf("%s: rendering failed: %s", subject, renderErr)}
+	}
+
+	if kind == "hasDocuments" {
+		if (len(docs) == *args.Count) == a.Not {
+			return []string{fmt.Sprintf("%s: expected %d documents, got %d", subject, *args.Count, len(docs))}
+		}
+		return nil
+	}
+
+	if a.DocumentIndex != nil {
+		if *a.DocumentIndex < 0 || *a.DocumentIndex >= len(docs) {
+			return []string{fmt.Sprintf("%s: documentIndex %d is out of range, %d documents rendered", subject, *a.DocumentIndex, len(docs))}
+		}
+		docs = docs[*a.DocumentIndex : *a.DocumentIndex+1]
+	}
+	if len(docs) == 0 {
+		return []string{fmt.Sprintf("%s: no documents rendered", subject)}
+	}
+
+	var failures []string
+	for _, doc := range docs {
+		ok, expected, got := checkDocument(kind, args, doc.content)
+		if ok != a.Not {
+			continue
+		}
+		if a.Not {
+			failures = append(failures, fmt.Sprintf("%s: %s: expected not %s", doc, subject, expected))
+		} else {
+			failures = append(failures, fmt.Sprintf("%s: %s: expected %s, got %s", doc, subject, expected, got))
+		}
+	}
+	return failures
+}
+
+// checkDocument checks an assertion on the content of a document. It returns
+// whether it holds, and descriptions of the expected and of the actual value.
+func checkDocument(kind string, args *AssertionArgs, content interface{}) (ok bool, expected, got string) {
+	if kind == "isKind" {
+		actual, _ := lookupPath(content, []pathElem{{key: "kind"}})
+		return actual == args.Of, strconv.Quote(args.Of), describe(actual, actual != nil)
+	}
+
+	path, _ := parsePath(args.Path)
+	actual, found := lookupPath(content, path)
+	got = describe(actual, found)
+	switch kind {
+	case "equal":
+		return found && reflect.DeepEqual(actual, args.Value), describe(args.Value, true), got
+	case "exists":
+		return found, "a value", got
+	case "matchRegex":
+		s, isString := actual.(string)
+		return isString && regexp.MustCompile(args.Pattern).MatchString(s), "a string matching " + strconv.Quote(args.Pattern), got
+	case "contains":
+		list, _ := actual.([]interface{})
+		for _, item := range list {
+			if reflect.DeepEqual(item, args.Value) {
+				return true, "a list containing " + describe(args.Value, true), got
+			}
+		}
+		return false, "a list containing " + describe(args.Value, true), got
+	}
+	return false, "", got
+}
+
+// describe describes a value in failure messages.
+func describe(v interface{}, found bool) string {
+	if !found {
+		return "nothing"
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(b)
+}
+
+// pathElem is an element of a path: the key of a map or the index of a list.
+type pathElem struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+// parsePath parses a path like 'spec.ports[0].port' or
+// 'metadata.labels["app.kubernetes.io/name"]'.
+func parsePath(p string) ([]pathElem, error) {
+	var elems []pathElem
+	for i := 0; i < len(p); {
+		if p[i] == '[' {
+			end := strings.IndexByte(p[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed '[' in path %q", p)
+			}
+			inner := p[i+1 : i+end]
+			if n, err := strconv.Atoi(inner); err == nil {
+				elems = append(elems, pathElem{index: n, isIndex: true})
+			} else if key, err := strconv.Unquote(inner); err == nil {
+				elems = append(elems, pathElem{key: key})
+			} else {
+				return nil, fmt.Errorf("invalid index %q in path %q", inner, p)
+			}
+			i += end + 1
+		} else {
+			end := strings.IndexAny(p[i:], ".[")
+			if end < 0 {
+				end = len(p) - i
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("empty key in path %q", p)
+			}
+			elems = append(elems, pathElem{key: p[i : i+end]})
+			i += end
+		}
+		if i < len(p) && p[i] == '.' {
+			if i++; i == len(p) {
+				return nil, fmt.Errorf("empty key in path %q", p)
+			}
+		}
+	}
+	if len(elems) == 0 {
+		return nil, errors.New("path is required")
+	}
+	return elems, nil
+}
+
+// lookupPath returns the value at path in v, and whether there is one.
+func lookupPath(v interface{}, path []pathElem) (interface{}, bool) {
+	for _, e := range path {
+		if e.isIndex {
+			list, ok := v.([]interface{})
+			if !ok || e.index < 0 || e.index >= len(list) {
+				return nil, false
+			}
+			v = list[e.index]
+			continue
+		}
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if v, ok = m[e.key]; !ok {
+			return nil, false
+		}
+	}
+	return v, true
+}
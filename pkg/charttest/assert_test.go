@@ -0,0 +1,127 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package charttest
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParsePath(t *testing.T) {
+	tests := []struct {
+		path   string
+		expect []pathElem
+	}{
+		{"a", []pathElem{{key: "a"}}},
+		{"spec.ports[0].port", []pathElem{{key: "spec"}, {key: "ports"}, {index: 0, isIndex: true}, {key: "port"}}},
+		{`metadata.labels["app.kubernetes.io/name"]`, []pathElem{{key: "metadata"}, {key: "labels"}, {key: "app.kubernetes.io/name"}}},
+		{"[1][2]", []pathElem{{index: 1, isIndex: true}, {index: 2, isIndex: true}}},
+	}
+	for _, tt := range tests {
+		got, err := parsePath(tt.path)
+		if err != nil {
+			t.Errorf("%s: %s", tt.path, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.expect) {
+			t.Errorf("%s: expected %v, got %v", tt.path, tt.expect, got)
+		}
+	}
+
+	for _, path := range []string{"", ".a", "a.", "a..b", "a[0", "a[x]"} {
+		if _, err := parsePath(path); err == nil {
+			t.Errorf("%q: expected an error", path)
+		}
+	}
+}
+
+func TestAssertionCheck(t *testing.T) {
+	docs := []document{
+		{template: "templates/a.yaml", content: map[string]interface{}{
+			"kind": "Service",
+			"spec": map[string]interface{}{"ports": []interface{}{map[string]interface{}{"port": float64(80)}}},
+		}},
+		{template: "templates/a.yaml", index: 1, content: map[string]interface{}{"kind": "ConfigMap"}},
+	}
+	one, two := 1, 2
+
+	tests := []struct {
+		name      string
+		assertion Assertion
+		renderErr error
+		expect    []string
+	}{
+		{
+			name:      "equal",
+			assertion: Assertion{Equal: &AssertionArgs{Path: "spec.ports[0].port", Value: float64(80)}, DocumentIndex: new(int)},
+		},
+		{
+			name:      "equal on every document",
+			assertion: Assertion{Equal: &AssertionArgs{Path: "spec.ports[0].port", Value: float64(80)}},
+			expect:    []string{"templates/a.yaml#1: equal spec.ports[0].port: expected 80, got nothing"},
+		},
+		{
+			name:      "not isKind",
+			assertion: Assertion{IsKind: &AssertionArgs{Of: "ConfigMap"}, Not: true},
+			expect:    []string{`templates/a.yaml#1: not isKind: expected not "ConfigMap"`},
+		},
+		{
+			name:      "contains",
+			assertion: Assertion{Contains: &AssertionArgs{Path: "spec.ports", Value: map[string]interface{}{"port": float64(81)}}, DocumentIndex: new(int)},
+			expect:    []string{`templates/a.yaml#0: contains spec.ports: expected a list containing {"port":81}, got [{"port":80}]`},
+		},
+		{
+			name:      "matchRegex",
+			assertion: Assertion{MatchRegex: &AssertionArgs{Path: "kind", Pattern: "^(Service|ConfigMap)$"}},
+		},
+		{
+			name:      "documentIndex out of range",
+			assertion: Assertion{Exists: &AssertionArgs{Path: "kind"}, DocumentIndex: &two},
+			expect:    []string{"exists kind: documentIndex 2 is out of range, 2 documents rendered"},
+		},
+		{
+			name:      "hasDocuments",
+			assertion: Assertion{HasDocuments: &AssertionArgs{Count: &one}},
+			expect:    []string{"hasDocuments: expected 1 documents, got 2"},
+		},
+		{
+			name:      "rendering failed",
+			assertion: Assertion{Exists: &AssertionArgs{Path: "kind"}},
+			renderErr: errors.New("boom"),
+			expect:    []string{"exists kind: rendering failed: boom"},
+		},
+		{
+			name:      "failedTemplate",
+			assertion: Assertion{FailedTemplate: &AssertionArgs{ErrorMessage: "boom"}},
+			renderErr: errors.New("render error: boom"),
+		},
+		{
+			name:      "failedTemplate without error",
+			assertion: Assertion{FailedTemplate: &AssertionArgs{ErrorMessage: "boom"}},
+			expect:    []string{`failedTemplate: expected an error containing "boom", got no error`},
+		},
+	}
+	for _, tt := range tests {
+		if err := tt.assertion.validate(); err != nil {
+			t.Fatalf("%s: %s", tt.name, err)
+		}
+		if got := tt.assertion.check(docs, tt.renderErr); !reflect.DeepEqual(got, tt.expect) {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.expect, got)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*Package charttest runs the unit tests of a chart.
+
+The tests are declared in the 'tests/' directory of the chart, in files named
+'*_test.yaml'. Every file is a suite of tests, and every test renders the chart
+locally with some values, then asserts what the rendered manifests contain:
+
+	suite: configmap
+	templates:
+	  - templates/configmap.yaml
+	tests:
+	  - it: uses the production tag
+	    environment: values-prod.yaml
+	    set:
+	      image.tag: "2.0"
+	    asserts:
+	      - isKind:
+	          of: ConfigMap
+	      - equal:
+	          path: data.tag
+	          value: "2.0"
+
+The tests are run by 'helm unittest'.
+*/
+package charttest // import "k8s.io/helm/pkg/charttest"
@@ -0,0 +1,105 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package charttest
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	File     string          `xml:"file,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit writes the result as a JUnit XML report, read by most CI
+// systems. Every suite is a test suite, with the tests as test cases.
+func (r *Result) WriteJUnit(out io.Writer) error {
+	report := junitTestSuites{Name: r.Chart}
+	var total time.Duration
+	for _, s := range r.Suites {
+		suite := junitTestSuite{
+			Name:  s.Name,
+			File:  s.File,
+			Tests: len(s.Tests),
+			Time:  junitTime(s.Duration()),
+		}
+		for _, t := range s.Tests {
+			tc := junitTestCase{
+				Name:      t.Name,
+				Classname: r.Chart + "." + s.Name,
+				Time:      junitTime(t.Duration),
+			}
+			if !t.Passed() {
+				suite.Failures++
+				tc.Failure = &junitFailure{
+					Message: fmt.Sprintf("%d assertions failed", len(t.Failures)),
+					Text:    strings.Join(t.Failures, "\n"),
+				}
+			}
+			suite.Cases = append(suite.Cases, tc)
+		}
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		total += s.Duration()
+		report.Suites = append(report.Suites, suite)
+	}
+	report.Time = junitTime(total)
+
+	if _, err := io.WriteString(out, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(out)
+	enc.Indent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		return err
+	}
+	_, err := io.WriteString(out, "\n")
+	return err
+}
+
+func junitTime(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
@@ -0,0 +1,249 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package charttest
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/releaseutil"
+	"k8s.io/helm/pkg/renderutil"
+	"k8s.io/helm/pkg/timeconv"
+)
+
+// Options are the options of the rendering of the charts.
+type Options struct {
+	// KubeVersion is the Kubernetes version used as Capabilities.KubeVersion.
+	KubeVersion string
+	// APIVersions are the api versions used as Capabilities.APIVersions.
+	APIVersions []string
+}
+
+// Result is the result of the test suites of a chart.
+type Result struct {
+	// Chart is the name of the chart.
+	Chart  string
+	Suites []*SuiteResult
+}
+
+// SuiteResult is the result of a test suite.
+type SuiteResult struct {
+	Name  string
+	File  string
+	Tests []*TestResult
+}
+
+// TestResult is the result of a test.
+type TestResult struct {
+	Name     string
+	Duration time.Duration
+	// Failures describe the assertions that failed.
+	Failures []string
+}
+
+// Passed returns true if all the test suites passed.
+func (r *Result) Passed() bool {
+	for _, s := range r.Suites {
+		if !s.Passed() {
+			return false
+		}
+	}
+	return true
+}
+
+// Passed returns true if all the tests of the suite passed.
+func (r *SuiteResult) Passed() bool {
+	for _, t := range r.Tests {
+		if !t.Passed() {
+			return false
+		}
+	}
+	return true
+}
+
+// Duration returns the time spent running the tests of the suite.
+func (r *SuiteResult) Duration() time.Duration {
+	var d time.Duration
+	for _, t := range r.Tests {
+		d += t.Duration
+	}
+	return d
+}
+
+// Passed returns true if no assertion of the test failed.
+func (r *TestResult) Passed() bool {
+	return len(r.Failures) == 0
+}
+
+// Run runs the test suites of the chart at chartPath. Every test loads the
+// chart, with the environment values file of the test, and renders it.
+func Run(chartPath string, opts Options) (*Result, error) {
+	c, err := chartutil.Load(chartPath)
+	if err != nil {
+		return nil, err
+	}
+	suites, err := LoadSuites(c)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{Chart: c.Metadata.Name}
+	for _, s := range suites {
+		sr := &SuiteResult{Name: s.Name, File: s.File}
+		for _, t := range s.Tests {
+			sr.Tests = append(sr.Tests, runTest(chartPath, s, t, opts))
+		}
+		result.Suites = append(result.Suites, sr)
+	}
+	return result, nil
+}
+
+func runTest(chartPath string, s *Suite, t *Test, opts Options) *TestResult {
+	start := time.Now()
+	result := &TestResult{Name: t.It}
+	docs, renderErr, err := render(chartPath, s, t, opts)
+	if err != nil {
+		result.Failures = []string{err.Error()}
+	} else {
+		for i := range t.Asserts {
+			result.Failures = append(result.Failures, t.Asserts[i].check(docs, renderErr)...)
+		}
+	}
+	result.Duration = time.Since(start)
+	return result
+}
+
+// render renders the chart for the test t, and returns the documents of the
+// asserted templates or the error rendering them. The error returned is an
+// error setting up the test.
+func render(chartPath string, s *Suite, t *Test, opts Options) ([]document, error, error) {
+	c, err := chartutil.LoadWithEnvValuesFile(chartPath, t.Environment)
+	if err != nil {
+		return nil, nil, err
+	}
+	config, err := testValues(t)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	release := chartutil.ReleaseOptions{
+		Name:        "RELEASE-NAME",
+		Namespace:   "NAMESPACE",
+		IsInstall:   !t.Release.Upgrade,
+		IsUpgrade:   t.Release.Upgrade,
+		Time:        timeconv.Now(),
+		Environment: t.Environment,
+	}
+	if t.Release.Name != "" {
+		release.Name = t.Release.Name
+	}
+	if t.Release.Namespace != "" {
+		release.Namespace = t.Release.Namespace
+	}
+	rendered, renderErr := renderutil.Render(c, config, renderutil.Options{
+		ReleaseOptions: release,
+		KubeVersion:    opts.KubeVersion,
+		APIVersions:    opts.APIVersions,
+	})
+	if renderErr != nil {
+		return nil, renderErr, nil
+	}
+
+	templates := t.Templates
+	if len(templates) == 0 {
+		templates = s.Templates
+	}
+	prefix := c.Metadata.Name + "/"
+	if len(templates) == 0 {
+		for name := range rendered {
+			if b := path.Base(name); b != "NOTES.txt" && !strings.HasPrefix(b, "_") {
+				templates = append(templates, strings.TrimPrefix(name, prefix))
+			}
+		}
+		sort.Strings(templates)
+	}
+
+	var docs []document
+	for _, tpl := range templates {
+		content, ok := rendered[prefix+tpl]
+		if !ok {
+			return nil, nil, fmt.Errorf("template %s not found", tpl)
+		}
+		manifests := releaseutil.SplitManifests(content)
+		index := 0
+		for i := 0; i < len(manifests); i++ {
+			var v interface{}
+			if err := yaml.Unmarshal([]byte(manifests[fmt.Sprintf("manifest-%d", i)]), &v); err != nil {
+				return nil, nil, fmt.Errorf("%s: invalid YAML: %s", tpl, err)
+			}
+			if v == nil {
+				continue
+			}
+			docs = append(docs, document{template: tpl, index: index, content: v})
+			index++
+		}
+	}
+	return docs, nil, nil
+}
+
+// testValues returns the values of the test t.
+func testValues(t *Test) (*chart.Config, error) {
+	// The values are copied as Set modifies them.
+	raw, err := yaml.Marshal(t.Values)
+	if err != nil {
+		return nil, err
+	}
+	base := map[string]interface{}{}
+	if err := yaml.Unmarshal(raw, &base); err != nil {
+		return nil, err
+	}
+	if base == nil {
+		base = map[string]interface{}{}
+	}
+
+	keys := make([]string, 0, len(t.Set))
+	for k := range t.Set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		m := base
+		parts := strings.Split(k, ".")
+		for _, p := range parts[:len(parts)-1] {
+			next, ok := m[p].(map[string]interface{})
+			if !ok {
+				next = map[string]interface{}{}
+				m[p] = next
+			}
+			m = next
+		}
+		m[parts[len(parts)-1]] = t.Set[k]
+	}
+
+	raw, err = yaml.Marshal(base)
+	if err != nil {
+		return nil, err
+	}
+	return &chart.Config{Raw: string(raw), Values: map[string]*chart.Value{}}, nil
+}
@@ -0,0 +1,84 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package charttest
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	result, err := Run("testdata/moby", Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Chart != "moby" || len(result.Suites) != 2 {
+		t.Fatalf("Unexpected result %+v", result)
+	}
+	for _, s := range result.Suites {
+		for _, tr := range s.Tests {
+			if !tr.Passed() {
+				t.Errorf("%s: %s: %s", s.Name, tr.Name, strings.Join(tr.Failures, "\n"))
+			}
+		}
+	}
+	if !result.Passed() {
+		t.Error("Expected the tests to pass")
+	}
+}
+
+func TestRunFailing(t *testing.T) {
+	result, err := Run("testdata/failing", Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Passed() {
+		t.Fatal("Expected the tests to fail")
+	}
+	tests := result.Suites[0].Tests
+	if !tests[0].Passed() {
+		t.Errorf("Expected %q to pass, got %v", tests[0].Name, tests[0].Failures)
+	}
+	expect := []string{
+		"templates/service.yaml#0: equal spec.ports[0].port: expected 8080, got 80",
+		`templates/service.yaml#0: not isKind: expected not "Service"`,
+	}
+	if got := tests[1].Failures; strings.Join(got, "\n") != strings.Join(expect, "\n") {
+		t.Errorf("Expected failures %q, got %q", expect, got)
+	}
+
+	var buf bytes.Buffer
+	if err := result.WriteJUnit(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var report junitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("Invalid JUnit report: %s\n%s", err, buf.String())
+	}
+	if report.Name != "failing" || report.Tests != 2 || report.Failures != 1 || len(report.Suites) != 1 {
+		t.Fatalf("Unexpected report %+v", report)
+	}
+	cases := report.Suites[0].Cases
+	if cases[0].Failure != nil || cases[1].Failure == nil || cases[1].Classname != "failing.service" {
+		t.Errorf("Unexpected test cases %+v", cases)
+	}
+	if !strings.Contains(cases[1].Failure.Text, "expected 8080, got 80") {
+		t.Errorf("Expected the failures in the report, got %q", cases[1].Failure.Text)
+	}
+}
@@ -0,0 +1,119 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package charttest
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+// TestsDir is the directory of a chart containing its test suites.
+const TestsDir = "tests"
+
+// Suite is a file of tests of a chart.
+type Suite struct {
+	// Name is the name of the suite. It defaults to the name of the file.
+	Name string `json:"suite"`
+	// File is the path of the file in the chart, e.g. "tests/service_test.yaml".
+	File string `json:"-"`
+	// Templates are the templates asserted by the tests that do not set
+	// their templates, e.g. "templates/service.yaml". All the templates are
+	// asserted if none is set.
+	Templates []string `json:"templates,omitempty"`
+	Tests     []*Test  `json:"tests"`
+}
+
+// Test renders the chart and asserts the rendered manifests.
+type Test struct {
+	// It describes the test, e.g. "uses the production tag".
+	It string `json:"it"`
+	// Environment is the environment values file of the chart to use.
+	Environment string `json:"environment,omitempty"`
+	// Values are values overriding the values of the chart.
+	Values map[string]interface{} `json:"values,omitempty"`
+	// Set are values overriding the values of the chart, by dotted path,
+	// e.g. "image.tag". They are set after Values.
+	Set map[string]interface{} `json:"set,omitempty"`
+	// Release sets the release the chart is rendered for.
+	Release Release `json:"release,omitempty"`
+	// Templates are the templates asserted, overriding the ones of the suite.
+	Templates []string    `json:"templates,omitempty"`
+	Asserts   []Assertion `json:"asserts"`
+}
+
+// Release is the release a chart is rendered for in a test.
+type Release struct {
+	// Name defaults to "RELEASE-NAME".
+	Name string `json:"name,omitempty"`
+	// Namespace defaults to "NAMESPACE".
+	Namespace string `json:"namespace,omitempty"`
+	// Upgrade renders the chart as upgrading the release.
+	Upgrade bool `json:"upgrade,omitempty"`
+}
+
+// LoadSuites loads the test suites of the chart c, sorted by file.
+func LoadSuites(c *chart.Chart) ([]*Suite, error) {
+	var suites []*Suite
+	for _, f := range c.Files {
+		if path.Dir(f.TypeUrl) != TestsDir || !strings.HasSuffix(f.TypeUrl, "_test.yaml") {
+			continue
+		}
+		s, err := ParseSuite(f.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", f.TypeUrl, err)
+		}
+		s.File = f.TypeUrl
+		if s.Name == "" {
+			s.Name = strings.TrimSuffix(path.Base(f.TypeUrl), "_test.yaml")
+		}
+		suites = append(suites, s)
+	}
+	sort.Slice(suites, func(i, j int) bool { return suites[i].File < suites[j].File })
+	return suites, nil
+}
+
+// ParseSuite parses a test suite and validates its tests.
+func ParseSuite(data []byte) (*Suite, error) {
+	s := &Suite{}
+	if err := yaml.Unmarshal(data, s); err != nil {
+		return nil, err
+	}
+	if len(s.Tests) == 0 {
+		return nil, errors.New("no tests")
+	}
+	for i, t := range s.Tests {
+		if t.It == "" {
+			return nil, fmt.Errorf("test %d: 'it' is required", i)
+		}
+		if len(t.Asserts) == 0 {
+			return nil, fmt.Errorf("test %q: no asserts", t.It)
+		}
+		for j := range t.Asserts {
+			if err := t.Asserts[j].validate(); err != nil {
+				return nil, fmt.Errorf("test %q: assert %d: %s", t.It, j, err)
+			}
+		}
+	}
+	return s, nil
+}
@@ -0,0 +1,68 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package charttest
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/helm/pkg/chartutil"
+)
+
+func TestLoadSuites(t *testing.T) {
+	c, err := chartutil.Load("testdata/moby")
+	if err != nil {
+		t.Fatal(err)
+	}
+	suites, err := LoadSuites(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(suites) != 2 {
+		t.Fatalf("Expected 2 suites, got %d", len(suites))
+	}
+	// The name of a suite defaults to the name of its file.
+	if s := suites[0]; s.Name != "config" || s.File != "tests/config_test.yaml" || len(s.Tests) != 1 {
+		t.Errorf("Unexpected suite %+v", s)
+	}
+	if s := suites[1]; s.Name != "deployment" || len(s.Templates) != 1 || len(s.Tests) != 4 {
+		t.Errorf("Unexpected suite %+v", s)
+	}
+}
+
+func TestParseSuiteErrors(t *testing.T) {
+	tests := []struct {
+		spec   string
+		expect string
+	}{
+		{"suite: empty", "no tests"},
+		{"tests:\n- asserts:\n  - exists: {path: a}", "'it' is required"},
+		{"tests:\n- it: a", "no asserts"},
+		{"tests:\n- it: a\n  asserts:\n  - not: true", "exactly one of"},
+		{"tests:\n- it: a\n  asserts:\n  - exists: {path: a}\n    isKind: {of: Pod}", "exactly one of"},
+		{"tests:\n- it: a\n  asserts:\n  - equal: {path: 'a..b'}", "empty key"},
+		{"tests:\n- it: a\n  asserts:\n  - matchRegex: {path: a, pattern: '('}", "matchRegex"},
+		{"tests:\n- it: a\n  asserts:\n  - isKind: {}", "'of' is required"},
+		{"tests:\n- it: a\n  asserts:\n  - hasDocuments: {}", "'count' is required"},
+	}
+	for _, tt := range tests {
+		_, err := ParseSuite([]byte(tt.spec))
+		if err == nil || !strings.Contains(err.Error(), tt.expect) {
+			t.Errorf("%q: expected error containing %q, got %v", tt.spec, tt.expect, err)
+		}
+	}
+}
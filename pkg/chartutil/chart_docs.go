@@ -0,0 +1,100 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import (
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/golang/protobuf/ptypes/any"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+// NotesFile is the path of the template of the notes of a chart, displayed
+// once it is installed.
+const NotesFile = "templates/NOTES.txt"
+
+var (
+	// readmeFileNames are the names of the README file of a chart, in lower
+	// case, by order of preference.
+	readmeFileNames = []string{"readme.md", "readme.txt", "readme"}
+	// licenseFileNames are the names of the LICENSE file of a chart, in lower
+	// case, by order of preference.
+	licenseFileNames = []string{"license", "license.md", "license.txt", "licence", "licence.md", "licence.txt"}
+	// iconFileNames are the names of the icon file of a chart without an icon
+	// in its Chart.yaml, in lower case, by order of preference.
+	iconFileNames = []string{"icon.svg", "icon.png", "icon.jpg", "icon.jpeg", "icon.gif"}
+)
+
+// Readme returns the README file of the chart c, whatever the case of its
+// name, or nil if it has none.
+func Readme(c *chart.Chart) *any.Any {
+	return findFile(c.Files, readmeFileNames)
+}
+
+// License returns the LICENSE file of the chart c, whatever the case of its
+// name, or nil if it has none.
+func License(c *chart.Chart) *any.Any {
+	return findFile(c.Files, licenseFileNames)
+}
+
+// Notes returns the template of the notes of the chart c, or nil if it has
+// none.
+func Notes(c *chart.Chart) *chart.Template {
+	for _, t := range c.Templates {
+		if t.Name == NotesFile {
+			return t
+		}
+	}
+	return nil
+}
+
+// Icon returns the icon of the chart c. If the icon of its Chart.yaml is a
+// URL, it returns the URL. Otherwise, it returns the file of the chart the
+// icon of its Chart.yaml refers to, or, without an icon in its Chart.yaml, its
+// icon file. Both are empty if the chart has no icon.
+func Icon(c *chart.Chart) (string, *any.Any) {
+	icon := c.GetMetadata().GetIcon()
+	if icon == "" {
+		return "", findFile(c.Files, iconFileNames)
+	}
+	if u, err := url.Parse(icon); err == nil && u.Scheme != "" {
+		return icon, nil
+	}
+	name := path.Clean(icon)
+	for _, f := range c.Files {
+		if path.Clean(f.TypeUrl) == name {
+			return "", f
+		}
+	}
+	return "", nil
+}
+
+// findFile returns the first file of files at the root of the chart whose
+// name, in lower case, is in names, the first names being preferred.
+func findFile(files []*any.Any, names []string) *any.Any {
+	for _, name := range names {
+		for _, f := range files {
+			if strings.ToLower(f.TypeUrl) == name {
+				return f
+			}
+		}
+	}
+	return nil
+}
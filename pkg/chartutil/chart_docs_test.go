@@ -0,0 +1,73 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/any"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+func TestChartDocs(t *testing.T) {
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "docs"},
+		Templates: []*chart.Template{
+			{Name: "templates/service.yaml"},
+			{Name: NotesFile, Data: []byte("Thank you for installing {{ .Chart.Name }}.")},
+		},
+		Files: []*any.Any{
+			{TypeUrl: "README.txt", Value: []byte("plain")},
+			{TypeUrl: "README.md", Value: []byte("# Docs")},
+			{TypeUrl: "docs/LICENSE", Value: []byte("not the license")},
+			{TypeUrl: "LICENSE", Value: []byte("Apache")},
+			{TypeUrl: "assets/logo.svg", Value: []byte("<svg/>")},
+			{TypeUrl: "icon.png", Value: []byte("png")},
+		},
+	}
+
+	if f := Readme(c); f == nil || f.TypeUrl != "README.md" {
+		t.Errorf("Expected README.md, got %v", f)
+	}
+	if f := License(c); f == nil || string(f.Value) != "Apache" {
+		t.Errorf("Expected LICENSE, got %v", f)
+	}
+	if n := Notes(c); n == nil || n.Name != NotesFile {
+		t.Errorf("Expected %s, got %v", NotesFile, n)
+	}
+
+	if u, f := Icon(c); u != "" || f == nil || f.TypeUrl != "icon.png" {
+		t.Errorf("Expected icon.png, got %q, %v", u, f)
+	}
+	c.Metadata.Icon = "./assets/logo.svg"
+	if u, f := Icon(c); u != "" || f == nil || f.TypeUrl != "assets/logo.svg" {
+		t.Errorf("Expected assets/logo.svg, got %q, %v", u, f)
+	}
+	c.Metadata.Icon = "https://example.com/logo.svg"
+	if u, f := Icon(c); u != c.Metadata.Icon || f != nil {
+		t.Errorf("Expected %s, got %q, %v", c.Metadata.Icon, u, f)
+	}
+
+	empty := &chart.Chart{Metadata: &chart.Metadata{Name: "empty"}}
+	if Readme(empty) != nil || License(empty) != nil || Notes(empty) != nil {
+		t.Error("Expected a chart without files to have no README, LICENSE or notes")
+	}
+	if u, f := Icon(empty); u != "" || f != nil {
+		t.Errorf("Expected no icon, got %q, %v", u, f)
+	}
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/ghodss/yaml"
 
 	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/version"
 )
 
 // ApiVersionV1 is the API version number for version 1.
@@ -33,6 +34,22 @@ import (
 // This is ApiVersionV1 instead of APIVersionV1 to match the protobuf-generated name.
 const ApiVersionV1 = "v1" // nolint
 
+// ApiVersionV2 is the API version number for version 2.
+//
+// Charts with apiVersion v2 declare their dependencies in Chart.yaml instead
+// of requirements.yaml, lock them in Chart.lock and have a type.
+const ApiVersionV2 = "v2" // nolint
+
+const (
+	// ChartTypeApplication is the type of charts that can be installed. It
+	// is the type of charts without type.
+	ChartTypeApplication = "application"
+	// ChartTypeLibrary is the type of charts providing named templates to
+	// the charts depending on them. Library charts cannot be installed, and
+	// their templates are not rendered.
+	ChartTypeLibrary = "library"
+)
+
 // UnmarshalChartfile takes raw Chart.yaml data and unmarshals it.
 func UnmarshalChartfile(data []byte) (*chart.Metadata, error) {
 	y := &chart.Metadata{}
@@ -43,6 +60,65 @@ func UnmarshalChartfile(data []byte) (*chart.Metadata, error) {
 	return y, nil
 }
 
+// validateChartfile validates the API version of the metadata, and its type
+// for charts with apiVersion v2. The type of charts with apiVersion v1 is
+// ignored.
+func validateChartfile(m *chart.Metadata) error {
+	switch m.ApiVersion {
+	case "", ApiVersionV1:
+		m.Type = ""
+	case ApiVersionV2:
+		switch m.Type {
+		case "", ChartTypeApplication, ChartTypeLibrary:
+		default:
+			return fmt.Errorf("type '%s' is not valid. The value must be %q or %q", m.Type, ChartTypeApplication, ChartTypeLibrary)
+		}
+	default:
+		return &ErrInvalidAPIVersion{APIVersion: m.ApiVersion}
+	}
+	return nil
+}
+
+// IsLibraryChart returns true if the chart is a library chart.
+func IsLibraryChart(c *chart.Chart) bool {
+	return c.GetMetadata().GetType() == ChartTypeLibrary
+}
+
+// CheckKubeVersion checks that the Kubernetes version satisfies the
+// kubeVersion constraints of the chart and of its dependencies.
+func CheckKubeVersion(c *chart.Chart, kubeVersion string) error {
+	if c.Metadata.KubeVersion != "" && !version.IsCompatibleRange(c.Metadata.KubeVersion, kubeVersion) {
+		return fmt.Errorf("Chart requires kubernetesVersion: %s which is incompatible with Kubernetes %s", c.Metadata.KubeVersion, kubeVersion)
+	}
+	for _, dep := range c.Dependencies {
+		if err := CheckKubeVersion(dep, kubeVersion); err != nil {
+			return fmt.Errorf("dependency %s: %s", dep.Metadata.Name, err)
+		}
+	}
+	return nil
+}
+
+// marshalChartfile returns the Chart.yaml file of the chart. The
+// dependencies of charts with apiVersion v2 are written to it.
+func marshalChartfile(c *chart.Chart) ([]byte, error) {
+	data, err := yaml.Marshal(c.Metadata)
+	if err != nil || c.Metadata.ApiVersion != ApiVersionV2 {
+		return data, err
+	}
+	reqs, err := LoadRequirements(c)
+	if err == ErrRequirementsNotFound {
+		return data, nil
+	} else if err != nil {
+		return nil, err
+	}
+	chartfile := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &chartfile); err != nil {
+		return nil, err
+	}
+	chartfile["dependencies"] = reqs.Dependencies
+	return yaml.Marshal(chartfile)
+}
+
 // LoadChartfile loads a Chart.yaml file into a *chart.Metadata.
 func LoadChartfile(filename string) (*chart.Metadata, error) {
 	b, err := ioutil.ReadFile(filename)
@@ -88,7 +164,7 @@ func IsChartDir(dirName string) (bool, error) {
 		return false, err
 	}
 	if chartContent == nil {
-		return false, errors.New("chart metadata (Chart.yaml) missing")
+		return false, ErrMissingChartYAML
 	}
 	if chartContent.Name == "" {
 		return false, errors.New("invalid chart (Chart.yaml): name must not be empty")
@@ -17,6 +17,7 @@ limitations under the License.
 package chartutil
 
 import (
+	"strings"
 	"testing"
 
 	"k8s.io/helm/pkg/proto/hapi/chart"
@@ -120,3 +121,21 @@ func TestIsChartDir(t *testing.T) {
 		return
 	}
 }
+
+func TestCheckKubeVersion(t *testing.T) {
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "frobnitz", KubeVersion: ">=1.10.0"},
+		Dependencies: []*chart.Chart{
+			{Metadata: &chart.Metadata{Name: "alpine", KubeVersion: "<1.16.0"}},
+		},
+	}
+	if err := CheckKubeVersion(c, "v1.14.0"); err != nil {
+		t.Errorf("Expected v1.14.0 to be compatible, got %s", err)
+	}
+	if err := CheckKubeVersion(c, "v1.9.0"); err == nil || !strings.Contains(err.Error(), "Chart requires kubernetesVersion: >=1.10.0") {
+		t.Errorf("Expected v1.9.0 to be incompatible with the chart, got %v", err)
+	}
+	if err := CheckKubeVersion(c, "v1.16.0"); err == nil || !strings.HasPrefix(err.Error(), "dependency alpine: ") {
+		t.Errorf("Expected v1.16.0 to be incompatible with the dependency, got %v", err)
+	}
+}
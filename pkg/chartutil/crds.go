@@ -0,0 +1,62 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+// CRD is a file of the crds/ directory of a chart.
+type CRD struct {
+	// Name is the path of the file, prefixed with the path of the chart, e.g.
+	// "mychart/charts/mysubchart/crds/crontab.yaml".
+	Name string
+	Data []byte
+}
+
+// CRDs returns the custom resource definitions of the crds/ directories of
+// the chart and its dependencies, the ones of the dependencies first.
+//
+// The files are not templates: they are installed as they are, before the
+// templates of the chart are rendered.
+func CRDs(c *chart.Chart) []CRD {
+	return crds(c, c.Metadata.Name)
+}
+
+func crds(c *chart.Chart, prefix string) []CRD {
+	var result []CRD
+	for _, dep := range c.Dependencies {
+		result = append(result, crds(dep, path.Join(prefix, ChartsDir, dep.Metadata.Name))...)
+	}
+
+	var own []CRD
+	for _, f := range c.Files {
+		if !strings.HasPrefix(f.TypeUrl, CRDsDir+"/") {
+			continue
+		}
+		switch path.Ext(f.TypeUrl) {
+		case ".yaml", ".yml", ".json":
+			own = append(own, CRD{Name: path.Join(prefix, f.TypeUrl), Data: f.Value})
+		}
+	}
+	sort.Slice(own, func(i, j int) bool { return own[i].Name < own[j].Name })
+	return append(result, own...)
+}
@@ -0,0 +1,63 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/any"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+func TestCRDs(t *testing.T) {
+	sub := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "sub"},
+		Files: []*any.Any{
+			{TypeUrl: "crds/crontab.yaml", Value: []byte("kind: CustomResourceDefinition")},
+		},
+	}
+	c := &chart.Chart{
+		Metadata:     &chart.Metadata{Name: "parent"},
+		Dependencies: []*chart.Chart{sub},
+		Files: []*any.Any{
+			{TypeUrl: "crds/z.json", Value: []byte("{}")},
+			{TypeUrl: "crds/a.yml", Value: []byte("a")},
+			{TypeUrl: "crds/README.md", Value: []byte("not a CRD")},
+			{TypeUrl: "files/crds/b.yaml", Value: []byte("not a CRD")},
+		},
+	}
+
+	crds := CRDs(c)
+	expect := []CRD{
+		{Name: "parent/charts/sub/crds/crontab.yaml", Data: []byte("kind: CustomResourceDefinition")},
+		{Name: "parent/crds/a.yml", Data: []byte("a")},
+		{Name: "parent/crds/z.json", Data: []byte("{}")},
+	}
+	if len(crds) != len(expect) {
+		t.Fatalf("expected %d CRDs, got %d: %v", len(expect), len(crds), crds)
+	}
+	for i, crd := range crds {
+		if crd.Name != expect[i].Name || string(crd.Data) != string(expect[i].Data) {
+			t.Errorf("expected CRD %d to be %s, got %s", i, expect[i].Name, crd.Name)
+		}
+	}
+
+	if crds := CRDs(sub); len(crds) != 1 || crds[0].Name != "sub/crds/crontab.yaml" {
+		t.Errorf("expected the CRD of the subchart, got %v", crds)
+	}
+}
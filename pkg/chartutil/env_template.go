@@ -0,0 +1,140 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/Masterminds/sprig"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+// TemplateEnvDirective is the comment that marks an environment values file
+// as a template. A templated environment values file is rendered with the
+// release, the chart metadata and the values of the chart before it is merged
+// with them:
+//
+//	# helm:template
+//	image:
+//	  tag: "{{ .Chart.AppVersion }}-{{ .Release.Namespace }}"
+const TemplateEnvDirective = "# helm:template"
+
+// IsTemplatedEnvironment returns whether the environment values file data is a
+// template.
+func IsTemplatedEnvironment(data []byte) bool {
+	return hasDirective(data, TemplateEnvDirective)
+}
+
+// hasDirective returns whether a line of the values file data is the comment
+// directive.
+func hasDirective(data []byte, directive string) bool {
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for s.Scan() {
+		if strings.TrimSpace(s.Text()) == directive {
+			return true
+		}
+	}
+	return false
+}
+
+// RenderEnvironments returns the chart c with the templated environment
+// values file of options, in the chart and in its subcharts, rendered and
+// merged with the values of the chart. The chart c is not modified.
+//
+// A templated environment values file is kept in the files of the chart by
+// LoadWithEnvValuesFile, as it can only be rendered once the release is known.
+func RenderEnvironments(c *chart.Chart, options ReleaseOptions) (*chart.Chart, error) {
+	if options.Environment == "" {
+		return c, nil
+	}
+	rc := *c
+	rc.Dependencies = make([]*chart.Chart, len(c.Dependencies))
+	for i, d := range c.Dependencies {
+		rd, err := RenderEnvironments(d, options)
+		if err != nil {
+			return nil, err
+		}
+		rc.Dependencies[i] = rd
+	}
+
+	for _, f := range c.Files {
+		if f.TypeUrl != options.Environment || !IsTemplatedEnvironment(f.Value) {
+			continue
+		}
+		vals, err := ReadValues([]byte(c.Values.GetRaw()))
+		if err != nil {
+			return nil, err
+		}
+		env, err := RenderEnvironment(f.TypeUrl, f.Value, c.Metadata, vals, options)
+		if err != nil {
+			return nil, fmt.Errorf("chart %s: environment values file %s: %s", c.Metadata.Name, f.TypeUrl, err)
+		}
+		MergeValues(vals, env)
+		raw, err := vals.YAML()
+		if err != nil {
+			return nil, err
+		}
+		rc.Values = &chart.Config{Raw: strings.TrimSpace(raw)}
+	}
+	return &rc, nil
+}
+
+// RenderEnvironment renders the templated environment values file name, of
+// content data, with the release options, the metadata of the chart and its
+// values vals, and parses the values it renders.
+func RenderEnvironment(name string, data []byte, metadata *chart.Metadata, vals Values, options ReleaseOptions) (Values, error) {
+	t, err := template.New(name).Funcs(envFuncMap()).Option("missingkey=zero").Parse(string(data))
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	err = t.Execute(&buf, map[string]interface{}{
+		"Release": releaseValues(options),
+		"Chart":   metadata,
+		"Values":  vals,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ReadValues(buf.Bytes())
+}
+
+// envFuncMap returns the functions of the templated environment values files:
+// the functions of sprig, without the access to the environment variables of
+// the renderer, and toYaml and required.
+func envFuncMap() template.FuncMap {
+	f := sprig.TxtFuncMap()
+	delete(f, "env")
+	delete(f, "expandenv")
+	f["toYaml"] = ToYaml
+	f["required"] = func(warn string, val interface{}) (interface{}, error) {
+		if val == nil {
+			return val, errors.New(warn)
+		}
+		if s, ok := val.(string); ok && s == "" {
+			return val, errors.New(warn)
+		}
+		return val, nil
+	}
+	return f
+}
@@ -0,0 +1,84 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/any"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+func TestIsTemplatedEnvironment(t *testing.T) {
+	if !IsTemplatedEnvironment([]byte("# helm:template\nreplicas: {{ .Values.replicas }}\n")) {
+		t.Error("Expected the environment to be a template")
+	}
+	if IsTemplatedEnvironment([]byte("# helm:templates are not supported\nreplicas: 3\n")) {
+		t.Error("Expected the environment not to be a template")
+	}
+}
+
+func TestRenderEnvironments(t *testing.T) {
+	sub := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "backend", Version: "0.2.0"},
+		Values:   &chart.Config{Raw: "port: 8080"},
+		Files: []*any.Any{
+			{TypeUrl: "values-prod.yaml", Value: []byte("# helm:template\nhost: \"{{ .Release.Name }}-{{ .Chart.Name }}:{{ .Values.port }}\"\n")},
+		},
+	}
+	c := &chart.Chart{
+		Metadata:     &chart.Metadata{Name: "web", Version: "1.2.3"},
+		Values:       &chart.Config{Raw: "replicas: 1\nimage:\n  repository: nginx"},
+		Dependencies: []*chart.Chart{sub},
+		Files: []*any.Any{
+			{TypeUrl: "values-prod.yaml", Value: []byte("# helm:template\nreplicas: 3\nimage:\n  repository: \"docker.io/{{ .Values.image.repository }}\"\n  tag: \"{{ .Chart.Version }}-{{ .Release.Revision }}\"\n")},
+			{TypeUrl: "values-staging.yaml", Value: []byte("# helm:template\nreplicas: {{ fail \"not rendered\" }}\n")},
+		},
+	}
+
+	rc, err := RenderEnvironments(c, ReleaseOptions{Name: "prod", Revision: 4, Environment: "values-prod.yaml"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expect := "image:\n  repository: docker.io/nginx\n  tag: 1.2.3-4\nreplicas: 3"; rc.Values.Raw != expect {
+		t.Errorf("Expected the values\n%s\ngot\n%s", expect, rc.Values.Raw)
+	}
+	if expect := "host: prod-backend:8080\nport: 8080"; rc.Dependencies[0].Values.Raw != expect {
+		t.Errorf("Expected the values of the subchart\n%s\ngot\n%s", expect, rc.Dependencies[0].Values.Raw)
+	}
+	if c.Values.Raw != "replicas: 1\nimage:\n  repository: nginx" || sub.Values.Raw != "port: 8080" {
+		t.Error("Expected the chart not to be modified")
+	}
+
+	if rc, _ := RenderEnvironments(c, ReleaseOptions{Name: "prod"}); rc != c {
+		t.Error("Expected the chart to be returned as is without an environment")
+	}
+
+	_, err = RenderEnvironments(c, ReleaseOptions{Name: "staging", Environment: "values-staging.yaml"})
+	if err == nil || !strings.Contains(err.Error(), "chart web: environment values file values-staging.yaml:") || !strings.Contains(err.Error(), "not rendered") {
+		t.Errorf("Expected a render error, got %v", err)
+	}
+}
+
+func TestRenderEnvironmentWithoutEnvFunc(t *testing.T) {
+	_, err := RenderEnvironment("values-prod.yaml", []byte("# helm:template\nhome: {{ env \"HOME\" }}\n"), &chart.Metadata{Name: "web"}, Values{}, ReleaseOptions{})
+	if err == nil || !strings.Contains(err.Error(), `function "env" not defined`) {
+		t.Errorf("Expected env to be undefined, got %v", err)
+	}
+}
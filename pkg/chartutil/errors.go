@@ -0,0 +1,85 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// ErrMissingChartYAML indicates that a chart has no Chart.yaml.
+var ErrMissingChartYAML = errors.New("chart metadata (Chart.yaml) missing")
+
+// ErrIllegalPath indicates that a chart archive contains a file whose path is
+// illegal: an absolute path, a path outside the base directory of the chart,
+// or a path that is not a valid file name.
+type ErrIllegalPath struct {
+	// Path is the path of the file in the archive.
+	Path string
+	// Reason is the message of the error.
+	Reason string
+}
+
+func (e *ErrIllegalPath) Error() string {
+	return e.Reason
+}
+
+// ErrInvalidAPIVersion indicates that the apiVersion of a Chart.yaml is
+// neither v1 nor v2.
+type ErrInvalidAPIVersion struct {
+	// APIVersion is the invalid apiVersion.
+	APIVersion string
+}
+
+func (e *ErrInvalidAPIVersion) Error() string {
+	return fmt.Sprintf("apiVersion '%s' is not valid. The value must be %q or %q", e.APIVersion, ApiVersionV1, ApiVersionV2)
+}
+
+// ErrValuesUnparsable indicates that values are not valid YAML. Its message
+// is the message of the YAML parser.
+type ErrValuesUnparsable struct {
+	// File is the values file, if the values were read from a file.
+	File string
+	// Line is the line of the error, or 0 if the parser doesn't report it.
+	Line int
+	// Err is the error of the YAML parser.
+	Err error
+}
+
+func (e *ErrValuesUnparsable) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ErrValuesUnparsable) Unwrap() error {
+	return e.Err
+}
+
+// yamlLineRegex matches the line of a YAML parser error, like
+// "yaml: line 3: did not find expected key".
+var yamlLineRegex = regexp.MustCompile(`line (\d+):`)
+
+// valuesUnparsable returns the ErrValuesUnparsable of the YAML parser error
+// err, reading the values of the file file.
+func valuesUnparsable(file string, err error) *ErrValuesUnparsable {
+	e := &ErrValuesUnparsable{File: file, Err: err}
+	if m := yamlLineRegex.FindStringSubmatch(err.Error()); m != nil {
+		e.Line, _ = strconv.Atoi(m[1])
+	}
+	return e
+}
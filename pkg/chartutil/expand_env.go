@@ -0,0 +1,177 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+// ExpandEnvDirective is the comment that opts a values file into the
+// expansion of environment variables. It is followed by the comma-separated
+// patterns of the variables the file may reference:
+//
+//	# helm:expand-env GIT_SHA, CI_*
+const ExpandEnvDirective = "# helm:expand-env"
+
+// envRefRegex matches ${NAME} and ${NAME:-default}, and their escaped form
+// $${NAME}.
+var envRefRegex = regexp.MustCompile(`\$?\$\{([A-Za-z_][A-Za-z0-9_]*)(:-[^}]*)?\}`)
+
+// ExpandEnvAllowlist returns the patterns of the variables allowed by the
+// expand-env directives of the values file data, or nil if it has none.
+func ExpandEnvAllowlist(data []byte) []string {
+	var allow []string
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if !strings.HasPrefix(line, ExpandEnvDirective) {
+			continue
+		}
+		for _, p := range strings.Split(strings.TrimPrefix(line, ExpandEnvDirective), ",") {
+			if p = strings.TrimSpace(p); p != "" {
+				allow = append(allow, p)
+			}
+		}
+	}
+	return allow
+}
+
+// ExpandEnv substitutes the references to environment variables in the
+// string values of vals, in place.
+//
+// A reference is either ${NAME}, or ${NAME:-default} to fall back to a default
+// when the variable is unset. $${NAME} is kept as the literal ${NAME}. Only
+// the variables matching one of the patterns of allow, in the syntax of
+// path.Match, may be referenced, so that values don't leak the environment by
+// accident. Nothing is substituted when allow is empty.
+//
+// The values are expanded after they are parsed, so a variable can't inject
+// YAML into the values.
+func ExpandEnv(vals Values, allow []string) error {
+	if len(allow) == 0 {
+		return nil
+	}
+	_, err := expandEnvValue(map[string]interface{}(vals), "", allow)
+	return err
+}
+
+// ExpandChartEnv substitutes the references to the environment variables of
+// allow in the values of the chart c and of its subcharts.
+func ExpandChartEnv(c *chart.Chart, allow []string) error {
+	if len(allow) == 0 {
+		return nil
+	}
+	if c.Values != nil && c.Values.Raw != "" {
+		vals, err := ReadValues([]byte(c.Values.Raw))
+		if err != nil {
+			return err
+		}
+		if err := ExpandEnv(vals, allow); err != nil {
+			return fmt.Errorf("chart %s: %s", c.Metadata.Name, err)
+		}
+		raw, err := vals.YAML()
+		if err != nil {
+			return err
+		}
+		c.Values.Raw = strings.TrimSpace(raw)
+	}
+	for _, d := range c.Dependencies {
+		if err := ExpandChartEnv(d, allow); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func expandEnvValue(v interface{}, key string, allow []string) (interface{}, error) {
+	switch v := v.(type) {
+	case string:
+		s, err := expandEnvString(v, allow)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", key, err)
+		}
+		return s, nil
+	case map[string]interface{}:
+		for k, val := range v {
+			child := k
+			if key != "" {
+				child = key + "." + k
+			}
+			expanded, err := expandEnvValue(val, child, allow)
+			if err != nil {
+				return nil, err
+			}
+			v[k] = expanded
+		}
+	case Values:
+		return expandEnvValue(map[string]interface{}(v), key, allow)
+	case []interface{}:
+		for i, val := range v {
+			expanded, err := expandEnvValue(val, fmt.Sprintf("%s[%d]", key, i), allow)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = expanded
+		}
+	}
+	return v, nil
+}
+
+func expandEnvString(s string, allow []string) (string, error) {
+	var err error
+	expanded := envRefRegex.ReplaceAllStringFunc(s, func(ref string) string {
+		if strings.HasPrefix(ref, "$$") {
+			return ref[1:]
+		}
+		m := envRefRegex.FindStringSubmatch(ref)
+		name := m[1]
+		if !envAllowed(name, allow) {
+			if err == nil {
+				err = fmt.Errorf("the environment variable %s is not allowed to be expanded", name)
+			}
+			return ref
+		}
+		if val, ok := os.LookupEnv(name); ok {
+			return val
+		}
+		if m[2] != "" {
+			return strings.TrimPrefix(m[2], ":-")
+		}
+		if err == nil {
+			err = fmt.Errorf("the environment variable %s is not set", name)
+		}
+		return ref
+	})
+	return expanded, err
+}
+
+func envAllowed(name string, allow []string) bool {
+	for _, p := range allow {
+		if ok, _ := path.Match(p, name); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExpandEnvAllowlist(t *testing.T) {
+	data := []byte("# helm:expand-env GIT_SHA, CI_*\nimage:\n  tag: ${GIT_SHA}\n  # helm:expand-env BUILD_ID\n")
+	expect := []string{"GIT_SHA", "CI_*", "BUILD_ID"}
+	if allow := ExpandEnvAllowlist(data); !reflect.DeepEqual(allow, expect) {
+		t.Errorf("Expected %v, got %v", expect, allow)
+	}
+	if allow := ExpandEnvAllowlist([]byte("image:\n  tag: ${GIT_SHA}\n")); allow != nil {
+		t.Errorf("Expected no allowlist, got %v", allow)
+	}
+}
+
+func TestExpandEnv(t *testing.T) {
+	os.Setenv("HELM_TEST_SHA", "abc123")
+	os.Setenv("HELM_TEST_SECRET", "hunter2")
+	defer os.Unsetenv("HELM_TEST_SHA")
+	defer os.Unsetenv("HELM_TEST_SECRET")
+
+	vals, err := ReadValues([]byte(`
+image:
+  tag: "${HELM_TEST_SHA}"
+  pullPolicy: "${HELM_TEST_POLICY:-IfNotPresent}"
+annotations:
+- "build ${HELM_TEST_SHA}"
+- "$${HELM_TEST_SHA}"
+replicas: 3
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ExpandEnv(vals, []string{"HELM_TEST_S*A", "HELM_TEST_POLICY"}); err != nil {
+		t.Fatal(err)
+	}
+	expect := "annotations:\n- build abc123\n- ${HELM_TEST_SHA}\nimage:\n  pullPolicy: IfNotPresent\n  tag: abc123\nreplicas: 3\n"
+	if y, _ := vals.YAML(); y != expect {
+		t.Errorf("Expected\n%s\ngot\n%s", expect, y)
+	}
+
+	for _, tt := range []struct {
+		value  string
+		expect string
+	}{
+		{"password: ${HELM_TEST_SECRET}", "password: the environment variable HELM_TEST_SECRET is not allowed to be expanded"},
+		{"db:\n  host: ${HELM_TEST_SHA_UNSET}", "db.host: the environment variable HELM_TEST_SHA_UNSET is not set"},
+	} {
+		vals, err := ReadValues([]byte(tt.value))
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = ExpandEnv(vals, []string{"HELM_TEST_SHA*"})
+		if err == nil || !strings.Contains(err.Error(), tt.expect) {
+			t.Errorf("Expected the error %q, got %v", tt.expect, err)
+		}
+	}
+}
+
+func TestExpandEnvWithoutAllowlist(t *testing.T) {
+	vals := Values{"tag": "${HELM_TEST_SHA}"}
+	if err := ExpandEnv(vals, nil); err != nil {
+		t.Fatal(err)
+	}
+	if vals["tag"] != "${HELM_TEST_SHA}" {
+		t.Errorf("Expected the values to be left alone, got %v", vals)
+	}
+}
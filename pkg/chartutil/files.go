@@ -19,8 +19,12 @@ import (
 	"bytes"
 	"encoding/base64"
 	"encoding/json"
+	"fmt"
 	"path"
+	"sort"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/ghodss/yaml"
 
@@ -163,6 +167,127 @@ func (f Files) Lines(path string) []string {
 	return strings.Split(string(f[path]), "\n")
 }
 
+// GetBytesLimited gets a file by path, like GetBytes, but fails if the file is
+// larger than limit bytes, so that a chart cannot embed an unexpectedly large
+// file in a manifest.
+//
+// This is designed to be called from a template.
+//
+//	{{ .Files.GetBytesLimited "dashboards/main.json" 262144 | toString }}
+func (f Files) GetBytesLimited(name string, limit int) ([]byte, error) {
+	v := f.GetBytes(name)
+	if len(v) > limit {
+		return nil, fmt.Errorf("file %s is %d bytes, more than the limit of %d bytes", name, len(v), limit)
+	}
+	return v, nil
+}
+
+// GlobLines returns each line of the files matching a glob pattern, in the
+// order of the names of the files, so that they can be ranged over in your
+// templates without concatenating the files.
+//
+// This is designed to be called from a template.
+//
+//	{{ range .Files.GlobLines "allowlists/*.txt" }}
+//	- {{ . }}{{ end }}
+func (f Files) GlobLines(pattern string) []string {
+	g := f.Glob(pattern)
+	names := make([]string, 0, len(g))
+	for name := range g {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	lines := []string{}
+	for _, name := range names {
+		lines = append(lines, g.Lines(name)...)
+	}
+	return lines
+}
+
+// AsChunkedConfig is AsConfig, with the files larger than size bytes split
+// across several keys of at most size bytes, named after the file with a
+// sequence number, e.g. "dashboard.json.000" and "dashboard.json.001". The
+// keys of a file sort in the order of its chunks, and the files are split on
+// UTF-8 character boundaries.
+//
+// This is designed to be called from a template.
+//
+//	data:
+//	{{ (.Files.Glob "dashboards/*").AsChunkedConfig 500000 | indent 4 }}
+func (f Files) AsChunkedConfig(size int) string {
+	if f == nil {
+		return ""
+	}
+
+	m := map[string]string{}
+	for k, v := range f {
+		for name, chunk := range chunkFile(path.Base(k), v, size, true) {
+			m[name] = string(chunk)
+		}
+	}
+	return ToYaml(m)
+}
+
+// AsChunkedSecrets is AsSecrets, with the files larger than size bytes split
+// across several keys like AsChunkedConfig. Every chunk is encoded in base64
+// on its own, so that the file is the concatenation of the decoded chunks.
+//
+// This is designed to be called from a template.
+//
+//	data:
+//	{{ (.Files.Glob "certs/*").AsChunkedSecrets 500000 | indent 4 }}
+func (f Files) AsChunkedSecrets(size int) string {
+	if f == nil {
+		return ""
+	}
+
+	m := map[string]string{}
+	for k, v := range f {
+		for name, chunk := range chunkFile(path.Base(k), v, size, false) {
+			m[name] = base64.StdEncoding.EncodeToString(chunk)
+		}
+	}
+	return ToYaml(m)
+}
+
+// chunkFile splits the file name of content data into chunks of at most size
+// bytes, keyed by name and their sequence number. A file which is not larger
+// than size is a single chunk keyed by name. If text is true, the chunks are
+// split on UTF-8 character boundaries.
+func chunkFile(name string, data []byte, size int, text bool) map[string][]byte {
+	if size <= 0 || len(data) <= size {
+		return map[string][]byte{name: data}
+	}
+
+	var chunks [][]byte
+	for len(data) > 0 {
+		end := size
+		if end >= len(data) {
+			end = len(data)
+		} else if text {
+			for end > 0 && !utf8.RuneStart(data[end]) {
+				end--
+			}
+			if end == 0 {
+				end = size
+			}
+		}
+		chunks = append(chunks, data[:end])
+		data = data[end:]
+	}
+
+	width := len(strconv.Itoa(len(chunks) - 1))
+	if width < 3 {
+		width = 3
+	}
+	m := make(map[string][]byte, len(chunks))
+	for i, c := range chunks {
+		m[fmt.Sprintf("%s.%0*d", name, width, i)] = c
+	}
+	return m
+}
+
 // ToYaml takes an interface, marshals it to yaml, and returns a string. It will
 // always return a string, even on marshal error (empty string).
 //
@@ -176,6 +301,105 @@ func ToYaml(v interface{}) string {
 	return string(data)
 }
 
+// ToYamlPretty takes an interface, marshals it to yaml with the items of
+// sequences indented under their key, and returns a string. It will always
+// return a string, even on marshal error (empty string).
+//
+// This is designed to be called from a template.
+func ToYamlPretty(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		// Swallow errors inside of a template.
+		return ""
+	}
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	var doc interface{}
+	if err := d.Decode(&doc); err != nil {
+		return ""
+	}
+
+	b := bytes.NewBuffer(nil)
+	switch doc := doc.(type) {
+	case map[string]interface{}:
+		if len(doc) > 0 {
+			writePrettyMap(b, doc, 0, true)
+			return b.String()
+		}
+	case []interface{}:
+		if len(doc) > 0 {
+			writePrettyList(b, doc, 0, true)
+			return b.String()
+		}
+	}
+	return prettyScalar(doc, 0) + "\n"
+}
+
+func writePrettyMap(b *bytes.Buffer, m map[string]interface{}, indent int, first bool) {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for i, k := range keys {
+		if i > 0 || !first {
+			b.WriteString(strings.Repeat(" ", indent))
+		}
+		b.WriteString(prettyScalar(k, indent) + ":")
+		switch v := m[k].(type) {
+		case map[string]interface{}:
+			if len(v) > 0 {
+				b.WriteString("\n")
+				writePrettyMap(b, v, indent+2, false)
+				continue
+			}
+		case []interface{}:
+			if len(v) > 0 {
+				b.WriteString("\n")
+				writePrettyList(b, v, indent+2, false)
+				continue
+			}
+		}
+		b.WriteString(" " + prettyScalar(m[k], indent) + "\n")
+	}
+}
+
+func writePrettyList(b *bytes.Buffer, l []interface{}, indent int, first bool) {
+	for i, item := range l {
+		if i > 0 || !first {
+			b.WriteString(strings.Repeat(" ", indent))
+		}
+		b.WriteString("- ")
+		switch v := item.(type) {
+		case map[string]interface{}:
+			if len(v) > 0 {
+				writePrettyMap(b, v, indent+2, true)
+				continue
+			}
+		case []interface{}:
+			if len(v) > 0 {
+				writePrettyList(b, v, indent+2, true)
+				continue
+			}
+		}
+		b.WriteString(prettyScalar(item, indent) + "\n")
+	}
+}
+
+// prettyScalar returns the yaml of a scalar, or an empty map or list, owned
+// by a key or list item at indent.
+func prettyScalar(v interface{}, indent int) string {
+	if n, ok := v.(json.Number); ok {
+		return n.String()
+	}
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	// Block scalars and folded strings continue on the following lines.
+	return strings.Replace(strings.TrimSuffix(string(data), "\n"), "\n", "\n"+strings.Repeat(" ", indent), -1)
+}
+
 // FromYaml converts a YAML document into a map[string]interface{}.
 //
 // This is not a general-purpose YAML parser, and will not parse all valid
@@ -191,6 +415,21 @@ func FromYaml(str string) map[string]interface{} {
 	return m
 }
 
+// FromYamlArray converts a YAML array into a []interface{}.
+//
+// This is not a general-purpose YAML parser, and will not parse all valid
+// YAML documents. Additionally, because its intended use is within templates
+// it tolerates errors. It will insert the returned error message string as
+// the first and only item in the returned array.
+func FromYamlArray(str string) []interface{} {
+	a := []interface{}{}
+
+	if err := yaml.Unmarshal([]byte(str), &a); err != nil {
+		a = []interface{}{err.Error()}
+	}
+	return a
+}
+
 // ToToml takes an interface, marshals it to toml, and returns a string. It will
 // always return a string, even on marshal error (empty string).
 //
@@ -219,6 +458,18 @@ func ToJson(v interface{}) string { // nolint
 	return string(data)
 }
 
+// MustToJson takes an interface, marshals it to json, and returns a string.
+// Unlike ToJson, it returns the marshal error, which fails the rendering.
+//
+// This is designed to be called from a template.
+func MustToJson(v interface{}) (string, error) { // nolint
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
 // FromJson converts a JSON document into a map[string]interface{}.
 //
 // This is not a general-purpose JSON parser, and will not parse all valid
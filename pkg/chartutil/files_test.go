@@ -16,6 +16,7 @@ limitations under the License.
 package chartutil
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/golang/protobuf/ptypes/any"
@@ -98,6 +99,48 @@ func TestLines(t *testing.T) {
 	as.Equal("bar", out[0])
 }
 
+func TestGetBytesLimited(t *testing.T) {
+	as := assert.New(t)
+
+	f := NewFiles(getTestFiles())
+
+	b, err := f.GetBytesLimited("ship/captain.txt", 11)
+	as.NoError(err)
+	as.Equal("The Captain", string(b))
+
+	_, err = f.GetBytesLimited("ship/captain.txt", 5)
+	as.EqualError(err, "file ship/captain.txt is 11 bytes, more than the limit of 5 bytes")
+}
+
+func TestGlobLines(t *testing.T) {
+	as := assert.New(t)
+
+	f := NewFiles(getTestFiles())
+
+	as.Equal([]string{"bar", "foo", "The Captain", "Legatt"}, f.GlobLines("{multiline,ship}/*"))
+	as.Empty(f.GlobLines("nothing/*"))
+}
+
+func TestToChunkedConfig(t *testing.T) {
+	as := assert.New(t)
+
+	f := NewFiles(getTestFiles())
+	out := f.Glob("ship/**").AsChunkedConfig(6)
+	as.Equal("captain.txt.000: The Ca\ncaptain.txt.001: ptain\nstowaway.txt: Legatt\n", out)
+
+	// Characters are not split across chunks.
+	out = Files{"dir/a.txt": []byte("h\u00e9llo")}.AsChunkedConfig(2)
+	as.Equal("a.txt.000: h\na.txt.001: \u00e9\na.txt.002: ll\na.txt.003: o\n", out)
+}
+
+func TestToChunkedSecrets(t *testing.T) {
+	as := assert.New(t)
+
+	f := NewFiles(getTestFiles())
+	out := f.Glob("ship/**").AsChunkedSecrets(6)
+	as.Equal("captain.txt.000: VGhlIENh\ncaptain.txt.001: cHRhaW4=\nstowaway.txt: TGVnYXR0\n", out)
+}
+
 func TestToYaml(t *testing.T) {
 	expect := "foo: bar\n"
 	v := struct {
@@ -111,6 +154,47 @@ func TestToYaml(t *testing.T) {
 	}
 }
 
+func TestToYamlPretty(t *testing.T) {
+	v := map[string]interface{}{
+		"name":  "web",
+		"empty": []interface{}{},
+		"ports": []interface{}{
+			map[string]interface{}{"port": 80, "name": "http"},
+			map[string]interface{}{"port": 443, "name": "https"},
+		},
+		"args":   []interface{}{"--verbose", []interface{}{"a", "b"}},
+		"script": "echo hello\necho world",
+	}
+	expect := `args:
+  - --verbose
+  - - a
+    - b
+empty: []
+name: web
+ports:
+  - name: http
+    port: 80
+  - name: https
+    port: 443
+script: |-
+  echo hello
+  echo world
+`
+	got := ToYamlPretty(v)
+	if got != expect {
+		t.Errorf("Expected %q, got %q", expect, got)
+	}
+
+	// The pretty output is the same document.
+	if want, doc := ToYaml(v), FromYaml(got); ToYaml(doc) != want {
+		t.Errorf("Expected %q, got %q", want, ToYaml(doc))
+	}
+
+	if got := ToYamlPretty("bar"); got != "bar\n" {
+		t.Errorf("Expected %q, got %q", "bar\n", got)
+	}
+}
+
 func TestToToml(t *testing.T) {
 	expect := "foo = \"bar\"\n"
 	v := struct {
@@ -167,6 +251,30 @@ one:
 	}
 }
 
+func TestFromYamlArray(t *testing.T) {
+	list := FromYamlArray("- one\n- two: 2\n")
+	if len(list) != 2 || list[0] != "one" {
+		t.Fatalf("Unexpected list %v", list)
+	}
+	if m, ok := list[1].(map[string]interface{}); !ok || m["two"] != float64(2) {
+		t.Errorf("Expected a map as second item, got %#v", list[1])
+	}
+
+	list = FromYamlArray("hello: world\n")
+	if len(list) != 1 || !strings.Contains(list[0].(string), "error") {
+		t.Errorf("Expected parser error, got %v", list)
+	}
+}
+
+func TestMustToJson(t *testing.T) {
+	if got, err := MustToJson(map[string]string{"foo": "bar"}); err != nil || got != `{"foo":"bar"}` {
+		t.Errorf("Expected %q, got %q (%v)", `{"foo":"bar"}`, got, err)
+	}
+	if _, err := MustToJson(map[string]interface{}{"fn": func() {}}); err == nil {
+		t.Error("Expected marshal error")
+	}
+}
+
 func TestToJson(t *testing.T) {
 	expect := `{"foo":"bar"}`
 	v := struct {
@@ -0,0 +1,231 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// flattenEscaped are the characters of keys escaped with a backslash in
+// flattened paths. They are the characters with a meaning in --set paths.
+const flattenEscaped = `\.[]=,`
+
+// Flatten returns the leaves of the values tree v by their path, e.g.
+// "image.tag" or "spec.ports[0].port". The paths use the syntax of --set:
+// the keys are separated by dots, the indexes of lists are in brackets, and
+// the characters \ . [ ] = and , of keys are escaped with a backslash, e.g.
+// `annotations.example\.com/owner`.
+//
+// Empty tables and lists are leaves, so that Unflatten restores them.
+func Flatten(v Values) map[string]interface{} {
+	flat := map[string]interface{}{}
+	flattenTable(flat, "", v)
+	return flat
+}
+
+func flattenTable(flat map[string]interface{}, prefix string, t Values) {
+	if len(t) == 0 && prefix != "" {
+		flat[prefix] = map[string]interface{}{}
+		return
+	}
+	for k, v := range t {
+		flattenValue(flat, JoinPath(prefix, k), v)
+	}
+}
+
+func flattenValue(flat map[string]interface{}, path string, v interface{}) {
+	if t, ok := asValues(v); ok {
+		flattenTable(flat, path, t)
+		return
+	}
+	list, ok := v.([]interface{})
+	if !ok {
+		flat[path] = v
+		return
+	}
+	if len(list) == 0 {
+		flat[path] = []interface{}{}
+		return
+	}
+	for i, item := range list {
+		flattenValue(flat, fmt.Sprintf("%s[%d]", path, i), item)
+	}
+}
+
+// JoinPath returns the flattened path of the key k of the table at path. The
+// path of the root table is empty.
+func JoinPath(path, k string) string {
+	if path == "" {
+		return escapeKey(k)
+	}
+	return path + "." + escapeKey(k)
+}
+
+// escapeKey escapes the key k for a flattened path.
+func escapeKey(k string) string {
+	if !strings.ContainsAny(k, flattenEscaped) {
+		return k
+	}
+	var b strings.Builder
+	for _, r := range k {
+		if strings.ContainsRune(flattenEscaped, r) {
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// Unflatten returns the values tree of the leaves flat, by their path as
+// returned by Flatten. The missing items of lists are nil.
+//
+// It returns an error if a path is invalid, or if two paths conflict, like
+// "image" and "image.tag".
+func Unflatten(flat map[string]interface{}) (Values, error) {
+	paths := make([]string, 0, len(flat))
+	for p := range flat {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	root := map[string]interface{}{}
+	for _, p := range paths {
+		elems, err := parseFlatPath(p)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := setPath(root, elems, flat[p]); err != nil {
+			return nil, fmt.Errorf("%s: %s", p, err)
+		}
+	}
+	return root, nil
+}
+
+// flatPathElem is an element of a flattened path: a key, or an index.
+type flatPathElem struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+func parseFlatPath(p string) ([]flatPathElem, error) {
+	var elems []flatPathElem
+	var key strings.Builder
+	// keyed is false right after an index, until the next separator.
+	keyed := true
+	endKey := func() error {
+		if keyed {
+			if key.Len() == 0 {
+				return fmt.Errorf("invalid path %q: empty key", p)
+			}
+			elems = append(elems, flatPathElem{key: key.String()})
+			key.Reset()
+		}
+		return nil
+	}
+
+	for i := 0; i < len(p); i++ {
+		switch c := p[i]; c {
+		case '\\':
+			if i++; i == len(p) {
+				return nil, fmt.Errorf("invalid path %q: trailing backslash", p)
+			}
+			key.WriteByte(p[i])
+		case '.':
+			if err := endKey(); err != nil {
+				return nil, err
+			}
+			keyed = true
+		case '[':
+			if err := endKey(); err != nil {
+				return nil, err
+			}
+			end := strings.IndexByte(p[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid path %q: unclosed '['", p)
+			}
+			n, err := strconv.Atoi(p[i+1 : i+end])
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("invalid path %q: invalid index %q", p, p[i+1:i+end])
+			}
+			elems = append(elems, flatPathElem{index: n, isIndex: true})
+			i += end
+			keyed = false
+			if i+1 < len(p) && p[i+1] != '.' && p[i+1] != '[' {
+				return nil, fmt.Errorf("invalid path %q: expected '.' or '[' after index", p)
+			}
+		default:
+			if !keyed {
+				return nil, fmt.Errorf("invalid path %q", p)
+			}
+			key.WriteByte(c)
+		}
+	}
+	if err := endKey(); err != nil {
+		return nil, err
+	}
+	return elems, nil
+}
+
+// setPath sets the value at path in tree, and returns the tree, as lists may
+// be reallocated.
+func setPath(tree interface{}, path []flatPathElem, v interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		if tree != nil {
+			return nil, errors.New("conflicting paths")
+		}
+		return v, nil
+	}
+
+	e := path[0]
+	if e.isIndex {
+		if tree == nil {
+			tree = []interface{}{}
+		}
+		list, ok := tree.([]interface{})
+		if !ok {
+			return nil, errors.New("conflicting paths: index of a value which is not a list")
+		}
+		for len(list) <= e.index {
+			list = append(list, nil)
+		}
+		item, err := setPath(list[e.index], path[1:], v)
+		if err != nil {
+			return nil, err
+		}
+		list[e.index] = item
+		return list, nil
+	}
+
+	if tree == nil {
+		tree = map[string]interface{}{}
+	}
+	table, ok := tree.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("conflicting paths: key of a value which is not a table")
+	}
+	item, err := setPath(table[e.key], path[1:], v)
+	if err != nil {
+		return nil, err
+	}
+	table[e.key] = item
+	return table, nil
+}
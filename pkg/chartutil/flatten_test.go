@@ -0,0 +1,144 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"k8s.io/helm/pkg/strvals"
+)
+
+const flattenValues = `
+name: moby
+replicas: 2
+image:
+  repository: nginx
+  tag: "1.0"
+ports:
+  - port: 80
+    protocol: TCP
+  - port: 443
+matrix:
+  - [1, 2]
+  - []
+annotations:
+  example.com/owner: ops
+  "a[b]=c,d\\e": x
+resources: {}
+`
+
+func TestFlatten(t *testing.T) {
+	v, err := ReadValues([]byte(flattenValues))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect := map[string]interface{}{
+		"name":                           "moby",
+		"replicas":                       json.Number("2"),
+		"image.repository":               "nginx",
+		"image.tag":                      "1.0",
+		"ports[0].port":                  json.Number("80"),
+		"ports[0].protocol":              "TCP",
+		"ports[1].port":                  json.Number("443"),
+		"matrix[0][0]":                   json.Number("1"),
+		"matrix[0][1]":                   json.Number("2"),
+		"matrix[1]":                      []interface{}{},
+		`annotations.example\.com/owner`: "ops",
+		`annotations.a\[b\]\=c\,d\\e`:    "x",
+		"resources":                      map[string]interface{}{},
+	}
+	flat := Flatten(v)
+	if !reflect.DeepEqual(flat, expect) {
+		t.Errorf("Expected\n%v\ngot\n%v", expect, flat)
+	}
+
+	back, err := Unflatten(flat)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(back, v) {
+		t.Errorf("Expected Unflatten to restore\n%v\ngot\n%v", v, back)
+	}
+}
+
+func TestFlattenSetCompatible(t *testing.T) {
+	v := Values{
+		"image": map[string]interface{}{"tag": "two"},
+		"annotations": map[string]interface{}{
+			"example.com/owner": "ops",
+			"a[b]=c,d":          "x",
+		},
+		"args": []interface{}{"one", "two"},
+	}
+
+	flat := Flatten(v)
+	var sets []string
+	for path, value := range flat {
+		sets = append(sets, fmt.Sprintf("%s=%v", path, value))
+	}
+	sort.Strings(sets)
+
+	parsed, err := strvals.Parse(strings.Join(sets, ","))
+	if err != nil {
+		t.Fatalf("Failed to parse %v: %s", sets, err)
+	}
+	if !reflect.DeepEqual(Values(parsed), v) {
+		t.Errorf("Expected --set %v to set\n%v\ngot\n%v", sets, v, parsed)
+	}
+}
+
+func TestUnflatten(t *testing.T) {
+	v, err := Unflatten(map[string]interface{}{
+		"list[2]":    "c",
+		"list[0].id": 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := Values{
+		"list": []interface{}{map[string]interface{}{"id": 1}, nil, "c"},
+	}
+	if !reflect.DeepEqual(v, expect) {
+		t.Errorf("Expected %v, got %v", expect, v)
+	}
+
+	for _, tt := range []struct {
+		flat   map[string]interface{}
+		expect string
+	}{
+		{map[string]interface{}{"": 1}, `invalid path "": empty key`},
+		{map[string]interface{}{"a..b": 1}, `invalid path "a..b": empty key`},
+		{map[string]interface{}{"a.": 1}, `invalid path "a.": empty key`},
+		{map[string]interface{}{"[0]": 1}, `invalid path "[0]": empty key`},
+		{map[string]interface{}{"a[x]": 1}, `invalid path "a[x]": invalid index "x"`},
+		{map[string]interface{}{"a[0": 1}, `invalid path "a[0": unclosed '['`},
+		{map[string]interface{}{"a[0]b": 1}, `invalid path "a[0]b": expected '.' or '[' after index`},
+		{map[string]interface{}{`a\`: 1}, `invalid path "a\\": trailing backslash`},
+		{map[string]interface{}{"a": 1, "a.b": 2}, "a.b: conflicting paths: key of a value which is not a table"},
+		{map[string]interface{}{"a.b": 1, "a[0]": 2}, "a[0]: conflicting paths: index of a value which is not a list"},
+	} {
+		if _, err := Unflatten(tt.flat); err == nil || err.Error() != tt.expect {
+			t.Errorf("Expected error %q for %v, got %v", tt.expect, tt.flat, err)
+		}
+	}
+}
@@ -20,11 +20,13 @@ import (
 	"archive/tar"
 	"bytes"
 	"compress/gzip"
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"os"
 	"path"
 	"path/filepath"
@@ -36,6 +38,7 @@ import (
 	"github.com/ghodss/yaml"
 	"github.com/golang/protobuf/ptypes/any"
 
+	"k8s.io/helm/pkg/getter"
 	"k8s.io/helm/pkg/ignore"
 	"k8s.io/helm/pkg/proto/hapi/chart"
 	"k8s.io/helm/pkg/sympath"
@@ -48,8 +51,16 @@ import (
 //
 // If a .helmignore file is present, the directory loader will skip loading any files
 // matching it. But .helmignore is not evaluated when reading out of an archive.
-func Load(name string) (*chart.Chart, error) {
-	return LoadWithEnvValuesFile(name, "")
+//
+// The options opts apply to the loading of a directory, see LoadDir.
+func Load(name string, opts ...LoadOption) (*chart.Chart, error) {
+	return LoadWithEnvValuesFile(name, "", opts...)
+}
+
+// LoadWithContext is Load, stopping with the error of ctx when ctx is done
+// before the chart is loaded.
+func LoadWithContext(ctx context.Context, name string, opts ...LoadOption) (*chart.Chart, error) {
+	return LoadWithEnvValuesFileWithContext(ctx, name, "", opts...)
 }
 
 // LoadWithEnvValuesFile takes a string name and a file name, tries to resolve it to a file or directory, and then loads it.
@@ -59,19 +70,28 @@ func Load(name string) (*chart.Chart, error) {
 //
 // If a .helmignore file is present, the directory loader will skip loading any files
 // matching it. But .helmignore is not evaluated when reading out of an archive.
-func LoadWithEnvValuesFile(name string, envValuesFile string) (*chart.Chart, error) {
+func LoadWithEnvValuesFile(name string, envValuesFile string, opts ...LoadOption) (*chart.Chart, error) {
+	return LoadWithEnvValuesFileWithContext(context.Background(), name, envValuesFile, opts...)
+}
+
+// LoadWithEnvValuesFileWithContext is LoadWithEnvValuesFile, stopping with the
+// error of ctx when ctx is done before the chart is loaded.
+func LoadWithEnvValuesFileWithContext(ctx context.Context, name string, envValuesFile string, opts ...LoadOption) (*chart.Chart, error) {
 	name = filepath.FromSlash(name)
 	fi, err := os.Stat(name)
 	if err != nil {
+		if u, perr := url.Parse(name); perr == nil && len(u.Scheme) > 1 && u.Host != "" {
+			return nil, fmt.Errorf("chart %q is a URL, it must be loaded with LoadURL", name)
+		}
 		return nil, err
 	}
 	if fi.IsDir() {
 		if validChart, err := IsChartDir(name); !validChart {
 			return nil, err
 		}
-		return LoadDirWithEnvValuesFiles(name, envValuesFile)
+		return loadDir(ctx, name, envValuesFile, map[string]bool{}, newLoadOptions(opts))
 	}
-	return LoadFileWithEnvValuesFile(name, envValuesFile)
+	return loadFile(ctx, name, envValuesFile)
 }
 
 // BufferedFile represents an archive file buffered for later processing.
@@ -82,6 +102,19 @@ type BufferedFile struct {
 
 var drivePathPattern = regexp.MustCompile(`^[a-zA-Z]:/`)
 
+// contextReader reads from r until ctx is done.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (r *contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.r.Read(p)
+}
+
 // loadArchiveFiles loads files out of an archive
 func loadArchiveFiles(in io.Reader) ([]*BufferedFile, error) {
 	unzipped, err := gzip.NewReader(in)
@@ -127,16 +160,16 @@ func loadArchiveFiles(in io.Reader) ([]*BufferedFile, error) {
 		n = strings.Replace(n, delimiter, "/", -1)
 
 		if path.IsAbs(n) {
-			return nil, errors.New("chart illegally contains absolute paths")
+			return nil, &ErrIllegalPath{Path: hd.Name, Reason: "chart illegally contains absolute paths"}
 		}
 
 		n = path.Clean(n)
 		if n == "." {
 			// In this case, the original path was relative when it should have been absolute.
-			return nil, fmt.Errorf("chart illegally contains content outside the base directory: %q", hd.Name)
+			return nil, &ErrIllegalPath{Path: hd.Name, Reason: fmt.Sprintf("chart illegally contains content outside the base directory: %q", hd.Name)}
 		}
 		if strings.HasPrefix(n, "..") {
-			return nil, errors.New("chart illegally references parent directory")
+			return nil, &ErrIllegalPath{Path: hd.Name, Reason: "chart illegally references parent directory"}
 		}
 
 		// In some particularly arcane acts of path creativity, it is possible to intermix
@@ -144,11 +177,11 @@ func loadArchiveFiles(in io.Reader) ([]*BufferedFile, error) {
 		// c:/foo even after all the built-in absolute path checks. So we explicitly check
 		// for this condition.
 		if drivePathPattern.MatchString(n) {
-			return nil, errors.New("chart contains illegally named files")
+			return nil, &ErrIllegalPath{Path: hd.Name, Reason: "chart contains illegally named files"}
 		}
 
 		if parts[0] == "Chart.yaml" {
-			return nil, errors.New("chart yaml not in base directory")
+			return nil, &ErrIllegalPath{Path: hd.Name, Reason: "chart yaml not in base directory"}
 		}
 
 		if _, err := io.Copy(b, tr); err != nil {
@@ -191,6 +224,7 @@ func LoadFilesWithEnvValues(files []*BufferedFile, envValuesFile string) (*chart
 	subcharts := map[string][]*BufferedFile{}
 	values := Values{}
 	environment := Values{}
+	var chartfile []byte
 
 	for _, f := range files {
 		if f.Name == "Chart.yaml" {
@@ -199,16 +233,25 @@ func LoadFilesWithEnvValues(files []*BufferedFile, envValuesFile string) (*chart
 				return c, err
 			}
 			c.Metadata = m
-			var apiVersion = c.Metadata.ApiVersion
-			if apiVersion != "" && apiVersion != ApiVersionV1 {
-				return c, fmt.Errorf("apiVersion '%s' is not valid. The value must be \"v1\"", apiVersion)
+			if err := validateChartfile(m); err != nil {
+				return c, err
 			}
+			chartfile = f.Data
 		} else if f.Name == "values.toml" {
 			return c, errors.New("values.toml is illegal as of 2.0.0-alpha.2")
 		} else if f.Name == "values.yaml" {
 			yaml.Unmarshal(f.Data, &values)
+			if err := ExpandEnv(values, ExpandEnvAllowlist(f.Data)); err != nil {
+				return c, fmt.Errorf("%s: %s", f.Name, err)
+			}
+		} else if f.Name == envValuesFile && IsTemplatedEnvironment(f.Data) {
+			// Rendered with the release, see RenderEnvironments
+			c.Files = append(c.Files, &any.Any{TypeUrl: f.Name, Value: f.Data})
 		} else if f.Name == envValuesFile {
 			yaml.Unmarshal(f.Data, &environment)
+			if err := ExpandEnv(environment, ExpandEnvAllowlist(f.Data)); err != nil {
+				return c, fmt.Errorf("%s: %s", f.Name, err)
+			}
 		} else if strings.HasPrefix(f.Name, "templates/") {
 			c.Templates = append(c.Templates, &chart.Template{Name: f.Name, Data: f.Data})
 		} else if strings.HasPrefix(f.Name, "charts/") {
@@ -240,11 +283,16 @@ func LoadFilesWithEnvValues(files []*BufferedFile, envValuesFile string) (*chart
 
 	// Ensure that we got a Chart.yaml file
 	if c.Metadata == nil {
-		return c, errors.New("chart metadata (Chart.yaml) missing")
+		return c, ErrMissingChartYAML
 	}
 	if c.Metadata.Name == "" {
 		return c, errors.New("invalid chart (Chart.yaml): name must not be empty")
 	}
+	if c.Metadata.ApiVersion == ApiVersionV2 {
+		if err := loadChartfileDependencies(c, chartfile); err != nil {
+			return c, err
+		}
+	}
 
 	for n, files := range subcharts {
 		var sc *chart.Chart
@@ -291,6 +339,11 @@ func LoadFile(name string) (*chart.Chart, error) {
 
 // LoadFileWithEnvValuesFile loads from an archive file.
 func LoadFileWithEnvValuesFile(name string, envValuesFile string) (*chart.Chart, error) {
+	return loadFile(context.Background(), name, envValuesFile)
+}
+
+// loadFile loads from an archive file until ctx is done.
+func loadFile(ctx context.Context, name string, envValuesFile string) (*chart.Chart, error) {
 	if fi, err := os.Stat(name); err != nil {
 		return nil, err
 	} else if fi.IsDir() {
@@ -308,7 +361,7 @@ func LoadFileWithEnvValuesFile(name string, envValuesFile string) (*chart.Chart,
 		return nil, err
 	}
 
-	c, err := LoadArchiveWithEnvValuesFile(raw, envValuesFile)
+	c, err := LoadArchiveWithEnvValuesFile(&contextReader{ctx: ctx, r: raw}, envValuesFile)
 	if err != nil {
 		if err == gzip.ErrHeader {
 			return nil, fmt.Errorf("file '%s' does not appear to be a valid chart file (details: %s)", name, err)
@@ -317,6 +370,54 @@ func LoadFileWithEnvValuesFile(name string, envValuesFile string) (*chart.Chart,
 	return c, err
 }
 
+// LoadURL downloads the chart archive at href with the getter of its scheme,
+// e.g. the downloader of a plugin for "s3" or "git+https", and loads it.
+func LoadURL(href string, getters getter.Providers) (*chart.Chart, error) {
+	return LoadURLWithContext(context.Background(), href, getters)
+}
+
+// LoadURLWithContext is LoadURL, stopping with the error of ctx when ctx is
+// done before the chart is downloaded and loaded. As the getters can't be
+// cancelled, the download goes on in the background.
+func LoadURLWithContext(ctx context.Context, href string, getters getter.Providers) (*chart.Chart, error) {
+	u, err := url.Parse(href)
+	if err != nil {
+		return nil, err
+	}
+	newGetter, err := getters.ByScheme(u.Scheme)
+	if err != nil {
+		return nil, err
+	}
+	g, err := newGetter(href, "", "", "")
+	if err != nil {
+		return nil, err
+	}
+	type download struct {
+		data *bytes.Buffer
+		err  error
+	}
+	done := make(chan download, 1)
+	go func() {
+		data, err := g.Get(href)
+		done <- download{data, err}
+	}()
+	var d download
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case d = <-done:
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+
+	c, err := LoadArchive(&contextReader{ctx: ctx, r: d.data})
+	if err == gzip.ErrHeader {
+		return nil, fmt.Errorf("%s does not appear to be a valid chart archive (details: %s)", href, err)
+	}
+	return c, err
+}
+
 // ensureArchive's job is to return an informative error if the file does not appear to be a gzipped archive.
 //
 // Sometimes users will provide a values.yaml for an argument where a chart is expected. One common occurrence
@@ -347,18 +448,44 @@ func ensureArchive(name string, raw *os.File) error {
 // LoadDir loads from a directory.
 //
 // This loads charts only from directories.
-func LoadDir(dir string) (*chart.Chart, error) {
-	return LoadDirWithEnvValuesFiles(dir, "")
+//
+// The symbolic links of the directory are handled as specified by the
+// Symlinks option. By default, a symbolic link which points outside of the
+// chart directory is an error. The files the followed symbolic links point to
+// are loaded under the path of the link. SymlinksFollow must only be used for
+// trusted chart directories.
+func LoadDir(dir string, opts ...LoadOption) (*chart.Chart, error) {
+	return LoadDirWithEnvValuesFiles(dir, "", opts...)
 }
 
 // LoadDirWithEnvValuesFiles loads from a directory.
 //
 // This loads charts only from directories.
-func LoadDirWithEnvValuesFiles(dir string, envValueFiles string) (*chart.Chart, error) {
+//
+// The dependencies of the requirements with a "file://" repository that are
+// not in the charts/ directory are loaded from their path, relative to the
+// directory of the chart.
+func LoadDirWithEnvValuesFiles(dir string, envValueFiles string, opts ...LoadOption) (*chart.Chart, error) {
+	return loadDir(context.Background(), dir, envValueFiles, map[string]bool{}, newLoadOptions(opts))
+}
+
+// loadDir loads a chart directory and its local dependencies until ctx is
+// done. loading holds the directories of the charts being loaded, to detect
+// cycles.
+func loadDir(ctx context.Context, dir string, envValueFiles string, loading map[string]bool, opts loadOptions) (*chart.Chart, error) {
 	topdir, err := filepath.Abs(dir)
 	if err != nil {
 		return nil, err
 	}
+	// The real path of the chart directory, to find the symbolic links which
+	// point outside of it.
+	realdir, err := filepath.EvalSymlinks(topdir)
+	if err != nil {
+		return nil, err
+	}
+	chartdir := topdir
+	loading[chartdir] = true
+	defer delete(loading, chartdir)
 
 	// Just used for errors.
 	c := &chart.Chart{}
@@ -378,6 +505,9 @@ func LoadDirWithEnvValuesFiles(dir string, envValueFiles string) (*chart.Chart,
 	topdir += string(filepath.Separator)
 
 	walk := func(name string, fi os.FileInfo, err error) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		n := strings.TrimPrefix(name, topdir)
 		if n == "" {
 			// No need to process top level. Avoid bug with helmignore .* matching
@@ -394,14 +524,25 @@ func LoadDirWithEnvValuesFiles(dir string, envValueFiles string) (*chart.Chart,
 		if fi.IsDir() {
 			// Directory-based ignore rules should involve skipping the entire
 			// contents of that directory.
-			if rules.Ignore(n, fi) {
+			if by := rules.IgnoredBy(n, fi); by != "" {
+				opts.reportIgnored(n+"/", ignoredByReason(by))
 				return filepath.SkipDir
 			}
+			// The .helmignore file of a subdirectory applies to its contents.
+			nested := filepath.Join(name, ignore.HelmIgnore)
+			if _, err := os.Stat(nested); err == nil {
+				r, err := ignore.ParseFile(nested)
+				if err != nil {
+					return fmt.Errorf("error reading %s/%s: %s", n, ignore.HelmIgnore, err)
+				}
+				rules.AddNested(n, r)
+			}
 			return nil
 		}
 
 		// If a .helmignore file matches, skip this file.
-		if rules.Ignore(n, fi) {
+		if by := rules.IgnoredBy(n, fi); by != "" {
+			opts.reportIgnored(n, ignoredByReason(by))
 			return nil
 		}
 
@@ -413,9 +554,90 @@ func LoadDirWithEnvValuesFiles(dir string, envValueFiles string) (*chart.Chart,
 		files = append(files, &BufferedFile{Name: n, Data: data})
 		return nil
 	}
-	if err = sympath.Walk(topdir, walk); err != nil {
+	symlink := func(name, resolved string, fi os.FileInfo) (bool, error) {
+		n := filepath.ToSlash(strings.TrimPrefix(name, topdir))
+		if fi.IsDir() {
+			n += "/"
+		}
+		if by := rules.IgnoredBy(n, fi); by != "" {
+			opts.reportIgnored(n, ignoredByReason(by))
+			return false, nil
+		}
+		switch opts.symlinks {
+		case SymlinksIgnore:
+			opts.reportIgnored(n, "symlink to "+resolved)
+			return false, nil
+		case SymlinksFollow:
+			return true, nil
+		}
+		if sympath.IsWithin(realdir, resolved) {
+			return true, nil
+		}
+		if opts.symlinks == SymlinksWithinChart {
+			opts.reportIgnored(n, "symlink to "+resolved+", outside of the chart directory")
+			return false, nil
+		}
+		return false, fmt.Errorf("the symlink %s points to %s, outside of the chart directory", n, resolved)
+	}
+	if err = sympath.WalkSymlinks(topdir, walk, symlink); err != nil {
+		return c, err
+	}
+
+	c, err = LoadFilesWithEnvValues(files, envValueFiles)
+	if err != nil {
 		return c, err
 	}
+	return c, loadLocalDependencies(ctx, c, chartdir, envValueFiles, loading, opts)
+}
+
+// ignoredByReason returns the reason why a file ignored by the .helmignore
+// pattern is not loaded.
+func ignoredByReason(pattern string) string {
+	return fmt.Sprintf("matches the %s pattern %q", ignore.HelmIgnore, pattern)
+}
+
+// loadLocalDependencies adds the dependencies of the requirements with a
+// "file://" repository that are not in the charts/ directory of the chart,
+// loaded from their path relative to the chart directory dir.
+func loadLocalDependencies(ctx context.Context, c *chart.Chart, dir, envValueFiles string, loading map[string]bool, opts loadOptions) error {
+	reqs, err := LoadRequirements(c)
+	if err != nil {
+		// Charts without requirements have no local dependencies.
+		return nil
+	}
 
-	return LoadFilesWithEnvValues(files, envValueFiles)
+	loaded := map[string]bool{}
+	for _, dep := range c.Dependencies {
+		loaded[dep.Metadata.Name] = true
+	}
+	for _, r := range reqs.Dependencies {
+		if !strings.HasPrefix(r.Repository, "file://") || loaded[r.Name] {
+			continue
+		}
+		depdir := filepath.FromSlash(strings.TrimPrefix(r.Repository, "file://"))
+		if !filepath.IsAbs(depdir) {
+			depdir = filepath.Join(dir, depdir)
+		}
+		if loading[depdir] {
+			return fmt.Errorf("the local dependency %s of %s depends on %s", r.Name, c.Metadata.Name, c.Metadata.Name)
+		}
+		depOpts := opts
+		if opts.ignored != nil {
+			// Report the files under the path of the dependency in the archive.
+			name := r.Name
+			depOpts.ignored = func(n, reason string) {
+				opts.ignored("charts/"+name+"/"+n, reason)
+			}
+		}
+		dep, err := loadDir(ctx, depdir, envValueFiles, loading, depOpts)
+		if err != nil {
+			return fmt.Errorf("cannot load the local dependency %s of %s from %s: %s", r.Name, c.Metadata.Name, r.Repository, err)
+		}
+		if dep.Metadata.Name != r.Name {
+			return fmt.Errorf("the local dependency %s of %s is the chart %s", r.Name, c.Metadata.Name, dep.Metadata.Name)
+		}
+		c.Dependencies = append(c.Dependencies, dep)
+		loaded[r.Name] = true
+	}
+	return nil
 }
@@ -0,0 +1,109 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import "fmt"
+
+// SymlinkPolicy is how LoadDir handles the symbolic links of a chart
+// directory.
+type SymlinkPolicy int
+
+const (
+	// SymlinksErrorOnExternal follows the symbolic links whose target is in
+	// the chart directory, and fails on the others. This is the default.
+	SymlinksErrorOnExternal SymlinkPolicy = iota
+	// SymlinksWithinChart follows the symbolic links whose target is in the
+	// chart directory, and skips the others.
+	SymlinksWithinChart
+	// SymlinksIgnore skips all the symbolic links.
+	SymlinksIgnore
+	// SymlinksFollow follows all the symbolic links, wherever they point.
+	// Only use it for trusted chart directories.
+	SymlinksFollow
+)
+
+// symlinkPolicies are the names of the symlink policies.
+var symlinkPolicies = []string{
+	SymlinksErrorOnExternal: "error-on-external",
+	SymlinksWithinChart:     "within-chart",
+	SymlinksIgnore:          "ignore",
+	SymlinksFollow:          "follow",
+}
+
+// ParseSymlinkPolicy returns the symlink policy named s: error-on-external,
+// within-chart, ignore or follow.
+func ParseSymlinkPolicy(s string) (SymlinkPolicy, error) {
+	for policy, name := range symlinkPolicies {
+		if s == name {
+			return SymlinkPolicy(policy), nil
+		}
+	}
+	return SymlinksErrorOnExternal, fmt.Errorf("unknown symlink policy %q, the policy must be error-on-external, within-chart, ignore or follow", s)
+}
+
+// String returns the name of the symlink policy.
+func (p SymlinkPolicy) String() string {
+	if p < 0 || int(p) >= len(symlinkPolicies) {
+		return fmt.Sprintf("SymlinkPolicy(%d)", int(p))
+	}
+	return symlinkPolicies[p]
+}
+
+// LoadOption allows specifying how LoadDir loads a chart directory.
+type LoadOption func(*loadOptions)
+
+// loadOptions specify how LoadDir loads a chart directory.
+type loadOptions struct {
+	// how the symbolic links are handled
+	symlinks SymlinkPolicy
+	// if set, called for each file which is not loaded
+	ignored func(name, reason string)
+}
+
+// Symlinks specifies how the symbolic links of a chart directory are handled.
+func Symlinks(policy SymlinkPolicy) LoadOption {
+	return func(opts *loadOptions) {
+		opts.symlinks = policy
+	}
+}
+
+// ReportIgnored specifies a function called with the path, relative to the
+// chart directory, of each file or directory which is not loaded, and the
+// reason why. The path of a directory ends with a slash; its contents are not
+// reported.
+func ReportIgnored(fn func(name, reason string)) LoadOption {
+	return func(opts *loadOptions) {
+		opts.ignored = fn
+	}
+}
+
+// reportIgnored reports that the file name is not loaded, if the
+// ReportIgnored option is set.
+func (o loadOptions) reportIgnored(name, reason string) {
+	if o.ignored != nil {
+		o.ignored(name, reason)
+	}
+}
+
+// newLoadOptions returns the loadOptions of opts.
+func newLoadOptions(opts []LoadOption) loadOptions {
+	var o loadOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
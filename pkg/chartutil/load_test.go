@@ -18,7 +18,10 @@ package chartutil
 
 import (
 	"archive/tar"
+	"bytes"
 	"compress/gzip"
+	"context"
+	"errors"
 	"io/ioutil"
 	"os"
 	"path"
@@ -29,6 +32,7 @@ import (
 	"time"
 
 	"github.com/ghodss/yaml"
+	"k8s.io/helm/pkg/getter"
 	"k8s.io/helm/pkg/proto/hapi/chart"
 )
 
@@ -42,6 +46,237 @@ func TestLoadDir(t *testing.T) {
 	verifyRequirements(t, c)
 }
 
+func TestLoadDirNestedHelmignore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "helm-helmignore-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"Chart.yaml":             "apiVersion: v1\nname: ignored\nversion: 0.1.0\n",
+		".helmignore":            "docs/*\n!docs/README.md\n*.bak\n",
+		"templates/service.yaml": "kind: Service",
+		"templates/service.bak":  "kind: Service",
+		"templates/.helmignore":  "!*.bak\nscratch.yaml\n",
+		"templates/scratch.yaml": "kind: ConfigMap",
+		"docs/README.md":         "# Docs",
+		"docs/design.md":         "# Design",
+		"files/data.bak":         "data",
+	}
+	for name, data := range files {
+		p := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(p, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var ignored []string
+	c, err := LoadDir(dir, ReportIgnored(func(name, reason string) {
+		ignored = append(ignored, name+": "+reason)
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectIgnored := []string{
+		`docs/design.md: matches the .helmignore pattern "docs/*"`,
+		`files/data.bak: matches the .helmignore pattern "*.bak"`,
+		`templates/.helmignore: matches the .helmignore pattern "templates/.?*"`,
+		`templates/scratch.yaml: matches the .helmignore pattern "scratch.yaml"`,
+	}
+	if !reflect.DeepEqual(ignored, expectIgnored) {
+		t.Errorf("Expected the ignored files %v, got %v", expectIgnored, ignored)
+	}
+	var templates []string
+	for _, tpl := range c.Templates {
+		templates = append(templates, tpl.Name)
+	}
+	if expect := []string{"templates/service.bak", "templates/service.yaml"}; !reflect.DeepEqual(templates, expect) {
+		t.Errorf("Expected the templates %v, got %v", expect, templates)
+	}
+	var others []string
+	for _, f := range c.Files {
+		others = append(others, f.TypeUrl)
+	}
+	if expect := []string{".helmignore", "docs/README.md"}; !reflect.DeepEqual(others, expect) {
+		t.Errorf("Expected the files %v, got %v", expect, others)
+	}
+}
+
+func TestLoadDirSymlinks(t *testing.T) {
+	dir, err := ioutil.TempDir("", "helm-symlinks-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	chartdir := filepath.Join(dir, "chart")
+	for name, data := range map[string]string{
+		"chart/Chart.yaml":             "apiVersion: v1\nname: symlinks\nversion: 0.1.0\n",
+		"chart/templates/service.yaml": "kind: Service",
+		"secret.yaml":                  "kind: Secret",
+	} {
+		p := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(p, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Symlink("service.yaml", filepath.Join(chartdir, "templates", "internal.yaml")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(dir, "secret.yaml"), filepath.Join(chartdir, "templates", "external.yaml")); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		name   string
+		opts   []LoadOption
+		expect []string
+		err    string
+	}{
+		{"default", nil, nil, "the symlink templates/external.yaml points to"},
+		{"error on external", []LoadOption{Symlinks(SymlinksErrorOnExternal)}, nil, "the symlink templates/external.yaml points to"},
+		{"within chart", []LoadOption{Symlinks(SymlinksWithinChart)}, []string{"templates/internal.yaml", "templates/service.yaml"}, ""},
+		{"ignore", []LoadOption{Symlinks(SymlinksIgnore)}, []string{"templates/service.yaml"}, ""},
+		{"follow", []LoadOption{Symlinks(SymlinksFollow)}, []string{"templates/external.yaml", "templates/internal.yaml", "templates/service.yaml"}, ""},
+	} {
+		c, err := LoadDir(chartdir, tt.opts...)
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("%s: expected the error %q, got %v", tt.name, tt.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %s", tt.name, err)
+			continue
+		}
+		var templates []string
+		for _, tpl := range c.Templates {
+			templates = append(templates, tpl.Name)
+		}
+		if !reflect.DeepEqual(templates, tt.expect) {
+			t.Errorf("%s: expected the templates %v, got %v", tt.name, tt.expect, templates)
+		}
+	}
+
+	// A symlink which is ignored by .helmignore is not an error.
+	if err := ioutil.WriteFile(filepath.Join(chartdir, ".helmignore"), []byte("templates/external.yaml\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadDir(chartdir); err != nil {
+		t.Errorf("Expected the ignored symlink to be skipped, got %s", err)
+	}
+}
+
+func TestLoadDirSymlinkedSubchart(t *testing.T) {
+	dir, err := ioutil.TempDir("", "helm-symlinks-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for name, data := range map[string]string{
+		"charts/app/Chart.yaml":             "apiVersion: v1\nname: app\nversion: 0.1.0\n",
+		"charts/app/templates/service.yaml": "kind: Service",
+		"common/Chart.yaml":                 "apiVersion: v1\nname: common\nversion: 0.1.0\n",
+		"common/templates/_helpers.tpl":     "{{- define \"common.name\" }}common{{ end }}",
+		"common/templates/configmap.yaml":   "kind: ConfigMap",
+	} {
+		p := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(p, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The layout of a subchart shared by the charts of a repository.
+	chartdir := filepath.Join(dir, "charts", "app")
+	if err := os.Mkdir(filepath.Join(chartdir, "charts"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join("..", "..", "common"), filepath.Join(chartdir, "charts", "common")); err != nil {
+		t.Fatal(err)
+	}
+
+	// It points outside of the chart, and is only loaded when following all
+	// the symlinks, as it was by default.
+	if _, err := Load(chartdir); err == nil || !strings.Contains(err.Error(), "the symlink charts/common/ points to") {
+		t.Errorf("Expected the symlinked subchart to be an error, got %v", err)
+	}
+	c, err := Load(chartdir, Symlinks(SymlinksFollow))
+	if err != nil {
+		t.Fatalf("Expected the symlinked subchart to be loaded, got %s", err)
+	}
+	if len(c.Dependencies) != 1 || c.Dependencies[0].Metadata.Name != "common" {
+		t.Fatalf("Expected the dependency common, got %v", c.Dependencies)
+	}
+	var templates []string
+	for _, tpl := range c.Dependencies[0].Templates {
+		templates = append(templates, tpl.Name)
+	}
+	if expect := []string{"templates/_helpers.tpl", "templates/configmap.yaml"}; !reflect.DeepEqual(templates, expect) {
+		t.Errorf("Expected the templates %v, got %v", expect, templates)
+	}
+	for _, f := range c.Files {
+		t.Errorf("Unexpected file %s", f.TypeUrl)
+	}
+
+	if _, err := Load(chartdir, Symlinks(SymlinksWithinChart)); err != nil {
+		t.Errorf("Expected the symlinked subchart to be skipped, got %s", err)
+	}
+
+	// A symlink to a directory which contains it would be walked forever.
+	if err := os.Symlink("..", filepath.Join(chartdir, "templates", "loop")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(chartdir, Symlinks(SymlinksFollow)); err == nil || !strings.Contains(err.Error(), "is a cycle") {
+		t.Errorf("Expected a cycle error, got %v", err)
+	}
+}
+
+func TestParseSymlinkPolicy(t *testing.T) {
+	for _, policy := range []SymlinkPolicy{SymlinksErrorOnExternal, SymlinksWithinChart, SymlinksIgnore, SymlinksFollow} {
+		parsed, err := ParseSymlinkPolicy(policy.String())
+		if err != nil {
+			t.Errorf("%s: %s", policy, err)
+		} else if parsed != policy {
+			t.Errorf("Expected %s, got %s", policy, parsed)
+		}
+	}
+	if _, err := ParseSymlinkPolicy("always"); err == nil {
+		t.Error("Expected an error for an unknown policy")
+	}
+}
+
+func TestLoadDirLocalDependencies(t *testing.T) {
+	c, err := Load("testdata/localdeps/parent")
+	if err != nil {
+		t.Fatalf("Failed to load testdata: %s", err)
+	}
+	if len(c.Dependencies) != 1 {
+		t.Fatalf("Expected 1 dependency, got %d", len(c.Dependencies))
+	}
+	dep := c.Dependencies[0]
+	if dep.Metadata.Name != "sibling" {
+		t.Errorf("Expected the sibling chart, got %s", dep.Metadata.Name)
+	}
+	if len(dep.Templates) != 1 || dep.Templates[0].Name != "templates/configmap.yaml" {
+		t.Errorf("Expected the templates of the sibling chart, got %v", dep.Templates)
+	}
+
+	if _, err := Load("testdata/localdeps/cycle"); err == nil || !strings.Contains(err.Error(), "depends on cycle") {
+		t.Errorf("Expected an error for a chart depending on itself, got %v", err)
+	}
+}
+
 func TestLoadDirWithEnvValuesFile(t *testing.T) {
 	expectedDev1 := Values{
 		"albatross": "true",
@@ -83,15 +318,92 @@ func TestLoadDirWithEnvValuesFile(t *testing.T) {
 
 }
 
-func TestLoadNonV1Chart(t *testing.T) {
-	_, err := Load("testdata/frobnitz.v2")
+func TestLoadWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, name := range []string{"testdata/frobnitz", "testdata/frobnitz-1.2.3.tgz"} {
+		if _, err := LoadWithContext(ctx, name); err != context.Canceled {
+			t.Errorf("Expected loading %s to be canceled, got %v", name, err)
+		}
+		if _, err := LoadWithContext(context.Background(), name); err != nil {
+			t.Errorf("Failed to load %s: %s", name, err)
+		}
+	}
+}
+
+func TestLoadV2Chart(t *testing.T) {
+	c, err := Load("testdata/frobnitz.v2")
+	if err != nil {
+		t.Fatalf("Failed to load testdata: %s", err)
+	}
+	if c.Metadata.ApiVersion != ApiVersionV2 {
+		t.Errorf("Expected apiVersion v2, got %q", c.Metadata.ApiVersion)
+	}
+
+	reqs, err := LoadRequirements(c)
+	if err != nil {
+		t.Fatalf("Failed to load the dependencies of Chart.yaml: %s", err)
+	}
+	if len(reqs.Dependencies) != 2 || reqs.Dependencies[0].Name != "alpine" || reqs.Dependencies[1].Name != "mariner" {
+		t.Errorf("Expected the dependencies alpine and mariner, got %v", reqs.Dependencies)
+	}
+
+	lock, err := LoadRequirementsLock(c)
+	if err != nil {
+		t.Fatalf("Failed to load Chart.lock: %s", err)
+	}
+	if lock.Digest != "invalid" {
+		t.Errorf("Expected the digest of Chart.lock, got %q", lock.Digest)
+	}
+}
+
+func TestLoadChartfileVersions(t *testing.T) {
+	load := func(chartfile string, files ...*BufferedFile) (*chart.Chart, error) {
+		files = append(files,
+			&BufferedFile{Name: "Chart.yaml", Data: []byte("name: frobnitz\nversion: 1.2.3\n" + chartfile)},
+			&BufferedFile{Name: "templates/service.yaml", Data: []byte("kind: Service")},
+		)
+		return LoadFilesWithEnvValues(files, "")
+	}
+
+	c, err := load("apiVersion: v2\ntype: library\n")
 	if err != nil {
-		if strings.Compare(err.Error(), "apiVersion 'v2' is not valid. The value must be \"v1\"") != 0 {
-			t.Errorf("Unexpected message: %s", err)
+		t.Fatal(err)
+	}
+	if !IsLibraryChart(c) {
+		t.Errorf("Expected a library chart")
+	}
+	if _, err := LoadRequirements(c); err != ErrRequirementsNotFound {
+		t.Errorf("Expected no dependencies, got %v", err)
+	}
+
+	c, err = load("apiVersion: v1\ntype: library\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Metadata.Type != "" {
+		t.Errorf("Expected the type of a v1 chart to be ignored, got %q", c.Metadata.Type)
+	}
+
+	for chartfile, expect := range map[string]string{
+		"apiVersion: v3\n":                    "apiVersion 'v3' is not valid. The value must be \"v1\" or \"v2\"",
+		"apiVersion: v2\ntype: plugin\n":      "type 'plugin' is not valid",
+		"apiVersion: v2\ndependencies: bad\n": "cannot load dependencies of Chart.yaml",
+	} {
+		if _, err := load(chartfile); err == nil || !strings.Contains(err.Error(), expect) {
+			t.Errorf("Expected an error containing %q loading %q, got %v", expect, chartfile, err)
 		}
-		return
 	}
-	t.Fatalf("chart with v2 apiVersion should not load")
+
+	var versionErr *ErrInvalidAPIVersion
+	if _, err := load("apiVersion: v3\n"); !errors.As(err, &versionErr) || versionErr.APIVersion != "v3" {
+		t.Errorf("Expected an ErrInvalidAPIVersion, got %#v", err)
+	}
+
+	requirements := &BufferedFile{Name: "requirements.yaml", Data: []byte("dependencies: []\n")}
+	if _, err := load("apiVersion: v2\n", requirements); err == nil || !strings.Contains(err.Error(), "requirements.yaml is not supported") {
+		t.Errorf("Expected requirements.yaml to be rejected in a v2 chart, got %v", err)
+	}
 }
 
 func TestLoadFile(t *testing.T) {
@@ -169,6 +481,14 @@ func TestLoadArchive_InvalidArchive(t *testing.T) {
 		if err.Error() != tt.expectError {
 			t.Errorf("Expected %q, got %q for %s", tt.expectError, err.Error(), tt.chartname)
 		}
+		var pathErr *ErrIllegalPath
+		if errors.As(err, &pathErr) {
+			if pathErr.Path != tt.internal {
+				t.Errorf("Expected the illegal path %q, got %q for %s", tt.internal, pathErr.Path, tt.chartname)
+			}
+		} else if !errors.Is(err, ErrMissingChartYAML) {
+			t.Errorf("Expected an ErrIllegalPath or ErrMissingChartYAML, got %T for %s", err, tt.chartname)
+		}
 	}
 
 	// Make sure that absolute path gets interpreted as relative
@@ -409,3 +729,34 @@ func verifyChartFileAndTemplate(t *testing.T, c *chart.Chart, name string) {
 		t.Error("No template data.")
 	}
 }
+
+type archiveGetter struct {
+	path string
+}
+
+func (g *archiveGetter) Get(href string) (*bytes.Buffer, error) {
+	data, err := ioutil.ReadFile(g.path)
+	return bytes.NewBuffer(data), err
+}
+
+func TestLoadURL(t *testing.T) {
+	getters := getter.Providers{{
+		Schemes: []string{"git+https"},
+		New: func(URL, CertFile, KeyFile, CAFile string) (getter.Getter, error) {
+			return &archiveGetter{path: "testdata/frobnitz-1.2.3.tgz"}, nil
+		},
+	}}
+
+	c, err := LoadURL("git+https://example.com/charts/frobnitz-1.2.3.tgz", getters)
+	if err != nil {
+		t.Fatalf("Failed to load testdata: %s", err)
+	}
+	verifyFrobnitz(t, c)
+
+	if _, err := LoadURL("s3://bucket/frobnitz-1.2.3.tgz", getters); err == nil {
+		t.Error("Expected an error for a scheme without getter")
+	}
+	if _, err := Load("git+https://example.com/charts/frobnitz-1.2.3.tgz"); err == nil || !strings.Contains(err.Error(), "LoadURL") {
+		t.Errorf("Expected Load to refer to LoadURL for a URL, got %v", err)
+	}
+}
@@ -0,0 +1,180 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/Masterminds/semver"
+	"github.com/asaskevich/govalidator"
+	"github.com/ghodss/yaml"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+// LicenseAnnotation is the annotation of Chart.yaml stating the license of
+// the chart, as an SPDX identifier like "Apache-2.0".
+const LicenseAnnotation = "helm.sh/license"
+
+// MetadataPolicy is the policy of an organization for the metadata of its
+// charts, checked by 'helm lint --policy' and 'helm package --policy':
+//
+//	requireMaintainers: true
+//	requireMaintainerEmail: true
+//	requireHome: true
+//	requiredAnnotations:
+//	- example.com/team
+//	prereleases:
+//	- alpha.*
+//	- rc.*
+//	licenses:
+//	- Apache-2.0
+//	- MIT
+//
+// A pattern ending with '*' matches the values it is a prefix of.
+type MetadataPolicy struct {
+	// RequireMaintainers requires the chart to have a maintainer.
+	RequireMaintainers bool `json:"requireMaintainers,omitempty"`
+	// RequireMaintainerEmail requires every maintainer to have an email.
+	RequireMaintainerEmail bool `json:"requireMaintainerEmail,omitempty"`
+	// RequireHome requires the chart to have a home URL.
+	RequireHome bool `json:"requireHome,omitempty"`
+	// RequiredAnnotations are the annotations the chart must have.
+	RequiredAnnotations []string `json:"requiredAnnotations,omitempty"`
+	// ForbidPrereleases forbids pre-release versions, like 1.0.0-rc.1.
+	ForbidPrereleases bool `json:"forbidPrereleases,omitempty"`
+	// Prereleases are the patterns of the allowed pre-releases of the
+	// version, like "rc.*". Any pre-release is allowed if there are none.
+	Prereleases []string `json:"prereleases,omitempty"`
+	// Licenses are the allowed licenses, as SPDX identifiers. The chart must
+	// state one of them in its LicenseAnnotation if there are any.
+	Licenses []string `json:"licenses,omitempty"`
+}
+
+// LoadMetadataPolicy reads the YAML metadata policy of the file filename.
+func LoadMetadataPolicy(filename string) (*MetadataPolicy, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	p := &MetadataPolicy{}
+	if err := yaml.Unmarshal(data, p); err != nil {
+		return nil, fmt.Errorf("cannot parse the metadata policy %s: %s", filename, err)
+	}
+	if p.ForbidPrereleases && len(p.Prereleases) > 0 {
+		return nil, fmt.Errorf("metadata policy %s: prereleases cannot be allowed when they are forbidden", filename)
+	}
+	return p, nil
+}
+
+// Violations returns the violations of the policy by the metadata m, in the
+// order of the fields of the policy.
+func (p *MetadataPolicy) Violations(m *chart.Metadata) []error {
+	var errs []error
+	if p.RequireMaintainers && len(m.Maintainers) == 0 {
+		errs = append(errs, errors.New("the chart must have a maintainer"))
+	}
+	if p.RequireMaintainerEmail {
+		for _, maintainer := range m.Maintainers {
+			if maintainer.Email == "" {
+				errs = append(errs, fmt.Errorf("the maintainer %q must have an email", maintainer.Name))
+			}
+		}
+	}
+	if p.RequireHome && m.Home == "" {
+		errs = append(errs, errors.New("the chart must have a home URL"))
+	} else if p.RequireHome && !govalidator.IsRequestURL(m.Home) {
+		errs = append(errs, fmt.Errorf("the home URL %q is not a valid URL", m.Home))
+	}
+	for _, a := range p.RequiredAnnotations {
+		if _, ok := m.Annotations[a]; !ok {
+			errs = append(errs, fmt.Errorf("the chart must have the annotation %s", a))
+		}
+	}
+	if err := p.checkPrerelease(m.Version); err != nil {
+		errs = append(errs, err)
+	}
+	if len(p.Licenses) > 0 {
+		if license := m.Annotations[LicenseAnnotation]; license == "" {
+			errs = append(errs, fmt.Errorf("the chart must state its license in the annotation %s, one of %s", LicenseAnnotation, strings.Join(p.Licenses, ", ")))
+		} else if !containsFold(p.Licenses, license) {
+			errs = append(errs, fmt.Errorf("the license %s is not allowed, the license must be one of %s", license, strings.Join(p.Licenses, ", ")))
+		}
+	}
+	return errs
+}
+
+// Validate returns an error listing the violations of the policy by the
+// metadata m, if any.
+func (p *MetadataPolicy) Validate(m *chart.Metadata) error {
+	errs := p.Violations(m)
+	if len(errs) == 0 {
+		return nil
+	}
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Error()
+	}
+	return fmt.Errorf("chart metadata (Chart.yaml) violates the policy: %s", strings.Join(msgs, "; "))
+}
+
+// checkPrerelease returns an error if the pre-release of the version is not
+// allowed. An invalid version is reported by the linter.
+func (p *MetadataPolicy) checkPrerelease(version string) error {
+	v, err := semver.NewVersion(version)
+	if err != nil || v.Prerelease() == "" {
+		return nil
+	}
+	if p.ForbidPrereleases {
+		return fmt.Errorf("the version %s is a pre-release, which is not allowed", version)
+	}
+	if len(p.Prereleases) == 0 {
+		return nil
+	}
+	for _, pattern := range p.Prereleases {
+		if pattern == v.Prerelease() || strings.HasSuffix(pattern, "*") && strings.HasPrefix(v.Prerelease(), strings.TrimSuffix(pattern, "*")) {
+			return nil
+		}
+	}
+	return fmt.Errorf("the pre-release %s of the version %s is not allowed, the pre-release must match %s", v.Prerelease(), version, strings.Join(p.Prereleases, ", "))
+}
+
+// containsFold returns whether the list contains s, whatever the case.
+func containsFold(list []string, s string) bool {
+	for _, e := range list {
+		if strings.EqualFold(e, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// UnmarshalChartfileWithPolicy unmarshals the raw Chart.yaml data like
+// UnmarshalChartfile, and validates it against the policy p.
+func UnmarshalChartfileWithPolicy(data []byte, p *MetadataPolicy) (*chart.Metadata, error) {
+	m, err := UnmarshalChartfile(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.Validate(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
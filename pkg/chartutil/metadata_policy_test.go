@@ -0,0 +1,138 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+func TestMetadataPolicyViolations(t *testing.T) {
+	p := &MetadataPolicy{
+		RequireMaintainers:     true,
+		RequireMaintainerEmail: true,
+		RequireHome:            true,
+		RequiredAnnotations:    []string{"example.com/team"},
+		Prereleases:            []string{"alpha.*", "rc.*"},
+		Licenses:               []string{"Apache-2.0", "MIT"},
+	}
+
+	for _, tt := range []struct {
+		chartfile string
+		expect    []string
+	}{
+		{
+			chartfile: `
+name: good
+version: 1.0.0-rc.1
+home: https://example.com/charts/good
+maintainers:
+- name: ops
+  email: ops@example.com
+annotations:
+  example.com/team: ops
+  helm.sh/license: apache-2.0
+`,
+		},
+		{
+			chartfile: `
+name: bad
+version: 1.0.0-beta.1
+home: example
+maintainers:
+- name: ops
+annotations:
+  helm.sh/license: GPL-3.0
+`,
+			expect: []string{
+				`the maintainer "ops" must have an email`,
+				`the home URL "example" is not a valid URL`,
+				"the chart must have the annotation example.com/team",
+				"the pre-release beta.1 of the version 1.0.0-beta.1 is not allowed, the pre-release must match alpha.*, rc.*",
+				"the license GPL-3.0 is not allowed, the license must be one of Apache-2.0, MIT",
+			},
+		},
+		{
+			chartfile: "name: empty\nversion: 1.0.0\n",
+			expect: []string{
+				"the chart must have a maintainer",
+				"the chart must have a home URL",
+				"the chart must have the annotation example.com/team",
+				"the chart must state its license in the annotation helm.sh/license, one of Apache-2.0, MIT",
+			},
+		},
+	} {
+		m, err := UnmarshalChartfile([]byte(tt.chartfile))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, err := range p.Violations(m) {
+			got = append(got, err.Error())
+		}
+		if !reflect.DeepEqual(got, tt.expect) {
+			t.Errorf("%s: expected\n%q\ngot\n%q", m.Name, tt.expect, got)
+		}
+	}
+
+	forbid := &MetadataPolicy{ForbidPrereleases: true}
+	err := forbid.Validate(&chart.Metadata{Name: "pre", Version: "1.0.0-alpha.1"})
+	expect := "chart metadata (Chart.yaml) violates the policy: the version 1.0.0-alpha.1 is a pre-release, which is not allowed"
+	if err == nil || err.Error() != expect {
+		t.Errorf("Expected the error %q, got %v", expect, err)
+	}
+}
+
+func TestUnmarshalChartfileWithPolicy(t *testing.T) {
+	p := &MetadataPolicy{RequireHome: true}
+	if _, err := UnmarshalChartfileWithPolicy([]byte("name: nohome\nversion: 1.0.0\n"), p); err == nil || !strings.HasSuffix(err.Error(), "the chart must have a home URL") {
+		t.Errorf("Expected the violation of the policy, got %v", err)
+	}
+	m, err := UnmarshalChartfileWithPolicy([]byte("name: home\nversion: 1.0.0\nhome: https://example.com\n"), p)
+	if err != nil || m.Name != "home" {
+		t.Errorf("Expected the chart home, got %v, %v", m, err)
+	}
+}
+
+func TestLoadMetadataPolicy(t *testing.T) {
+	dir, err := ioutil.TempDir("", "helm-policy-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "policy.yaml")
+	ioutil.WriteFile(file, []byte("requireHome: true\nlicenses:\n- MIT\n"), 0644)
+	p, err := LoadMetadataPolicy(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expect := (&MetadataPolicy{RequireHome: true, Licenses: []string{"MIT"}}); !reflect.DeepEqual(p, expect) {
+		t.Errorf("Expected %+v, got %+v", expect, p)
+	}
+
+	ioutil.WriteFile(file, []byte("forbidPrereleases: true\nprereleases:\n- rc.*\n"), 0644)
+	if _, err := LoadMetadataPolicy(file); err == nil {
+		t.Error("Expected an error allowing the forbidden pre-releases")
+	}
+}
@@ -0,0 +1,111 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import (
+	"bytes"
+	"path"
+	"sort"
+	"strings"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+// SubchartNotesDirective is the comment of an environment values file that
+// renders the notes of the subcharts along with the notes of the chart when
+// the chart is deployed with the environment:
+//
+//	# helm:subchart-notes
+const SubchartNotesDirective = "# helm:subchart-notes"
+
+// HasSubchartNotesDirective returns whether the environment values file data
+// renders the notes of the subcharts.
+func HasSubchartNotesDirective(data []byte) bool {
+	return hasDirective(data, SubchartNotesDirective)
+}
+
+// EnvironmentSubchartNotes returns whether the environment values file env of
+// the chart c renders the notes of the subcharts. The chart must be loaded
+// without the environment, which keeps the environment values files in its
+// files.
+func EnvironmentSubchartNotes(c *chart.Chart, env string) bool {
+	for _, f := range c.Files {
+		if f.TypeUrl == env {
+			return HasSubchartNotesDirective(f.Value)
+		}
+	}
+	return false
+}
+
+// IsNotes returns whether the rendered template name is the notes of a chart
+// or of a subchart.
+func IsNotes(name string) bool {
+	return path.Base(name) == path.Base(NotesFile)
+}
+
+// JoinNotes returns the notes of the chart chartName among its rendered
+// templates. With subNotes, they are followed by the notes of its subcharts,
+// each under a header naming the subchart, in the order of their names.
+func JoinNotes(chartName string, rendered map[string]string, subNotes bool) string {
+	main := path.Join(chartName, NotesFile)
+	var subcharts []string
+	for name := range rendered {
+		if IsNotes(name) && name != main {
+			subcharts = append(subcharts, name)
+		}
+	}
+	sort.Slice(subcharts, func(i, j int) bool {
+		return subchartNotesName(chartName, subcharts[i]) < subchartNotesName(chartName, subcharts[j])
+	})
+
+	var b bytes.Buffer
+	b.WriteString(rendered[main])
+	if !subNotes {
+		return b.String()
+	}
+	for _, name := range subcharts {
+		notes := strings.TrimSpace(rendered[name])
+		if notes == "" {
+			continue
+		}
+		// A blank line separates the notes.
+		if b.Len() > 0 && !bytes.HasSuffix(b.Bytes(), []byte("\n")) {
+			b.WriteString("\n")
+		}
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString("==> " + subchartNotesName(chartName, name) + "\n")
+		b.WriteString(notes + "\n")
+	}
+	return b.String()
+}
+
+// subchartNotesName returns the name of the subchart of the chart chartName
+// whose rendered notes are name: the names of its parents below the chart and
+// its own, joined with slashes, e.g. "backend/redis" for
+// "mychart/charts/backend/charts/redis/templates/NOTES.txt".
+func subchartNotesName(chartName, name string) string {
+	dir := strings.TrimSuffix(strings.TrimPrefix(name, chartName+"/"), "/"+NotesFile)
+	var names []string
+	for _, p := range strings.Split(dir, "/") {
+		if p != "charts" {
+			names = append(names, p)
+		}
+	}
+	return strings.Join(names, "/")
+}
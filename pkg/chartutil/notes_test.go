@@ -0,0 +1,65 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/any"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+func TestJoinNotes(t *testing.T) {
+	rendered := map[string]string{
+		"umbrella/templates/NOTES.txt":                               "Umbrella notes",
+		"umbrella/templates/service.yaml":                            "kind: Service",
+		"umbrella/charts/web/templates/NOTES.txt":                    "Web notes\n",
+		"umbrella/charts/backend/templates/NOTES.txt":                "Backend notes\n",
+		"umbrella/charts/backend/charts/redis/templates/NOTES.txt":   "Redis notes\n",
+		"umbrella/charts/backend/charts/silent/templates/NOTES.txt":  "\n",
+		"umbrella/charts/backend/charts/silent/templates/config.yml": "kind: ConfigMap",
+	}
+	if notes := JoinNotes("umbrella", rendered, false); notes != "Umbrella notes" {
+		t.Errorf("Expected the notes of the chart only, got %q", notes)
+	}
+	expect := "Umbrella notes\n\n==> backend\nBackend notes\n\n==> backend/redis\nRedis notes\n\n==> web\nWeb notes\n"
+	if notes := JoinNotes("umbrella", rendered, true); notes != expect {
+		t.Errorf("Expected\n%s\ngot\n%s", expect, notes)
+	}
+
+	delete(rendered, "umbrella/templates/NOTES.txt")
+	if notes := JoinNotes("umbrella", rendered, true); notes[:12] != "==> backend\n" {
+		t.Errorf("Expected the notes of the subcharts only, got %q", notes)
+	}
+}
+
+func TestEnvironmentSubchartNotes(t *testing.T) {
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "umbrella"},
+		Files: []*any.Any{
+			{TypeUrl: "values-prod.yaml", Value: []byte("# helm:subchart-notes\nreplicas: 3\n")},
+			{TypeUrl: "values-dev.yaml", Value: []byte("replicas: 1\n")},
+		},
+	}
+	if !EnvironmentSubchartNotes(c, "values-prod.yaml") {
+		t.Error("Expected values-prod.yaml to render the notes of the subcharts")
+	}
+	if EnvironmentSubchartNotes(c, "values-dev.yaml") || EnvironmentSubchartNotes(c, "values-qa.yaml") {
+		t.Error("Expected values-dev.yaml and values-qa.yaml not to render the notes of the subcharts")
+	}
+}
@@ -0,0 +1,155 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+// NullDelete is a default value of a chart deleted by a null value when the
+// values are coalesced.
+type NullDelete struct {
+	// Path is the flattened path of the value in the values of the top chart,
+	// e.g. "mysubchart.image.tag".
+	Path string
+	// Chart is the path of the chart whose default value is deleted, e.g.
+	// "mychart/charts/mysubchart".
+	Chart string
+	// Origin is the values.yaml file of the parent chart setting the value to
+	// null, e.g. "mychart/values.yaml". It is empty if the supplied values
+	// set it to null.
+	Origin string
+}
+
+// valuesSource is a source of the values of a chart: the supplied values or
+// the values of one of its parent charts.
+type valuesSource struct {
+	origin string
+	// prefix is the path of the values of the chart in the values of the top
+	// chart.
+	prefix string
+	flat   map[string]interface{}
+}
+
+// NullDeletes returns the default values of the chart and its dependencies
+// deleted by null values when vals are coalesced with them, as
+// CoalesceValues does, sorted by path.
+func NullDeletes(chrt *chart.Chart, vals *chart.Config) ([]NullDelete, error) {
+	dest := map[string]interface{}{}
+	if vals != nil {
+		evals, err := ReadValues([]byte(vals.Raw))
+		if err != nil {
+			return nil, err
+		}
+		dest = evals
+	}
+
+	var deletes []NullDelete
+	sources := []valuesSource{{flat: Flatten(dest)}}
+	if err := nullDeletes(chrt, chrt.Metadata.Name, "", dest, sources, &deletes); err != nil {
+		return nil, err
+	}
+	sort.Slice(deletes, func(i, j int) bool { return deletes[i].Path < deletes[j].Path })
+	return deletes, nil
+}
+
+// nullDeletes appends the default values of the chart c deleted by the null
+// values of dest, and of its dependencies, to deletes. The values are
+// coalesced as coalesce does.
+func nullDeletes(c *chart.Chart, chartPath, prefix string, dest map[string]interface{}, sources []valuesSource, deletes *[]NullDelete) error {
+	defaults := map[string]interface{}{}
+	if c.Values != nil && c.Values.Raw != "" {
+		nv, err := ReadValues([]byte(c.Values.Raw))
+		if err != nil {
+			return fmt.Errorf("Error: Reading chart '%s' default values (%s): %s", c.Metadata.Name, c.Values.Raw, err)
+		}
+		defaults = nv
+	}
+	findNullDeletes(dest, defaults, prefix, func(p string) {
+		*deletes = append(*deletes, NullDelete{Path: p, Chart: chartPath, Origin: nullOrigin(sources, p)})
+	})
+
+	dest, err := coalesceValues(c, dest)
+	if err != nil {
+		return err
+	}
+	sources = append(sources, valuesSource{
+		origin: path.Join(chartPath, ValuesfileName),
+		prefix: prefix,
+		flat:   Flatten(defaults),
+	})
+	for _, subchart := range c.Dependencies {
+		name := subchart.Metadata.Name
+		dv, ok := dest[name]
+		if !ok || dv == nil {
+			dv = map[string]interface{}{}
+		}
+		dvmap, ok := dv.(map[string]interface{})
+		if !ok {
+			return subchartCollisionError(c, subchart, dv)
+		}
+		dvmap = coalesceGlobals(dvmap, dest, c.Metadata.Name)
+		if err := nullDeletes(subchart, path.Join(chartPath, ChartsDir, name), JoinPath(prefix, name), dvmap, sources, deletes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// findNullDeletes calls found with the path of every value of src deleted by
+// a null value of dst, as coalesceTables deletes them.
+func findNullDeletes(dst, src map[string]interface{}, prefix string, found func(string)) {
+	for key, val := range src {
+		dv, ok := dst[key]
+		if !ok || val == nil {
+			continue
+		}
+		p := JoinPath(prefix, key)
+		if dv == nil {
+			found(p)
+			continue
+		}
+		srcTable, srcIsTable := val.(map[string]interface{})
+		dstTable, dstIsTable := dv.(map[string]interface{})
+		if srcIsTable && dstIsTable {
+			findNullDeletes(dstTable, srcTable, p, found)
+		}
+	}
+}
+
+// nullOrigin returns the origin of the source with the highest precedence
+// setting the value at path p to null.
+func nullOrigin(sources []valuesSource, p string) string {
+	for _, s := range sources {
+		rel := p
+		if s.prefix != "" {
+			if !strings.HasPrefix(p, s.prefix+".") {
+				continue
+			}
+			rel = p[len(s.prefix)+1:]
+		}
+		if v, ok := s.flat[rel]; ok && v == nil {
+			return s.origin
+		}
+	}
+	return ""
+}
@@ -0,0 +1,67 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+func TestNullDeletes(t *testing.T) {
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "demo"},
+		Dependencies: []*chart.Chart{
+			{
+				Metadata: &chart.Metadata{Name: "logstash"},
+				Values: &chart.Config{
+					Raw: `{livenessProbe: {httpGet: {path: "/", port: monitor}}, image: {tag: "1.0"}}`,
+				},
+			},
+		},
+		Values: &chart.Config{
+			Raw: `{name: demo, resources: {limits: {cpu: 1}}, logstash: {livenessProbe: {httpGet: null, exec: "/bin/true"}}}`,
+		},
+	}
+	vals := &chart.Config{
+		Raw: `{name: null, resources: {limits: null}, logstash: {image: {tag: null}}, extra: null}`,
+	}
+
+	deletes, err := NullDeletes(c, vals)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := []NullDelete{
+		{Path: "logstash.image.tag", Chart: "demo/charts/logstash"},
+		{Path: "logstash.livenessProbe.httpGet", Chart: "demo/charts/logstash", Origin: "demo/values.yaml"},
+		{Path: "name", Chart: "demo"},
+		{Path: "resources.limits", Chart: "demo"},
+	}
+	if !reflect.DeepEqual(deletes, expect) {
+		t.Errorf("Expected\n%+v\ngot\n%+v", expect, deletes)
+	}
+
+	deletes, err = NullDeletes(c, &chart.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect = expect[1:2]
+	if !reflect.DeepEqual(deletes, expect) {
+		t.Errorf("Expected\n%+v\ngot\n%+v", expect, deletes)
+	}
+}
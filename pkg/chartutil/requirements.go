@@ -17,11 +17,14 @@ package chartutil
 
 import (
 	"errors"
+	"fmt"
 	"log"
 	"strings"
 	"time"
 
 	"github.com/ghodss/yaml"
+	"github.com/golang/protobuf/ptypes/any"
+	"k8s.io/helm/pkg/dag"
 	"k8s.io/helm/pkg/proto/hapi/chart"
 	"k8s.io/helm/pkg/version"
 )
@@ -29,6 +32,9 @@ import (
 const (
 	requirementsName = "requirements.yaml"
 	lockfileName     = "requirements.lock"
+	// lockfileNameV2 is the name of the lock file of charts with apiVersion
+	// v2.
+	lockfileNameV2 = "Chart.lock"
 )
 
 var (
@@ -68,6 +74,12 @@ type Dependency struct {
 	ImportValues []interface{} `json:"import-values,omitempty"`
 	// Alias usable alias to be used for the chart
 	Alias string `json:"alias,omitempty"`
+	// Needs are the names, or aliases, of the dependencies whose resources
+	// must be ready before the resources of this dependency are created.
+	Needs []string `json:"needs,omitempty"`
+	// Digest is the digest of the chart archive of a locked dependency, e.g.
+	// "sha256:4f2e...". It is only set in lock files.
+	Digest string `json:"digest,omitempty"`
 }
 
 // ErrNoRequirementsFile to detect error condition
@@ -94,6 +106,8 @@ type RequirementsLock struct {
 }
 
 // LoadRequirements loads a requirements file from an in-memory chart.
+//
+// The dependencies of charts with apiVersion v2 are loaded from Chart.yaml.
 func LoadRequirements(c *chart.Chart) (*Requirements, error) {
 	var data []byte
 	for _, f := range c.Files {
@@ -108,11 +122,46 @@ func LoadRequirements(c *chart.Chart) (*Requirements, error) {
 	return r, yaml.Unmarshal(data, r)
 }
 
-// LoadRequirementsLock loads a requirements lock file.
+// DependencyWaves returns the names, or aliases, of the dependencies of the
+// chart c in waves, following their needs: every dependency of a wave only
+// needs dependencies of the previous waves. It returns nil when no dependency
+// needs another.
+func DependencyWaves(c *chart.Chart) ([][]string, error) {
+	reqs, err := LoadRequirements(c)
+	if err == ErrRequirementsNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	g := dag.New()
+	var needs bool
+	for _, d := range reqs.Dependencies {
+		name := d.Name
+		if d.Alias != "" {
+			name = d.Alias
+		}
+		g.Add(name, d.Needs...)
+		needs = needs || len(d.Needs) > 0
+	}
+	if !needs {
+		return nil, nil
+	}
+	waves, err := g.Waves()
+	if err != nil {
+		return nil, fmt.Errorf("cannot order the dependencies of %s: %s", c.Metadata.Name, err)
+	}
+	return waves, nil
+}
+
+// LoadRequirementsLock loads a requirements lock file, Chart.lock for charts
+// with apiVersion v2.
 func LoadRequirementsLock(c *chart.Chart) (*RequirementsLock, error) {
+	name := LockfileName(c)
 	var data []byte
 	for _, f := range c.Files {
-		if f.TypeUrl == lockfileName {
+		if f.TypeUrl == name {
 			data = f.Value
 		}
 	}
@@ -123,6 +172,40 @@ func LoadRequirementsLock(c *chart.Chart) (*RequirementsLock, error) {
 	return r, yaml.Unmarshal(data, r)
 }
 
+// LockfileName returns the name of the lock file of the chart:
+// requirements.lock, or Chart.lock for charts with apiVersion v2.
+func LockfileName(c *chart.Chart) string {
+	if c.GetMetadata().GetApiVersion() == ApiVersionV2 {
+		return lockfileNameV2
+	}
+	return lockfileName
+}
+
+// loadChartfileDependencies loads the dependencies declared in the Chart.yaml
+// file of a chart with apiVersion v2. They are added to the chart as a
+// requirements file, so that they are processed like the requirements of
+// charts with apiVersion v1.
+func loadChartfileDependencies(c *chart.Chart, chartfile []byte) error {
+	for _, f := range c.Files {
+		if f.TypeUrl == requirementsName {
+			return errors.New(requirementsName + " is not supported by charts with apiVersion v2, dependencies must be declared in Chart.yaml")
+		}
+	}
+	reqs := &Requirements{}
+	if err := yaml.Unmarshal(chartfile, reqs); err != nil {
+		return fmt.Errorf("cannot load dependencies of Chart.yaml: %s", err)
+	}
+	if len(reqs.Dependencies) == 0 {
+		return nil
+	}
+	data, err := yaml.Marshal(reqs)
+	if err != nil {
+		return err
+	}
+	c.Files = append(c.Files, &any.Any{TypeUrl: requirementsName, Value: data})
+	return nil
+}
+
 // ProcessRequirementsConditions disables charts based on condition path value in values
 func ProcessRequirementsConditions(reqs *Requirements, cvals Values, cpath string) {
 	var cond string
@@ -220,7 +303,6 @@ func ProcessRequirementsTags(reqs *Requirements, cvals Values) {
 }
 
 func getAliasDependency(charts []*chart.Chart, aliasChart *Dependency) *chart.Chart {
-	var chartFound chart.Chart
 	for _, existingChart := range charts {
 		if existingChart == nil {
 			continue
@@ -234,17 +316,33 @@ func getAliasDependency(charts []*chart.Chart, aliasChart *Dependency) *chart.Ch
 		if !version.IsCompatibleRange(aliasChart.Version, existingChart.Metadata.Version) {
 			continue
 		}
-		chartFound = *existingChart
-		newMetadata := *existingChart.Metadata
+		chartFound := copyChart(existingChart)
 		if aliasChart.Alias != "" {
-			newMetadata.Name = aliasChart.Alias
+			chartFound.Metadata.Name = aliasChart.Alias
 		}
-		chartFound.Metadata = &newMetadata
-		return &chartFound
+		return chartFound
 	}
 	return nil
 }
 
+// copyChart copies a chart with its metadata and the tree of its
+// dependencies, so that every instance of a chart included several times
+// under different aliases is processed with its own values.
+func copyChart(c *chart.Chart) *chart.Chart {
+	cp := *c
+	if c.Metadata != nil {
+		md := *c.Metadata
+		cp.Metadata = &md
+	}
+	cp.Dependencies = make([]*chart.Chart, 0, len(c.Dependencies))
+	for _, d := range c.Dependencies {
+		if d != nil {
+			cp.Dependencies = append(cp.Dependencies, copyChart(d))
+		}
+	}
+	return &cp
+}
+
 // ProcessRequirementsEnabled removes disabled charts from dependencies
 func ProcessRequirementsEnabled(c *chart.Chart, v *chart.Config) error {
 	return doProcessRequirementsEnabled(c, v, "")
@@ -277,10 +375,11 @@ func doProcessRequirementsEnabled(c *chart.Chart, v *chart.Config, path string)
 			}
 		}
 		if !dependencyFound {
-			chartDependencies = append(chartDependencies, existingDependency)
+			chartDependencies = append(chartDependencies, copyChart(existingDependency))
 		}
 	}
 
+	names := map[string]bool{}
 	for _, req := range reqs.Dependencies {
 		if chartDependency := getAliasDependency(c.Dependencies, req); chartDependency != nil {
 			chartDependencies = append(chartDependencies, chartDependency)
@@ -288,6 +387,10 @@ func doProcessRequirementsEnabled(c *chart.Chart, v *chart.Config, path string)
 		if req.Alias != "" {
 			req.Name = req.Alias
 		}
+		if names[req.Name] {
+			return fmt.Errorf("dependency %q is declared more than once in the requirements of %s, set a unique alias for each instance", req.Name, c.Metadata.Name)
+		}
+		names[req.Name] = true
 	}
 	c.Dependencies = chartDependencies
 
@@ -328,10 +431,9 @@ func doProcessRequirementsEnabled(c *chart.Chart, v *chart.Config, path string)
 	// recursively call self to process sub dependencies
 	for _, t := range cd {
 		subpath := path + t.Metadata.Name + "."
-		err := doProcessRequirementsEnabled(t, &cc, subpath)
-		// if its not just missing requirements file, return error
-		if nerr, ok := err.(ErrNoRequirementsFile); !ok && err != nil {
-			return nerr
+		// a missing requirements file is not an error
+		if err := doProcessRequirementsEnabled(t, &cc, subpath); err != nil {
+			return err
 		}
 	}
 	c.Dependencies = cd
@@ -18,11 +18,13 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"reflect"
 	"sort"
 	"testing"
 
 	"strconv"
 
+	"github.com/golang/protobuf/ptypes/any"
 	"k8s.io/helm/pkg/proto/hapi/chart"
 	"k8s.io/helm/pkg/version"
 )
@@ -186,6 +188,73 @@ func TestRequirementsAliasCondition(t *testing.T) {
 	verifyRequirementsEnabled(t, c, v, e)
 }
 
+func TestRequirementsAliasValues(t *testing.T) {
+	c, err := Load("testdata/subpop")
+	if err != nil {
+		t.Fatalf("Failed to load testdata: %s", err)
+	}
+	v := &chart.Config{Raw: "subchart2:\n  service:\n    name: first\nsubchart2alias:\n  service:\n    name: second\n"}
+	if err := ProcessRequirementsEnabled(c, v); err != nil {
+		t.Fatalf("Error processing enabled requirements %v", err)
+	}
+	vals, err := CoalesceValues(c, v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for path, expect := range map[string]string{
+		"subchart2.service.name":          "first",
+		"subchart2alias.service.name":     "second",
+		"subchart2alias.service.type":     "ClusterIP",
+		"subchart2alias.image.pullPolicy": "IfNotPresent",
+	} {
+		if got, err := vals.PathValue(path); err != nil || got != expect {
+			t.Errorf("Expected %s to be %q, got %v (%v)", path, expect, got, err)
+		}
+	}
+}
+
+func TestRequirementsAliasNestedDependencies(t *testing.T) {
+	leaf := &chart.Chart{Metadata: &chart.Metadata{Name: "leaf", Version: "0.1.0"}}
+	inner := &chart.Chart{
+		Metadata:     &chart.Metadata{Name: "inner", Version: "0.1.0"},
+		Files:        []*any.Any{requirementsFile("- name: leaf\n  version: 0.1.0\n  condition: leaf.enabled\n")},
+		Dependencies: []*chart.Chart{leaf},
+	}
+	// inner is not declared in the requirements of sub
+	sub := &chart.Chart{
+		Metadata:     &chart.Metadata{Name: "sub", Version: "0.1.0"},
+		Files:        []*any.Any{requirementsFile("  []\n")},
+		Dependencies: []*chart.Chart{inner},
+	}
+	c := &chart.Chart{
+		Metadata:     &chart.Metadata{Name: "parent", Version: "0.1.0"},
+		Files:        []*any.Any{requirementsFile("- name: sub\n  version: 0.1.0\n  alias: first\n- name: sub\n  version: 0.1.0\n  alias: second\n")},
+		Dependencies: []*chart.Chart{sub},
+	}
+	// the leaf is only enabled in the second instance of the subchart
+	v := &chart.Config{Raw: "first:\n  inner:\n    leaf:\n      enabled: false\nsecond:\n  inner:\n    leaf:\n      enabled: true\n"}
+	verifyRequirementsEnabled(t, c, v, []string{"first", "inner", "inner", "leaf", "parent", "second"})
+
+	if len(inner.Dependencies) != 1 {
+		t.Errorf("Expected the loaded chart to be left unchanged, got %d dependencies", len(inner.Dependencies))
+	}
+}
+
+func TestRequirementsDuplicateName(t *testing.T) {
+	c := &chart.Chart{
+		Metadata:     &chart.Metadata{Name: "parent", Version: "0.1.0"},
+		Files:        []*any.Any{requirementsFile("- name: sub\n  version: 0.1.0\n  alias: cache\n- name: sub\n  version: 0.1.0\n  alias: cache\n")},
+		Dependencies: []*chart.Chart{{Metadata: &chart.Metadata{Name: "sub", Version: "0.1.0"}}},
+	}
+	if err := ProcessRequirementsEnabled(c, &chart.Config{}); err == nil {
+		t.Error("Expected an error for two dependencies with the same alias")
+	}
+}
+
+func requirementsFile(deps string) *any.Any {
+	return &any.Any{TypeUrl: requirementsName, Value: []byte("dependencies:\n" + deps)}
+}
+
 func verifyRequirementsEnabled(t *testing.T, c *chart.Chart, v *chart.Config, e []string) {
 	out := []*chart.Chart{}
 	err := ProcessRequirementsEnabled(c, v)
@@ -518,3 +587,44 @@ func TestDependentChartsWithSomeSubchartsSpecifiedInRequirements(t *testing.T) {
 	}
 
 }
+
+func TestDependencyWaves(t *testing.T) {
+	withRequirements := func(requirements string) *chart.Chart {
+		return &chart.Chart{
+			Metadata: &chart.Metadata{Name: "umbrella"},
+			Files:    []*any.Any{{TypeUrl: "requirements.yaml", Value: []byte(requirements)}},
+		}
+	}
+
+	waves, err := DependencyWaves(withRequirements(`dependencies:
+- name: postgresql
+  alias: db
+- name: web
+  needs: [db, cache]
+- name: redis
+  alias: cache
+- name: metrics
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := [][]string{{"db", "cache", "metrics"}, {"web"}}
+	if !reflect.DeepEqual(waves, expect) {
+		t.Errorf("Expected the waves %v, got %v", expect, waves)
+	}
+
+	waves, err = DependencyWaves(withRequirements("dependencies:\n- name: web\n- name: db\n"))
+	if err != nil || waves != nil {
+		t.Errorf("Expected no waves without needs, got %v, %v", waves, err)
+	}
+
+	_, err = DependencyWaves(withRequirements(`dependencies:
+- name: web
+  needs: [db]
+- name: db
+  needs: [web]
+`))
+	if err == nil || err.Error() != "cannot order the dependencies of umbrella: dependency cycle: web -> db -> web" {
+		t.Errorf("Expected a cycle error, got %v", err)
+	}
+}
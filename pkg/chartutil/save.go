@@ -23,18 +23,35 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path"
 	"path/filepath"
+	"strings"
 	"time"
 
-	"github.com/ghodss/yaml"
-
 	"k8s.io/helm/pkg/proto/hapi/chart"
 )
 
 var headerBytes = []byte("+aHR0cHM6Ly95b3V0dS5iZS96OVV6MWljandyTQo=")
 
 // SaveDir saves a chart as files in a directory.
+//
+// If the directory is /foo, and the chart is named bar, this will write the
+// chart to /foo/bar, which must not exist. The dependencies of the chart are
+// saved as archives in its charts/ directory.
+//
+// SaveDir is the inverse of LoadDir: loading the directory it writes returns
+// the chart.
 func SaveDir(c *chart.Chart, dest string) error {
+	if c.Metadata == nil {
+		return errors.New("no Chart.yaml data")
+	}
+	if c.Metadata.Name == "" {
+		return errors.New("no chart name specified (Chart.yaml)")
+	}
+	if err := checkChartPaths(c); err != nil {
+		return err
+	}
+
 	// Create the chart directory
 	outdir := filepath.Join(dest, c.Metadata.Name)
 	if err := os.Mkdir(outdir, 0755); err != nil {
@@ -42,7 +59,11 @@ func SaveDir(c *chart.Chart, dest string) error {
 	}
 
 	// Save the chart file.
-	if err := SaveChartfile(filepath.Join(outdir, ChartfileName), c.Metadata); err != nil {
+	cdata, err := marshalChartfile(c)
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(filepath.Join(outdir, ChartfileName), cdata, 0644); err != nil {
 		return err
 	}
 
@@ -76,6 +97,9 @@ func SaveDir(c *chart.Chart, dest string) error {
 
 	// Save files
 	for _, f := range c.Files {
+		if isChartfileDependencies(c, f.TypeUrl) {
+			continue
+		}
 		n := filepath.Join(outdir, f.TypeUrl)
 
 		d := filepath.Dir(n)
@@ -166,9 +190,12 @@ func Save(c *chart.Chart, outDir string) (string, error) {
 
 func writeTarContents(out *tar.Writer, c *chart.Chart, prefix string) error {
 	base := filepath.Join(prefix, c.Metadata.Name)
+	if err := checkChartPaths(c); err != nil {
+		return err
+	}
 
 	// Save Chart.yaml
-	cdata, err := yaml.Marshal(c.Metadata)
+	cdata, err := marshalChartfile(c)
 	if err != nil {
 		return err
 	}
@@ -193,6 +220,9 @@ func writeTarContents(out *tar.Writer, c *chart.Chart, prefix string) error {
 
 	// Save files
 	for _, f := range c.Files {
+		if isChartfileDependencies(c, f.TypeUrl) {
+			continue
+		}
 		n := filepath.Join(base, f.TypeUrl)
 		if err := writeToTar(out, n, f.Value); err != nil {
 			return err
@@ -225,3 +255,43 @@ func writeToTar(out *tar.Writer, name string, body []byte) error {
 	}
 	return nil
 }
+
+// isChartfileDependencies returns true if the file of the chart holds the
+// dependencies declared in Chart.yaml, which are saved to Chart.yaml.
+func isChartfileDependencies(c *chart.Chart, name string) bool {
+	return c.Metadata.ApiVersion == ApiVersionV2 && name == requirementsName
+}
+
+// checkChartPaths returns an ErrIllegalPath if a template or a file of the
+// chart would be saved outside of the chart directory, or if a template would
+// not be saved to the templates/ directory, where it is loaded from.
+func checkChartPaths(c *chart.Chart) error {
+	for _, t := range c.Templates {
+		if err := checkChartPath(t.Name); err != nil {
+			return err
+		}
+		if !strings.HasPrefix(path.Clean(filepath.ToSlash(t.Name)), TemplatesDir+"/") {
+			return &ErrIllegalPath{Path: t.Name, Reason: fmt.Sprintf("template %s is not in the %s/ directory", t.Name, TemplatesDir)}
+		}
+	}
+	for _, f := range c.Files {
+		if err := checkChartPath(f.TypeUrl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkChartPath returns an ErrIllegalPath if the file name would be saved
+// outside of the chart directory.
+func checkChartPath(name string) error {
+	n := filepath.ToSlash(name)
+	if path.IsAbs(n) || drivePathPattern.MatchString(n) {
+		return &ErrIllegalPath{Path: name, Reason: fmt.Sprintf("chart illegally contains absolute paths: %s", name)}
+	}
+	n = path.Clean(n)
+	if n == "." || n == ".." || strings.HasPrefix(n, "../") {
+		return &ErrIllegalPath{Path: name, Reason: fmt.Sprintf("chart illegally references parent directory: %s", name)}
+	}
+	return nil
+}
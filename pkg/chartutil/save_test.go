@@ -22,6 +22,8 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -207,3 +209,109 @@ func TestSaveDir(t *testing.T) {
 		t.Fatal("Templates data did not match")
 	}
 }
+
+func TestSaveDirRoundTrip(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "helm-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	c, err := LoadDir("testdata/frobnitz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveDir(c, tmp); err != nil {
+		t.Fatalf("Failed to save: %s", err)
+	}
+	c2, err := LoadDir(filepath.Join(tmp, "frobnitz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(c2.Metadata, c.Metadata) {
+		t.Errorf("Expected the metadata %v, got %v", c.Metadata, c2.Metadata)
+	}
+	if c2.Values.Raw != c.Values.Raw {
+		t.Errorf("Expected the values\n%s\ngot\n%s", c.Values.Raw, c2.Values.Raw)
+	}
+	if len(c2.Templates) != len(c.Templates) {
+		t.Errorf("Expected %d templates, got %d", len(c.Templates), len(c2.Templates))
+	}
+	if len(c2.Files) != len(c.Files) {
+		t.Errorf("Expected %d files, got %d", len(c.Files), len(c2.Files))
+	}
+	if len(c2.Dependencies) != len(c.Dependencies) {
+		t.Errorf("Expected %d dependencies, got %d", len(c.Dependencies), len(c2.Dependencies))
+	}
+}
+
+func TestSaveDirIllegalPaths(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "helm-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	for _, c := range []*chart.Chart{
+		{
+			Metadata: &chart.Metadata{Name: "ahab", Version: "1.2.3"},
+			Files:    []*any.Any{{TypeUrl: "../whale.txt", Value: []byte("Moby Dick")}},
+		},
+		{
+			Metadata: &chart.Metadata{Name: "ahab", Version: "1.2.3"},
+			Files:    []*any.Any{{TypeUrl: "/etc/whale.txt", Value: []byte("Moby Dick")}},
+		},
+		{
+			Metadata:  &chart.Metadata{Name: "ahab", Version: "1.2.3"},
+			Templates: []*chart.Template{{Name: "pequod.yaml", Data: []byte("ship: Pequod")}},
+		},
+	} {
+		err := SaveDir(c, tmp)
+		if _, ok := err.(*ErrIllegalPath); !ok {
+			t.Errorf("Expected an illegal path error, got %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(tmp, "ahab")); !os.IsNotExist(err) {
+			t.Errorf("Expected the chart not to be saved")
+		}
+	}
+}
+
+func TestSaveV2Chart(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "helm-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	c, err := Load("testdata/frobnitz.v2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	archive, err := Save(c, tmp)
+	if err != nil {
+		t.Fatalf("Failed to save: %s", err)
+	}
+	if err := SaveDir(c, tmp); err != nil {
+		t.Fatalf("Failed to save: %s", err)
+	}
+
+	// The dependencies are saved to Chart.yaml, the chart would not load
+	// with a requirements.yaml file otherwise.
+	for _, name := range []string{archive, filepath.Join(tmp, "frobnitz")} {
+		c2, err := Load(name)
+		if err != nil {
+			t.Fatalf("Failed to load %s: %s", name, err)
+		}
+		reqs, err := LoadRequirements(c2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(reqs.Dependencies) != 2 {
+			t.Errorf("Expected 2 dependencies in %s, got %d", name, len(reqs.Dependencies))
+		}
+		if _, err := LoadRequirementsLock(c2); err != nil {
+			t.Errorf("Expected %s to have a Chart.lock: %s", name, err)
+		}
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package chartutil
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -50,25 +51,38 @@ func MergeValues(dest Values, src Values) Values {
 			dest[k] = v
 			continue
 		}
-		nextMap, ok := v.(Values)
+		nextMap, ok := asValues(v)
 		// If it isn't another map, overwrite the value
 		if !ok {
 			dest[k] = v
 			continue
 		}
 		// Edge case: If the key exists in the destination, but isn't a map
-		destMap, isMap := dest[k].(Values)
+		destMap, isMap := asValues(dest[k])
 		// If the source map has a map for this key, prefer it
 		if !isMap {
 			dest[k] = v
 			continue
 		}
-		// If we got to this point, it is a map in both, so merge them
-		dest[k] = MergeValues(destMap, nextMap)
+		// If we got to this point, it is a map in both, so merge them. The
+		// map is merged in place, keeping its type.
+		MergeValues(destMap, nextMap)
 	}
 	return dest
 }
 
+// asValues returns v as Values if it is a table: Values, or a
+// map[string]interface{} as decoded from YAML.
+func asValues(v interface{}) (Values, bool) {
+	switch m := v.(type) {
+	case Values:
+		return m, true
+	case map[string]interface{}:
+		return Values(m), true
+	}
+	return nil, false
+}
+
 // YAML encodes the Values into a YAML string.
 func (v Values) YAML() (string, error) {
 	b, err := yaml.Marshal(v)
@@ -159,11 +173,16 @@ func tableLookup(v Values, simple string) (Values, error) {
 }
 
 // ReadValues will parse YAML byte data into a Values.
+//
+// An ErrValuesUnparsable is returned if the data is not valid YAML.
 func ReadValues(data []byte) (vals Values, err error) {
 	err = yaml.Unmarshal(data, &vals, func(d *json.Decoder) *json.Decoder {
 		d.UseNumber()
 		return d
 	})
+	if err != nil {
+		err = valuesUnparsable("", err)
+	}
 	if len(vals) == 0 {
 		vals = Values{}
 	}
@@ -176,7 +195,11 @@ func ReadValuesFile(filename string) (Values, error) {
 	if err != nil {
 		return map[string]interface{}{}, err
 	}
-	return ReadValues(data)
+	vals, err := ReadValues(data)
+	if e, ok := err.(*ErrValuesUnparsable); ok {
+		e.File = filename
+	}
+	return vals, err
 }
 
 // CoalesceValues coalesces all of the values in a chart (and its subcharts).
@@ -189,6 +212,12 @@ func ReadValuesFile(filename string) (Values, error) {
 //	- A chart has access to all of the variables for it, as well as all of
 //		the values destined for its dependencies.
 func CoalesceValues(chrt *chart.Chart, vals *chart.Config) (Values, error) {
+	return CoalesceValuesWithContext(context.Background(), chrt, vals)
+}
+
+// CoalesceValuesWithContext is CoalesceValues, stopping with the error of ctx
+// when ctx is done before the values of every subchart are coalesced.
+func CoalesceValuesWithContext(ctx context.Context, chrt *chart.Chart, vals *chart.Config) (Values, error) {
 	cvals := Values{}
 	// Parse values if not nil. We merge these at the top level because
 	// the passed-in values are in the same namespace as the parent chart.
@@ -197,32 +226,35 @@ func CoalesceValues(chrt *chart.Chart, vals *chart.Config) (Values, error) {
 		if err != nil {
 			return cvals, err
 		}
-		return coalesce(chrt, evals)
+		return coalesce(ctx, chrt, evals)
 	}
 
-	return coalesceDeps(chrt, cvals)
+	return coalesceDeps(ctx, chrt, cvals)
 }
 
 // coalesce coalesces the dest values and the chart values, giving priority to the dest values.
 //
 // This is a helper function for CoalesceValues.
-func coalesce(ch *chart.Chart, dest map[string]interface{}) (map[string]interface{}, error) {
+func coalesce(ctx context.Context, ch *chart.Chart, dest map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	dest, err = coalesceValues(ch, dest)
 	if err != nil {
 		return dest, err
 	}
-	return coalesceDeps(ch, dest)
+	return coalesceDeps(ctx, ch, dest)
 }
 
 // coalesceDeps coalesces the dependencies of the given chart.
-func coalesceDeps(chrt *chart.Chart, dest map[string]interface{}) (map[string]interface{}, error) {
+func coalesceDeps(ctx context.Context, chrt *chart.Chart, dest map[string]interface{}) (map[string]interface{}, error) {
 	for _, subchart := range chrt.Dependencies {
-		if c, ok := dest[subchart.Metadata.Name]; !ok {
+		if err := ctx.Err(); err != nil {
+			return dest, err
+		}
+		if c, ok := dest[subchart.Metadata.Name]; !ok || c == nil {
 			// If dest doesn't already have the key, create it.
 			dest[subchart.Metadata.Name] = map[string]interface{}{}
 		} else if !istable(c) {
-			return dest, fmt.Errorf("type mismatch on %s: %t", subchart.Metadata.Name, c)
+			return dest, subchartCollisionError(chrt, subchart, c)
 		}
 		if dv, ok := dest[subchart.Metadata.Name]; ok {
 			dvmap := dv.(map[string]interface{})
@@ -232,7 +264,7 @@ func coalesceDeps(chrt *chart.Chart, dest map[string]interface{}) (map[string]in
 
 			var err error
 			// Now coalesce the rest of the values.
-			dest[subchart.Metadata.Name], err = coalesce(subchart, dvmap)
+			dest[subchart.Metadata.Name], err = coalesce(ctx, subchart, dvmap)
 			if err != nil {
 				return dest, err
 			}
@@ -241,6 +273,14 @@ func coalesceDeps(chrt *chart.Chart, dest map[string]interface{}) (map[string]in
 	return dest, nil
 }
 
+// subchartCollisionError returns the error of the value v of the key named
+// after the subchart of chrt, which is not a table.
+func subchartCollisionError(chrt, subchart *chart.Chart, v interface{}) error {
+	name := subchart.Metadata.Name
+	return fmt.Errorf("%s: %v is not a table, but %s is a subchart of chart %s and its value must be the table of the values of the subchart; rename the key if it is meant for chart %s",
+		name, v, name, chrt.Metadata.Name, chrt.Metadata.Name)
+}
+
 // coalesceGlobals copies the globals out of src and merges them into dest.
 //
 // For convenience, returns dest.
@@ -291,7 +331,7 @@ func coalesceValues(c *chart.Chart, v map[string]interface{}) (map[string]interf
 		// On error, we return just the overridden values.
 		// FIXME: We should log this error. It indicates that the YAML data
 		// did not parse.
-		return v, fmt.Errorf("Error: Reading chart '%s' default values (%s): %s", c.Metadata.Name, c.Values.Raw, err)
+		return v, fmt.Errorf("Error: Reading chart '%s' default values (%s): %w", c.Metadata.Name, c.Values.Raw, err)
 	}
 
 	return coalesceTables(v, nv.AsMap(), c.Metadata.Name), nil
@@ -357,6 +397,9 @@ type ReleaseOptions struct {
 	IsUpgrade bool
 	IsInstall bool
 	Revision  int
+	// Environment is the environment values file of the release, rendered
+	// when it is a template. See RenderEnvironments.
+	Environment string
 }
 
 // ToRenderValues composes the struct from the data coming from the Releases, Charts and Values files
@@ -373,32 +416,46 @@ func ToRenderValues(chrt *chart.Chart, chrtVals *chart.Config, options ReleaseOp
 // ToRenderValuesCaps composes the struct from the data coming from the Releases, Charts and Values files
 //
 // This takes both ReleaseOptions and Capabilities to merge into the render values.
+// The values are validated against the values.cue schemas of the charts, see
+// ValidateValuesSchema.
 func ToRenderValuesCaps(chrt *chart.Chart, chrtVals *chart.Config, options ReleaseOptions, caps *Capabilities) (Values, error) {
 
 	top := map[string]interface{}{
-		"Release": map[string]interface{}{
-			"Name":      options.Name,
-			"Time":      options.Time,
-			"Namespace": options.Namespace,
-			"IsUpgrade": options.IsUpgrade,
-			"IsInstall": options.IsInstall,
-			"Revision":  options.Revision,
-			"Service":   "Tiller",
-		},
+		"Release":      releaseValues(options),
 		"Chart":        chrt.Metadata,
 		"Files":        NewFiles(chrt.Files),
 		"Capabilities": caps,
 	}
 
+	chrt, err := RenderEnvironments(chrt, options)
+	if err != nil {
+		return top, err
+	}
 	vals, err := CoalesceValues(chrt, chrtVals)
 	if err != nil {
 		return top, err
 	}
+	if vals, err = ValidateValuesSchema(chrt, vals); err != nil {
+		return top, err
+	}
 
 	top["Values"] = vals
 	return top, nil
 }
 
+// releaseValues returns the .Release object of the templates.
+func releaseValues(options ReleaseOptions) map[string]interface{} {
+	return map[string]interface{}{
+		"Name":      options.Name,
+		"Time":      options.Time,
+		"Namespace": options.Namespace,
+		"IsUpgrade": options.IsUpgrade,
+		"IsInstall": options.IsInstall,
+		"Revision":  options.Revision,
+		"Service":   "Tiller",
+	}
+}
+
 // istable is a special-purpose function to see if the present thing matches the definition of a YAML table.
 func istable(v interface{}) bool {
 	_, ok := v.(map[string]interface{})
@@ -0,0 +1,68 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import (
+	"reflect"
+	"sort"
+)
+
+// ValueChangeKind is the kind of a ValueChange.
+type ValueChangeKind string
+
+// The kinds of ValueChange.
+const (
+	ValueAdded   ValueChangeKind = "added"
+	ValueRemoved ValueChangeKind = "removed"
+	ValueChanged ValueChangeKind = "changed"
+)
+
+// ValueChange is a change of a leaf of a values tree, as returned by Flatten.
+type ValueChange struct {
+	// Path is the flattened path of the value.
+	Path string          `json:"path"`
+	Kind ValueChangeKind `json:"kind"`
+	// Old is the value before the change. It is nil if the value is added.
+	Old interface{} `json:"old,omitempty"`
+	// New is the value after the change. It is nil if the value is removed.
+	New interface{} `json:"new,omitempty"`
+}
+
+// DiffValues returns the changes of the leaves of the values tree from in the
+// values tree to, sorted by path. A value replaced by a table or a list, or
+// the reverse, is removed and the leaves replacing it are added.
+func DiffValues(from, to Values) []ValueChange {
+	oldFlat, newFlat := Flatten(from), Flatten(to)
+
+	changes := []ValueChange{}
+	for p, o := range oldFlat {
+		n, ok := newFlat[p]
+		switch {
+		case !ok:
+			changes = append(changes, ValueChange{Path: p, Kind: ValueRemoved, Old: o})
+		case !reflect.DeepEqual(o, n):
+			changes = append(changes, ValueChange{Path: p, Kind: ValueChanged, Old: o, New: n})
+		}
+	}
+	for p, n := range newFlat {
+		if _, ok := oldFlat[p]; !ok {
+			changes = append(changes, ValueChange{Path: p, Kind: ValueAdded, New: n})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes
+}
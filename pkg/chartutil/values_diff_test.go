@@ -0,0 +1,63 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestDiffValues(t *testing.T) {
+	from, err := ReadValues([]byte(`
+replicas: 1
+image: {repository: nginx, tag: "1.0"}
+args: [a, b]
+debug: true
+resources: {}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	to, err := ReadValues([]byte(`
+replicas: 2
+image: {repository: nginx, tag: "1.1"}
+args: [a]
+resources: {limits: {cpu: 1}}
+ingress: {enabled: true}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect := []ValueChange{
+		{Path: "args[1]", Kind: ValueRemoved, Old: "b"},
+		{Path: "debug", Kind: ValueRemoved, Old: true},
+		{Path: "image.tag", Kind: ValueChanged, Old: "1.0", New: "1.1"},
+		{Path: "ingress.enabled", Kind: ValueAdded, New: true},
+		{Path: "replicas", Kind: ValueChanged, Old: json.Number("1"), New: json.Number("2")},
+		{Path: "resources", Kind: ValueRemoved, Old: map[string]interface{}{}},
+		{Path: "resources.limits.cpu", Kind: ValueAdded, New: json.Number("1")},
+	}
+	if changes := DiffValues(from, to); !reflect.DeepEqual(changes, expect) {
+		t.Errorf("Expected\n%+v\ngot\n%+v", expect, changes)
+	}
+
+	if changes := DiffValues(from, from); len(changes) != 0 {
+		t.Errorf("Expected no changes, got %+v", changes)
+	}
+}
@@ -0,0 +1,472 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ValuesDocument is a values document parsed as a YAML node tree. Unlike
+// Values, it keeps the comments, the anchors and the order of the keys of the
+// document, and the style of its scalars, when it is edited, merged and
+// written back.
+//
+// The values of a document are addressed by dotted paths like "image.tag" or
+// "ingress.hosts[0].name". Dots in keys are escaped with a backslash.
+type ValuesDocument struct {
+	// doc is the document node, whose content is a map.
+	doc *yaml.Node
+}
+
+// ReadValuesDocument parses the values document data. Empty data is an empty
+// document.
+func ReadValuesDocument(data []byte) (*ValuesDocument, error) {
+	doc := &yaml.Node{}
+	if err := yaml.Unmarshal(data, doc); err != nil {
+		return nil, valuesUnparsable("", err)
+	}
+	if doc.Kind == 0 {
+		return &ValuesDocument{doc: &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{mapNode()}}}, nil
+	}
+	if n := doc.Content[0]; n.Kind == yaml.ScalarNode && n.Tag == "!!null" {
+		// A document of comments only.
+		m := mapNode()
+		m.HeadComment, m.FootComment = n.HeadComment, n.FootComment
+		doc.Content[0] = m
+	}
+	if doc.Content[0].Kind != yaml.MappingNode {
+		return nil, errors.New("values are not a YAML map")
+	}
+	return &ValuesDocument{doc: doc}, nil
+}
+
+// ReadValuesDocumentFile parses the values document of the file filename.
+func ReadValuesDocumentFile(filename string) (*ValuesDocument, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	d, err := ReadValuesDocument(data)
+	if e, ok := err.(*ErrValuesUnparsable); ok {
+		e.File = filename
+	} else if err != nil {
+		err = fmt.Errorf("%s: %s", filename, err)
+	}
+	return d, err
+}
+
+// Values returns the values of the document.
+func (d *ValuesDocument) Values() (Values, error) {
+	y, err := d.YAML()
+	if err != nil {
+		return nil, err
+	}
+	return ReadValues([]byte(y))
+}
+
+// YAML encodes the document into a YAML string.
+func (d *ValuesDocument) YAML() (string, error) {
+	var b bytes.Buffer
+	e := yaml.NewEncoder(&b)
+	e.SetIndent(2)
+	if err := e.Encode(d.doc); err != nil {
+		return "", err
+	}
+	if err := e.Close(); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// Lookup returns the value at path, and whether it is set.
+func (d *ValuesDocument) Lookup(path string) (interface{}, bool, error) {
+	p, err := parseValuesPath(path)
+	if err != nil {
+		return nil, false, err
+	}
+	n := d.doc.Content[0]
+	for _, e := range p {
+		if n.Kind == yaml.AliasNode {
+			n = n.Alias
+		}
+		var i int
+		switch {
+		case e.index < 0 && n.Kind == yaml.MappingNode:
+			i = mappingIndex(n, e.key)
+		case e.index >= 0 && n.Kind == yaml.SequenceNode && e.index < len(n.Content):
+			i = e.index
+		default:
+			i = -1
+		}
+		if i < 0 {
+			return nil, false, nil
+		}
+		n = n.Content[i]
+	}
+	var v interface{}
+	if err := n.Decode(&v); err != nil {
+		return nil, false, err
+	}
+	return v, true, nil
+}
+
+// Set sets the value at path to v, creating the maps and appending to the
+// lists of the path as needed. The comments, the anchor and the quoting of a
+// replaced value are kept.
+func (d *ValuesDocument) Set(path string, v interface{}) error {
+	p, err := parseValuesPath(path)
+	if err != nil {
+		return err
+	}
+	n := &yaml.Node{}
+	if err := n.Encode(v); err != nil {
+		return fmt.Errorf("cannot set %s: %s", path, err)
+	}
+	if err := setNode(d.doc.Content[0], p, n); err != nil {
+		return fmt.Errorf("cannot set %s: %s", path, err)
+	}
+	return nil
+}
+
+// Delete deletes the value at path, with its comments, and returns whether it
+// was set.
+func (d *ValuesDocument) Delete(path string) (bool, error) {
+	p, err := parseValuesPath(path)
+	if err != nil {
+		return false, err
+	}
+	deleted, err := deleteNode(d.doc.Content[0], p)
+	if err != nil {
+		return false, fmt.Errorf("cannot delete %s: %s", path, err)
+	}
+	return deleted, nil
+}
+
+// Merge merges the document src into the document, preferring the values of
+// src, like MergeValues. The comments of the document are kept, and the
+// comments of src are added with its values.
+func (d *ValuesDocument) Merge(src *ValuesDocument) {
+	mergeNodes(d.doc.Content[0], src.doc.Content[0])
+}
+
+// MergeValues merges the values src into the document, preferring the values
+// of src, like MergeValues.
+func (d *ValuesDocument) MergeValues(src Values) error {
+	n := &yaml.Node{}
+	if err := n.Encode(src); err != nil {
+		return err
+	}
+	mergeNodes(d.doc.Content[0], n)
+	return nil
+}
+
+// MoveToFront moves the top-level keys, with their values and comments, to
+// the front of the document in the given order, and returns whether the
+// document changed. The keys that are not set are ignored.
+func (d *ValuesDocument) MoveToFront(keys ...string) bool {
+	m := d.doc.Content[0]
+	var content []*yaml.Node
+	moved := map[string]bool{}
+	for _, k := range keys {
+		if i := mappingIndex(m, k); i >= 0 && !moved[k] {
+			content = append(content, m.Content[i-1], m.Content[i])
+			moved[k] = true
+		}
+	}
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if !moved[m.Content[i].Value] {
+			content = append(content, m.Content[i], m.Content[i+1])
+		}
+	}
+	changed := false
+	for i := range content {
+		if content[i] != m.Content[i] {
+			changed = true
+		}
+	}
+	m.Content = content
+	return changed
+}
+
+// IndentedBy returns whether the nested maps of the document, as it was read,
+// are indented by width spaces.
+func (d *ValuesDocument) IndentedBy(width int) bool {
+	return indentedBy(d.doc.Content[0], width)
+}
+
+// indentedBy returns whether the block maps nested in the node n are
+// indented by width spaces.
+func indentedBy(n *yaml.Node, width int) bool {
+	switch n.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			k, v := n.Content[i], n.Content[i+1]
+			if v.Kind == yaml.MappingNode && v.Style&yaml.FlowStyle == 0 && len(v.Content) > 0 && v.Content[0].Column-k.Column != width {
+				return false
+			}
+			if !indentedBy(v, width) {
+				return false
+			}
+		}
+	case yaml.SequenceNode:
+		for _, item := range n.Content {
+			if !indentedBy(item, width) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// mergeNodes merges the map node src into the map node dest.
+func mergeNodes(dest, src *yaml.Node) {
+	if src.Kind == yaml.AliasNode {
+		src = src.Alias
+	}
+	for i := 0; i+1 < len(src.Content); i += 2 {
+		k, v := src.Content[i], src.Content[i+1]
+		j := mappingIndex(dest, k.Value)
+		if j < 0 {
+			dest.Content = append(dest.Content, detachNode(k), detachNode(v))
+			continue
+		}
+		sv := v
+		if sv.Kind == yaml.AliasNode {
+			sv = sv.Alias
+		}
+		if dv := dest.Content[j]; dv.Kind == yaml.MappingNode && sv.Kind == yaml.MappingNode {
+			mergeNodes(dv, sv)
+			continue
+		}
+		replaceNode(dest.Content[j], detachNode(v))
+	}
+}
+
+// detachNode returns a copy of the node n, with its aliases replaced by
+// copies of the values they refer to, as their anchors are in another
+// document.
+func detachNode(n *yaml.Node) *yaml.Node {
+	if n.Kind == yaml.AliasNode {
+		c := detachNode(n.Alias)
+		c.Anchor = ""
+		c.HeadComment, c.LineComment, c.FootComment = n.HeadComment, n.LineComment, n.FootComment
+		return c
+	}
+	c := *n
+	c.Anchor = ""
+	c.Content = make([]*yaml.Node, len(n.Content))
+	for i, cn := range n.Content {
+		c.Content[i] = detachNode(cn)
+	}
+	return &c
+}
+
+// valuesPathElem is an element of a values path: the key of a map, or the
+// index of a list if index is not negative.
+type valuesPathElem struct {
+	key   string
+	index int
+}
+
+func (e valuesPathElem) String() string {
+	if e.index >= 0 {
+		return fmt.Sprintf("[%d]", e.index)
+	}
+	return e.key
+}
+
+// parseValuesPath parses a values path like "ingress.hosts[0].name".
+func parseValuesPath(p string) ([]valuesPathElem, error) {
+	invalid := func(reason string) error {
+		return fmt.Errorf("invalid value path %q: %s", p, reason)
+	}
+	var elems []valuesPathElem
+	var key []byte
+	// segment is whether the current segment of the path, between dots, has
+	// a key.
+	segment := false
+	flush := func() {
+		if len(key) > 0 {
+			elems = append(elems, valuesPathElem{key: string(key), index: -1})
+			key = nil
+			segment = true
+		}
+	}
+	for i := 0; i < len(p); i++ {
+		switch p[i] {
+		case '\\':
+			if i++; i == len(p) {
+				return nil, invalid("trailing backslash")
+			}
+			key = append(key, p[i])
+		case '.':
+			flush()
+			if !segment {
+				return nil, invalid("empty key")
+			}
+			segment = false
+		case '[':
+			flush()
+			if !segment {
+				return nil, invalid("index without a key")
+			}
+			j := strings.IndexByte(p[i:], ']')
+			if j < 0 {
+				return nil, invalid("unclosed index")
+			}
+			n, err := strconv.Atoi(p[i+1 : i+j])
+			if err != nil || n < 0 {
+				return nil, invalid(fmt.Sprintf("invalid index %q", p[i+1:i+j]))
+			}
+			elems = append(elems, valuesPathElem{index: n})
+			i += j
+		default:
+			if segment && len(key) == 0 {
+				return nil, invalid("key after an index")
+			}
+			key = append(key, p[i])
+		}
+	}
+	flush()
+	if !segment {
+		return nil, invalid("empty key")
+	}
+	return elems, nil
+}
+
+// setNode sets the node at the path p under the node n to v.
+func setNode(n *yaml.Node, p []valuesPathElem, v *yaml.Node) error {
+	e := p[0]
+	if n.Kind == yaml.AliasNode {
+		return errors.New("cannot edit values through an alias")
+	}
+	if n.Kind == yaml.ScalarNode && n.Tag == "!!null" {
+		// An empty value becomes the map or the list of the path.
+		n.Value, n.Style = "", 0
+		if e.index < 0 {
+			n.Kind, n.Tag = yaml.MappingNode, "!!map"
+		} else {
+			n.Kind, n.Tag = yaml.SequenceNode, "!!seq"
+		}
+	}
+
+	var i int
+	if e.index < 0 {
+		if n.Kind != yaml.MappingNode {
+			return fmt.Errorf("the parent of %s is not a map", e)
+		}
+		if i = mappingIndex(n, e.key); i < 0 {
+			n.Content = append(n.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: e.key}, nullNode())
+			i = len(n.Content) - 1
+		}
+	} else {
+		if n.Kind != yaml.SequenceNode {
+			return fmt.Errorf("the parent of %s is not a list", e)
+		}
+		if e.index > len(n.Content) {
+			return fmt.Errorf("the index %d is out of range", e.index)
+		}
+		if e.index == len(n.Content) {
+			n.Content = append(n.Content, nullNode())
+		}
+		i = e.index
+	}
+	if len(p) == 1 {
+		replaceNode(n.Content[i], v)
+		return nil
+	}
+	return setNode(n.Content[i], p[1:], v)
+}
+
+// deleteNode deletes the node at the path p under the node n, and returns
+// whether it was there.
+func deleteNode(n *yaml.Node, p []valuesPathElem) (bool, error) {
+	e := p[0]
+	if n.Kind == yaml.AliasNode {
+		return false, errors.New("cannot edit values through an alias")
+	}
+	if n.Kind == yaml.ScalarNode && n.Tag == "!!null" {
+		return false, nil
+	}
+
+	var i int
+	if e.index < 0 {
+		if n.Kind != yaml.MappingNode {
+			return false, fmt.Errorf("the parent of %s is not a map", e)
+		}
+		if i = mappingIndex(n, e.key); i < 0 {
+			return false, nil
+		}
+		if len(p) == 1 {
+			n.Content = append(n.Content[:i-1], n.Content[i+1:]...)
+			return true, nil
+		}
+	} else {
+		if n.Kind != yaml.SequenceNode {
+			return false, fmt.Errorf("the parent of %s is not a list", e)
+		}
+		if e.index >= len(n.Content) {
+			return false, nil
+		}
+		i = e.index
+		if len(p) == 1 {
+			n.Content = append(n.Content[:i], n.Content[i+1:]...)
+			return true, nil
+		}
+	}
+	return deleteNode(n.Content[i], p[1:])
+}
+
+// replaceNode replaces the node old with v in place, so that the aliases of
+// old refer to v. The anchor of old is kept, and so are its comments and its
+// quoting if v has none.
+func replaceNode(old, v *yaml.Node) {
+	if old.Kind == yaml.ScalarNode && old.Tag == "!!str" && v.Kind == yaml.ScalarNode && v.Tag == "!!str" && v.Style == 0 {
+		v.Style = old.Style
+	}
+	if v.HeadComment == "" && v.LineComment == "" && v.FootComment == "" {
+		v.HeadComment, v.LineComment, v.FootComment = old.HeadComment, old.LineComment, old.FootComment
+	}
+	v.Anchor = old.Anchor
+	*old = *v
+}
+
+// mappingIndex returns the index of the value of key in the content of the map
+// node n, or -1 if n has no such key.
+func mappingIndex(n *yaml.Node, key string) int {
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return i + 1
+		}
+	}
+	return -1
+}
+
+func mapNode() *yaml.Node {
+	return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+}
+
+func nullNode() *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null"}
+}
@@ -0,0 +1,192 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+const testValuesDocument = `# The defaults of the ship
+defaults: &defaults
+  speed: 10 # knots
+ship:
+  # The name of the ship
+  name: "Pequod"
+  <<: *defaults
+crew:
+  - ahab
+`
+
+func TestValuesDocumentRoundTrip(t *testing.T) {
+	d, err := ReadValuesDocument([]byte(testValuesDocument))
+	if err != nil {
+		t.Fatal(err)
+	}
+	y, err := d.YAML()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if y != testValuesDocument {
+		t.Errorf("Expected\n%s\ngot\n%s", testValuesDocument, y)
+	}
+}
+
+func TestValuesDocumentMerge(t *testing.T) {
+	d, err := ReadValuesDocument([]byte(testValuesDocument))
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, err := ReadValuesDocument([]byte("ship:\n  name: Rachel\n  # The captain of the ship\n  captain: gardiner\ncrew: [starbuck]\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.Merge(src)
+	if err := d.MergeValues(Values{"defaults": map[string]interface{}{"speed": 12}}); err != nil {
+		t.Fatal(err)
+	}
+
+	y, err := d.YAML()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expect := range []string{
+		"# The defaults of the ship",
+		"defaults: &defaults",
+		"speed: 12 # knots",
+		"# The name of the ship",
+		`name: "Rachel"`,
+		"<<: *defaults",
+		"# The captain of the ship",
+	} {
+		if !strings.Contains(y, expect) {
+			t.Errorf("Expected %q in\n%s", expect, y)
+		}
+	}
+
+	vals, err := d.Values()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s, err := vals.PathValue("ship.captain"); err != nil || s != "gardiner" {
+		t.Errorf("Expected the captain gardiner, got %v (%v)", s, err)
+	}
+	if s, err := vals.PathValue("ship.speed"); err != nil || fmt.Sprint(s) != "12" {
+		t.Errorf("Expected the merged speed 12, got %v (%v)", s, err)
+	}
+}
+
+func TestValuesDocumentEdit(t *testing.T) {
+	d, err := ReadValuesDocument([]byte(testValuesDocument))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Set("crew[1]", "starbuck"); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Set(`annotations.ship\.name`, "Pequod"); err != nil {
+		t.Fatal(err)
+	}
+	if deleted, err := d.Delete("ship.name"); err != nil || !deleted {
+		t.Fatalf("Expected ship.name to be deleted, got %v (%v)", deleted, err)
+	}
+	if deleted, err := d.Delete("ship.flag"); err != nil || deleted {
+		t.Fatalf("Expected ship.flag not to be deleted, got %v (%v)", deleted, err)
+	}
+
+	for path, expect := range map[string]interface{}{
+		"crew[1]":                "starbuck",
+		`annotations.ship\.name`: "Pequod",
+		"defaults.speed":         10,
+	} {
+		if v, ok, err := d.Lookup(path); err != nil || !ok || v != expect {
+			t.Errorf("Expected %s to be %v, got %v (%v)", path, expect, v, err)
+		}
+	}
+	if _, ok, _ := d.Lookup("ship.name"); ok {
+		t.Error("Expected ship.name to be deleted")
+	}
+	if y, _ := d.YAML(); strings.Contains(y, "# The name of the ship") {
+		t.Errorf("Expected the comment of ship.name to be deleted, got\n%s", y)
+	}
+
+	for path, expect := range map[string]string{
+		"ship..name":         "empty key",
+		"crew[0]name":        "key after an index",
+		"crew[x]":            `invalid index "x"`,
+		"crew[3]":            "the index 3 is out of range",
+		"defaults.speed.max": "the parent of max is not a map",
+		"ship.<<.speed":      "cannot edit values through an alias",
+	} {
+		if err := d.Set(path, 1); err == nil || !strings.Contains(err.Error(), expect) {
+			t.Errorf("Expected setting %s to fail with %q, got %v", path, expect, err)
+		}
+	}
+}
+
+func TestReadValuesDocumentErrors(t *testing.T) {
+	if _, err := ReadValuesDocument([]byte("- ahab\n")); err == nil {
+		t.Error("Expected a list to be rejected")
+	}
+	_, err := ReadValuesDocument([]byte("poet: Coleridge\ntitle: Rime\n  stanza: 1\n"))
+	if e, ok := err.(*ErrValuesUnparsable); !ok || e.Line != 3 {
+		t.Errorf("Expected an unparsable values error on line 3, got %#v", err)
+	}
+	if d, err := ReadValuesDocument(nil); err != nil {
+		t.Fatal(err)
+	} else if vals, err := d.Values(); err != nil || len(vals) != 0 {
+		t.Errorf("Expected empty values, got %v (%v)", vals, err)
+	}
+}
+
+func TestValuesDocumentMoveToFront(t *testing.T) {
+	d, err := ReadValuesDocument([]byte("name: pequod\n# The version\nversion: 1.0.0\napiVersion: v1\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !d.MoveToFront("apiVersion", "icon") {
+		t.Error("Expected the document to change")
+	}
+	if d.MoveToFront("apiVersion") {
+		t.Error("Expected the document not to change")
+	}
+	expect := "apiVersion: v1\nname: pequod\n# The version\nversion: 1.0.0\n"
+	if y, _ := d.YAML(); y != expect {
+		t.Errorf("Expected\n%s\ngot\n%s", expect, y)
+	}
+}
+
+func TestValuesDocumentIndentedBy(t *testing.T) {
+	for _, tt := range []struct {
+		doc    string
+		expect bool
+	}{
+		{testValuesDocument, true},
+		{"ship:\n    name: Pequod\n", false},
+		{"crew:\n- name: ahab\n  ship:\n     name: Pequod\n", false},
+		{"crew:\n- name: ahab\n  ship: {name: Pequod}\n", true},
+	} {
+		d, err := ReadValuesDocument([]byte(tt.doc))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d.IndentedBy(2) != tt.expect {
+			t.Errorf("Expected IndentedBy(2) of %q to be %t", tt.doc, tt.expect)
+		}
+	}
+}
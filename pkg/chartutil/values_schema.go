@@ -0,0 +1,115 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"cuelang.org/go/cue"
+	cueerrors "cuelang.org/go/cue/errors"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+// ValuesSchemaFile is the name of the optional CUE schema of the values of a
+// chart.
+const ValuesSchemaFile = "values.cue"
+
+// ValidateValuesSchema validates the coalesced values vals of the chart chrt,
+// and the values of its subcharts, against the values.cue schemas of the
+// charts. It returns the values with the defaults of the schemas applied.
+//
+// The values of a chart without a schema are returned unchanged.
+func ValidateValuesSchema(chrt *chart.Chart, vals Values) (Values, error) {
+	for _, dep := range chrt.Dependencies {
+		name := dep.GetMetadata().GetName()
+		sub, ok := asValues(vals[name])
+		if !ok {
+			continue
+		}
+		sub, err := ValidateValuesSchema(dep, sub)
+		if err != nil {
+			return vals, err
+		}
+		vals[name] = sub
+	}
+
+	schema := valuesSchema(chrt)
+	if schema == nil {
+		return vals, nil
+	}
+	v, err := ValidateValuesAgainstSchema(schema, vals)
+	if err != nil {
+		return vals, fmt.Errorf("values don't meet the specifications of the schema of chart %s:\n%s", chrt.GetMetadata().GetName(), err)
+	}
+	return v, nil
+}
+
+// valuesSchema returns the values.cue file of the chart, or nil.
+func valuesSchema(chrt *chart.Chart) []byte {
+	for _, f := range chrt.GetFiles() {
+		if f.TypeUrl == ValuesSchemaFile {
+			return f.Value
+		}
+	}
+	return nil
+}
+
+// ValidateValuesAgainstSchema unifies the values vals with the CUE schema
+// schema, and returns the resulting values, with the defaults of the schema
+// applied. The error lists every constraint the values don't meet, with its
+// position in the schema.
+//
+// The fields of the schema are the top level values, e.g.
+//
+//	replicas: int & >0 | *1
+//	image: {
+//		repository: string
+//		pullPolicy: *"IfNotPresent" | "Always" | "Never"
+//	}
+func ValidateValuesAgainstSchema(schema []byte, vals Values) (Values, error) {
+	var r cue.Runtime
+	s, err := r.Compile(ValuesSchemaFile, schema)
+	if err != nil {
+		return vals, cueError(err)
+	}
+	b, err := json.Marshal(vals)
+	if err != nil {
+		return vals, err
+	}
+	v, err := r.Compile("values", b)
+	if err != nil {
+		return vals, cueError(err)
+	}
+
+	u := s.Value().Unify(v.Value())
+	if err := u.Validate(cue.Concrete(true)); err != nil {
+		return vals, cueError(err)
+	}
+	out, err := u.MarshalJSON()
+	if err != nil {
+		return vals, cueError(err)
+	}
+	return ReadValues(out)
+}
+
+// cueError returns the errors of err one per line, with their positions.
+func cueError(err error) error {
+	return fmt.Errorf("%s", strings.TrimSpace(cueerrors.Details(err, nil)))
+}
@@ -0,0 +1,111 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/any"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+const testValuesSchema = `
+replicas: int & >0
+image: {
+	repository: string
+	pullPolicy: *"IfNotPresent" | "Always" | "Never"
+}
+`
+
+func TestValidateValuesAgainstSchema(t *testing.T) {
+	vals, err := ReadValues([]byte("replicas: 2\nimage:\n  repository: nginx\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := ValidateValuesAgainstSchema([]byte(testValuesSchema), vals)
+	if err != nil {
+		t.Fatal(err)
+	}
+	y, _ := out.YAML()
+	if expect := "image:\n  pullPolicy: IfNotPresent\n  repository: nginx\nreplicas: 2\n"; y != expect {
+		t.Errorf("Expected the defaults of the schema to be applied:\n%s\ngot\n%s", expect, y)
+	}
+}
+
+func TestValidateValuesAgainstSchemaErrors(t *testing.T) {
+	for _, tt := range []struct {
+		values string
+		expect string
+	}{
+		{"replicas: 0\nimage:\n  repository: nginx\n", "replicas"},
+		{"replicas: 1\nimage:\n  repository: nginx\n  pullPolicy: Sometimes\n", "pullPolicy"},
+		{"replicas: 1\n", "repository"},
+		{"replicas: one\nimage:\n  repository: nginx\n", "replicas"},
+	} {
+		vals, err := ReadValues([]byte(tt.values))
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = ValidateValuesAgainstSchema([]byte(testValuesSchema), vals)
+		if err == nil || !strings.Contains(err.Error(), tt.expect) {
+			t.Errorf("Expected %q to fail on %s, got %v", tt.values, tt.expect, err)
+		}
+	}
+
+	if _, err := ValidateValuesAgainstSchema([]byte("replicas: int &"), Values{}); err == nil {
+		t.Error("Expected an invalid schema to fail")
+	}
+}
+
+func TestValidateValuesSchema(t *testing.T) {
+	schema := &any.Any{TypeUrl: ValuesSchemaFile, Value: []byte(testValuesSchema)}
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "parent"},
+		Values:   &chart.Config{Raw: "replicas: 1\nimage:\n  repository: nginx\n"},
+		Files:    []*any.Any{schema},
+		Dependencies: []*chart.Chart{{
+			Metadata: &chart.Metadata{Name: "child"},
+			Values:   &chart.Config{Raw: "replicas: 1\nimage:\n  repository: redis\n"},
+			Files:    []*any.Any{schema},
+		}},
+	}
+
+	vals, err := CoalesceValues(c, &chart.Config{Raw: "child:\n  image:\n    pullPolicy: Always\n"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	vals, err = ValidateValuesSchema(c, vals)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p, _ := vals.PathValue("image.pullPolicy"); p != "IfNotPresent" {
+		t.Errorf("Expected the default pull policy of the parent, got %v", p)
+	}
+	if p, _ := vals.PathValue("child.image.pullPolicy"); p != "Always" {
+		t.Errorf("Expected the pull policy of the child, got %v", p)
+	}
+
+	vals, err = CoalesceValues(c, &chart.Config{Raw: "child:\n  replicas: 0\n"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ValidateValuesSchema(c, vals); err == nil || !strings.Contains(err.Error(), "schema of chart child") {
+		t.Errorf("Expected the values of the child to fail its schema, got %v", err)
+	}
+}
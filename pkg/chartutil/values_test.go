@@ -18,8 +18,12 @@ package chartutil
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/ioutil"
+	"os"
 	"reflect"
 	"strings"
 	"testing"
@@ -182,6 +186,28 @@ func TestReadValuesFile(t *testing.T) {
 	matchValues(t, data)
 }
 
+func TestReadValuesFileUnparsable(t *testing.T) {
+	tmp, err := ioutil.TempFile("", "helm-values-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(tmp.Name())
+	tmp.WriteString("poet: Coleridge\ntitle: Rime\n  stanza: 1\n")
+	tmp.Close()
+
+	_, err = ReadValuesFile(tmp.Name())
+	var valuesErr *ErrValuesUnparsable
+	if !errors.As(err, &valuesErr) {
+		t.Fatalf("Expected an ErrValuesUnparsable, got %#v", err)
+	}
+	if valuesErr.File != tmp.Name() || valuesErr.Line != 3 {
+		t.Errorf("Expected the error at %s:3, got %s:%d", tmp.Name(), valuesErr.File, valuesErr.Line)
+	}
+	if valuesErr.Error() != valuesErr.Err.Error() {
+		t.Errorf("Expected the message of the parser, got %q", valuesErr.Error())
+	}
+}
+
 func ExampleValues() {
 	doc := `
 title: "Moby Dick"
@@ -329,6 +355,18 @@ web:
     timeoutSeconds: null # catches the case where this wasn't defined in the original source...
 `
 
+func TestCoalesceValuesWithContext(t *testing.T) {
+	c, err := LoadDir("testdata/moby")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := CoalesceValuesWithContext(ctx, c, &chart.Config{Raw: testCoalesceValuesYaml}); err != context.Canceled {
+		t.Errorf("Expected the coalescing to be canceled, got %v", err)
+	}
+}
+
 func TestCoalesceValues(t *testing.T) {
 	tchart := "testdata/moby"
 	c, err := LoadDir(tchart)
@@ -675,3 +713,30 @@ func TestSubchartCoaleseWithNullValue(t *testing.T) {
 		t.Errorf("got %+v, expected %+v", result, expected)
 	}
 }
+
+func TestCoalesceSubchartCollision(t *testing.T) {
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "demo"},
+		Dependencies: []*chart.Chart{
+			{
+				Metadata: &chart.Metadata{Name: "redis"},
+				Values:   &chart.Config{Raw: `port: 6379`},
+			},
+		},
+	}
+
+	_, err := CoalesceValues(c, &chart.Config{Raw: `redis: redis.example.com`})
+	expect := "redis: redis.example.com is not a table, but redis is a subchart of chart demo"
+	if err == nil || !strings.HasPrefix(err.Error(), expect) {
+		t.Errorf("Expected an error starting with %q, got %v", expect, err)
+	}
+
+	// A null value leaves the default values of the subchart.
+	v, err := CoalesceValues(c, &chart.Config{Raw: `redis: null`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if port, err := v.PathValue("redis.port"); err != nil || port != json.Number("6379") {
+		t.Errorf("Expected the default port of the subchart, got %v (%v)", port, err)
+	}
+}
@@ -0,0 +1,75 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials // import "k8s.io/helm/pkg/credentials"
+
+import "sync"
+
+// cachedCredentials are the result of a Get of a store.
+type cachedCredentials struct {
+	username string
+	secret   string
+	err      error
+}
+
+// CachingStore is a Store keeping the credentials it reads from another
+// store in memory, so that a helper program is run once per server.
+type CachingStore struct {
+	store Store
+
+	mu    sync.Mutex
+	cache map[string]cachedCredentials
+}
+
+var _ Store = (*CachingStore)(nil)
+
+// NewCachingStore returns a store caching the credentials of store.
+func NewCachingStore(store Store) *CachingStore {
+	return &CachingStore{store: store, cache: map[string]cachedCredentials{}}
+}
+
+// Get returns the credentials for serverURL, reading them from the
+// underlying store the first time only. ErrNotFound is cached too.
+func (c *CachingStore) Get(serverURL string) (string, string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cc, ok := c.cache[serverURL]; ok {
+		return cc.username, cc.secret, cc.err
+	}
+	username, secret, err := c.store.Get(serverURL)
+	if err != nil && err != ErrNotFound {
+		// Do not cache the failures of the helper.
+		return "", "", err
+	}
+	c.cache[serverURL] = cachedCredentials{username: username, secret: secret, err: err}
+	return username, secret, err
+}
+
+// Store saves the credentials for serverURL in the underlying store.
+func (c *CachingStore) Store(serverURL, username, secret string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.cache, serverURL)
+	return c.store.Store(serverURL, username, secret)
+}
+
+// Erase removes the credentials for serverURL from the underlying store.
+func (c *CachingStore) Erase(serverURL string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.cache, serverURL)
+	return c.store.Erase(serverURL)
+}
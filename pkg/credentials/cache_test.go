@@ -0,0 +1,53 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import "testing"
+
+func TestCachingStore(t *testing.T) {
+	store := &mapStore{creds: map[string][2]string{}}
+	c := NewCachingStore(store)
+	const url = "https://charts.example.com"
+
+	for i := 0; i < 2; i++ {
+		if _, _, err := c.Get(url); err != ErrNotFound {
+			t.Fatalf("expected ErrNotFound, got %v", err)
+		}
+	}
+	if store.gets != 1 {
+		t.Errorf("expected the store to be read once, got %d", store.gets)
+	}
+
+	if err := c.Store(url, "user", "s3cr3t"); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if user, secret, err := c.Get(url); err != nil || user != "user" || secret != "s3cr3t" {
+			t.Fatalf("expected user/s3cr3t, got %s/%s (%v)", user, secret, err)
+		}
+	}
+	if store.gets != 2 {
+		t.Errorf("expected the store to be read again once after a change, got %d", store.gets)
+	}
+
+	if err := c.Erase(url); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := c.Get(url); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound after erase, got %v", err)
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials // import "k8s.io/helm/pkg/credentials"
+
+import (
+	"errors"
+	"net/url"
+	"path"
+	"runtime"
+	"strings"
+)
+
+// ErrNotFound indicates that a store holds no credentials for a server.
+var ErrNotFound = errors.New("credentials not found")
+
+// Store is the interface implemented by credential stores.
+//
+// Credentials are keyed by the URL of the server they authenticate against.
+type Store interface {
+	// Get returns the credentials for serverURL, or ErrNotFound.
+	Get(serverURL string) (username, secret string, err error)
+	// Store saves the credentials for serverURL, replacing existing ones.
+	Store(serverURL, username, secret string) error
+	// Erase removes the credentials for serverURL.
+	Erase(serverURL string) error
+}
+
+// NativeStore returns the name of the credential helper backed by the
+// keychain of the current operating system.
+func NativeStore() string {
+	switch runtime.GOOS {
+	case "darwin":
+		return "osxkeychain"
+	case "windows":
+		return "wincred"
+	default:
+		return "secretservice"
+	}
+}
+
+// Lookup returns the credentials for serverURL from the store. Credentials
+// are scoped by host: when none are stored for serverURL itself, the
+// credentials of its parent paths are used, then the ones stored for its
+// host, e.g. by "docker login". It returns ErrNotFound if there are none.
+func Lookup(store Store, serverURL string) (username, secret string, err error) {
+	for _, u := range scopes(serverURL) {
+		username, secret, err = store.Get(u)
+		if err != ErrNotFound {
+			return username, secret, err
+		}
+	}
+	return "", "", ErrNotFound
+}
+
+// scopes returns serverURL, its parent paths and its host, from the most to
+// the least specific.
+func scopes(serverURL string) []string {
+	out := []string{serverURL}
+	u, err := url.Parse(serverURL)
+	if err != nil || u.Host == "" {
+		return out
+	}
+	p := strings.TrimSuffix(u.Path, "/")
+	for {
+		v := url.URL{Scheme: u.Scheme, Host: u.Host, Path: p}
+		if s := v.String(); s != out[len(out)-1] {
+			out = append(out, s)
+		}
+		if p == "" || p == "/" {
+			break
+		}
+		p = path.Dir(p)
+		if p == "/" {
+			p = ""
+		}
+	}
+	return append(out, u.Host)
+}
@@ -0,0 +1,93 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"reflect"
+	"testing"
+)
+
+// mapStore is a Store in memory counting the calls to Get.
+type mapStore struct {
+	creds map[string][2]string
+	gets  int
+}
+
+func (s *mapStore) Get(serverURL string) (string, string, error) {
+	s.gets++
+	c, ok := s.creds[serverURL]
+	if !ok {
+		return "", "", ErrNotFound
+	}
+	return c[0], c[1], nil
+}
+
+func (s *mapStore) Store(serverURL, username, secret string) error {
+	s.creds[serverURL] = [2]string{username, secret}
+	return nil
+}
+
+func (s *mapStore) Erase(serverURL string) error {
+	delete(s.creds, serverURL)
+	return nil
+}
+
+func TestScopes(t *testing.T) {
+	tests := map[string][]string{
+		"https://charts.example.com/org/stable/": {
+			"https://charts.example.com/org/stable/",
+			"https://charts.example.com/org/stable",
+			"https://charts.example.com/org",
+			"https://charts.example.com",
+			"charts.example.com",
+		},
+		"https://charts.example.com": {"https://charts.example.com", "charts.example.com"},
+		"registry.example.com":       {"registry.example.com"},
+	}
+	for in, expect := range tests {
+		if got := scopes(in); !reflect.DeepEqual(got, expect) {
+			t.Errorf("%s: expected %v, got %v", in, expect, got)
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	store := &mapStore{creds: map[string][2]string{
+		"https://charts.example.com/private": {"private", "s3cr3t"},
+		"charts.example.com":                 {"host", "hunter2"},
+	}}
+
+	tests := []struct {
+		url, username string
+	}{
+		{"https://charts.example.com/private/incubator", "private"},
+		{"https://charts.example.com/private", "private"},
+		{"https://charts.example.com/public", "host"},
+	}
+	for _, tt := range tests {
+		username, _, err := Lookup(store, tt.url)
+		if err != nil {
+			t.Errorf("%s: %s", tt.url, err)
+		} else if username != tt.username {
+			t.Errorf("%s: expected the credentials of %s, got %s", tt.url, tt.username, username)
+		}
+	}
+
+	if _, _, err := Lookup(store, "https://other.example.com"); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,29 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*Package credentials stores chart repository credentials outside of the
+repositories file.
+
+Credentials are kept by external helper programs that speak the
+docker-credential-helpers protocol, such as docker-credential-osxkeychain or
+docker-credential-wincred, so that the operating system keychain can be used
+instead of plaintext files in $HELM_HOME.
+
+Credentials are keyed by server URL, so the same stores serve chart
+repositories and registries. Lookup falls back to the credentials of the host
+of a URL, and CachingStore runs a helper once per server.
+*/
+package credentials // import "k8s.io/helm/pkg/credentials"
@@ -0,0 +1,113 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials // import "k8s.io/helm/pkg/credentials"
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+)
+
+// HelperPrefix is prepended to the name of a store to find its helper program.
+const HelperPrefix = "docker-credential-"
+
+// errCredentialsNotFoundMessage is printed by helpers when no credentials are stored.
+const errCredentialsNotFoundMessage = "credentials not found in native keychain"
+
+// helperCredentials is the payload exchanged with a credential helper.
+type helperCredentials struct {
+	ServerURL string
+	Username  string
+	Secret    string
+}
+
+// HelperStore is a Store backed by a docker-credential-helpers compatible program.
+type HelperStore struct {
+	// Program is the name or path of the helper executable.
+	Program string
+}
+
+var _ Store = (*HelperStore)(nil)
+
+// NewHelperStore returns a store using the helper named "docker-credential-<name>".
+func NewHelperStore(name string) *HelperStore {
+	return &HelperStore{Program: HelperPrefix + name}
+}
+
+// Available reports whether the helper program can be found.
+func (h *HelperStore) Available() error {
+	if _, err := exec.LookPath(h.Program); err != nil {
+		return fmt.Errorf("credential helper %q not found: %s", h.Program, err)
+	}
+	return nil
+}
+
+// Get returns the credentials for serverURL.
+func (h *HelperStore) Get(serverURL string) (string, string, error) {
+	out, err := h.run("get", strings.NewReader(serverURL))
+	if err != nil {
+		if strings.Contains(err.Error(), errCredentialsNotFoundMessage) {
+			return "", "", ErrNotFound
+		}
+		return "", "", err
+	}
+
+	var c helperCredentials
+	if err := json.Unmarshal(out, &c); err != nil {
+		return "", "", fmt.Errorf("cannot decode output of %s: %s", h.Program, err)
+	}
+	return c.Username, c.Secret, nil
+}
+
+// Store saves the credentials for serverURL.
+func (h *HelperStore) Store(serverURL, username, secret string) error {
+	b, err := json.Marshal(helperCredentials{ServerURL: serverURL, Username: username, Secret: secret})
+	if err != nil {
+		return err
+	}
+	_, err = h.run("store", bytes.NewReader(b))
+	return err
+}
+
+// Erase removes the credentials for serverURL.
+func (h *HelperStore) Erase(serverURL string) error {
+	_, err := h.run("erase", strings.NewReader(serverURL))
+	if err != nil && strings.Contains(err.Error(), errCredentialsNotFoundMessage) {
+		return ErrNotFound
+	}
+	return err
+}
+
+func (h *HelperStore) run(action string, stdin io.Reader) ([]byte, error) {
+	cmd := exec.Command(h.Program, action)
+	cmd.Stdin = stdin
+	out, err := cmd.Output()
+	if err != nil {
+		msg := strings.TrimSpace(string(out))
+		if ee, ok := err.(*exec.ExitError); ok && msg == "" {
+			msg = strings.TrimSpace(string(ee.Stderr))
+		}
+		if msg == "" {
+			msg = err.Error()
+		}
+		return nil, fmt.Errorf("%s %s: %s", h.Program, action, msg)
+	}
+	return out, nil
+}
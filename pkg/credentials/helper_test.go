@@ -0,0 +1,74 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestHelperStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "helm-credentials-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	old := os.Getenv("HELM_TEST_CREDENTIALS")
+	defer os.Setenv("HELM_TEST_CREDENTIALS", old)
+	os.Setenv("HELM_TEST_CREDENTIALS", filepath.Join(dir, "creds"))
+
+	store := &HelperStore{Program: "testdata/docker-credential-test"}
+	if err := store.Available(); err != nil {
+		t.Fatal(err)
+	}
+
+	const url = "https://charts.example.com"
+	if _, _, err := store.Get(url); err != ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	if err := store.Store(url, "user", "s3cr3t"); err != nil {
+		t.Fatal(err)
+	}
+	user, secret, err := store.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user != "user" || secret != "s3cr3t" {
+		t.Errorf("expected user/s3cr3t, got %s/%s", user, secret)
+	}
+
+	if err := store.Erase(url); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := store.Get(url); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound after erase, got %v", err)
+	}
+}
+
+func TestHelperStoreMissing(t *testing.T) {
+	store := NewHelperStore("does-not-exist")
+	if store.Program != "docker-credential-does-not-exist" {
+		t.Errorf("unexpected program %q", store.Program)
+	}
+	if err := store.Available(); err == nil {
+		t.Error("expected missing helper to be reported")
+	}
+}
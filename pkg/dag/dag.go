@@ -0,0 +1,246 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package dag orders and schedules named nodes that need each other, like the
+releases of a multi-release spec or the subcharts of a chart.
+*/
+package dag // import "k8s.io/helm/pkg/dag"
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Graph is a directed graph of nodes, each needing other nodes. The nodes
+// keep the order they are added in.
+type Graph struct {
+	names []string
+	needs map[string][]string
+}
+
+// New returns an empty graph.
+func New() *Graph {
+	return &Graph{needs: map[string][]string{}}
+}
+
+// Add adds the node name, which needs the nodes needs.
+func (g *Graph) Add(name string, needs ...string) {
+	if _, ok := g.needs[name]; !ok {
+		g.names = append(g.names, name)
+	}
+	g.needs[name] = append(g.needs[name], needs...)
+}
+
+// Nodes returns the names of the nodes.
+func (g *Graph) Nodes() []string {
+	return g.names
+}
+
+// Needs returns the nodes needed by the node name.
+func (g *Graph) Needs(name string) []string {
+	return g.needs[name]
+}
+
+// Reverse returns the graph where every node needs the nodes that needed it.
+func (g *Graph) Reverse() *Graph {
+	r := New()
+	for _, n := range g.names {
+		r.Add(n)
+	}
+	for _, n := range g.names {
+		for _, d := range g.needs[n] {
+			r.Add(d, n)
+		}
+	}
+	return r
+}
+
+// CycleError is returned when the needs of nodes form a cycle.
+type CycleError struct {
+	// Path is the cycle, from a node back to itself.
+	Path []string
+}
+
+func (e *CycleError) Error() string {
+	return "dependency cycle: " + strings.Join(e.Path, " -> ")
+}
+
+// SkippedError is the error of a node not run, as a node it needs failed or
+// was skipped.
+type SkippedError struct {
+	Need string
+}
+
+func (e *SkippedError) Error() string {
+	return fmt.Sprintf("skipped, as %s failed", e.Need)
+}
+
+// Validate checks that the needed nodes are in the graph, and that the needs
+// don't form a cycle.
+func (g *Graph) Validate() error {
+	for _, n := range g.names {
+		for _, d := range g.needs[n] {
+			if _, ok := g.needs[d]; !ok {
+				return fmt.Errorf("%s needs %s, which does not exist", n, d)
+			}
+		}
+	}
+
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := map[string]int{}
+	var stack []string
+	var visit func(n string) error
+	visit = func(n string) error {
+		state[n] = visiting
+		stack = append(stack, n)
+		for _, d := range g.needs[n] {
+			switch state[d] {
+			case visiting:
+				for i, s := range stack {
+					if s == d {
+						path := append(append([]string{}, stack[i:]...), d)
+						return &CycleError{Path: path}
+					}
+				}
+			case 0:
+				if err := visit(d); err != nil {
+					return err
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[n] = visited
+		return nil
+	}
+	for _, n := range g.names {
+		if state[n] == 0 {
+			if err := visit(n); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Waves returns the nodes in waves: every node of a wave only needs nodes of
+// the previous waves. The nodes of a wave keep the order of the graph.
+func (g *Graph) Waves() ([][]string, error) {
+	if err := g.Validate(); err != nil {
+		return nil, err
+	}
+	done := map[string]bool{}
+	pending := g.names
+	var waves [][]string
+	for len(pending) > 0 {
+		var wave, rest []string
+		for _, n := range pending {
+			if g.needsDone(n, done) {
+				wave = append(wave, n)
+			} else {
+				rest = append(rest, n)
+			}
+		}
+		for _, n := range wave {
+			done[n] = true
+		}
+		waves = append(waves, wave)
+		pending = rest
+	}
+	return waves, nil
+}
+
+func (g *Graph) needsDone(n string, done map[string]bool) bool {
+	for _, d := range g.needs[n] {
+		if !done[d] {
+			return false
+		}
+	}
+	return true
+}
+
+// Run calls fn for every node, as soon as the nodes it needs succeeded, with
+// at most concurrency calls at once. Zero means no limit. The nodes needing a
+// node that failed are not run, their error is a *SkippedError.
+//
+// Run returns the errors of the nodes that failed or were skipped.
+func (g *Graph) Run(concurrency int, fn func(name string) error) (map[string]error, error) {
+	if err := g.Validate(); err != nil {
+		return nil, err
+	}
+
+	dependents := g.Reverse()
+	pending := map[string]int{}
+	var ready []string
+	for _, n := range g.names {
+		pending[n] = len(g.needs[n])
+		if pending[n] == 0 {
+			ready = append(ready, n)
+		}
+	}
+
+	type result struct {
+		name string
+		err  error
+	}
+	results := make(chan result)
+	errs := map[string]error{}
+	var running, finished int
+
+	var skip func(n, need string)
+	skip = func(n, need string) {
+		if _, ok := errs[n]; ok {
+			return
+		}
+		errs[n] = &SkippedError{Need: need}
+		finished++
+		for _, d := range dependents.needs[n] {
+			skip(d, n)
+		}
+	}
+
+	for finished < len(g.names) {
+		for len(ready) > 0 && (concurrency <= 0 || running < concurrency) {
+			n := ready[0]
+			ready = ready[1:]
+			running++
+			go func(n string) {
+				results <- result{n, fn(n)}
+			}(n)
+		}
+
+		res := <-results
+		running--
+		finished++
+		if res.err != nil {
+			errs[res.name] = res.err
+			for _, d := range dependents.needs[res.name] {
+				skip(d, res.name)
+			}
+			continue
+		}
+		for _, d := range dependents.needs[res.name] {
+			pending[d]--
+			if _, skipped := errs[d]; !skipped && pending[d] == 0 {
+				ready = append(ready, d)
+			}
+		}
+	}
+	return errs, nil
+}
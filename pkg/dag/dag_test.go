@@ -0,0 +1,138 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dag
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func graph() *Graph {
+	g := New()
+	g.Add("database")
+	g.Add("cache")
+	g.Add("web", "database", "cache")
+	g.Add("worker", "database")
+	g.Add("ingress", "web")
+	return g
+}
+
+func TestWaves(t *testing.T) {
+	waves, err := graph().Waves()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := [][]string{{"database", "cache"}, {"web", "worker"}, {"ingress"}}
+	if !reflect.DeepEqual(waves, expect) {
+		t.Errorf("Expected the waves %v, got %v", expect, waves)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	g := New()
+	g.Add("a", "b")
+	g.Add("b", "c")
+	g.Add("c", "a")
+	g.Add("d")
+	err := g.Validate()
+	if _, ok := err.(*CycleError); !ok || err.Error() != "dependency cycle: a -> b -> c -> a" {
+		t.Errorf("Expected a cycle error, got %v", err)
+	}
+
+	g = New()
+	g.Add("a", "a")
+	if err := g.Validate(); err == nil || err.Error() != "dependency cycle: a -> a" {
+		t.Errorf("Expected a cycle error, got %v", err)
+	}
+
+	g = New()
+	g.Add("a", "b")
+	if err := g.Validate(); err == nil || err.Error() != "a needs b, which does not exist" {
+		t.Errorf("Expected a missing node error, got %v", err)
+	}
+}
+
+func TestReverse(t *testing.T) {
+	r := graph().Reverse()
+	if expect := []string{"web", "worker"}; !reflect.DeepEqual(r.Needs("database"), expect) {
+		t.Errorf("Expected database to need %v, got %v", expect, r.Needs("database"))
+	}
+	if len(r.Needs("ingress")) != 0 {
+		t.Errorf("Expected ingress to need nothing, got %v", r.Needs("ingress"))
+	}
+}
+
+func TestRun(t *testing.T) {
+	g := graph()
+	var mu sync.Mutex
+	var order []string
+	errs, err := g.Run(2, func(name string) error {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, n := range g.Needs(name) {
+			if !contains(order, n) {
+				t.Errorf("%s ran before %s, which it needs", name, n)
+			}
+		}
+		order = append(order, name)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(errs) != 0 {
+		t.Errorf("Expected no errors, got %v", errs)
+	}
+	if len(order) != 5 {
+		t.Errorf("Expected every node to run, got %v", order)
+	}
+}
+
+func TestRunSkipsDependents(t *testing.T) {
+	errs, err := graph().Run(0, func(name string) error {
+		if name == "cache" {
+			return errors.New("failed")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(errs) != 3 {
+		t.Errorf("Expected 3 errors, got %v", errs)
+	}
+	if errs["cache"].Error() != "failed" {
+		t.Errorf("Expected cache to fail, got %v", errs["cache"])
+	}
+	if err, ok := errs["web"].(*SkippedError); !ok || err.Need != "cache" {
+		t.Errorf("Expected web to be skipped, got %v", errs["web"])
+	}
+	if err, ok := errs["ingress"].(*SkippedError); !ok || err.Need != "web" {
+		t.Errorf("Expected ingress to be skipped, got %v", errs["ingress"])
+	}
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
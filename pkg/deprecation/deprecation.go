@@ -0,0 +1,174 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package deprecation finds the manifests using Kubernetes APIs that are
+deprecated or removed in a Kubernetes version.
+*/
+package deprecation // import "k8s.io/helm/pkg/deprecation"
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver"
+	"github.com/ghodss/yaml"
+
+	"k8s.io/helm/pkg/releaseutil"
+)
+
+// API is the API version of a kind that is deprecated, and removed in a later
+// Kubernetes version.
+type API struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	// DeprecatedIn is the Kubernetes version the API is deprecated in.
+	DeprecatedIn string `json:"deprecatedIn"`
+	// RemovedIn is the Kubernetes version the API is removed in.
+	RemovedIn string `json:"removedIn"`
+	// Replacement is the API version to use instead.
+	Replacement string `json:"replacement"`
+}
+
+// APIs are the deprecated APIs, with the Kubernetes versions they are
+// deprecated and removed in.
+var APIs = []API{
+	{APIVersion: "extensions/v1beta1", Kind: "DaemonSet", DeprecatedIn: "1.9", RemovedIn: "1.16", Replacement: "apps/v1"},
+	{APIVersion: "extensions/v1beta1", Kind: "Deployment", DeprecatedIn: "1.9", RemovedIn: "1.16", Replacement: "apps/v1"},
+	{APIVersion: "extensions/v1beta1", Kind: "ReplicaSet", DeprecatedIn: "1.9", RemovedIn: "1.16", Replacement: "apps/v1"},
+	{APIVersion: "apps/v1beta1", Kind: "Deployment", DeprecatedIn: "1.9", RemovedIn: "1.16", Replacement: "apps/v1"},
+	{APIVersion: "apps/v1beta1", Kind: "StatefulSet", DeprecatedIn: "1.9", RemovedIn: "1.16", Replacement: "apps/v1"},
+	{APIVersion: "apps/v1beta2", Kind: "DaemonSet", DeprecatedIn: "1.9", RemovedIn: "1.16", Replacement: "apps/v1"},
+	{APIVersion: "apps/v1beta2", Kind: "Deployment", DeprecatedIn: "1.9", RemovedIn: "1.16", Replacement: "apps/v1"},
+	{APIVersion: "apps/v1beta2", Kind: "ReplicaSet", DeprecatedIn: "1.9", RemovedIn: "1.16", Replacement: "apps/v1"},
+	{APIVersion: "apps/v1beta2", Kind: "StatefulSet", DeprecatedIn: "1.9", RemovedIn: "1.16", Replacement: "apps/v1"},
+	{APIVersion: "extensions/v1beta1", Kind: "NetworkPolicy", DeprecatedIn: "1.9", RemovedIn: "1.16", Replacement: "networking.k8s.io/v1"},
+	{APIVersion: "extensions/v1beta1", Kind: "PodSecurityPolicy", DeprecatedIn: "1.10", RemovedIn: "1.16", Replacement: "policy/v1beta1"},
+	{APIVersion: "extensions/v1beta1", Kind: "Ingress", DeprecatedIn: "1.14", RemovedIn: "1.22", Replacement: "networking.k8s.io/v1beta1"},
+	{APIVersion: "apiextensions.k8s.io/v1beta1", Kind: "CustomResourceDefinition", DeprecatedIn: "1.16", RemovedIn: "1.22", Replacement: "apiextensions.k8s.io/v1"},
+	{APIVersion: "admissionregistration.k8s.io/v1beta1", Kind: "MutatingWebhookConfiguration", DeprecatedIn: "1.16", RemovedIn: "1.22", Replacement: "admissionregistration.k8s.io/v1"},
+	{APIVersion: "admissionregistration.k8s.io/v1beta1", Kind: "ValidatingWebhookConfiguration", DeprecatedIn: "1.16", RemovedIn: "1.22", Replacement: "admissionregistration.k8s.io/v1"},
+	{APIVersion: "rbac.authorization.k8s.io/v1alpha1", Kind: "ClusterRole", DeprecatedIn: "1.17", RemovedIn: "1.22", Replacement: "rbac.authorization.k8s.io/v1"},
+	{APIVersion: "rbac.authorization.k8s.io/v1alpha1", Kind: "ClusterRoleBinding", DeprecatedIn: "1.17", RemovedIn: "1.22", Replacement: "rbac.authorization.k8s.io/v1"},
+	{APIVersion: "rbac.authorization.k8s.io/v1alpha1", Kind: "Role", DeprecatedIn: "1.17", RemovedIn: "1.22", Replacement: "rbac.authorization.k8s.io/v1"},
+	{APIVersion: "rbac.authorization.k8s.io/v1alpha1", Kind: "RoleBinding", DeprecatedIn: "1.17", RemovedIn: "1.22", Replacement: "rbac.authorization.k8s.io/v1"},
+	{APIVersion: "rbac.authorization.k8s.io/v1beta1", Kind: "ClusterRole", DeprecatedIn: "1.17", RemovedIn: "1.22", Replacement: "rbac.authorization.k8s.io/v1"},
+	{APIVersion: "rbac.authorization.k8s.io/v1beta1", Kind: "ClusterRoleBinding", DeprecatedIn: "1.17", RemovedIn: "1.22", Replacement: "rbac.authorization.k8s.io/v1"},
+	{APIVersion: "rbac.authorization.k8s.io/v1beta1", Kind: "Role", DeprecatedIn: "1.17", RemovedIn: "1.22", Replacement: "rbac.authorization.k8s.io/v1"},
+	{APIVersion: "rbac.authorization.k8s.io/v1beta1", Kind: "RoleBinding", DeprecatedIn: "1.17", RemovedIn: "1.22", Replacement: "rbac.authorization.k8s.io/v1"},
+}
+
+// Finding is a manifest using a deprecated API.
+type Finding struct {
+	// Source is the template or the release of the manifest.
+	Source string `json:"source"`
+	// Name is the name of the manifest.
+	Name string `json:"name"`
+	API  API    `json:"api"`
+	// Removed is whether the API is removed in the Kubernetes version, or
+	// only deprecated.
+	Removed bool `json:"removed"`
+}
+
+// String returns the finding as e.g. "source (Deployment/web):
+// extensions/v1beta1 is removed in Kubernetes 1.16, use apps/v1 instead".
+func (f Finding) String() string {
+	state := "deprecated in Kubernetes " + f.API.DeprecatedIn
+	if f.Removed {
+		state = "removed in Kubernetes " + f.API.RemovedIn
+	}
+	return fmt.Sprintf("%s (%s/%s): %s is %s, use %s instead", f.Source, f.API.Kind, f.Name, f.API.APIVersion, state, f.API.Replacement)
+}
+
+// Check returns the manifests of the rendered templates using APIs that are
+// deprecated or removed in a Kubernetes version, e.g. "1.16" or "v1.16.2".
+func Check(templates map[string]string, kubeVersion string) ([]Finding, error) {
+	v, err := semver.NewVersion(kubeVersion)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse a kubernetes version: %v", err)
+	}
+
+	names := make([]string, 0, len(templates))
+	for name := range templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var findings []Finding
+	for _, name := range names {
+		base := path.Base(name)
+		if strings.HasPrefix(base, "_") || base == "NOTES.txt" {
+			continue
+		}
+		findings = append(findings, CheckManifest(name, templates[name], v)...)
+	}
+	return findings, nil
+}
+
+// CheckManifest returns the documents of a manifest using APIs that are
+// deprecated or removed in a Kubernetes version. The findings are attributed to
+// source.
+func CheckManifest(source, manifest string, kubeVersion *semver.Version) []Finding {
+	var findings []Finding
+	docs := releaseutil.SplitManifests(manifest)
+	for i := 0; i < len(docs); i++ {
+		var head releaseutil.SimpleHead
+		if err := yaml.Unmarshal([]byte(docs[fmt.Sprintf("manifest-%d", i)]), &head); err != nil {
+			continue
+		}
+		api, ok := lookup(head.Version, head.Kind)
+		if !ok || !atLeast(kubeVersion, api.DeprecatedIn) {
+			continue
+		}
+		f := Finding{Source: source, API: api, Removed: atLeast(kubeVersion, api.RemovedIn)}
+		if head.Metadata != nil {
+			f.Name = head.Metadata.Name
+		}
+		findings = append(findings, f)
+	}
+	return findings
+}
+
+// Removed returns the findings of APIs removed in the Kubernetes version.
+func Removed(findings []Finding) []Finding {
+	var removed []Finding
+	for _, f := range findings {
+		if f.Removed {
+			removed = append(removed, f)
+		}
+	}
+	return removed
+}
+
+func lookup(apiVersion, kind string) (API, bool) {
+	for _, api := range APIs {
+		if api.APIVersion == apiVersion && api.Kind == kind {
+			return api, true
+		}
+	}
+	return API{}, false
+}
+
+// atLeast returns whether v is at least the major and minor version.
+func atLeast(v *semver.Version, version string) bool {
+	min, err := semver.NewVersion(version)
+	if err != nil {
+		return false
+	}
+	return v.Major() > min.Major() || v.Major() == min.Major() && v.Minor() >= min.Minor()
+}
@@ -0,0 +1,83 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deprecation
+
+import (
+	"reflect"
+	"testing"
+)
+
+const manifests = `apiVersion: extensions/v1beta1
+kind: Deployment
+metadata:
+  name: web
+---
+apiVersion: extensions/v1beta1
+kind: Ingress
+metadata:
+  name: web
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+`
+
+func TestCheck(t *testing.T) {
+	templates := map[string]string{
+		"web/templates/web.yaml":     manifests,
+		"web/templates/_helpers.tpl": "apiVersion: extensions/v1beta1\nkind: Deployment",
+	}
+	deployment := API{APIVersion: "extensions/v1beta1", Kind: "Deployment", DeprecatedIn: "1.9", RemovedIn: "1.16", Replacement: "apps/v1"}
+	ingress := API{APIVersion: "extensions/v1beta1", Kind: "Ingress", DeprecatedIn: "1.14", RemovedIn: "1.22", Replacement: "networking.k8s.io/v1beta1"}
+
+	tests := []struct {
+		kubeVersion string
+		expect      []Finding
+	}{
+		{"1.8", nil},
+		{"1.13", []Finding{{Source: "web/templates/web.yaml", Name: "web", API: deployment}}},
+		{"v1.16.2", []Finding{
+			{Source: "web/templates/web.yaml", Name: "web", API: deployment, Removed: true},
+			{Source: "web/templates/web.yaml", Name: "web", API: ingress},
+		}},
+	}
+	for _, tt := range tests {
+		findings, err := Check(templates, tt.kubeVersion)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(findings, tt.expect) {
+			t.Errorf("%s: expected %+v, got %+v", tt.kubeVersion, tt.expect, findings)
+		}
+	}
+
+	findings, _ := Check(templates, "1.16")
+	if removed := Removed(findings); len(removed) != 1 || removed[0].API != deployment {
+		t.Errorf("Expected the deployment to be removed, got %+v", removed)
+	}
+	if s := findings[0].String(); s != "web/templates/web.yaml (Deployment/web): extensions/v1beta1 is removed in Kubernetes 1.16, use apps/v1 instead" {
+		t.Errorf("Unexpected finding %q", s)
+	}
+	if s := findings[1].String(); s != "web/templates/web.yaml (Ingress/web): extensions/v1beta1 is deprecated in Kubernetes 1.14, use networking.k8s.io/v1beta1 instead" {
+		t.Errorf("Unexpected finding %q", s)
+	}
+
+	if _, err := Check(templates, "next"); err == nil {
+		t.Error("Expected an invalid version to fail")
+	}
+}
@@ -71,6 +71,9 @@ type ChartDownloader struct {
 	Username string
 	// Password chart repository password
 	Password string
+	// TrustPolicy is the signature policy of the chart repositories. It is
+	// read from the trust-policy.yaml file of HelmHome if it is nil.
+	TrustPolicy *TrustPolicy
 }
 
 // DownloadTo retrieves a chart. Depending on the settings, it may also download a provenance file.
@@ -85,20 +88,66 @@ type ChartDownloader struct {
 // Returns a string path to the location where the file was downloaded and a verification
 // (if provenance was verified), or an error if something bad happened.
 func (c *ChartDownloader) DownloadTo(ref, version, dest string) (string, *provenance.Verification, error) {
-	u, g, err := c.ResolveChartVersion(ref, version)
+	destfile, ver, _, err := c.download(ref, version, dest)
+	return destfile, ver, err
+}
+
+// FetchChart downloads a chart like DownloadTo, and verifies the digest of the
+// archive against the digest of the chart in the index of its repository when
+// the index has one. A chart that does not match its digest is removed.
+//
+// Returns the path of the downloaded archive, its SHA-256 digest and the
+// verification of its provenance.
+func FetchChart(c *ChartDownloader, ref, version, dest string) (string, string, *provenance.Verification, error) {
+	destfile, ver, cv, err := c.download(ref, version, dest)
+	if err != nil {
+		return destfile, "", ver, err
+	}
+
+	digest, err := provenance.DigestFile(destfile)
+	if err != nil {
+		return destfile, "", ver, err
+	}
+	if cv != nil && cv.Digest != "" && strings.TrimPrefix(cv.Digest, "sha256:") != digest {
+		os.Remove(destfile)
+		os.Remove(destfile + ".prov")
+		return "", "", nil, fmt.Errorf("the digest %s of %s does not match the digest %s of the repository index", digest, filepath.Base(destfile), cv.Digest)
+	}
+	return destfile, digest, ver, nil
+}
+
+// download retrieves a chart as described by DownloadTo. It also returns the
+// index entry of the chart, if the chart was found in a repository index.
+func (c *ChartDownloader) download(ref, version, dest string) (string, *provenance.Verification, *repo.ChartVersion, error) {
+	u, g, cv, err := c.resolveChartVersion(ref, version)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	trust, err := c.trustFor(ref, u)
 	if err != nil {
-		return "", nil, err
+		return "", nil, cv, err
 	}
 
 	data, err := g.Get(u.String())
 	if err != nil {
-		return "", nil, err
+		return "", nil, cv, err
 	}
 
 	name := filepath.Base(u.Path)
 	destfile := filepath.Join(dest, name)
 	if err := ioutil.WriteFile(destfile, data.Bytes(), 0644); err != nil {
-		return destfile, nil, err
+		return destfile, nil, cv, err
+	}
+
+	if trust != nil {
+		ver, err := c.verifyTrusted(g, u, destfile, trust)
+		if err != nil {
+			// Don't leave an untrusted chart behind.
+			os.Remove(destfile)
+			os.Remove(destfile + ".prov")
+			return "", ver, cv, fmt.Errorf("chart %s rejected by the trust policy: %s", ref, err)
+		}
+		return destfile, ver, cv, nil
 	}
 
 	// If provenance is requested, verify it.
@@ -107,14 +156,14 @@ func (c *ChartDownloader) DownloadTo(ref, version, dest string) (string, *proven
 		body, err := g.Get(u.String() + ".prov")
 		if err != nil {
 			if c.Verify == VerifyAlways {
-				return destfile, ver, fmt.Errorf("Failed to fetch provenance %q", u.String()+".prov")
+				return destfile, ver, cv, fmt.Errorf("Failed to fetch provenance %q", u.String()+".prov")
 			}
 			fmt.Fprintf(c.Out, "WARNING: Verification not found for %s: %s\n", ref, err)
-			return destfile, ver, nil
+			return destfile, ver, cv, nil
 		}
 		provfile := destfile + ".prov"
 		if err := ioutil.WriteFile(provfile, body.Bytes(), 0644); err != nil {
-			return destfile, nil, err
+			return destfile, nil, cv, err
 		}
 
 		if c.Verify != VerifyLater {
@@ -122,11 +171,58 @@ func (c *ChartDownloader) DownloadTo(ref, version, dest string) (string, *proven
 			if err != nil {
 				// Fail always in this case, since it means the verification step
 				// failed.
-				return destfile, ver, err
+				return destfile, ver, cv, err
 			}
 		}
 	}
-	return destfile, ver, nil
+	return destfile, ver, cv, nil
+}
+
+// trustFor returns the trust policy of the chart ref, resolved to the URL u,
+// or nil if there is none.
+func (c *ChartDownloader) trustFor(ref string, u *url.URL) (*RepositoryTrust, error) {
+	policy := c.TrustPolicy
+	if policy == nil {
+		p, err := LoadTrustPolicy(c.HelmHome.TrustPolicy())
+		if err != nil || p == nil {
+			return nil, err
+		}
+		policy = p
+	}
+
+	rf, err := repo.LoadRepositoriesFile(c.HelmHome.RepositoryFile())
+	if err != nil {
+		return nil, err
+	}
+	var rc *repo.Entry
+	if r, err := url.Parse(ref); err == nil && r.IsAbs() {
+		rc, _, _ = c.scanReposForURL(ref, rf)
+	} else {
+		rc, _ = pickChartRepositoryConfigByName(strings.SplitN(ref, "/", 2)[0], rf.Repositories)
+	}
+	return policy.For(rc, u), nil
+}
+
+// verifyTrusted fetches the provenance file of the chart archive destfile,
+// downloaded from the URL u with the getter g, and verifies that the chart is
+// signed by a signer of the trust policy trust.
+func (c *ChartDownloader) verifyTrusted(g getter.Getter, u *url.URL, destfile string, trust *RepositoryTrust) (*provenance.Verification, error) {
+	body, err := g.Get(u.String() + ".prov")
+	if err != nil {
+		return nil, fmt.Errorf("the chart is not signed: failed to fetch provenance %q", u.String()+".prov")
+	}
+	if err := ioutil.WriteFile(destfile+".prov", body.Bytes(), 0644); err != nil {
+		return nil, err
+	}
+	keyring := trust.Keyring
+	if keyring == "" {
+		keyring = c.Keyring
+	}
+	ver, err := VerifyChart(destfile, keyring)
+	if err != nil {
+		return ver, err
+	}
+	return ver, trust.CheckSigner(ver.SignedBy)
 }
 
 // ResolveChartVersion resolves a chart reference to a URL.
@@ -144,14 +240,22 @@ func (c *ChartDownloader) DownloadTo(ref, version, dest string) (string, *proven
 //		* If version is empty, this will return the URL for the latest version
 //		* If no version can be found, an error is returned
 func (c *ChartDownloader) ResolveChartVersion(ref, version string) (*url.URL, getter.Getter, error) {
+	u, g, _, err := c.resolveChartVersion(ref, version)
+	return u, g, err
+}
+
+// resolveChartVersion resolves a chart reference as described by
+// ResolveChartVersion. It also returns the index entry of the chart, if the
+// chart was found in a repository index.
+func (c *ChartDownloader) resolveChartVersion(ref, version string) (*url.URL, getter.Getter, *repo.ChartVersion, error) {
 	u, err := url.Parse(ref)
 	if err != nil {
-		return nil, nil, fmt.Errorf("invalid chart URL format: %s", ref)
+		return nil, nil, nil, fmt.Errorf("invalid chart URL format: %s", ref)
 	}
 
 	rf, err := repo.LoadRepositoriesFile(c.HelmHome.RepositoryFile())
 	if err != nil {
-		return u, nil, err
+		return u, nil, nil, err
 	}
 
 	if u.IsAbs() && len(u.Host) > 0 && len(u.Path) > 0 {
@@ -161,34 +265,34 @@ func (c *ChartDownloader) ResolveChartVersion(ref, version string) (*url.URL, ge
 		// we want to find the repo in case we have special SSL cert config
 		// for that repo.
 
-		rc, err := c.scanReposForURL(ref, rf)
+		rc, cv, err := c.scanReposForURL(ref, rf)
 		if err != nil {
 			// If there is no special config, return the default HTTP client and
 			// swallow the error.
 			if err == ErrNoOwnerRepo {
 				getterConstructor, err := c.Getters.ByScheme(u.Scheme)
 				if err != nil {
-					return u, nil, err
+					return u, nil, nil, err
 				}
 				g, err := getterConstructor(ref, "", "", "")
 				if t, ok := g.(*getter.HttpGetter); ok {
 					t.SetCredentials(c.Username, c.Password)
 				}
-				return u, g, err
+				return u, g, nil, err
 			}
-			return u, nil, err
+			return u, nil, nil, err
 		}
 		r, err := repo.NewChartRepository(rc, c.Getters)
 		c.setCredentials(r)
 		// If we get here, we don't need to go through the next phase of looking
 		// up the URL. We have it already. So we just return.
-		return u, r.Client, err
+		return u, r.Client, cv, err
 	}
 
 	// See if it's of the form: repo/path_to_chart
 	p := strings.SplitN(u.Path, "/", 2)
 	if len(p) < 2 {
-		return u, nil, fmt.Errorf("Non-absolute URLs should be in form of repo_name/path_to_chart, got: %s", u)
+		return u, nil, nil, fmt.Errorf("Non-absolute URLs should be in form of repo_name/path_to_chart, got: %s", u)
 	}
 
 	repoName := p[0]
@@ -196,56 +300,56 @@ func (c *ChartDownloader) ResolveChartVersion(ref, version string) (*url.URL, ge
 	rc, err := pickChartRepositoryConfigByName(repoName, rf.Repositories)
 
 	if err != nil {
-		return u, nil, err
+		return u, nil, nil, err
 	}
 
 	r, err := repo.NewChartRepository(rc, c.Getters)
 	if err != nil {
-		return u, nil, err
+		return u, nil, nil, err
 	}
 	c.setCredentials(r)
 
 	// Skip if dependency not contain name
 	if len(r.Config.Name) == 0 {
-		return u, r.Client, nil
+		return u, r.Client, nil, nil
 	}
 
 	// Next, we need to load the index, and actually look up the chart.
 	i, err := repo.LoadIndexFile(c.HelmHome.CacheIndex(r.Config.Name))
 	if err != nil {
-		return u, r.Client, fmt.Errorf("no cached repo found. (try 'helm repo update'). %s", err)
+		return u, r.Client, nil, fmt.Errorf("no cached repo found. (try 'helm repo update'). %s", err)
 	}
 
 	cv, err := i.Get(chartName, version)
 	if err != nil {
-		return u, r.Client, fmt.Errorf("chart %q matching version %q not found in %s index. (try 'helm repo update'). %s", chartName, version, r.Config.Name, err)
+		return u, r.Client, nil, fmt.Errorf("chart %q matching version %q not found in %s index. (try 'helm repo update'). %s", chartName, version, r.Config.Name, err)
 	}
 
 	if len(cv.URLs) == 0 {
-		return u, r.Client, fmt.Errorf("chart %q has no downloadable URLs", ref)
+		return u, r.Client, cv, fmt.Errorf("chart %q has no downloadable URLs", ref)
 	}
 
 	// TODO: Seems that picking first URL is not fully correct
 	u, err = url.Parse(cv.URLs[0])
 	if err != nil {
-		return u, r.Client, fmt.Errorf("invalid chart URL format: %s", ref)
+		return u, r.Client, cv, fmt.Errorf("invalid chart URL format: %s", ref)
 	}
 
 	// If the URL is relative (no scheme), prepend the chart repo's base URL
 	if !u.IsAbs() {
 		repoURL, err := url.Parse(rc.URL)
 		if err != nil {
-			return repoURL, r.Client, err
+			return repoURL, r.Client, cv, err
 		}
 		q := repoURL.Query()
 		// We need a trailing slash for ResolveReference to work, but make sure there isn't already one
 		repoURL.Path = strings.TrimSuffix(repoURL.Path, "/") + "/"
 		u = repoURL.ResolveReference(u)
 		u.RawQuery = q.Encode()
-		return u, r.Client, err
+		return u, r.Client, cv, err
 	}
 
-	return u, r.Client, nil
+	return u, r.Client, cv, nil
 }
 
 // setCredentials if HttpGetter is used, this method sets the configured repository credentials on the HttpGetter.
@@ -322,7 +426,8 @@ func pickChartRepositoryConfigByName(name string, cfgs []*repo.Entry) (*repo.Ent
 //
 // This will attempt to find the given URL in all of the known repositories files.
 //
-// If the URL is found, this will return the repo entry that contained that URL.
+// If the URL is found, this will return the repo entry that contained that URL
+// and the index entry of the chart.
 //
 // If all of the repos are checked, but the URL is not found, an ErrNoOwnerRepo
 // error is returned.
@@ -336,30 +441,30 @@ func pickChartRepositoryConfigByName(name string, cfgs []*repo.Entry) (*repo.Ent
 // The same URL can technically exist in two or more repositories. This algorithm
 // will return the first one it finds. Order is determined by the order of repositories
 // in the repositories.yaml file.
-func (c *ChartDownloader) scanReposForURL(u string, rf *repo.RepoFile) (*repo.Entry, error) {
+func (c *ChartDownloader) scanReposForURL(u string, rf *repo.RepoFile) (*repo.Entry, *repo.ChartVersion, error) {
 	// FIXME: This is far from optimal. Larger installations and index files will
 	// incur a performance hit for this type of scanning.
 	for _, rc := range rf.Repositories {
 		r, err := repo.NewChartRepository(rc, c.Getters)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		i, err := repo.LoadIndexFile(c.HelmHome.CacheIndex(r.Config.Name))
 		if err != nil {
-			return nil, fmt.Errorf("no cached repo found. (try 'helm repo update'). %s", err)
+			return nil, nil, fmt.Errorf("no cached repo found. (try 'helm repo update'). %s", err)
 		}
 
 		for _, entry := range i.Entries {
 			for _, ver := range entry {
 				for _, dl := range ver.URLs {
 					if urlutil.Equal(u, dl) {
-						return rc, nil
+						return rc, ver, nil
 					}
 				}
 			}
 		}
 	}
 	// This means that there is no repo file for the given URL.
-	return nil, ErrNoOwnerRepo
+	return nil, nil, ErrNoOwnerRepo
 }
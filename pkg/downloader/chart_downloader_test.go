@@ -23,11 +23,13 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"k8s.io/helm/pkg/getter"
 	"k8s.io/helm/pkg/helm/environment"
 	"k8s.io/helm/pkg/helm/helmpath"
+	"k8s.io/helm/pkg/provenance"
 	"k8s.io/helm/pkg/repo"
 	"k8s.io/helm/pkg/repo/repotest"
 )
@@ -220,6 +222,61 @@ func TestDownloadTo(t *testing.T) {
 	}
 }
 
+func TestFetchChart(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "helm-fetchchart-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	hh := helmpath.Home(tmp)
+	dest := filepath.Join(hh.String(), "dest")
+	for _, p := range []string{hh.Cache(), dest} {
+		if err := os.MkdirAll(p, 0755); err != nil {
+			t.Fatalf("Could not create %s: %s", p, err)
+		}
+	}
+
+	srv := repotest.NewServer(tmp)
+	defer srv.Stop()
+	if _, err := srv.CopyCharts("testdata/*.tgz*"); err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.LinkIndices(); err != nil {
+		t.Fatal(err)
+	}
+
+	c := &ChartDownloader{
+		HelmHome: hh,
+		Out:      os.Stderr,
+		Getters:  getter.All(environment.EnvSettings{}),
+	}
+	where, digest, _, err := FetchChart(c, "test/signtest", "", dest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expect := filepath.Join(dest, "signtest-0.1.0.tgz"); where != expect {
+		t.Errorf("Expected download to %s, got %s", expect, where)
+	}
+	if expect, _ := provenance.DigestFile("testdata/signtest-0.1.0.tgz"); digest != expect {
+		t.Errorf("Expected digest %s, got %s", expect, digest)
+	}
+
+	// The archive no longer matches the digest of the index.
+	if err := ioutil.WriteFile(filepath.Join(srv.Root(), "signtest-0.1.0.tgz"), []byte("tampered"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(where); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, _, err := FetchChart(c, "test/signtest", "", dest); err == nil || !strings.Contains(err.Error(), "does not match") {
+		t.Errorf("Expected a digest mismatch, got %v", err)
+	}
+	if _, err := os.Stat(where); !os.IsNotExist(err) {
+		t.Errorf("Expected the archive to be removed, got %v", err)
+	}
+}
+
 func TestDownloadTo_VerifyLater(t *testing.T) {
 	tmp, err := ioutil.TempDir("", "helm-downloadto-")
 	if err != nil {
@@ -283,6 +340,75 @@ func TestDownloadTo_VerifyLater(t *testing.T) {
 	}
 }
 
+func TestDownloadTo_TrustPolicy(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "helm-downloadto-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	hh := helmpath.Home(tmp)
+	dest := filepath.Join(hh.String(), "dest")
+	for _, p := range []string{hh.String(), hh.Repository(), hh.Cache(), dest} {
+		if err := os.MkdirAll(p, 0755); err != nil {
+			t.Fatalf("Could not create %s: %s", p, err)
+		}
+	}
+
+	// Set up a fake repo
+	srv := repotest.NewServer(tmp)
+	defer srv.Stop()
+	if _, err := srv.CopyCharts("testdata/*.tgz*"); err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.LinkIndices(); err != nil {
+		t.Fatal(err)
+	}
+
+	cname := "/signtest-0.1.0.tgz"
+	for _, tt := range []struct {
+		name    string
+		signers []string
+		err     string
+	}{
+		{"trusted signer", []string{"helm-testing@helm.sh"}, ""},
+		{"untrusted signer", []string{"charts@example.com"}, "rejected by the trust policy"},
+	} {
+		// The policy applies even though the chart is not verified otherwise.
+		c := ChartDownloader{
+			HelmHome: hh,
+			Out:      os.Stderr,
+			Verify:   VerifyNever,
+			Getters:  getter.All(environment.EnvSettings{}),
+			TrustPolicy: &TrustPolicy{Repositories: []*RepositoryTrust{{
+				URL:     srv.URL() + "/*",
+				Signers: tt.signers,
+				Keyring: "testdata/helm-test-key.pub",
+			}}},
+		}
+		where, v, err := c.DownloadTo(srv.URL()+cname, "", dest)
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("%s: expected the error %q, got %v", tt.name, tt.err, err)
+			}
+			if _, err := os.Stat(filepath.Join(dest, cname)); !os.IsNotExist(err) {
+				t.Errorf("%s: expected the rejected chart to be removed", tt.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %s", tt.name, err)
+			continue
+		}
+		if expect := filepath.Join(dest, cname); where != expect {
+			t.Errorf("%s: expected download to %s, got %s", tt.name, expect, where)
+		}
+		if v.FileHash == "" {
+			t.Errorf("%s: expected the chart to be verified", tt.name)
+		}
+	}
+}
+
 func TestScanReposForURL(t *testing.T) {
 	hh := helmpath.Home("testdata/helmhome")
 	c := ChartDownloader{
@@ -298,7 +424,7 @@ func TestScanReposForURL(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	entry, err := c.scanReposForURL(u, rf)
+	entry, cv, err := c.scanReposForURL(u, rf)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -306,10 +432,13 @@ func TestScanReposForURL(t *testing.T) {
 	if entry.Name != "testing" {
 		t.Errorf("Unexpected repo %q for URL %q", entry.Name, u)
 	}
+	if cv.Name != "alpine" || cv.Version != "0.2.0" {
+		t.Errorf("Unexpected chart %s-%s for URL %q", cv.Name, cv.Version, u)
+	}
 
 	// A lookup failure should produce an ErrNoOwnerRepo
 	u = "https://no.such.repo/foo/bar-1.23.4.tgz"
-	if _, err = c.scanReposForURL(u, rf); err != ErrNoOwnerRepo {
+	if _, _, err = c.scanReposForURL(u, rf); err != ErrNoOwnerRepo {
 		t.Fatalf("expected ErrNoOwnerRepo, got %v", err)
 	}
 }
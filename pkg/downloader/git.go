@@ -0,0 +1,121 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package downloader
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/Masterminds/semver"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/provenance"
+	"k8s.io/helm/pkg/resolver"
+)
+
+// gitCommand is the git executable used to clone git dependencies.
+var gitCommand = "git"
+
+// tarFromGit shallow clones the git repository of a dependency at its ref,
+// archives the chart at its path into destPath and returns the version and
+// the digest of the content of the chart.
+func tarFromGit(destPath, name, repo, version string) (string, string, error) {
+	g, err := resolver.ParseGitRepository(repo)
+	if err != nil {
+		return "", "", err
+	}
+	return tarFromGitRepository(destPath, name, repo, g, version)
+}
+
+// tarFromGitRepository is tarFromGit for the parsed git repository g of the
+// dependency.
+func tarFromGitRepository(destPath, name, repo string, g *resolver.GitRepository, version string) (string, string, error) {
+	tmp, err := ioutil.TempDir("", "helm-git-")
+	if err != nil {
+		return "", "", err
+	}
+	defer os.RemoveAll(tmp)
+
+	ref := g.Ref
+	if ref == "" {
+		ref = "HEAD"
+	}
+	// The URL and the ref are never parsed as options, and the ext::
+	// transport, running a command, is disabled.
+	cmds := [][]string{
+		{"init", "-q", tmp},
+		{"-c", "protocol.ext.allow=never", "-C", tmp, "fetch", "-q", "--depth", "1", "--", g.URL, ref},
+		{"-C", tmp, "checkout", "-q", "FETCH_HEAD"},
+	}
+	for _, args := range cmds {
+		if err := runGit(args...); err != nil {
+			return "", "", fmt.Errorf("could not clone %s at %s: %s", g.URL, ref, err)
+		}
+	}
+	if err := os.RemoveAll(filepath.Join(tmp, ".git")); err != nil {
+		return "", "", err
+	}
+
+	chartPath := filepath.Join(tmp, filepath.FromSlash(g.Path))
+	ch, err := chartutil.LoadDir(chartPath)
+	if err != nil {
+		return "", "", fmt.Errorf("could not load %s from %s: %s", g.Path, repo, err)
+	}
+	if ch.Metadata.Name != name {
+		return "", "", fmt.Errorf("the chart in %s is %s, expected %s", repo, ch.Metadata.Name, name)
+	}
+
+	constraint, err := semver.NewConstraint(version)
+	if err != nil {
+		return "", "", fmt.Errorf("dependency %s has an invalid version/constraint format: %s", name, err)
+	}
+	v, err := semver.NewVersion(ch.Metadata.Version)
+	if err != nil {
+		return "", "", err
+	}
+	if !constraint.Check(v) {
+		return "", "", fmt.Errorf("dependency %s at version %s from %s does not satisfy the constraint %s", name, ch.Metadata.Version, repo, version)
+	}
+
+	digest, err := provenance.DigestDir(chartPath)
+	if err != nil {
+		return "", "", err
+	}
+	if _, err := chartutil.Save(ch, destPath); err != nil {
+		return "", "", err
+	}
+	return ch.Metadata.Version, "sha256:" + digest, nil
+}
+
+// runGit runs git with the given arguments.
+func runGit(args ...string) error {
+	cmd := exec.Command(gitCommand, args...)
+	stderr := bytes.NewBuffer(nil)
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		if _, ok := err.(*exec.ExitError); ok {
+			return fmt.Errorf("git exited with error: %s", strings.TrimSpace(stderr.String()))
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package downloader
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"k8s.io/helm/pkg/resolver"
+)
+
+func TestTarFromGit(t *testing.T) {
+	if _, err := exec.LookPath(gitCommand); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	src, err := ioutil.TempDir("", "helm-git-src-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(src)
+	dest, err := ioutil.TempDir("", "helm-git-dest-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dest)
+
+	chartDir := filepath.Join(src, "charts", "local-subchart")
+	if err := os.MkdirAll(chartDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := copyFile("testdata/local-subchart/Chart.yaml", filepath.Join(chartDir, "Chart.yaml")); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"init", "-q", src},
+		{"-C", src, "add", "."},
+		{"-C", src, "-c", "user.name=helm", "-c", "user.email=helm@example.com", "commit", "-q", "-m", "chart"},
+		{"-C", src, "tag", "v0.1.0"},
+	} {
+		if err := runGit(args...); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The file:// scheme is rejected by tarFromGit, so the repository is not
+	// parsed.
+	repo := "git+file://" + filepath.ToSlash(src) + "?ref=v0.1.0&path=charts/local-subchart"
+	g := &resolver.GitRepository{URL: "file://" + filepath.ToSlash(src), Ref: "v0.1.0", Path: "charts/local-subchart"}
+	ver, digest, err := tarFromGitRepository(dest, "local-subchart", repo, g, "^0.1.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ver != "0.1.0" {
+		t.Errorf("Expected version 0.1.0, got %s", ver)
+	}
+	if local, err := localDigest(".", "file://testdata/local-subchart"); err != nil || local != digest {
+		t.Errorf("Expected the digest %s, got %s (%v)", local, digest, err)
+	}
+	if _, err := os.Stat(filepath.Join(dest, "local-subchart-0.1.0.tgz")); err != nil {
+		t.Errorf("Expected the chart to be archived: %s", err)
+	}
+
+	if _, _, err := tarFromGitRepository(dest, "local-subchart", repo, g, "^0.2.0"); err == nil || !strings.Contains(err.Error(), "does not satisfy") {
+		t.Errorf("Expected a constraint error, got %v", err)
+	}
+	if _, _, err := tarFromGitRepository(dest, "other", repo, g, "^0.1.0"); err == nil {
+		t.Error("Expected an error for a chart with another name")
+	}
+	missing := *g
+	missing.Ref = "v9.9.9"
+	if _, _, err := tarFromGitRepository(dest, "local-subchart", repo, &missing, "^0.1.0"); err == nil {
+		t.Error("Expected an error for a missing ref")
+	}
+
+	// A ref starting with a dash is not run as an option of git fetch.
+	marker := filepath.Join(dest, "pwned")
+	injected := *g
+	injected.Ref = "--upload-pack=touch " + marker + "; git-upload-pack"
+	if _, _, err := tarFromGitRepository(dest, "local-subchart", repo, &injected, "^0.1.0"); err == nil {
+		t.Error("Expected an error for a ref starting with a dash")
+	}
+	if _, err := os.Stat(marker); err == nil {
+		t.Error("Expected the ref not to be run as an option")
+	}
+}
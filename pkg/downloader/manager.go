@@ -34,6 +34,7 @@ import (
 	"k8s.io/helm/pkg/getter"
 	"k8s.io/helm/pkg/helm/helmpath"
 	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/provenance"
 	"k8s.io/helm/pkg/repo"
 	"k8s.io/helm/pkg/resolver"
 	"k8s.io/helm/pkg/urlutil"
@@ -55,8 +56,15 @@ type Manager struct {
 	Keyring string
 	// SkipUpdate indicates that the repository should not be updated first.
 	SkipUpdate bool
+	// Refresh downloads the indexes of the repositories even if the cached
+	// indexes are up to date.
+	Refresh bool
 	// Getter collection for the operation
 	Getters []getter.Provider
+	// Symlinks is how the symbolic links of the chart directory and of its
+	// local dependencies are handled. The charts cloned from git
+	// repositories are always loaded with the default policy.
+	Symlinks chartutil.SymlinkPolicy
 }
 
 // Build rebuilds a local charts directory from a lockfile.
@@ -83,6 +91,9 @@ func (m *Manager) Build() error {
 		return fmt.Errorf("requirements.yaml cannot be opened: %s", err)
 	}
 	if sum, err := resolver.HashReq(req); err != nil || sum != lock.Digest {
+		if c.Metadata.ApiVersion == chartutil.ApiVersionV2 {
+			return fmt.Errorf("Chart.lock is out of sync with the dependencies of Chart.yaml")
+		}
 		return fmt.Errorf("requirements.lock is out of sync with requirements.yaml")
 	}
 
@@ -158,12 +169,27 @@ func (m *Manager) Update() error {
 
 	// If the lock file hasn't changed, don't write a new one.
 	oldLock, err := chartutil.LoadRequirementsLock(c)
-	if err == nil && oldLock.Digest == lock.Digest {
+	if err == nil && !lockChanged(oldLock, lock) {
 		return nil
 	}
 
 	// Finally, we need to write the lockfile.
-	return writeLock(m.ChartPath, lock)
+	return writeLock(m.ChartPath, chartutil.LockfileName(c), lock)
+}
+
+// lockChanged returns whether the requirements or the locked versions and
+// digests of the dependencies differ between two lock files.
+func lockChanged(old, lock *chartutil.RequirementsLock) bool {
+	if old.Digest != lock.Digest || len(old.Dependencies) != len(lock.Dependencies) {
+		return true
+	}
+	for i, d := range lock.Dependencies {
+		o := old.Dependencies[i]
+		if o.Name != d.Name || o.Repository != d.Repository || o.Version != d.Version || o.Digest != d.Digest {
+			return true
+		}
+	}
+	return false
 }
 
 func (m *Manager) loadChartDir() (*chart.Chart, error) {
@@ -172,7 +198,7 @@ func (m *Manager) loadChartDir() (*chart.Chart, error) {
 	} else if !fi.IsDir() {
 		return nil, errors.New("only unpacked charts can be updated")
 	}
-	return chartutil.LoadDir(m.ChartPath)
+	return chartutil.LoadDir(m.ChartPath, chartutil.Symlinks(m.Symlinks))
 }
 
 // resolve takes a list of requirements and translates them into an exact version to download.
@@ -220,7 +246,7 @@ func (m *Manager) downloadAll(deps []*chartutil.Dependency) error {
 		if dep.Repository == "" {
 			fmt.Fprintf(m.Out, "Dependency %s did not declare a repository. Assuming it exists in the charts directory\n", dep.Name)
 			chartPath := filepath.Join(tmpPath, dep.Name)
-			ch, err := chartutil.LoadDir(chartPath)
+			ch, err := chartutil.LoadDir(chartPath, chartutil.Symlinks(m.Symlinks))
 			if err != nil {
 				return fmt.Errorf("Unable to load chart: %v", err)
 			}
@@ -245,12 +271,37 @@ func (m *Manager) downloadAll(deps []*chartutil.Dependency) error {
 			if m.Debug {
 				fmt.Fprintf(m.Out, "Archiving %s from repo %s\n", dep.Name, dep.Repository)
 			}
-			ver, err := tarFromLocalDir(m.ChartPath, dep.Name, dep.Repository, dep.Version)
+			ver, err := tarFromLocalDir(m.ChartPath, dep.Name, dep.Repository, dep.Version, chartutil.Symlinks(m.Symlinks))
+			if err != nil {
+				saveError = err
+				break
+			}
+			dep.Version = ver
+			digest, err := localDigest(m.ChartPath, dep.Repository)
 			if err != nil {
 				saveError = err
 				break
 			}
+			if dep.Digest != "" && dep.Digest != digest {
+				saveError = fmt.Errorf("the content of %s changed since the lock file was written, run 'helm dependency update'", dep.Repository)
+				break
+			}
+			dep.Digest = digest
+			continue
+		}
+		if resolver.IsGitRepository(dep.Repository) {
+			fmt.Fprintf(m.Out, "Cloning %s from repo %s\n", dep.Name, dep.Repository)
+			ver, digest, err := tarFromGit(destPath, dep.Name, dep.Repository, dep.Version)
+			if err != nil {
+				saveError = err
+				break
+			}
+			if dep.Digest != "" && dep.Digest != digest {
+				saveError = fmt.Errorf("the content of %s changed since the lock file was written, run 'helm dependency update'", dep.Repository)
+				break
+			}
 			dep.Version = ver
+			dep.Digest = digest
 			continue
 		}
 
@@ -274,10 +325,22 @@ func (m *Manager) downloadAll(deps []*chartutil.Dependency) error {
 			Password: password,
 		}
 
-		if _, _, err := dl.DownloadTo(churl, "", destPath); err != nil {
+		fname, _, err := dl.DownloadTo(churl, "", destPath)
+		if err != nil {
 			saveError = fmt.Errorf("could not download %s: %s", churl, err)
 			break
 		}
+		digest, err := provenance.DigestFile(fname)
+		if err != nil {
+			saveError = err
+			break
+		}
+		digest = "sha256:" + digest
+		if dep.Digest != "" && dep.Digest != digest {
+			saveError = fmt.Errorf("the digest %s of %s does not match the digest %s of the lock file", digest, churl, dep.Digest)
+			break
+		}
+		dep.Digest = digest
 	}
 
 	if saveError == nil {
@@ -360,8 +423,8 @@ func (m *Manager) hasAllRepos(deps []*chartutil.Dependency) error {
 	// by Helm.
 	missing := []string{}
 	for _, dd := range deps {
-		// If repo is from local path, continue
-		if strings.HasPrefix(dd.Repository, "file://") {
+		// If repo is from local path or git, continue
+		if strings.HasPrefix(dd.Repository, "file://") || resolver.IsGitRepository(dd.Repository) {
 			continue
 		}
 
@@ -403,6 +466,14 @@ func (m *Manager) getRepoNames(deps []*chartutil.Dependency) (map[string]string,
 		if dd.Repository == "" {
 			continue
 		}
+		// The resolver looks for the chart in all the repositories
+		if dd.Repository == resolver.AnyRepository {
+			continue
+		}
+		// The chart is cloned from its git repository
+		if resolver.IsGitRepository(dd.Repository) {
+			continue
+		}
 		// if dep chart is from local path, verify the path is valid
 		if strings.HasPrefix(dd.Repository, "file://") {
 			if _, err := resolver.GetLocalPath(dd.Repository, m.ChartPath); err != nil {
@@ -489,6 +560,7 @@ func (m *Manager) parallelRepoUpdate(repos []*repo.Entry) error {
 		if err != nil {
 			return err
 		}
+		r.Refresh = m.Refresh
 		wg.Add(1)
 		go func(r *repo.ChartRepository) {
 			if err := r.DownloadIndexFile(m.HelmHome.Cache()); err != nil {
@@ -633,18 +705,19 @@ func (m *Manager) loadChartRepositories() (map[string]*repo.ChartRepository, err
 	return indices, nil
 }
 
-// writeLock writes a lockfile to disk
-func writeLock(chartpath string, lock *chartutil.RequirementsLock) error {
+// writeLock writes a lockfile named name to disk
+func writeLock(chartpath, name string, lock *chartutil.RequirementsLock) error {
 	data, err := yaml.Marshal(lock)
 	if err != nil {
 		return err
 	}
-	dest := filepath.Join(chartpath, "requirements.lock")
+	dest := filepath.Join(chartpath, name)
 	return ioutil.WriteFile(dest, data, 0644)
 }
 
-// tarFromLocalDir archive a dep chart from local directory and save it into charts/
-func tarFromLocalDir(chartpath, name, repo, version string) (string, error) {
+// tarFromLocalDir archive a dep chart from local directory, loaded with the
+// options opts, and save it into charts/
+func tarFromLocalDir(chartpath, name, repo, version string, opts ...chartutil.LoadOption) (string, error) {
 	destPath := filepath.Join(chartpath, "charts")
 
 	if !strings.HasPrefix(repo, "file://") {
@@ -656,7 +729,7 @@ func tarFromLocalDir(chartpath, name, repo, version string) (string, error) {
 		return "", err
 	}
 
-	ch, err := chartutil.LoadDir(origPath)
+	ch, err := chartutil.LoadDir(origPath, opts...)
 	if err != nil {
 		return "", err
 	}
@@ -679,6 +752,20 @@ func tarFromLocalDir(chartpath, name, repo, version string) (string, error) {
 	return "", fmt.Errorf("can't get a valid version for dependency %s", name)
 }
 
+// localDigest returns the digest of the content of the directory of a chart
+// with a "file://" repository.
+func localDigest(chartpath, repo string) (string, error) {
+	origPath, err := resolver.GetLocalPath(repo, chartpath)
+	if err != nil {
+		return "", err
+	}
+	digest, err := provenance.DigestDir(origPath)
+	if err != nil {
+		return "", err
+	}
+	return "sha256:" + digest, nil
+}
+
 // move files from tmppath to destpath
 func move(tmpPath, destPath string) error {
 	files, _ := ioutil.ReadDir(tmpPath)
@@ -180,3 +180,41 @@ func TestGetRepoNames(t *testing.T) {
 		}
 	}
 }
+
+func TestLockChanged(t *testing.T) {
+	lock := func(digest string) *chartutil.RequirementsLock {
+		return &chartutil.RequirementsLock{
+			Digest: "sha256:requirements",
+			Dependencies: []*chartutil.Dependency{
+				{Name: "local-subchart", Version: "0.1.0", Repository: "file://./testdata/local-subchart", Digest: digest},
+			},
+		}
+	}
+
+	if lockChanged(lock("sha256:a"), lock("sha256:a")) {
+		t.Error("Expected identical locks to be unchanged")
+	}
+	if !lockChanged(lock(""), lock("sha256:a")) {
+		t.Error("Expected a lock without digests to be changed")
+	}
+	if !lockChanged(lock("sha256:a"), lock("sha256:b")) {
+		t.Error("Expected a lock with a different digest to be changed")
+	}
+}
+
+func TestLocalDigest(t *testing.T) {
+	digest, err := localDigest(".", "file://testdata/local-subchart")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(digest, "sha256:") {
+		t.Errorf("Expected a sha256 digest, got %s", digest)
+	}
+	if again, err := localDigest(".", "file://./testdata/local-subchart"); err != nil || again != digest {
+		t.Errorf("Expected the digest %s, got %s (%v)", digest, again, err)
+	}
+
+	if _, err := localDigest(".", "file://testdata/notexist"); err == nil {
+		t.Error("Expected an error for a missing local chart")
+	}
+}
@@ -0,0 +1,153 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package downloader
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"golang.org/x/crypto/openpgp"
+
+	"k8s.io/helm/pkg/repo"
+	"k8s.io/helm/pkg/urlutil"
+)
+
+// TrustPolicy is the signature policy of the charts of the chart
+// repositories. The charts of a repository with a policy are only downloaded
+// if they are signed by one of its signers, whatever the verification
+// strategy of the ChartDownloader.
+//
+//	repositories:
+//	- name: stable
+//	  signers:
+//	  - helm-charts@example.com
+//	  keyring: keys/stable.gpg
+//	- url: https://charts.example.com/*
+//	  signers:
+//	  - 5A1B2C3D4E5F60718293A4B5C6D7E8F901234567
+type TrustPolicy struct {
+	Repositories []*RepositoryTrust `json:"repositories"`
+}
+
+// RepositoryTrust is the signature policy of the charts of the repositories
+// matching its name or its URL.
+type RepositoryTrust struct {
+	// Name is the name of the repository in repositories.yaml.
+	Name string `json:"name,omitempty"`
+	// URL is the URL of the repository. A URL ending with '*' matches the
+	// URLs it is a prefix of.
+	URL string `json:"url,omitempty"`
+	// Signers are the keys which may sign the charts: the name or the email
+	// of one of their identities, or their fingerprint. Any key of the
+	// keyring may if there are none.
+	Signers []string `json:"signers,omitempty"`
+	// Keyring is the keyring of the public keys of the signers, relative to
+	// the directory of the policy file. The keyring of the ChartDownloader
+	// is used if it is not set.
+	Keyring string `json:"keyring,omitempty"`
+}
+
+// LoadTrustPolicy reads the trust policy of the file path. It returns nil if
+// the file does not exist.
+func LoadTrustPolicy(path string) (*TrustPolicy, error) {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	p := &TrustPolicy{}
+	if err := yaml.Unmarshal(data, p); err != nil {
+		return nil, fmt.Errorf("cannot parse the trust policy %s: %s", path, err)
+	}
+	for _, r := range p.Repositories {
+		if r.Name == "" && r.URL == "" {
+			return nil, fmt.Errorf("trust policy %s: a repository needs a name or a URL", path)
+		}
+		if r.Keyring != "" && !filepath.IsAbs(r.Keyring) {
+			r.Keyring = filepath.Join(filepath.Dir(path), r.Keyring)
+		}
+	}
+	return p, nil
+}
+
+// For returns the policy of the chart at the URL u, of the repository rc,
+// which is nil if the chart is not in a known repository. A policy matches
+// the name or the URL of the repository, or the URL of the chart. It returns
+// nil if none does.
+func (p *TrustPolicy) For(rc *repo.Entry, u *url.URL) *RepositoryTrust {
+	if p == nil {
+		return nil
+	}
+	for _, r := range p.Repositories {
+		if rc != nil && r.Name != "" && r.Name == rc.Name {
+			return r
+		}
+		if r.URL == "" {
+			continue
+		}
+		if rc != nil && matchURL(r.URL, rc.URL) || u != nil && matchURL(r.URL, u.String()) {
+			return r
+		}
+	}
+	return nil
+}
+
+// matchURL returns whether the URL pattern matches the URL u.
+func matchURL(pattern, u string) bool {
+	if strings.HasSuffix(pattern, "*") {
+		// The URL of a repository may not end with a slash.
+		return strings.HasPrefix(strings.TrimSuffix(u, "/")+"/", strings.TrimSuffix(pattern, "*"))
+	}
+	return urlutil.Equal(pattern, u)
+}
+
+// CheckSigner returns an error if the entity which signed a chart is not one
+// of the signers of the policy.
+func (r *RepositoryTrust) CheckSigner(e *openpgp.Entity) error {
+	if len(r.Signers) == 0 {
+		return nil
+	}
+	fingerprint := strings.ToUpper(hex.EncodeToString(e.PrimaryKey.Fingerprint[:]))
+	for _, s := range r.Signers {
+		if strings.EqualFold(strings.Replace(s, " ", "", -1), fingerprint) {
+			return nil
+		}
+		for _, id := range e.Identities {
+			if s == id.Name || id.UserId != nil && strings.EqualFold(s, id.UserId.Email) {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("the chart is signed by %s, which is not a signer trusted by the trust policy", signerName(e, fingerprint))
+}
+
+// signerName returns the name of an identity of the entity e, or its
+// fingerprint if it has none.
+func signerName(e *openpgp.Entity, fingerprint string) string {
+	for name := range e.Identities {
+		return fmt.Sprintf("%q", name)
+	}
+	return fingerprint
+}
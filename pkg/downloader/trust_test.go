@@ -0,0 +1,90 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package downloader
+
+import (
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"k8s.io/helm/pkg/repo"
+)
+
+func TestLoadTrustPolicy(t *testing.T) {
+	dir, err := ioutil.TempDir("", "helm-trust-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "trust-policy.yaml")
+	if p, err := LoadTrustPolicy(file); err != nil || p != nil {
+		t.Errorf("Expected no policy without file, got %v, %v", p, err)
+	}
+
+	data := "repositories:\n- name: stable\n  signers:\n  - charts@example.com\n  keyring: keys/stable.gpg\n"
+	if err := ioutil.WriteFile(file, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	p, err := LoadTrustPolicy(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Repositories) != 1 {
+		t.Fatalf("Expected 1 repository, got %d", len(p.Repositories))
+	}
+	if expect := filepath.Join(dir, "keys", "stable.gpg"); p.Repositories[0].Keyring != expect {
+		t.Errorf("Expected the keyring %s, got %s", expect, p.Repositories[0].Keyring)
+	}
+
+	if err := ioutil.WriteFile(file, []byte("repositories:\n- signers:\n  - charts@example.com\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadTrustPolicy(file); err == nil {
+		t.Error("Expected a repository without name nor URL to fail")
+	}
+}
+
+func TestTrustPolicyFor(t *testing.T) {
+	stable := &RepositoryTrust{Name: "stable"}
+	example := &RepositoryTrust{URL: "https://charts.example.com/*"}
+	p := &TrustPolicy{Repositories: []*RepositoryTrust{stable, example}}
+
+	chartURL, _ := url.Parse("https://charts.example.com/nginx-0.1.0.tgz")
+	otherURL, _ := url.Parse("https://other.example.com/nginx-0.1.0.tgz")
+	for _, tt := range []struct {
+		name   string
+		rc     *repo.Entry
+		u      *url.URL
+		expect *RepositoryTrust
+	}{
+		{"by name", &repo.Entry{Name: "stable", URL: "https://kubernetes-charts.storage.googleapis.com"}, nil, stable},
+		{"by repository URL", &repo.Entry{Name: "example", URL: "https://charts.example.com"}, otherURL, example},
+		{"by chart URL", nil, chartURL, example},
+		{"no match", &repo.Entry{Name: "other", URL: "https://other.example.com"}, otherURL, nil},
+	} {
+		if r := p.For(tt.rc, tt.u); r != tt.expect {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expect, r)
+		}
+	}
+
+	if r := (*TrustPolicy)(nil).For(nil, chartURL); r != nil {
+		t.Errorf("Expected no policy, got %v", r)
+	}
+}
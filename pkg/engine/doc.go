@@ -19,5 +19,49 @@ limitations under the License.
 Tiller provides a simple interface for taking a Chart and rendering its templates.
 The 'engine' package implements this interface using Go's built-in 'text/template'
 package.
+
+Template files with the .lua extension are rendered by Lua scripts instead,
+in a sandbox without access to the file system or the process. A script sees
+the same objects as a Go template as globals (Values, Release, Chart,
+Capabilities, Files and Template), and the 'helm' table of helpers:
+
+	helm.toYaml(v), helm.fromYaml(s)    convert between tables and YAML
+	helm.toJson(v), helm.fromJson(s)    convert between tables and JSON
+	helm.b64enc(s), helm.b64dec(s)      encode and decode base64
+	helm.sha256sum(s)                   the hex SHA-256 digest of s
+	helm.required(msg, v)               fail with msg if v is nil or empty
+	helm.fail(msg)                      fail with msg
+	helm.include(name[, ctx])           render a named Go template
+	helm.lookup(apiVersion, kind[, namespace[, name]])
+	                                    look up cluster resources
+
+The script returns the rendered file: a string, a table rendered as a YAML
+document, or a list of tables rendered as YAML documents:
+
+	return {
+	  apiVersion = "v1",
+	  kind = "ConfigMap",
+	  metadata = { name = Release.Name .. "-config" },
+	  data = { replicas = tostring(Values.replicas) },
+	}
+
+Template files with the .jsonnet extension are rendered by Jsonnet. The top
+level objects are bound to external variables, e.g. std.extVar("Values"), and
+the values are passed as the top level argument Values of templates that are
+functions. A template may import the .libsonnet and .jsonnet files of the
+templates of its chart, relative to its own path; .libsonnet files are not
+rendered. The native functions include, lookup, toYaml, fromYaml and sha256sum
+mirror the Lua helpers and are called with std.native:
+
+	function(Values) {
+	  apiVersion: "v1",
+	  kind: "ConfigMap",
+	  metadata: { name: std.extVar("Release").Name + "-config" },
+	  data: { labels: std.native("include")("mychart.labels", null) },
+	}
+
+A Jsonnet template evaluates to the rendered file, or to Kubernetes objects
+rendered as YAML documents: an object with a kind, or lists and objects of
+such objects.
 */
 package engine // import "k8s.io/helm/pkg/engine"
@@ -18,11 +18,14 @@ package engine
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"log"
 	"path"
+	"reflect"
 	"sort"
 	"strings"
+	"sync"
 	"text/template"
 
 	"github.com/Masterminds/sprig"
@@ -39,10 +42,46 @@ type Engine struct {
 	// If strict is enabled, template rendering will fail if a template references
 	// a value that was not passed in.
 	Strict bool
+	// Optional are the paths of the values, in the values of the chart, that
+	// templates may reference without them being set when Strict is enabled,
+	// in addition to the values of the OptionalAnnotation of the charts.
+	Optional []string
 	// In LintMode, some 'required' template values may be missing, so don't fail
 	LintMode bool
+	// If Isolate is enabled, named templates are scoped to the chart defining
+	// them instead of being shared by the chart and all of its subcharts.
+	// Isolation is also enabled by the IsolationAnnotation on the rendered chart.
+	Isolate bool
+	// Lookup implements the 'lookup' template function. If it is nil, 'lookup'
+	// always returns an empty map. Tiller sets it to read the resources from
+	// its cluster.
+	Lookup LookupFunc
+	// Trace, if not nil, records the rendering of the templates.
+	Trace *RenderTrace
+	// Scopes, if not nil, is set to the values passed to every rendered
+	// template file as .Values, by template name. The values of a subchart
+	// are the values after the globals and the values of the parent charts
+	// are merged.
+	Scopes map[string]chartutil.Values
+	// Workers is the number of templates rendered in parallel. Templates are
+	// rendered serially if it is 1 or less, or if the rendering is traced.
+	// With more than one worker, every template renders with its own copy of
+	// the values: the changes a template makes to .Values, e.g. with set,
+	// are not seen by the other templates.
+	Workers int
+
+	// ctx, if not nil, stops the rendering when it is done.
+	ctx context.Context
+	// optional are the paths of the optional values of the chart being
+	// rendered, split on dots.
+	optional [][]string
 }
 
+// LookupFunc returns the resource of the given apiVersion and kind named name
+// in namespace, or the list of those resources if name is empty. Resources
+// that do not exist are returned as an empty map.
+type LookupFunc func(apiVersion, kind, namespace, name string) (map[string]interface{}, error)
+
 // New creates a new Go template Engine instance.
 //
 // The FuncMap is initialized here. You may modify the FuncMap _prior to_ the
@@ -71,6 +110,10 @@ func New() *Engine {
 //	   included in the FuncMap is a placeholder.
 //      - "tpl": This is late-bound in Engine.Render(). The version
 //	   included in the FuncMap is a placeholder.
+//      - "renderString": This is late-bound in Engine.Render(). The version
+//	   included in the FuncMap is a placeholder.
+//      - "lookup": This is late-bound in Engine.Render(). The version
+//	   included in the FuncMap returns an empty map.
 func FuncMap() template.FuncMap {
 	f := sprig.TxtFuncMap()
 	delete(f, "env")
@@ -78,18 +121,29 @@ func FuncMap() template.FuncMap {
 
 	// Add some extra functionality
 	extra := template.FuncMap{
-		"toToml":   chartutil.ToToml,
-		"toYaml":   chartutil.ToYaml,
-		"fromYaml": chartutil.FromYaml,
-		"toJson":   chartutil.ToJson,
-		"fromJson": chartutil.FromJson,
+		"toToml":        chartutil.ToToml,
+		"toYaml":        chartutil.ToYaml,
+		"toYamlPretty":  chartutil.ToYamlPretty,
+		"fromYaml":      chartutil.FromYaml,
+		"fromYamlArray": chartutil.FromYamlArray,
+		"toJson":        chartutil.ToJson,
+		"mustToJson":    chartutil.MustToJson,
+		"fromJson":      chartutil.FromJson,
+		"deepCopy":      deepCopy,
 
 		// This is a placeholder for the "include" function, which is
 		// late-bound to a template. By declaring it here, we preserve the
 		// integrity of the linter.
-		"include":  func(string, interface{}) string { return "not implemented" },
-		"required": func(string, interface{}) interface{} { return "not implemented" },
-		"tpl":      func(string, interface{}) interface{} { return "not implemented" },
+		"include":      func(string, interface{}) string { return "not implemented" },
+		"required":     func(string, interface{}) interface{} { return "not implemented" },
+		"tpl":          func(string, interface{}) interface{} { return "not implemented" },
+		"renderString": func(string, interface{}) string { return "not implemented" },
+
+		// This is a placeholder for the "lookup" function, which behaves as
+		// if no resource exists unless the Engine has a Lookup.
+		"lookup": func(string, string, string, string) (map[string]interface{}, error) {
+			return map[string]interface{}{}, nil
+		},
 	}
 
 	for k, v := range extra {
@@ -121,7 +175,21 @@ func FuncMap() template.FuncMap {
 func (e *Engine) Render(chrt *chart.Chart, values chartutil.Values) (map[string]string, error) {
 	// Render the charts
 	tmap := allTemplates(chrt, values)
-	return e.render(tmap)
+	e.recordScopes(tmap)
+	ce := *e
+	ce.optional = e.optionalPaths(chrt)
+	if !ce.Isolate && IsolationEnabled(chrt) {
+		ce.Isolate = true
+	}
+	return ce.render(tmap)
+}
+
+// RenderWithContext is Render, stopping with the error of ctx when ctx is
+// done before every template is rendered.
+func (e *Engine) RenderWithContext(ctx context.Context, chrt *chart.Chart, values chartutil.Values) (map[string]string, error) {
+	ce := *e
+	ce.ctx = ctx
+	return ce.Render(chrt, values)
 }
 
 // renderable is an object that can be rendered.
@@ -132,6 +200,9 @@ type renderable struct {
 	vals chartutil.Values
 	// basePath namespace prefix to the templates of the current chart
 	basePath string
+	// library is true for the templates of library charts, which are not
+	// rendered. They only define named templates.
+	library bool
 }
 
 // alterFuncMap takes the Engine's FuncMap and adds context-specific functions.
@@ -147,12 +218,34 @@ func (e *Engine) alterFuncMap(t *template.Template, referenceTpls map[string]ren
 	// Add the 'include' function here so we can close over t.
 	funcMap["include"] = func(name string, data interface{}) (string, error) {
 		buf := bytes.NewBuffer(nil)
-		if err := t.ExecuteTemplate(buf, name, data); err != nil {
+		if err := e.Trace.include(t, name, func() error { return t.ExecuteTemplate(buf, name, data) }); err != nil {
 			return "", err
 		}
 		return buf.String(), nil
 	}
 
+	if e.Lookup != nil {
+		funcMap["lookup"] = e.Lookup
+	}
+
+	// Add the 'renderString' function here so we can close over t. Unlike
+	// 'tpl', the context can be any value, e.g. the values of a subchart.
+	funcMap["renderString"] = func(tpl string, data interface{}) (string, error) {
+		c, err := t.Clone()
+		if err != nil {
+			return "", err
+		}
+		r, err := c.New("renderString").Parse(tpl)
+		if err != nil {
+			return "", fmt.Errorf("Error during renderString function execution for %q: %s", tpl, err)
+		}
+		buf := bytes.NewBuffer(nil)
+		if err := r.Execute(buf, data); err != nil {
+			return "", fmt.Errorf("Error during renderString function execution for %q: %s", tpl, err)
+		}
+		return buf.String(), nil
+	}
+
 	// Add the 'required' function here
 	funcMap["required"] = func(warn string, val interface{}) (interface{}, error) {
 		if val == nil {
@@ -209,7 +302,8 @@ func (e *Engine) alterFuncMap(t *template.Template, referenceTpls map[string]ren
 
 // render takes a map of templates/values and renders them.
 func (e *Engine) render(tpls map[string]renderable) (rendered map[string]string, err error) {
-	return e.renderWithReferences(tpls, tpls)
+	rendered, err = e.renderWithReferences(tpls, tpls)
+	return rendered, locateError(err, tpls)
 }
 
 // renderWithReferences takes a map of templates/values to render, and a map of
@@ -227,6 +321,31 @@ func (e *Engine) renderWithReferences(tpls map[string]renderable, referenceTpls
 			err = fmt.Errorf("rendering template failed: %v", r)
 		}
 	}()
+	p, err := e.parse(tpls, referenceTpls)
+	if err != nil {
+		return map[string]string{}, err
+	}
+	return e.executeTemplates(p.files, tpls, p.lookup)
+}
+
+// parsed are parsed templates, ready to be executed.
+type parsed struct {
+	// files are the parsed template files, in parse order.
+	files []string
+	// lookup returns the template set a file was parsed into.
+	lookup func(file string) *template.Template
+	// resolve returns the template that name refers to in the template file,
+	// or nil if there is none.
+	resolve func(file, name string) *template.Template
+}
+
+// parse parses the templates to render, and the templates which can be
+// referenced within them.
+func (e *Engine) parse(tpls map[string]renderable, referenceTpls map[string]renderable) (*parsed, error) {
+	if e.Isolate {
+		return e.parseIsolated(tpls, referenceTpls)
+	}
+
 	t := template.New("gotpl")
 	if e.Strict {
 		t.Option("missingkey=error")
@@ -246,47 +365,185 @@ func (e *Engine) renderWithReferences(tpls map[string]renderable, referenceTpls
 
 	for _, fname := range keys {
 		r := tpls[fname]
+		files = append(files, fname)
+		if !isGoTemplate(fname) {
+			// Lua and Jsonnet templates are executed by their own engines.
+			continue
+		}
 		t = t.New(fname).Funcs(funcMap)
 		if _, err := t.Parse(r.tpl); err != nil {
-			return map[string]string{}, fmt.Errorf("parse error in %q: %s", fname, err)
+			return nil, &fileError{kind: "parse", file: fname, err: err}
 		}
-		files = append(files, fname)
 	}
 
 	// Adding the reference templates to the template context
 	// so they can be referenced in the tpl function
 	for fname, r := range referenceTpls {
-		if t.Lookup(fname) == nil {
+		if isGoTemplate(fname) && t.Lookup(fname) == nil {
 			t = t.New(fname).Funcs(funcMap)
 			if _, err := t.Parse(r.tpl); err != nil {
-				return map[string]string{}, fmt.Errorf("parse error in %q: %s", fname, err)
+				return nil, &fileError{kind: "parse", file: fname, err: err}
 			}
 		}
 	}
 
-	rendered = make(map[string]string, len(files))
-	var buf bytes.Buffer
+	return &parsed{
+		files:   files,
+		lookup:  func(string) *template.Template { return t },
+		resolve: func(_, name string) *template.Template { return t.Lookup(name) },
+	}, nil
+}
+
+// executeTemplates renders the given files of tpls, using lookup to find the
+// template set each file was parsed into.
+//
+// Files are rendered by e.Workers goroutines, unless the rendering is traced.
+// The result does not depend on the number of workers, and if several files
+// fail to render, the error of the first one in files is returned.
+func (e *Engine) executeTemplates(files []string, tpls map[string]renderable, lookup func(file string) *template.Template) (map[string]string, error) {
+	// Don't render partials, Jsonnet libraries or the templates of library
+	// charts. We don't care about their direct output. They are only included
+	// from other templates.
+	var render []string
 	for _, file := range files {
-		// Don't render partials. We don't care about the direct output of partials.
-		// They are only included from other templates.
-		if strings.HasPrefix(path.Base(file), "_") {
+		if isRendered(file, tpls[file]) {
+			render = append(render, file)
+		}
+	}
+
+	results := make([]string, len(render))
+	errs := make([]error, len(render))
+	if e.Workers <= 1 || e.Trace != nil || len(render) < 2 {
+		var buf bytes.Buffer
+		for i, file := range render {
+			if results[i], errs[i] = e.executeTemplate(lookup(file), file, tpls, &buf); errs[i] != nil {
+				break
+			}
+		}
+	} else {
+		jobs := make(chan int)
+		var wg sync.WaitGroup
+		for w := 0; w < e.Workers && w < len(render); w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				var buf bytes.Buffer
+				for i := range jobs {
+					results[i], errs[i] = e.executeTemplate(lookup(render[i]), render[i], tpls, &buf)
+				}
+			}()
+		}
+		for i := range render {
+			jobs <- i
+		}
+		close(jobs)
+		wg.Wait()
+	}
+
+	rendered := make(map[string]string, len(render))
+	for i, file := range render {
+		if errs[i] != nil {
+			return map[string]string{}, errs[i]
+		}
+		rendered[file] = results[i]
+	}
+	return rendered, nil
+}
+
+// isMutableMap returns whether v is a map that templates can change, a map of
+// interface{} values like chartutil.Values.
+func isMutableMap(v interface{}) bool {
+	t := reflect.TypeOf(v)
+	return t != nil && t.Kind() == reflect.Map && t.Elem().Kind() == reflect.Interface
+}
+
+// isRendered returns whether the template file r is rendered, and is not
+// a partial, a Jsonnet library or a template of a library chart.
+func isRendered(file string, r renderable) bool {
+	return !strings.HasPrefix(path.Base(file), "_") && !r.library && path.Ext(file) != JsonnetLibraryExtension
+}
+
+// recordScopes sets e.Scopes, if not nil, to the values of the rendered
+// template files of tpls.
+func (e *Engine) recordScopes(tpls map[string]renderable) {
+	if e.Scopes == nil {
+		return
+	}
+	for file := range e.Scopes {
+		delete(e.Scopes, file)
+	}
+	for file, r := range tpls {
+		if !isRendered(file, r) {
 			continue
 		}
-		// At render time, add information about the template that is being rendered.
-		vals := tpls[file].vals
-		vals["Template"] = map[string]interface{}{"Name": file, "BasePath": tpls[file].basePath}
-		if err := t.ExecuteTemplate(&buf, file, vals); err != nil {
-			return map[string]string{}, fmt.Errorf("render error in %q: %s", file, err)
+		vals, err := r.vals.Table("Values")
+		if err != nil {
+			vals = chartutil.Values{}
 		}
+		e.Scopes[file] = vals
+	}
+}
 
-		// Work around the issue where Go will emit "<no value>" even if Options(missing=zero)
-		// is set. Since missing=error will never get here, we do not need to handle
-		// the Strict case.
-		rendered[file] = strings.Replace(buf.String(), "<no value>", "", -1)
-		buf.Reset()
+// executeTemplate renders the template file of t, using buf as scratch space.
+func (e *Engine) executeTemplate(t *template.Template, file string, tpls map[string]renderable, buf *bytes.Buffer) (out string, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("rendering template failed: %v", p)
+		}
+	}()
+	if e.ctx != nil {
+		if err := e.ctx.Err(); err != nil {
+			return "", err
+		}
+	}
+	buf.Reset()
+	r := tpls[file]
+
+	// At render time, add information about the template that is being
+	// rendered. The values are copied as templates of a chart share them.
+	// With several workers, every template is given its own copy of the maps
+	// it may change with set, unset or merge, like .Values, for the templates
+	// rendered in parallel not to write to the same maps.
+	vals := make(chartutil.Values, len(r.vals)+1)
+	for k, v := range r.vals {
+		if e.Workers > 1 && isMutableMap(v) {
+			v = deepCopy(v)
+		}
+		vals[k] = v
+	}
+	vals["Template"] = map[string]interface{}{"Name": file, "BasePath": r.basePath}
+	if !isGoTemplate(file) {
+		err := e.Trace.execute(t, file, func() (err error) {
+			if isLuaTemplate(file) {
+				out, err = e.executeLua(t, file, r.tpl, vals)
+			} else {
+				out, err = e.executeJsonnet(t, file, tpls, vals)
+			}
+			return err
+		})
+		if err != nil {
+			return "", &fileError{kind: "render", file: file, err: err}
+		}
+		return out, nil
+	}
+	execute := func() error { return t.ExecuteTemplate(buf, file, vals) }
+	if e.Strict {
+		execute = func() error { return e.executeStrict(t, file, r.basePath, vals, buf) }
+	}
+	if err := e.Trace.execute(t, file, execute); err != nil {
+		return "", &fileError{kind: "render", file: file, err: err}
 	}
 
-	return rendered, nil
+	// Work around the issue where Go will emit "<no value>" even if Options(missing=zero)
+	// is set. In the Strict case, the optional values that are not set are
+	// emitted as "<no value>" too.
+	return strings.Replace(buf.String(), "<no value>", "", -1), nil
+}
+
+// isGoTemplate returns true if the template file is a Go template, and not a
+// Lua or Jsonnet template.
+func isGoTemplate(file string) bool {
+	return !isLuaTemplate(file) && !isJsonnetTemplate(file)
 }
 
 func sortTemplates(tpls map[string]renderable) []string {
@@ -369,6 +626,7 @@ func recAllTpls(c *chart.Chart, templates map[string]renderable, parentVals char
 			tpl:      string(t.Data),
 			vals:     cvals,
 			basePath: path.Join(newParentID, "templates"),
+			library:  chartutil.IsLibraryChart(c),
 		}
 	}
 }
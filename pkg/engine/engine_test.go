@@ -17,7 +17,9 @@ limitations under the License.
 package engine
 
 import (
+	"context"
 	"fmt"
+	"reflect"
 	"sync"
 	"testing"
 
@@ -80,7 +82,7 @@ func TestFuncMap(t *testing.T) {
 	}
 
 	// Test for Engine-specific template functions.
-	expect := []string{"include", "required", "tpl", "toYaml", "fromYaml", "toToml", "toJson", "fromJson"}
+	expect := []string{"include", "required", "tpl", "lookup", "toYaml", "fromYaml", "toToml", "toJson", "fromJson"}
 	for _, f := range expect {
 		if _, ok := fns[f]; !ok {
 			t.Errorf("Expected add-on function %q", f)
@@ -88,6 +90,25 @@ func TestFuncMap(t *testing.T) {
 	}
 }
 
+func TestRenderWithContext(t *testing.T) {
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "moby"},
+		Templates: []*chart.Template{
+			{Name: "templates/test1", Data: []byte("{{.outer}}")},
+			{Name: "templates/test2", Data: []byte("{{.inner}}")},
+		},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	e := New()
+	if _, err := e.RenderWithContext(ctx, c, chartutil.Values{"outer": "spouter", "inner": "inn"}); err != context.Canceled {
+		t.Errorf("Expected the rendering to be canceled, got %v", err)
+	}
+	if _, err := e.Render(c, chartutil.Values{"outer": "spouter", "inner": "inn"}); err != nil {
+		t.Errorf("Expected the engine not to keep the context, got %v", err)
+	}
+}
+
 func TestRender(t *testing.T) {
 	c := &chart.Chart{
 		Metadata: &chart.Metadata{
@@ -200,6 +221,131 @@ func TestParallelRenderInternals(t *testing.T) {
 	wg.Wait()
 }
 
+func TestRenderWorkers(t *testing.T) {
+	c := &chart.Chart{
+		Metadata:  &chart.Metadata{Name: "moby"},
+		Templates: []*chart.Template{{Name: "templates/_helpers", Data: []byte(`{{define "name"}}{{.Template.Name}}{{end}}`)}},
+		Dependencies: []*chart.Chart{
+			{Metadata: &chart.Metadata{Name: "sub"}, Templates: []*chart.Template{{Name: "templates/x", Data: []byte(`{{.Values.x}} {{template "name" .}}`)}}},
+		},
+	}
+	for i := 0; i < 50; i++ {
+		name := fmt.Sprintf("templates/t%02d", i)
+		c.Templates = append(c.Templates, &chart.Template{Name: name, Data: []byte(`{{.Values.who | upper}} {{include "name" .}}`)})
+	}
+	vals := chartutil.Values{"Values": map[string]interface{}{"who": "ishmael", "sub": map[string]interface{}{"x": "y"}}}
+
+	serial, err := New().Render(c, vals)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := New()
+	e.Workers = 8
+	parallel, err := e.Render(c, vals)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(serial, parallel) {
+		t.Errorf("Expected parallel rendering to match serial rendering.\nserial:   %v\nparallel: %v", serial, parallel)
+	}
+	if got := parallel["moby/templates/t07"]; got != "ISHMAEL moby/templates/t07" {
+		t.Errorf("Expected template name to be set per template, got %q", got)
+	}
+}
+
+// TestRenderWorkersMutatingValues renders templates changing .Values in
+// parallel. Run with -race.
+func TestRenderWorkersMutatingValues(t *testing.T) {
+	c := &chart.Chart{Metadata: &chart.Metadata{Name: "moby"}}
+	for i := 0; i < 50; i++ {
+		c.Templates = append(c.Templates, &chart.Template{
+			Name: fmt.Sprintf("templates/t%02d", i),
+			Data: []byte(`{{$_ := set .Values "who" .Template.Name}}{{$_ := set .Values.crew "mate" .Template.Name}}{{$_ := unset .Values.crew "captain"}}{{$_ := merge .Values.ship (dict "name" .Template.Name)}}` +
+				`{{.Values.who}} {{.Values.crew.mate}} {{.Values.crew.captain}} {{.Values.ship.name}}`),
+		})
+	}
+	vals := chartutil.Values{"Values": map[string]interface{}{
+		"who":  "ishmael",
+		"crew": map[string]interface{}{"captain": "ahab"},
+		"ship": map[string]interface{}{},
+	}}
+
+	e := New()
+	e.Workers = 8
+	out, err := e.Render(c, vals)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 50; i++ {
+		name := fmt.Sprintf("moby/templates/t%02d", i)
+		if expect := fmt.Sprintf("%s %s  %s", name, name, name); out[name] != expect {
+			t.Errorf("Expected %q, got %q", expect, out[name])
+		}
+	}
+	expect := map[string]interface{}{
+		"who":  "ishmael",
+		"crew": map[string]interface{}{"captain": "ahab"},
+		"ship": map[string]interface{}{},
+	}
+	if !reflect.DeepEqual(vals["Values"], expect) {
+		t.Errorf("Expected the values not to be changed, got %v", vals["Values"])
+	}
+}
+
+func TestRenderWorkersError(t *testing.T) {
+	c := &chart.Chart{Metadata: &chart.Metadata{Name: "moby"}}
+	for i := 0; i < 20; i++ {
+		tpl := "ok"
+		if i%5 == 3 {
+			tpl = fmt.Sprintf(`{{fail "t%02d"}}`, i)
+		}
+		c.Templates = append(c.Templates, &chart.Template{Name: fmt.Sprintf("templates/t%02d", i), Data: []byte(tpl)})
+	}
+
+	_, serial := New().Render(c, chartutil.Values{})
+	if serial == nil {
+		t.Fatal("Expected rendering to fail")
+	}
+	e := New()
+	e.Workers = 4
+	for i := 0; i < 10; i++ {
+		if _, err := e.Render(c, chartutil.Values{}); err == nil || err.Error() != serial.Error() {
+			t.Fatalf("Expected the error of serial rendering %q, got %v", serial, err)
+		}
+	}
+}
+
+func TestRenderScopes(t *testing.T) {
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "moby"},
+		Templates: []*chart.Template{
+			{Name: "templates/_helpers", Data: []byte(`{{define "name"}}{{.Template.Name}}{{end}}`)},
+			{Name: "templates/main", Data: []byte(`{{.Values.who}}`)},
+		},
+		Dependencies: []*chart.Chart{
+			{Metadata: &chart.Metadata{Name: "sub"}, Templates: []*chart.Template{{Name: "templates/x", Data: []byte(`{{.Values.x}}`)}}},
+		},
+	}
+	vals := chartutil.Values{"Values": map[string]interface{}{
+		"who":    "ishmael",
+		"global": map[string]interface{}{"env": "prod"},
+		"sub":    map[string]interface{}{"x": "y", "global": map[string]interface{}{"env": "prod"}},
+	}}
+
+	e := New()
+	e.Scopes = map[string]chartutil.Values{"moby/templates/removed": {}}
+	if _, err := e.Render(c, vals); err != nil {
+		t.Fatal(err)
+	}
+	expect := map[string]chartutil.Values{
+		"moby/templates/main":         vals["Values"].(map[string]interface{}),
+		"moby/charts/sub/templates/x": {"x": "y", "global": map[string]interface{}{"env": "prod"}},
+	}
+	if !reflect.DeepEqual(e.Scopes, expect) {
+		t.Errorf("Expected the scopes\n%v\ngot\n%v", expect, e.Scopes)
+	}
+}
+
 func TestAllTemplates(t *testing.T) {
 	ch1 := &chart.Chart{
 		Metadata: &chart.Metadata{Name: "ch1"},
@@ -267,6 +413,37 @@ func TestRenderDependency(t *testing.T) {
 
 }
 
+func TestRenderLibraryDependency(t *testing.T) {
+	e := New()
+	ch := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "outerchart"},
+		Templates: []*chart.Template{
+			{Name: "templates/outer", Data: []byte(`Hello {{template "myblock"}}`)},
+		},
+		Dependencies: []*chart.Chart{
+			{
+				Metadata: &chart.Metadata{Name: "library", ApiVersion: "v2", Type: "library"},
+				Templates: []*chart.Template{
+					{Name: "templates/inner", Data: []byte(`{{define "myblock"}}World{{end}}Not rendered`)},
+				},
+			},
+		},
+	}
+
+	out, err := e.Render(ch, map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("failed to render chart: %s", err)
+	}
+
+	if len(out) != 1 {
+		t.Errorf("Expected the templates of the library chart not to be rendered, got %v", out)
+	}
+	expect := "Hello World"
+	if out["outerchart/templates/outer"] != expect {
+		t.Errorf("Expected %q, got %q", expect, out["outerchart/templates/outer"])
+	}
+}
+
 func TestRenderNestedValues(t *testing.T) {
 	e := New()
 
@@ -589,3 +766,59 @@ func TestAlterFuncMap(t *testing.T) {
 	}
 
 }
+
+func TestRenderLookup(t *testing.T) {
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "moby"},
+		Templates: []*chart.Template{
+			{Name: "templates/secret", Data: []byte(`{{ $s := lookup "v1" "Secret" "default" "db" }}{{ if $s }}{{ $s.data.password }}{{ else }}generated{{ end }}`)},
+		},
+	}
+
+	out, err := New().Render(c, chartutil.Values{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := out["moby/templates/secret"]; got != "generated" {
+		t.Errorf("Expected faked lookup to return no resource, got %q", got)
+	}
+
+	e := New()
+	e.Lookup = func(apiVersion, kind, namespace, name string) (map[string]interface{}, error) {
+		if apiVersion != "v1" || kind != "Secret" || namespace != "default" || name != "db" {
+			t.Errorf("Unexpected lookup of %s %s %s/%s", apiVersion, kind, namespace, name)
+		}
+		return map[string]interface{}{"data": map[string]interface{}{"password": "c2VjcmV0"}}, nil
+	}
+	out, err = e.Render(c, chartutil.Values{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := out["moby/templates/secret"]; got != "c2VjcmV0" {
+		t.Errorf("Expected existing password, got %q", got)
+	}
+}
+
+func TestRenderString(t *testing.T) {
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "moby"},
+		Templates: []*chart.Template{
+			{Name: "templates/_helpers", Data: []byte(`{{define "greet"}}Hello {{.}}{{end}}`)},
+			{Name: "templates/base", Data: []byte(`{{ renderString .Values.template .Values.sub }}`)},
+		},
+	}
+	vals := chartutil.Values{
+		"Values": map[string]interface{}{
+			"template": `{{ include "greet" .name }} from {{ .port }}`,
+			"sub":      map[string]interface{}{"name": "subchart", "port": 80},
+		},
+	}
+
+	out, err := New().Render(c, vals)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expect := "Hello subchart from 80"; out["moby/templates/base"] != expect {
+		t.Errorf("Expected %q, got %q", expect, out["moby/templates/base"])
+	}
+}
@@ -0,0 +1,181 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// TemplateError is an error parsing or rendering a template file of a chart.
+// It is located in the file where it occurred, which is the file defining the
+// included template for the errors raised inside an included template.
+type TemplateError struct {
+	// Kind is "parse" or "render".
+	Kind string
+	// Template is the template file parsed or rendered, e.g.
+	// "mychart/templates/deployment.yaml".
+	Template string
+	// File is the file of the error, relative to the chart, e.g.
+	// "templates/_helpers.tpl" or "charts/mysubchart/templates/service.yaml".
+	File string
+	// Line is the line of the error.
+	Line int
+	// Column is the column of the error, or 0 if it is unknown, as for the
+	// parse errors. Lines and columns start at 1.
+	Column int
+	// IncludedFrom are the locations of the calls including the template of
+	// the error, innermost first, e.g. "templates/deployment.yaml:12:8".
+	IncludedFrom []string
+	// Message is the message of the error, without its location.
+	Message string
+	// Excerpt are the lines of the file around the error, or empty if the
+	// source of the file is unknown.
+	Excerpt string
+}
+
+func (e *TemplateError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s error in %q: %s: %s", e.Kind, e.Template, location(e.File, e.Line, e.Column), e.Message)
+	for _, l := range e.IncludedFrom {
+		fmt.Fprintf(&b, "\n\tincluded from %s", l)
+	}
+	if e.Excerpt != "" {
+		b.WriteString("\n")
+		b.WriteString(e.Excerpt)
+	}
+	return b.String()
+}
+
+// fileError is an error parsing or rendering the template file. It is turned
+// into a TemplateError by locateError once the rendering fails, as the errors
+// of the 'tpl' function are nested in the errors of the templates calling it.
+type fileError struct {
+	kind string
+	file string
+	err  error
+}
+
+func (e *fileError) Error() string {
+	return fmt.Sprintf("%s error in %q: %s", e.kind, e.file, e.err)
+}
+
+// locationRegex matches the locations of the errors of Go templates, e.g.
+// "template: mychart/templates/x:3:10: ", or "template: mychart/templates/x:3: "
+// for parse errors.
+var locationRegex = regexp.MustCompile(`template: ([^:\n]+):(\d+)(?::(\d+))?: `)
+
+// nestedRegex matches the calls of the functions rendering templates that are
+// not files of the chart, whose errors are located in the rendered string.
+var nestedRegex = regexp.MustCompile(`error calling (tpl|renderString): `)
+
+// locateError returns err as a TemplateError if it is the fileError of a Go
+// template, reading the sources of the templates in tpls.
+func locateError(err error, tpls map[string]renderable) error {
+	fe, ok := err.(*fileError)
+	if !ok {
+		return err
+	}
+	msg := fe.err.Error()
+	matches := locationRegex.FindAllStringSubmatchIndex(msg, -1)
+	if len(matches) == 0 {
+		return err
+	}
+	// The innermost error is the last one in a file of the chart.
+	last := 0
+	for last+1 < len(matches) && !nestedRegex.MatchString(msg[matches[last][1]:matches[last+1][0]]) {
+		last++
+	}
+
+	te := &TemplateError{Kind: fe.kind, Template: fe.file}
+	for i, m := range matches[:last+1] {
+		name := msg[m[2]:m[3]]
+		line, _ := strconv.Atoi(msg[m[4]:m[5]])
+		col := 0
+		if m[6] >= 0 {
+			col, _ = strconv.Atoi(msg[m[6]:m[7]])
+			// Go templates count columns from 0.
+			col++
+		}
+		if i < last {
+			te.IncludedFrom = append([]string{location(chartRelative(name), line, col)}, te.IncludedFrom...)
+			continue
+		}
+		te.File, te.Line, te.Column = chartRelative(name), line, col
+		te.Message = msg[m[1]:]
+		if r, ok := tpls[name]; ok {
+			te.Excerpt = excerpt(r.tpl, line, col)
+		}
+	}
+	return te
+}
+
+// location returns the location of the line and column of file.
+func location(file string, line, col int) string {
+	if col == 0 {
+		return fmt.Sprintf("%s:%d", file, line)
+	}
+	return fmt.Sprintf("%s:%d:%d", file, line, col)
+}
+
+// chartRelative returns the path of the template name relative to the chart,
+// e.g. "templates/x" for "mychart/templates/x".
+func chartRelative(name string) string {
+	if i := strings.Index(name, "/"); i >= 0 {
+		return name[i+1:]
+	}
+	return name
+}
+
+// excerpt returns the lines of src around line, with the line marked by ">"
+// and the column by "^" if col is not 0.
+func excerpt(src string, line, col int) string {
+	lines := strings.Split(src, "\n")
+	if line < 1 || line > len(lines) {
+		return ""
+	}
+	first, last := line-1, line+1
+	if first < 1 {
+		first = 1
+	}
+	if last > len(lines) {
+		last = len(lines)
+	}
+	width := len(strconv.Itoa(last))
+
+	var b strings.Builder
+	for n := first; n <= last; n++ {
+		marker := " "
+		if n == line {
+			marker = ">"
+		}
+		fmt.Fprintln(&b, strings.TrimRight(fmt.Sprintf("\t%s %*d | %s", marker, width, n, lines[n-1]), " "))
+		if n == line && col > 0 && col <= len(lines[n-1])+1 {
+			// Tabs are kept for the caret to line up with the column.
+			indent := strings.Map(func(r rune) rune {
+				if r == '\t' {
+					return r
+				}
+				return ' '
+			}, lines[n-1][:col-1])
+			fmt.Fprintf(&b, "\t  %*s | %s^\n", width, "", indent)
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
@@ -0,0 +1,100 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+func TestRenderTemplateError(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		tpls   []*chart.Template
+		expect TemplateError
+	}{
+		{
+			name: "parse error",
+			tpls: []*chart.Template{{Name: "templates/pod.yaml", Data: []byte("kind: Pod\nimage: {{ .Values.image | upper }\n")}},
+			expect: TemplateError{
+				Kind:     "parse",
+				Template: "moby/templates/pod.yaml",
+				File:     "templates/pod.yaml",
+				Line:     2,
+				Message:  `unexpected "}" in operand`,
+				Excerpt:  "\t  1 | kind: Pod\n\t> 2 | image: {{ .Values.image | upper }\n\t  3 |",
+			},
+		},
+		{
+			name: "error in an included template",
+			tpls: []*chart.Template{
+				{Name: "templates/_helpers.tpl", Data: []byte(`{{define "moby.name"}}{{required "name is required" .Values.name}}{{end}}`)},
+				{Name: "templates/pod.yaml", Data: []byte("kind: Pod\nname: {{include \"moby.name\" .}}\n")},
+			},
+			expect: TemplateError{
+				Kind:         "render",
+				Template:     "moby/templates/pod.yaml",
+				File:         "templates/_helpers.tpl",
+				Line:         1,
+				Column:       25,
+				IncludedFrom: []string{"templates/pod.yaml:2:9"},
+				Message:      "error calling required: name is required",
+				Excerpt:      "\t> 1 | {{define \"moby.name\"}}{{required \"name is required\" .Values.name}}{{end}}\n\t    |                         ^",
+			},
+		},
+	} {
+		c := &chart.Chart{Metadata: &chart.Metadata{Name: "moby"}, Templates: tt.tpls}
+		_, err := New().Render(c, chartutil.Values{"Values": map[string]interface{}{}})
+		te, ok := err.(*TemplateError)
+		if !ok {
+			t.Errorf("%s: Expected a TemplateError, got %v", tt.name, err)
+			continue
+		}
+		// The message of an execution error starts with the call, which Go
+		// may truncate.
+		if !strings.HasSuffix(te.Message, tt.expect.Message) {
+			t.Errorf("%s: Expected the message to end with %q, got %q", tt.name, tt.expect.Message, te.Message)
+		}
+		te.Message = tt.expect.Message
+		if !reflect.DeepEqual(*te, tt.expect) {
+			t.Errorf("%s: Expected\n%#v\ngot\n%#v", tt.name, tt.expect, *te)
+		}
+		if !strings.HasPrefix(te.Error(), tt.expect.Kind+" error in \"moby/templates/pod.yaml\": "+tt.expect.File) {
+			t.Errorf("%s: Unexpected message %q", tt.name, te.Error())
+		}
+	}
+}
+
+func TestRenderTemplateErrorInTpl(t *testing.T) {
+	c := &chart.Chart{
+		Metadata:  &chart.Metadata{Name: "moby"},
+		Templates: []*chart.Template{{Name: "templates/pod.yaml", Data: []byte(`kind: {{ tpl "{{ .Values.missing.x }}" . }}`)}},
+	}
+	_, err := New().Render(c, chartutil.Values{"Values": map[string]interface{}{}})
+	te, ok := err.(*TemplateError)
+	if !ok {
+		t.Fatalf("Expected a TemplateError, got %v", err)
+	}
+	// The error is located at the call of tpl, not in the rendered string.
+	if te.File != "templates/pod.yaml" || te.Line != 1 || te.Column != 10 || len(te.IncludedFrom) != 0 {
+		t.Errorf("Expected the error at templates/pod.yaml:1:10, got %s", te)
+	}
+}
@@ -0,0 +1,129 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"reflect"
+	"sort"
+)
+
+// Sources of the template functions.
+const (
+	// SourceEngine marks the functions bound by the Engine when rendering.
+	SourceEngine = "engine"
+	// SourceHelm marks the other functions provided by Helm.
+	SourceHelm = "helm"
+	// SourceSprig marks the functions of the Sprig library.
+	SourceSprig = "sprig"
+)
+
+// Function describes a template function available to charts.
+type Function struct {
+	Name string `json:"name"`
+	// Source is SourceEngine, SourceHelm or SourceSprig.
+	Source string `json:"source"`
+	// Signature is the Go signature of the function, e.g. "func(string) string".
+	Signature string `json:"signature"`
+	// Usage is the syntax of the function. It is only set for Helm functions.
+	Usage string `json:"usage,omitempty"`
+	// Description is a one line description. It is only set for Helm functions.
+	Description string `json:"description,omitempty"`
+}
+
+// helmFunctions describes the functions Helm adds to the Sprig library. The
+// signature of the engine functions is set here, as the FuncMap only has
+// placeholders for them.
+var helmFunctions = []Function{
+	{Name: "deepCopy", Usage: "deepCopy VALUE", Description: "Returns a copy of the maps and lists of VALUE that can be modified without changing VALUE"},
+	{Name: "fromJson", Usage: "fromJson STRING", Description: "Parses a JSON object into a dict"},
+	{Name: "fromYaml", Usage: "fromYaml STRING", Description: "Parses a YAML map into a dict"},
+	{Name: "fromYamlArray", Usage: "fromYamlArray STRING", Description: "Parses a YAML sequence into a list"},
+	{Name: "include", Source: SourceEngine, Signature: "func(string, interface {}) (string, error)", Usage: "include NAME CONTEXT", Description: "Renders the named template NAME with CONTEXT and returns the result"},
+	{Name: "lookup", Source: SourceEngine, Signature: "func(string, string, string, string) (map[string]interface {}, error)", Usage: "lookup API_VERSION KIND NAMESPACE NAME", Description: "Returns a resource of the cluster, or an empty dict if it does not exist or lookups are disabled"},
+	{Name: "mustToJson", Usage: "mustToJson VALUE", Description: "Encodes VALUE as JSON and fails the rendering on error"},
+	{Name: "renderString", Source: SourceEngine, Signature: "func(string, interface {}) (string, error)", Usage: "renderString TEMPLATE CONTEXT", Description: "Renders the string TEMPLATE with CONTEXT, such as the values of a subchart, and the named templates of the chart"},
+	{Name: "required", Source: SourceEngine, Signature: "func(string, interface {}) (interface {}, error)", Usage: "required MESSAGE VALUE", Description: "Fails the rendering with MESSAGE if VALUE is empty"},
+	{Name: "toJson", Usage: "toJson VALUE", Description: "Encodes VALUE as JSON"},
+	{Name: "toToml", Usage: "toToml VALUE", Description: "Encodes VALUE as TOML"},
+	{Name: "toYaml", Usage: "toYaml VALUE", Description: "Encodes VALUE as YAML"},
+	{Name: "toYamlPretty", Usage: "toYamlPretty VALUE", Description: "Encodes VALUE as YAML, indenting list items under their key"},
+	{Name: "tpl", Source: SourceEngine, Signature: "func(string, chartutil.Values) (string, error)", Usage: "tpl TEMPLATE VALUES", Description: "Renders the string TEMPLATE with the top level context VALUES"},
+}
+
+// Functions returns the template functions available to charts, sorted by
+// name. Helm functions replace the Sprig functions of the same name.
+func Functions() []Function {
+	funcs := map[string]Function{}
+	for name, fn := range FuncMap() {
+		funcs[name] = Function{Name: name, Source: SourceSprig, Signature: reflect.TypeOf(fn).String()}
+	}
+	for _, f := range helmFunctions {
+		if f.Source == "" {
+			f.Source = SourceHelm
+		}
+		if f.Signature == "" {
+			f.Signature = funcs[f.Name].Signature
+		}
+		funcs[f.Name] = f
+	}
+
+	list := make([]Function, 0, len(funcs))
+	for _, f := range funcs {
+		list = append(list, f)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// deepCopy returns a copy of v in which maps and slices are copied
+// recursively.
+func deepCopy(v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	return copyValue(reflect.ValueOf(v)).Interface()
+}
+
+func copyValue(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		return copyValue(v.Elem())
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		for _, k := range v.MapKeys() {
+			c.SetMapIndex(k, copyValue(v.MapIndex(k)))
+		}
+		return c
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(copyValue(v.Index(i)))
+		}
+		return c
+	default:
+		return v
+	}
+}
@@ -0,0 +1,84 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/helm/pkg/chartutil"
+)
+
+func TestFunctions(t *testing.T) {
+	funcs := map[string]Function{}
+	for _, f := range Functions() {
+		funcs[f.Name] = f
+	}
+	if len(funcs) != len(FuncMap()) {
+		t.Errorf("Expected %d functions, got %d", len(FuncMap()), len(funcs))
+	}
+
+	for _, name := range []string{"include", "lookup", "renderString", "required", "tpl"} {
+		if f := funcs[name]; f.Source != SourceEngine || f.Usage == "" || f.Description == "" {
+			t.Errorf("Expected documented engine function %q, got %+v", name, f)
+		}
+	}
+	for _, name := range []string{"toYaml", "toYamlPretty", "deepCopy"} {
+		if f := funcs[name]; f.Source != SourceHelm || f.Usage == "" || f.Description == "" {
+			t.Errorf("Expected documented Helm function %q, got %+v", name, f)
+		}
+	}
+	for name, f := range funcs {
+		if f.Signature == "" {
+			t.Errorf("Expected signature for %q", name)
+		}
+	}
+	if s := funcs["upper"].Signature; s != "func(string) string" {
+		t.Errorf("Expected signature of upper, got %q", s)
+	}
+	for _, name := range []string{"mergeOverwrite", "regexSplit", "upper"} {
+		if f := funcs[name]; f.Source != SourceSprig {
+			t.Errorf("Expected Sprig function %q, got %+v", name, f)
+		}
+	}
+	for _, f := range helmFunctions {
+		if _, ok := FuncMap()[f.Name]; !ok {
+			t.Errorf("Documented function %q is not in the FuncMap", f.Name)
+		}
+	}
+}
+
+func TestDeepCopy(t *testing.T) {
+	orig := chartutil.Values{
+		"list": []interface{}{map[string]interface{}{"a": 1}, nil},
+		"map":  map[string]interface{}{"b": "c", "nil": nil},
+	}
+	c := deepCopy(orig).(chartutil.Values)
+	if !reflect.DeepEqual(c, orig) {
+		t.Fatalf("Expected %v, got %v", orig, c)
+	}
+
+	c["map"].(map[string]interface{})["b"] = "changed"
+	c["list"].([]interface{})[0].(map[string]interface{})["a"] = 2
+	if orig["map"].(map[string]interface{})["b"] != "c" || orig["list"].([]interface{})[0].(map[string]interface{})["a"] != 1 {
+		t.Errorf("Expected original to be unchanged, got %v", orig)
+	}
+
+	if deepCopy(nil) != nil {
+		t.Error("Expected nil copy of nil")
+	}
+}
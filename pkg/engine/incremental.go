@@ -0,0 +1,480 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"text/template/parse"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+// Renderer renders the templates of a chart, and then re-renders only the
+// templates affected by changes of the chart or of its values. It is meant
+// for development loops such as 'helm template --watch'.
+//
+// The parsed templates are kept between renders, and the templates are only
+// parsed again when a template file changes. What a template depends on is
+// found statically: the template files defining it and the named templates it
+// includes, and the values they reference. A template that calls tpl or
+// renderString, or includes a template whose name is not a string literal, is
+// re-rendered on every change.
+//
+// A Renderer must not be used concurrently.
+type Renderer struct {
+	engine Engine
+	// isolate is true if isolation is enabled by the engine, not the chart.
+	isolate bool
+
+	tpls     map[string]renderable
+	parsed   *parsed
+	deps     map[string]*templateDeps
+	rendered map[string]string
+}
+
+// NewRenderer renders the templates of chrt with values, like Render, and
+// returns a Renderer to render them again after changes.
+func (e *Engine) NewRenderer(chrt *chart.Chart, values chartutil.Values) (*Renderer, error) {
+	r := &Renderer{engine: *e, isolate: e.Isolate, rendered: map[string]string{}}
+	if _, err := r.Update(chrt, values); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Rendered returns the rendered templates, by template name.
+func (r *Renderer) Rendered() map[string]string {
+	rendered := make(map[string]string, len(r.rendered))
+	for name, out := range r.rendered {
+		rendered[name] = out
+	}
+	return rendered
+}
+
+// Update renders the templates of chrt with values, the new versions of the
+// chart and values last rendered. Only the templates affected by the changes
+// are rendered, and their names are returned in sorted order.
+//
+// If rendering fails, the next update renders all the templates.
+func (r *Renderer) Update(chrt *chart.Chart, values chartutil.Values) (rerendered []string, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			r.parsed = nil
+			err = fmt.Errorf("rendering template failed: %v", p)
+		}
+	}()
+
+	tpls := allTemplates(chrt, values)
+	r.engine.recordScopes(tpls)
+	r.engine.optional = r.engine.optionalPaths(chrt)
+	isolate := r.isolate || IsolationEnabled(chrt)
+
+	// changedFiles are the template files added, removed or modified.
+	changedFiles := map[string]bool{}
+	for name, t := range tpls {
+		if old, ok := r.tpls[name]; !ok || old.tpl != t.tpl {
+			changedFiles[name] = true
+		}
+	}
+	for name := range r.tpls {
+		if _, ok := tpls[name]; !ok {
+			changedFiles[name] = true
+		}
+	}
+
+	all := r.parsed == nil || isolate != r.engine.Isolate
+	oldDeps := r.deps
+	if all || len(changedFiles) > 0 {
+		r.parsed = nil
+		r.engine.Isolate = isolate
+		p, err := r.engine.parse(tpls, tpls)
+		if err != nil {
+			return nil, locateError(err, tpls)
+		}
+		r.parsed = p
+		r.deps = map[string]*templateDeps{}
+		for _, file := range p.files {
+			r.deps[file] = findDeps(p, file)
+		}
+	}
+
+	// changedValues are the paths of the changed values, by chart.
+	changedValues := map[string][]string{}
+	var dirty []string
+	for _, file := range r.parsed.files {
+		old, ok := r.tpls[file]
+		if all || !ok || changedFiles[file] || old.library != tpls[file].library {
+			dirty = append(dirty, file)
+			continue
+		}
+		id := chartID(tpls[file])
+		paths, ok := changedValues[id]
+		if !ok {
+			paths = valueChanges(old.vals, tpls[file].vals)
+			changedValues[id] = paths
+		}
+		if r.deps[file].affected(changedFiles, paths) || oldDeps[file].affected(changedFiles, nil) {
+			dirty = append(dirty, file)
+		}
+	}
+
+	rendered, err := r.engine.executeTemplates(dirty, tpls, r.parsed.lookup)
+	if err != nil {
+		r.parsed = nil
+		return nil, locateError(err, tpls)
+	}
+
+	// Remove the output of the removed templates, and of the templates of
+	// charts that became library charts.
+	for name := range r.rendered {
+		if t, ok := tpls[name]; !ok || t.library {
+			delete(r.rendered, name)
+		}
+	}
+	for name, out := range rendered {
+		r.rendered[name] = out
+		rerendered = append(rerendered, name)
+	}
+	sort.Strings(rerendered)
+	r.tpls = tpls
+	return rerendered, nil
+}
+
+// templateDeps are what a template depends on.
+type templateDeps struct {
+	// files are the template files defining the template and the named
+	// templates it includes.
+	files map[string]bool
+	// values are the paths of the values referenced, e.g. "Values.image".
+	// The top level context is referenced as "Values".
+	values []string
+	// other is true if the template references the top level context other
+	// than its values, e.g. .Release or .Files.
+	other bool
+	// dynamic is true if the dependencies cannot be found statically.
+	dynamic bool
+}
+
+// affected returns true if the template must be rendered again after the
+// given template files and value paths changed. A value path "*" means that
+// the context other than the values changed.
+func (d *templateDeps) affected(files map[string]bool, values []string) bool {
+	if d == nil {
+		return false
+	}
+	if d.dynamic && (len(files) > 0 || len(values) > 0) {
+		return true
+	}
+	for file := range d.files {
+		if files[file] {
+			return true
+		}
+	}
+	for _, changed := range values {
+		if changed == "*" {
+			if d.other {
+				return true
+			}
+			continue
+		}
+		for _, p := range d.values {
+			if p == changed || strings.HasPrefix(changed, p+".") || strings.HasPrefix(p, changed+".") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// valueChanges returns the paths of the values that differ between old and
+// new, e.g. "Values.image.tag", or "*" if the context other than the values
+// differs.
+func valueChanges(old, new chartutil.Values) []string {
+	var paths []string
+	diffValues(old["Values"], new["Values"], "Values", &paths)
+	for _, vals := range []chartutil.Values{old, new} {
+		for k := range vals {
+			if k != "Values" && !reflect.DeepEqual(old[k], new[k]) {
+				return append(paths, "*")
+			}
+		}
+	}
+	return paths
+}
+
+func diffValues(a, b interface{}, path string, paths *[]string) {
+	am, aok := valuesMap(a)
+	bm, bok := valuesMap(b)
+	if !aok || !bok {
+		if !reflect.DeepEqual(a, b) {
+			*paths = append(*paths, path)
+		}
+		return
+	}
+	for k, v := range am {
+		diffValues(v, bm[k], path+"."+k, paths)
+	}
+	for k := range bm {
+		if _, ok := am[k]; !ok {
+			*paths = append(*paths, path+"."+k)
+		}
+	}
+}
+
+func valuesMap(v interface{}) (map[string]interface{}, bool) {
+	switch m := v.(type) {
+	case map[string]interface{}:
+		return m, true
+	case chartutil.Values:
+		return m, true
+	}
+	return nil, false
+}
+
+// Contexts of the nodes of a template, besides the paths of values.
+const (
+	// ctxRoot is the top level context.
+	ctxRoot = "$"
+	// ctxOther is a value that is not part of the values, e.g. .Release.
+	ctxOther = "-"
+	// ctxUnknown is a value that cannot be found statically.
+	ctxUnknown = "?"
+)
+
+// findDeps returns what the template file of p depends on.
+func findDeps(p *parsed, file string) *templateDeps {
+	w := &depsWalker{
+		p:      p,
+		deps:   &templateDeps{files: map[string]bool{file: true}},
+		values: map[string]bool{},
+		seen:   map[string]bool{},
+	}
+	if !isGoTemplate(file) {
+		// Lua and Jsonnet templates may read any value.
+		w.deps.dynamic = true
+	} else if t := p.lookup(file).Lookup(file); t != nil {
+		w.visit(t.Tree, ctxRoot)
+	}
+	for v := range w.values {
+		w.deps.values = append(w.deps.values, v)
+	}
+	sort.Strings(w.deps.values)
+	return w.deps
+}
+
+// depsWalker walks the parse trees of a template and of the named templates
+// it includes, tracking the context of the nodes.
+type depsWalker struct {
+	p      *parsed
+	deps   *templateDeps
+	values map[string]bool
+	// seen are the trees visited, with the context they were visited with.
+	seen map[string]bool
+}
+
+// scope is the context of a node: the value of dot and of the variables.
+type scope struct {
+	dot  string
+	vars map[string]string
+}
+
+func (s scope) with(dot string) scope {
+	vars := make(map[string]string, len(s.vars))
+	for k, v := range s.vars {
+		vars[k] = v
+	}
+	return scope{dot: dot, vars: vars}
+}
+
+func (w *depsWalker) visit(tree *parse.Tree, dot string) {
+	if tree == nil || w.seen[tree.Name+"\x00"+dot] {
+		return
+	}
+	w.seen[tree.Name+"\x00"+dot] = true
+	w.deps.files[tree.ParseName] = true
+	w.walk(tree.ParseName, tree.Root, scope{dot: dot, vars: map[string]string{"$": ctxRoot}})
+}
+
+// include visits the named template name called from file with context ctx.
+func (w *depsWalker) include(file, name, ctx string) {
+	if t := w.p.resolve(file, name); t != nil {
+		w.visit(t.Tree, ctx)
+	}
+}
+
+// ref records a reference to a value with context ctx.
+func (w *depsWalker) ref(ctx string) {
+	switch ctx {
+	case ctxRoot, ctxUnknown:
+		w.values["Values"] = true
+		w.deps.other = true
+	case ctxOther:
+		w.deps.other = true
+	default:
+		w.values[ctx] = true
+	}
+}
+
+func (w *depsWalker) walk(file string, node parse.Node, s scope) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, c := range n.Nodes {
+			w.walk(file, c, s)
+		}
+	case *parse.ActionNode:
+		w.walkPipe(file, n.Pipe, s)
+	case *parse.IfNode:
+		w.walkPipe(file, n.Pipe, s)
+		w.walk(file, n.List, s.with(s.dot))
+		w.walk(file, n.ElseList, s.with(s.dot))
+	case *parse.WithNode:
+		inner := s.with(s.dot)
+		w.walkPipe(file, n.Pipe, inner)
+		inner.dot = s.context(n.Pipe)
+		w.walk(file, n.List, inner)
+		w.walk(file, n.ElseList, s.with(s.dot))
+	case *parse.RangeNode:
+		inner := s.with(s.dot)
+		w.walkPipe(file, n.Pipe, inner)
+		elem := s.context(n.Pipe)
+		inner.dot = elem
+		switch len(n.Pipe.Decl) {
+		case 1:
+			inner.vars[n.Pipe.Decl[0].Ident[0]] = elem
+		case 2:
+			inner.vars[n.Pipe.Decl[0].Ident[0]] = ctxOther
+			inner.vars[n.Pipe.Decl[1].Ident[0]] = elem
+		}
+		w.walk(file, n.List, inner)
+		w.walk(file, n.ElseList, s.with(s.dot))
+	case *parse.TemplateNode:
+		ctx := s.context(n.Pipe)
+		if ctx == ctxUnknown {
+			w.walkPipe(file, n.Pipe, s)
+		}
+		w.include(file, n.Name, ctx)
+	}
+}
+
+// walkPipe walks the commands of pipe and declares its variables in s.
+func (w *depsWalker) walkPipe(file string, pipe *parse.PipeNode, s scope) {
+	if pipe == nil {
+		return
+	}
+	for _, cmd := range pipe.Cmds {
+		w.walkCommand(file, cmd, s)
+	}
+	for _, v := range pipe.Decl {
+		s.vars[v.Ident[0]] = s.context(pipe)
+	}
+}
+
+func (w *depsWalker) walkCommand(file string, cmd *parse.CommandNode, s scope) {
+	args := cmd.Args
+	if id, ok := args[0].(*parse.IdentifierNode); ok {
+		switch id.Ident {
+		case "include":
+			if len(args) < 2 {
+				return
+			}
+			name, ok := args[1].(*parse.StringNode)
+			if !ok {
+				w.deps.dynamic = true
+				break
+			}
+			ctx := ctxOther
+			if len(args) > 2 {
+				ctx = s.context(args[2])
+				if ctx == ctxUnknown {
+					w.walkArgs(file, args[2:], s)
+				}
+			}
+			w.include(file, name.Text, ctx)
+			return
+		case "tpl", "renderString":
+			w.deps.dynamic = true
+		}
+		args = args[1:]
+	}
+	w.walkArgs(file, args, s)
+}
+
+func (w *depsWalker) walkArgs(file string, args []parse.Node, s scope) {
+	for _, arg := range args {
+		switch a := arg.(type) {
+		case *parse.PipeNode:
+			w.walkPipe(file, a, s.with(s.dot))
+		case *parse.ChainNode:
+			w.walkArgs(file, []parse.Node{a.Node}, s)
+		case *parse.DotNode, *parse.FieldNode, *parse.VariableNode:
+			w.ref(s.context(a))
+		}
+	}
+}
+
+// context returns the context of the value of node.
+func (s scope) context(node parse.Node) string {
+	switch n := node.(type) {
+	case *parse.DotNode:
+		return s.dot
+	case *parse.FieldNode:
+		return field(s.dot, n.Ident)
+	case *parse.VariableNode:
+		v, ok := s.vars[n.Ident[0]]
+		if !ok {
+			return ctxUnknown
+		}
+		return field(v, n.Ident[1:])
+	case *parse.ChainNode:
+		return field(s.context(n.Node), n.Field)
+	case *parse.PipeNode:
+		if n == nil {
+			return ctxOther
+		}
+		if len(n.Cmds) == 1 && len(n.Cmds[0].Args) == 1 {
+			return s.context(n.Cmds[0].Args[0])
+		}
+		return ctxUnknown
+	case *parse.StringNode, *parse.NumberNode, *parse.BoolNode, *parse.NilNode:
+		return ctxOther
+	}
+	return ctxUnknown
+}
+
+// field returns the context of the field ident of a value with context ctx.
+func field(ctx string, ident []string) string {
+	if len(ident) == 0 {
+		return ctx
+	}
+	switch ctx {
+	case ctxRoot:
+		if ident[0] != "Values" {
+			return ctxOther
+		}
+		return strings.Join(ident, ".")
+	case ctxOther, ctxUnknown:
+		return ctx
+	}
+	return ctx + "." + strings.Join(ident, ".")
+}
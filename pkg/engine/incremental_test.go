@@ -0,0 +1,179 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+func TestFindDeps(t *testing.T) {
+	tests := []struct {
+		tpl     string
+		values  []string
+		other   bool
+		dynamic bool
+	}{
+		{tpl: `{{ .Values.image.tag }}`, values: []string{"Values.image.tag"}},
+		{tpl: `{{ $.Values.a | quote }} {{ .Release.Name }}`, values: []string{"Values.a"}, other: true},
+		{tpl: `{{ with .Values.sub }}{{ .name }}{{ end }}`, values: []string{"Values.sub", "Values.sub.name"}},
+		{tpl: `{{ range $k, $v := .Values.env }}{{ $k }}={{ $v.value }}{{ end }}`, values: []string{"Values.env", "Values.env.value"}, other: true},
+		{tpl: `{{ $img := .Values.image }}{{ $img.repo }}`, values: []string{"Values.image", "Values.image.repo"}},
+		{tpl: `{{ toYaml .Values }}`, values: []string{"Values"}},
+		{tpl: `{{ toYaml . }}`, values: []string{"Values"}, other: true},
+		{tpl: `{{ include "labels" . }}`, values: []string{"Values.app"}},
+		{tpl: `{{ include "labels" (dict "Values" .Values.sub) }}`, values: []string{"Values", "Values.sub"}, other: true},
+		{tpl: `{{ template "labels" .Values.sub }}`, values: []string{"Values.sub.Values.app"}},
+		{tpl: `{{ tpl .Values.t . }}`, values: []string{"Values", "Values.t"}, other: true, dynamic: true},
+		{tpl: `{{ include .Values.name . }}`, values: []string{"Values", "Values.name"}, other: true, dynamic: true},
+	}
+
+	for _, tt := range tests {
+		tpls := map[string]renderable{
+			"moby/templates/t":        {tpl: tt.tpl},
+			"moby/templates/_helpers": {tpl: `{{ define "labels" }}app: {{ .Values.app }}{{ end }}`},
+		}
+		p, err := New().parse(tpls, tpls)
+		if err != nil {
+			t.Fatal(err)
+		}
+		d := findDeps(p, "moby/templates/t")
+		if !reflect.DeepEqual(d.values, tt.values) || d.other != tt.other || d.dynamic != tt.dynamic {
+			t.Errorf("%s: expected values %v, other %t, dynamic %t, got %v, %t, %t", tt.tpl, tt.values, tt.other, tt.dynamic, d.values, d.other, d.dynamic)
+		}
+	}
+}
+
+func TestRendererUpdate(t *testing.T) {
+	newChart := func(helpers string) *chart.Chart {
+		return &chart.Chart{
+			Metadata: &chart.Metadata{Name: "moby"},
+			Templates: []*chart.Template{
+				{Name: "templates/_helpers", Data: []byte(helpers)},
+				{Name: "templates/image", Data: []byte(`{{ .Values.image.repo }}:{{ .Values.image.tag }}`)},
+				{Name: "templates/labels", Data: []byte(`{{ include "labels" . }}`)},
+				{Name: "templates/release", Data: []byte(`{{ .Release.Name }}`)},
+				{Name: "templates/tpl", Data: []byte(`{{ tpl "{{ .Values.app }}" . }}`)},
+			},
+			Dependencies: []*chart.Chart{{
+				Metadata:  &chart.Metadata{Name: "sub"},
+				Templates: []*chart.Template{{Name: "templates/port", Data: []byte(`{{ .Values.port }}`)}},
+			}},
+		}
+	}
+	newValues := func(tag, release string, port int) chartutil.Values {
+		return chartutil.Values{
+			"Release": map[string]interface{}{"Name": release},
+			"Values": map[string]interface{}{
+				"app":   "moby",
+				"image": map[string]interface{}{"repo": "nginx", "tag": tag},
+				"sub":   map[string]interface{}{"port": port},
+			},
+		}
+	}
+	labels := `{{ define "labels" }}app: {{ .Values.app }}{{ end }}`
+
+	r, err := New().NewRenderer(newChart(labels), newValues("1.0", "a", 80))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		chart      *chart.Chart
+		values     chartutil.Values
+		rerendered []string
+	}{
+		{"nothing changed", newChart(labels), newValues("1.0", "a", 80), nil},
+		{"value changed", newChart(labels), newValues("1.1", "a", 80), []string{"moby/templates/image", "moby/templates/tpl"}},
+		{"subchart value changed", newChart(labels), newValues("1.1", "a", 8080), []string{"moby/charts/sub/templates/port", "moby/templates/tpl"}},
+		{"release changed", newChart(labels), newValues("1.1", "b", 8080), []string{"moby/templates/release", "moby/templates/tpl"}},
+		{"named template changed", newChart(`{{ define "labels" }}name: {{ .Values.app }}{{ end }}`), newValues("1.1", "b", 8080), []string{"moby/templates/labels", "moby/templates/tpl"}},
+	}
+	for _, tt := range tests {
+		rerendered, err := r.Update(tt.chart, tt.values)
+		if err != nil {
+			t.Fatalf("%s: %s", tt.name, err)
+		}
+		if !reflect.DeepEqual(rerendered, tt.rerendered) {
+			t.Errorf("%s: expected %v to be rendered again, got %v", tt.name, tt.rerendered, rerendered)
+		}
+		expect, err := New().Render(tt.chart, tt.values)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := r.Rendered(); !reflect.DeepEqual(got, expect) {
+			t.Errorf("%s: expected %v, got %v", tt.name, expect, got)
+		}
+	}
+}
+
+func TestRendererUpdateLibrary(t *testing.T) {
+	newChart := func(subType string) *chart.Chart {
+		return &chart.Chart{
+			Metadata:  &chart.Metadata{Name: "moby"},
+			Templates: []*chart.Template{{Name: "templates/name", Data: []byte(`{{ include "name" . }}`)}},
+			Dependencies: []*chart.Chart{{
+				Metadata:  &chart.Metadata{Name: "sub", ApiVersion: "v2", Type: subType},
+				Templates: []*chart.Template{{Name: "templates/name", Data: []byte(`{{ define "name" }}moby{{ end }}sub`)}},
+			}},
+		}
+	}
+	vals := chartutil.Values{}
+
+	r, err := New().NewRenderer(newChart("application"), vals)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, subType := range []string{"library", "application"} {
+		if _, err := r.Update(newChart(subType), vals); err != nil {
+			t.Fatal(err)
+		}
+		expect, err := New().Render(newChart(subType), vals)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := r.Rendered(); !reflect.DeepEqual(got, expect) {
+			t.Errorf("%s: expected %v, got %v", subType, expect, got)
+		}
+	}
+}
+
+func TestRendererUpdateError(t *testing.T) {
+	c := &chart.Chart{
+		Metadata:  &chart.Metadata{Name: "moby"},
+		Templates: []*chart.Template{{Name: "templates/a", Data: []byte(`{{ required "a is required" .Values.a }}`)}},
+	}
+	r, err := New().NewRenderer(c, chartutil.Values{"Values": map[string]interface{}{"a": "x"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Update(c, chartutil.Values{"Values": map[string]interface{}{}}); err == nil {
+		t.Fatal("Expected rendering to fail")
+	}
+
+	rerendered, err := r.Update(c, chartutil.Values{"Values": map[string]interface{}{"a": "x"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expect := []string{"moby/templates/a"}; !reflect.DeepEqual(rerendered, expect) {
+		t.Errorf("Expected %v to be rendered again after an error, got %v", expect, rerendered)
+	}
+}
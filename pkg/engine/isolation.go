@@ -0,0 +1,216 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"bytes"
+	"path"
+	"sort"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+// IsolationAnnotation is the Chart.yaml annotation that enables template
+// isolation when set to "true" on the chart being rendered.
+const IsolationAnnotation = "helm.sh/template-isolation"
+
+// IsolationEnabled returns true if the chart requests template isolation.
+func IsolationEnabled(c *chart.Chart) bool {
+	return c != nil && c.Metadata != nil && c.Metadata.Annotations[IsolationAnnotation] == "true"
+}
+
+// parseIsolated parses templates into one template set per chart.
+//
+// A named template is only visible to the chart defining it. Templates of
+// another chart are referenced by prefixing the name with the name of that
+// chart, e.g. 'include "mysubchart.labels"' calls the "labels" template of
+// mysubchart, or its "mysubchart.labels" template if there is no "labels".
+func (e *Engine) parseIsolated(tpls map[string]renderable, referenceTpls map[string]renderable) (*parsed, error) {
+	sets := map[string]*template.Template{}
+	// charts maps chart names to the ids of the charts, parents first.
+	charts := map[string]string{}
+
+	ids := []string{}
+	for _, r := range tpls {
+		ids = append(ids, chartID(r))
+	}
+	for _, r := range referenceTpls {
+		ids = append(ids, chartID(r))
+	}
+	sort.Sort(byPathLen(ids))
+
+	for _, id := range ids {
+		if _, ok := sets[id]; ok {
+			continue
+		}
+		t := template.New(id)
+		if e.Strict {
+			t.Option("missingkey=error")
+		} else {
+			t.Option("missingkey=zero")
+		}
+
+		funcMap := e.alterFuncMap(t, referenceTpls)
+		caller := id
+		funcMap["include"] = func(name string, data interface{}) (string, error) {
+			target, tname := resolveIsolated(sets, charts, caller, name)
+			buf := bytes.NewBuffer(nil)
+			if err := e.Trace.include(target, tname, func() error { return target.ExecuteTemplate(buf, tname, data) }); err != nil {
+				return "", err
+			}
+			return buf.String(), nil
+		}
+		sets[id] = t.Funcs(funcMap)
+
+		if _, ok := charts[path.Base(id)]; !ok {
+			charts[path.Base(id)] = id
+		}
+	}
+
+	files := []string{}
+	for _, fname := range sortTemplates(tpls) {
+		r := tpls[fname]
+		files = append(files, fname)
+		if !isGoTemplate(fname) {
+			continue
+		}
+		if _, err := sets[chartID(r)].New(fname).Parse(r.tpl); err != nil {
+			return nil, &fileError{kind: "parse", file: fname, err: err}
+		}
+	}
+
+	// Adding the reference templates to the template context
+	// so they can be referenced in the tpl function
+	for fname, r := range referenceTpls {
+		t := sets[chartID(r)]
+		if isGoTemplate(fname) && t.Lookup(fname) == nil {
+			if _, err := t.New(fname).Parse(r.tpl); err != nil {
+				return nil, &fileError{kind: "parse", file: fname, err: err}
+			}
+		}
+	}
+
+	return &parsed{
+		files: files,
+		lookup: func(file string) *template.Template {
+			return sets[chartID(tpls[file])]
+		},
+		resolve: func(file, name string) *template.Template {
+			r, ok := tpls[file]
+			if !ok {
+				r = referenceTpls[file]
+			}
+			target, tname := resolveIsolated(sets, charts, chartID(r), name)
+			return target.Lookup(tname)
+		},
+	}, nil
+}
+
+// chartID returns the path of the chart a template belongs to, e.g.
+// "mychart/charts/mysubchart".
+func chartID(r renderable) string {
+	return path.Dir(r.basePath)
+}
+
+// resolveIsolated finds the template set and template name referenced by
+// name from the chart identified by caller.
+func resolveIsolated(sets map[string]*template.Template, charts map[string]string, caller, name string) (*template.Template, string) {
+	own := sets[caller]
+	if own.Lookup(name) != nil {
+		return own, name
+	}
+	if i := strings.Index(name, "."); i > 0 {
+		if id, ok := charts[name[:i]]; ok {
+			other := sets[id]
+			if rest := name[i+1:]; other.Lookup(rest) != nil {
+				return other, rest
+			}
+			if other.Lookup(name) != nil {
+				return other, name
+			}
+		}
+	}
+	return own, name
+}
+
+// NamedTemplateCollision describes a named template defined more than once.
+type NamedTemplateCollision struct {
+	// Name is the name of the template.
+	Name string
+	// Files are the template files defining it, in the order they are parsed.
+	// When templates are shared, the definition of the last file wins.
+	Files []string
+}
+
+// NamedTemplateCollisions returns the named templates that are defined by more
+// than one template file of the chart and its subcharts.
+//
+// If isolated is true, only collisions between files of the same chart are
+// returned, as every chart has its own named templates.
+func NamedTemplateCollisions(c *chart.Chart, isolated bool) []NamedTemplateCollision {
+	tpls := allTemplates(c, map[string]interface{}{})
+	funcs := FuncMap()
+
+	defined := map[string][]string{}
+	var keys []string
+	for _, fname := range sortTemplates(tpls) {
+		if !isGoTemplate(fname) {
+			continue
+		}
+		trees, err := parse.Parse(fname, tpls[fname].tpl, "", "", funcs, builtinFuncs)
+		if err != nil {
+			// parse errors are reported when rendering
+			continue
+		}
+		for name := range trees {
+			if name == fname {
+				continue
+			}
+			key := name
+			if isolated {
+				key = chartID(tpls[fname]) + "\x00" + name
+			}
+			if _, ok := defined[key]; !ok {
+				keys = append(keys, key)
+			}
+			defined[key] = append(defined[key], fname)
+		}
+	}
+
+	sort.Strings(keys)
+	var collisions []NamedTemplateCollision
+	for _, key := range keys {
+		if files := defined[key]; len(files) > 1 {
+			collisions = append(collisions, NamedTemplateCollision{
+				Name:  key[strings.LastIndex(key, "\x00")+1:],
+				Files: files,
+			})
+		}
+	}
+	return collisions
+}
+
+// builtinFuncs are the names of the functions predefined by text/template,
+// needed to parse templates outside of a template set.
+var builtinFuncs = map[string]interface{}{
+	"and": nil, "call": nil, "html": nil, "index": nil, "js": nil, "len": nil, "not": nil,
+	"or": nil, "print": nil, "printf": nil, "println": nil, "urlquery": nil,
+	"eq": nil, "ge": nil, "gt": nil, "le": nil, "lt": nil, "ne": nil, "slice": nil,
+}
@@ -0,0 +1,107 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"testing"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+func collidingChart() *chart.Chart {
+	return &chart.Chart{
+		Metadata: &chart.Metadata{Name: "outerchart"},
+		Templates: []*chart.Template{
+			{Name: "templates/_helpers.tpl", Data: []byte(`{{define "name"}}outer{{end}}`)},
+			{Name: "templates/outer", Data: []byte(`{{include "name" .}} {{include "innerchart.name" .}}`)},
+		},
+		Dependencies: []*chart.Chart{
+			{
+				Metadata: &chart.Metadata{Name: "innerchart"},
+				Templates: []*chart.Template{
+					{Name: "templates/_helpers.tpl", Data: []byte(`{{define "name"}}inner{{end}}`)},
+					{Name: "templates/inner", Data: []byte(`{{include "name" .}}`)},
+				},
+			},
+		},
+	}
+}
+
+func TestRenderIsolated(t *testing.T) {
+	e := New()
+	e.Isolate = true
+
+	out, err := e.Render(collidingChart(), map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("failed to render chart: %s", err)
+	}
+
+	expect := map[string]string{
+		"outerchart/templates/outer":                   "outer inner",
+		"outerchart/charts/innerchart/templates/inner": "inner",
+	}
+	for name, want := range expect {
+		if out[name] != want {
+			t.Errorf("Expected %q for %s, got %q", want, name, out[name])
+		}
+	}
+}
+
+func TestRenderIsolatedAnnotation(t *testing.T) {
+	ch := collidingChart()
+	ch.Metadata.Annotations = map[string]string{IsolationAnnotation: "true"}
+
+	out, err := New().Render(ch, map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("failed to render chart: %s", err)
+	}
+	if got := out["outerchart/charts/innerchart/templates/inner"]; got != "inner" {
+		t.Errorf("Expected subchart to use its own template, got %q", got)
+	}
+}
+
+func TestRenderSharedCollision(t *testing.T) {
+	ch := collidingChart()
+	ch.Templates[1].Data = []byte(`{{include "name" .}}`)
+
+	out, err := New().Render(ch, map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("failed to render chart: %s", err)
+	}
+	// Without isolation the parent definition overrides the subchart one.
+	if got := out["outerchart/charts/innerchart/templates/inner"]; got != "outer" {
+		t.Errorf("Expected shared template to be overridden, got %q", got)
+	}
+}
+
+func TestNamedTemplateCollisions(t *testing.T) {
+	collisions := NamedTemplateCollisions(collidingChart(), false)
+	if len(collisions) != 1 {
+		t.Fatalf("Expected 1 collision, got %d: %v", len(collisions), collisions)
+	}
+	c := collisions[0]
+	if c.Name != "name" {
+		t.Errorf("Expected collision on %q, got %q", "name", c.Name)
+	}
+	if len(c.Files) != 2 || c.Files[1] != "outerchart/templates/_helpers.tpl" {
+		t.Errorf("Unexpected files %v", c.Files)
+	}
+
+	if collisions := NamedTemplateCollisions(collidingChart(), true); len(collisions) != 0 {
+		t.Errorf("Expected no collisions with isolation, got %v", collisions)
+	}
+}
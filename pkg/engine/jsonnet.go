@@ -0,0 +1,256 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/google/go-jsonnet"
+	"github.com/google/go-jsonnet/ast"
+
+	"k8s.io/helm/pkg/chartutil"
+)
+
+const (
+	// JsonnetExtension is the extension of the template files rendered by
+	// Jsonnet instead of Go templates.
+	JsonnetExtension = ".jsonnet"
+	// JsonnetLibraryExtension is the extension of the Jsonnet files that are
+	// only imported by Jsonnet templates, and not rendered.
+	JsonnetLibraryExtension = ".libsonnet"
+
+	// jsonnetMaxStack is the maximum depth of the calls of a Jsonnet template,
+	// as with the jsonnet command, so that an endless recursion fails.
+	jsonnetMaxStack = 500
+)
+
+// jsonnetTimeout is how long a Jsonnet template may run, as for Lua. The
+// evaluation cannot be interrupted, so a template running longer is abandoned
+// and keeps running in the background until it ends.
+var jsonnetTimeout = luaTimeout
+
+// isJsonnetTemplate returns true if the template file is a Jsonnet template or
+// library.
+func isJsonnetTemplate(file string) bool {
+	ext := path.Ext(file)
+	return ext == JsonnetExtension || ext == JsonnetLibraryExtension
+}
+
+// executeJsonnet renders the Jsonnet template file of t with the values vals.
+//
+// The top level objects of the values are bound to external variables, e.g.
+// std.extVar("Values") or std.extVar("Release"), and the values are also
+// passed as the top level argument Values of templates that are functions.
+// The Jsonnet files of the templates of the chart can be imported with paths
+// relative to the importing file.
+//
+// The template evaluates to the rendered file, or to the Kubernetes objects
+// rendered as YAML documents: an object with a kind, and lists and objects of
+// such objects.
+func (e *Engine) executeJsonnet(t *template.Template, file string, tpls map[string]renderable, vals chartutil.Values) (string, error) {
+	vm := jsonnet.MakeVM()
+	vm.MaxStack = jsonnetMaxStack
+	vm.Importer(&jsonnetImporter{tpls: tpls, chart: chartID(tpls[file])})
+	for k, v := range vals {
+		b, err := json.Marshal(plainValue(v))
+		if err != nil {
+			return "", err
+		}
+		vm.ExtCode(k, string(b))
+		if k == "Values" {
+			vm.TLACode(k, string(b))
+		}
+	}
+	for _, f := range e.jsonnetNatives(t, vals) {
+		vm.NativeFunction(f)
+	}
+
+	type result struct {
+		out string
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		out, err := vm.EvaluateSnippet(file, tpls[file].tpl)
+		done <- result{out, err}
+	}()
+	var out string
+	select {
+	case r := <-done:
+		if r.err != nil {
+			return "", r.err
+		}
+		out = r.out
+	case <-time.After(jsonnetTimeout):
+		return "", fmt.Errorf("did not finish in %s", jsonnetTimeout)
+	}
+
+	d := json.NewDecoder(strings.NewReader(out))
+	d.UseNumber()
+	var v interface{}
+	if err := d.Decode(&v); err != nil {
+		return "", err
+	}
+	if s, ok := v.(string); ok {
+		return s, nil
+	}
+	var objects []interface{}
+	if err := jsonnetObjects(v, &objects); err != nil {
+		return "", fmt.Errorf("invalid output: %s", err)
+	}
+	return yamlDocuments(objects)
+}
+
+// jsonnetObjects appends the Kubernetes objects of v to objects.
+func jsonnetObjects(v interface{}, objects *[]interface{}) error {
+	switch v := v.(type) {
+	case nil:
+	case []interface{}:
+		for _, item := range v {
+			if err := jsonnetObjects(item, objects); err != nil {
+				return err
+			}
+		}
+	case map[string]interface{}:
+		if _, ok := v["kind"]; ok {
+			*objects = append(*objects, v)
+			return nil
+		}
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if err := jsonnetObjects(v[k], objects); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("expected a string, objects with a kind, or lists or objects of them, got %v", v)
+	}
+	return nil
+}
+
+// jsonnetImporter imports the Jsonnet templates of a chart.
+type jsonnetImporter struct {
+	tpls  map[string]renderable
+	chart string
+}
+
+// Import returns the template at importedPath, relative to the file importing
+// it.
+func (i *jsonnetImporter) Import(importedFrom, importedPath string) (jsonnet.Contents, string, error) {
+	p := path.Join(path.Dir(importedFrom), importedPath)
+	r, ok := i.tpls[p]
+	if !ok || !isJsonnetTemplate(p) || chartID(r) != i.chart {
+		return jsonnet.Contents{}, "", fmt.Errorf("couldn't open import %q: no Jsonnet template %s in chart %s", importedPath, p, i.chart)
+	}
+	return jsonnet.MakeContents(r.tpl), p, nil
+}
+
+// jsonnetNatives returns the native functions of Jsonnet templates, called
+// with std.native, e.g. std.native("include")("mychart.labels", null).
+func (e *Engine) jsonnetNatives(t *template.Template, vals chartutil.Values) []*jsonnet.NativeFunction {
+	return []*jsonnet.NativeFunction{
+		{
+			Name:   "include",
+			Params: ast.Identifiers{"name", "context"},
+			Func: func(args []interface{}) (interface{}, error) {
+				name, ok := args[0].(string)
+				if !ok {
+					return nil, fmt.Errorf("include: the name must be a string, got %v", args[0])
+				}
+				var data interface{} = vals
+				if args[1] != nil {
+					data = args[1]
+				}
+				var buf bytes.Buffer
+				if err := e.Trace.include(t, name, func() error { return t.ExecuteTemplate(&buf, name, data) }); err != nil {
+					return nil, fmt.Errorf("include %q: %s", name, err)
+				}
+				return strings.Replace(buf.String(), "<no value>", "", -1), nil
+			},
+		},
+		{
+			Name:   "lookup",
+			Params: ast.Identifiers{"apiVersion", "kind", "namespace", "name"},
+			Func: func(args []interface{}) (interface{}, error) {
+				if e.Lookup == nil {
+					return map[string]interface{}{}, nil
+				}
+				s := make([]string, len(args))
+				for i, a := range args {
+					s[i], _ = a.(string)
+				}
+				res, err := e.Lookup(s[0], s[1], s[2], s[3])
+				if err != nil {
+					return nil, err
+				}
+				return plainValue(res), nil
+			},
+		},
+		{
+			Name:   "toYaml",
+			Params: ast.Identifiers{"value"},
+			Func: func(args []interface{}) (interface{}, error) {
+				b, err := yaml.Marshal(args[0])
+				if err != nil {
+					return nil, err
+				}
+				return strings.TrimSuffix(string(b), "\n"), nil
+			},
+		},
+		{
+			Name:   "fromYaml",
+			Params: ast.Identifiers{"yaml"},
+			Func: func(args []interface{}) (interface{}, error) {
+				s, ok := args[0].(string)
+				if !ok {
+					return nil, fmt.Errorf("fromYaml: expected a string, got %v", args[0])
+				}
+				var v interface{}
+				if err := yaml.Unmarshal([]byte(s), &v); err != nil {
+					return nil, err
+				}
+				return v, nil
+			},
+		},
+		{
+			Name:   "sha256sum",
+			Params: ast.Identifiers{"str"},
+			Func: func(args []interface{}) (interface{}, error) {
+				s, ok := args[0].(string)
+				if !ok {
+					return nil, fmt.Errorf("sha256sum: expected a string, got %v", args[0])
+				}
+				sum := sha256.Sum256([]byte(s))
+				return hex.EncodeToString(sum[:]), nil
+			},
+		},
+	}
+}
@@ -0,0 +1,127 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+func TestRenderJsonnet(t *testing.T) {
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "moby"},
+		Templates: []*chart.Template{
+			{Name: "templates/_helpers.tpl", Data: []byte(`{{ define "moby.labels" }}app: {{ .Chart.Name }}{{ end }}`)},
+			{Name: "templates/lib/names.libsonnet", Data: []byte(`{ name(suffix):: std.extVar("Release").Name + "-" + suffix }`)},
+			{Name: "templates/configmap.jsonnet", Data: []byte(`
+local names = import "lib/names.libsonnet";
+{
+  apiVersion: "v1",
+  kind: "ConfigMap",
+  metadata: {
+    name: names.name(std.extVar("Chart").Name),
+    labels: std.native("fromYaml")(std.native("include")("moby.labels", null)),
+  },
+  data: { replicas: std.toString(std.extVar("Values").replicas) },
+}`)},
+			{Name: "templates/pods.jsonnet", Data: []byte(`
+function(Values) [
+  { kind: "Pod", metadata: { name: "pod-" + i, replicas: Values.replicas } }
+  for i in std.range(1, Values.replicas - 1)
+]`)},
+			{Name: "templates/services.jsonnet", Data: []byte(`{ web: { kind: "Service" }, api: { kind: "Service", port: 80 } }`)},
+			{Name: "templates/raw.jsonnet", Data: []byte(`"kind: " + std.asciiUpper("namespace") + "\n"`)},
+			{Name: "templates/none.jsonnet", Data: []byte(`null`)},
+		},
+	}
+	vals := chartutil.Values{
+		"Values":  chartutil.Values{"replicas": 3},
+		"Release": map[string]interface{}{"Name": "rel"},
+		"Chart":   c.Metadata,
+	}
+
+	out, err := New().Render(c, vals)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := map[string]string{
+		"moby/templates/configmap.jsonnet": "apiVersion: v1\ndata:\n  replicas: \"3\"\nkind: ConfigMap\nmetadata:\n  labels:\n    app: moby\n  name: rel-moby\n",
+		"moby/templates/pods.jsonnet":      "kind: Pod\nmetadata:\n  name: pod-1\n  replicas: 3\n---\nkind: Pod\nmetadata:\n  name: pod-2\n  replicas: 3\n",
+		"moby/templates/services.jsonnet":  "kind: Service\nport: 80\n---\nkind: Service\n",
+		"moby/templates/raw.jsonnet":       "kind: NAMESPACE\n",
+		"moby/templates/none.jsonnet":      "",
+	}
+	for file, e := range expect {
+		if out[file] != e {
+			t.Errorf("Expected %s to render %q, got %q", file, e, out[file])
+		}
+	}
+	if _, ok := out["moby/templates/lib/names.libsonnet"]; ok {
+		t.Error("Expected Jsonnet libraries not to be rendered")
+	}
+}
+
+func TestRenderJsonnetErrors(t *testing.T) {
+	for _, tt := range []struct {
+		src    string
+		expect string
+	}{
+		{`import "/etc/passwd"`, "couldn't open import"},
+		{`import "../charts/sub/templates/lib.libsonnet"`, "couldn't open import"},
+		{`import "_helpers.tpl"`, "couldn't open import"},
+		{`error "unsupported"`, "unsupported"},
+		{`42`, "invalid output"},
+		{`{`, "broken.jsonnet"},
+		{`local f(x) = 1 + f(x + 1); f(0)`, "max stack frames exceeded"},
+	} {
+		c := &chart.Chart{
+			Metadata: &chart.Metadata{Name: "moby"},
+			Templates: []*chart.Template{
+				{Name: "templates/broken.jsonnet", Data: []byte(tt.src)},
+				{Name: "templates/_helpers.tpl", Data: []byte(`{{ define "moby.labels" }}{{ end }}`)},
+			},
+			Dependencies: []*chart.Chart{{
+				Metadata:  &chart.Metadata{Name: "sub"},
+				Templates: []*chart.Template{{Name: "templates/lib.libsonnet", Data: []byte(`{}`)}},
+			}},
+		}
+		_, err := New().Render(c, chartutil.Values{"Values": chartutil.Values{}})
+		if err == nil || !strings.Contains(err.Error(), tt.expect) {
+			t.Errorf("Expected %q to fail with %q, got %v", tt.src, tt.expect, err)
+		}
+	}
+}
+
+func TestRenderJsonnetTimeout(t *testing.T) {
+	defer func(timeout time.Duration) { jsonnetTimeout = timeout }(jsonnetTimeout)
+	jsonnetTimeout = 100 * time.Millisecond
+
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "moby"},
+		Templates: []*chart.Template{
+			{Name: "templates/loop.jsonnet", Data: []byte(`local f(x) = f(x + 1) tailstrict; f(0)`)},
+		},
+	}
+	_, err := New().Render(c, chartutil.Values{"Values": chartutil.Values{}})
+	if err == nil || !strings.Contains(err.Error(), "did not finish in 100ms") {
+		t.Errorf("Expected the template to time out, got %v", err)
+	}
+}
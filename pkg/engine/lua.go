@@ -0,0 +1,356 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"path"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/ghodss/yaml"
+	lua "github.com/yuin/gopher-lua"
+
+	"k8s.io/helm/pkg/chartutil"
+)
+
+// LuaExtension is the extension of the template files rendered by the Lua
+// engine instead of Go templates.
+const LuaExtension = ".lua"
+
+// luaTimeout is how long a Lua template may run.
+const luaTimeout = 30 * time.Second
+
+// The limits of the memory of a Lua template: the depth of its calls, the
+// size of its value stack, and the length of the strings built by string.rep.
+const (
+	luaCallStackSize   = 200
+	luaRegistrySize    = 1024
+	luaRegistryMaxSize = 64 * 1024
+	luaMaxRepLength    = 1 << 20
+)
+
+// luaMaxDepth is the maximum nesting of the tables returned by a Lua template,
+// which also stops the conversion of tables referencing themselves.
+const luaMaxDepth = 100
+
+// luaUnsafe are the functions of the Lua base library removed from the
+// sandbox, as they access the file system or the process, or load code.
+var luaUnsafe = []string{"collectgarbage", "dofile", "load", "loadfile", "loadstring", "module", "print", "require", "getfenv", "setfenv"}
+
+// isLuaTemplate returns true if the template file is rendered by Lua.
+func isLuaTemplate(file string) bool {
+	return path.Ext(file) == LuaExtension
+}
+
+// executeLua renders the Lua template file of t with the values vals.
+//
+// The script runs in a sandbox without the io, os and package libraries, with
+// bounded calls, value stack and strings built by string.rep, and sees the top level objects of the values as globals: Values, Release,
+// Chart, Capabilities, Files and Template. The helpers of the 'helm' table are
+// documented in the package documentation.
+//
+// The script returns the output of the template: a string, a table
+// converted to a YAML document, or a list of tables converted to YAML
+// documents separated by '---'. Nothing is rendered if it returns nil.
+func (e *Engine) executeLua(t *template.Template, file, src string, vals chartutil.Values) (string, error) {
+	L := lua.NewState(lua.Options{
+		SkipOpenLibs:    true,
+		CallStackSize:   luaCallStackSize,
+		RegistrySize:    luaRegistrySize,
+		RegistryMaxSize: luaRegistryMaxSize,
+	})
+	defer L.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), luaTimeout)
+	defer cancel()
+	L.SetContext(ctx)
+
+	for _, lib := range []struct {
+		name string
+		open lua.LGFunction
+	}{
+		{lua.BaseLibName, lua.OpenBase},
+		{lua.TabLibName, lua.OpenTable},
+		{lua.StringLibName, lua.OpenString},
+		{lua.MathLibName, lua.OpenMath},
+	} {
+		if err := L.CallByParam(lua.P{Fn: L.NewFunction(lib.open), NRet: 0, Protect: true}, lua.LString(lib.name)); err != nil {
+			return "", err
+		}
+	}
+	for _, name := range luaUnsafe {
+		L.SetGlobal(name, lua.LNil)
+	}
+	L.GetGlobal(lua.StringLibName).(*lua.LTable).RawSetString("rep", L.NewFunction(luaStringRep))
+
+	for k, v := range vals {
+		L.SetGlobal(k, toLua(L, v))
+	}
+	L.SetGlobal("helm", e.luaHelpers(L, t, vals))
+
+	fn, err := L.Load(strings.NewReader(src), file)
+	if err != nil {
+		return "", err
+	}
+	L.Push(fn)
+	if err := L.PCall(0, 1, nil); err != nil {
+		return "", err
+	}
+	ret := L.Get(-1)
+	if s, ok := ret.(lua.LString); ok {
+		return string(s), nil
+	}
+	out, err := fromLua(ret, 0)
+	if err != nil {
+		return "", fmt.Errorf("invalid output: %s", err)
+	}
+	return luaManifests(out)
+}
+
+// luaStringRep is string.rep, failing instead of building strings longer than
+// luaMaxRepLength.
+func luaStringRep(L *lua.LState) int {
+	s := L.CheckString(1)
+	n := L.CheckInt(2)
+	if n <= 0 {
+		L.Push(lua.LString(""))
+		return 1
+	}
+	if int64(len(s))*int64(n) > luaMaxRepLength {
+		L.ArgError(2, fmt.Sprintf("string.rep cannot build strings longer than %d bytes", luaMaxRepLength))
+	}
+	L.Push(lua.LString(strings.Repeat(s, n)))
+	return 1
+}
+
+// luaManifests returns the YAML documents of the output of a Lua template.
+func luaManifests(out interface{}) (string, error) {
+	var docs []interface{}
+	switch o := out.(type) {
+	case nil:
+		return "", nil
+	case []interface{}:
+		docs = o
+	case map[string]interface{}:
+		docs = []interface{}{o}
+	default:
+		return "", fmt.Errorf("invalid output: expected a string, a table or a list of tables, got %v", out)
+	}
+
+	return yamlDocuments(docs)
+}
+
+// luaHelpers returns the table of the helper functions of Lua templates.
+func (e *Engine) luaHelpers(L *lua.LState, t *template.Template, vals chartutil.Values) *lua.LTable {
+	// arg returns the Go value of the argument n.
+	arg := func(L *lua.LState, n int) interface{} {
+		v, err := fromLua(L.Get(n), 0)
+		if err != nil {
+			L.ArgError(n, err.Error())
+		}
+		return v
+	}
+
+	return L.SetFuncs(L.NewTable(), map[string]lua.LGFunction{
+		"toYaml": func(L *lua.LState) int {
+			b, err := yaml.Marshal(arg(L, 1))
+			if err != nil {
+				L.RaiseError("toYaml: %s", err)
+			}
+			L.Push(lua.LString(strings.TrimSuffix(string(b), "\n")))
+			return 1
+		},
+		"fromYaml": func(L *lua.LState) int {
+			var v interface{}
+			if err := yaml.Unmarshal([]byte(L.CheckString(1)), &v); err != nil {
+				L.RaiseError("fromYaml: %s", err)
+			}
+			L.Push(toLua(L, v))
+			return 1
+		},
+		"toJson": func(L *lua.LState) int {
+			b, err := json.Marshal(arg(L, 1))
+			if err != nil {
+				L.RaiseError("toJson: %s", err)
+			}
+			L.Push(lua.LString(b))
+			return 1
+		},
+		"fromJson": func(L *lua.LState) int {
+			var v interface{}
+			if err := json.Unmarshal([]byte(L.CheckString(1)), &v); err != nil {
+				L.RaiseError("fromJson: %s", err)
+			}
+			L.Push(toLua(L, v))
+			return 1
+		},
+		"b64enc": func(L *lua.LState) int {
+			L.Push(lua.LString(base64.StdEncoding.EncodeToString([]byte(L.CheckString(1)))))
+			return 1
+		},
+		"b64dec": func(L *lua.LState) int {
+			b, err := base64.StdEncoding.DecodeString(L.CheckString(1))
+			if err != nil {
+				L.RaiseError("b64dec: %s", err)
+			}
+			L.Push(lua.LString(b))
+			return 1
+		},
+		"sha256sum": func(L *lua.LState) int {
+			sum := sha256.Sum256([]byte(L.CheckString(1)))
+			L.Push(lua.LString(hex.EncodeToString(sum[:])))
+			return 1
+		},
+		"required": func(L *lua.LState) int {
+			msg, v := L.CheckString(1), L.Get(2)
+			if v == lua.LNil || v == lua.LString("") {
+				if !e.LintMode {
+					L.RaiseError("%s", msg)
+				}
+				// Don't fail on missing required values when linting
+				log.Printf("[INFO] Missing required value: %s", msg)
+				v = lua.LString("")
+			}
+			L.Push(v)
+			return 1
+		},
+		"fail": func(L *lua.LState) int {
+			L.RaiseError("%s", L.CheckString(1))
+			return 0
+		},
+		"include": func(L *lua.LState) int {
+			name := L.CheckString(1)
+			var data interface{} = vals
+			if L.GetTop() > 1 {
+				data = arg(L, 2)
+			}
+			var buf bytes.Buffer
+			if err := e.Trace.include(t, name, func() error { return t.ExecuteTemplate(&buf, name, data) }); err != nil {
+				L.RaiseError("include %q: %s", name, err)
+			}
+			L.Push(lua.LString(strings.Replace(buf.String(), "<no value>", "", -1)))
+			return 1
+		},
+		"lookup": func(L *lua.LState) int {
+			res := map[string]interface{}{}
+			if e.Lookup != nil {
+				var err error
+				res, err = e.Lookup(L.CheckString(1), L.CheckString(2), L.OptString(3, ""), L.OptString(4, ""))
+				if err != nil {
+					L.RaiseError("lookup: %s", err)
+				}
+			}
+			L.Push(toLua(L, res))
+			return 1
+		},
+	})
+}
+
+// toLua converts a Go value to a Lua value, see plainValue.
+func toLua(L *lua.LState, v interface{}) lua.LValue {
+	return plainToLua(L, plainValue(v))
+}
+
+func plainToLua(L *lua.LState, v interface{}) lua.LValue {
+	switch v := v.(type) {
+	case bool:
+		return lua.LBool(v)
+	case int64:
+		return lua.LNumber(v)
+	case float64:
+		return lua.LNumber(v)
+	case string:
+		return lua.LString(v)
+	case []interface{}:
+		t := L.CreateTable(len(v), 0)
+		for i, item := range v {
+			t.RawSetInt(i+1, plainToLua(L, item))
+		}
+		return t
+	case map[string]interface{}:
+		t := L.CreateTable(0, len(v))
+		for k, item := range v {
+			t.RawSetString(k, plainToLua(L, item))
+		}
+		return t
+	}
+	return lua.LNil
+}
+
+// fromLua converts a Lua value to a Go value. Tables with only the keys 1 to n
+// are converted to lists, and other tables to maps. Whole numbers are
+// converted to integers.
+func fromLua(v lua.LValue, depth int) (interface{}, error) {
+	if depth > luaMaxDepth {
+		return nil, fmt.Errorf("tables nested more than %d levels deep", luaMaxDepth)
+	}
+	switch v := v.(type) {
+	case *lua.LNilType:
+		return nil, nil
+	case lua.LBool:
+		return bool(v), nil
+	case lua.LString:
+		return string(v), nil
+	case lua.LNumber:
+		f := float64(v)
+		if f == math.Trunc(f) && math.Abs(f) < 1<<53 {
+			return int64(f), nil
+		}
+		return f, nil
+	case *lua.LTable:
+		if n := v.MaxN(); n > 0 && n == countKeys(v) {
+			list := make([]interface{}, n)
+			for i := 1; i <= n; i++ {
+				item, err := fromLua(v.RawGetInt(i), depth+1)
+				if err != nil {
+					return nil, err
+				}
+				list[i-1] = item
+			}
+			return list, nil
+		}
+		m := map[string]interface{}{}
+		var err error
+		v.ForEach(func(k, val lua.LValue) {
+			if err != nil {
+				return
+			}
+			var item interface{}
+			if item, err = fromLua(val, depth+1); err == nil {
+				m[k.String()] = item
+			}
+		})
+		return m, err
+	}
+	return nil, fmt.Errorf("cannot convert a Lua %s", v.Type())
+}
+
+// countKeys returns the number of keys of the table t.
+func countKeys(t *lua.LTable) int {
+	n := 0
+	t.ForEach(func(lua.LValue, lua.LValue) { n++ })
+	return n
+}
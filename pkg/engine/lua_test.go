@@ -0,0 +1,138 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+func TestRenderLua(t *testing.T) {
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "moby"},
+		Templates: []*chart.Template{
+			{Name: "templates/_helpers.tpl", Data: []byte(`{{ define "moby.labels" }}app: {{ .Chart.Name }}{{ end }}`)},
+			{Name: "templates/configmap.lua", Data: []byte(`
+local labels = helm.fromYaml(helm.include("moby.labels"))
+return {
+  apiVersion = "v1",
+  kind = "ConfigMap",
+  metadata = { name = Release.Name .. "-" .. Chart.Name, labels = labels },
+  data = { replicas = tostring(Values.replicas), secret = helm.b64enc(Values.name) },
+}`)},
+			{Name: "templates/pods.lua", Data: []byte(`
+local pods = {}
+for i = 1, Values.replicas - 1 do
+  pods[#pods + 1] = { kind = "Pod", metadata = { name = "pod-" .. i } }
+end
+return pods`)},
+			{Name: "templates/raw.lua", Data: []byte(`return "kind: " .. string.upper("namespace") .. "\n"`)},
+			{Name: "templates/none.lua", Data: []byte(`if Values.replicas > 10 then return { kind = "Service" } end`)},
+			{Name: "templates/plain.yaml", Data: []byte(`replicas: {{ .Values.replicas }}`)},
+		},
+	}
+	vals := chartutil.Values{
+		"Values":  chartutil.Values{"replicas": 3, "name": "x"},
+		"Release": map[string]interface{}{"Name": "rel"},
+		"Chart":   c.Metadata,
+	}
+
+	out, err := New().Render(c, vals)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := map[string]string{
+		"moby/templates/configmap.lua": "apiVersion: v1\ndata:\n  replicas: \"3\"\n  secret: eA==\nkind: ConfigMap\nmetadata:\n  labels:\n    app: moby\n  name: rel-moby\n",
+		"moby/templates/pods.lua":      "kind: Pod\nmetadata:\n  name: pod-1\n---\nkind: Pod\nmetadata:\n  name: pod-2\n",
+		"moby/templates/raw.lua":       "kind: NAMESPACE\n",
+		"moby/templates/none.lua":      "",
+		"moby/templates/plain.yaml":    "replicas: 3",
+	}
+	for file, e := range expect {
+		if out[file] != e {
+			t.Errorf("Expected %s to render %q, got %q", file, e, out[file])
+		}
+	}
+}
+
+func TestRenderLuaErrors(t *testing.T) {
+	for _, tt := range []struct {
+		src    string
+		expect string
+	}{
+		{`return io.open("/etc/passwd")`, "non-table"},
+		{`dofile("/etc/passwd")`, "non-function"},
+		{`return os.getenv("HOME")`, "non-table"},
+		{`return helm.required("name is required", Values.missing)`, "name is required"},
+		{`helm.fail("unsupported")`, "unsupported"},
+		{`return { string.upper }`, "cannot convert"},
+		{`local t = {} t.self = t return t`, "nested"},
+		{`return {`, "syntax error"},
+		{`return string.rep("x", 1e9)`, "string.rep cannot build strings longer than"},
+		{`local function f(n) return 1 + f(n + 1) end return f(0)`, "stack overflow"},
+		{`local t = {} for i = 1, 1e5 do t[i] = i end return { unpack(t) }`, "registry overflow"},
+	} {
+		c := &chart.Chart{
+			Metadata:  &chart.Metadata{Name: "moby"},
+			Templates: []*chart.Template{{Name: "templates/broken.lua", Data: []byte(tt.src)}},
+		}
+		_, err := New().Render(c, chartutil.Values{"Values": chartutil.Values{}})
+		if err == nil || !strings.Contains(err.Error(), tt.expect) {
+			t.Errorf("Expected %q to fail with %q, got %v", tt.src, tt.expect, err)
+		}
+	}
+}
+
+func TestRenderLuaSandbox(t *testing.T) {
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "moby"},
+		Templates: []*chart.Template{{Name: "templates/sandbox.lua", Data: []byte(`
+local names = {}
+for _, name in ipairs({ "os", "io", "package", "require", "load", "loadstring", "dofile", "loadfile" }) do
+  if _G[name] ~= nil then names[#names + 1] = name end
+end
+return table.concat(names, ",")`)}},
+	}
+	out, err := New().Render(c, chartutil.Values{"Values": chartutil.Values{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := out["moby/templates/sandbox.lua"]; got != "" {
+		t.Errorf("Expected no unsafe globals in the sandbox, got %s", got)
+	}
+}
+
+func TestRenderLuaRequiredLintMode(t *testing.T) {
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "moby"},
+		Templates: []*chart.Template{
+			{Name: "templates/cm.lua", Data: []byte(`return { name = helm.required("name is required", Values.name) }`)},
+		},
+	}
+	e := New()
+	e.LintMode = true
+	out, err := e.Render(c, chartutil.Values{"Values": chartutil.Values{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := out["moby/templates/cm.lua"]; got != "name: \"\"\n" {
+		t.Errorf("Expected an empty name, got %q", got)
+	}
+}
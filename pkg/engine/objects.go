@@ -0,0 +1,100 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/ghodss/yaml"
+)
+
+// plainValue converts v to nil, a bool, an int64, a float64, a string, a
+// []interface{} or a map[string]interface{}, for the template languages other
+// than Go templates.
+//
+// Structs are converted to maps of their exported fields, so that templates
+// in other languages see the same names as Go templates, e.g. Chart.Name or
+// Capabilities.KubeVersion.GitVersion. Byte slices, like the content of
+// Files, are converted to strings.
+func plainValue(v interface{}) interface{} {
+	return plainReflectValue(reflect.ValueOf(v))
+}
+
+func plainReflectValue(v reflect.Value) interface{} {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return plainReflectValue(v.Elem())
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.String:
+		return v.String()
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			return string(v.Bytes())
+		}
+		list := make([]interface{}, v.Len())
+		for i := range list {
+			list[i] = plainReflectValue(v.Index(i))
+		}
+		return list
+	case reflect.Map:
+		m := make(map[string]interface{}, v.Len())
+		for _, k := range v.MapKeys() {
+			m[fmt.Sprint(k.Interface())] = plainReflectValue(v.MapIndex(k))
+		}
+		return m
+	case reflect.Struct:
+		m := make(map[string]interface{}, v.NumField())
+		for i := 0; i < v.NumField(); i++ {
+			f := v.Type().Field(i)
+			if f.PkgPath != "" || strings.HasPrefix(f.Name, "XXX_") {
+				continue
+			}
+			m[f.Name] = plainReflectValue(v.Field(i))
+		}
+		return m
+	}
+	return nil
+}
+
+// yamlDocuments returns the YAML documents of objects, separated by '---'.
+func yamlDocuments(objects []interface{}) (string, error) {
+	var buf bytes.Buffer
+	for i, obj := range objects {
+		b, err := yaml.Marshal(obj)
+		if err != nil {
+			return "", err
+		}
+		if i > 0 {
+			buf.WriteString("---\n")
+		}
+		buf.Write(b)
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,300 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+// OptionalAnnotation is the Chart.yaml annotation declaring the values the
+// templates of a chart may reference without them being set, when the engine
+// is strict. It is a comma-separated list of dotted value paths where "*"
+// matches any key. The values under an optional value are optional too:
+//
+//	annotations:
+//	  helm.sh/optional-values: ingress, podAnnotations.*
+const OptionalAnnotation = "helm.sh/optional-values"
+
+// OptionalPaths returns the paths of the optional values declared by the chart
+// c and its subcharts. The paths of a subchart are prefixed with its name.
+func OptionalPaths(c *chart.Chart) []string {
+	var paths []string
+	for _, p := range strings.Split(c.GetMetadata().GetAnnotations()[OptionalAnnotation], ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			paths = append(paths, p)
+		}
+	}
+	for _, d := range c.GetDependencies() {
+		for _, p := range OptionalPaths(d) {
+			paths = append(paths, d.GetMetadata().GetName()+"."+p)
+		}
+	}
+	return paths
+}
+
+// optionalPaths returns the paths of the optional values of e and of the
+// chart c, split on dots.
+func (e *Engine) optionalPaths(c *chart.Chart) [][]string {
+	if !e.Strict {
+		return nil
+	}
+	var paths [][]string
+	for _, p := range append(append([]string{}, e.Optional...), OptionalPaths(c)...) {
+		paths = append(paths, strings.Split(p, "."))
+	}
+	return paths
+}
+
+// missingKeyRegex matches the error of a template referencing a missing key
+// in strict mode, e.g. 'template: mychart/templates/x:3:10: executing
+// "mychart/templates/x" at <.Values.image.tag>: map has no entry for key
+// "image"'. The error of the innermost included template is matched, after
+// the errors of the templates including it.
+var missingKeyRegex = regexp.MustCompile(`(?s)^(.*)template: ([^\n]*):(\d+):(\d+): executing "([^"]*)" at <([^>]*)>: map has no entry for key "([^"]*)"$`)
+
+// missingValue is a value referenced by a template without being set.
+type missingValue struct {
+	// including are the errors of the templates including the template
+	// referencing the value.
+	including string
+	// location is the location of the reference in the template, e.g.
+	// "mychart/templates/x:3:10".
+	location string
+	// ref is the reference, e.g. ".Values.image.tag".
+	ref string
+	// path is the path of the missing value in .Values, e.g. ["image"].
+	path []string
+	// last is true if the missing value is the value referenced, and not one
+	// of its parents.
+	last bool
+}
+
+// executeStrict renders the template file of t with vals into buf, failing on
+// the references to missing values. The optional values are set to nil, or to
+// an empty map if a value under them is referenced, and the template is
+// rendered again.
+func (e *Engine) executeStrict(t *template.Template, file, basePath string, vals chartutil.Values, buf *bytes.Buffer) error {
+	for {
+		buf.Reset()
+		err := t.ExecuteTemplate(buf, file, vals)
+		if err == nil {
+			return nil
+		}
+		m := findMissingValue(t, vals, err)
+		if m == nil {
+			return err
+		}
+		name := append(chartPath(basePath), m.path...)
+		if !e.isOptional(name, m.path) {
+			return fmt.Errorf("%stemplate: %s: missing value %s (%s)", m.including, m.location, strings.Join(name, "."), m.ref)
+		}
+		var v interface{}
+		if !m.last {
+			v = map[string]interface{}{}
+		}
+		vals = chartutil.Values(setValue(vals, append([]string{"Values"}, m.path...), v))
+	}
+}
+
+// isOptional returns whether the value name, of path p in the values of the
+// template, is optional. Globals are also matched by their path in the
+// template values.
+func (e *Engine) isOptional(name, p []string) bool {
+	for _, o := range e.optional {
+		if matchPrefix(o, name) || (p[0] == "global" && matchPrefix(o, p)) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPrefix returns whether the pattern matches p or one of its parents.
+func matchPrefix(pattern, p []string) bool {
+	if len(pattern) > len(p) {
+		return false
+	}
+	for i, elem := range pattern {
+		if elem != "*" && elem != p[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// chartPath returns the names of the subcharts of the templates of basePath,
+// e.g. ["sub"] for "mychart/charts/sub/templates".
+func chartPath(basePath string) []string {
+	var names []string
+	parts := strings.Split(basePath, "/")
+	for i := 1; i+1 < len(parts); i++ {
+		if parts[i] == "charts" {
+			names = append(names, parts[i+1])
+			i++
+		}
+	}
+	return names
+}
+
+// findMissingValue returns the value of .Values missing in vals that caused
+// the error err of rendering a template of t, or nil if err is not caused
+// by a missing value.
+func findMissingValue(t *template.Template, vals chartutil.Values, err error) *missingValue {
+	m := missingKeyRegex.FindStringSubmatch(err.Error())
+	if m == nil {
+		return nil
+	}
+	line, _ := strconv.Atoi(m[3])
+	col, _ := strconv.Atoi(m[4])
+	ref := m[6]
+	// The reference is truncated in the error if it is long, and is read
+	// from the template instead.
+	if tpl := t.Lookup(m[5]); tpl != nil && tpl.Tree != nil {
+		if ident := findReference(tpl.Tree, tpl.Tree.Root, m[2], line, col); ident != nil {
+			ref = "." + strings.Join(ident, ".")
+		}
+	}
+	if strings.HasSuffix(ref, "...") {
+		return nil
+	}
+	ident := strings.Split(strings.TrimPrefix(strings.TrimPrefix(ref, "$"), "."), ".")
+	if len(ident) < 2 || ident[0] != "Values" {
+		return nil
+	}
+
+	var cur interface{} = vals["Values"]
+	for i, key := range ident[1:] {
+		table, ok := asTable(cur)
+		if !ok {
+			return nil
+		}
+		if cur, ok = table[key]; !ok {
+			return &missingValue{
+				including: m[1],
+				location:  fmt.Sprintf("%s:%d:%d", m[2], line, col),
+				ref:       strings.TrimPrefix(ref, "$"),
+				path:      ident[1 : i+2],
+				last:      i+2 == len(ident),
+			}
+		}
+	}
+	return nil
+}
+
+// findReference returns the identifiers of the field or variable of tree
+// at line and col of file, without the leading "$".
+func findReference(tree *parse.Tree, node parse.Node, file string, line, col int) []string {
+	var ident []string
+	walkNodes(node, func(n parse.Node) {
+		var id []string
+		switch n := n.(type) {
+		case *parse.FieldNode:
+			id = n.Ident
+		case *parse.VariableNode:
+			if len(n.Ident) > 1 && n.Ident[0] == "$" {
+				id = n.Ident[1:]
+			}
+		}
+		if id != nil && ident == nil {
+			if loc, _ := tree.ErrorContext(n); loc == fmt.Sprintf("%s:%d:%d", file, line, col) {
+				ident = id
+			}
+		}
+	})
+	return ident
+}
+
+// walkNodes calls fn for node and the nodes under it.
+func walkNodes(node parse.Node, fn func(parse.Node)) {
+	if node == nil {
+		return
+	}
+	fn(node)
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, c := range n.Nodes {
+			walkNodes(c, fn)
+		}
+	case *parse.ActionNode:
+		walkNodes(n.Pipe, fn)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, c := range n.Cmds {
+			walkNodes(c, fn)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			walkNodes(arg, fn)
+		}
+	case *parse.ChainNode:
+		walkNodes(n.Node, fn)
+	case *parse.IfNode:
+		walkBranch(&n.BranchNode, fn)
+	case *parse.RangeNode:
+		walkBranch(&n.BranchNode, fn)
+	case *parse.WithNode:
+		walkBranch(&n.BranchNode, fn)
+	case *parse.TemplateNode:
+		walkNodes(n.Pipe, fn)
+	}
+}
+
+func walkBranch(n *parse.BranchNode, fn func(parse.Node)) {
+	walkNodes(n.Pipe, fn)
+	walkNodes(n.List, fn)
+	walkNodes(n.ElseList, fn)
+}
+
+// asTable returns v as a map, if it is one.
+func asTable(v interface{}) (map[string]interface{}, bool) {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		return t, true
+	case chartutil.Values:
+		return t, true
+	}
+	return nil, false
+}
+
+// setValue returns a copy of the table with the value at path p set to v. The
+// tables along p are copied, the others are shared with table.
+func setValue(table map[string]interface{}, p []string, v interface{}) map[string]interface{} {
+	c := make(map[string]interface{}, len(table)+1)
+	for k, v := range table {
+		c[k] = v
+	}
+	if len(p) == 1 {
+		c[p[0]] = v
+		return c
+	}
+	child, _ := asTable(table[p[0]])
+	c[p[0]] = setValue(child, p[1:], v)
+	return c
+}
@@ -0,0 +1,75 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+func TestRenderStrict(t *testing.T) {
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{
+			Name:        "moby",
+			Annotations: map[string]string{OptionalAnnotation: "podAnnotations"},
+		},
+		Templates: []*chart.Template{
+			{Name: "templates/_helpers", Data: []byte(`{{define "image"}}{{.Values.image.repository}}:{{.Values.image.tag}}{{end}}`)},
+			{Name: "templates/pod", Data: []byte("image: {{include \"image\" .}}\nannotations: {{.Values.podAnnotations.owner}}\n")},
+		},
+		Dependencies: []*chart.Chart{
+			{
+				Metadata:  &chart.Metadata{Name: "sub"},
+				Templates: []*chart.Template{{Name: "templates/x", Data: []byte("{{.Values.ingress.enabled}}{{.Values.global.region}}")}},
+			},
+		},
+	}
+	vals := chartutil.Values{"Values": map[string]interface{}{
+		"image": map[string]interface{}{"repository": "nginx", "tag": "1.17"},
+		"sub":   map[string]interface{}{"global": map[string]interface{}{}},
+	}}
+
+	e := New()
+	e.Strict = true
+	_, err := e.Render(c, vals)
+	expect := `render error in "moby/charts/sub/templates/x": charts/sub/templates/x:1:10: missing value sub.ingress (.Values.ingress.enabled)`
+	if err == nil || !strings.HasPrefix(err.Error(), expect+"\n") {
+		t.Fatalf("Expected the error %q, got %v", expect, err)
+	}
+
+	e.Optional = []string{"sub.ingress", "global.*"}
+	out, err := e.Render(c, vals)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out["moby/templates/pod"] != "image: nginx:1.17\nannotations: \n" || out["moby/charts/sub/templates/x"] != "" {
+		t.Errorf("Expected the optional values to be empty, got %v", out)
+	}
+	if _, ok := vals["Values"].(map[string]interface{})["podAnnotations"]; ok {
+		t.Error("Expected the values not to be modified")
+	}
+
+	vals["Values"].(map[string]interface{})["image"] = map[string]interface{}{"repository": "nginx"}
+	_, err = e.Render(c, vals)
+	expect = "templates/_helpers:1:57: missing value image.tag (.Values.image.tag)\n\tincluded from templates/pod:1:10\n"
+	if err == nil || !strings.Contains(err.Error(), expect) {
+		t.Errorf("Expected the error of the included template %q, got %v", expect, err)
+	}
+}
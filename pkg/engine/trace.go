@@ -0,0 +1,210 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"sort"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
+)
+
+// RenderTrace records how the templates of a chart are rendered, to find slow
+// or expensive templates. Tracing is enabled by setting Engine.Trace.
+type RenderTrace struct {
+	// Templates are the rendered template files, in render order.
+	Templates []*TemplateTrace `json:"templates"`
+	// Includes are the named templates that were included, by name.
+	Includes map[string]*IncludeTrace `json:"includes"`
+
+	// current is the template file being rendered.
+	current *TemplateTrace
+	// trees are the parse trees of the current template file and of the
+	// named templates it included.
+	trees map[string]*parse.Tree
+	// stack are the names of the templates being included.
+	stack []string
+}
+
+// TemplateTrace is the trace of a rendered template file.
+type TemplateTrace struct {
+	Name string `json:"name"`
+	// Duration is the time spent rendering the template.
+	Duration time.Duration `json:"duration"`
+	// Values are the paths of the values referenced by the template and by
+	// the named templates it included, e.g. "Values.image.tag".
+	Values []string `json:"values,omitempty"`
+	// Includes are the named templates included while rendering the template.
+	Includes []string `json:"includes,omitempty"`
+	// MaxDepth is the deepest nesting of included templates.
+	MaxDepth int `json:"maxDepth"`
+}
+
+// IncludeTrace is the trace of a named template.
+type IncludeTrace struct {
+	Name string `json:"name"`
+	// Calls is the number of times the template was included.
+	Calls int `json:"calls"`
+	// Duration is the total time spent in the template, including the time
+	// spent in the templates it included.
+	Duration time.Duration `json:"duration"`
+	// Recursive is true if the template was included by itself.
+	Recursive bool `json:"recursive,omitempty"`
+}
+
+// NewRenderTrace creates an empty trace.
+func NewRenderTrace() *RenderTrace {
+	return &RenderTrace{Includes: map[string]*IncludeTrace{}}
+}
+
+// execute renders the template file named name of t with fn, and records it.
+// Templates rendered by the tpl function are recorded as part of the template
+// file calling tpl.
+func (r *RenderTrace) execute(t *template.Template, name string, fn func() error) error {
+	if r == nil || r.current != nil {
+		return fn()
+	}
+
+	r.current = &TemplateTrace{Name: name}
+	r.trees = map[string]*parse.Tree{}
+	if tpl := t.Lookup(name); tpl != nil {
+		r.trees[name] = tpl.Tree
+	}
+	start := time.Now()
+	err := fn()
+	r.current.Duration = time.Since(start)
+
+	values := map[string]bool{}
+	for tname, tree := range r.trees {
+		if tname != name {
+			r.current.Includes = append(r.current.Includes, tname)
+		}
+		if tree != nil {
+			valuePaths(tree.Root, values)
+		}
+	}
+	for p := range values {
+		r.current.Values = append(r.current.Values, p)
+	}
+	sort.Strings(r.current.Includes)
+	sort.Strings(r.current.Values)
+
+	r.Templates = append(r.Templates, r.current)
+	r.current, r.trees = nil, nil
+	return err
+}
+
+// include renders the named template name of t with fn, and records it.
+func (r *RenderTrace) include(t *template.Template, name string, fn func() error) error {
+	if r == nil {
+		return fn()
+	}
+
+	it, ok := r.Includes[name]
+	if !ok {
+		it = &IncludeTrace{Name: name}
+		r.Includes[name] = it
+	}
+	it.Calls++
+	if contains(r.stack, name) {
+		it.Recursive = true
+	}
+	r.stack = append(r.stack, name)
+	if r.current != nil {
+		if len(r.stack) > r.current.MaxDepth {
+			r.current.MaxDepth = len(r.stack)
+		}
+		if tpl := t.Lookup(name); tpl != nil {
+			r.trees[name] = tpl.Tree
+		}
+	}
+
+	start := time.Now()
+	err := fn()
+	r.stack = r.stack[:len(r.stack)-1]
+	// Recursive calls are already part of the duration of the outer call.
+	if !contains(r.stack, name) {
+		it.Duration += time.Since(start)
+	}
+	return err
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// valuePaths adds the paths of the values referenced by node, such as
+// ".Values.image.tag" or "$.Values.image.tag", to paths.
+func valuePaths(node parse.Node, paths map[string]bool) {
+	if node == nil {
+		return
+	}
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, c := range n.Nodes {
+			valuePaths(c, paths)
+		}
+	case *parse.ActionNode:
+		valuePaths(n.Pipe, paths)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, c := range n.Cmds {
+			valuePaths(c, paths)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			valuePaths(arg, paths)
+		}
+	case *parse.IfNode:
+		valueBranchPaths(&n.BranchNode, paths)
+	case *parse.RangeNode:
+		valueBranchPaths(&n.BranchNode, paths)
+	case *parse.WithNode:
+		valueBranchPaths(&n.BranchNode, paths)
+	case *parse.TemplateNode:
+		valuePaths(n.Pipe, paths)
+	case *parse.FieldNode:
+		addValuePath(n.Ident, paths)
+	case *parse.VariableNode:
+		if len(n.Ident) > 1 && n.Ident[0] == "$" {
+			addValuePath(n.Ident[1:], paths)
+		}
+	}
+}
+
+func valueBranchPaths(n *parse.BranchNode, paths map[string]bool) {
+	valuePaths(n.Pipe, paths)
+	valuePaths(n.List, paths)
+	valuePaths(n.ElseList, paths)
+}
+
+func addValuePath(ident []string, paths map[string]bool) {
+	if len(ident) > 1 && ident[0] == "Values" {
+		paths[strings.Join(ident, ".")] = true
+	}
+}
@@ -0,0 +1,78 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+func TestRenderTrace(t *testing.T) {
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "moby"},
+		Templates: []*chart.Template{
+			{Name: "templates/_helpers", Data: []byte(`{{define "name"}}{{.Values.nameOverride}}{{end}}` +
+				`{{define "labels"}}app: {{include "name" .}}{{end}}` +
+				`{{define "countdown"}}{{if gt (int .) 0}}{{include "countdown" (sub (int .) 1)}}{{end}}{{end}}`)},
+			{Name: "templates/deployment", Data: []byte(`{{include "labels" .}} {{.Values.image.tag}} {{with $.Values.port}}{{.}}{{end}}`)},
+			{Name: "templates/countdown", Data: []byte(`{{include "countdown" 3}}`)},
+			{Name: "templates/plain", Data: []byte(`{{ tpl "{{ .Values.nameOverride }}" . }}`)},
+		},
+	}
+	vals := chartutil.Values{
+		"Values": map[string]interface{}{"nameOverride": "web", "image": map[string]interface{}{"tag": "1.0"}, "port": 80},
+	}
+
+	e := New()
+	e.Trace = NewRenderTrace()
+	if _, err := e.Render(c, vals); err != nil {
+		t.Fatal(err)
+	}
+
+	traces := map[string]*TemplateTrace{}
+	for _, tt := range e.Trace.Templates {
+		traces[tt.Name] = tt
+	}
+	if len(traces) != 3 {
+		t.Fatalf("Expected 3 traced templates, got %d: %v", len(traces), e.Trace.Templates)
+	}
+
+	deploy := traces["moby/templates/deployment"]
+	if expect := []string{"labels", "name"}; !reflect.DeepEqual(deploy.Includes, expect) {
+		t.Errorf("Expected includes %v, got %v", expect, deploy.Includes)
+	}
+	if expect := []string{"Values.image.tag", "Values.nameOverride", "Values.port"}; !reflect.DeepEqual(deploy.Values, expect) {
+		t.Errorf("Expected values %v, got %v", expect, deploy.Values)
+	}
+	if deploy.MaxDepth != 2 {
+		t.Errorf("Expected depth 2, got %d", deploy.MaxDepth)
+	}
+
+	if depth := traces["moby/templates/countdown"].MaxDepth; depth != 4 {
+		t.Errorf("Expected depth 4, got %d", depth)
+	}
+	countdown := e.Trace.Includes["countdown"]
+	if countdown.Calls != 4 || !countdown.Recursive {
+		t.Errorf("Expected 4 recursive calls, got %+v", countdown)
+	}
+	if e.Trace.Includes["labels"].Recursive {
+		t.Error("Expected labels not to be recursive")
+	}
+}
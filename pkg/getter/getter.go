@@ -18,6 +18,7 @@ package getter
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 
 	"k8s.io/helm/pkg/helm/environment"
@@ -29,6 +30,32 @@ type Getter interface {
 	Get(url string) (*bytes.Buffer, error)
 }
 
+// ErrNotModified indicates that the content of a URL has not changed since it
+// was cached.
+var ErrNotModified = errors.New("not modified")
+
+// Validators identify the version of the content of a URL, e.g. with the ETag
+// and Last-Modified HTTP headers.
+type Validators struct {
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+}
+
+// IsZero returns true if none of the validators is set.
+func (v Validators) IsZero() bool {
+	return v.ETag == "" && v.LastModified == ""
+}
+
+// ConditionalGetter is a Getter that only downloads the content of a URL if
+// it has changed since it was cached.
+type ConditionalGetter interface {
+	Getter
+	// GetIfModified returns the content of the URL and its validators, or
+	// ErrNotModified if the content still matches the validators of the
+	// cached content.
+	GetIfModified(url string, cached Validators) (*bytes.Buffer, Validators, error)
+}
+
 // Constructor is the function for every getter which creates a specific instance
 // according to the configuration
 type Constructor func(URL, CertFile, KeyFile, CAFile string) (Getter, error)
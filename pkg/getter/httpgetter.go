@@ -22,7 +22,7 @@ import (
 	"net/http"
 	"strings"
 
-	"k8s.io/helm/pkg/tlsutil"
+	"k8s.io/helm/pkg/httpclient"
 	"k8s.io/helm/pkg/version"
 )
 
@@ -42,19 +42,33 @@ func (g *HttpGetter) SetCredentials(username, password string) {
 
 //Get performs a Get from repo.Getter and returns the body.
 func (g *HttpGetter) Get(href string) (*bytes.Buffer, error) {
-	return g.get(href)
+	buf, _, err := g.get(href, Validators{})
+	return buf, err
+}
+
+// GetIfModified performs a conditional Get with the If-None-Match and
+// If-Modified-Since headers of the cached validators. It returns
+// ErrNotModified if the server answers that the content has not changed.
+func (g *HttpGetter) GetIfModified(href string, cached Validators) (*bytes.Buffer, Validators, error) {
+	return g.get(href, cached)
 }
 
-func (g *HttpGetter) get(href string) (*bytes.Buffer, error) {
+func (g *HttpGetter) get(href string, cached Validators) (*bytes.Buffer, Validators, error) {
 	buf := bytes.NewBuffer(nil)
 
 	// Set a helm specific user agent so that a repo server and metrics can
 	// separate helm calls from other tools interacting with repos.
 	req, err := http.NewRequest("GET", href, nil)
 	if err != nil {
-		return buf, err
+		return buf, Validators{}, err
 	}
 	req.Header.Set("User-Agent", "Helm/"+strings.TrimPrefix(version.GetVersion(), "v"))
+	if cached.ETag != "" {
+		req.Header.Set("If-None-Match", cached.ETag)
+	}
+	if cached.LastModified != "" {
+		req.Header.Set("If-Modified-Since", cached.LastModified)
+	}
 
 	if g.username != "" && g.password != "" {
 		req.SetBasicAuth(g.username, g.password)
@@ -62,15 +76,22 @@ func (g *HttpGetter) get(href string) (*bytes.Buffer, error) {
 
 	resp, err := g.client.Do(req)
 	if err != nil {
-		return buf, err
+		return buf, Validators{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotModified && !cached.IsZero() {
+		return buf, cached, ErrNotModified
 	}
 	if resp.StatusCode != 200 {
-		return buf, fmt.Errorf("Failed to fetch %s : %s", href, resp.Status)
+		return buf, Validators{}, fmt.Errorf("Failed to fetch %s : %s", href, resp.Status)
 	}
 
+	validators := Validators{
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+	}
 	_, err = io.Copy(buf, resp.Body)
-	resp.Body.Close()
-	return buf, err
+	return buf, validators, err
 }
 
 // newHTTPGetter constructs a valid http/https client as Getter
@@ -80,18 +101,22 @@ func newHTTPGetter(URL, CertFile, KeyFile, CAFile string) (Getter, error) {
 
 // NewHTTPGetter constructs a valid http/https client as HttpGetter
 func NewHTTPGetter(URL, CertFile, KeyFile, CAFile string) (*HttpGetter, error) {
+	return NewHTTPGetterWithOptions(httpclient.Options{
+		URL:      URL,
+		CertFile: CertFile,
+		KeyFile:  KeyFile,
+		CAFile:   CAFile,
+	})
+}
+
+// NewHTTPGetterWithOptions constructs an HttpGetter with a proxy and a
+// timeout in addition to the TLS files.
+func NewHTTPGetterWithOptions(opts httpclient.Options) (*HttpGetter, error) {
 	var client HttpGetter
-	tr := &http.Transport{
-		DisableCompression: true,
-		Proxy:              http.ProxyFromEnvironment,
-	}
-	if (CertFile != "" && KeyFile != "") || CAFile != "" {
-		tlsConf, err := tlsutil.NewTLSConfig(URL, CertFile, KeyFile, CAFile)
-		if err != nil {
-			return &client, fmt.Errorf("can't create TLS config: %s", err.Error())
-		}
-		tr.TLSClientConfig = tlsConf
+	c, err := httpclient.New(opts)
+	if err != nil {
+		return &client, err
 	}
-	client.client = &http.Client{Transport: tr}
+	client.client = c
 	return &client, nil
 }
@@ -105,3 +105,37 @@ func TestHTTPGetterTarDownload(t *testing.T) {
 		t.Fatalf("Expected response with MIME type %s, but got %s", expectedMimeType, mimeType)
 	}
 }
+
+func TestHTTPGetterIfModified(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Last-Modified", "Mon, 02 Jan 2006 15:04:05 GMT")
+		w.Write([]byte("apiVersion: v1"))
+	}))
+	defer server.Close()
+
+	g, err := NewHTTPGetter(server.URL, "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, v, err := g.GetIfModified(server.URL, Validators{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data.String() != "apiVersion: v1" {
+		t.Errorf("Expected the content, got %q", data.String())
+	}
+	expect := Validators{ETag: `"v1"`, LastModified: "Mon, 02 Jan 2006 15:04:05 GMT"}
+	if v != expect {
+		t.Errorf("Expected validators %v, got %v", expect, v)
+	}
+
+	if _, _, err := g.GetIfModified(server.URL, v); err != ErrNotModified {
+		t.Errorf("Expected ErrNotModified, got %v", err)
+	}
+}
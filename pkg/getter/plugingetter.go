@@ -17,11 +17,6 @@ package getter
 
 import (
 	"bytes"
-	"fmt"
-	"os"
-	"os/exec"
-	"path/filepath"
-	"strings"
 
 	"k8s.io/helm/pkg/helm/environment"
 	"k8s.io/helm/pkg/plugin"
@@ -57,29 +52,12 @@ type pluginGetter struct {
 	command                   string
 	certFile, keyFile, cAFile string
 	settings                  environment.EnvSettings
-	name                      string
-	base                      string
+	plugin                    *plugin.Plugin
 }
 
 // Get runs downloader plugin command
 func (p *pluginGetter) Get(href string) (*bytes.Buffer, error) {
-	commands := strings.Split(p.command, " ")
-	argv := append(commands[1:], p.certFile, p.keyFile, p.cAFile, href)
-	prog := exec.Command(filepath.Join(p.base, commands[0]), argv...)
-	plugin.SetupPluginEnv(p.settings, p.name, p.base)
-	prog.Env = os.Environ()
-	buf := bytes.NewBuffer(nil)
-	prog.Stdout = buf
-	prog.Stderr = os.Stderr
-	prog.Stdin = os.Stdin
-	if err := prog.Run(); err != nil {
-		if eerr, ok := err.(*exec.ExitError); ok {
-			os.Stderr.Write(eerr.Stderr)
-			return nil, fmt.Errorf("plugin %q exited with error", p.command)
-		}
-		return nil, err
-	}
-	return buf, nil
+	return p.plugin.Download(p.command, p.settings, p.certFile, p.keyFile, p.cAFile, href)
 }
 
 // newPluginGetter constructs a valid plugin getter
@@ -91,8 +69,7 @@ func newPluginGetter(command string, settings environment.EnvSettings, name, bas
 			keyFile:  KeyFile,
 			cAFile:   CAFile,
 			settings: settings,
-			name:     name,
-			base:     base,
+			plugin:   &plugin.Plugin{Metadata: &plugin.Metadata{Name: name}, Dir: base},
 		}
 		return result, nil
 	}
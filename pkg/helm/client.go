@@ -23,12 +23,15 @@ import (
 
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
 
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/proto/hapi/release"
 	rls "k8s.io/helm/pkg/proto/hapi/services"
 )
 
@@ -45,7 +48,7 @@ type Client struct {
 func NewClient(opts ...Option) *Client {
 	var c Client
 	// set some sane defaults
-	c.Option(ConnectTimeout(5))
+	c.Option(ConnectTimeout(5), RetryBackoff(500*time.Millisecond), KeepaliveTime(30))
 	return c.Option(opts...)
 }
 
@@ -59,12 +62,17 @@ func (h *Client) Option(opts ...Option) *Client {
 
 // ListReleases lists the current releases.
 func (h *Client) ListReleases(opts ...ReleaseListOption) (*rls.ListReleasesResponse, error) {
+	return h.ListReleasesWithContext(NewContext(), opts...)
+}
+
+// ListReleasesWithContext lists the current releases while accepting a context.
+func (h *Client) ListReleasesWithContext(ctx context.Context, opts ...ReleaseListOption) (*rls.ListReleasesResponse, error) {
 	reqOpts := h.opts
 	for _, opt := range opts {
 		opt(&reqOpts)
 	}
 	req := &reqOpts.listReq
-	ctx := NewContext()
+	ctx = FromContext(ctx)
 
 	if reqOpts.before != nil {
 		if err := reqOpts.before(ctx, req); err != nil {
@@ -74,6 +82,26 @@ func (h *Client) ListReleases(opts ...ReleaseListOption) (*rls.ListReleasesRespo
 	return h.list(ctx, req)
 }
 
+// ListReleasesStream lists the current releases, passing the releases to fn
+// as they are received from Tiller instead of buffering the complete list. The
+// Count, Next and Total of every response passed to fn are those of the whole
+// listing. An error returned by fn stops the listing and is returned.
+func (h *Client) ListReleasesStream(fn func(*rls.ListReleasesResponse) error, opts ...ReleaseListOption) error {
+	reqOpts := h.opts
+	for _, opt := range opts {
+		opt(&reqOpts)
+	}
+	req := &reqOpts.listReq
+	ctx := NewContext()
+
+	if reqOpts.before != nil {
+		if err := reqOpts.before(ctx, req); err != nil {
+			return err
+		}
+	}
+	return h.listStream(ctx, req, fn)
+}
+
 // InstallRelease loads a chart from chstr, installs it, and returns the release response.
 func (h *Client) InstallRelease(chstr, ns string, opts ...InstallOption) (*rls.InstallReleaseResponse, error) {
 	// load the chart to install
@@ -88,7 +116,7 @@ func (h *Client) InstallRelease(chstr, ns string, opts ...InstallOption) (*rls.I
 // InstallReleaseWithContext loads a chart from chstr, installs it, and returns the release response while accepting a context.
 func (h *Client) InstallReleaseWithContext(ctx context.Context, chstr, ns string, opts ...InstallOption) (*rls.InstallReleaseResponse, error) {
 	// load the chart to install
-	chart, err := chartutil.Load(chstr)
+	chart, err := chartutil.LoadWithContext(ctx, chstr)
 	if err != nil {
 		return nil, err
 	}
@@ -141,6 +169,11 @@ func (h *Client) installReleaseFromChartWithContext(ctx context.Context, chart *
 
 // DeleteRelease uninstalls a named release and returns the response.
 func (h *Client) DeleteRelease(rlsName string, opts ...DeleteOption) (*rls.UninstallReleaseResponse, error) {
+	return h.DeleteReleaseWithContext(NewContext(), rlsName, opts...)
+}
+
+// DeleteReleaseWithContext uninstalls a named release and returns the response while accepting a context.
+func (h *Client) DeleteReleaseWithContext(ctx context.Context, rlsName string, opts ...DeleteOption) (*rls.UninstallReleaseResponse, error) {
 	// apply the uninstall options
 	reqOpts := h.opts
 	for _, opt := range opts {
@@ -149,7 +182,7 @@ func (h *Client) DeleteRelease(rlsName string, opts ...DeleteOption) (*rls.Unins
 
 	if reqOpts.dryRun {
 		// In the dry run case, just see if the release exists
-		r, err := h.ReleaseContent(rlsName)
+		r, err := h.ReleaseContentWithContext(ctx, rlsName)
 		if err != nil {
 			return &rls.UninstallReleaseResponse{}, err
 		}
@@ -159,7 +192,7 @@ func (h *Client) DeleteRelease(rlsName string, opts ...DeleteOption) (*rls.Unins
 	req := &reqOpts.uninstallReq
 	req.Name = rlsName
 	req.DisableHooks = reqOpts.disableHooks
-	ctx := NewContext()
+	ctx = FromContext(ctx)
 
 	if reqOpts.before != nil {
 		if err := reqOpts.before(ctx, req); err != nil {
@@ -183,7 +216,7 @@ func (h *Client) UpdateRelease(rlsName string, chstr string, opts ...UpdateOptio
 // UpdateReleaseWithContext loads a chart from chstr and updates a release to a new/different chart while accepting a context.
 func (h *Client) UpdateReleaseWithContext(ctx context.Context, rlsName string, chstr string, opts ...UpdateOption) (*rls.UpdateReleaseResponse, error) {
 	// load the chart to update
-	chart, err := chartutil.Load(chstr)
+	chart, err := chartutil.LoadWithContext(ctx, chstr)
 	if err != nil {
 		return nil, err
 	}
@@ -238,12 +271,17 @@ func (h *Client) updateReleaseFromChartWithContext(ctx context.Context, rlsName
 
 // GetVersion returns the server version.
 func (h *Client) GetVersion(opts ...VersionOption) (*rls.GetVersionResponse, error) {
+	return h.GetVersionWithContext(NewContext(), opts...)
+}
+
+// GetVersionWithContext returns the server version while accepting a context.
+func (h *Client) GetVersionWithContext(ctx context.Context, opts ...VersionOption) (*rls.GetVersionResponse, error) {
 	reqOpts := h.opts
 	for _, opt := range opts {
 		opt(&reqOpts)
 	}
 	req := &rls.GetVersionRequest{}
-	ctx := NewContext()
+	ctx = FromContext(ctx)
 
 	if reqOpts.before != nil {
 		if err := reqOpts.before(ctx, req); err != nil {
@@ -255,6 +293,11 @@ func (h *Client) GetVersion(opts ...VersionOption) (*rls.GetVersionResponse, err
 
 // RollbackRelease rolls back a release to the previous version.
 func (h *Client) RollbackRelease(rlsName string, opts ...RollbackOption) (*rls.RollbackReleaseResponse, error) {
+	return h.RollbackReleaseWithContext(NewContext(), rlsName, opts...)
+}
+
+// RollbackReleaseWithContext rolls back a release to the previous version while accepting a context.
+func (h *Client) RollbackReleaseWithContext(ctx context.Context, rlsName string, opts ...RollbackOption) (*rls.RollbackReleaseResponse, error) {
 	reqOpts := h.opts
 	for _, opt := range opts {
 		opt(&reqOpts)
@@ -265,7 +308,7 @@ func (h *Client) RollbackRelease(rlsName string, opts ...RollbackOption) (*rls.R
 	req.DisableHooks = reqOpts.disableHooks
 	req.DryRun = reqOpts.dryRun
 	req.Name = rlsName
-	ctx := NewContext()
+	ctx = FromContext(ctx)
 
 	if reqOpts.before != nil {
 		if err := reqOpts.before(ctx, req); err != nil {
@@ -277,13 +320,18 @@ func (h *Client) RollbackRelease(rlsName string, opts ...RollbackOption) (*rls.R
 
 // ReleaseStatus returns the given release's status.
 func (h *Client) ReleaseStatus(rlsName string, opts ...StatusOption) (*rls.GetReleaseStatusResponse, error) {
+	return h.ReleaseStatusWithContext(NewContext(), rlsName, opts...)
+}
+
+// ReleaseStatusWithContext returns the given release's status while accepting a context.
+func (h *Client) ReleaseStatusWithContext(ctx context.Context, rlsName string, opts ...StatusOption) (*rls.GetReleaseStatusResponse, error) {
 	reqOpts := h.opts
 	for _, opt := range opts {
 		opt(&reqOpts)
 	}
 	req := &reqOpts.statusReq
 	req.Name = rlsName
-	ctx := NewContext()
+	ctx = FromContext(ctx)
 
 	if reqOpts.before != nil {
 		if err := reqOpts.before(ctx, req); err != nil {
@@ -295,13 +343,18 @@ func (h *Client) ReleaseStatus(rlsName string, opts ...StatusOption) (*rls.GetRe
 
 // ReleaseContent returns the configuration for a given release.
 func (h *Client) ReleaseContent(rlsName string, opts ...ContentOption) (*rls.GetReleaseContentResponse, error) {
+	return h.ReleaseContentWithContext(NewContext(), rlsName, opts...)
+}
+
+// ReleaseContentWithContext returns the configuration for a given release while accepting a context.
+func (h *Client) ReleaseContentWithContext(ctx context.Context, rlsName string, opts ...ContentOption) (*rls.GetReleaseContentResponse, error) {
 	reqOpts := h.opts
 	for _, opt := range opts {
 		opt(&reqOpts)
 	}
 	req := &reqOpts.contentReq
 	req.Name = rlsName
-	ctx := NewContext()
+	ctx = FromContext(ctx)
 
 	if reqOpts.before != nil {
 		if err := reqOpts.before(ctx, req); err != nil {
@@ -313,6 +366,11 @@ func (h *Client) ReleaseContent(rlsName string, opts ...ContentOption) (*rls.Get
 
 // ReleaseHistory returns a release's revision history.
 func (h *Client) ReleaseHistory(rlsName string, opts ...HistoryOption) (*rls.GetHistoryResponse, error) {
+	return h.ReleaseHistoryWithContext(NewContext(), rlsName, opts...)
+}
+
+// ReleaseHistoryWithContext returns a release's revision history while accepting a context.
+func (h *Client) ReleaseHistoryWithContext(ctx context.Context, rlsName string, opts ...HistoryOption) (*rls.GetHistoryResponse, error) {
 	reqOpts := h.opts
 	for _, opt := range opts {
 		opt(&reqOpts)
@@ -320,7 +378,7 @@ func (h *Client) ReleaseHistory(rlsName string, opts ...HistoryOption) (*rls.Get
 
 	req := &reqOpts.histReq
 	req.Name = rlsName
-	ctx := NewContext()
+	ctx = FromContext(ctx)
 
 	if reqOpts.before != nil {
 		if err := reqOpts.before(ctx, req); err != nil {
@@ -332,6 +390,11 @@ func (h *Client) ReleaseHistory(rlsName string, opts ...HistoryOption) (*rls.Get
 
 // RunReleaseTest executes a pre-defined test on a release.
 func (h *Client) RunReleaseTest(rlsName string, opts ...ReleaseTestOption) (<-chan *rls.TestReleaseResponse, <-chan error) {
+	return h.RunReleaseTestWithContext(NewContext(), rlsName, opts...)
+}
+
+// RunReleaseTestWithContext executes a pre-defined test on a release while accepting a context.
+func (h *Client) RunReleaseTestWithContext(ctx context.Context, rlsName string, opts ...ReleaseTestOption) (<-chan *rls.TestReleaseResponse, <-chan error) {
 	reqOpts := h.opts
 	for _, opt := range opts {
 		opt(&reqOpts)
@@ -339,11 +402,68 @@ func (h *Client) RunReleaseTest(rlsName string, opts ...ReleaseTestOption) (<-ch
 
 	req := &reqOpts.testReq
 	req.Name = rlsName
-	ctx := NewContext()
+	ctx = FromContext(ctx)
 
 	return h.test(ctx, req)
 }
 
+// ImportReleaseHistory stores the revisions of an exported release history.
+func (h *Client) ImportReleaseHistory(rels []*release.Release, opts ...ImportOption) (*rls.ImportReleaseHistoryResponse, error) {
+	reqOpts := h.opts
+	for _, opt := range opts {
+		opt(&reqOpts)
+	}
+
+	req := &reqOpts.importReq
+	req.Releases = rels
+	ctx := NewContext()
+
+	if reqOpts.before != nil {
+		if err := reqOpts.before(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+	return h.importHistory(ctx, req)
+}
+
+// PruneReleaseHistory removes old revisions from the history of a release.
+func (h *Client) PruneReleaseHistory(rlsName string, opts ...PruneOption) (*rls.PruneReleaseHistoryResponse, error) {
+	reqOpts := h.opts
+	for _, opt := range opts {
+		opt(&reqOpts)
+	}
+
+	req := &reqOpts.pruneReq
+	req.Name = rlsName
+	ctx := NewContext()
+
+	if reqOpts.before != nil {
+		if err := reqOpts.before(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+	return h.pruneHistory(ctx, req)
+}
+
+// ReleaseAudit returns the audit records of a release, the most recent first.
+func (h *Client) ReleaseAudit(rlsName string, opts ...AuditOption) (*rls.GetReleaseAuditResponse, error) {
+	reqOpts := h.opts
+	for _, opt := range opts {
+		opt(&reqOpts)
+	}
+
+	req := &reqOpts.auditReq
+	req.Name = rlsName
+	ctx := NewContext()
+
+	if reqOpts.before != nil {
+		if err := reqOpts.before(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+	return h.audit(ctx, req)
+}
+
 // PingTiller pings the Tiller pod and ensures that it is up and running
 func (h *Client) PingTiller() error {
 	ctx := NewContext()
@@ -356,9 +476,9 @@ func (h *Client) connect(ctx context.Context) (conn *grpc.ClientConn, err error)
 	opts := []grpc.DialOption{
 		grpc.WithBlock(),
 		grpc.WithKeepaliveParams(keepalive.ClientParameters{
-			// Send keepalive every 30 seconds to prevent the connection from
-			// getting closed by upstreams
-			Time: time.Duration(30) * time.Second,
+			// Send keepalives to prevent the connection from getting closed
+			// by upstreams
+			Time: h.opts.keepaliveTime,
 		}),
 		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maxMsgSize)),
 	}
@@ -368,6 +488,9 @@ func (h *Client) connect(ctx context.Context) (conn *grpc.ClientConn, err error)
 	default:
 		opts = append(opts, grpc.WithInsecure())
 	}
+	if h.opts.dialer != nil {
+		opts = append(opts, grpc.WithDialer(h.opts.dialer))
+	}
 	ctx, cancel := context.WithTimeout(ctx, h.opts.connectTimeout)
 	defer cancel()
 	if conn, err = grpc.DialContext(ctx, h.opts.host, opts...); err != nil {
@@ -378,37 +501,63 @@ func (h *Client) connect(ctx context.Context) (conn *grpc.ClientConn, err error)
 
 // list executes tiller.ListReleases RPC.
 func (h *Client) list(ctx context.Context, req *rls.ListReleasesRequest) (*rls.ListReleasesResponse, error) {
-	c, err := h.connect(ctx)
+	var resp *rls.ListReleasesResponse
+	err := h.listStream(ctx, req, func(r *rls.ListReleasesResponse) error {
+		if resp == nil {
+			resp = r
+			return nil
+		}
+		resp.Releases = append(resp.Releases, r.GetReleases()...)
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	defer c.Close()
+	return resp, nil
+}
 
-	rlc := rls.NewReleaseServiceClient(c)
-	s, err := rlc.ListReleases(ctx, req)
-	if err != nil {
-		return nil, err
-	}
-	var resp *rls.ListReleasesResponse
-	for {
-		r, err := s.Recv()
-		if err == io.EOF {
-			break
+// listStream executes tiller.ListReleases RPC, passing each response received
+// on the stream to fn. The call is only retried until a response was passed to
+// fn.
+func (h *Client) listStream(ctx context.Context, req *rls.ListReleasesRequest, fn func(*rls.ListReleasesResponse) error) error {
+	delivered, received := false, 0
+	return h.retry(ctx, func(ctx context.Context) error {
+		c, err := h.connect(ctx)
+		if err != nil {
+			return err
 		}
+		defer c.Close()
+
+		rlc := rls.NewReleaseServiceClient(c)
+		s, err := rlc.ListReleases(ctx, req)
 		if err != nil {
-			return nil, err
+			return err
 		}
-		if resp == nil {
-			resp = r
-			continue
+		for {
+			r, err := s.Recv()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				if delivered {
+					// The releases already passed to fn would be repeated by a retry.
+					return fmt.Errorf("listing releases interrupted after %d releases: %s", received, err)
+				}
+				return err
+			}
+			if err := fn(r); err != nil {
+				return err
+			}
+			delivered = true
+			received += len(r.GetReleases())
 		}
-		resp.Releases = append(resp.Releases, r.GetReleases()...)
-	}
-	return resp, nil
+	})
 }
 
 // install executes tiller.InstallRelease RPC.
 func (h *Client) install(ctx context.Context, req *rls.InstallReleaseRequest) (*rls.InstallReleaseResponse, error) {
+	ctx, cancel := h.callContext(ctx)
+	defer cancel()
 	c, err := h.connect(ctx)
 	if err != nil {
 		return nil, err
@@ -421,6 +570,8 @@ func (h *Client) install(ctx context.Context, req *rls.InstallReleaseRequest) (*
 
 // delete executes tiller.UninstallRelease RPC.
 func (h *Client) delete(ctx context.Context, req *rls.UninstallReleaseRequest) (*rls.UninstallReleaseResponse, error) {
+	ctx, cancel := h.callContext(ctx)
+	defer cancel()
 	c, err := h.connect(ctx)
 	if err != nil {
 		return nil, err
@@ -433,6 +584,8 @@ func (h *Client) delete(ctx context.Context, req *rls.UninstallReleaseRequest) (
 
 // update executes tiller.UpdateRelease RPC.
 func (h *Client) update(ctx context.Context, req *rls.UpdateReleaseRequest) (*rls.UpdateReleaseResponse, error) {
+	ctx, cancel := h.callContext(ctx)
+	defer cancel()
 	c, err := h.connect(ctx)
 	if err != nil {
 		return nil, err
@@ -445,6 +598,8 @@ func (h *Client) update(ctx context.Context, req *rls.UpdateReleaseRequest) (*rl
 
 // rollback executes tiller.RollbackRelease RPC.
 func (h *Client) rollback(ctx context.Context, req *rls.RollbackReleaseRequest) (*rls.RollbackReleaseResponse, error) {
+	ctx, cancel := h.callContext(ctx)
+	defer cancel()
 	c, err := h.connect(ctx)
 	if err != nil {
 		return nil, err
@@ -457,18 +612,42 @@ func (h *Client) rollback(ctx context.Context, req *rls.RollbackReleaseRequest)
 
 // status executes tiller.GetReleaseStatus RPC.
 func (h *Client) status(ctx context.Context, req *rls.GetReleaseStatusRequest) (*rls.GetReleaseStatusResponse, error) {
-	c, err := h.connect(ctx)
-	if err != nil {
-		return nil, err
-	}
-	defer c.Close()
+	var resp *rls.GetReleaseStatusResponse
+	err := h.retry(ctx, func(ctx context.Context) error {
+		c, err := h.connect(ctx)
+		if err != nil {
+			return err
+		}
+		defer c.Close()
 
-	rlc := rls.NewReleaseServiceClient(c)
-	return rlc.GetReleaseStatus(ctx, req)
+		rlc := rls.NewReleaseServiceClient(c)
+		resp, err = rlc.GetReleaseStatus(ctx, req)
+		return err
+	})
+	return resp, err
 }
 
 // content executes tiller.GetReleaseContent RPC.
 func (h *Client) content(ctx context.Context, req *rls.GetReleaseContentRequest) (*rls.GetReleaseContentResponse, error) {
+	var resp *rls.GetReleaseContentResponse
+	err := h.retry(ctx, func(ctx context.Context) error {
+		c, err := h.connect(ctx)
+		if err != nil {
+			return err
+		}
+		defer c.Close()
+
+		rlc := rls.NewReleaseServiceClient(c)
+		resp, err = rlc.GetReleaseContent(ctx, req)
+		return err
+	})
+	return resp, err
+}
+
+// version executes tiller.GetVersion RPC.
+func (h *Client) version(ctx context.Context, req *rls.GetVersionRequest) (*rls.GetVersionResponse, error) {
+	ctx, cancel := h.callContext(ctx)
+	defer cancel()
 	c, err := h.connect(ctx)
 	if err != nil {
 		return nil, err
@@ -476,11 +655,47 @@ func (h *Client) content(ctx context.Context, req *rls.GetReleaseContentRequest)
 	defer c.Close()
 
 	rlc := rls.NewReleaseServiceClient(c)
-	return rlc.GetReleaseContent(ctx, req)
+	return rlc.GetVersion(ctx, req)
 }
 
-// version executes tiller.GetVersion RPC.
-func (h *Client) version(ctx context.Context, req *rls.GetVersionRequest) (*rls.GetVersionResponse, error) {
+// history executes tiller.GetHistory RPC.
+func (h *Client) history(ctx context.Context, req *rls.GetHistoryRequest) (*rls.GetHistoryResponse, error) {
+	var resp *rls.GetHistoryResponse
+	err := h.retry(ctx, func(ctx context.Context) error {
+		c, err := h.connect(ctx)
+		if err != nil {
+			return err
+		}
+		defer c.Close()
+
+		rlc := rls.NewReleaseServiceClient(c)
+		resp, err = rlc.GetHistory(ctx, req)
+		return err
+	})
+	return resp, err
+}
+
+// audit executes tiller.GetReleaseAudit RPC.
+func (h *Client) audit(ctx context.Context, req *rls.GetReleaseAuditRequest) (*rls.GetReleaseAuditResponse, error) {
+	var resp *rls.GetReleaseAuditResponse
+	err := h.retry(ctx, func(ctx context.Context) error {
+		c, err := h.connect(ctx)
+		if err != nil {
+			return err
+		}
+		defer c.Close()
+
+		rlc := rls.NewReleaseServiceClient(c)
+		resp, err = rlc.GetReleaseAudit(ctx, req)
+		return err
+	})
+	return resp, err
+}
+
+// pruneHistory executes tiller.PruneReleaseHistory RPC.
+func (h *Client) pruneHistory(ctx context.Context, req *rls.PruneReleaseHistoryRequest) (*rls.PruneReleaseHistoryResponse, error) {
+	ctx, cancel := h.callContext(ctx)
+	defer cancel()
 	c, err := h.connect(ctx)
 	if err != nil {
 		return nil, err
@@ -488,11 +703,13 @@ func (h *Client) version(ctx context.Context, req *rls.GetVersionRequest) (*rls.
 	defer c.Close()
 
 	rlc := rls.NewReleaseServiceClient(c)
-	return rlc.GetVersion(ctx, req)
+	return rlc.PruneReleaseHistory(ctx, req)
 }
 
-// history executes tiller.GetHistory RPC.
-func (h *Client) history(ctx context.Context, req *rls.GetHistoryRequest) (*rls.GetHistoryResponse, error) {
+// importHistory executes tiller.ImportReleaseHistory RPC.
+func (h *Client) importHistory(ctx context.Context, req *rls.ImportReleaseHistoryRequest) (*rls.ImportReleaseHistoryResponse, error) {
+	ctx, cancel := h.callContext(ctx)
+	defer cancel()
 	c, err := h.connect(ctx)
 	if err != nil {
 		return nil, err
@@ -500,7 +717,7 @@ func (h *Client) history(ctx context.Context, req *rls.GetHistoryRequest) (*rls.
 	defer c.Close()
 
 	rlc := rls.NewReleaseServiceClient(c)
-	return rlc.GetHistory(ctx, req)
+	return rlc.ImportReleaseHistory(ctx, req)
 }
 
 // test executes tiller.TestRelease RPC.
@@ -563,3 +780,43 @@ func (h *Client) ping(ctx context.Context) error {
 		return fmt.Errorf("tiller healthcheck returned an unknown status")
 	}
 }
+
+// callContext returns the context of a call to Tiller, limited by the call
+// timeout if set.
+func (h *Client) callContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if h.opts.callTimeout > 0 {
+		return context.WithTimeout(ctx, h.opts.callTimeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// retry runs call, retrying it with an exponential backoff while Tiller is
+// unavailable or the call timed out, up to the number of retries. Only the
+// calls that do not change releases may be retried.
+func (h *Client) retry(ctx context.Context, call func(context.Context) error) error {
+	backoff := h.opts.retryBackoff
+	for attempt := 0; ; attempt++ {
+		callCtx, cancel := h.callContext(ctx)
+		err := call(callCtx)
+		cancel()
+		if err == nil || attempt >= h.opts.retries || !retryable(err) {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// retryable returns whether a call that failed with err may succeed if
+// retried.
+func retryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	}
+	return false
+}
@@ -17,8 +17,18 @@ limitations under the License.
 package helm
 
 import (
+	"net"
+	"strings"
 	"testing"
 	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"k8s.io/helm/pkg/proto/hapi/release"
+	rls "k8s.io/helm/pkg/proto/hapi/services"
 )
 
 func TestNewClient(t *testing.T) {
@@ -32,3 +42,158 @@ func TestNewClient(t *testing.T) {
 		t.Errorf("expected timeout duration to be 1 minute, got %v", helmClient.opts.connectTimeout)
 	}
 }
+
+// flakyReleaseServer is a release server whose calls fail as unavailable
+// before succeeding.
+type flakyReleaseServer struct {
+	rls.ReleaseServiceServer
+	failures int
+	calls    int
+	delay    time.Duration
+	// interrupt fails a listing as unavailable after its first response.
+	interrupt bool
+}
+
+func (s *flakyReleaseServer) fail() error {
+	s.calls++
+	if s.calls <= s.failures {
+		return status.Error(codes.Unavailable, "tiller is restarting")
+	}
+	return nil
+}
+
+func (s *flakyReleaseServer) GetReleaseStatus(ctx context.Context, req *rls.GetReleaseStatusRequest) (*rls.GetReleaseStatusResponse, error) {
+	if err := s.fail(); err != nil {
+		return nil, err
+	}
+	return &rls.GetReleaseStatusResponse{Name: req.Name}, nil
+}
+
+func (s *flakyReleaseServer) GetReleaseContent(ctx context.Context, req *rls.GetReleaseContentRequest) (*rls.GetReleaseContentResponse, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(s.delay):
+	}
+	return &rls.GetReleaseContentResponse{}, nil
+}
+
+func (s *flakyReleaseServer) UninstallRelease(ctx context.Context, req *rls.UninstallReleaseRequest) (*rls.UninstallReleaseResponse, error) {
+	if err := s.fail(); err != nil {
+		return nil, err
+	}
+	return &rls.UninstallReleaseResponse{}, nil
+}
+
+func (s *flakyReleaseServer) ListReleases(req *rls.ListReleasesRequest, stream rls.ReleaseService_ListReleasesServer) error {
+	if err := s.fail(); err != nil {
+		return err
+	}
+	for i, name := range []string{"atlas", "thomas"} {
+		if i > 0 && s.interrupt {
+			return status.Error(codes.Unavailable, "tiller is restarting")
+		}
+		res := &rls.ListReleasesResponse{Count: 2, Total: 2, Releases: []*release.Release{{Name: name}}}
+		if err := stream.Send(res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func startReleaseServer(t *testing.T, srv rls.ReleaseServiceServer) (string, func()) {
+	lstn, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer()
+	rls.RegisterReleaseServiceServer(s, srv)
+	go s.Serve(lstn)
+	return lstn.Addr().String(), s.Stop
+}
+
+func TestRetries(t *testing.T) {
+	srv := &flakyReleaseServer{failures: 2}
+	addr, stop := startReleaseServer(t, srv)
+	defer stop()
+
+	c := NewClient(Host(addr), Retries(2), RetryBackoff(time.Millisecond))
+	res, err := c.ReleaseStatus("foo")
+	if err != nil {
+		t.Fatalf("expected the status call to succeed after 2 retries, got %s", err)
+	}
+	if res.Name != "foo" || srv.calls != 3 {
+		t.Errorf("expected 3 calls of the status of foo, got %d calls of %q", srv.calls, res.Name)
+	}
+
+	srv.calls = 0
+	c = NewClient(Host(addr), Retries(1), RetryBackoff(time.Millisecond))
+	if _, err := c.ReleaseStatus("foo"); status.Code(err) != codes.Unavailable {
+		t.Errorf("expected the status call to fail as unavailable, got %v", err)
+	}
+	if srv.calls != 2 {
+		t.Errorf("expected 2 calls, got %d", srv.calls)
+	}
+
+	// Calls changing releases are not retried.
+	srv.calls = 0
+	c = NewClient(Host(addr), Retries(3), RetryBackoff(time.Millisecond))
+	if _, err := c.DeleteRelease("foo"); err == nil {
+		t.Error("expected the delete call to fail")
+	}
+	if srv.calls != 1 {
+		t.Errorf("expected 1 delete call, got %d", srv.calls)
+	}
+}
+
+func TestListReleasesStream(t *testing.T) {
+	srv := &flakyReleaseServer{failures: 1}
+	addr, stop := startReleaseServer(t, srv)
+	defer stop()
+
+	var names []string
+	collect := func(res *rls.ListReleasesResponse) error {
+		for _, r := range res.Releases {
+			names = append(names, r.Name)
+		}
+		return nil
+	}
+
+	c := NewClient(Host(addr), Retries(1), RetryBackoff(time.Millisecond))
+	if err := c.ListReleasesStream(collect); err != nil {
+		t.Fatalf("expected the listing to succeed after a retry, got %s", err)
+	}
+	if strings.Join(names, ",") != "atlas,thomas" || srv.calls != 2 {
+		t.Errorf("expected atlas and thomas in 2 calls, got %v in %d calls", names, srv.calls)
+	}
+
+	res, err := c.ListReleases()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Releases) != 2 || res.Total != 2 {
+		t.Errorf("expected the 2 releases in a single response, got %v", res)
+	}
+
+	// A listing interrupted after a response is not retried, which would
+	// repeat the releases already received.
+	srv.calls, srv.failures, srv.interrupt, names = 0, 0, true, nil
+	if err := c.ListReleasesStream(collect); err == nil {
+		t.Error("expected the interrupted listing to fail")
+	}
+	if len(names) != 1 || srv.calls != 1 {
+		t.Errorf("expected 1 release in 1 call, got %v in %d calls", names, srv.calls)
+	}
+}
+
+func TestCallTimeout(t *testing.T) {
+	srv := &flakyReleaseServer{delay: time.Second}
+	addr, stop := startReleaseServer(t, srv)
+	defer stop()
+
+	c := NewClient(Host(addr))
+	c.opts.callTimeout = 100 * time.Millisecond
+	if _, err := c.ReleaseContent("foo"); status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("expected the content call to time out, got %v", err)
+	}
+}
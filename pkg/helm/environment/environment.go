@@ -54,12 +54,22 @@ type EnvSettings struct {
 	TillerHost string
 	// TillerConnectionTimeout is the duration (in seconds) helm will wait to establish a connection to Tiller.
 	TillerConnectionTimeout int64
+	// TillerCallTimeout is the duration (in seconds) a call to Tiller may take, 0 meaning no limit.
+	TillerCallTimeout int64
+	// TillerRetries is the number of times the calls that do not change releases are retried while Tiller is unavailable.
+	TillerRetries int
+	// TillerKeepalive is the interval (in seconds) of the keepalive pings sent to Tiller.
+	TillerKeepalive int64
+	// TillerTunnel is the transport of the tunnel to Tiller: auto, spdy or websocket.
+	TillerTunnel string
 	// TillerNamespace is the namespace in which Tiller runs.
 	TillerNamespace string
 	// Home is the local path to the Helm home directory.
 	Home helmpath.Home
 	// Debug indicates whether or not Helm is running in Debug mode.
 	Debug bool
+	// LogFormat is the format of the messages written to stderr, "text" or "json".
+	LogFormat string
 	// KubeContext is the name of the kubeconfig context.
 	KubeContext string
 	// KubeConfig is the path to an explicit kubeconfig file. This overwrites the value in $KUBECONFIG
@@ -85,8 +95,13 @@ func (s *EnvSettings) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&s.KubeContext, "kube-context", "", "Name of the kubeconfig context to use")
 	fs.StringVar(&s.KubeConfig, "kubeconfig", "", "Absolute path of the kubeconfig file to be used")
 	fs.BoolVar(&s.Debug, "debug", false, "Enable verbose output")
+	fs.StringVar(&s.LogFormat, "log-format", "text", "Format of the log messages written to stderr: text or json")
 	fs.StringVar(&s.TillerNamespace, "tiller-namespace", "kube-system", "Namespace of Tiller")
 	fs.Int64Var(&s.TillerConnectionTimeout, "tiller-connection-timeout", int64(300), "The duration (in seconds) Helm will wait to establish a connection to Tiller")
+	fs.Int64Var(&s.TillerCallTimeout, "tiller-call-timeout", 0, "The duration (in seconds) a call to Tiller may take, including the connection, with 0 meaning no limit. Overrides $HELM_TILLER_CALL_TIMEOUT")
+	fs.IntVar(&s.TillerRetries, "tiller-retries", 3, "Number of times the calls to Tiller that do not change releases are retried, with an exponential backoff, while Tiller is unavailable. Overrides $HELM_TILLER_RETRIES")
+	fs.Int64Var(&s.TillerKeepalive, "tiller-keepalive", 30, "The interval (in seconds) of the keepalive pings sent to Tiller. Overrides $HELM_TILLER_KEEPALIVE")
+	fs.StringVar(&s.TillerTunnel, "tiller-tunnel", "auto", "The transport of the tunnel to Tiller: spdy, websocket, or auto to fall back to websocket when spdy fails. Overrides $HELM_TILLER_TUNNEL")
 }
 
 // AddFlagsTLS adds the flags for supporting client side TLS to the given flagset.
@@ -115,10 +130,15 @@ func (s *EnvSettings) InitTLS(fs *pflag.FlagSet) {
 
 // envMap maps flag names to envvars
 var envMap = map[string]string{
-	"debug":            "HELM_DEBUG",
-	"home":             "HELM_HOME",
-	"host":             "HELM_HOST",
-	"tiller-namespace": "TILLER_NAMESPACE",
+	"debug":               "HELM_DEBUG",
+	"home":                "HELM_HOME",
+	"host":                "HELM_HOST",
+	"log-format":          "HELM_LOG_FORMAT",
+	"tiller-namespace":    "TILLER_NAMESPACE",
+	"tiller-call-timeout": "HELM_TILLER_CALL_TIMEOUT",
+	"tiller-retries":      "HELM_TILLER_RETRIES",
+	"tiller-keepalive":    "HELM_TILLER_KEEPALIVE",
+	"tiller-tunnel":       "HELM_TILLER_TUNNEL",
 }
 
 var tlsEnvMap = map[string]string{
@@ -35,8 +35,8 @@ func TestEnvSettings(t *testing.T) {
 		envars map[string]string
 
 		// expected values
-		home, host, ns, kcontext, kconfig, plugins string
-		debug, tlsverify                           bool
+		home, host, ns, kcontext, kconfig, plugins, logformat string
+		debug, tlsverify                                      bool
 	}{
 		{
 			name:      "defaults",
@@ -44,27 +44,30 @@ func TestEnvSettings(t *testing.T) {
 			home:      DefaultHelmHome,
 			plugins:   helmpath.Home(DefaultHelmHome).Plugins(),
 			ns:        "kube-system",
+			logformat: "text",
 			tlsverify: false,
 		},
 		{
 			name:      "with flags set",
-			args:      []string{"--home", "/foo", "--host=here", "--debug", "--tiller-namespace=myns", "--kubeconfig", "/bar"},
+			args:      []string{"--home", "/foo", "--host=here", "--debug", "--tiller-namespace=myns", "--kubeconfig", "/bar", "--log-format", "json"},
 			home:      "/foo",
 			plugins:   helmpath.Home("/foo").Plugins(),
 			host:      "here",
 			ns:        "myns",
 			kconfig:   "/bar",
+			logformat: "json",
 			debug:     true,
 			tlsverify: false,
 		},
 		{
 			name:      "with envvars set",
 			args:      []string{},
-			envars:    map[string]string{"HELM_HOME": "/bar", "HELM_HOST": "there", "HELM_DEBUG": "1", "TILLER_NAMESPACE": "yourns"},
+			envars:    map[string]string{"HELM_HOME": "/bar", "HELM_HOST": "there", "HELM_DEBUG": "1", "TILLER_NAMESPACE": "yourns", "HELM_LOG_FORMAT": "json"},
 			home:      "/bar",
 			plugins:   helmpath.Home("/bar").Plugins(),
 			host:      "there",
 			ns:        "yourns",
+			logformat: "json",
 			debug:     true,
 			tlsverify: false,
 		},
@@ -76,6 +79,7 @@ func TestEnvSettings(t *testing.T) {
 			plugins:   helmpath.Home("/bar").Plugins(),
 			host:      "there",
 			ns:        "yourns",
+			logformat: "text",
 			debug:     true,
 			tlsverify: true,
 		},
@@ -87,6 +91,7 @@ func TestEnvSettings(t *testing.T) {
 			plugins:   "glade",
 			host:      "here",
 			ns:        "myns",
+			logformat: "text",
 			debug:     true,
 			tlsverify: false,
 		},
@@ -96,6 +101,7 @@ func TestEnvSettings(t *testing.T) {
 		"HELM_DEBUG":        "",
 		"HELM_HOME":         "",
 		"HELM_HOST":         "",
+		"HELM_LOG_FORMAT":   "",
 		"TILLER_NAMESPACE":  "",
 		"HELM_PLUGIN":       "",
 		"HELM_TLS_HOSTNAME": "",
@@ -146,6 +152,9 @@ func TestEnvSettings(t *testing.T) {
 			if settings.KubeConfig != tt.kconfig {
 				t.Errorf("expected kubeconfig %q, got %q", tt.kconfig, settings.KubeConfig)
 			}
+			if settings.LogFormat != tt.logformat {
+				t.Errorf("expected log-format %q, got %q", tt.logformat, settings.LogFormat)
+			}
 			if settings.TLSVerify != tt.tlsverify {
 				t.Errorf("expected tls-verify %t, got %t", tt.tlsverify, settings.TLSVerify)
 			}
@@ -155,6 +164,54 @@ func TestEnvSettings(t *testing.T) {
 	}
 }
 
+func TestTillerCallSettings(t *testing.T) {
+	reset := resetEnv(map[string]string{
+		"HELM_TILLER_CALL_TIMEOUT": "",
+		"HELM_TILLER_RETRIES":      "",
+		"HELM_TILLER_KEEPALIVE":    "",
+	})
+	defer reset()
+
+	os.Setenv("HELM_TILLER_CALL_TIMEOUT", "60")
+	os.Setenv("HELM_TILLER_RETRIES", "5")
+
+	flags := pflag.NewFlagSet("testing", pflag.ContinueOnError)
+	settings := &EnvSettings{}
+	settings.AddFlags(flags)
+	flags.Parse([]string{"--tiller-retries", "1"})
+	settings.Init(flags)
+
+	if settings.TillerCallTimeout != 60 {
+		t.Errorf("expected tiller-call-timeout 60, got %d", settings.TillerCallTimeout)
+	}
+	if settings.TillerRetries != 1 {
+		t.Errorf("expected tiller-retries 1, got %d", settings.TillerRetries)
+	}
+	if settings.TillerKeepalive != 30 {
+		t.Errorf("expected tiller-keepalive 30, got %d", settings.TillerKeepalive)
+	}
+}
+
+func TestTillerTunnelSetting(t *testing.T) {
+	reset := resetEnv(map[string]string{"HELM_TILLER_TUNNEL": ""})
+	defer reset()
+
+	flags := pflag.NewFlagSet("testing", pflag.ContinueOnError)
+	settings := &EnvSettings{}
+	settings.AddFlags(flags)
+	flags.Parse([]string{})
+	settings.Init(flags)
+	if settings.TillerTunnel != "auto" {
+		t.Errorf("expected tiller-tunnel auto, got %q", settings.TillerTunnel)
+	}
+
+	os.Setenv("HELM_TILLER_TUNNEL", "websocket")
+	settings.Init(flags)
+	if settings.TillerTunnel != "websocket" {
+		t.Errorf("expected tiller-tunnel websocket, got %q", settings.TillerTunnel)
+	}
+}
+
 func resetEnv(envars map[string]string) func() {
 	origEnv := os.Environ()
 
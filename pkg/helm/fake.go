@@ -19,9 +19,11 @@ package helm // import "k8s.io/helm/pkg/helm"
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"math/rand"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/golang/protobuf/ptypes/timestamp"
 	"golang.org/x/net/context"
@@ -31,8 +33,12 @@ import (
 	"k8s.io/helm/pkg/proto/hapi/release"
 	rls "k8s.io/helm/pkg/proto/hapi/services"
 	"k8s.io/helm/pkg/proto/hapi/version"
+	"k8s.io/helm/pkg/releaseutil"
 	"k8s.io/helm/pkg/renderutil"
+	"k8s.io/helm/pkg/storage"
+	"k8s.io/helm/pkg/storage/driver"
 	storageerrors "k8s.io/helm/pkg/storage/errors"
+	"k8s.io/helm/pkg/timeconv"
 )
 
 // FakeClient implements Interface
@@ -41,6 +47,8 @@ type FakeClient struct {
 	Responses       map[string]release.TestRun_Status
 	Opts            options
 	RenderManifests bool
+	// AuditRecords are the audit records of the releases, the oldest first.
+	AuditRecords []*rls.AuditRecord
 }
 
 // Option returns the fake release client
@@ -62,18 +70,49 @@ func (c *FakeClient) ListReleases(opts ...ReleaseListOption) (*rls.ListReleasesR
 	}
 	req := &reqOpts.listReq
 	rels := c.Rels
-	count := int64(len(c.Rels))
+	var filters []releaseutil.FilterFunc
+	if req.ChartName != "" {
+		filters = append(filters, releaseutil.ChartNameFilter(req.ChartName))
+	}
+	if req.AppVersion != "" {
+		filters = append(filters, releaseutil.AppVersionFilter(req.AppVersion))
+	}
+	if req.Environment != "" {
+		filters = append(filters, releaseutil.EnvironmentFilter(req.Environment))
+	}
+	if req.DeployedAfter != nil {
+		filters = append(filters, releaseutil.DeployedAfterFilter(timeconv.Time(req.DeployedAfter)))
+	}
+	if len(filters) > 0 {
+		rels = releaseutil.All(filters...).Filter(rels)
+	}
+	total := int64(len(rels))
+	if req.Offset != "" {
+		i := -1
+		for ii, r := range rels {
+			if r.GetName() == req.Offset {
+				i = ii
+				break
+			}
+		}
+		if i == -1 {
+			return nil, fmt.Errorf("offset %q not found", req.Offset)
+		}
+		rels = rels[i:]
+	}
+	count := int64(len(rels))
 	var next string
 	limit := req.GetLimit()
 	// TODO: Handle all other options.
 	if limit != 0 && limit < count {
+		next = rels[limit].GetName()
 		rels = rels[:limit]
 		count = limit
-		next = c.Rels[limit].GetName()
 	}
 
 	resp := &rls.ListReleasesResponse{
 		Count:    count,
+		Total:    total,
 		Releases: rels,
 	}
 	if next != "" {
@@ -82,6 +121,15 @@ func (c *FakeClient) ListReleases(opts ...ReleaseListOption) (*rls.ListReleasesR
 	return resp, nil
 }
 
+// ListReleasesStream passes the current releases to fn in a single response
+func (c *FakeClient) ListReleasesStream(fn func(*rls.ListReleasesResponse) error, opts ...ReleaseListOption) error {
+	resp, err := c.ListReleases(opts...)
+	if err != nil || len(resp.Releases) == 0 {
+		return err
+	}
+	return fn(resp)
+}
+
 // InstallRelease creates a new release and returns a InstallReleaseResponse containing that release
 func (c *FakeClient) InstallRelease(chStr, ns string, opts ...InstallOption) (*rls.InstallReleaseResponse, error) {
 	chart := &chart.Chart{}
@@ -182,7 +230,7 @@ func (c *FakeClient) UpdateReleaseFromChart(rlsName string, newChart *chart.Char
 		opt(&c.Opts)
 	}
 	// Check to see if the release already exists.
-	rel, err := c.ReleaseContent(rlsName, nil)
+	rel, err := c.ReleaseContent(rlsName)
 	if err != nil {
 		return nil, err
 	}
@@ -227,9 +275,10 @@ func (c *FakeClient) ReleaseStatus(rlsName string, opts ...StatusOption) (*rls.G
 	for _, rel := range c.Rels {
 		if rel.Name == rlsName {
 			return &rls.GetReleaseStatusResponse{
-				Name:      rel.Name,
-				Info:      rel.Info,
-				Namespace: rel.Namespace,
+				Name:        rel.Name,
+				Info:        rel.Info,
+				Namespace:   rel.Namespace,
+				Environment: rel.Environment,
 			}, nil
 		}
 	}
@@ -238,8 +287,14 @@ func (c *FakeClient) ReleaseStatus(rlsName string, opts ...StatusOption) (*rls.G
 
 // ReleaseContent returns the configuration for the matching release name in the fake release client.
 func (c *FakeClient) ReleaseContent(rlsName string, opts ...ContentOption) (resp *rls.GetReleaseContentResponse, err error) {
+	reqOpts := c.Opts
+	for _, opt := range opts {
+		opt(&reqOpts)
+	}
+	version := reqOpts.contentReq.Version
+
 	for _, rel := range c.Rels {
-		if rel.Name == rlsName {
+		if rel.Name == rlsName && (version == 0 || rel.Version == version) {
 			return &rls.GetReleaseContentResponse{
 				Release: rel,
 			}, nil
@@ -293,6 +348,132 @@ func (c *FakeClient) RunReleaseTest(rlsName string, opts ...ReleaseTestOption) (
 	return results, errc
 }
 
+// ImportReleaseHistory adds the given revisions to the fake client
+func (c *FakeClient) ImportReleaseHistory(rels []*release.Release, opts ...ImportOption) (*rls.ImportReleaseHistoryResponse, error) {
+	reqOpts := c.Opts
+	for _, opt := range opts {
+		opt(&reqOpts)
+	}
+	if len(rels) == 0 {
+		return nil, errors.New("no release revisions to import")
+	}
+
+	for _, rel := range rels {
+		if reqOpts.importReq.Name != "" {
+			rel.Name = reqOpts.importReq.Name
+		}
+		if reqOpts.importReq.Namespace != "" {
+			rel.Namespace = reqOpts.importReq.Namespace
+		}
+		for _, existing := range c.Rels {
+			if existing.Name == rel.Name {
+				return nil, errors.New("a release named " + rel.Name + " already exists")
+			}
+		}
+	}
+	c.Rels = append(c.Rels, rels...)
+
+	return &rls.ImportReleaseHistoryResponse{Release: rels[len(rels)-1]}, nil
+}
+
+// PruneReleaseHistory removes the revisions of the named release from the fake client
+// that violate the requested limits.
+func (c *FakeClient) PruneReleaseHistory(rlsName string, opts ...PruneOption) (*rls.PruneReleaseHistoryResponse, error) {
+	reqOpts := c.Opts
+	for _, opt := range opts {
+		opt(&reqOpts)
+	}
+
+	store := storage.Init(driver.NewMemory())
+	for _, rel := range c.Rels {
+		if rel.Name == rlsName {
+			if err := store.Create(rel); err != nil {
+				return nil, err
+			}
+		}
+	}
+	pruned, err := store.PruneCandidates(rlsName, storage.PrunePolicy{
+		MaxHistory: int(reqOpts.pruneReq.Max),
+		MaxAge:     time.Duration(reqOpts.pruneReq.MaxAge) * time.Second,
+		MaxBytes:   reqOpts.pruneReq.MaxBytes,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !reqOpts.pruneReq.DryRun {
+		for _, p := range pruned {
+			for i, rel := range c.Rels {
+				if rel == p {
+					c.Rels = append(c.Rels[:i], c.Rels[i+1:]...)
+					break
+				}
+			}
+		}
+	}
+	return &rls.PruneReleaseHistoryResponse{Releases: pruned}, nil
+}
+
+// ReleaseAudit returns the audit records of the named release from the fake
+// client, the most recent first.
+func (c *FakeClient) ReleaseAudit(rlsName string, opts ...AuditOption) (*rls.GetReleaseAuditResponse, error) {
+	reqOpts := c.Opts
+	for _, opt := range opts {
+		opt(&reqOpts)
+	}
+
+	res := &rls.GetReleaseAuditResponse{}
+	for i := len(c.AuditRecords) - 1; i >= 0; i-- {
+		if max := reqOpts.auditReq.Max; max > 0 && len(res.Records) == int(max) {
+			break
+		}
+		if r := c.AuditRecords[i]; r.Release == rlsName {
+			res.Records = append(res.Records, r)
+		}
+	}
+	return res, nil
+}
+
+// ListReleasesWithContext lists the current releases and accepts a context
+func (c *FakeClient) ListReleasesWithContext(ctx context.Context, opts ...ReleaseListOption) (*rls.ListReleasesResponse, error) {
+	return c.ListReleases(opts...)
+}
+
+// DeleteReleaseWithContext deletes a release from the FakeClient and accepts a context
+func (c *FakeClient) DeleteReleaseWithContext(ctx context.Context, rlsName string, opts ...DeleteOption) (*rls.UninstallReleaseResponse, error) {
+	return c.DeleteRelease(rlsName, opts...)
+}
+
+// GetVersionWithContext returns a fake version and accepts a context
+func (c *FakeClient) GetVersionWithContext(ctx context.Context, opts ...VersionOption) (*rls.GetVersionResponse, error) {
+	return c.GetVersion(opts...)
+}
+
+// RollbackReleaseWithContext returns nil, nil and accepts a context
+func (c *FakeClient) RollbackReleaseWithContext(ctx context.Context, rlsName string, opts ...RollbackOption) (*rls.RollbackReleaseResponse, error) {
+	return c.RollbackRelease(rlsName, opts...)
+}
+
+// ReleaseStatusWithContext returns a release status response with info from the matching release name and accepts a context
+func (c *FakeClient) ReleaseStatusWithContext(ctx context.Context, rlsName string, opts ...StatusOption) (*rls.GetReleaseStatusResponse, error) {
+	return c.ReleaseStatus(rlsName, opts...)
+}
+
+// ReleaseContentWithContext returns the configuration for the matching release name in the fake release client and accepts a context
+func (c *FakeClient) ReleaseContentWithContext(ctx context.Context, rlsName string, opts ...ContentOption) (*rls.GetReleaseContentResponse, error) {
+	return c.ReleaseContent(rlsName, opts...)
+}
+
+// ReleaseHistoryWithContext returns a release's revision history and accepts a context
+func (c *FakeClient) ReleaseHistoryWithContext(ctx context.Context, rlsName string, opts ...HistoryOption) (*rls.GetHistoryResponse, error) {
+	return c.ReleaseHistory(rlsName, opts...)
+}
+
+// RunReleaseTestWithContext executes a pre-defined tests on a release and accepts a context
+func (c *FakeClient) RunReleaseTestWithContext(ctx context.Context, rlsName string, opts ...ReleaseTestOption) (<-chan *rls.TestReleaseResponse, <-chan error) {
+	return c.RunReleaseTest(rlsName, opts...)
+}
+
 // PingTiller pings the Tiller pod and ensures that it is up and running
 func (c *FakeClient) PingTiller() error {
 	return nil
@@ -322,6 +503,7 @@ type MockReleaseOptions struct {
 	StatusCode  release.Status_Code
 	Namespace   string
 	Description string
+	Environment string
 }
 
 // ReleaseMock creates a mock release object based on options set by
@@ -381,10 +563,11 @@ func ReleaseMock(opts *MockReleaseOptions) *release.Release {
 			Status:        &release.Status{Code: scode},
 			Description:   description,
 		},
-		Chart:     ch,
-		Config:    config,
-		Version:   version,
-		Namespace: namespace,
+		Chart:       ch,
+		Config:      config,
+		Version:     version,
+		Namespace:   namespace,
+		Environment: opts.Environment,
 		Hooks: []*release.Hook{
 			{
 				Name:     "pre-install-hook",
@@ -409,12 +592,13 @@ func RenderReleaseMock(r *release.Release, asUpgrade bool) error {
 
 	renderOpts := renderutil.Options{
 		ReleaseOptions: chartutil.ReleaseOptions{
-			Name:      r.Name,
-			Namespace: r.Namespace,
-			Time:      r.Info.LastDeployed,
-			Revision:  int(r.Version),
-			IsUpgrade: asUpgrade,
-			IsInstall: !asUpgrade,
+			Name:        r.Name,
+			Namespace:   r.Namespace,
+			Time:        r.Info.LastDeployed,
+			Revision:    int(r.Version),
+			IsUpgrade:   asUpgrade,
+			IsInstall:   !asUpgrade,
+			Environment: r.Environment,
 		},
 	}
 	rendered, err := renderutil.Render(r.Chart, r.Config, renderOpts)
@@ -23,6 +23,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"golang.org/x/net/context"
@@ -317,6 +318,24 @@ func TestReleaseStatus_VerifyOptions(t *testing.T) {
 	assert(t, "", client.opts.statusReq.Name)
 }
 
+// Verify the context of ReleaseStatusWithContext is passed to the call.
+func TestReleaseStatusWithContext(t *testing.T) {
+	type key struct{}
+	ctx := context.WithValue(context.Background(), key{}, "caller")
+
+	b4c := BeforeCall(func(ctx context.Context, msg proto.Message) error {
+		if v := ctx.Value(key{}); v != "caller" {
+			t.Errorf("expected the context of the caller, got the value %v", v)
+		}
+		return errSkip
+	})
+
+	client := NewClient(b4c)
+	if _, err := client.ReleaseStatusWithContext(ctx, "test"); err != errSkip {
+		t.Fatalf("did not expect error but got (%v)\n``", err)
+	}
+}
+
 // Verify each ContentOption is applied to a GetReleaseContentRequest correctly.
 func TestReleaseContent_VerifyOptions(t *testing.T) {
 	// Options testdata
@@ -350,6 +369,110 @@ func TestReleaseContent_VerifyOptions(t *testing.T) {
 	assert(t, "", client.opts.contentReq.Name)
 }
 
+// Verify each ImportOption is applied to an ImportReleaseHistoryRequest correctly.
+func TestImportReleaseHistory_VerifyOptions(t *testing.T) {
+	// Options testdata
+	var namespace = "migrated"
+	var releaseName = "renamed"
+	var rels = []*rls.Release{{Name: "test", Version: 1}}
+
+	// Expected ImportReleaseHistoryRequest message
+	exp := &tpb.ImportReleaseHistoryRequest{
+		Releases:  rels,
+		Namespace: namespace,
+		Name:      releaseName,
+	}
+
+	// BeforeCall option to intercept Helm client ImportReleaseHistoryRequest
+	b4c := BeforeCall(func(_ context.Context, msg proto.Message) error {
+		switch act := msg.(type) {
+		case *tpb.ImportReleaseHistoryRequest:
+			t.Logf("ImportReleaseHistoryRequest: %#+v\n", act)
+			assert(t, exp, act)
+		default:
+			t.Fatalf("expected message of type ImportReleaseHistoryRequest, got %T\n", act)
+		}
+		return errSkip
+	})
+
+	client := NewClient(b4c)
+	if _, err := client.ImportReleaseHistory(rels, ImportNamespace(namespace), ImportReleaseName(releaseName)); err != errSkip {
+		t.Fatalf("did not expect error but got (%v)\n``", err)
+	}
+
+	// ensure options for call are not saved to client
+	assert(t, "", client.opts.importReq.Namespace)
+}
+
+func TestPruneReleaseHistory_VerifyOptions(t *testing.T) {
+	// Options testdata
+	var releaseName = "test"
+	var max int32 = 5
+	var maxAge = 2 * time.Hour
+	var maxBytes int64 = 1024
+
+	// Expected PruneReleaseHistoryRequest message
+	exp := &tpb.PruneReleaseHistoryRequest{
+		Name:     releaseName,
+		Max:      max,
+		MaxAge:   7200,
+		MaxBytes: maxBytes,
+		DryRun:   true,
+	}
+
+	// BeforeCall option to intercept Helm client PruneReleaseHistoryRequest
+	b4c := BeforeCall(func(_ context.Context, msg proto.Message) error {
+		switch act := msg.(type) {
+		case *tpb.PruneReleaseHistoryRequest:
+			t.Logf("PruneReleaseHistoryRequest: %#+v\n", act)
+			assert(t, exp, act)
+		default:
+			t.Fatalf("expected message of type PruneReleaseHistoryRequest, got %T\n", act)
+		}
+		return errSkip
+	})
+
+	client := NewClient(b4c)
+	if _, err := client.PruneReleaseHistory(releaseName, PruneMaxHistory(max), PruneMaxAge(maxAge), PruneMaxBytes(maxBytes), PruneDryRun(true)); err != errSkip {
+		t.Fatalf("did not expect error but got (%v)\n``", err)
+	}
+
+	// ensure options for call are not saved to client
+	assert(t, int32(0), client.opts.pruneReq.Max)
+}
+
+func TestReleaseAudit_VerifyOptions(t *testing.T) {
+	// Options testdata
+	var releaseName = "test"
+	var max int32 = 10
+
+	// Expected GetReleaseAuditRequest message
+	exp := &tpb.GetReleaseAuditRequest{
+		Name: releaseName,
+		Max:  max,
+	}
+
+	// BeforeCall option to intercept Helm client GetReleaseAuditRequest
+	b4c := BeforeCall(func(_ context.Context, msg proto.Message) error {
+		switch act := msg.(type) {
+		case *tpb.GetReleaseAuditRequest:
+			t.Logf("GetReleaseAuditRequest: %#+v\n", act)
+			assert(t, exp, act)
+		default:
+			t.Fatalf("expected message of type GetReleaseAuditRequest, got %T\n", act)
+		}
+		return errSkip
+	})
+
+	client := NewClient(b4c)
+	if _, err := client.ReleaseAudit(releaseName, AuditMax(max)); err != errSkip {
+		t.Fatalf("did not expect error but got (%v)\n``", err)
+	}
+
+	// ensure options for call are not saved to client
+	assert(t, int32(0), client.opts.auditReq.Max)
+}
+
 func assert(t *testing.T, expect, actual interface{}) {
 	if !reflect.DeepEqual(expect, actual) {
 		t.Fatalf("expected %#+v, actual %#+v\n", expect, actual)
@@ -61,6 +61,12 @@ func (h Home) CacheIndex(name string) string {
 	return h.Path("repository", "cache", target)
 }
 
+// TrustPolicy returns the path to the trust-policy.yaml file, which holds the
+// signature policies of the chart repositories.
+func (h Home) TrustPolicy() string {
+	return h.Path("repository", "trust-policy.yaml")
+}
+
 // Starters returns the path to the Helm starter packs.
 func (h Home) Starters() string {
 	return h.Path("starters")
@@ -0,0 +1,127 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helm // import "k8s.io/helm/pkg/helm"
+
+import (
+	"k8s.io/helm/pkg/proto/hapi/release"
+)
+
+// DefaultListPageSize is the default number of releases a ReleaseIterator
+// fetches per call.
+const DefaultListPageSize = 256
+
+// ReleaseIterator iterates over the releases listed by Tiller, fetching them
+// one page at a time so that only a page of releases is held in memory.
+//
+// Pages are chained with continue tokens: the name of the first release of the
+// next page, which is the offset of the ListReleases call fetching that page.
+//
+//	it := helm.NewReleaseIterator(client, 100, helm.ReleaseListSort(int32(rls.ListSort_NAME)))
+//	for it.Next() {
+//		fmt.Println(it.Release().Name)
+//	}
+//	if err := it.Err(); err != nil {
+//		return err
+//	}
+type ReleaseIterator struct {
+	client   Interface
+	opts     []ReleaseListOption
+	pageSize int
+
+	page    []*release.Release
+	cur     *release.Release
+	next    string
+	total   int64
+	fetched bool
+	err     error
+}
+
+// NewReleaseIterator returns an iterator over the releases listed by client
+// with opts, fetching pageSize releases per call. A pageSize of 0 or less uses
+// DefaultListPageSize. The iteration starts at the offset set in opts, if any.
+func NewReleaseIterator(client Interface, pageSize int, opts ...ReleaseListOption) *ReleaseIterator {
+	if pageSize <= 0 {
+		pageSize = DefaultListPageSize
+	}
+	return &ReleaseIterator{client: client, opts: opts, pageSize: pageSize}
+}
+
+// Next advances the iterator to the next release, fetching the next page when
+// the current one is exhausted. It returns false at the end of the listing or
+// on an error, which is then returned by Err.
+func (it *ReleaseIterator) Next() bool {
+	it.cur = nil
+	if it.err != nil {
+		return false
+	}
+	if len(it.page) == 0 && !it.fetchPage() {
+		return false
+	}
+	it.cur, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+// fetchPage fetches the page starting at the continue token. It returns false
+// if there are no more releases.
+func (it *ReleaseIterator) fetchPage() bool {
+	if it.fetched && it.next == "" {
+		return false
+	}
+	opts := append([]ReleaseListOption{}, it.opts...)
+	opts = append(opts, ReleaseListLimit(it.pageSize))
+	if it.fetched {
+		opts = append(opts, ReleaseListOffset(it.next))
+	}
+	res, err := it.client.ListReleases(opts...)
+	if err != nil {
+		it.err = err
+		return false
+	}
+	it.fetched = true
+	if res == nil {
+		it.next = ""
+		return false
+	}
+	it.page, it.next, it.total = res.GetReleases(), res.GetNext(), res.GetTotal()
+	return len(it.page) > 0
+}
+
+// Release returns the current release.
+func (it *ReleaseIterator) Release() *release.Release {
+	return it.cur
+}
+
+// Err returns the error that ended the iteration, if any.
+func (it *ReleaseIterator) Err() error {
+	return it.err
+}
+
+// Continue returns the continue token of the release following the current
+// one, to be passed to ReleaseListOffset to resume the listing later, or an
+// empty string if there are no more releases.
+func (it *ReleaseIterator) Continue() string {
+	if len(it.page) > 0 {
+		return it.page[0].GetName()
+	}
+	return it.next
+}
+
+// Total returns the total number of releases matching the listing, as of the
+// last page fetched.
+func (it *ReleaseIterator) Total() int64 {
+	return it.total
+}
@@ -0,0 +1,89 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helm
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/helm/pkg/proto/hapi/release"
+	rls "k8s.io/helm/pkg/proto/hapi/services"
+)
+
+// countingClient counts the ListReleases calls of a client.
+type countingClient struct {
+	*FakeClient
+	calls int
+}
+
+func (c *countingClient) ListReleases(opts ...ReleaseListOption) (*rls.ListReleasesResponse, error) {
+	c.calls++
+	return c.FakeClient.ListReleases(opts...)
+}
+
+func TestReleaseIterator(t *testing.T) {
+	var rels []*release.Release
+	for _, name := range []string{"atlas", "bonzai", "carabiner", "dune", "eclipse"} {
+		rels = append(rels, ReleaseMock(&MockReleaseOptions{Name: name}))
+	}
+	c := &countingClient{FakeClient: &FakeClient{Rels: rels}}
+
+	var names []string
+	it := NewReleaseIterator(c, 2)
+	for it.Next() {
+		names = append(names, it.Release().Name)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"atlas", "bonzai", "carabiner", "dune", "eclipse"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected %v, got %v", expected, names)
+	}
+	if c.calls != 3 {
+		t.Errorf("expected 3 pages, got %d", c.calls)
+	}
+	if it.Total() != 5 || it.Continue() != "" {
+		t.Errorf("expected a total of 5 and no continue token, got %d and %q", it.Total(), it.Continue())
+	}
+
+	// Stop in the middle of a page and resume from the continue token.
+	it = NewReleaseIterator(c, 2)
+	for i := 0; i < 3 && it.Next(); i++ {
+	}
+	if it.Continue() != "dune" {
+		t.Fatalf("expected the continue token dune, got %q", it.Continue())
+	}
+	it = NewReleaseIterator(c, 2, ReleaseListOffset(it.Continue()))
+	names = nil
+	for it.Next() {
+		names = append(names, it.Release().Name)
+	}
+	if expected := []string{"dune", "eclipse"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected %v, got %v", expected, names)
+	}
+}
+
+func TestReleaseIterator_Error(t *testing.T) {
+	c := &FakeClient{Rels: []*release.Release{ReleaseMock(&MockReleaseOptions{Name: "atlas"})}}
+	it := NewReleaseIterator(c, 2, ReleaseListOffset("missing"))
+	if it.Next() {
+		t.Errorf("expected no release, got %v", it.Release())
+	}
+	if it.Err() == nil {
+		t.Error("expected the error of the missing offset")
+	}
+}
@@ -18,6 +18,7 @@ package helm
 
 import (
 	"crypto/tls"
+	"net"
 	"time"
 
 	"github.com/golang/protobuf/proto"
@@ -27,6 +28,7 @@ import (
 	cpb "k8s.io/helm/pkg/proto/hapi/chart"
 	"k8s.io/helm/pkg/proto/hapi/release"
 	rls "k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/timeconv"
 	"k8s.io/helm/pkg/version"
 )
 
@@ -83,6 +85,22 @@ type options struct {
 	testReq rls.TestReleaseRequest
 	// connectTimeout specifies the time duration Helm will wait to establish a connection to tiller
 	connectTimeout time.Duration
+	// callTimeout limits the duration of every call to tiller, including the connection, if set
+	callTimeout time.Duration
+	// retries is the number of times a call that does not change releases is retried while tiller is unavailable
+	retries int
+	// retryBackoff is the delay before the first retry, doubled before every next retry
+	retryBackoff time.Duration
+	// keepaliveTime is the interval of the keepalive pings sent to tiller
+	keepaliveTime time.Duration
+	// dialer connects to tiller instead of a TCP connection to host, if set
+	dialer func(addr string, timeout time.Duration) (net.Conn, error)
+	// release import options are applied directly to the import release history request
+	importReq rls.ImportReleaseHistoryRequest
+	// release prune options are applied directly to the prune release history request
+	pruneReq rls.PruneReleaseHistoryRequest
+	// release audit options are applied directly to the get release audit request
+	auditReq rls.GetReleaseAuditRequest
 }
 
 // Host specifies the host address of the Tiller release server, (default = ":44134").
@@ -92,6 +110,14 @@ func Host(host string) Option {
 	}
 }
 
+// Dialer specifies the function which connects to the Tiller release server,
+// e.g. in memory, instead of a TCP connection to the host.
+func Dialer(dial func(addr string, timeout time.Duration) (net.Conn, error)) Option {
+	return func(opts *options) {
+		opts.dialer = dial
+	}
+}
+
 // WithTLS specifies the tls configuration if the helm client is enabled to use TLS.
 func WithTLS(cfg *tls.Config) Option {
 	return func(opts *options) {
@@ -166,6 +192,36 @@ func ReleaseListNamespace(namespace string) ReleaseListOption {
 	}
 }
 
+// ReleaseListChartName specifies the name of the chart of the releases to list
+func ReleaseListChartName(name string) ReleaseListOption {
+	return func(opts *options) {
+		opts.listReq.ChartName = name
+	}
+}
+
+// ReleaseListAppVersion specifies the app version of the releases to list
+func ReleaseListAppVersion(appVersion string) ReleaseListOption {
+	return func(opts *options) {
+		opts.listReq.AppVersion = appVersion
+	}
+}
+
+// ReleaseListEnvironment specifies the environment values file of the releases
+// to list
+func ReleaseListEnvironment(environment string) ReleaseListOption {
+	return func(opts *options) {
+		opts.listReq.Environment = environment
+	}
+}
+
+// ReleaseListDeployedAfter specifies the time after which the releases to
+// list were last deployed
+func ReleaseListDeployedAfter(t time.Time) ReleaseListOption {
+	return func(opts *options) {
+		opts.listReq.DeployedAfter = timeconv.Timestamp(t)
+	}
+}
+
 // InstallOption allows specifying various settings
 // configurable by the helm client user for overriding
 // the defaults used when running the `helm install` command.
@@ -192,6 +248,40 @@ func ConnectTimeout(timeout int64) Option {
 	}
 }
 
+// CallTimeout specifies the number of seconds a call to Tiller may take,
+// including the connection, before it fails. Zero means no limit. Release
+// tests are not limited.
+func CallTimeout(timeout int64) Option {
+	return func(opts *options) {
+		opts.callTimeout = time.Duration(timeout) * time.Second
+	}
+}
+
+// Retries specifies the number of times the calls that do not change
+// releases (list, status, content and history) are retried while Tiller is
+// unavailable or the call timed out.
+func Retries(retries int) Option {
+	return func(opts *options) {
+		opts.retries = retries
+	}
+}
+
+// RetryBackoff specifies the delay before the first retry. The delay is
+// doubled before every next retry.
+func RetryBackoff(backoff time.Duration) Option {
+	return func(opts *options) {
+		opts.retryBackoff = backoff
+	}
+}
+
+// KeepaliveTime specifies the number of seconds between the keepalive pings
+// sent to Tiller, to prevent the connection from getting closed by upstreams.
+func KeepaliveTime(seconds int64) Option {
+	return func(opts *options) {
+		opts.keepaliveTime = time.Duration(seconds) * time.Second
+	}
+}
+
 // InstallTimeout specifies the number of seconds before kubernetes calls timeout
 func InstallTimeout(timeout int64) InstallOption {
 	return func(opts *options) {
@@ -290,6 +380,22 @@ func InstallDescription(description string) InstallOption {
 	}
 }
 
+// InstallEnvironment records the environment values file of the chart in the
+// release
+func InstallEnvironment(environment string) InstallOption {
+	return func(opts *options) {
+		opts.instReq.Environment = environment
+	}
+}
+
+// UpgradeEnvironment records the environment values file of the chart in the
+// release
+func UpgradeEnvironment(environment string) UpdateOption {
+	return func(opts *options) {
+		opts.updateReq.Environment = environment
+	}
+}
+
 // UpgradeDescription specifies the description for the update
 func UpgradeDescription(description string) UpdateOption {
 	return func(opts *options) {
@@ -311,6 +417,14 @@ func DeleteDescription(description string) DeleteOption {
 	}
 }
 
+// DeleteCascade specifies the propagation policy of the deletion of the
+// resources: background, foreground or orphan
+func DeleteCascade(cascade string) DeleteOption {
+	return func(opts *options) {
+		opts.uninstallReq.Cascade = cascade
+	}
+}
+
 // UpgradeCleanupOnFail allows deletion of new resources created in this upgrade when upgrade failed
 func UpgradeCleanupOnFail(cleanupOnFail bool) UpdateOption {
 	return func(opts *options) {
@@ -318,6 +432,20 @@ func UpgradeCleanupOnFail(cleanupOnFail bool) UpdateOption {
 	}
 }
 
+// UpgradeForceCrds will (if true) apply the CRDs of the crds/ directory of the chart, which are otherwise only created on install.
+func UpgradeForceCrds(forceCrds bool) UpdateOption {
+	return func(opts *options) {
+		opts.updateReq.ForceCrds = forceCrds
+	}
+}
+
+// UpgradeThreeWayMerge will (if true) patch the resources with a three-way merge of the previous manifest, the new manifest and the live objects, like kubectl apply.
+func UpgradeThreeWayMerge(threeWayMerge bool) UpdateOption {
+	return func(opts *options) {
+		opts.updateReq.ThreeWayMerge = threeWayMerge
+	}
+}
+
 // RollbackCleanupOnFail allows deletion of new resources created in this rollback when rollback failed
 func RollbackCleanupOnFail(cleanupOnFail bool) RollbackOption {
 	return func(opts *options) {
@@ -325,6 +453,22 @@ func RollbackCleanupOnFail(cleanupOnFail bool) RollbackOption {
 	}
 }
 
+// RollbackValuesOnly specifies whether to deploy the chart of the current
+// release with the values of the version instead of the version.
+func RollbackValuesOnly(valuesOnly bool) RollbackOption {
+	return func(opts *options) {
+		opts.rollbackReq.ValuesOnly = valuesOnly
+	}
+}
+
+// RollbackValues specifies the values deployed by a values only rollback
+// instead of the values of the version.
+func RollbackValues(raw []byte) RollbackOption {
+	return func(opts *options) {
+		opts.rollbackReq.Values = &cpb.Config{Raw: string(raw)}
+	}
+}
+
 // DeleteDisableHooks will disable hooks for a deletion operation.
 func DeleteDisableHooks(disable bool) DeleteOption {
 	return func(opts *options) {
@@ -452,6 +596,15 @@ func ReuseValues(reuse bool) UpdateOption {
 	}
 }
 
+// ReuseValuesStrategy sets how Tiller combines the values from the last release
+// with the values of the upgrade when ReuseValues is true: "merge" (the
+// default), "replace" or "deep".
+func ReuseValuesStrategy(strategy string) UpdateOption {
+	return func(opts *options) {
+		opts.updateReq.ReuseValuesStrategy = strategy
+	}
+}
+
 // UpgradeRecreate will (if true) recreate pods after upgrade.
 func UpgradeRecreate(recreate bool) UpdateOption {
 	return func(opts *options) {
@@ -490,6 +643,14 @@ func StatusReleaseVersion(version int32) StatusOption {
 	}
 }
 
+// StatusInventory will instruct Tiller to get each resource of the release
+// from the cluster, and return whether it exists and is ready.
+func StatusInventory(inventory bool) StatusOption {
+	return func(opts *options) {
+		opts.statusReq.Inventory = inventory
+	}
+}
+
 // DeleteOption allows setting optional attributes when
 // performing a UninstallRelease tiller rpc.
 type DeleteOption func(*options)
@@ -519,6 +680,24 @@ func WithMaxHistory(max int32) HistoryOption {
 	}
 }
 
+// ImportOption allows configuring optional request data for
+// issuing an ImportReleaseHistory rpc.
+type ImportOption func(*options)
+
+// ImportNamespace replaces the namespace of every imported revision.
+func ImportNamespace(namespace string) ImportOption {
+	return func(opts *options) {
+		opts.importReq.Namespace = namespace
+	}
+}
+
+// ImportReleaseName stores the imported revisions under a different release name.
+func ImportReleaseName(name string) ImportOption {
+	return func(opts *options) {
+		opts.importReq.Name = name
+	}
+}
+
 // NewContext creates a versioned context.
 func NewContext() context.Context {
 	return FromContext(context.TODO())
@@ -533,3 +712,47 @@ func FromContext(ctx context.Context) context.Context {
 // ReleaseTestOption allows configuring optional request data for
 // issuing a TestRelease rpc.
 type ReleaseTestOption func(*options)
+
+// PruneOption allows configuring optional request data for
+// issuing a PruneReleaseHistory rpc.
+type PruneOption func(*options)
+
+// PruneMaxHistory keeps at most max revisions of the release.
+func PruneMaxHistory(max int32) PruneOption {
+	return func(opts *options) {
+		opts.pruneReq.Max = max
+	}
+}
+
+// PruneMaxAge removes the revisions deployed longer than age ago.
+func PruneMaxAge(age time.Duration) PruneOption {
+	return func(opts *options) {
+		opts.pruneReq.MaxAge = int64(age / time.Second)
+	}
+}
+
+// PruneMaxBytes removes the oldest revisions until the encoded size of the
+// history does not exceed max bytes.
+func PruneMaxBytes(max int64) PruneOption {
+	return func(opts *options) {
+		opts.pruneReq.MaxBytes = max
+	}
+}
+
+// PruneDryRun reports the revisions to remove without removing them.
+func PruneDryRun(dry bool) PruneOption {
+	return func(opts *options) {
+		opts.pruneReq.DryRun = dry
+	}
+}
+
+// AuditOption allows configuring optional request data for
+// issuing a GetReleaseAudit rpc.
+type AuditOption func(*options)
+
+// AuditMax returns at most max audit records, the most recent first.
+func AuditMax(max int32) AuditOption {
+	return func(opts *options) {
+		opts.auditReq.Max = max
+	}
+}
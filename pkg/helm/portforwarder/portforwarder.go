@@ -18,6 +18,7 @@ package portforwarder
 
 import (
 	"fmt"
+	"io"
 
 	"k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -34,14 +35,64 @@ var (
 	tillerPodLabels = labels.Set{"app": "helm", "name": "tiller"}
 )
 
+// The transports of the tunnel to Tiller.
+const (
+	// TransportAuto forwards the port over SPDY, and falls back to WebSockets
+	// if the SPDY connection cannot be established.
+	TransportAuto = "auto"
+	// TransportSPDY forwards the port over SPDY, like kubectl port-forward.
+	TransportSPDY = "spdy"
+	// TransportWebSocket forwards the port over WebSockets, which go through
+	// more HTTP proxies than SPDY.
+	TransportWebSocket = "websocket"
+)
+
 // New creates a new and initialized tunnel.
 func New(namespace string, client kubernetes.Interface, config *rest.Config) (*kube.Tunnel, error) {
+	return NewWithTransport(namespace, client, config, TransportSPDY, nil)
+}
+
+// NewWithTransport creates a new and initialized tunnel forwarding the port
+// of Tiller over the given transport. Errors of the SPDY connection that make
+// TransportAuto fall back to WebSockets, and the reconnections of the
+// WebSocket tunnel, are written to out if it is not nil.
+func NewWithTransport(namespace string, client kubernetes.Interface, config *rest.Config, transport string, out io.Writer) (*kube.Tunnel, error) {
 	podName, err := GetTillerPodName(client.CoreV1(), namespace)
 	if err != nil {
 		return nil, err
 	}
-	t := kube.NewTunnel(client.CoreV1().RESTClient(), config, namespace, podName, environment.DefaultTillerPort)
-	return t, t.ForwardPort()
+	newTunnel := func() *kube.Tunnel {
+		t := kube.NewTunnel(client.CoreV1().RESTClient(), config, namespace, podName, environment.DefaultTillerPort)
+		t.PodNameFunc = func() (string, error) {
+			return GetTillerPodName(client.CoreV1(), namespace)
+		}
+		if out != nil {
+			t.Out = out
+		}
+		return t
+	}
+
+	switch transport {
+	case TransportSPDY:
+		t := newTunnel()
+		return t, t.ForwardPort()
+	case TransportWebSocket:
+		t := newTunnel()
+		return t, t.ForwardPortWebSocket()
+	case TransportAuto, "":
+		t := newTunnel()
+		err := t.ForwardPort()
+		if err == nil {
+			return t, nil
+		}
+		if out != nil {
+			fmt.Fprintf(out, "Could not forward the port of Tiller over SPDY, falling back to WebSockets: %s\n", err)
+		}
+		t = newTunnel()
+		return t, t.ForwardPortWebSocket()
+	default:
+		return nil, fmt.Errorf("unknown tunnel transport %q, expected %s, %s or %s", transport, TransportAuto, TransportSPDY, TransportWebSocket)
+	}
 }
 
 // GetTillerPodName fetches the name of tiller pod running in the given namespace.
@@ -0,0 +1,82 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package httpclient builds the HTTP clients used to download repository
+// indexes, charts and plugins, so that all of them share the same handling of
+// client certificates, certificate authorities, proxies and timeouts.
+package httpclient
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"k8s.io/helm/pkg/tlsutil"
+)
+
+// Options configure an HTTP client.
+type Options struct {
+	// URL is the URL the client connects to. Its host is the name expected in
+	// the certificate of the server.
+	URL string
+	// CertFile and KeyFile are the certificate and key the client
+	// authenticates with for mutual TLS.
+	CertFile string
+	KeyFile  string
+	// CAFile is the bundle of certificate authorities the certificate of the
+	// server is verified with, instead of the ones of the system.
+	CAFile string
+	// Proxy is the URL of the proxy. The proxy is read from the HTTP_PROXY,
+	// HTTPS_PROXY and NO_PROXY environment variables when it is empty.
+	Proxy string
+	// Timeout is the time limit of a request, including reading the body.
+	// There is no limit when it is zero.
+	Timeout time.Duration
+}
+
+// New returns an HTTP client configured with the options.
+func New(opts Options) (*http.Client, error) {
+	tr, err := NewTransport(opts)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Client{Transport: tr, Timeout: opts.Timeout}, nil
+}
+
+// NewTransport returns an HTTP transport configured with the TLS and proxy
+// options.
+func NewTransport(opts Options) (*http.Transport, error) {
+	tr := &http.Transport{
+		DisableCompression: true,
+		Proxy:              http.ProxyFromEnvironment,
+	}
+	if opts.Proxy != "" {
+		u, err := url.Parse(opts.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL %s: %s", opts.Proxy, err)
+		}
+		tr.Proxy = http.ProxyURL(u)
+	}
+	if (opts.CertFile != "" && opts.KeyFile != "") || opts.CAFile != "" {
+		tlsConf, err := tlsutil.NewTLSConfig(opts.URL, opts.CertFile, opts.KeyFile, opts.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("can't create TLS config: %s", err)
+		}
+		tr.TLSClientConfig = tlsConf
+	}
+	return tr, nil
+}
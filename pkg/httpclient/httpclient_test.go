@@ -0,0 +1,77 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httpclient
+
+import (
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestNew(t *testing.T) {
+	cd := "../../testdata"
+	join := filepath.Join
+	ca, pub, priv := join(cd, "ca.pem"), join(cd, "crt.pem"), join(cd, "key.pem")
+
+	c, err := New(Options{
+		URL:      "https://example.com/charts",
+		CertFile: pub,
+		KeyFile:  priv,
+		CAFile:   ca,
+		Proxy:    "http://proxy.example.com:3128",
+		Timeout:  10 * time.Second,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Timeout != 10*time.Second {
+		t.Errorf("Expected a timeout of 10s, got %s", c.Timeout)
+	}
+
+	tr := c.Transport.(*http.Transport)
+	if tr.TLSClientConfig == nil {
+		t.Fatal("Expected a TLS configuration")
+	}
+	if tr.TLSClientConfig.ServerName != "example.com" {
+		t.Errorf("Expected the server name example.com, got %s", tr.TLSClientConfig.ServerName)
+	}
+	if len(tr.TLSClientConfig.Certificates) != 1 {
+		t.Errorf("Expected a client certificate, got %d", len(tr.TLSClientConfig.Certificates))
+	}
+	if tr.TLSClientConfig.RootCAs == nil {
+		t.Error("Expected the certificate authorities of the CA file")
+	}
+
+	req, _ := http.NewRequest("GET", "https://example.com/charts/index.yaml", nil)
+	proxy, err := tr.Proxy(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if proxy == nil || proxy.String() != "http://proxy.example.com:3128" {
+		t.Errorf("Expected the configured proxy, got %v", proxy)
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	if _, err := New(Options{Proxy: "://proxy"}); err == nil {
+		t.Error("Expected an error for an invalid proxy URL")
+	}
+	if _, err := New(Options{URL: "https://example.com", CAFile: "testdata/nosuchfile.pem"}); err == nil {
+		t.Error("Expected an error for a missing CA file")
+	}
+}
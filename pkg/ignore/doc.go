@@ -30,7 +30,15 @@ The formatting rules are as follows:
 	- Inline comments are NOT supported ('foo* # Any foo' does not contain a comment)
 	- There is no support for multi-line patterns
 	- Shell glob patterns are supported. See Go's "path/filepath".Match
-	- If a pattern begins with a leading !, the match will be negated.
+	- If a pattern begins with a leading !, the match will be negated: the
+	  path is re-included
+	- A leading \! or \# matches a literal ! or #
+	- The last pattern matching a path decides whether it is ignored
+	- The contents of an ignored directory are ignored, and can't be
+	  re-included
+	- The patterns of an ignore file of a subdirectory, added with AddNested,
+	  match the paths relative to it, and take precedence over the patterns of
+	  its parent directories
 	- If a pattern begins with a leading /, only paths relatively rooted will match.
 	- If the pattern ends with a trailing /, only directories will match
 	- If a pattern contains no slashes, file basenames are tested (not paths)
@@ -57,11 +65,14 @@ Example:
 	# Match any file named ab.txt, ac.txt, or ad.txt
 	a[b-d].txt
 
+	# Match the files of the docs directory, except README.md
+	docs/*
+	!docs/README.md
+
 Notable differences from .gitignore:
 	- The '**' syntax is not supported.
 	- The globbing library is Go's 'filepath.Match', not fnmatch(3)
 	- Trailing spaces are always ignored (there is no supported escape sequence)
 	- The evaluation of escape sequences has not been tested for compatibility
-	- There is no support for '\!' as a special leading sequence.
 */
 package ignore // import "k8s.io/helm/pkg/ignore"
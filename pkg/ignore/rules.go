@@ -23,6 +23,7 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 )
 
@@ -35,6 +36,9 @@ const HelmIgnore = ".helmignore"
 // Empty() will create an immutable empty ruleset.
 type Rules struct {
 	patterns []*pattern
+	// nested are the rules of the ignore files of the subdirectories, by the
+	// slash-separated path of their directory.
+	nested map[string]*Rules
 }
 
 // Empty builds an empty ruleset.
@@ -77,50 +81,90 @@ func (r *Rules) Len() int {
 	return len(r.patterns)
 }
 
+// AddNested adds the rules of the ignore file of the subdirectory dir. Their
+// patterns match the paths relative to dir, and take precedence over the
+// patterns of the ignore files of the parent directories.
+func (r *Rules) AddNested(dir string, nested *Rules) {
+	if r.nested == nil {
+		r.nested = map[string]*Rules{}
+	}
+	r.nested[strings.Trim(filepath.ToSlash(dir), "/")] = nested
+}
+
 // Ignore evaluates the file at the given path, and returns true if it should be ignored.
 //
-// Ignore evaluates path against the rules in order. Evaluation stops when a match
-// is found. Matching a negative rule will stop evaluation.
+// As with .gitignore, the last pattern matching the path decides whether it
+// is ignored: a negated pattern re-includes a path an earlier pattern
+// ignores. A path inside an ignored directory is ignored, and can't be
+// re-included.
 func (r *Rules) Ignore(path string, fi os.FileInfo) bool {
+	return r.IgnoredBy(path, fi) != ""
+}
+
+// IgnoredBy returns the pattern which ignores the file at the given path, as
+// it is written in its ignore file, or "" if the file is not ignored. The
+// pattern of a file inside an ignored directory is the one ignoring the
+// directory.
+func (r *Rules) IgnoredBy(path string, fi os.FileInfo) string {
 	// Don't match on empty dirs.
 	if path == "" {
-		return false
+		return ""
 	}
 
 	// Disallow ignoring the current working directory.
 	// See issue:
 	// 1776 (New York City) Hamilton: "Pardon me, are you Aaron Burr, sir?"
 	if path == "." || path == "./" {
-		return false
+		return ""
 	}
-	for _, p := range r.patterns {
-		if p.match == nil {
-			log.Printf("ignore: no matcher supplied for %q", p.raw)
-			return false
+	path = strings.TrimSuffix(filepath.ToSlash(path), "/")
+	parts := strings.Split(path, "/")
+	for i := 1; i < len(parts); i++ {
+		if p := r.ignored(strings.Join(parts[:i], "/"), true); p != nil {
+			return p.raw
 		}
+	}
+	if p := r.ignored(path, fi.IsDir()); p != nil {
+		return p.raw
+	}
+	return ""
+}
 
-		// For negative rules, we need to capture and return non-matches,
-		// and continue for matches.
-		if p.negate {
-			if p.mustDir && !fi.IsDir() {
-				return true
-			}
-			if !p.match(path, fi) {
-				return true
-			}
-			continue
+// ignored returns the last pattern matching the path, which is a directory if
+// isDir, if it ignores the path, the patterns of the ignore files of its
+// parent directories being evaluated after the ones of their own parents.
+func (r *Rules) ignored(path string, isDir bool) *pattern {
+	last := matchPatterns(r.patterns, path, isDir, nil)
+	var dirs []string
+	for dir := range r.nested {
+		if strings.HasPrefix(path, dir+"/") {
+			dirs = append(dirs, dir)
 		}
+	}
+	sort.Slice(dirs, func(i, j int) bool { return len(dirs[i]) < len(dirs[j]) })
+	for _, dir := range dirs {
+		last = matchPatterns(r.nested[dir].patterns, strings.TrimPrefix(path, dir+"/"), isDir, last)
+	}
+	if last == nil || last.negate {
+		return nil
+	}
+	return last
+}
 
+// matchPatterns returns the last of the patterns matching the path, which is
+// a directory if isDir, or last if none does.
+func matchPatterns(patterns []*pattern, path string, isDir bool, last *pattern) *pattern {
+	for _, p := range patterns {
 		// If the rule is looking for directories, and this is not a directory,
 		// skip it.
-		if p.mustDir && !fi.IsDir() {
+		if p.mustDir && !isDir {
 			continue
 		}
-		if p.match(path, fi) {
-			return true
+		if p.match(path) {
+			last = p
 		}
 	}
-	return false
+	return last
 }
 
 // parseRule parses a rule string and creates a pattern, which is then stored in the Rules object.
@@ -141,19 +185,21 @@ func (r *Rules) parseRule(rule string) error {
 		return errors.New("double-star (**) syntax is not supported")
 	}
 
-	// Fail any patterns that can't compile. A non-empty string must be
-	// given to Match() to avoid optimization that skips rule evaluation.
-	if _, err := filepath.Match(rule, "abc"); err != nil {
-		return err
-	}
-
 	p := &pattern{raw: rule}
 
 	// Negation is handled at a higher level, so strip the leading ! from the
-	// string.
+	// string. A leading \! or \# is a literal ! or #.
 	if strings.HasPrefix(rule, "!") {
 		p.negate = true
 		rule = rule[1:]
+	} else if strings.HasPrefix(rule, `\!`) || strings.HasPrefix(rule, `\#`) {
+		rule = rule[1:]
+	}
+
+	// Fail any patterns that can't compile. A non-empty string must be
+	// given to Match() to avoid optimization that skips rule evaluation.
+	if _, err := filepath.Match(rule, "abc"); err != nil {
+		return err
 	}
 
 	// Directory verification is handled by a higher level, so the trailing /
@@ -166,8 +212,8 @@ func (r *Rules) parseRule(rule string) error {
 
 	if strings.HasPrefix(rule, "/") {
 		// Require path matches the root path.
-		p.match = func(n string, fi os.FileInfo) bool {
-			rule = strings.TrimPrefix(rule, "/")
+		rule = strings.TrimPrefix(rule, "/")
+		p.match = func(n string) bool {
 			ok, err := filepath.Match(rule, n)
 			if err != nil {
 				log.Printf("Failed to compile %q: %s", rule, err)
@@ -177,7 +223,7 @@ func (r *Rules) parseRule(rule string) error {
 		}
 	} else if strings.Contains(rule, "/") {
 		// require structural match.
-		p.match = func(n string, fi os.FileInfo) bool {
+		p.match = func(n string) bool {
 			ok, err := filepath.Match(rule, n)
 			if err != nil {
 				log.Printf("Failed to compile %q: %s", rule, err)
@@ -186,7 +232,7 @@ func (r *Rules) parseRule(rule string) error {
 			return ok
 		}
 	} else {
-		p.match = func(n string, fi os.FileInfo) bool {
+		p.match = func(n string) bool {
 			// When there is no slash in the pattern, we evaluate ONLY the
 			// filename.
 			n = filepath.Base(n)
@@ -206,7 +252,7 @@ func (r *Rules) parseRule(rule string) error {
 // matcher is a function capable of computing a match.
 //
 // It returns true if the rule matches.
-type matcher func(name string, fi os.FileInfo) bool
+type matcher func(name string) bool
 
 // pattern describes a pattern to be matched in a rule set.
 type pattern struct {
@@ -112,11 +112,14 @@ func TestIgnore(t *testing.T) {
 		{`cargo/`, "mast/", false},
 		{`helm.txt/`, "helm.txt", false},
 
+		{`cargo/`, "cargo/a.txt", true},
+		{`cargo/*`, "cargo", false},
+
 		// Negation tests
 		{`!helm.txt`, "helm.txt", false},
-		{`!helm.txt`, "tiller.txt", true},
-		{`!*.txt`, "cargo", true},
-		{`!cargo/`, "mast/", true},
+		{`!helm.txt`, "tiller.txt", false},
+		{`!*.txt`, "cargo", false},
+		{`!cargo/`, "mast/", false},
 
 		// Absolute path tests
 		{`/a.txt`, "a.txt", true},
@@ -140,6 +143,105 @@ func TestIgnore(t *testing.T) {
 	}
 }
 
+func TestIgnoreLastMatch(t *testing.T) {
+	rules := `*.txt
+!a.txt
+cargo/*
+!cargo/b.txt
+mast/
+!mast/a.txt
+\!important.txt
+`
+	r, err := parseString(rules)
+	if err != nil {
+		t.Fatalf("Failed to parse: %s", err)
+	}
+	tests := []struct {
+		name   string
+		expect bool
+	}{
+		{"helm.txt", true},
+		{"a.txt", false},
+		{"cargo", false},
+		{"cargo/a.txt", true},
+		{"cargo/b.txt", false},
+		// A file of an ignored directory can't be re-included.
+		{"mast", true},
+		{"mast/a.txt", true},
+	}
+	for _, test := range tests {
+		fi, err := os.Stat(filepath.Join(testdata, test.name))
+		if err != nil {
+			t.Fatalf("Fixture missing: %s", err)
+		}
+		if r.Ignore(test.name, fi) != test.expect {
+			t.Errorf("Expected %q to be %v", test.name, test.expect)
+		}
+	}
+	if p := r.patterns[len(r.patterns)-1]; p.negate || !p.match("!important.txt") {
+		t.Errorf("Expected %q to match a literal !, got %+v", p.raw, p)
+	}
+}
+
+func TestIgnoreNested(t *testing.T) {
+	r, err := parseString("*.txt\n")
+	if err != nil {
+		t.Fatalf("Failed to parse: %s", err)
+	}
+	nested, err := parseString("!a.txt\n/c.txt\n")
+	if err != nil {
+		t.Fatalf("Failed to parse: %s", err)
+	}
+	r.AddNested("cargo", nested)
+	r.AddNested("mast/", Empty())
+
+	tests := []struct {
+		name   string
+		expect bool
+	}{
+		{"a.txt", true},
+		{"cargo/a.txt", false},
+		{"cargo/b.txt", true},
+		{"cargo/c.txt", true},
+		{"mast/a.txt", true},
+	}
+	for _, test := range tests {
+		fi, err := os.Stat(filepath.Join(testdata, test.name))
+		if err != nil {
+			t.Fatalf("Fixture missing: %s", err)
+		}
+		if r.Ignore(test.name, fi) != test.expect {
+			t.Errorf("Expected %q to be %v", test.name, test.expect)
+		}
+	}
+}
+
+func TestIgnoredBy(t *testing.T) {
+	r, err := parseString("cargo/\n*.txt\n!a.txt\n")
+	if err != nil {
+		t.Fatalf("Failed to parse: %s", err)
+	}
+
+	tests := []struct {
+		name   string
+		expect string
+	}{
+		{"a.txt", ""},
+		{"mast/b.txt", "*.txt"},
+		{"cargo", "cargo/"},
+		{"cargo/a.txt", "cargo/"},
+	}
+	for _, test := range tests {
+		fi, err := os.Stat(filepath.Join(testdata, test.name))
+		if err != nil {
+			t.Fatalf("Fixture missing: %s", err)
+		}
+		if by := r.IgnoredBy(test.name, fi); by != test.expect {
+			t.Errorf("Expected %q to be ignored by %q, got %q", test.name, test.expect, by)
+		}
+	}
+}
+
 func TestAddDefaults(t *testing.T) {
 	r := Rules{}
 	r.AddDefaults()
@@ -47,6 +47,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/jsonmergepatch"
 	"k8s.io/apimachinery/pkg/util/strategicpatch"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/apimachinery/pkg/watch"
@@ -346,6 +347,12 @@ type UpdateOptions struct {
 	ShouldWait bool
 	// Allow deletion of new resources created in this update when update failed
 	CleanupOnFail bool
+	// ThreeWayMerge patches the resources with a three-way merge of the
+	// original configuration, the target configuration and the live object,
+	// like kubectl apply: the fields of the configuration changed out of band
+	// are restored, even if the configuration does not change them, and the
+	// fields added out of band are kept.
+	ThreeWayMerge bool
 }
 
 // UpdateWithOptions reads the current configuration and a target configuration from io.reader
@@ -377,7 +384,8 @@ func (c *Client) UpdateWithOptions(namespace string, originalReader, targetReade
 		}
 
 		helper := resource.NewHelper(info.Client, info.Mapping)
-		if _, err := helper.Get(info.Namespace, info.Name, info.Export); err != nil {
+		live, err := helper.Get(info.Namespace, info.Name, info.Export)
+		if err != nil {
 			if !errors.IsNotFound(err) {
 				return fmt.Errorf("Could not get information about the resource: %s", err)
 			}
@@ -408,7 +416,10 @@ func (c *Client) UpdateWithOptions(namespace string, originalReader, targetReade
 			)
 		}
 
-		if err := updateResource(c, info, originalInfo.Object, opts.Force, opts.Recreate); err != nil {
+		if !opts.ThreeWayMerge {
+			live = nil
+		}
+		if err := updateResource(c, info, originalInfo.Object, live, opts.Force, opts.Recreate); err != nil {
 			c.Log("error updating the resource %q:\n\t %v", info.Name, err)
 			updateErrors = append(updateErrors, err.Error())
 		}
@@ -489,22 +500,63 @@ func (c *Client) Delete(namespace string, reader io.Reader) error {
 //
 // Namespace will set the namespace.
 func (c *Client) DeleteWithTimeout(namespace string, reader io.Reader, timeout int64, shouldWait bool) error {
+	return c.DeleteWithOptions(namespace, reader, DeleteOptions{
+		Timeout:    timeout,
+		ShouldWait: shouldWait,
+	})
+}
+
+// DeleteOptions provides options to control delete behavior
+type DeleteOptions struct {
+	Timeout    int64
+	ShouldWait bool
+	// Cascade is the propagation policy of the deletion to the dependents of
+	// the resources: "background", "foreground" or "orphan". The default is
+	// "background".
+	Cascade string
+}
+
+// PropagationPolicy returns the deletion propagation policy of a cascade
+// option. An empty cascade is the background policy.
+func PropagationPolicy(cascade string) (metav1.DeletionPropagation, error) {
+	switch strings.ToLower(cascade) {
+	case "", "background":
+		return metav1.DeletePropagationBackground, nil
+	case "foreground":
+		return metav1.DeletePropagationForeground, nil
+	case "orphan":
+		return metav1.DeletePropagationOrphan, nil
+	}
+	return "", fmt.Errorf("invalid cascade %q, must be one of background, foreground or orphan", cascade)
+}
+
+// DeleteWithOptions deletes Kubernetes resources from an io.reader, with
+// the propagation policy of the cascade option. If ShouldWait is true, the
+// function will wait for all resources to be deleted from etcd before
+// returning, or when the timeout has expired.
+//
+// Namespace will set the namespace.
+func (c *Client) DeleteWithOptions(namespace string, reader io.Reader, opts DeleteOptions) error {
+	policy, err := PropagationPolicy(opts.Cascade)
+	if err != nil {
+		return err
+	}
 	infos, err := c.BuildUnstructured(namespace, reader)
 	if err != nil {
 		return err
 	}
 	err = perform(infos, func(info *resource.Info) error {
 		c.Log("Starting delete for %q %s", info.Name, info.Mapping.GroupVersionKind.Kind)
-		err := deleteResource(info)
+		err := deleteResourceWithPolicy(info, policy)
 		return c.skipIfNotFound(err)
 	})
 	if err != nil {
 		return err
 	}
 
-	if shouldWait {
-		c.Log("Waiting for %d seconds for delete to be completed", timeout)
-		return waitUntilAllResourceDeleted(infos, time.Duration(timeout)*time.Second)
+	if opts.ShouldWait {
+		c.Log("Waiting for %d seconds for delete to be completed", opts.Timeout)
+		return waitUntilAllResourceDeleted(infos, time.Duration(opts.Timeout)*time.Second)
 	}
 
 	return nil
@@ -659,7 +711,10 @@ func createResource(info *resource.Info) error {
 }
 
 func deleteResource(info *resource.Info) error {
-	policy := metav1.DeletePropagationBackground
+	return deleteResourceWithPolicy(info, metav1.DeletePropagationBackground)
+}
+
+func deleteResourceWithPolicy(info *resource.Info, policy metav1.DeletionPropagation) error {
 	opts := &metav1.DeleteOptions{PropagationPolicy: &policy}
 	_, err := resource.NewHelper(info.Client, info.Mapping).DeleteWithOptions(info.Namespace, info.Name, opts)
 	return err
@@ -714,8 +769,75 @@ func createPatch(target *resource.Info, current runtime.Object) ([]byte, types.P
 	}
 }
 
-func updateResource(c *Client, target *resource.Info, currentObj runtime.Object, force bool, recreate bool) error {
-	patch, patchType, err := createPatch(target, currentObj)
+// createThreeWayPatch creates a patch from the live object to the target
+// configuration, as kubectl apply does: the fields of the target
+// configuration are set, the fields of the original configuration removed
+// from the target configuration are deleted, and the other fields of the live
+// object are kept.
+func createThreeWayPatch(target *resource.Info, original, live runtime.Object) ([]byte, types.PatchType, error) {
+	originalData, err := json.Marshal(original)
+	if err != nil {
+		return nil, types.StrategicMergePatchType, fmt.Errorf("serializing original configuration: %s", err)
+	}
+	newData, err := json.Marshal(target.Object)
+	if err != nil {
+		return nil, types.StrategicMergePatchType, fmt.Errorf("serializing target configuration: %s", err)
+	}
+	liveData, err := json.Marshal(live)
+	if err != nil {
+		return nil, types.StrategicMergePatchType, fmt.Errorf("serializing live object: %s", err)
+	}
+
+	// Get a versioned object
+	versionedObject, err := asVersioned(target)
+
+	// As for the two-way patches, the unstructured objects and the CRDs do not
+	// support strategic merge patches.
+	_, isUnstructured := versionedObject.(runtime.Unstructured)
+	_, isCRD := versionedObject.(*apiextv1beta1.CustomResourceDefinition)
+
+	var patch []byte
+	patchType := types.StrategicMergePatchType
+	switch {
+	case runtime.IsNotRegisteredError(err), isUnstructured, isCRD:
+		patchType = types.MergePatchType
+		patch, err = jsonmergepatch.CreateThreeWayJSONMergePatch(originalData, newData, liveData)
+		if err != nil {
+			return nil, patchType, fmt.Errorf("failed to create three-way merge patch: %v", err)
+		}
+	case err != nil:
+		return nil, patchType, fmt.Errorf("failed to get versionedObject: %s", err)
+	default:
+		lookupPatchMeta, err := strategicpatch.NewPatchMetaFromStruct(versionedObject)
+		if err != nil {
+			return nil, patchType, fmt.Errorf("failed to get the patch metadata: %v", err)
+		}
+		patch, err = strategicpatch.CreateThreeWayMergePatch(originalData, newData, liveData, lookupPatchMeta, true)
+		if err != nil {
+			return nil, patchType, fmt.Errorf("failed to create three-way merge patch: %v", err)
+		}
+	}
+
+	// As for the two-way patches, no patch is returned when there are no
+	// changes.
+	if string(patch) == "{}" {
+		return nil, patchType, nil
+	}
+	return patch, patchType, nil
+}
+
+// updateResource patches the resource of target from its current
+// configuration. If live is not nil, the patch is a three-way merge patch
+// applied to the live object.
+func updateResource(c *Client, target *resource.Info, currentObj, live runtime.Object, force bool, recreate bool) error {
+	var patch []byte
+	var patchType types.PatchType
+	var err error
+	if live != nil {
+		patch, patchType, err = createThreeWayPatch(target, currentObj, live)
+	} else {
+		patch, patchType, err = createPatch(target, currentObj)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to create patch: %s", err)
 	}
@@ -246,6 +246,63 @@ func TestUpdate(t *testing.T) {
 	}
 }
 
+func TestUpdateThreeWayMerge(t *testing.T) {
+	original := newPodList("starfish")
+	target := newPodList("starfish")
+	// The image is changed and a label is added out of band.
+	live := newPodList("starfish")
+	live.Items[0].Spec.Containers[0].Image = "abc/app:hotfix"
+	live.Items[0].Labels = map[string]string{"team": "ops"}
+
+	for _, tt := range []struct {
+		name          string
+		threeWayMerge bool
+		expected      string
+	}{
+		{"two-way", false, ""},
+		{"three-way", true, `{"spec":{"$setElementOrder/containers":[{"name":"app:v4"}],"containers":[{"image":"abc/app:v4","name":"app:v4"}]}}`},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var patch string
+			tf := cmdtesting.NewTestFactory()
+			defer tf.Cleanup()
+			tf.UnstructuredClient = &fake.RESTClient{
+				NegotiatedSerializer: unstructuredSerializer,
+				Client: fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+					p, m := req.URL.Path, req.Method
+					switch {
+					case p == "/namespaces/default/pods/starfish" && m == "GET":
+						return newResponse(200, &live.Items[0])
+					case p == "/namespaces/default/pods/starfish" && m == "PATCH":
+						data, err := ioutil.ReadAll(req.Body)
+						if err != nil {
+							t.Fatalf("could not dump request: %s", err)
+						}
+						req.Body.Close()
+						patch = string(data)
+						return newResponse(200, &target.Items[0])
+					default:
+						t.Fatalf("unexpected request: %s %s", req.Method, req.URL.Path)
+						return nil, nil
+					}
+				}),
+			}
+
+			c := &Client{
+				Factory: tf,
+				Log:     nopLogger,
+			}
+			opts := UpdateOptions{ThreeWayMerge: tt.threeWayMerge}
+			if err := c.UpdateWithOptions(v1.NamespaceDefault, objBody(&original), objBody(&target), opts); err != nil {
+				t.Fatal(err)
+			}
+			if patch != tt.expected {
+				t.Errorf("expected patch\n%s\ngot\n%s", tt.expected, patch)
+			}
+		})
+	}
+}
+
 func TestUpdateNonManagedResourceError(t *testing.T) {
 	actual := newPodList("starfish")
 	current := newPodList()
@@ -331,6 +388,35 @@ func TestDeleteWithTimeout(t *testing.T) {
 	}
 }
 
+func TestPropagationPolicy(t *testing.T) {
+	tests := []struct {
+		cascade string
+		expect  metav1.DeletionPropagation
+		err     bool
+	}{
+		{cascade: "", expect: metav1.DeletePropagationBackground},
+		{cascade: "background", expect: metav1.DeletePropagationBackground},
+		{cascade: "Foreground", expect: metav1.DeletePropagationForeground},
+		{cascade: "orphan", expect: metav1.DeletePropagationOrphan},
+		{cascade: "sideways", err: true},
+	}
+	for _, tt := range tests {
+		policy, err := PropagationPolicy(tt.cascade)
+		if err != nil {
+			if !tt.err {
+				t.Errorf("%q: unexpected error: %s", tt.cascade, err)
+			}
+			continue
+		}
+		if tt.err {
+			t.Errorf("%q: expected an error", tt.cascade)
+		}
+		if policy != tt.expect {
+			t.Errorf("%q: expected %q, got %q", tt.cascade, tt.expect, policy)
+		}
+	}
+}
+
 func TestBuild(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -0,0 +1,77 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube // import "k8s.io/helm/pkg/kube"
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+// Lookup returns the resource of the given apiVersion and kind named name in
+// namespace. If name is empty, it returns the list of those resources. The
+// namespace is ignored for cluster scoped resources.
+//
+// A resource that does not exist is returned as an empty map. Lookup only
+// reads from the cluster.
+func (c *Client) Lookup(apiVersion, kind, namespace, name string) (map[string]interface{}, error) {
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return nil, err
+	}
+	mapper, err := c.ToRESTMapper()
+	if err != nil {
+		return nil, err
+	}
+	mapping, err := mapper.RESTMapping(schema.GroupKind{Group: gv.Group, Kind: kind}, gv.Version)
+	if err != nil {
+		return nil, fmt.Errorf("unable to look up %s %s: %s", apiVersion, kind, err)
+	}
+	client, err := c.DynamicClient()
+	if err != nil {
+		return nil, err
+	}
+
+	var resources dynamic.ResourceInterface = client.Resource(mapping.Resource)
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace && namespace != "" {
+		resources = client.Resource(mapping.Resource).Namespace(namespace)
+	}
+
+	if name == "" {
+		list, err := resources.List(metav1.ListOptions{})
+		if err != nil {
+			return lookupResult(err)
+		}
+		return list.UnstructuredContent(), nil
+	}
+	obj, err := resources.Get(name, metav1.GetOptions{})
+	if err != nil {
+		return lookupResult(err)
+	}
+	return obj.UnstructuredContent(), nil
+}
+
+func lookupResult(err error) (map[string]interface{}, error) {
+	if errors.IsNotFound(err) {
+		return map[string]interface{}{}, nil
+	}
+	return nil, err
+}
@@ -0,0 +1,236 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube // import "k8s.io/helm/pkg/kube"
+
+import (
+	"fmt"
+	"io"
+
+	appsv1 "k8s.io/api/apps/v1"
+	appsv1beta1 "k8s.io/api/apps/v1beta1"
+	appsv1beta2 "k8s.io/api/apps/v1beta2"
+	v1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/resource"
+	deploymentutil "k8s.io/kubernetes/pkg/controller/deployment/util"
+)
+
+// ResourceStatus is the status of a resource in the cluster.
+type ResourceStatus struct {
+	APIVersion string
+	Kind       string
+	Name       string
+	Namespace  string
+	// Exists is false if the resource is not found in the cluster.
+	Exists bool
+	// Ready is whether the resource is ready, as --wait waits for it. The
+	// kinds --wait does not wait for are ready as soon as they exist.
+	Ready bool
+	// Reason tells why the resource is not ready.
+	Reason string
+}
+
+// Statuses gets the resources of the manifests of reader from the cluster,
+// and returns their status in the order of the manifests.
+//
+// Namespace will set the namespace.
+func (c *Client) Statuses(namespace string, reader io.Reader) ([]ResourceStatus, error) {
+	infos, err := c.BuildUnstructured(namespace, reader)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]ResourceStatus, 0, len(infos))
+	for _, info := range infos {
+		gvk := info.Mapping.GroupVersionKind
+		status := ResourceStatus{
+			APIVersion: gvk.GroupVersion().String(),
+			Kind:       gvk.Kind,
+			Name:       info.Name,
+			Namespace:  info.Namespace,
+		}
+		c.Log("Doing get for %s: %q", gvk.Kind, info.Name)
+		if err := info.Get(); err != nil {
+			if !errors.IsNotFound(err) {
+				return nil, err
+			}
+			status.Reason = "not found"
+			statuses = append(statuses, status)
+			continue
+		}
+		status.Exists = true
+		if status.Reason, err = c.notReadyReason(info); err != nil {
+			return nil, err
+		}
+		status.Ready = status.Reason == ""
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// notReadyReason returns why the resource of info is not ready, or an empty
+// string if it is ready.
+func (c *Client) notReadyReason(info *resource.Info) (string, error) {
+	var selector map[string]string
+	switch value := asVersionedOrUnstructured(info).(type) {
+	case *v1.Pod:
+		if !isPodReady(value) {
+			return podNotReadyReason(value), nil
+		}
+		return "", nil
+	case *appsv1.Deployment, *appsv1beta1.Deployment, *appsv1beta2.Deployment, *extensions.Deployment:
+		return c.deploymentNotReadyReason(info.Namespace, info.Name)
+	case *v1.ReplicationController:
+		selector = value.Spec.Selector
+	case *extensions.DaemonSet:
+		selector = value.Spec.Selector.MatchLabels
+	case *appsv1.DaemonSet:
+		selector = value.Spec.Selector.MatchLabels
+	case *appsv1beta2.DaemonSet:
+		selector = value.Spec.Selector.MatchLabels
+	case *appsv1.StatefulSet:
+		selector = value.Spec.Selector.MatchLabels
+	case *appsv1beta1.StatefulSet:
+		selector = value.Spec.Selector.MatchLabels
+	case *appsv1beta2.StatefulSet:
+		selector = value.Spec.Selector.MatchLabels
+	case *extensions.ReplicaSet:
+		selector = value.Spec.Selector.MatchLabels
+	case *appsv1beta2.ReplicaSet:
+		selector = value.Spec.Selector.MatchLabels
+	case *appsv1.ReplicaSet:
+		selector = value.Spec.Selector.MatchLabels
+	case *v1.PersistentVolumeClaim:
+		if value.Status.Phase != v1.ClaimBound {
+			return fmt.Sprintf("the claim is %s", value.Status.Phase), nil
+		}
+		return "", nil
+	case *v1.Service:
+		if !isServiceReady(value) {
+			return "the service has no IP address", nil
+		}
+		return "", nil
+	case *extensions.Ingress:
+		if !isIngressReady(value) {
+			return "the ingress has no load balancer", nil
+		}
+		return "", nil
+	default:
+		return "", nil
+	}
+
+	kcs, err := c.KubernetesClientSet()
+	if err != nil {
+		return "", err
+	}
+	pods, err := getPods(kcs, info.Namespace, selector)
+	if err != nil {
+		return "", err
+	}
+	return podsNotReadyReason(pods), nil
+}
+
+// podsNotReadyReason returns how many of the pods are ready and why the first
+// pod which is not ready is not, or an empty string if they are all ready.
+func podsNotReadyReason(pods []v1.Pod) string {
+	ready := 0
+	var reason string
+	for i := range pods {
+		if isPodReady(&pods[i]) {
+			ready++
+		} else if reason == "" {
+			reason = fmt.Sprintf("pod %s: %s", pods[i].Name, podNotReadyReason(&pods[i]))
+		}
+	}
+	if ready < len(pods) {
+		return fmt.Sprintf("%d of %d pods are ready; %s", ready, len(pods), reason)
+	}
+	return ""
+}
+
+// podNotReadyReason returns why the pod is not ready: it can't be scheduled,
+// one of its containers is waiting, for instance for its image to be pulled,
+// or failed, or its readiness probe is failing. Otherwise, it is the phase of
+// the pod.
+func podNotReadyReason(pod *v1.Pod) string {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == v1.PodScheduled && c.Status == v1.ConditionFalse && c.Message != "" {
+			return fmt.Sprintf("the pod can't be scheduled: %s", c.Message)
+		}
+	}
+	containers := append(append([]v1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, s := range containers {
+		switch {
+		case s.State.Waiting != nil && s.State.Waiting.Reason != "" && s.State.Waiting.Reason != "ContainerCreating" && s.State.Waiting.Reason != "PodInitializing":
+			if s.State.Waiting.Message != "" {
+				return fmt.Sprintf("container %s is waiting: %s: %s", s.Name, s.State.Waiting.Reason, s.State.Waiting.Message)
+			}
+			return fmt.Sprintf("container %s is waiting: %s", s.Name, s.State.Waiting.Reason)
+		case s.State.Terminated != nil && s.State.Terminated.ExitCode != 0:
+			return fmt.Sprintf("container %s exited with code %d: %s", s.Name, s.State.Terminated.ExitCode, s.State.Terminated.Reason)
+		}
+	}
+	for _, s := range pod.Status.ContainerStatuses {
+		if s.State.Running != nil && !s.Ready {
+			return fmt.Sprintf("container %s is running but its readiness probe is failing", s.Name)
+		}
+	}
+	return fmt.Sprintf("the pod is %s", pod.Status.Phase)
+}
+
+// deploymentNotReadyReason returns why the deployment is not ready, or an
+// empty string if it is ready.
+func (c *Client) deploymentNotReadyReason(namespace, name string) (string, error) {
+	kcs, err := c.KubernetesClientSet()
+	if err != nil {
+		return "", err
+	}
+	currentDeployment, err := kcs.AppsV1().Deployments(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+	// If paused deployment will never be ready
+	if currentDeployment.Spec.Paused {
+		return "the deployment is paused", nil
+	}
+	newReplicaSet, err := deploymentutil.GetNewReplicaSet(currentDeployment, kcs.AppsV1())
+	if err != nil {
+		return "", err
+	}
+	if newReplicaSet == nil {
+		return "the deployment has no replica set", nil
+	}
+	if !isDeploymentReady(deployment{newReplicaSet, currentDeployment}) {
+		reason := fmt.Sprintf("%d of %d replicas are ready", newReplicaSet.Status.ReadyReplicas, *currentDeployment.Spec.Replicas)
+		if newReplicaSet.Spec.Selector == nil {
+			return reason, nil
+		}
+		pods, err := getPods(kcs, namespace, newReplicaSet.Spec.Selector.MatchLabels)
+		if err != nil {
+			return "", err
+		}
+		for i := range pods {
+			if !isPodReady(&pods[i]) {
+				return fmt.Sprintf("%s; pod %s: %s", reason, pods[i].Name, podNotReadyReason(&pods[i])), nil
+			}
+		}
+		return reason, nil
+	}
+	return "", nil
+}
@@ -0,0 +1,149 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest/fake"
+)
+
+func TestStatuses(t *testing.T) {
+	ready := newPodWithStatus("otter", v1.PodStatus{
+		Phase:      v1.PodRunning,
+		Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}},
+	}, "")
+	pending := newPodWithStatus("squid", v1.PodStatus{Phase: v1.PodPending}, "")
+	svc := newService("dolphin")
+	svc.Spec.ClusterIP = "10.0.0.1"
+
+	c := newTestClient()
+	defer c.Cleanup()
+	c.TestFactory.UnstructuredClient = &fake.RESTClient{
+		GroupVersion:         schema.GroupVersion{Version: "v1"},
+		NegotiatedSerializer: unstructuredSerializer,
+		Client: fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+			p, m := req.URL.Path, req.Method
+			switch {
+			case p == "/namespaces/default/pods/otter" && m == "GET":
+				return newResponse(200, &ready)
+			case p == "/namespaces/default/pods/squid" && m == "GET":
+				return newResponse(200, &pending)
+			case p == "/namespaces/default/pods/starfish" && m == "GET":
+				return newResponse(404, notFoundBody())
+			case p == "/namespaces/default/services/dolphin" && m == "GET":
+				return newResponse(200, &svc)
+			default:
+				t.Fatalf("unexpected request: %s %s", req.Method, req.URL.Path)
+				return nil, nil
+			}
+		}),
+	}
+
+	manifests := []string{
+		"kind: Pod\napiVersion: v1\nmetadata:\n  name: otter",
+		"kind: Pod\napiVersion: v1\nmetadata:\n  name: squid",
+		"kind: Pod\napiVersion: v1\nmetadata:\n  name: starfish",
+		"kind: Service\napiVersion: v1\nmetadata:\n  name: dolphin",
+	}
+	statuses, err := c.Statuses("default", strings.NewReader(strings.Join(manifests, "\n---\n")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect := []ResourceStatus{
+		{APIVersion: "v1", Kind: "Pod", Name: "otter", Namespace: "default", Exists: true, Ready: true},
+		{APIVersion: "v1", Kind: "Pod", Name: "squid", Namespace: "default", Exists: true, Reason: "the pod is Pending"},
+		{APIVersion: "v1", Kind: "Pod", Name: "starfish", Namespace: "default", Reason: "not found"},
+		{APIVersion: "v1", Kind: "Service", Name: "dolphin", Namespace: "default", Exists: true, Ready: true},
+	}
+	if !reflect.DeepEqual(statuses, expect) {
+		t.Errorf("Expected\n%+v\ngot\n%+v", expect, statuses)
+	}
+}
+
+func TestPodNotReadyReason(t *testing.T) {
+	tests := []struct {
+		status v1.PodStatus
+		expect string
+	}{
+		{
+			v1.PodStatus{Phase: v1.PodPending},
+			"the pod is Pending",
+		},
+		{
+			v1.PodStatus{
+				Phase:      v1.PodPending,
+				Conditions: []v1.PodCondition{{Type: v1.PodScheduled, Status: v1.ConditionFalse, Message: "0/3 nodes are available: 3 Insufficient cpu."}},
+			},
+			"the pod can't be scheduled: 0/3 nodes are available: 3 Insufficient cpu.",
+		},
+		{
+			v1.PodStatus{
+				Phase: v1.PodPending,
+				ContainerStatuses: []v1.ContainerStatus{{
+					Name:  "web",
+					State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "ImagePullBackOff", Message: `Back-off pulling image "web:nope"`}},
+				}},
+			},
+			`container web is waiting: ImagePullBackOff: Back-off pulling image "web:nope"`,
+		},
+		{
+			v1.PodStatus{
+				Phase: v1.PodPending,
+				InitContainerStatuses: []v1.ContainerStatus{{
+					Name:  "migrate",
+					State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{ExitCode: 1, Reason: "Error"}},
+				}},
+			},
+			"container migrate exited with code 1: Error",
+		},
+		{
+			v1.PodStatus{
+				Phase: v1.PodRunning,
+				ContainerStatuses: []v1.ContainerStatus{{
+					Name:  "web",
+					State: v1.ContainerState{Running: &v1.ContainerStateRunning{}},
+				}},
+			},
+			"container web is running but its readiness probe is failing",
+		},
+	}
+	for _, tt := range tests {
+		pod := newPodWithStatus("squid", tt.status, "")
+		if reason := podNotReadyReason(&pod); reason != tt.expect {
+			t.Errorf("Expected %q, got %q", tt.expect, reason)
+		}
+	}
+
+	ready := newPodWithStatus("otter", v1.PodStatus{
+		Phase:      v1.PodRunning,
+		Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}},
+	}, "")
+	pending := newPodWithStatus("squid", v1.PodStatus{Phase: v1.PodPending}, "")
+	if reason := podsNotReadyReason([]v1.Pod{ready, pending}); reason != "1 of 2 pods are ready; pod squid: the pod is Pending" {
+		t.Errorf("Expected the pending pod to be reported, got %q", reason)
+	}
+	if reason := podsNotReadyReason([]v1.Pod{ready}); reason != "" {
+		t.Errorf("Expected the pods to be ready, got %q", reason)
+	}
+}
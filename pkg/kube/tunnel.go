@@ -23,6 +23,7 @@ import (
 	"net"
 	"net/http"
 	"strconv"
+	"sync"
 
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/portforward"
@@ -36,10 +37,14 @@ type Tunnel struct {
 	Namespace string
 	PodName   string
 	Out       io.Writer
-	stopChan  chan struct{}
-	readyChan chan struct{}
-	config    *rest.Config
-	client    rest.Interface
+	// PodNameFunc resolves the pod again when a WebSocket tunnel cannot
+	// reach it, so that a restarted pod is followed.
+	PodNameFunc func() (string, error)
+	mu          sync.Mutex
+	stopChan    chan struct{}
+	readyChan   chan struct{}
+	config      *rest.Config
+	client      rest.Interface
 }
 
 // NewTunnel creates a new tunnel
@@ -0,0 +1,361 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube
+
+import (
+	"bufio"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/websocket"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// portForwardProtocol is the binary channel protocol of the port-forward
+	// subresource over WebSockets. Each message starts with the channel
+	// number, the even channels carrying the data of a port and the odd ones
+	// its errors.
+	portForwardProtocol = "v4.channel.k8s.io"
+
+	dataChannel  = 0
+	errorChannel = 1
+
+	// webSocketDialAttempts is the number of times a stream to the pod is
+	// dialed before the connection is given up.
+	webSocketDialAttempts = 4
+	webSocketDialBackoff  = 250 * time.Millisecond
+)
+
+// ForwardPortWebSocket opens a tunnel to a kubernetes pod like ForwardPort,
+// but streams the connections over WebSockets instead of SPDY, which goes
+// through the HTTP proxies that do not let SPDY upgrades through.
+//
+// Every connection made to the local port opens its own stream to the pod, so
+// a broken stream is recovered the next time the client reconnects. A stream
+// that cannot be opened is dialed again with a backoff, after resolving the
+// pod with PodNameFunc if it is set, so that the tunnel follows a restarted
+// pod.
+func (t *Tunnel) ForwardPortWebSocket() error {
+	// Make sure the pod can be reached before accepting connections.
+	ws, err := t.dialWebSocket()
+	if err != nil {
+		return fmt.Errorf("forwarding ports: %v", err)
+	}
+	ws.Close()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return fmt.Errorf("could not find an available port: %s", err)
+	}
+	t.Local = l.Addr().(*net.TCPAddr).Port
+
+	go func() {
+		<-t.stopChan
+		l.Close()
+	}()
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go t.serveWebSocket(conn)
+		}
+	}()
+	return nil
+}
+
+// serveWebSocket copies the data of a local connection to a stream to the pod
+// and back, until either side closes.
+func (t *Tunnel) serveWebSocket(conn net.Conn) {
+	defer conn.Close()
+
+	var ws *websocket.Conn
+	var err error
+	backoff := webSocketDialBackoff
+	for i := 0; i < webSocketDialAttempts; i++ {
+		if i > 0 {
+			fmt.Fprintf(t.Out, "Reconnecting to pod %s/%s in %s: %s\n", t.Namespace, t.podName(), backoff, err)
+			time.Sleep(backoff)
+			backoff *= 2
+			t.resolvePod()
+		}
+		if ws, err = t.dialWebSocket(); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(t.Out, "Could not forward port %d of pod %s/%s: %s\n", t.Remote, t.Namespace, t.podName(), err)
+		return
+	}
+	defer ws.Close()
+
+	errc := make(chan error, 2)
+	go func() { errc <- writeStream(ws, conn) }()
+	go func() { errc <- readStream(conn, ws) }()
+	if err := <-errc; err != nil && err != io.EOF {
+		fmt.Fprintf(t.Out, "Error forwarding port %d of pod %s/%s: %s\n", t.Remote, t.Namespace, t.podName(), err)
+	}
+}
+
+func (t *Tunnel) podName() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.PodName
+}
+
+// resolvePod looks the pod up again, keeping the current one if it fails.
+func (t *Tunnel) resolvePod() {
+	if t.PodNameFunc == nil {
+		return
+	}
+	name, err := t.PodNameFunc()
+	if err != nil {
+		fmt.Fprintf(t.Out, "Could not resolve the pod to forward to: %s\n", err)
+		return
+	}
+	t.mu.Lock()
+	t.PodName = name
+	t.mu.Unlock()
+}
+
+// dialWebSocket opens a stream to the remote port of the pod through the
+// port-forward subresource of the API server.
+func (t *Tunnel) dialWebSocket() (*websocket.Conn, error) {
+	// example: wss://localhost:8443/api/v1/namespaces/helm/pods/tiller-deploy-9itlq/portforward?ports=44134
+	u := t.client.Get().
+		Resource("pods").
+		Namespace(t.Namespace).
+		Name(t.podName()).
+		SubResource("portforward").
+		Param("ports", strconv.Itoa(t.Remote)).URL()
+
+	header, err := authHeader(t.config, u)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := dialAPIServer(t.config, u)
+	if err != nil {
+		return nil, err
+	}
+
+	wsURL := *u
+	wsURL.Scheme = "ws"
+	if u.Scheme == "https" {
+		wsURL.Scheme = "wss"
+	}
+	origin := &url.URL{Scheme: u.Scheme, Host: u.Host}
+	cfg, err := websocket.NewConfig(wsURL.String(), origin.String())
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	cfg.Protocol = []string{portForwardProtocol}
+	cfg.Header = header
+
+	ws, err := websocket.NewClient(cfg, conn)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("error upgrading connection: %s", err)
+	}
+	return ws, nil
+}
+
+// writeStream sends what is read from r on the data channel of the stream.
+func writeStream(ws *websocket.Conn, r io.Reader) error {
+	buf := make([]byte, 32*1024)
+	for {
+		buf[0] = dataChannel
+		n, err := r.Read(buf[1:])
+		if n > 0 {
+			if err := websocket.Message.Send(ws, buf[:n+1]); err != nil {
+				return err
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// readStream writes the data channel of the stream to w, and returns the
+// errors sent on the error channel.
+func readStream(w io.Writer, ws *websocket.Conn) error {
+	// The first message of each channel only holds the port number.
+	gotPort := map[byte]bool{}
+	for {
+		var msg []byte
+		if err := websocket.Message.Receive(ws, &msg); err != nil {
+			return err
+		}
+		if len(msg) == 0 {
+			continue
+		}
+		channel, data := msg[0], msg[1:]
+		if !gotPort[channel] {
+			if len(data) < 2 {
+				return fmt.Errorf("invalid port number on channel %d", channel)
+			}
+			gotPort[channel] = true
+			data = data[2:]
+		}
+		switch channel {
+		case dataChannel:
+			if _, err := w.Write(data); err != nil {
+				return err
+			}
+		case errorChannel:
+			if len(data) > 0 {
+				return fmt.Errorf("%s", data)
+			}
+		}
+	}
+}
+
+// authHeader returns the headers carrying the credentials of config for a
+// request to u.
+func authHeader(config *rest.Config, u *url.URL) (http.Header, error) {
+	capture := &headerCapture{}
+	rt, err := rest.HTTPWrappersForConfig(config, capture)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := rt.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	return capture.header, nil
+}
+
+// headerCapture is a http.RoundTripper recording the headers of the request
+// instead of sending it.
+type headerCapture struct {
+	header http.Header
+}
+
+func (c *headerCapture) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.header = req.Header
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(strings.NewReader("")),
+		Request:    req,
+	}, nil
+}
+
+// dialAPIServer opens a connection to the API server of u, through the HTTP
+// proxy of the environment if there is one, and secured with the TLS
+// settings of config.
+func dialAPIServer(config *rest.Config, u *url.URL) (net.Conn, error) {
+	addr := u.Host
+	if u.Port() == "" {
+		if u.Scheme == "https" {
+			addr = net.JoinHostPort(u.Hostname(), "443")
+		} else {
+			addr = net.JoinHostPort(u.Hostname(), "80")
+		}
+	}
+
+	proxyURL, err := http.ProxyFromEnvironment(&http.Request{URL: u})
+	if err != nil {
+		return nil, err
+	}
+	var conn net.Conn
+	if proxyURL != nil {
+		conn, err = dialProxy(proxyURL, addr)
+	} else {
+		conn, err = net.DialTimeout("tcp", addr, 30*time.Second)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Scheme != "https" {
+		return conn, nil
+	}
+	tlsConfig, err := rest.TLSConfigFor(config)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{}
+	}
+	if tlsConfig.ServerName == "" && !tlsConfig.InsecureSkipVerify {
+		tlsConfig = tlsConfig.Clone()
+		tlsConfig.ServerName = u.Hostname()
+	}
+	tlsConn := tls.Client(conn, tlsConfig)
+	if err := tlsConn.Handshake(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return tlsConn, nil
+}
+
+// dialProxy opens a connection to addr through the HTTP proxy at proxyURL.
+func dialProxy(proxyURL *url.URL, addr string) (net.Conn, error) {
+	proxyAddr := proxyURL.Host
+	if proxyURL.Port() == "" {
+		proxyAddr = net.JoinHostPort(proxyURL.Hostname(), "80")
+	}
+	conn, err := net.DialTimeout("tcp", proxyAddr, 30*time.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &http.Request{
+		Method: "CONNECT",
+		URL:    &url.URL{Opaque: addr},
+		Host:   addr,
+		Header: http.Header{},
+	}
+	if u := proxyURL.User; u != nil {
+		password, _ := u.Password()
+		auth := base64.StdEncoding.EncodeToString([]byte(u.Username() + ":" + password))
+		req.Header.Set("Proxy-Authorization", "Basic "+auth)
+	}
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	resp, err := http.ReadResponse(bufio.NewReader(conn), req)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		conn.Close()
+		return nil, fmt.Errorf("proxy %s refused the connection to %s: %s", proxyURL.Host, addr, resp.Status)
+	}
+	return conn, nil
+}
@@ -0,0 +1,141 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/net/websocket"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// portForwardServer emulates the port-forward subresource over WebSockets,
+// echoing the data sent to the port.
+func portForwardServer() *httptest.Server {
+	return httptest.NewServer(websocket.Server{
+		Handshake: func(config *websocket.Config, req *http.Request) error {
+			if req.URL.Path != "/api/v1/namespaces/kube-system/pods/tiller-deploy/portforward" {
+				return fmt.Errorf("unexpected path %s", req.URL.Path)
+			}
+			if ports := req.URL.Query().Get("ports"); ports != "44134" {
+				return fmt.Errorf("unexpected ports %s", ports)
+			}
+			if auth := req.Header.Get("Authorization"); auth != "Bearer secret" {
+				return fmt.Errorf("unexpected authorization %q", auth)
+			}
+			config.Protocol = []string{portForwardProtocol}
+			return nil
+		},
+		Handler: func(ws *websocket.Conn) {
+			defer ws.Close()
+			port := []byte{0x66, 0xac} // 44134
+			for _, channel := range []byte{dataChannel, errorChannel} {
+				if err := websocket.Message.Send(ws, append([]byte{channel}, port...)); err != nil {
+					return
+				}
+			}
+			for {
+				var msg []byte
+				if err := websocket.Message.Receive(ws, &msg); err != nil {
+					return
+				}
+				if err := websocket.Message.Send(ws, msg); err != nil {
+					return
+				}
+			}
+		},
+	})
+}
+
+func TestForwardPortWebSocket(t *testing.T) {
+	srv := portForwardServer()
+	defer srv.Close()
+
+	config := &rest.Config{Host: srv.URL, BearerToken: "secret"}
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tunnel := NewTunnel(client.CoreV1().RESTClient(), config, "kube-system", "tiller-deploy", 44134)
+	if err := tunnel.ForwardPortWebSocket(); err != nil {
+		t.Fatal(err)
+	}
+	defer tunnel.Close()
+
+	// Every connection opens its own stream.
+	for i := 0; i < 2; i++ {
+		conn, err := net.Dial("tcp", fmt.Sprintf("127.0.0.1:%d", tunnel.Local))
+		if err != nil {
+			t.Fatal(err)
+		}
+		msg := []byte(fmt.Sprintf("ping %d", i))
+		if _, err := conn.Write(msg); err != nil {
+			t.Fatal(err)
+		}
+		got := make([]byte, len(msg))
+		if _, err := io.ReadFull(conn, got); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, msg) {
+			t.Errorf("expected %q, got %q", msg, got)
+		}
+		conn.Close()
+	}
+}
+
+func TestForwardPortWebSocketUnreachable(t *testing.T) {
+	srv := portForwardServer()
+	defer srv.Close()
+
+	config := &rest.Config{Host: srv.URL, BearerToken: "secret"}
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tunnel := NewTunnel(client.CoreV1().RESTClient(), config, "kube-system", "missing", 44134)
+	if err := tunnel.ForwardPortWebSocket(); err == nil {
+		t.Error("expected an error forwarding the port of a missing pod")
+	}
+}
+
+func TestReadStreamError(t *testing.T) {
+	srv := httptest.NewServer(websocket.Handler(func(ws *websocket.Conn) {
+		defer ws.Close()
+		websocket.Message.Send(ws, []byte{errorChannel, 0x66, 0xac})
+		websocket.Message.Send(ws, append([]byte{errorChannel}, "socat not found"...))
+	}))
+	defer srv.Close()
+
+	ws, err := websocket.Dial("ws"+srv.URL[len("http"):], "", srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close()
+
+	var out bytes.Buffer
+	err = readStream(&out, ws)
+	if err == nil || err.Error() != "socat not found" {
+		t.Errorf("expected the error of the error channel, got %v", err)
+	}
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package kube // import "k8s.io/helm/pkg/kube"
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
@@ -39,7 +41,8 @@ type deployment struct {
 }
 
 // waitForResources polls to get the current status of all pods, PVCs, and Services
-// until all are ready or a timeout is reached
+// until all are ready or a timeout is reached. Each time they are not, it logs
+// which resources are not ready and why, and the timeout error lists them.
 func (c *Client) waitForResources(timeout time.Duration, created Result) error {
 	c.Log("beginning wait for %d resources with timeout of %v", len(created), timeout)
 
@@ -47,7 +50,8 @@ func (c *Client) waitForResources(timeout time.Duration, created Result) error {
 	if err != nil {
 		return err
 	}
-	return wait.Poll(2*time.Second, timeout, func() (bool, error) {
+	var notReady []string
+	err = wait.Poll(2*time.Second, timeout, func() (bool, error) {
 		pods := []v1.Pod{}
 		services := []v1.Service{}
 		pvc := []v1.PersistentVolumeClaim{}
@@ -218,8 +222,40 @@ func (c *Client) waitForResources(timeout time.Duration, created Result) error {
 			}
 		}
 		isReady := c.podsReady(pods) && c.servicesReady(services) && c.volumesReady(pvc) && c.deploymentsReady(deployments) && c.ingressesReady(ingresses)
+		if !isReady {
+			notReady = c.notReadyResources(created)
+			c.Log("waiting for %d of %d resources: %s", len(notReady), len(created), strings.Join(notReady, ", "))
+		}
 		return isReady, nil
 	})
+	if err == wait.ErrWaitTimeout && len(notReady) > 0 {
+		return fmt.Errorf("%s, resources not ready: %s", err, strings.Join(notReady, ", "))
+	}
+	return err
+}
+
+// notReadyResources returns the resources of infos which are not ready, with
+// why they are not, as "Kind namespace/name (reason)".
+func (c *Client) notReadyResources(infos Result) []string {
+	var notReady []string
+	for _, info := range infos {
+		// Get the resource into a copy of its info, which is left as it was
+		// created.
+		current := *info
+		if err := current.Get(); err != nil {
+			c.Log("unable to get %s %s/%s: %s", info.Mapping.GroupVersionKind.Kind, info.Namespace, info.Name, err)
+			continue
+		}
+		reason, err := c.notReadyReason(&current)
+		if err != nil {
+			c.Log("unable to tell whether %s %s/%s is ready: %s", info.Mapping.GroupVersionKind.Kind, info.Namespace, info.Name, err)
+			continue
+		}
+		if reason != "" {
+			notReady = append(notReady, fmt.Sprintf("%s %s/%s (%s)", info.Mapping.GroupVersionKind.Kind, info.Namespace, info.Name, reason))
+		}
+	}
+	return notReady
 }
 
 func (c *Client) podsReady(pods []v1.Pod) bool {
@@ -234,18 +270,7 @@ func (c *Client) podsReady(pods []v1.Pod) bool {
 
 func (c *Client) servicesReady(svc []v1.Service) bool {
 	for _, s := range svc {
-		// ExternalName Services are external to cluster so helm shouldn't be checking to see if they're 'ready' (i.e. have an IP Set)
-		if s.Spec.Type == v1.ServiceTypeExternalName {
-			continue
-		}
-
-		// Make sure the service is not explicitly set to "None" before checking the IP
-		if s.Spec.ClusterIP != v1.ClusterIPNone && s.Spec.ClusterIP == "" {
-			c.Log("Service is not ready: %s/%s", s.GetNamespace(), s.GetName())
-			return false
-		}
-		// This checks if the service has a LoadBalancer and that balancer has an Ingress defined
-		if s.Spec.Type == v1.ServiceTypeLoadBalancer && s.Status.LoadBalancer.Ingress == nil {
+		if !isServiceReady(&s) {
 			c.Log("Service is not ready: %s/%s", s.GetNamespace(), s.GetName())
 			return false
 		}
@@ -253,6 +278,20 @@ func (c *Client) servicesReady(svc []v1.Service) bool {
 	return true
 }
 
+func isServiceReady(s *v1.Service) bool {
+	// ExternalName Services are external to cluster so helm shouldn't be checking to see if they're 'ready' (i.e. have an IP Set)
+	if s.Spec.Type == v1.ServiceTypeExternalName {
+		return true
+	}
+
+	// Make sure the service is not explicitly set to "None" before checking the IP
+	if s.Spec.ClusterIP != v1.ClusterIPNone && s.Spec.ClusterIP == "" {
+		return false
+	}
+	// This checks if the service has a LoadBalancer and that balancer has an Ingress defined
+	return s.Spec.Type != v1.ServiceTypeLoadBalancer || s.Status.LoadBalancer.Ingress != nil
+}
+
 func (c *Client) volumesReady(vols []v1.PersistentVolumeClaim) bool {
 	for _, v := range vols {
 		if v.Status.Phase != v1.ClaimBound {
@@ -265,7 +304,7 @@ func (c *Client) volumesReady(vols []v1.PersistentVolumeClaim) bool {
 
 func (c *Client) deploymentsReady(deployments []deployment) bool {
 	for _, v := range deployments {
-		if !(v.replicaSets.Status.ReadyReplicas >= *v.deployment.Spec.Replicas-deploymentutil.MaxUnavailable(*v.deployment)) {
+		if !isDeploymentReady(v) {
 			c.Log("Deployment is not ready: %s/%s", v.deployment.GetNamespace(), v.deployment.GetName())
 			return false
 		}
@@ -273,6 +312,10 @@ func (c *Client) deploymentsReady(deployments []deployment) bool {
 	return true
 }
 
+func isDeploymentReady(v deployment) bool {
+	return v.replicaSets.Status.ReadyReplicas >= *v.deployment.Spec.Replicas-deploymentutil.MaxUnavailable(*v.deployment)
+}
+
 func getPods(client kubernetes.Interface, namespace string, selector map[string]string) ([]v1.Pod, error) {
 	list, err := client.CoreV1().Pods(namespace).List(metav1.ListOptions{
 		FieldSelector: fields.Everything().String(),
@@ -295,10 +338,14 @@ func isPodReady(pod *v1.Pod) bool {
 
 func (c *Client) ingressesReady(ingresses []extensions.Ingress) bool {
 	for _, ingress := range ingresses {
-		if &ingress.Status == nil || len(ingress.Status.LoadBalancer.Ingress) == 0 {
+		if !isIngressReady(&ingress) {
 			c.Log("Ingress is not ready: %s/%s", ingress.GetNamespace(), ingress.GetName())
 			return false
 		}
 	}
 	return true
 }
+
+func isIngressReady(ingress *extensions.Ingress) bool {
+	return len(ingress.Status.LoadBalancer.Ingress) > 0
+}
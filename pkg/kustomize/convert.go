@@ -0,0 +1,499 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kustomize
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/ghodss/yaml"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/releaseutil"
+)
+
+const (
+	// BaseDir is the directory of the base of a kustomize tree.
+	BaseDir = "base"
+	// OverlaysDir is the directory of the overlays of a kustomize tree, one
+	// overlay per directory.
+	OverlaysDir = "overlays"
+	// EnvironmentsDir is the directory of the environment values files of a
+	// converted chart, one per overlay.
+	EnvironmentsDir = "environments"
+)
+
+// workloadKinds are the kinds of the resources whose replicas are values.
+var workloadKinds = map[string]bool{
+	"Deployment":            true,
+	"ReplicaSet":            true,
+	"ReplicationController": true,
+	"StatefulSet":           true,
+}
+
+// clusterKinds are the kinds of the cluster-scoped resources, which have no
+// namespace.
+var clusterKinds = map[string]bool{
+	"APIService":                     true,
+	"ClusterRole":                    true,
+	"ClusterRoleBinding":             true,
+	"CustomResourceDefinition":       true,
+	"MutatingWebhookConfiguration":   true,
+	"Namespace":                      true,
+	"Node":                           true,
+	"PersistentVolume":               true,
+	"PodSecurityPolicy":              true,
+	"PriorityClass":                  true,
+	"StorageClass":                   true,
+	"ValidatingWebhookConfiguration": true,
+}
+
+// Conversion is a chart converted from a kustomize tree.
+type Conversion struct {
+	// Files are the files of the chart, with paths relative to the chart
+	// directory.
+	Files []*chartutil.BufferedFile
+	// Environments are the environments of the chart, one per overlay.
+	Environments []string
+	// Warnings are the parts of the tree that could not be converted, and
+	// must be ported by hand.
+	Warnings []string
+}
+
+// Convert converts the kustomize tree of dir to a chart named name.
+//
+// The resources of the base of the tree become templates, with the names,
+// namespaces, labels, annotations, images and replicas replaced by values.
+// The values of the chart are those of the base, and every overlay becomes
+// an environment values file, environments/OVERLAY.yaml, to be used with
+// '--environment'. The resources only in overlays, patches and generators are
+// not converted, and are reported in the warnings of the conversion.
+func Convert(dir, name string) (*Conversion, error) {
+	baseDir := filepath.Join(dir, BaseDir)
+	base, err := LoadKustomization(baseDir)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Conversion{}
+	c.unsupported(BaseDir, base)
+	c.warn(base.Bases, "%s: base %s is not converted", BaseDir)
+
+	var resources []map[string]interface{}
+	for _, r := range base.Resources {
+		path := filepath.Join(baseDir, r)
+		if fi, err := os.Stat(path); err == nil && fi.IsDir() {
+			c.Warnings = append(c.Warnings, fmt.Sprintf("%s: resource %s is a directory and is not converted", BaseDir, r))
+			continue
+		}
+		docs, err := loadResources(path)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, docs...)
+	}
+
+	vals := baseValues(base, resources)
+	images := vals["images"].(map[string]interface{})
+	replicas := vals["replicas"].(map[string]interface{})
+
+	c.add("Chart.yaml", &chart.Metadata{
+		ApiVersion:  chartutil.ApiVersionV1,
+		Name:        name,
+		Version:     "0.1.0",
+		Description: fmt.Sprintf("A Helm chart converted from the kustomize tree %s", filepath.Base(dir)),
+	}, "")
+	c.add("values.yaml", vals, "# Default values for "+name+", converted from the kustomize base.\n")
+	c.Files = append(c.Files, &chartutil.BufferedFile{Name: "templates/_helpers.tpl", Data: []byte(fmt.Sprintf(helpersTemplate, name))})
+	seen := map[string]int{}
+	for _, r := range resources {
+		file := templateName(r, seen)
+		c.Files = append(c.Files, &chartutil.BufferedFile{Name: file, Data: []byte(resourceTemplate(name, r, base))})
+	}
+
+	overlays, err := ioutil.ReadDir(filepath.Join(dir, OverlaysDir))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, fi := range overlays {
+		if !fi.IsDir() {
+			continue
+		}
+		env := fi.Name()
+		overlayDir := filepath.Join(dir, OverlaysDir, env)
+		o, err := LoadKustomization(overlayDir)
+		if err != nil {
+			c.Warnings = append(c.Warnings, fmt.Sprintf("%s: %s", filepath.Join(OverlaysDir, env), err))
+			continue
+		}
+		envVals, ok := c.overlayValues(filepath.Join(OverlaysDir, env), overlayDir, baseDir, base, o, images, replicas)
+		if !ok {
+			continue
+		}
+		header := fmt.Sprintf("# Values of the %s environment, converted from the overlay %s.\n# Use them with --environment %s.\n", env, filepath.ToSlash(filepath.Join(OverlaysDir, env)), environmentFile(env))
+		c.add(environmentFile(env), envVals, header)
+		c.Environments = append(c.Environments, env)
+	}
+	return c, nil
+}
+
+// environmentFile returns the path of the values file of the environment env
+// in a converted chart.
+func environmentFile(env string) string {
+	return EnvironmentsDir + "/" + env + ".yaml"
+}
+
+// add adds the file name, v marshaled to YAML after the header, to the
+// files of the conversion.
+func (c *Conversion) add(name string, v interface{}, header string) {
+	b, err := yaml.Marshal(v)
+	if err != nil {
+		// The values are decoded from YAML, so they always marshal
+		panic(err)
+	}
+	c.Files = append(c.Files, &chartutil.BufferedFile{Name: name, Data: append([]byte(header), b...)})
+}
+
+// warn adds a warning per item of items, formatted with format, the
+// kustomization and the item.
+func (c *Conversion) warn(items []string, format, kustomization string) {
+	for _, item := range items {
+		c.Warnings = append(c.Warnings, fmt.Sprintf(format, kustomization, item))
+	}
+}
+
+// unsupported adds a warning for the patches and generators of the
+// kustomization k.
+func (c *Conversion) unsupported(name string, k *Kustomization) {
+	c.warn(k.PatchesStrategicMerge, "%s: patch %s is not converted", name)
+	for _, f := range []struct {
+		field string
+		n     int
+	}{
+		{"patchesJson6902", len(k.PatchesJSON6902)},
+		{"patches", len(k.Patches)},
+		{"configMapGenerator", len(k.ConfigMapGenerator)},
+		{"secretGenerator", len(k.SecretGenerator)},
+	} {
+		if f.n > 0 {
+			c.Warnings = append(c.Warnings, fmt.Sprintf("%s: %s is not converted", name, f.field))
+		}
+	}
+}
+
+// loadResources returns the resources of the file path.
+func loadResources(path string) ([]map[string]interface{}, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	manifests := releaseutil.SplitManifests(string(b))
+	var resources []map[string]interface{}
+	for i := 0; i < len(manifests); i++ {
+		var r map[string]interface{}
+		if err := yaml.Unmarshal([]byte(manifests[fmt.Sprintf("manifest-%d", i)]), &r); err != nil {
+			return nil, fmt.Errorf("cannot load %s: %s", path, err)
+		}
+		if r != nil {
+			resources = append(resources, r)
+		}
+	}
+	return resources, nil
+}
+
+// baseValues returns the values of the base kustomization k with the
+// resources resources.
+func baseValues(k *Kustomization, resources []map[string]interface{}) map[string]interface{} {
+	images := map[string]interface{}{}
+	replicas := map[string]interface{}{}
+	for _, r := range resources {
+		for _, c := range containers(r) {
+			if image, ok := c["image"].(string); ok {
+				repo, tag, digest := splitImage(image)
+				img := map[string]interface{}{"repository": repo, "tag": tag}
+				if digest != "" {
+					img["digest"] = digest
+				}
+				images[repo] = img
+			}
+		}
+		if workloadKinds[kind(r)] {
+			count := interface{}(int64(1))
+			if spec, ok := r["spec"].(map[string]interface{}); ok && spec["replicas"] != nil {
+				count = spec["replicas"]
+			}
+			replicas[resourceName(r)] = count
+		}
+	}
+	for _, i := range k.Images {
+		if img, ok := images[i.Name].(map[string]interface{}); ok {
+			setImage(img, i)
+		}
+	}
+	for _, r := range k.Replicas {
+		replicas[r.Name] = r.Count
+	}
+
+	return map[string]interface{}{
+		"namePrefix":        k.NamePrefix,
+		"nameSuffix":        k.NameSuffix,
+		"namespace":         k.Namespace,
+		"commonLabels":      stringMap(k.CommonLabels),
+		"commonAnnotations": stringMap(k.CommonAnnotations),
+		"images":            images,
+		"replicas":          replicas,
+	}
+}
+
+// overlayValues returns the values of the environment of the overlay o in
+// overlayDir, named name, which are merged with the values of the base. It
+// returns false if the overlay does not use the base.
+func (c *Conversion) overlayValues(name, overlayDir, baseDir string, base, o *Kustomization, images, replicas map[string]interface{}) (map[string]interface{}, bool) {
+	c.unsupported(name, o)
+	usesBase := false
+	for _, r := range append(append([]string{}, o.Resources...), o.Bases...) {
+		if filepath.Clean(filepath.Join(overlayDir, r)) == filepath.Clean(baseDir) {
+			usesBase = true
+			continue
+		}
+		c.Warnings = append(c.Warnings, fmt.Sprintf("%s: resource %s is not converted", name, r))
+	}
+	if !usesBase {
+		c.Warnings = append(c.Warnings, fmt.Sprintf("%s: the overlay does not use the base, no environment is created", name))
+		return nil, false
+	}
+
+	vals := map[string]interface{}{}
+	if o.NamePrefix != "" {
+		vals["namePrefix"] = o.NamePrefix + base.NamePrefix
+	}
+	if o.NameSuffix != "" {
+		vals["nameSuffix"] = base.NameSuffix + o.NameSuffix
+	}
+	if o.Namespace != "" {
+		vals["namespace"] = o.Namespace
+	}
+	if len(o.CommonLabels) > 0 {
+		vals["commonLabels"] = stringMap(o.CommonLabels)
+	}
+	if len(o.CommonAnnotations) > 0 {
+		vals["commonAnnotations"] = stringMap(o.CommonAnnotations)
+	}
+	envImages := map[string]interface{}{}
+	for _, i := range o.Images {
+		if _, ok := images[i.Name]; !ok {
+			c.Warnings = append(c.Warnings, fmt.Sprintf("%s: image %s is not used by the base and is not converted", name, i.Name))
+			continue
+		}
+		img := map[string]interface{}{}
+		setImage(img, i)
+		envImages[i.Name] = img
+	}
+	if len(envImages) > 0 {
+		vals["images"] = envImages
+	}
+	envReplicas := map[string]interface{}{}
+	for _, r := range o.Replicas {
+		if _, ok := replicas[r.Name]; !ok {
+			c.Warnings = append(c.Warnings, fmt.Sprintf("%s: replicas of %s are not converted, the base has no such workload", name, r.Name))
+			continue
+		}
+		envReplicas[r.Name] = r.Count
+	}
+	if len(envReplicas) > 0 {
+		vals["replicas"] = envReplicas
+	}
+	return vals, true
+}
+
+// setImage sets the fields of the image values img overridden by i.
+func setImage(img map[string]interface{}, i Image) {
+	if i.NewName != "" {
+		img["repository"] = i.NewName
+	}
+	if i.NewTag != "" {
+		img["tag"] = i.NewTag
+	}
+	if i.Digest != "" {
+		img["digest"] = i.Digest
+	}
+}
+
+// splitImage splits an image into its repository, its tag and its digest.
+func splitImage(image string) (repo, tag, digest string) {
+	repo = image
+	if i := strings.Index(repo, "@"); i >= 0 {
+		repo, digest = repo[:i], repo[i+1:]
+	}
+	if i := strings.LastIndex(repo, ":"); i > strings.LastIndex(repo, "/") {
+		repo, tag = repo[:i], repo[i+1:]
+	}
+	return repo, tag, digest
+}
+
+func stringMap(m map[string]string) map[string]interface{} {
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
+
+func kind(r map[string]interface{}) string {
+	k, _ := r["kind"].(string)
+	return k
+}
+
+func resourceName(r map[string]interface{}) string {
+	md, _ := r["metadata"].(map[string]interface{})
+	name, _ := md["name"].(string)
+	return name
+}
+
+// containers returns the containers and init containers of the pod template
+// of the resource r.
+func containers(r map[string]interface{}) []map[string]interface{} {
+	spec, _ := r["spec"].(map[string]interface{})
+	tpl, _ := spec["template"].(map[string]interface{})
+	podSpec, _ := tpl["spec"].(map[string]interface{})
+	var out []map[string]interface{}
+	for _, field := range []string{"initContainers", "containers"} {
+		list, _ := podSpec[field].([]interface{})
+		for _, item := range list {
+			if c, ok := item.(map[string]interface{}); ok {
+				out = append(out, c)
+			}
+		}
+	}
+	return out
+}
+
+// nonNameChars are the characters replaced in the names of the templates.
+var nonNameChars = regexp.MustCompile(`[^a-z0-9.-]+`)
+
+// templateName returns the name of the template of the resource r, unique
+// among the names seen.
+func templateName(r map[string]interface{}, seen map[string]int) string {
+	base := nonNameChars.ReplaceAllString(strings.ToLower(kind(r)+"-"+resourceName(r)), "-")
+	seen[base]++
+	if n := seen[base]; n > 1 {
+		base = fmt.Sprintf("%s-%d", base, n)
+	}
+	return "templates/" + base + ".yaml"
+}
+
+const helpersTemplate = `{{/*
+The image of a container, from its table of the images values.
+*/}}
+{{- define "%s.image" -}}
+{{ .repository }}{{ if .digest }}@{{ .digest }}{{ else if .tag }}:{{ .tag }}{{ end }}
+{{- end -}}
+`
+
+// Placeholders marshaled in the resources, and replaced by template actions.
+const (
+	labelsPlaceholder      = "__helm_common_labels__"
+	annotationsPlaceholder = "__helm_common_annotations__"
+)
+
+// resourceTemplate returns the template of the resource r of the chart name,
+// with the fields of the kustomization base replaced by values.
+func resourceTemplate(name string, r map[string]interface{}, base *Kustomization) string {
+	values := map[string]string{}
+	placeholder := func(action string) string {
+		p := fmt.Sprintf("__helm_value_%d__", len(values))
+		values[p] = action
+		return p
+	}
+	table := func(m map[string]interface{}, key string) map[string]interface{} {
+		t, ok := m[key].(map[string]interface{})
+		if !ok {
+			t = map[string]interface{}{}
+			m[key] = t
+		}
+		return t
+	}
+	// addCommon adds the common labels or annotations to the table key of
+	// m. The labels set by the base are dropped, as they are values.
+	addCommon := func(m map[string]interface{}, key, p string, common map[string]string) {
+		t := table(m, key)
+		for k := range common {
+			delete(t, k)
+		}
+		t[p] = ""
+	}
+
+	resName := resourceName(r)
+	md := table(r, "metadata")
+	md["name"] = placeholder(fmt.Sprintf("{{ .Values.namePrefix }}%s{{ .Values.nameSuffix }}", resName))
+	if !clusterKinds[kind(r)] {
+		md["namespace"] = placeholder("{{ .Values.namespace | default .Release.Namespace }}")
+	}
+	addCommon(md, "labels", labelsPlaceholder, base.CommonLabels)
+	addCommon(md, "annotations", annotationsPlaceholder, base.CommonAnnotations)
+
+	if spec, ok := r["spec"].(map[string]interface{}); ok {
+		if workloadKinds[kind(r)] {
+			spec["replicas"] = placeholder(fmt.Sprintf("{{ index .Values.replicas %q }}", resName))
+		}
+		if sel, ok := spec["selector"].(map[string]interface{}); ok {
+			if kind(r) == "Service" {
+				addCommon(spec, "selector", labelsPlaceholder, base.CommonLabels)
+			} else if _, ok := sel["matchLabels"]; ok || workloadKinds[kind(r)] {
+				addCommon(sel, "matchLabels", labelsPlaceholder, base.CommonLabels)
+			}
+		}
+		if tpl, ok := spec["template"].(map[string]interface{}); ok {
+			tplMd := table(tpl, "metadata")
+			addCommon(tplMd, "labels", labelsPlaceholder, base.CommonLabels)
+			addCommon(tplMd, "annotations", annotationsPlaceholder, base.CommonAnnotations)
+		}
+	}
+	for _, c := range containers(r) {
+		if image, ok := c["image"].(string); ok {
+			repo, _, _ := splitImage(image)
+			c["image"] = placeholder(fmt.Sprintf("{{ include %q (index .Values.images %q) }}", name+".image", repo))
+		}
+	}
+
+	b, err := yaml.Marshal(r)
+	if err != nil {
+		// The resource is decoded from YAML, so it always marshals
+		panic(err)
+	}
+	lines := strings.Split(string(b), "\n")
+	for i, line := range lines {
+		trimmed := strings.TrimLeft(line, " ")
+		indent := line[:len(line)-len(trimmed)]
+		switch trimmed {
+		case labelsPlaceholder + `: ""`:
+			lines[i] = fmt.Sprintf("%s{{- with .Values.commonLabels }}{{ toYaml . | nindent %d }}{{ end }}", indent, len(indent))
+		case annotationsPlaceholder + `: ""`:
+			lines[i] = fmt.Sprintf("%s{{- with .Values.commonAnnotations }}{{ toYaml . | nindent %d }}{{ end }}", indent, len(indent))
+		}
+	}
+	out := strings.Join(lines, "\n")
+	for p, action := range values {
+		out = strings.Replace(out, p, action, -1)
+	}
+	return out
+}
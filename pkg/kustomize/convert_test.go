@@ -0,0 +1,155 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kustomize
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ghodss/yaml"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/engine"
+)
+
+func TestConvert(t *testing.T) {
+	c, err := Convert("testdata/app", "app")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var files []string
+	for _, f := range c.Files {
+		files = append(files, f.Name)
+	}
+	expectFiles := []string{
+		"Chart.yaml",
+		"values.yaml",
+		"templates/_helpers.tpl",
+		"templates/deployment-web.yaml",
+		"templates/service-web.yaml",
+		"environments/prod.yaml",
+		"environments/staging.yaml",
+	}
+	if !reflect.DeepEqual(files, expectFiles) {
+		t.Errorf("Expected the files %v, got %v", expectFiles, files)
+	}
+	if expect := []string{"prod", "staging"}; !reflect.DeepEqual(c.Environments, expect) {
+		t.Errorf("Expected the environments %v, got %v", expect, c.Environments)
+	}
+	expectWarnings := []string{
+		"overlays/prod: patch resources.yaml is not converted",
+		"overlays/prod: resource ingress.yaml is not converted",
+	}
+	if !reflect.DeepEqual(c.Warnings, expectWarnings) {
+		t.Errorf("Expected the warnings %q, got %q", expectWarnings, c.Warnings)
+	}
+
+	for _, tt := range []struct {
+		env        string
+		deployment string
+		service    string
+	}{
+		{
+			env: "",
+			deployment: `metadata: {labels: {app: web}, name: web, namespace: default}
+spec:
+  replicas: 2
+  selector: {matchLabels: {app: web}}
+  template:
+    metadata: {labels: {app: web}}
+    spec: {containers: [{name: nginx, image: "nginx:1.17", ports: [{containerPort: 80}]}]}`,
+			service: `metadata: {labels: {app: web}, name: web, namespace: default}
+spec: {selector: {app: web}, ports: [{port: 80}]}`,
+		},
+		{
+			env: "environments/prod.yaml",
+			deployment: `metadata: {labels: {app: web, env: prod}, name: prod-web, namespace: production}
+spec:
+  replicas: 5
+  selector: {matchLabels: {app: web, env: prod}}
+  template:
+    metadata: {labels: {app: web, env: prod}}
+    spec: {containers: [{name: nginx, image: "registry.example.com/nginx:1.17.3", ports: [{containerPort: 80}]}]}`,
+			service: `metadata: {labels: {app: web, env: prod}, name: prod-web, namespace: production}
+spec: {selector: {app: web, env: prod}, ports: [{port: 80}]}`,
+		},
+		{
+			env: "environments/staging.yaml",
+			deployment: `metadata: {annotations: {owner: qa}, labels: {app: web}, name: web-staging, namespace: default}
+spec:
+  replicas: 2
+  selector: {matchLabels: {app: web}}
+  template:
+    metadata: {annotations: {owner: qa}, labels: {app: web}}
+    spec: {containers: [{name: nginx, image: "nginx:1.17", ports: [{containerPort: 80}]}]}`,
+			service: `metadata: {annotations: {owner: qa}, labels: {app: web}, name: web-staging, namespace: default}
+spec: {selector: {app: web}, ports: [{port: 80}]}`,
+		},
+	} {
+		ch, err := chartutil.LoadFilesWithEnvValues(c.Files, tt.env)
+		if err != nil {
+			t.Fatal(err)
+		}
+		vals, err := chartutil.ToRenderValues(ch, nil, chartutil.ReleaseOptions{Name: "rel", Namespace: "default"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		out, err := engine.New().Render(ch, vals)
+		if err != nil {
+			t.Fatalf("%s: %s", tt.env, err)
+		}
+		for file, expect := range map[string]string{
+			"app/templates/deployment-web.yaml": "apiVersion: apps/v1\nkind: Deployment\n" + tt.deployment,
+			"app/templates/service-web.yaml":    "apiVersion: v1\nkind: Service\n" + tt.service,
+		} {
+			var got, want map[string]interface{}
+			if err := yaml.Unmarshal([]byte(out[file]), &got); err != nil {
+				t.Fatalf("%s: invalid %s: %s\n%s", tt.env, file, err, out[file])
+			}
+			if err := yaml.Unmarshal([]byte(expect), &want); err != nil {
+				t.Fatal(err)
+			}
+			// Empty common annotations are rendered as null
+			for _, md := range []map[string]interface{}{metadata(got), metadata(template(got))} {
+				if v, ok := md["annotations"]; ok && v == nil {
+					delete(md, "annotations")
+				}
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("%s: expected %s to render\n%v\ngot\n%v", tt.env, file, want, got)
+			}
+		}
+	}
+}
+
+func metadata(r map[string]interface{}) map[string]interface{} {
+	md, _ := r["metadata"].(map[string]interface{})
+	return md
+}
+
+func template(r map[string]interface{}) map[string]interface{} {
+	spec, _ := r["spec"].(map[string]interface{})
+	tpl, _ := spec["template"].(map[string]interface{})
+	return tpl
+}
+
+func TestConvertWithoutBase(t *testing.T) {
+	if _, err := Convert("testdata/app/overlays", "app"); err == nil {
+		t.Error("Expected a tree without a base to fail")
+	}
+}
@@ -0,0 +1,110 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package kustomize converts between kustomize trees and charts, and writes
+kustomization files.
+
+Only the fields of kustomization files that map to values are understood:
+the resources, the name prefix and suffix, the namespace, the common labels
+and annotations, the images and the replicas. Kustomize itself is not needed.
+*/
+package kustomize // import "k8s.io/helm/pkg/kustomize"
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/ghodss/yaml"
+)
+
+// KustomizationAPIVersion is the API version of the kustomization files
+// written by Write.
+const KustomizationAPIVersion = "kustomize.config.k8s.io/v1beta1"
+
+// kustomizationFiles are the names of a kustomization file, in the order
+// kustomize looks for them.
+var kustomizationFiles = []string{"kustomization.yaml", "kustomization.yml", "Kustomization"}
+
+// Kustomization is a kustomization file.
+type Kustomization struct {
+	APIVersion            string            `json:"apiVersion,omitempty"`
+	Kind                  string            `json:"kind,omitempty"`
+	Resources             []string          `json:"resources,omitempty"`
+	Bases                 []string          `json:"bases,omitempty"`
+	Namespace             string            `json:"namespace,omitempty"`
+	NamePrefix            string            `json:"namePrefix,omitempty"`
+	NameSuffix            string            `json:"nameSuffix,omitempty"`
+	CommonLabels          map[string]string `json:"commonLabels,omitempty"`
+	CommonAnnotations     map[string]string `json:"commonAnnotations,omitempty"`
+	Images                []Image           `json:"images,omitempty"`
+	Replicas              []Replicas        `json:"replicas,omitempty"`
+	PatchesStrategicMerge []string          `json:"patchesStrategicMerge,omitempty"`
+	PatchesJSON6902       []interface{}     `json:"patchesJson6902,omitempty"`
+	Patches               []interface{}     `json:"patches,omitempty"`
+	ConfigMapGenerator    []interface{}     `json:"configMapGenerator,omitempty"`
+	SecretGenerator       []interface{}     `json:"secretGenerator,omitempty"`
+}
+
+// Image overrides the name, tag or digest of the images of containers.
+type Image struct {
+	Name    string `json:"name"`
+	NewName string `json:"newName,omitempty"`
+	NewTag  string `json:"newTag,omitempty"`
+	Digest  string `json:"digest,omitempty"`
+}
+
+// Replicas overrides the number of replicas of a resource.
+type Replicas struct {
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+}
+
+// LoadKustomization reads the kustomization file of the directory dir.
+func LoadKustomization(dir string) (*Kustomization, error) {
+	for _, name := range kustomizationFiles {
+		b, err := ioutil.ReadFile(filepath.Join(dir, name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		k := &Kustomization{}
+		if err := yaml.Unmarshal(b, k); err != nil {
+			return nil, fmt.Errorf("cannot load %s: %s", filepath.Join(dir, name), err)
+		}
+		return k, nil
+	}
+	return nil, fmt.Errorf("no kustomization file in %s", dir)
+}
+
+// Write writes k to the kustomization.yaml file of the directory dir.
+func (k *Kustomization) Write(dir string) error {
+	if k.APIVersion == "" {
+		k.APIVersion = KustomizationAPIVersion
+	}
+	if k.Kind == "" {
+		k.Kind = "Kustomization"
+	}
+	b, err := yaml.Marshal(k)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(dir, kustomizationFiles[0]), b, 0644)
+}
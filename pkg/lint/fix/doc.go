@@ -0,0 +1,20 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+/*Package fix corrects the mechanical issues found by the linter in a chart
+directory, like a missing trailing newline in a template, and writes the
+corrected files in place.
+*/
+package fix // import "k8s.io/helm/pkg/lint/fix"
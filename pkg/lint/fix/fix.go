@@ -0,0 +1,198 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fix
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/asaskevich/govalidator"
+
+	"k8s.io/helm/pkg/chartutil"
+)
+
+// Fix is a correction of a file of a chart.
+type Fix struct {
+	// Path is the path of the file, relative to the chart directory.
+	Path string
+	// Description describes the correction.
+	Description string
+}
+
+func (f Fix) String() string {
+	return fmt.Sprintf("[FIXED] %s: %s", f.Path, f.Description)
+}
+
+// All corrects the Chart.yaml, values.yaml and templates of the chart
+// directory dir, and returns the fixes written. Files that cannot be parsed
+// are left to the linter to report.
+func All(dir string) ([]Fix, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	var fixes []Fix
+	for _, fn := range []func(string) ([]Fix, error){Chartfile, Values, Templates} {
+		f, err := fn(dir)
+		if err != nil {
+			return fixes, err
+		}
+		fixes = append(fixes, f...)
+	}
+	return fixes, nil
+}
+
+// Chartfile sets the missing apiVersion and name of the Chart.yaml of the
+// chart directory dir, moves apiVersion to the top, and adds the https scheme
+// to an icon URL without scheme.
+func Chartfile(dir string) ([]Fix, error) {
+	name := filepath.Join(dir, chartutil.ChartfileName)
+	doc, err := chartutil.ReadValuesDocumentFile(name)
+	if err != nil {
+		return nil, nil
+	}
+
+	var fixes []Fix
+	add := func(format string, a ...interface{}) {
+		fixes = append(fixes, Fix{Path: chartutil.ChartfileName, Description: fmt.Sprintf(format, a...)})
+	}
+	if doc.MoveToFront("apiVersion") {
+		add("moved apiVersion to the top")
+	}
+	if v, _, _ := doc.Lookup("apiVersion"); v == nil || v == "" {
+		apiVersion := chartutil.ApiVersionV1
+		// Only charts of apiVersion v2 have a type and dependencies.
+		for _, key := range []string{"type", "dependencies"} {
+			if _, ok, _ := doc.Lookup(key); ok {
+				apiVersion = chartutil.ApiVersionV2
+			}
+		}
+		if err := doc.Set("apiVersion", apiVersion); err != nil {
+			return nil, err
+		}
+		doc.MoveToFront("apiVersion")
+		add("set the missing apiVersion to %s", apiVersion)
+	}
+	if v, _, _ := doc.Lookup("name"); v == nil || v == "" {
+		if err := doc.Set("name", filepath.Base(dir)); err != nil {
+			return nil, err
+		}
+		add("set the missing name to the name of the directory, %s", filepath.Base(dir))
+	}
+	if v, _, _ := doc.Lookup("icon"); v != nil {
+		icon := fmt.Sprint(v)
+		fixed := "https://" + strings.TrimPrefix(icon, "//")
+		if icon != "" && !govalidator.IsRequestURL(icon) && !strings.Contains(icon, "://") && govalidator.IsRequestURL(fixed) {
+			if err := doc.Set("icon", fixed); err != nil {
+				return nil, err
+			}
+			add("added the https scheme to the icon URL")
+		}
+	}
+
+	if len(fixes) == 0 {
+		return nil, nil
+	}
+	return fixes, writeDocument(name, doc)
+}
+
+// Values indents the maps of the values.yaml of the chart directory dir by
+// two spaces.
+func Values(dir string) ([]Fix, error) {
+	name := filepath.Join(dir, chartutil.ValuesfileName)
+	doc, err := chartutil.ReadValuesDocumentFile(name)
+	if err != nil || doc.IndentedBy(2) {
+		return nil, nil
+	}
+	fix := Fix{Path: chartutil.ValuesfileName, Description: "indented by two spaces"}
+	return []Fix{fix}, writeDocument(name, doc)
+}
+
+// templateLabelsRegex matches the label helpers, the named templates whose
+// name ends with "labels", like "mychart.labels", called with 'template'.
+var templateLabelsRegex = regexp.MustCompile(`\{\{(-? *)template ("[^"\n]*[lL]abels")`)
+
+// indentLabelsRegex matches the label helpers included on their own line and
+// indented with 'indent'.
+var indentLabelsRegex = regexp.MustCompile(`(?m)\n\{\{ *include ("[^"\n]*[lL]abels") ([^|{}\n]+?) *\| *indent (\d+) *\}\}$`)
+
+// Templates adds the missing trailing newline of the templates of the chart
+// directory dir, and normalizes the calls of the label helpers: they are
+// included with 'include' instead of 'template', and indented with 'nindent'
+// after the key they are the value of, as in:
+//
+//	metadata:
+//	  labels:
+//	    {{- include "mychart.labels" . | nindent 4 }}
+func Templates(dir string) ([]Fix, error) {
+	templatesDir := filepath.Join(dir, chartutil.TemplatesDir)
+	var fixes []Fix
+	err := filepath.Walk(templatesDir, func(name string, fi os.FileInfo, err error) error {
+		if err != nil {
+			if name == templatesDir && os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if fi.IsDir() || strings.HasPrefix(fi.Name(), ".") {
+			return nil
+		}
+		data, err := ioutil.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, name)
+		rel = filepath.ToSlash(rel)
+
+		content := templateLabelsRegex.ReplaceAllString(string(data), "{{${1}include $2")
+		content = indentLabelsRegex.ReplaceAllStringFunc(content, func(s string) string {
+			m := indentLabelsRegex.FindStringSubmatch(s)
+			n, _ := strconv.Atoi(m[3])
+			return fmt.Sprintf("\n%s{{- include %s %s | nindent %d }}", strings.Repeat(" ", n), m[1], m[2], n)
+		})
+		if content != string(data) {
+			fixes = append(fixes, Fix{Path: rel, Description: "included the label helpers with include and nindent"})
+		}
+		if content != "" && !strings.HasSuffix(content, "\n") {
+			content += "\n"
+			fixes = append(fixes, Fix{Path: rel, Description: "added the missing trailing newline"})
+		}
+		if content == string(data) {
+			return nil
+		}
+		return ioutil.WriteFile(name, []byte(content), fi.Mode())
+	})
+	return fixes, err
+}
+
+// writeDocument writes the document doc to the file name.
+func writeDocument(name string, doc *chartutil.ValuesDocument) error {
+	y, err := doc.YAML()
+	if err != nil {
+		return err
+	}
+	fi, err := os.Stat(name)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(name, []byte(y), fi.Mode())
+}
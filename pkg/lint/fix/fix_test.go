@@ -0,0 +1,151 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fix
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// writeChart writes the files of a chart named mychart to a temporary
+// directory, and returns the directory of the chart.
+func writeChart(t *testing.T, files map[string]string) string {
+	tmp, err := ioutil.TempDir("", "helm-lint-fix-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := filepath.Join(tmp, "mychart")
+	for name, content := range files {
+		name = filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(name, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func readFile(t *testing.T, name string) string {
+	data, err := ioutil.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestAll(t *testing.T) {
+	dir := writeChart(t, map[string]string{
+		"Chart.yaml":  "# The chart.\nversion: 0.1.0\ndependencies:\n- name: redis\nicon: example.com/icon.png\n",
+		"values.yaml": "# The image.\nimage:\n    repository: nginx # The repository.\n    tag: stable\nports:\n- 80\n",
+		"templates/_helpers.tpl": `{{- define "mychart.labels" -}}
+app: {{ .Chart.Name }}
+{{- end -}}`,
+		"templates/service.yaml": `metadata:
+  labels:
+{{ template "mychart.labels" . | indent 4 }}
+spec:
+  selector:
+{{ include "mychart.selectorLabels" (dict "root" .) | indent 4 }}
+  ports:
+{{ toYaml .Values.ports | indent 4 }}
+`,
+	})
+	defer os.RemoveAll(filepath.Dir(dir))
+
+	fixes, err := All(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, f := range fixes {
+		got = append(got, f.String())
+	}
+	expect := []string{
+		"[FIXED] Chart.yaml: set the missing apiVersion to v2",
+		"[FIXED] Chart.yaml: set the missing name to the name of the directory, mychart",
+		"[FIXED] Chart.yaml: added the https scheme to the icon URL",
+		"[FIXED] values.yaml: indented by two spaces",
+		"[FIXED] templates/_helpers.tpl: added the missing trailing newline",
+		"[FIXED] templates/service.yaml: included the label helpers with include and nindent",
+	}
+	if !reflect.DeepEqual(got, expect) {
+		t.Errorf("Expected\n%q\ngot\n%q", expect, got)
+	}
+
+	for name, expect := range map[string]string{
+		"Chart.yaml":  "apiVersion: v2\n# The chart.\nversion: 0.1.0\ndependencies:\n  - name: redis\nicon: https://example.com/icon.png\nname: mychart\n",
+		"values.yaml": "# The image.\nimage:\n  repository: nginx # The repository.\n  tag: stable\nports:\n  - 80\n",
+		"templates/service.yaml": `metadata:
+  labels:
+    {{- include "mychart.labels" . | nindent 4 }}
+spec:
+  selector:
+    {{- include "mychart.selectorLabels" (dict "root" .) | nindent 4 }}
+  ports:
+{{ toYaml .Values.ports | indent 4 }}
+`,
+	} {
+		if got := readFile(t, filepath.Join(dir, name)); got != expect {
+			t.Errorf("Expected %s\n%s\ngot\n%s", name, expect, got)
+		}
+	}
+
+	// The fixed chart has nothing left to fix.
+	if fixes, err := All(dir); err != nil || len(fixes) != 0 {
+		t.Errorf("Expected no fixes, got %v, %v", fixes, err)
+	}
+}
+
+func TestChartfileApiVersionOrder(t *testing.T) {
+	dir := writeChart(t, map[string]string{
+		"Chart.yaml": "name: mychart\nversion: 0.1.0\napiVersion: v1\n",
+	})
+	defer os.RemoveAll(filepath.Dir(dir))
+
+	fixes, err := Chartfile(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := []Fix{{Path: "Chart.yaml", Description: "moved apiVersion to the top"}}
+	if !reflect.DeepEqual(fixes, expect) {
+		t.Errorf("Expected %v, got %v", expect, fixes)
+	}
+	if got := readFile(t, filepath.Join(dir, "Chart.yaml")); got != "apiVersion: v1\nname: mychart\nversion: 0.1.0\n" {
+		t.Errorf("Unexpected Chart.yaml\n%s", got)
+	}
+}
+
+func TestAllLeavesInvalidFiles(t *testing.T) {
+	dir := writeChart(t, map[string]string{
+		"Chart.yaml":  "name: [mychart\n",
+		"values.yaml": "- not a map\n",
+	})
+	defer os.RemoveAll(filepath.Dir(dir))
+
+	fixes, err := All(dir)
+	if err != nil || len(fixes) != 0 {
+		t.Errorf("Expected no fixes, got %v, %v", fixes, err)
+	}
+	if got := readFile(t, filepath.Join(dir, "Chart.yaml")); got != "name: [mychart\n" {
+		t.Errorf("Expected Chart.yaml to be left alone, got\n%s", got)
+	}
+}
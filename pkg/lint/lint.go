@@ -19,18 +19,26 @@ package lint // import "k8s.io/helm/pkg/lint"
 import (
 	"path/filepath"
 
+	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/lint/rules"
 	"k8s.io/helm/pkg/lint/support"
 )
 
 // All runs all of the available linters on the given base directory.
 func All(basedir string, values []byte, namespace string, strict bool) support.Linter {
+	return AllWithRules(basedir, values, namespace, strict, nil)
+}
+
+// AllWithRules runs all of the available linters, and the custom rules, on the
+// given base directory, loaded with the options opts.
+func AllWithRules(basedir string, values []byte, namespace string, strict bool, custom []support.Rule, opts ...chartutil.LoadOption) support.Linter {
 	// Using abs path to get directory context
 	chartDir, _ := filepath.Abs(basedir)
 
-	linter := support.Linter{ChartDir: chartDir}
+	linter := support.Linter{ChartDir: chartDir, LoadOptions: opts}
 	rules.Chartfile(&linter)
 	rules.Values(&linter)
 	rules.Templates(&linter, values, namespace, strict)
+	rules.Custom(&linter, custom, values, namespace)
 	return linter
 }
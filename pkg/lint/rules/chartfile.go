@@ -52,6 +52,8 @@ func Chartfile(linter *support.Linter) {
 
 	// Chart metadata
 	linter.RunLinterRule(support.ErrorSev, chartFileName, validateChartAPIVersion(chartFile))
+	linter.RunLinterRule(support.WarningSev, chartFileName, validateChartTypeAPIVersion(chartFile))
+	linter.RunLinterRule(support.ErrorSev, chartFileName, validateChartType(chartFile))
 	linter.RunLinterRule(support.ErrorSev, chartFileName, validateChartVersion(chartFile))
 	linter.RunLinterRule(support.ErrorSev, chartFileName, validateChartEngine(chartFile))
 	linter.RunLinterRule(support.ErrorSev, chartFileName, validateChartMaintainer(chartFile))
@@ -102,13 +104,30 @@ func validateChartAPIVersion(cf *chart.Metadata) error {
 		return errors.New("apiVersion is required")
 	}
 
-	if cf.ApiVersion != "v1" {
-		return fmt.Errorf("apiVersion '%s' is not valid. The value must be \"v1\"", cf.ApiVersion)
+	if cf.ApiVersion != chartutil.ApiVersionV1 && cf.ApiVersion != chartutil.ApiVersionV2 {
+		return fmt.Errorf("apiVersion '%s' is not valid. The value must be \"v1\" or \"v2\"", cf.ApiVersion)
 	}
 
 	return nil
 }
 
+func validateChartTypeAPIVersion(cf *chart.Metadata) error {
+	if cf.Type != "" && cf.ApiVersion != chartutil.ApiVersionV2 {
+		return errors.New("type is only supported by charts with apiVersion v2 and is ignored")
+	}
+	return nil
+}
+
+func validateChartType(cf *chart.Metadata) error {
+	if cf.Type == "" || cf.ApiVersion != chartutil.ApiVersionV2 {
+		return nil
+	}
+	if cf.Type != chartutil.ChartTypeApplication && cf.Type != chartutil.ChartTypeLibrary {
+		return fmt.Errorf("type '%s' is not valid. The value must be \"application\" or \"library\"", cf.Type)
+	}
+	return nil
+}
+
 func validateChartVersion(cf *chart.Metadata) error {
 	if cf.Version == "" {
 		return errors.New("version is required")
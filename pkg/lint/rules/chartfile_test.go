@@ -148,6 +148,39 @@ func TestValidateChartEngine(t *testing.T) {
 	}
 }
 
+func TestValidateChartAPIVersion(t *testing.T) {
+	for _, apiVersion := range []string{"v1", "v2"} {
+		err := validateChartAPIVersion(&chart.Metadata{ApiVersion: apiVersion})
+		if err != nil {
+			t.Errorf("validateChartAPIVersion(%s) to return no error, got a linter error %s", apiVersion, err.Error())
+		}
+	}
+
+	err := validateChartAPIVersion(&chart.Metadata{ApiVersion: "v3"})
+	if err == nil || !strings.Contains(err.Error(), "apiVersion 'v3' is not valid") {
+		t.Errorf("validateChartAPIVersion(v3) to return an error, got %v", err)
+	}
+}
+
+func TestValidateChartType(t *testing.T) {
+	for _, chartType := range []string{"", "application", "library"} {
+		err := validateChartType(&chart.Metadata{ApiVersion: "v2", Type: chartType})
+		if err != nil {
+			t.Errorf("validateChartType(%s) to return no error, got a linter error %s", chartType, err.Error())
+		}
+	}
+
+	err := validateChartType(&chart.Metadata{ApiVersion: "v2", Type: "plugin"})
+	if err == nil || !strings.Contains(err.Error(), "type 'plugin' is not valid") {
+		t.Errorf("validateChartType(plugin) to return an error, got %v", err)
+	}
+
+	err = validateChartTypeAPIVersion(&chart.Metadata{ApiVersion: "v1", Type: "library"})
+	if err == nil || !strings.Contains(err.Error(), "only supported by charts with apiVersion v2") {
+		t.Errorf("validateChartTypeAPIVersion to return an error for a v1 chart, got %v", err)
+	}
+}
+
 func TestValidateChartMaintainer(t *testing.T) {
 	var failTest = []struct {
 		Name     string
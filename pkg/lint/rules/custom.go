@@ -0,0 +1,142 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/engine"
+	"k8s.io/helm/pkg/lint/support"
+	cpb "k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/renderutil"
+	"k8s.io/helm/pkg/timeconv"
+)
+
+// Custom runs the custom rules on the chart of the Linter, rendered with the
+// values and in the namespace, in every environment of the chart.
+func Custom(linter *support.Linter, custom []support.Rule, values []byte, namespace string) {
+	if len(custom) == 0 {
+		return
+	}
+	in, err := ruleInput(linter.ChartDir, values, namespace, linter.LoadOptions...)
+	if err != nil {
+		// Reported by the other rules.
+		return
+	}
+	for _, rule := range custom {
+		msgs, err := rule.Lint(in)
+		if err != nil {
+			linter.RunLinterRule(support.ErrorSev, chartutil.ChartfileName, fmt.Errorf("rule %s failed: %s", rule.Name(), err))
+			continue
+		}
+		for _, msg := range msgs {
+			if msg.Err == nil {
+				continue
+			}
+			linter.RunLinterRule(msg.Severity, msg.Path, fmt.Errorf("%s: %s", rule.Name(), msg.Err))
+		}
+	}
+}
+
+// ruleInput returns the input of the custom rules for the chart in chartDir,
+// loaded with the options opts.
+func ruleInput(chartDir string, values []byte, namespace string, opts ...chartutil.LoadOption) (*support.RuleInput, error) {
+	c, err := chartutil.Load(chartDir, opts...)
+	if err != nil {
+		return nil, err
+	}
+	vals, err := chartutil.CoalesceValues(c, &cpb.Config{Raw: string(values)})
+	if err != nil {
+		return nil, err
+	}
+	in := &support.RuleInput{
+		ChartDir:  chartDir,
+		Chart:     c,
+		Metadata:  c.Metadata,
+		Values:    vals,
+		Templates: map[string]*template.Template{},
+		Sources:   map[string]string{},
+	}
+	parseTemplates(in, c, "")
+
+	envFiles, err := environmentValuesFiles(chartDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, env := range append([]string{""}, envFiles...) {
+		manifests, err := renderEnvironment(chartDir, env, values, namespace, opts...)
+		if err != nil {
+			continue
+		}
+		in.Environments = append(in.Environments, support.RuleEnvironment{Name: env, Manifests: manifests})
+	}
+	return in, nil
+}
+
+// parseTemplates parses the Go templates of the chart c, and of its
+// subcharts, in the directory dir of the chart linted.
+func parseTemplates(in *support.RuleInput, c *cpb.Chart, dir string) {
+	for _, t := range c.Templates {
+		name := path.Join(dir, t.Name)
+		switch path.Ext(name) {
+		case engine.LuaExtension, engine.JsonnetExtension, engine.JsonnetLibraryExtension:
+			continue
+		}
+		in.Sources[name] = string(t.Data)
+		if tpl, err := template.New(name).Funcs(engine.FuncMap()).Parse(string(t.Data)); err == nil {
+			in.Templates[name] = tpl
+		}
+	}
+	for _, d := range c.Dependencies {
+		parseTemplates(in, d, path.Join(dir, "charts", d.Metadata.Name))
+	}
+}
+
+// renderEnvironment renders the chart in chartDir with the environment values
+// file env, and returns the manifests by path in the chart.
+func renderEnvironment(chartDir, env string, values []byte, namespace string, opts ...chartutil.LoadOption) (map[string]string, error) {
+	c, err := chartutil.LoadWithEnvValuesFile(chartDir, env, opts...)
+	if err != nil {
+		return nil, err
+	}
+	rendered, err := renderutil.Render(c, &cpb.Config{Raw: string(values)}, renderutil.Options{
+		ReleaseOptions: chartutil.ReleaseOptions{
+			Name:        "testRelease",
+			Time:        timeconv.Now(),
+			Namespace:   namespace,
+			IsInstall:   true,
+			Environment: env,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	manifests := map[string]string{}
+	prefix := c.Metadata.Name + "/"
+	for name, content := range rendered {
+		if b := filepath.Base(name); b == "NOTES.txt" || strings.HasPrefix(b, "_") {
+			continue
+		}
+		manifests[strings.TrimPrefix(name, prefix)] = content
+	}
+	return manifests, nil
+}
@@ -0,0 +1,166 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/lint/support"
+)
+
+// replicasRule reports the last line of the ConfigMap rendered in every
+// environment, the replicas.
+type replicasRule struct {
+	in *support.RuleInput
+}
+
+func (r *replicasRule) Name() string { return "replicas" }
+
+func (r *replicasRule) Lint(in *support.RuleInput) ([]support.Message, error) {
+	r.in = in
+	var msgs []support.Message
+	for _, env := range in.Environments {
+		name := env.Name
+		if name == "" {
+			name = "values.yaml"
+		}
+		lines := strings.Split(strings.TrimSpace(env.Manifests["templates/configmap.yaml"]), "\n")
+		last := strings.TrimSpace(lines[len(lines)-1])
+		msgs = append(msgs, support.NewMessage(support.InfoSev, "templates/configmap.yaml", errors.New(name+": "+last)))
+	}
+	return msgs, nil
+}
+
+type failingRule struct{}
+
+func (failingRule) Name() string { return "failing" }
+
+func (failingRule) Lint(in *support.RuleInput) ([]support.Message, error) {
+	return nil, errors.New("cannot reach the policy server")
+}
+
+func TestCustom(t *testing.T) {
+	chartDir, _ := filepath.Abs(envDriftChartDir)
+	linter := support.Linter{ChartDir: chartDir}
+	rule := &replicasRule{}
+	Custom(&linter, []support.Rule{rule, failingRule{}}, nil, "default")
+
+	expect := []string{
+		`[INFO] templates/configmap.yaml: replicas: values.yaml: replicas: "1"`,
+		`[INFO] templates/configmap.yaml: replicas: values-prod.yaml: replicas: "3"`,
+		`[INFO] templates/configmap.yaml: replicas: values-staging.yml: replicas: "1"`,
+		"[ERROR] Chart.yaml: rule failing failed: cannot reach the policy server",
+	}
+	var got []string
+	for _, m := range linter.Messages {
+		got = append(got, m.Error())
+	}
+	if !reflect.DeepEqual(got, expect) {
+		t.Errorf("Expected\n%q\ngot\n%q", expect, got)
+	}
+
+	in := rule.in
+	if in.Metadata.Name != "envdrift" || in.Values["replicas"] != float64(1) {
+		t.Errorf("Unexpected chart %v with values %v", in.Metadata, in.Values)
+	}
+	tpl := in.Templates["templates/configmap.yaml"]
+	if tpl == nil || in.Sources["templates/configmap.yaml"] == "" {
+		t.Fatalf("Expected the template templates/configmap.yaml, got %v", in.Templates)
+	}
+	if !strings.Contains(tpl.Tree.Root.String(), "{{.Values.replicas | quote}}") {
+		t.Errorf("Unexpected parsed template\n%s", tpl.Tree.Root)
+	}
+}
+
+func TestExecRule(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("rule bundles are shell scripts")
+	}
+	dir, err := ioutil.TempDir("", "helm-lint-rules-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// The bundle requires the chart to be named envdrift, and the manifests
+	// of every environment.
+	script := `#!/bin/sh
+test "$1" = lint || exit 1
+input=$(cat)
+case "$input" in
+*'"name":"envdrift"'*'"name":"values-prod.yaml","manifests":{"templates/configmap.yaml":'*) ;;
+*) echo "unexpected input" >&2; exit 1 ;;
+esac
+echo '[{"severity": "warning", "path": "templates/configmap.yaml", "message": "missing label team"}]'
+`
+	if err := ioutil.WriteFile(filepath.Join(dir, "labels"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "README.md"), []byte("The rules of the team."), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	custom, err := LoadRules(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(custom) != 1 || custom[0].Name() != "labels" {
+		t.Fatalf("Expected the rule labels, got %v", custom)
+	}
+
+	chartDir, _ := filepath.Abs(envDriftChartDir)
+	linter := support.Linter{ChartDir: chartDir}
+	Custom(&linter, custom, nil, "default")
+	expect := []string{"[WARNING] templates/configmap.yaml: labels: missing label team"}
+	var got []string
+	for _, m := range linter.Messages {
+		got = append(got, m.Error())
+	}
+	if !reflect.DeepEqual(got, expect) {
+		t.Errorf("Expected\n%q\ngot\n%q", expect, got)
+	}
+	if linter.HighestSeverity != support.WarningSev {
+		t.Errorf("Expected the severity %d, got %d", support.WarningSev, linter.HighestSeverity)
+	}
+}
+
+func TestPolicyRule(t *testing.T) {
+	chartDir, _ := filepath.Abs(envDriftChartDir)
+	linter := support.Linter{ChartDir: chartDir}
+	policy := &chartutil.MetadataPolicy{RequireMaintainers: true, Licenses: []string{"MIT"}}
+	Custom(&linter, []support.Rule{&PolicyRule{Policy: policy}}, nil, "default")
+
+	expect := []string{
+		"[ERROR] Chart.yaml: policy: the chart must have a maintainer",
+		"[ERROR] Chart.yaml: policy: the chart must state its license in the annotation helm.sh/license, one of MIT",
+	}
+	var got []string
+	for _, m := range linter.Messages {
+		got = append(got, m.Error())
+	}
+	if !reflect.DeepEqual(got, expect) {
+		t.Errorf("Expected\n%q\ngot\n%q", expect, got)
+	}
+}
@@ -0,0 +1,157 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"plugin"
+	"strings"
+
+	"k8s.io/helm/pkg/lint/support"
+)
+
+// RulesSymbol is the symbol of the custom rules in a Go plugin: a function
+// of type func() []support.Rule returning the rules of the plugin.
+const RulesSymbol = "Rules"
+
+// LoadRules loads the custom rules of path, which is either a Go plugin,
+// whose extension is .so, an executable rule bundle run as an ExecRule, or a
+// directory of them.
+//
+// Go plugins are built with 'go build -buildmode=plugin' against the sources
+// of the version of Helm loading them, and can only be loaded on the systems
+// supporting them, like Linux and macOS.
+func LoadRules(path string) ([]support.Rule, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !fi.IsDir() {
+		return loadRulesFile(path)
+	}
+
+	files, err := ioutil.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	var rules []support.Rule
+	for _, f := range files {
+		if f.IsDir() || strings.HasPrefix(f.Name(), ".") {
+			continue
+		}
+		// Skip the READMEs and the data files of the rule bundles.
+		if filepath.Ext(f.Name()) != ".so" && f.Mode()&0111 == 0 {
+			continue
+		}
+		r, err := loadRulesFile(filepath.Join(path, f.Name()))
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, r...)
+	}
+	return rules, nil
+}
+
+// loadRulesFile loads the custom rules of the Go plugin or rule bundle file.
+func loadRulesFile(file string) ([]support.Rule, error) {
+	if filepath.Ext(file) != ".so" {
+		return []support.Rule{&ExecRule{Program: file}}, nil
+	}
+	p, err := plugin.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load the rules of %s: %s", file, err)
+	}
+	sym, err := p.Lookup(RulesSymbol)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load the rules of %s: %s", file, err)
+	}
+	rules, ok := sym.(func() []support.Rule)
+	if !ok {
+		return nil, fmt.Errorf("cannot load the rules of %s: %s is a %T, not a func() []support.Rule", file, RulesSymbol, sym)
+	}
+	return rules(), nil
+}
+
+// ExecRule is a rule bundle run as a program, written in any language. The
+// program is run as "<program> lint", with the RuleInput of the chart in JSON
+// on its standard input, without the parsed templates. It prints the
+// messages of its rules on its standard output, as a JSON list of objects
+// with the severity, "info", "warning" or "error", the path of the file in
+// the chart and the message:
+//
+//	[{"severity": "error", "path": "templates/deployment.yaml", "message": "no memory limit"}]
+//
+// The program exits with a non-zero status when it fails, with the reason
+// on its standard error.
+type ExecRule struct {
+	// Program is the path of the program.
+	Program string
+}
+
+// execMessage is a message printed by an ExecRule.
+type execMessage struct {
+	Severity string `json:"severity"`
+	Path     string `json:"path"`
+	Message  string `json:"message"`
+}
+
+// Name returns the file name of the program.
+func (r *ExecRule) Name() string {
+	return filepath.Base(r.Program)
+}
+
+// Lint runs the program with in.
+func (r *ExecRule) Lint(in *support.RuleInput) ([]support.Message, error) {
+	input, err := json.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+	cmd := exec.Command(r.Program, "lint")
+	cmd.Stdin = bytes.NewReader(input)
+	out, err := cmd.Output()
+	if err != nil {
+		msg := err.Error()
+		if ee, ok := err.(*exec.ExitError); ok && len(ee.Stderr) > 0 {
+			msg = strings.TrimSpace(string(ee.Stderr))
+		}
+		return nil, errors.New(msg)
+	}
+
+	if len(bytes.TrimSpace(out)) == 0 {
+		return nil, nil
+	}
+	var printed []execMessage
+	if err := json.Unmarshal(out, &printed); err != nil {
+		return nil, fmt.Errorf("cannot decode the output of %s: %s", r.Program, err)
+	}
+	msgs := make([]support.Message, 0, len(printed))
+	for _, m := range printed {
+		severity, err := support.ParseSeverity(m.Severity)
+		if err != nil {
+			return nil, fmt.Errorf("invalid output of %s: %s", r.Program, err)
+		}
+		msgs = append(msgs, support.NewMessage(severity, m.Path, errors.New(m.Message)))
+	}
+	return msgs, nil
+}
@@ -0,0 +1,43 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/lint/support"
+)
+
+// PolicyRule is the Rule reporting the violations of a metadata policy by the
+// Chart.yaml of the chart as errors.
+type PolicyRule struct {
+	// Policy is the metadata policy.
+	Policy *chartutil.MetadataPolicy
+}
+
+// Name returns "policy".
+func (r *PolicyRule) Name() string {
+	return "policy"
+}
+
+// Lint returns the violations of the policy by the chart of in.
+func (r *PolicyRule) Lint(in *support.RuleInput) ([]support.Message, error) {
+	var msgs []support.Message
+	for _, err := range r.Policy.Violations(in.Metadata) {
+		msgs = append(msgs, support.NewMessage(support.ErrorSev, chartutil.ChartfileName, err))
+	}
+	return msgs, nil
+}
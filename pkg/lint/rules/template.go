@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/ghodss/yaml"
 	"k8s.io/helm/pkg/chartutil"
@@ -44,7 +45,7 @@ func Templates(linter *support.Linter, values []byte, namespace string, strict b
 	}
 
 	// Load chart and parse templates, based on tiller/release_server
-	chart, err := chartutil.Load(linter.ChartDir)
+	chart, err := chartutil.Load(linter.ChartDir, linter.LoadOptions...)
 
 	chartLoaded := linter.RunLinterRule(support.ErrorSev, path, err)
 
@@ -52,6 +53,8 @@ func Templates(linter *support.Linter, values []byte, namespace string, strict b
 		return
 	}
 
+	linter.RunLinterRule(support.WarningSev, path, validateNoNamedTemplateCollisions(chart))
+
 	options := chartutil.ReleaseOptions{Name: "testRelease", Time: timeconv.Now(), Namespace: namespace}
 	caps := &chartutil.Capabilities{
 		APIVersions:   chartutil.DefaultVersionSet,
@@ -101,8 +104,9 @@ func Templates(linter *support.Linter, values []byte, namespace string, strict b
 
 		linter.RunLinterRule(support.WarningSev, path, validateAllowedExtension(fileName))
 
-		// We only apply the following lint rules to yaml files
-		if filepath.Ext(fileName) != ".yaml" || filepath.Ext(fileName) == ".yml" {
+		// We only apply the following lint rules to yaml files, and to the
+		// Lua and Jsonnet templates rendering yaml
+		if ext := filepath.Ext(fileName); ext != ".yaml" && ext != engine.LuaExtension && ext != engine.JsonnetExtension {
 			continue
 		}
 
@@ -121,6 +125,19 @@ func Templates(linter *support.Linter, values []byte, namespace string, strict b
 }
 
 // Validation functions
+func validateNoNamedTemplateCollisions(chart *cpb.Chart) error {
+	collisions := engine.NamedTemplateCollisions(chart, engine.IsolationEnabled(chart))
+	if len(collisions) == 0 {
+		return nil
+	}
+	msgs := make([]string, 0, len(collisions))
+	for _, c := range collisions {
+		msgs = append(msgs, fmt.Sprintf("%q is defined in %s, only the definition in %s is used",
+			c.Name, strings.Join(c.Files, ", "), c.Files[len(c.Files)-1]))
+	}
+	return fmt.Errorf("named template collision: %s", strings.Join(msgs, "; "))
+}
+
 func validateTemplatesDir(templatesPath string) error {
 	if fi, err := os.Stat(templatesPath); err != nil {
 		return errors.New("directory not found")
@@ -132,7 +149,7 @@ func validateTemplatesDir(templatesPath string) error {
 
 func validateAllowedExtension(fileName string) error {
 	ext := filepath.Ext(fileName)
-	validExtensions := []string{".yaml", ".yml", ".tpl", ".txt"}
+	validExtensions := []string{".yaml", ".yml", ".tpl", ".txt", ".lua", ".jsonnet", ".libsonnet"}
 
 	for _, b := range validExtensions {
 		if b == ext {
@@ -140,7 +157,7 @@ func validateAllowedExtension(fileName string) error {
 		}
 	}
 
-	return fmt.Errorf("file extension '%s' not valid. Valid extensions are .yaml, .yml, .tpl, or .txt", ext)
+	return fmt.Errorf("file extension '%s' not valid. Valid extensions are .yaml, .yml, .tpl, .txt, .lua, .jsonnet, or .libsonnet", ext)
 }
 
 func validateYamlContent(err error) error {
@@ -23,6 +23,7 @@ import (
 	"testing"
 
 	"k8s.io/helm/pkg/lint/support"
+	cpb "k8s.io/helm/pkg/proto/hapi/chart"
 )
 
 const (
@@ -35,11 +36,11 @@ func TestValidateAllowedExtension(t *testing.T) {
 	var failTest = []string{"/foo", "/test.toml"}
 	for _, test := range failTest {
 		err := validateAllowedExtension(test)
-		if err == nil || !strings.Contains(err.Error(), "Valid extensions are .yaml, .yml, .tpl, or .txt") {
-			t.Errorf("validateAllowedExtension('%s') to return \"Valid extensions are .yaml, .yml, .tpl, or .txt\", got no error", test)
+		if err == nil || !strings.Contains(err.Error(), "Valid extensions are .yaml, .yml, .tpl, .txt, .lua, .jsonnet, or .libsonnet") {
+			t.Errorf("validateAllowedExtension('%s') to return \"Valid extensions are .yaml, .yml, .tpl, .txt, .lua, .jsonnet, or .libsonnet\", got no error", test)
 		}
 	}
-	var successTest = []string{"/foo.yaml", "foo.yaml", "foo.tpl", "/foo/bar/baz.yaml", "NOTES.txt"}
+	var successTest = []string{"/foo.yaml", "foo.yaml", "foo.tpl", "/foo/bar/baz.yaml", "NOTES.txt", "configmap.lua", "deployment.jsonnet", "lib.libsonnet"}
 	for _, test := range successTest {
 		err := validateAllowedExtension(test)
 		if err != nil {
@@ -82,3 +83,30 @@ func TestTemplateIntegrationHappyPath(t *testing.T) {
 		t.Fatalf("Expected no error, got %d, %v", len(res), res)
 	}
 }
+
+func TestValidateNoNamedTemplateCollisions(t *testing.T) {
+	ch := &cpb.Chart{
+		Metadata: &cpb.Metadata{Name: "outer"},
+		Templates: []*cpb.Template{
+			{Name: "templates/_helpers.tpl", Data: []byte(`{{define "fullname"}}outer{{end}}`)},
+		},
+		Dependencies: []*cpb.Chart{
+			{
+				Metadata: &cpb.Metadata{Name: "inner"},
+				Templates: []*cpb.Template{
+					{Name: "templates/_helpers.tpl", Data: []byte(`{{define "fullname"}}inner{{end}}`)},
+				},
+			},
+		},
+	}
+
+	err := validateNoNamedTemplateCollisions(ch)
+	if err == nil || !strings.Contains(err.Error(), `"fullname" is defined in outer/charts/inner/templates/_helpers.tpl, outer/templates/_helpers.tpl`) {
+		t.Errorf("Expected a collision on fullname, got %v", err)
+	}
+
+	ch.Metadata.Annotations = map[string]string{"helm.sh/template-isolation": "true"}
+	if err := validateNoNamedTemplateCollisions(ch); err != nil {
+		t.Errorf("Expected no collision with template isolation, got %s", err)
+	}
+}
@@ -0,0 +1,5 @@
+replicas: int & >0
+image: {
+	repository: string
+	pullPolicy: *"IfNotPresent" | "Always" | "Never"
+}
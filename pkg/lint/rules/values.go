@@ -17,12 +17,17 @@ limitations under the License.
 package rules
 
 import (
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/lint/support"
+	"k8s.io/helm/pkg/timeconv"
 )
 
 // Values lints a chart's values.yaml file.
@@ -35,7 +40,163 @@ func Values(linter *support.Linter) {
 		return
 	}
 
-	linter.RunLinterRule(support.ErrorSev, file, validateValuesFile(linter, vf))
+	if !linter.RunLinterRule(support.ErrorSev, file, validateValuesFile(linter, vf)) {
+		return
+	}
+
+	envFiles, err := environmentValuesFiles(linter.ChartDir)
+	if err != nil {
+		linter.RunLinterRule(support.ErrorSev, file, err)
+		return
+	}
+	base, _ := chartutil.ReadValuesFile(vf)
+	for _, envFile := range envFiles {
+		env, err := readEnvironmentValues(linter.ChartDir, envFile, base)
+		if !linter.RunLinterRule(support.ErrorSev, envFile, validateEnvironmentValuesFile(err)) {
+			continue
+		}
+		for _, drift := range valuesTypeDrift(base, env, file, envFile) {
+			linter.RunLinterRule(support.WarningSev, envFile, drift)
+		}
+	}
+
+	// Validate the values, and the values of every environment, against the
+	// CUE schema of the chart, if any.
+	schema, err := ioutil.ReadFile(filepath.Join(linter.ChartDir, chartutil.ValuesSchemaFile))
+	if err != nil {
+		return
+	}
+	linter.RunLinterRule(support.ErrorSev, file, validateValuesSchema(schema, base))
+	for _, envFile := range envFiles {
+		vals, _ := chartutil.ReadValuesFile(vf)
+		env, err := readEnvironmentValues(linter.ChartDir, envFile, vals)
+		if err != nil {
+			continue
+		}
+		linter.RunLinterRule(support.ErrorSev, envFile, validateValuesSchema(schema, chartutil.MergeValues(vals, env)))
+	}
+}
+
+// validateValuesSchema validates the values vals against the CUE schema
+// schema.
+func validateValuesSchema(schema []byte, vals chartutil.Values) error {
+	if _, err := chartutil.ValidateValuesAgainstSchema(schema, vals); err != nil {
+		return fmt.Errorf("values don't meet the specifications of %s:\n\t%s", chartutil.ValuesSchemaFile, strings.Replace(err.Error(), "\n", "\n\t", -1))
+	}
+	return nil
+}
+
+// environmentValuesFiles returns the environment values files of the chart
+// in chartDir: the YAML files next to values.yaml whose name starts with
+// "values", like values-prod.yaml, and the YAML files of the environments
+// directory, like environments/prod.yaml.
+func environmentValuesFiles(chartDir string) ([]string, error) {
+	var files []string
+	for _, pattern := range []string{"values?*.yaml", "values?*.yml", "environments/*.yaml", "environments/*.yml"} {
+		matches, err := filepath.Glob(filepath.Join(chartDir, pattern))
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range matches {
+			if name, _ := filepath.Rel(chartDir, m); name != "values.yaml" {
+				files = append(files, filepath.ToSlash(name))
+			}
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// readEnvironmentValues reads the environment values file envFile of the
+// chart in chartDir, whose values are base. A templated environment values
+// file is rendered for a test release.
+func readEnvironmentValues(chartDir, envFile string, base chartutil.Values) (chartutil.Values, error) {
+	data, err := ioutil.ReadFile(filepath.Join(chartDir, envFile))
+	if err != nil {
+		return nil, err
+	}
+	if !chartutil.IsTemplatedEnvironment(data) {
+		return chartutil.ReadValues(data)
+	}
+	metadata, err := chartutil.LoadChartfile(filepath.Join(chartDir, "Chart.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	options := chartutil.ReleaseOptions{
+		Name:        "testRelease",
+		Time:        timeconv.Now(),
+		Namespace:   "default",
+		IsInstall:   true,
+		Environment: envFile,
+	}
+	return chartutil.RenderEnvironment(envFile, data, metadata, base, options)
+}
+
+func validateEnvironmentValuesFile(err error) error {
+	if err != nil {
+		return fmt.Errorf("unable to parse YAML\n\t%s", err)
+	}
+	return nil
+}
+
+// valuesTypeDrift compares the types of the values of the environment values
+// file envFile with the types of the same values in the values file file,
+// and returns the values of different types. The types of the items of lists
+// are compared by index. Null values, often placeholders, are not compared.
+func valuesTypeDrift(base, env chartutil.Values, file, envFile string) []error {
+	var drifts []error
+	var walk func(path string, b, e interface{})
+	walk = func(path string, b, e interface{}) {
+		bt, et := valueType(b), valueType(e)
+		if bt == "null" || et == "null" {
+			return
+		}
+		if bt != et {
+			drifts = append(drifts, fmt.Errorf("%s is %s in %s but %s in %s, which may break the templates in one of them", path, bt, file, et, envFile))
+			return
+		}
+		switch bt {
+		case "a table":
+			bm, em := b.(map[string]interface{}), e.(map[string]interface{})
+			keys := make([]string, 0, len(em))
+			for k := range em {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				if bv, ok := bm[k]; ok {
+					walk(chartutil.JoinPath(path, k), bv, em[k])
+				}
+			}
+		case "a list":
+			bl, el := b.([]interface{}), e.([]interface{})
+			for i := 0; i < len(bl) && i < len(el); i++ {
+				walk(fmt.Sprintf("%s[%d]", path, i), bl[i], el[i])
+			}
+		}
+	}
+	walk("", map[string]interface{}(base), map[string]interface{}(env))
+	return drifts
+}
+
+// valueType returns the type of a value read from a values file, with an
+// article.
+func valueType(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "a string"
+	case bool:
+		return "a boolean"
+	case json.Number, float64, int, int64:
+		return "a number"
+	case []interface{}:
+		return "a list"
+	case map[string]interface{}:
+		return "a table"
+	}
+	return fmt.Sprintf("a %T", v)
 }
 
 func validateValuesFileExistence(linter *support.Linter, valuesPath string) error {
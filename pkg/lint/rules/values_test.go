@@ -0,0 +1,89 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/helm/pkg/lint/support"
+)
+
+const envDriftChartDir = "testdata/envdrift"
+
+func TestEnvironmentValuesFiles(t *testing.T) {
+	files, err := environmentValuesFiles(envDriftChartDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := []string{"values-prod.yaml", "values-staging.yml"}
+	if !reflect.DeepEqual(files, expect) {
+		t.Errorf("Expected %v, got %v", expect, files)
+	}
+}
+
+func TestValuesTypeDrift(t *testing.T) {
+	chartDir, _ := filepath.Abs(envDriftChartDir)
+	linter := support.Linter{ChartDir: chartDir}
+	Values(&linter)
+
+	expect := []string{
+		"[WARNING] values-prod.yaml: image.tag is a string in values.yaml but a number in values-prod.yaml, which may break the templates in one of them",
+		"[WARNING] values-prod.yaml: nodeSelector is a table in values.yaml but a string in values-prod.yaml, which may break the templates in one of them",
+		"[WARNING] values-prod.yaml: replicas is a number in values.yaml but a string in values-prod.yaml, which may break the templates in one of them",
+		"[WARNING] values-staging.yml: image is a table in values.yaml but a string in values-staging.yml, which may break the templates in one of them",
+		"[WARNING] values-staging.yml: ports[0] is a number in values.yaml but a string in values-staging.yml, which may break the templates in one of them",
+	}
+	var got []string
+	for _, m := range linter.Messages {
+		got = append(got, m.Error())
+	}
+	if !reflect.DeepEqual(got, expect) {
+		t.Errorf("Expected\n%q\ngot\n%q", expect, got)
+	}
+	if linter.HighestSeverity != support.WarningSev {
+		t.Errorf("Expected the highest severity to be a warning, got %d", linter.HighestSeverity)
+	}
+}
+
+func TestValuesTypeDriftGoodChart(t *testing.T) {
+	linter := support.Linter{ChartDir: goodChartDir}
+	Values(&linter)
+	if len(linter.Messages) != 0 {
+		t.Errorf("Expected no messages, got %v", linter.Messages)
+	}
+}
+
+func TestValuesSchema(t *testing.T) {
+	chartDir, _ := filepath.Abs("testdata/cueschema")
+	linter := support.Linter{ChartDir: chartDir}
+	Values(&linter)
+
+	var files []string
+	for _, m := range linter.Messages {
+		if m.Severity != support.ErrorSev || !strings.Contains(m.Err.Error(), "values don't meet the specifications of values.cue") {
+			t.Errorf("Unexpected message %v", m)
+		}
+		files = append(files, m.Path)
+	}
+	expect := []string{"environments/qa.yaml", "values-dev.yaml", "values-prod.yaml"}
+	if !reflect.DeepEqual(files, expect) {
+		t.Errorf("Expected schema errors in %v, got %v", expect, files)
+	}
+}
@@ -16,7 +16,11 @@ limitations under the License.
 
 package support
 
-import "fmt"
+import (
+	"fmt"
+
+	"k8s.io/helm/pkg/chartutil"
+)
 
 // Severity indicates the severity of a Message.
 const (
@@ -39,6 +43,8 @@ type Linter struct {
 	// The highest severity of all the failing lint rules
 	HighestSeverity int
 	ChartDir        string
+	// The options the chart directory is loaded with
+	LoadOptions []chartutil.LoadOption
 }
 
 // Message describes an error encountered while linting.
@@ -0,0 +1,81 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package support
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+// Rule is a custom lint rule, enforcing the conventions of an organization
+// like required labels or resource limits. Rules are compiled in Go plugins,
+// or run as programs by an ExecRule, and run by 'helm lint --rules'.
+type Rule interface {
+	// Name is the name of the rule, prefixed to its messages.
+	Name() string
+	// Lint returns the messages of the rule for the chart of in. An error is
+	// a failure of the rule itself, reported as an [ERROR].
+	Lint(in *RuleInput) ([]Message, error)
+}
+
+// RuleInput is the chart linted by a Rule.
+type RuleInput struct {
+	// ChartDir is the directory of the chart.
+	ChartDir string `json:"chartDir"`
+	// Chart is the chart, with its subcharts.
+	Chart *chart.Chart `json:"-"`
+	// Metadata is the Chart.yaml of the chart.
+	Metadata *chart.Metadata `json:"metadata"`
+	// Values are the values of values.yaml, merged with the values of the
+	// command line.
+	Values chartutil.Values `json:"values"`
+	// Templates are the Go templates of the chart and of its subcharts,
+	// parsed, by path in the chart, like "templates/deployment.yaml" or
+	// "charts/redis/templates/service.yaml". A template that can't be
+	// parsed is reported by the linter and missing.
+	Templates map[string]*template.Template `json:"-"`
+	// Sources are the sources of Templates, by path in the chart.
+	Sources map[string]string `json:"templates"`
+	// Environments are the manifests rendered with values.yaml and with
+	// every environment values file of the chart. An environment whose
+	// templates fail to render is missing.
+	Environments []RuleEnvironment `json:"environments"`
+}
+
+// RuleEnvironment are the manifests of the chart rendered in an environment.
+type RuleEnvironment struct {
+	// Name is the environment values file, like "values-prod.yaml", or ""
+	// for values.yaml alone.
+	Name string `json:"name"`
+	// Manifests are the rendered templates, without the partials and
+	// NOTES.txt, by path in the chart.
+	Manifests map[string]string `json:"manifests"`
+}
+
+// ParseSeverity returns the severity named s, like "warning" or "ERROR".
+func ParseSeverity(s string) (int, error) {
+	for i, name := range sev {
+		if strings.EqualFold(s, name) {
+			return i, nil
+		}
+	}
+	return UnknownSev, fmt.Errorf("unknown severity %q, the severity must be info, warning or error", s)
+}
@@ -0,0 +1,80 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin // import "k8s.io/helm/pkg/plugin"
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	helm_env "k8s.io/helm/pkg/helm/environment"
+)
+
+// Downloader returns the downloader of the plugin for the URL scheme, e.g.
+// "s3" or "git+https", or nil if the plugin has none.
+func (p *Plugin) Downloader(scheme string) *Downloaders {
+	for i, d := range p.Metadata.Downloaders {
+		for _, protocol := range d.Protocols {
+			if protocol == scheme {
+				return &p.Metadata.Downloaders[i]
+			}
+		}
+	}
+	return nil
+}
+
+// Download runs the command of a downloader of the plugin to download href,
+// and returns what the command writes to its standard output.
+//
+// The command is run as 'command certFile keyFile caFile href', with the
+// environment of Env. Environment variables in the command, e.g.
+// $HELM_PLUGIN_DIR, are expanded, and a relative command is relative to the
+// directory of the plugin. The download fails with the standard error of the
+// command if it exits with an error.
+func (p *Plugin) Download(command string, settings helm_env.EnvSettings, certFile, keyFile, caFile, href string) (*bytes.Buffer, error) {
+	env := pluginEnv(settings, p.Metadata.Name, p.Dir)
+	expand := func(key string) string {
+		if val, ok := env[key]; ok {
+			return val
+		}
+		return os.Getenv(key)
+	}
+
+	commands := strings.Split(command, " ")
+	main := os.Expand(commands[0], expand)
+	if !filepath.IsAbs(main) {
+		main = filepath.Join(p.Dir, main)
+	}
+	argv := append(commands[1:], certFile, keyFile, caFile, href)
+	prog := exec.Command(main, argv...)
+	prog.Env = Env(settings, p.Metadata.Name, p.Dir)
+	buf := bytes.NewBuffer(nil)
+	stderr := bytes.NewBuffer(nil)
+	prog.Stdout = buf
+	prog.Stderr = stderr
+	prog.Stdin = os.Stdin
+	if err := prog.Run(); err != nil {
+		if _, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("plugin %q exited with error: %s", command, strings.TrimSpace(stderr.String()))
+		}
+		return nil, err
+	}
+	return buf, nil
+}
@@ -0,0 +1,76 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin // import "k8s.io/helm/pkg/plugin"
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	helm_env "k8s.io/helm/pkg/helm/environment"
+	"k8s.io/helm/pkg/helm/helmpath"
+)
+
+func TestPluginDownloader(t *testing.T) {
+	plug, err := LoadDir("testdata/plugdir/downloader")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := plug.Downloader("myprotocols"); d == nil || d.Command != "echo Download" {
+		t.Errorf("Expected the downloader of myprotocols, got %v", d)
+	}
+	if d := plug.Downloader("https"); d != nil {
+		t.Errorf("Expected no downloader for https, got %v", d)
+	}
+}
+
+func TestDownload(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("TODO: refactor this test to work on windows")
+	}
+
+	dir, err := ioutil.TempDir("", "helm-downloader-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	script := "#!/bin/sh\ncase \"$5\" in *missing*) echo \"no such chart: $5\" >&2; exit 1;; esac\necho \"$HELM_PLUGIN_NAME $1 $4 $5\"\n"
+	if err := ioutil.WriteFile(filepath.Join(dir, "get.sh"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	plug := &Plugin{Dir: dir, Metadata: &Metadata{Name: "s3"}}
+	settings := helm_env.EnvSettings{Home: helmpath.Home(dir)}
+
+	for _, command := range []string{"get.sh sub", "$HELM_PLUGIN_DIR/get.sh sub"} {
+		data, err := plug.Download(command, settings, "", "", "ca.pem", "s3://bucket/mychart-0.1.0.tgz")
+		if err != nil {
+			t.Fatalf("%s: %s", command, err)
+		}
+		if expect := "s3 sub ca.pem s3://bucket/mychart-0.1.0.tgz\n"; data.String() != expect {
+			t.Errorf("%s: expected %q, got %q", command, expect, data.String())
+		}
+	}
+
+	_, err = plug.Download("get.sh sub", settings, "", "", "", "s3://bucket/missing-0.1.0.tgz")
+	if err == nil || !strings.Contains(err.Error(), "no such chart: s3://bucket/missing-0.1.0.tgz") {
+		t.Errorf("Expected the error of the downloader, got %v", err)
+	}
+}
@@ -15,6 +15,15 @@ limitations under the License.
 
 package plugin // import "k8s.io/helm/pkg/plugin"
 
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	helm_env "k8s.io/helm/pkg/helm/environment"
+)
+
 // Types of hooks
 const (
 	// Install is executed after the plugin is added.
@@ -25,6 +34,21 @@ const (
 	Update = "update"
 )
 
+// Types of hooks of the core commands. A hook of a core command receives a
+// document on stdin and writes it, possibly modified, to stdout. A hook
+// exiting with an error vetoes the command.
+const (
+	// PreInstall is executed with the values of 'helm install', before the
+	// chart is installed.
+	PreInstall = "pre-install"
+	// PreUpgrade is executed with the values of 'helm upgrade', before the
+	// release is upgraded.
+	PreUpgrade = "pre-upgrade"
+	// PostTemplate is executed with the manifests rendered by 'helm template',
+	// before they are written.
+	PostTemplate = "post-template"
+)
+
 // Hooks is a map of events to commands.
 type Hooks map[string]string
 
@@ -33,3 +57,34 @@ func (hooks Hooks) Get(event string) string {
 	h, _ := hooks[event]
 	return h
 }
+
+// RunCommandHooks runs the hooks of the plugins for the event of a core
+// command on data, in the order of the plugins. Every hook receives what the
+// previous hook wrote, and the output of the last hook is returned, or data if
+// no plugin has a hook for the event.
+//
+// The hooks are run with 'sh -c' and the environment of Env, with
+// HELM_HOOK_EVENT set to the event. A hook exiting with an error vetoes the
+// command: the error has what the hook wrote to stderr.
+func RunCommandHooks(plugins []*Plugin, settings helm_env.EnvSettings, event string, data []byte) ([]byte, error) {
+	for _, p := range plugins {
+		hook := p.Metadata.Hooks.Get(event)
+		if hook == "" {
+			continue
+		}
+
+		prog := exec.Command("sh", "-c", hook)
+		prog.Env = append(Env(settings, p.Metadata.Name, p.Dir), "HELM_HOOK_EVENT="+event)
+		stdout, stderr := bytes.NewBuffer(nil), bytes.NewBuffer(nil)
+		prog.Stdin = bytes.NewReader(data)
+		prog.Stdout, prog.Stderr = stdout, stderr
+		if err := prog.Run(); err != nil {
+			if _, ok := err.(*exec.ExitError); ok {
+				return nil, fmt.Errorf("the %s hook of plugin %q vetoed the command: %s", event, p.Metadata.Name, strings.TrimSpace(stderr.String()))
+			}
+			return nil, err
+		}
+		data = stdout.Bytes()
+	}
+	return data, nil
+}
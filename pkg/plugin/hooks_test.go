@@ -0,0 +1,60 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin // import "k8s.io/helm/pkg/plugin"
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+
+	helm_env "k8s.io/helm/pkg/helm/environment"
+)
+
+func TestRunCommandHooks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("TODO: refactor this test to work on windows")
+	}
+
+	plugins := []*Plugin{
+		{Metadata: &Metadata{Name: "tag", Hooks: Hooks{PreInstall: `sed "s/^team: .*/team: $HELM_PLUGIN_NAME/"`}}},
+		{Metadata: &Metadata{Name: "none", Hooks: Hooks{Install: "exit 1"}}},
+		{Metadata: &Metadata{Name: "event", Hooks: Hooks{PreInstall: "cat; echo \"event: $HELM_HOOK_EVENT\""}}},
+	}
+	settings := helm_env.EnvSettings{}
+
+	data, err := RunCommandHooks(plugins, settings, PreInstall, []byte("team: web\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expect := "team: tag\nevent: pre-install\n"; string(data) != expect {
+		t.Errorf("Expected %q, got %q", expect, data)
+	}
+
+	data, err = RunCommandHooks(plugins, settings, PreUpgrade, []byte("team: web\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expect := "team: web\n"; string(data) != expect {
+		t.Errorf("Expected the data unchanged without hooks, got %q", data)
+	}
+
+	veto := append(plugins, &Plugin{Metadata: &Metadata{Name: "policy", Hooks: Hooks{PreInstall: "echo 'team tag is not allowed' >&2; exit 1"}}})
+	_, err = RunCommandHooks(veto, settings, PreInstall, []byte("team: web\n"))
+	if err == nil || !strings.Contains(err.Error(), `the pre-install hook of plugin "policy" vetoed the command: team tag is not allowed`) {
+		t.Errorf("Expected the hook to veto the command, got %v", err)
+	}
+}
@@ -171,7 +171,25 @@ func FindPlugins(plugdirs string) ([]*Plugin, error) {
 // created here.
 func SetupPluginEnv(settings helm_env.EnvSettings,
 	shortName, base string) {
-	for key, val := range map[string]string{
+	for key, val := range pluginEnv(settings, shortName, base) {
+		os.Setenv(key, val)
+	}
+}
+
+// Env returns the environment of a command of a plugin: the environment of
+// Helm with the variables of SetupPluginEnv, without modifying os.Env.
+func Env(settings helm_env.EnvSettings, shortName, base string) []string {
+	env := os.Environ()
+	for key, val := range pluginEnv(settings, shortName, base) {
+		env = append(env, key+"="+val)
+	}
+	return env
+}
+
+// pluginEnv returns the variables set for the plugin shortName in the
+// directory base.
+func pluginEnv(settings helm_env.EnvSettings, shortName, base string) map[string]string {
+	env := map[string]string{
 		"HELM_PLUGIN_NAME": shortName,
 		"HELM_PLUGIN_DIR":  base,
 		"HELM_BIN":         os.Args[0],
@@ -190,11 +208,10 @@ func SetupPluginEnv(settings helm_env.EnvSettings,
 
 		"TILLER_HOST":      settings.TillerHost,
 		"TILLER_NAMESPACE": settings.TillerNamespace,
-	} {
-		os.Setenv(key, val)
 	}
 
 	if settings.Debug {
-		os.Setenv("HELM_DEBUG", "1")
+		env["HELM_DEBUG"] = "1"
 	}
+	return env
 }
@@ -0,0 +1,41 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package policy evaluates Rego policies against the rendered manifests and
+the values of a chart.
+
+The policies are Rego modules of package 'helm'. The 'deny' rules are
+evaluated against every manifest, with the input:
+
+	{"manifest": <the manifest>, "source": <the template>, "values": <the values>}
+
+and the 'deny_values' rules are evaluated once against the values, with the
+input:
+
+	{"values": <the values>}
+
+Every message of a 'deny' or 'deny_values' rule is a violation, e.g.
+
+	package helm
+
+	deny[msg] {
+		container := input.manifest.spec.template.spec.containers[_]
+		endswith(container.image, ":latest")
+		msg := sprintf("image %s uses the latest tag", [container.image])
+	}
+*/
+package policy // import "k8s.io/helm/pkg/policy"
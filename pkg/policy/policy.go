@@ -0,0 +1,196 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/open-policy-agent/opa/rego"
+
+	"k8s.io/helm/pkg/releaseutil"
+)
+
+const (
+	denyQuery       = "data.helm.deny[msg]"
+	denyValuesQuery = "data.helm.deny_values[msg]"
+)
+
+// Violation is a violation of a policy by a manifest or by the values.
+type Violation struct {
+	// Source is the template of the manifest, or empty for the values.
+	Source string `json:"source,omitempty"`
+	// Kind is the kind of the manifest.
+	Kind string `json:"kind,omitempty"`
+	// Name is the name of the manifest.
+	Name string `json:"name,omitempty"`
+	// Message is the message of the rule denying the manifest or the values.
+	Message string `json:"message"`
+}
+
+// String returns the violation as "source (Kind/name): message", or as
+// "values: message" for the values.
+func (v Violation) String() string {
+	if v.Source == "" {
+		return "values: " + v.Message
+	}
+	return fmt.Sprintf("%s (%s/%s): %s", v.Source, v.Kind, v.Name, v.Message)
+}
+
+// Error is the error of manifests or values violating policies.
+type Error struct {
+	Violations []Violation
+}
+
+func (e *Error) Error() string {
+	msgs := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		msgs[i] = "  " + v.String()
+	}
+	return fmt.Sprintf("%d policy violation(s):\n%s", len(e.Violations), strings.Join(msgs, "\n"))
+}
+
+// Engine evaluates the policies of a directory.
+type Engine struct {
+	deny       rego.PreparedEvalQuery
+	denyValues rego.PreparedEvalQuery
+}
+
+// Load loads the policies of the .rego files of dir and of its
+// subdirectories.
+func Load(dir string) (*Engine, error) {
+	var modules []func(*rego.Rego)
+	err := filepath.Walk(dir, func(p string, fi os.FileInfo, err error) error {
+		if err != nil || fi.IsDir() || filepath.Ext(p) != ".rego" {
+			return err
+		}
+		data, err := ioutil.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		modules = append(modules, rego.Module(p, string(data)))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(modules) == 0 {
+		return nil, fmt.Errorf("no policies found in %s", dir)
+	}
+
+	ctx := context.Background()
+	e := &Engine{}
+	if e.deny, err = rego.New(append(modules, rego.Query(denyQuery))...).PrepareForEval(ctx); err != nil {
+		return nil, err
+	}
+	if e.denyValues, err = rego.New(append(modules, rego.Query(denyValuesQuery))...).PrepareForEval(ctx); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Check evaluates the policies against every manifest of the rendered
+// templates and against the values. It returns an *Error with the violations
+// if any policy is violated.
+func (e *Engine) Check(templates map[string]string, values map[string]interface{}) error {
+	ctx := context.Background()
+	if values == nil {
+		values = map[string]interface{}{}
+	}
+
+	msgs, err := evalMessages(ctx, e.denyValues, map[string]interface{}{"values": values})
+	if err != nil {
+		return err
+	}
+	var violations []Violation
+	for _, msg := range msgs {
+		violations = append(violations, Violation{Message: msg})
+	}
+
+	names := make([]string, 0, len(templates))
+	for name := range templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		base := path.Base(name)
+		if strings.HasPrefix(base, "_") || base == "NOTES.txt" {
+			continue
+		}
+		docs := releaseutil.SplitManifests(templates[name])
+		for i := 0; i < len(docs); i++ {
+			doc := docs[fmt.Sprintf("manifest-%d", i)]
+			var manifest map[string]interface{}
+			if err := yaml.Unmarshal([]byte(doc), &manifest); err != nil {
+				return fmt.Errorf("cannot parse %s: %s", name, err)
+			}
+			if len(manifest) == 0 {
+				continue
+			}
+			input := map[string]interface{}{"manifest": manifest, "source": name, "values": values}
+			msgs, err := evalMessages(ctx, e.deny, input)
+			if err != nil {
+				return err
+			}
+			kind, _ := manifest["kind"].(string)
+			var objName string
+			if metadata, ok := manifest["metadata"].(map[string]interface{}); ok {
+				objName, _ = metadata["name"].(string)
+			}
+			for _, msg := range msgs {
+				violations = append(violations, Violation{Source: name, Kind: kind, Name: objName, Message: msg})
+			}
+		}
+	}
+
+	if len(violations) > 0 {
+		return &Error{Violations: violations}
+	}
+	return nil
+}
+
+// evalMessages returns the sorted messages of the rules of the query for the
+// input. A message which is not a string is returned as JSON.
+func evalMessages(ctx context.Context, query rego.PreparedEvalQuery, input interface{}) ([]string, error) {
+	rs, err := query.Eval(ctx, rego.EvalInput(input))
+	if err != nil {
+		return nil, err
+	}
+	var msgs []string
+	for _, r := range rs {
+		switch msg := r.Bindings["msg"].(type) {
+		case string:
+			msgs = append(msgs, msg)
+		default:
+			b, err := json.Marshal(msg)
+			if err != nil {
+				return nil, err
+			}
+			msgs = append(msgs, string(b))
+		}
+	}
+	sort.Strings(msgs)
+	return msgs, nil
+}
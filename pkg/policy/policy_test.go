@@ -0,0 +1,112 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const pods = `apiVersion: v1
+kind: Pod
+metadata:
+  name: web
+spec:
+  containers:
+  - name: web
+    image: nginx:latest
+  - name: sidecar
+    image: envoy:1.12
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: cron
+spec:
+  containers:
+  - name: cron
+    image: busybox:latest
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: worker
+spec:
+  containers:
+  - name: worker
+    image: busybox:1.31
+`
+
+func TestCheck(t *testing.T) {
+	e, err := Load("testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	templates := map[string]string{
+		"mychart/templates/pods.yaml":    pods,
+		"mychart/templates/_helpers.tpl": "image: nginx:latest",
+		"mychart/templates/NOTES.txt":    "image: nginx:latest",
+	}
+	err = e.Check(templates, map[string]interface{}{"replicas": 5})
+	perr, ok := err.(*Error)
+	if !ok {
+		t.Fatalf("Expected policy violations, got %v", err)
+	}
+	expect := []Violation{
+		{Message: "5 replicas are more than the maximum of 3"},
+		{Source: "mychart/templates/pods.yaml", Kind: "Pod", Name: "web", Message: "image nginx:latest uses the latest tag"},
+		{Source: "mychart/templates/pods.yaml", Kind: "Pod", Name: "cron", Message: "image busybox:latest uses the latest tag"},
+	}
+	if !reflect.DeepEqual(perr.Violations, expect) {
+		t.Errorf("Expected %+v, got %+v", expect, perr.Violations)
+	}
+	expectErr := `3 policy violation(s):
+  values: 5 replicas are more than the maximum of 3
+  mychart/templates/pods.yaml (Pod/web): image nginx:latest uses the latest tag
+  mychart/templates/pods.yaml (Pod/cron): image busybox:latest uses the latest tag`
+	if err.Error() != expectErr {
+		t.Errorf("Expected\n%s\ngot\n%s", expectErr, err)
+	}
+
+	if err := e.Check(map[string]string{"mychart/templates/pods.yaml": strings.Replace(pods, "latest", "1.17", -1)}, nil); err != nil {
+		t.Errorf("Expected no violations, got %v", err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "helm-policy-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if _, err := Load(dir); err == nil || !strings.Contains(err.Error(), "no policies found") {
+		t.Errorf("Expected no policies to be found, got %v", err)
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "broken.rego"), []byte("package helm\n\ndeny[msg] {\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(dir); err == nil || !strings.Contains(err.Error(), "broken.rego") {
+		t.Errorf("Expected the policy not to parse, got %v", err)
+	}
+}
@@ -0,0 +1,7 @@
+package helm
+
+deny[msg] {
+	container := input.manifest.spec.containers[_]
+	endswith(container.image, ":latest")
+	msg := sprintf("image %s uses the latest tag", [container.image])
+}
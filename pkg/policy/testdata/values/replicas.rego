@@ -0,0 +1,6 @@
+package helm
+
+deny_values[msg] {
+	input.values.replicas > 3
+	msg := sprintf("%v replicas are more than the maximum of 3", [input.values.replicas])
+}
@@ -38,7 +38,7 @@ func (x Metadata_Engine) String() string {
 	return proto.EnumName(Metadata_Engine_name, int32(x))
 }
 func (Metadata_Engine) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_metadata_d6c714c73a051dcb, []int{1, 0}
+	return fileDescriptor_metadata_188b5ed8aa121b96, []int{1, 0}
 }
 
 // Maintainer describes a Chart maintainer.
@@ -58,7 +58,7 @@ func (m *Maintainer) Reset()         { *m = Maintainer{} }
 func (m *Maintainer) String() string { return proto.CompactTextString(m) }
 func (*Maintainer) ProtoMessage()    {}
 func (*Maintainer) Descriptor() ([]byte, []int) {
-	return fileDescriptor_metadata_d6c714c73a051dcb, []int{0}
+	return fileDescriptor_metadata_188b5ed8aa121b96, []int{0}
 }
 func (m *Maintainer) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Maintainer.Unmarshal(m, b)
@@ -99,9 +99,9 @@ func (m *Maintainer) GetUrl() string {
 	return ""
 }
 
-// 	Metadata for a Chart file. This models the structure of a Chart.yaml file.
+// Metadata for a Chart file. This models the structure of a Chart.yaml file.
 //
-// 	Spec: https://k8s.io/helm/blob/master/docs/design/chart_format.md#the-chart-file
+// Spec: https://k8s.io/helm/blob/master/docs/design/chart_format.md#the-chart-file
 type Metadata struct {
 	// The name of the chart
 	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -138,7 +138,10 @@ type Metadata struct {
 	// made available for inspection by other applications.
 	Annotations map[string]string `protobuf:"bytes,16,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	// KubeVersion is a SemVer constraint specifying the version of Kubernetes required.
-	KubeVersion          string   `protobuf:"bytes,17,opt,name=kubeVersion,proto3" json:"kubeVersion,omitempty"`
+	KubeVersion string `protobuf:"bytes,17,opt,name=kubeVersion,proto3" json:"kubeVersion,omitempty"`
+	// Type is the type of the chart: application or library. Only charts
+	// with apiVersion v2 have a type.
+	Type                 string   `protobuf:"bytes,18,opt,name=type,proto3" json:"type,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -148,7 +151,7 @@ func (m *Metadata) Reset()         { *m = Metadata{} }
 func (m *Metadata) String() string { return proto.CompactTextString(m) }
 func (*Metadata) ProtoMessage()    {}
 func (*Metadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_metadata_d6c714c73a051dcb, []int{1}
+	return fileDescriptor_metadata_188b5ed8aa121b96, []int{1}
 }
 func (m *Metadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Metadata.Unmarshal(m, b)
@@ -287,6 +290,13 @@ func (m *Metadata) GetKubeVersion() string {
 	return ""
 }
 
+func (m *Metadata) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*Maintainer)(nil), "hapi.chart.Maintainer")
 	proto.RegisterType((*Metadata)(nil), "hapi.chart.Metadata")
@@ -294,36 +304,38 @@ func init() {
 	proto.RegisterEnum("hapi.chart.Metadata_Engine", Metadata_Engine_name, Metadata_Engine_value)
 }
 
-func init() { proto.RegisterFile("hapi/chart/metadata.proto", fileDescriptor_metadata_d6c714c73a051dcb) }
+func init() {
+	proto.RegisterFile("hapi/chart/metadata.proto", fileDescriptor_metadata_188b5ed8aa121b96)
+}
 
-var fileDescriptor_metadata_d6c714c73a051dcb = []byte{
-	// 435 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x52, 0x5d, 0x6b, 0xd4, 0x40,
+var fileDescriptor_metadata_188b5ed8aa121b96 = []byte{
+	// 442 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x53, 0x5d, 0x6b, 0xd4, 0x40,
 	0x14, 0x35, 0xcd, 0x66, 0x77, 0x73, 0x63, 0x35, 0x0e, 0x52, 0xc6, 0x22, 0x12, 0x16, 0x85, 0x7d,
 	0xda, 0x82, 0xbe, 0x14, 0x1f, 0x04, 0x85, 0x52, 0x41, 0xbb, 0x95, 0xe0, 0x07, 0xf8, 0x36, 0x4d,
-	0x2e, 0xdd, 0x61, 0x93, 0x99, 0x30, 0x99, 0xad, 0xec, 0xaf, 0xf0, 0x2f, 0xcb, 0xdc, 0x64, 0x9a,
-	0xac, 0xf4, 0xed, 0x9e, 0x73, 0x66, 0xce, 0xcc, 0xbd, 0xf7, 0xc0, 0x8b, 0x8d, 0x68, 0xe4, 0x59,
-	0xb1, 0x11, 0xc6, 0x9e, 0xd5, 0x68, 0x45, 0x29, 0xac, 0x58, 0x35, 0x46, 0x5b, 0xcd, 0xc0, 0x49,
-	0x2b, 0x92, 0x16, 0x9f, 0x01, 0xae, 0x84, 0x54, 0x56, 0x48, 0x85, 0x86, 0x31, 0x98, 0x28, 0x51,
-	0x23, 0x0f, 0xb2, 0x60, 0x19, 0xe7, 0x54, 0xb3, 0xe7, 0x10, 0x61, 0x2d, 0x64, 0xc5, 0x8f, 0x88,
-	0xec, 0x00, 0x4b, 0x21, 0xdc, 0x99, 0x8a, 0x87, 0xc4, 0xb9, 0x72, 0xf1, 0x37, 0x82, 0xf9, 0x55,
-	0xff, 0xd0, 0x83, 0x46, 0x0c, 0x26, 0x1b, 0x5d, 0x63, 0xef, 0x43, 0x35, 0xe3, 0x30, 0x6b, 0xf5,
-	0xce, 0x14, 0xd8, 0xf2, 0x30, 0x0b, 0x97, 0x71, 0xee, 0xa1, 0x53, 0xee, 0xd0, 0xb4, 0x52, 0x2b,
-	0x3e, 0xa1, 0x0b, 0x1e, 0xb2, 0x0c, 0x92, 0x12, 0xdb, 0xc2, 0xc8, 0xc6, 0x3a, 0x35, 0x22, 0x75,
-	0x4c, 0xb1, 0x53, 0x98, 0x6f, 0x71, 0xff, 0x47, 0x9b, 0xb2, 0xe5, 0x53, 0xb2, 0xbd, 0xc7, 0xec,
-	0x1c, 0x92, 0xfa, 0xbe, 0xe1, 0x96, 0xcf, 0xb2, 0x70, 0x99, 0xbc, 0x3d, 0x59, 0x0d, 0x23, 0x59,
-	0x0d, 0xf3, 0xc8, 0xc7, 0x47, 0xd9, 0x09, 0x4c, 0x51, 0xdd, 0x4a, 0x85, 0x7c, 0x4e, 0x4f, 0xf6,
-	0xc8, 0xf5, 0x25, 0x0b, 0xad, 0x78, 0xdc, 0xf5, 0xe5, 0x6a, 0xf6, 0x0a, 0x40, 0x34, 0xf2, 0x67,
-	0xdf, 0x00, 0x90, 0x32, 0x62, 0xd8, 0x4b, 0x88, 0x0b, 0xad, 0x4a, 0x49, 0x1d, 0x24, 0x24, 0x0f,
-	0x84, 0x73, 0xb4, 0xe2, 0xb6, 0xe5, 0x8f, 0x3b, 0x47, 0x57, 0x77, 0x8e, 0x8d, 0x77, 0x3c, 0xf6,
-	0x8e, 0x9e, 0x71, 0x7a, 0x89, 0x8d, 0xc1, 0x42, 0x58, 0x2c, 0xf9, 0x93, 0x2c, 0x58, 0xce, 0xf3,
-	0x11, 0xc3, 0x5e, 0xc3, 0xb1, 0x95, 0x55, 0x85, 0xc6, 0x5b, 0x3c, 0x25, 0x8b, 0x43, 0x92, 0x5d,
-	0x42, 0x22, 0x94, 0xd2, 0x56, 0xb8, 0x7f, 0xb4, 0x3c, 0xa5, 0xe9, 0xbc, 0x39, 0x98, 0x8e, 0xcf,
-	0xd2, 0xc7, 0xe1, 0xdc, 0x85, 0xb2, 0x66, 0x9f, 0x8f, 0x6f, 0xba, 0x25, 0x6d, 0x77, 0x37, 0xe8,
-	0x1f, 0x7b, 0xd6, 0x2d, 0x69, 0x44, 0x9d, 0x7e, 0x80, 0xf4, 0x7f, 0x0b, 0x97, 0xaa, 0x2d, 0xee,
-	0xfb, 0xd4, 0xb8, 0xd2, 0xa5, 0xef, 0x4e, 0x54, 0x3b, 0x9f, 0x9a, 0x0e, 0xbc, 0x3f, 0x3a, 0x0f,
-	0x16, 0x19, 0x4c, 0x2f, 0xba, 0x05, 0x24, 0x30, 0xfb, 0xb1, 0xfe, 0xb2, 0xbe, 0xfe, 0xb5, 0x4e,
-	0x1f, 0xb1, 0x18, 0xa2, 0xcb, 0xeb, 0xef, 0xdf, 0xbe, 0xa6, 0xc1, 0xa7, 0xd9, 0xef, 0x88, 0xfe,
-	0x7c, 0x33, 0xa5, 0xdc, 0xbf, 0xfb, 0x17, 0x00, 0x00, 0xff, 0xff, 0x36, 0xf9, 0x0d, 0xa6, 0x14,
-	0x03, 0x00, 0x00,
+	0x2e, 0xdd, 0x61, 0x93, 0x99, 0x30, 0x99, 0xad, 0xe4, 0xf7, 0xf8, 0x47, 0x65, 0x6e, 0x32, 0xdd,
+	0xac, 0xf4, 0xed, 0x9e, 0x73, 0x66, 0xce, 0xe4, 0xdc, 0x7b, 0x03, 0x2f, 0x36, 0xa2, 0x91, 0x67,
+	0xc5, 0x46, 0x18, 0x7b, 0x56, 0xa3, 0x15, 0xa5, 0xb0, 0x62, 0xd5, 0x18, 0x6d, 0x35, 0x03, 0x27,
+	0xad, 0x48, 0x5a, 0x7c, 0x06, 0xb8, 0x12, 0x52, 0x59, 0x21, 0x15, 0x1a, 0xc6, 0x60, 0xa2, 0x44,
+	0x8d, 0x3c, 0xc8, 0x82, 0x65, 0x9c, 0x53, 0xcd, 0x9e, 0x43, 0x84, 0xb5, 0x90, 0x15, 0x3f, 0x22,
+	0xb2, 0x07, 0x2c, 0x85, 0x70, 0x67, 0x2a, 0x1e, 0x12, 0xe7, 0xca, 0xc5, 0xdf, 0x08, 0xe6, 0x57,
+	0xc3, 0x43, 0x0f, 0x1a, 0x31, 0x98, 0x6c, 0x74, 0x8d, 0x83, 0x0f, 0xd5, 0x8c, 0xc3, 0xac, 0xd5,
+	0x3b, 0x53, 0x60, 0xcb, 0xc3, 0x2c, 0x5c, 0xc6, 0xb9, 0x87, 0x4e, 0xb9, 0x43, 0xd3, 0x4a, 0xad,
+	0xf8, 0x84, 0x2e, 0x78, 0xc8, 0x32, 0x48, 0x4a, 0x6c, 0x0b, 0x23, 0x1b, 0xeb, 0xd4, 0x88, 0xd4,
+	0x31, 0xc5, 0x4e, 0x61, 0xbe, 0xc5, 0xee, 0x8f, 0x36, 0x65, 0xcb, 0xa7, 0x64, 0x7b, 0x8f, 0xd9,
+	0x39, 0x24, 0xf5, 0x7d, 0xe0, 0x96, 0xcf, 0xb2, 0x70, 0x99, 0xbc, 0x3d, 0x59, 0xed, 0x5b, 0xb2,
+	0xda, 0xf7, 0x23, 0x1f, 0x1f, 0x65, 0x27, 0x30, 0x45, 0x75, 0x2b, 0x15, 0xf2, 0x39, 0x3d, 0x39,
+	0x20, 0x97, 0x4b, 0x16, 0x5a, 0xf1, 0xb8, 0xcf, 0xe5, 0x6a, 0xf6, 0x0a, 0x40, 0x34, 0xf2, 0xe7,
+	0x10, 0x00, 0x48, 0x19, 0x31, 0xec, 0x25, 0xc4, 0x85, 0x56, 0xa5, 0xa4, 0x04, 0x09, 0xc9, 0x7b,
+	0xc2, 0x39, 0x5a, 0x71, 0xdb, 0xf2, 0xc7, 0xbd, 0xa3, 0xab, 0x7b, 0xc7, 0xc6, 0x3b, 0x1e, 0x7b,
+	0x47, 0xcf, 0x38, 0xbd, 0xc4, 0xc6, 0x60, 0x21, 0x2c, 0x96, 0xfc, 0x49, 0x16, 0x2c, 0xe7, 0xf9,
+	0x88, 0x61, 0xaf, 0xe1, 0xd8, 0xca, 0xaa, 0x42, 0xe3, 0x2d, 0x9e, 0x92, 0xc5, 0x21, 0xc9, 0x2e,
+	0x21, 0x11, 0x4a, 0x69, 0x2b, 0xdc, 0x77, 0xb4, 0x3c, 0xa5, 0xee, 0xbc, 0x39, 0xe8, 0x8e, 0xdf,
+	0xa5, 0x8f, 0xfb, 0x73, 0x17, 0xca, 0x9a, 0x2e, 0x1f, 0xdf, 0x74, 0x43, 0xda, 0xee, 0x6e, 0xd0,
+	0x3f, 0xf6, 0xac, 0x1f, 0xd2, 0x88, 0xa2, 0x90, 0x5d, 0x83, 0x9c, 0x0d, 0x21, 0xbb, 0x06, 0x4f,
+	0x3f, 0x40, 0xfa, 0xbf, 0xad, 0xdb, 0xb4, 0x2d, 0x76, 0xc3, 0x26, 0xb9, 0xd2, 0x6d, 0xe4, 0x9d,
+	0xa8, 0x76, 0x7e, 0x93, 0x7a, 0xf0, 0xfe, 0xe8, 0x3c, 0x58, 0x64, 0x30, 0xbd, 0xe8, 0x87, 0x92,
+	0xc0, 0xec, 0xc7, 0xfa, 0xcb, 0xfa, 0xfa, 0xd7, 0x3a, 0x7d, 0xc4, 0x62, 0x88, 0x2e, 0xaf, 0xbf,
+	0x7f, 0xfb, 0x9a, 0x06, 0x9f, 0x66, 0xbf, 0x23, 0xca, 0x71, 0x33, 0xa5, 0x7f, 0xe1, 0xdd, 0xbf,
+	0x01, 0x00, 0x9f, 0x48, 0xa4, 0x51, 0x28, 0x03, 0x00, 0x00,
 }
@@ -69,7 +69,7 @@ func (x Hook_Event) String() string {
 	return proto.EnumName(Hook_Event_name, int32(x))
 }
 func (Hook_Event) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_hook_e64400ca8195038e, []int{0, 0}
+	return fileDescriptor_hook_fbdb9a43eca1eb78, []int{0, 0}
 }
 
 type Hook_DeletePolicy int32
@@ -95,7 +95,7 @@ func (x Hook_DeletePolicy) String() string {
 	return proto.EnumName(Hook_DeletePolicy_name, int32(x))
 }
 func (Hook_DeletePolicy) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_hook_e64400ca8195038e, []int{0, 1}
+	return fileDescriptor_hook_fbdb9a43eca1eb78, []int{0, 1}
 }
 
 // Hook defines a hook object.
@@ -116,7 +116,10 @@ type Hook struct {
 	// DeletePolicies are the policies that indicate when to delete the hook
 	DeletePolicies []Hook_DeletePolicy `protobuf:"varint,8,rep,packed,name=delete_policies,json=deletePolicies,proto3,enum=hapi.release.Hook_DeletePolicy" json:"delete_policies,omitempty"`
 	// DeleteTimeout indicates how long to wait for a resource to be deleted before timing out
-	DeleteTimeout        int64    `protobuf:"varint,9,opt,name=delete_timeout,json=deleteTimeout,proto3" json:"delete_timeout,omitempty"`
+	DeleteTimeout int64 `protobuf:"varint,9,opt,name=delete_timeout,json=deleteTimeout,proto3" json:"delete_timeout,omitempty"`
+	// ExactWeight is the weight of the hook, which may be fractional, e.g.
+	// 1.5. Weight is the weight rounded toward zero.
+	ExactWeight          float64  `protobuf:"fixed64,10,opt,name=exact_weight,json=exactWeight,proto3" json:"exact_weight,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -126,7 +129,7 @@ func (m *Hook) Reset()         { *m = Hook{} }
 func (m *Hook) String() string { return proto.CompactTextString(m) }
 func (*Hook) ProtoMessage()    {}
 func (*Hook) Descriptor() ([]byte, []int) {
-	return fileDescriptor_hook_e64400ca8195038e, []int{0}
+	return fileDescriptor_hook_fbdb9a43eca1eb78, []int{0}
 }
 func (m *Hook) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Hook.Unmarshal(m, b)
@@ -209,44 +212,140 @@ func (m *Hook) GetDeleteTimeout() int64 {
 	return 0
 }
 
+func (m *Hook) GetExactWeight() float64 {
+	if m != nil {
+		return m.ExactWeight
+	}
+	return 0
+}
+
+// HookFailure describes a hook which failed.
+type HookFailure struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Kind is the Kubernetes kind.
+	Kind string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	// Path is the chart-relative path to the template.
+	Path string `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+	// Event is the event the hook failed on, e.g. "pre-install".
+	Event string `protobuf:"bytes,4,opt,name=event,proto3" json:"event,omitempty"`
+	// Error is the error of the hook.
+	Error string `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	// Logs are the last lines of the logs of the pods of a Pod or Job hook.
+	Logs                 string   `protobuf:"bytes,6,opt,name=logs,proto3" json:"logs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HookFailure) Reset()         { *m = HookFailure{} }
+func (m *HookFailure) String() string { return proto.CompactTextString(m) }
+func (*HookFailure) ProtoMessage()    {}
+func (*HookFailure) Descriptor() ([]byte, []int) {
+	return fileDescriptor_hook_fbdb9a43eca1eb78, []int{1}
+}
+func (m *HookFailure) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HookFailure.Unmarshal(m, b)
+}
+func (m *HookFailure) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HookFailure.Marshal(b, m, deterministic)
+}
+func (dst *HookFailure) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HookFailure.Merge(dst, src)
+}
+func (m *HookFailure) XXX_Size() int {
+	return xxx_messageInfo_HookFailure.Size(m)
+}
+func (m *HookFailure) XXX_DiscardUnknown() {
+	xxx_messageInfo_HookFailure.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HookFailure proto.InternalMessageInfo
+
+func (m *HookFailure) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *HookFailure) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *HookFailure) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *HookFailure) GetEvent() string {
+	if m != nil {
+		return m.Event
+	}
+	return ""
+}
+
+func (m *HookFailure) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *HookFailure) GetLogs() string {
+	if m != nil {
+		return m.Logs
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*Hook)(nil), "hapi.release.Hook")
+	proto.RegisterType((*HookFailure)(nil), "hapi.release.HookFailure")
 	proto.RegisterEnum("hapi.release.Hook_Event", Hook_Event_name, Hook_Event_value)
 	proto.RegisterEnum("hapi.release.Hook_DeletePolicy", Hook_DeletePolicy_name, Hook_DeletePolicy_value)
 }
 
-func init() { proto.RegisterFile("hapi/release/hook.proto", fileDescriptor_hook_e64400ca8195038e) }
-
-var fileDescriptor_hook_e64400ca8195038e = []byte{
-	// 473 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x91, 0xdb, 0x8e, 0xda, 0x3c,
-	0x10, 0x80, 0x37, 0x1c, 0x02, 0x0c, 0x87, 0xf5, 0x6f, 0xfd, 0x6a, 0x2d, 0x6e, 0x16, 0x21, 0x55,
-	0xe2, 0x2a, 0x54, 0x5b, 0xf5, 0x01, 0x42, 0xe2, 0x2d, 0x88, 0x88, 0x20, 0x27, 0xa8, 0x52, 0x6f,
-	0xa2, 0x6c, 0xf1, 0x42, 0x44, 0x88, 0x23, 0x62, 0x5a, 0xf5, 0x81, 0xfb, 0x18, 0x95, 0x2a, 0x3b,
-	0x21, 0x5d, 0xa9, 0xbd, 0x9b, 0xf9, 0xe6, 0xf3, 0x78, 0xc6, 0x86, 0xb7, 0xc7, 0x38, 0x4f, 0xe6,
-	0x17, 0x9e, 0xf2, 0xb8, 0xe0, 0xf3, 0xa3, 0x10, 0x27, 0x2b, 0xbf, 0x08, 0x29, 0xf0, 0x40, 0x15,
-	0xac, 0xaa, 0x30, 0x7e, 0x38, 0x08, 0x71, 0x48, 0xf9, 0x5c, 0xd7, 0x9e, 0xaf, 0x2f, 0x73, 0x99,
-	0x9c, 0x79, 0x21, 0xe3, 0x73, 0x5e, 0xea, 0xd3, 0x5f, 0x2d, 0x68, 0x2d, 0x85, 0x38, 0x61, 0x0c,
-	0xad, 0x2c, 0x3e, 0x73, 0x62, 0x4c, 0x8c, 0x59, 0x8f, 0xe9, 0x58, 0xb1, 0x53, 0x92, 0xed, 0x49,
-	0xa3, 0x64, 0x2a, 0x56, 0x2c, 0x8f, 0xe5, 0x91, 0x34, 0x4b, 0xa6, 0x62, 0x3c, 0x86, 0xee, 0x39,
-	0xce, 0x92, 0x17, 0x5e, 0x48, 0xd2, 0xd2, 0xbc, 0xce, 0xf1, 0x7b, 0x30, 0xf9, 0x37, 0x9e, 0xc9,
-	0x82, 0xb4, 0x27, 0xcd, 0xd9, 0xe8, 0x91, 0x58, 0xaf, 0x07, 0xb4, 0xd4, 0xdd, 0x16, 0x55, 0x02,
-	0xab, 0x3c, 0xfc, 0x11, 0xba, 0x69, 0x5c, 0xc8, 0xe8, 0x72, 0xcd, 0x88, 0x39, 0x31, 0x66, 0xfd,
-	0xc7, 0xb1, 0x55, 0xae, 0x61, 0xdd, 0xd6, 0xb0, 0xc2, 0xdb, 0x1a, 0xac, 0xa3, 0x5c, 0x76, 0xcd,
-	0xf0, 0x1b, 0x30, 0xbf, 0xf3, 0xe4, 0x70, 0x94, 0xa4, 0x33, 0x31, 0x66, 0x6d, 0x56, 0x65, 0x78,
-	0x09, 0xf7, 0x7b, 0x9e, 0x72, 0xc9, 0xa3, 0x5c, 0xa4, 0xc9, 0xd7, 0x84, 0x17, 0xa4, 0xab, 0x27,
-	0x79, 0xf8, 0xc7, 0x24, 0xae, 0x36, 0xb7, 0x4a, 0xfc, 0xc1, 0x46, 0xfb, 0x3f, 0x59, 0xc2, 0x0b,
-	0xfc, 0x0e, 0x2a, 0x12, 0xa9, 0x57, 0x14, 0x57, 0x49, 0x7a, 0x13, 0x63, 0xd6, 0x64, 0xc3, 0x92,
-	0x86, 0x25, 0x9c, 0xfe, 0x34, 0xa0, 0xad, 0x37, 0xc2, 0x7d, 0xe8, 0xec, 0x36, 0xeb, 0x8d, 0xff,
-	0x79, 0x83, 0xee, 0xf0, 0x3d, 0xf4, 0xb7, 0x8c, 0x46, 0xab, 0x4d, 0x10, 0xda, 0x9e, 0x87, 0x0c,
-	0x8c, 0x60, 0xb0, 0xf5, 0x83, 0xb0, 0x26, 0x0d, 0x3c, 0x02, 0x50, 0x8a, 0x4b, 0x3d, 0x1a, 0x52,
-	0xd4, 0xd4, 0x47, 0x94, 0x51, 0x81, 0xd6, 0xad, 0xc7, 0x6e, 0xfb, 0x89, 0xd9, 0x2e, 0x45, 0xed,
-	0xba, 0xc7, 0x8d, 0x98, 0x9a, 0x30, 0x1a, 0x31, 0xdf, 0xf3, 0x16, 0xb6, 0xb3, 0x46, 0x1d, 0xfc,
-	0x1f, 0x0c, 0xb5, 0x53, 0xa3, 0x2e, 0x26, 0xf0, 0x3f, 0xa3, 0x1e, 0xb5, 0x03, 0x1a, 0x85, 0x34,
-	0x08, 0xa3, 0x60, 0xe7, 0x38, 0x34, 0x08, 0x50, 0xef, 0xaf, 0xca, 0x93, 0xbd, 0xf2, 0x76, 0x8c,
-	0x22, 0x50, 0x77, 0x3b, 0xcc, 0xad, 0xa7, 0xed, 0x4f, 0x1d, 0x18, 0xbc, 0x7e, 0x2e, 0x3c, 0x84,
-	0x9e, 0xee, 0x43, 0x5d, 0xea, 0xa2, 0x3b, 0x0c, 0x60, 0xaa, 0xc3, 0xd4, 0x45, 0x86, 0xea, 0xba,
-	0xa0, 0x4f, 0x3e, 0xa3, 0xd1, 0xd2, 0xf7, 0xd7, 0x91, 0xc3, 0xa8, 0x1d, 0xae, 0xfc, 0x0d, 0x6a,
-	0x2c, 0x7a, 0x5f, 0x3a, 0xd5, 0x07, 0x3c, 0x9b, 0xfa, 0x77, 0x3f, 0xfc, 0x0e, 0x00, 0x00, 0xff,
-	0xff, 0xce, 0x84, 0xd9, 0x98, 0xdb, 0x02, 0x00, 0x00,
+func init() { proto.RegisterFile("hapi/release/hook.proto", fileDescriptor_hook_fbdb9a43eca1eb78) }
+
+var fileDescriptor_hook_fbdb9a43eca1eb78 = []byte{
+	// 535 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x92, 0x5f, 0x6e, 0x9b, 0x40,
+	0x10, 0xc6, 0x43, 0x6c, 0xb0, 0x19, 0x9c, 0x84, 0xae, 0xa2, 0x76, 0x95, 0x97, 0x50, 0x4b, 0x95,
+	0x78, 0xc2, 0x55, 0xaa, 0x1e, 0x80, 0xc0, 0xba, 0xb6, 0x8c, 0x8c, 0xb5, 0x60, 0x45, 0xea, 0x0b,
+	0x22, 0xf1, 0xc6, 0x46, 0xc6, 0xac, 0x05, 0xb8, 0x7f, 0x8e, 0xd0, 0x5b, 0xf4, 0x72, 0xbd, 0x47,
+	0xb5, 0x0b, 0x76, 0x23, 0xb5, 0x6f, 0x7d, 0x9b, 0xf9, 0xcd, 0x37, 0xc3, 0xcc, 0xf2, 0xc1, 0x9b,
+	0x4d, 0xba, 0xcf, 0x46, 0x25, 0xcb, 0x59, 0x5a, 0xb1, 0xd1, 0x86, 0xf3, 0xad, 0xb3, 0x2f, 0x79,
+	0xcd, 0xd1, 0x40, 0x14, 0x9c, 0xb6, 0x70, 0x73, 0xbb, 0xe6, 0x7c, 0x9d, 0xb3, 0x91, 0xac, 0x3d,
+	0x1e, 0x9e, 0x47, 0x75, 0xb6, 0x63, 0x55, 0x9d, 0xee, 0xf6, 0x8d, 0x7c, 0xf8, 0x53, 0x85, 0xee,
+	0x84, 0xf3, 0x2d, 0x42, 0xd0, 0x2d, 0xd2, 0x1d, 0xc3, 0x8a, 0xa5, 0xd8, 0x3a, 0x95, 0xb1, 0x60,
+	0xdb, 0xac, 0x58, 0xe1, 0xf3, 0x86, 0x89, 0x58, 0xb0, 0x7d, 0x5a, 0x6f, 0x70, 0xa7, 0x61, 0x22,
+	0x46, 0x37, 0xd0, 0xdf, 0xa5, 0x45, 0xf6, 0xcc, 0xaa, 0x1a, 0x77, 0x25, 0x3f, 0xe5, 0xe8, 0x3d,
+	0x68, 0xec, 0x0b, 0x2b, 0xea, 0x0a, 0xab, 0x56, 0xc7, 0xbe, 0xbc, 0xc3, 0xce, 0xcb, 0x05, 0x1d,
+	0xf1, 0x6d, 0x87, 0x08, 0x01, 0x6d, 0x75, 0xe8, 0x23, 0xf4, 0xf3, 0xb4, 0xaa, 0x93, 0xf2, 0x50,
+	0x60, 0xcd, 0x52, 0x6c, 0xe3, 0xee, 0xc6, 0x69, 0xce, 0x70, 0x8e, 0x67, 0x38, 0xf1, 0xf1, 0x0c,
+	0xda, 0x13, 0x5a, 0x7a, 0x28, 0xd0, 0x6b, 0xd0, 0xbe, 0xb2, 0x6c, 0xbd, 0xa9, 0x71, 0xcf, 0x52,
+	0x6c, 0x95, 0xb6, 0x19, 0x9a, 0xc0, 0xd5, 0x8a, 0xe5, 0xac, 0x66, 0xc9, 0x9e, 0xe7, 0xd9, 0x53,
+	0xc6, 0x2a, 0xdc, 0x97, 0x9b, 0xdc, 0xfe, 0x63, 0x13, 0x5f, 0x2a, 0x17, 0x42, 0xf8, 0x9d, 0x5e,
+	0xae, 0xfe, 0x64, 0x19, 0xab, 0xd0, 0x3b, 0x68, 0x49, 0x22, 0x5e, 0x91, 0x1f, 0x6a, 0xac, 0x5b,
+	0x8a, 0xdd, 0xa1, 0x17, 0x0d, 0x8d, 0x1b, 0x88, 0xde, 0xc2, 0x80, 0x7d, 0x4b, 0x9f, 0xea, 0xa4,
+	0x5d, 0x07, 0x2c, 0xc5, 0x56, 0xa8, 0x21, 0xd9, 0x83, 0x44, 0xc3, 0x5f, 0x0a, 0xa8, 0xf2, 0x68,
+	0x64, 0x40, 0x6f, 0x39, 0x9f, 0xcd, 0xc3, 0x87, 0xb9, 0x79, 0x86, 0xae, 0xc0, 0x58, 0x50, 0x92,
+	0x4c, 0xe7, 0x51, 0xec, 0x06, 0x81, 0xa9, 0x20, 0x13, 0x06, 0x8b, 0x30, 0x8a, 0x4f, 0xe4, 0x1c,
+	0x5d, 0x02, 0x08, 0x89, 0x4f, 0x02, 0x12, 0x13, 0xb3, 0x23, 0x5b, 0x84, 0xa2, 0x05, 0xdd, 0xe3,
+	0x8c, 0xe5, 0xe2, 0x13, 0x75, 0x7d, 0x62, 0xaa, 0xa7, 0x19, 0x47, 0xa2, 0x49, 0x42, 0x49, 0x42,
+	0xc3, 0x20, 0xb8, 0x77, 0xbd, 0x99, 0xd9, 0x43, 0xaf, 0xe0, 0x42, 0x6a, 0x4e, 0xa8, 0x8f, 0x30,
+	0x5c, 0x53, 0x12, 0x10, 0x37, 0x22, 0x49, 0x4c, 0xa2, 0x38, 0x89, 0x96, 0x9e, 0x47, 0xa2, 0xc8,
+	0xd4, 0xff, 0xaa, 0x8c, 0xdd, 0x69, 0xb0, 0xa4, 0xc4, 0x04, 0xf1, 0x6d, 0x8f, 0xfa, 0xa7, 0x6d,
+	0x8d, 0xa1, 0x07, 0x83, 0x97, 0x2f, 0x8a, 0x2e, 0x40, 0x97, 0x73, 0x88, 0x4f, 0x7c, 0xf3, 0x0c,
+	0x01, 0x68, 0xa2, 0x99, 0xf8, 0xa6, 0x22, 0xa6, 0xde, 0x93, 0x71, 0x48, 0x49, 0x32, 0x09, 0xc3,
+	0x59, 0xe2, 0x51, 0xe2, 0xc6, 0xd3, 0x70, 0x6e, 0x9e, 0x0f, 0x7f, 0x28, 0x60, 0x88, 0x9f, 0x33,
+	0x4e, 0xb3, 0xfc, 0x50, 0xb2, 0xff, 0x72, 0xea, 0x35, 0xa8, 0xd2, 0x65, 0xad, 0x4d, 0x9b, 0x44,
+	0xd2, 0xb2, 0xe4, 0x25, 0x56, 0x5b, 0x2a, 0x12, 0xd1, 0x9f, 0xf3, 0x75, 0x25, 0x3d, 0xa8, 0x53,
+	0x19, 0xdf, 0xeb, 0x9f, 0x7b, 0xad, 0x5f, 0x1e, 0x35, 0x69, 0xc6, 0x0f, 0xbf, 0x07, 0x00, 0xc2,
+	0x55, 0x55, 0x1e, 0x8a, 0x03, 0x00, 0x00,
 }
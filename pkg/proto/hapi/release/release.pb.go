@@ -38,7 +38,14 @@ type Release struct {
 	// Version is an int32 which represents the version of the release.
 	Version int32 `protobuf:"varint,7,opt,name=version,proto3" json:"version,omitempty"`
 	// Namespace is the kubernetes namespace of the release.
-	Namespace            string   `protobuf:"bytes,8,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Namespace string `protobuf:"bytes,8,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Environment is the environment values file of the chart the release
+	// was installed or upgraded with, if any.
+	Environment string `protobuf:"bytes,9,opt,name=environment,proto3" json:"environment,omitempty"`
+	// Namespaces are the namespaces of the resources of the release. The
+	// resources that do not set metadata.namespace are in the namespace of
+	// the release.
+	Namespaces           []string `protobuf:"bytes,10,rep,name=namespaces,proto3" json:"namespaces,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -48,7 +55,7 @@ func (m *Release) Reset()         { *m = Release{} }
 func (m *Release) String() string { return proto.CompactTextString(m) }
 func (*Release) ProtoMessage()    {}
 func (*Release) Descriptor() ([]byte, []int) {
-	return fileDescriptor_release_4bea5d16ba219619, []int{0}
+	return fileDescriptor_release_40c00032cd40c218, []int{0}
 }
 func (m *Release) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Release.Unmarshal(m, b)
@@ -124,28 +131,46 @@ func (m *Release) GetNamespace() string {
 	return ""
 }
 
+func (m *Release) GetEnvironment() string {
+	if m != nil {
+		return m.Environment
+	}
+	return ""
+}
+
+func (m *Release) GetNamespaces() []string {
+	if m != nil {
+		return m.Namespaces
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Release)(nil), "hapi.release.Release")
 }
 
-func init() { proto.RegisterFile("hapi/release/release.proto", fileDescriptor_release_4bea5d16ba219619) }
-
-var fileDescriptor_release_4bea5d16ba219619 = []byte{
-	// 256 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x90, 0xbf, 0x4e, 0xc3, 0x40,
-	0x0c, 0xc6, 0x95, 0x36, 0x7f, 0x1a, 0xc3, 0x82, 0x07, 0xb0, 0x22, 0x86, 0x88, 0x01, 0x22, 0x86,
-	0x54, 0x82, 0x37, 0x80, 0x05, 0xd6, 0x1b, 0xd9, 0x8e, 0xe8, 0x42, 0x4e, 0xa5, 0xe7, 0x28, 0x17,
-	0xf1, 0x2c, 0x3c, 0x2e, 0xba, 0x3f, 0x85, 0x94, 0x2e, 0x4e, 0xec, 0xdf, 0xa7, 0xcf, 0xdf, 0x19,
-	0xaa, 0x41, 0x8e, 0x7a, 0x3b, 0xa9, 0x4f, 0x25, 0xad, 0x3a, 0x7c, 0xdb, 0x71, 0xe2, 0x99, 0xf1,
-	0xdc, 0xb1, 0x36, 0xce, 0xaa, 0xab, 0x23, 0xe5, 0xc0, 0xbc, 0x0b, 0xb2, 0x7f, 0x40, 0x9b, 0x9e,
-	0x8f, 0x40, 0x37, 0xc8, 0x69, 0xde, 0x76, 0x6c, 0x7a, 0xfd, 0x11, 0xc1, 0xe5, 0x12, 0xb8, 0x1a,
-	0xe6, 0x37, 0xdf, 0x2b, 0x28, 0x44, 0xf0, 0x41, 0x84, 0xd4, 0xc8, 0xbd, 0xa2, 0xa4, 0x4e, 0x9a,
-	0x52, 0xf8, 0x7f, 0xbc, 0x85, 0xd4, 0xd9, 0xd3, 0xaa, 0x4e, 0x9a, 0xb3, 0x07, 0x6c, 0x97, 0xf9,
-	0xda, 0x57, 0xd3, 0xb3, 0xf0, 0x1c, 0xef, 0x20, 0xf3, 0xb6, 0xb4, 0xf6, 0xc2, 0x8b, 0x20, 0x0c,
-	0x9b, 0x9e, 0x5d, 0x15, 0x81, 0xe3, 0x3d, 0xe4, 0x21, 0x18, 0xa5, 0x4b, 0xcb, 0xa8, 0xf4, 0x44,
-	0x44, 0x05, 0x56, 0xb0, 0xd9, 0x4b, 0xa3, 0x7b, 0x65, 0x67, 0xca, 0x7c, 0xa8, 0xdf, 0x1e, 0x1b,
-	0xc8, 0xdc, 0x41, 0x2c, 0xe5, 0xf5, 0xfa, 0x34, 0xd9, 0x0b, 0xf3, 0x4e, 0x04, 0x01, 0x12, 0x14,
-	0x5f, 0x6a, 0xb2, 0x9a, 0x0d, 0x15, 0x75, 0xd2, 0x64, 0xe2, 0xd0, 0xe2, 0x35, 0x94, 0xee, 0x91,
-	0x76, 0x94, 0x9d, 0xa2, 0x8d, 0x5f, 0xf0, 0x37, 0x78, 0x2a, 0xdf, 0x8a, 0x68, 0xf7, 0x9e, 0xfb,
-	0x63, 0x3d, 0xfe, 0x04, 0x00, 0x00, 0xff, 0xff, 0xc8, 0x8f, 0xec, 0x97, 0xbb, 0x01, 0x00, 0x00,
+func init() {
+	proto.RegisterFile("hapi/release/release.proto", fileDescriptor_release_40c00032cd40c218)
+}
+
+var fileDescriptor_release_40c00032cd40c218 = []byte{
+	// 279 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x90, 0xbd, 0x4e, 0xc3, 0x30,
+	0x14, 0x85, 0x95, 0xe6, 0xaf, 0xb9, 0x65, 0xe1, 0x0e, 0x60, 0x45, 0x08, 0x59, 0x0c, 0x10, 0x31,
+	0xa4, 0x12, 0xbc, 0x01, 0x2c, 0xb0, 0x7a, 0x64, 0x33, 0x91, 0x43, 0xac, 0x12, 0xdf, 0x28, 0x8e,
+	0xfa, 0xb0, 0x3c, 0x0d, 0xb2, 0x9d, 0x96, 0x94, 0x2e, 0x4e, 0x7c, 0xbe, 0x4f, 0xd7, 0xc7, 0x86,
+	0xb2, 0x93, 0x83, 0xde, 0x8e, 0xea, 0x5b, 0x49, 0xab, 0x0e, 0xdf, 0x7a, 0x18, 0x69, 0x22, 0xbc,
+	0x70, 0xac, 0x9e, 0xb3, 0xf2, 0xfa, 0xc4, 0xec, 0x88, 0x76, 0x41, 0xfb, 0x07, 0xb4, 0x69, 0xe9,
+	0x04, 0x34, 0x9d, 0x1c, 0xa7, 0x6d, 0x43, 0xa6, 0xd5, 0x5f, 0x33, 0xb8, 0x5a, 0x02, 0xb7, 0x86,
+	0xfc, 0xee, 0x67, 0x05, 0xb9, 0x08, 0x73, 0x10, 0x21, 0x31, 0xb2, 0x57, 0x2c, 0xe2, 0x51, 0x55,
+	0x08, 0xff, 0x8f, 0xf7, 0x90, 0xb8, 0xf1, 0x6c, 0xc5, 0xa3, 0x6a, 0xf3, 0x84, 0xf5, 0xb2, 0x5f,
+	0xfd, 0x6e, 0x5a, 0x12, 0x9e, 0xe3, 0x03, 0xa4, 0x7e, 0x2c, 0x8b, 0xbd, 0x78, 0x19, 0xc4, 0x70,
+	0xd2, 0xab, 0x5b, 0x45, 0xe0, 0xf8, 0x08, 0x59, 0x28, 0xc6, 0x92, 0xe5, 0xc8, 0xd9, 0xf4, 0x44,
+	0xcc, 0x06, 0x96, 0xb0, 0xee, 0xa5, 0xd1, 0xad, 0xb2, 0x13, 0x4b, 0x7d, 0xa9, 0xe3, 0x1e, 0x2b,
+	0x48, 0xdd, 0x83, 0x58, 0x96, 0xf1, 0xf8, 0xbc, 0xd9, 0x1b, 0xd1, 0x4e, 0x04, 0x01, 0x19, 0xe4,
+	0x7b, 0x35, 0x5a, 0x4d, 0x86, 0xe5, 0x3c, 0xaa, 0x52, 0x71, 0xd8, 0xe2, 0x0d, 0x14, 0xee, 0x92,
+	0x76, 0x90, 0x8d, 0x62, 0x6b, 0x7f, 0xc0, 0x5f, 0x80, 0x1c, 0x36, 0xca, 0xec, 0xf5, 0x48, 0xa6,
+	0x57, 0x66, 0x62, 0x85, 0xe7, 0xcb, 0x08, 0x6f, 0x01, 0x8e, 0xba, 0x65, 0xc0, 0xe3, 0xaa, 0x10,
+	0x8b, 0xe4, 0xa5, 0xf8, 0xc8, 0xe7, 0x42, 0x9f, 0x99, 0x7f, 0xee, 0xe7, 0xdf, 0x01, 0x00, 0xfe,
+	0x3d, 0x75, 0x55, 0xfd, 0x01, 0x00, 0x00,
 }
@@ -6,6 +6,7 @@ package services
 import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
+import timestamp "github.com/golang/protobuf/ptypes/timestamp"
 import chart "k8s.io/helm/pkg/proto/hapi/chart"
 import release "k8s.io/helm/pkg/proto/hapi/release"
 import version "k8s.io/helm/pkg/proto/hapi/version"
@@ -53,7 +54,7 @@ func (x ListSort_SortBy) String() string {
 	return proto.EnumName(ListSort_SortBy_name, int32(x))
 }
 func (ListSort_SortBy) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_0094511522f6b040, []int{1, 0}
+	return fileDescriptor_tiller_c088ace695169374, []int{1, 0}
 }
 
 // SortOrder defines sort orders to augment sorting operations.
@@ -77,7 +78,7 @@ func (x ListSort_SortOrder) String() string {
 	return proto.EnumName(ListSort_SortOrder_name, int32(x))
 }
 func (ListSort_SortOrder) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_0094511522f6b040, []int{1, 1}
+	return fileDescriptor_tiller_c088ace695169374, []int{1, 1}
 }
 
 // ListReleasesRequest requests a list of releases.
@@ -104,17 +105,27 @@ type ListReleasesRequest struct {
 	SortOrder   ListSort_SortOrder    `protobuf:"varint,5,opt,name=sort_order,json=sortOrder,proto3,enum=hapi.services.tiller.ListSort_SortOrder" json:"sort_order,omitempty"`
 	StatusCodes []release.Status_Code `protobuf:"varint,6,rep,packed,name=status_codes,json=statusCodes,proto3,enum=hapi.release.Status_Code" json:"status_codes,omitempty"`
 	// Namespace is the filter to select releases only from a specific namespace.
-	Namespace            string   `protobuf:"bytes,7,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Namespace string `protobuf:"bytes,7,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// ChartName is the filter to select releases only of a specific chart.
+	ChartName string `protobuf:"bytes,8,opt,name=chart_name,json=chartName,proto3" json:"chart_name,omitempty"`
+	// AppVersion is the filter to select releases only of a specific app version.
+	AppVersion string `protobuf:"bytes,9,opt,name=app_version,json=appVersion,proto3" json:"app_version,omitempty"`
+	// Environment is the filter to select releases only deployed with a
+	// specific environment values file.
+	Environment string `protobuf:"bytes,10,opt,name=environment,proto3" json:"environment,omitempty"`
+	// DeployedAfter is the filter to select releases only last deployed after
+	// a time.
+	DeployedAfter        *timestamp.Timestamp `protobuf:"bytes,11,opt,name=deployed_after,json=deployedAfter,proto3" json:"deployed_after,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
 func (m *ListReleasesRequest) Reset()         { *m = ListReleasesRequest{} }
 func (m *ListReleasesRequest) String() string { return proto.CompactTextString(m) }
 func (*ListReleasesRequest) ProtoMessage()    {}
 func (*ListReleasesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_0094511522f6b040, []int{0}
+	return fileDescriptor_tiller_c088ace695169374, []int{0}
 }
 func (m *ListReleasesRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListReleasesRequest.Unmarshal(m, b)
@@ -183,6 +194,34 @@ func (m *ListReleasesRequest) GetNamespace() string {
 	return ""
 }
 
+func (m *ListReleasesRequest) GetChartName() string {
+	if m != nil {
+		return m.ChartName
+	}
+	return ""
+}
+
+func (m *ListReleasesRequest) GetAppVersion() string {
+	if m != nil {
+		return m.AppVersion
+	}
+	return ""
+}
+
+func (m *ListReleasesRequest) GetEnvironment() string {
+	if m != nil {
+		return m.Environment
+	}
+	return ""
+}
+
+func (m *ListReleasesRequest) GetDeployedAfter() *timestamp.Timestamp {
+	if m != nil {
+		return m.DeployedAfter
+	}
+	return nil
+}
+
 // ListSort defines sorting fields on a release list.
 type ListSort struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -194,7 +233,7 @@ func (m *ListSort) Reset()         { *m = ListSort{} }
 func (m *ListSort) String() string { return proto.CompactTextString(m) }
 func (*ListSort) ProtoMessage()    {}
 func (*ListSort) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_0094511522f6b040, []int{1}
+	return fileDescriptor_tiller_c088ace695169374, []int{1}
 }
 func (m *ListSort) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListSort.Unmarshal(m, b)
@@ -234,7 +273,7 @@ func (m *ListReleasesResponse) Reset()         { *m = ListReleasesResponse{} }
 func (m *ListReleasesResponse) String() string { return proto.CompactTextString(m) }
 func (*ListReleasesResponse) ProtoMessage()    {}
 func (*ListReleasesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_0094511522f6b040, []int{2}
+	return fileDescriptor_tiller_c088ace695169374, []int{2}
 }
 func (m *ListReleasesResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListReleasesResponse.Unmarshal(m, b)
@@ -287,7 +326,10 @@ type GetReleaseStatusRequest struct {
 	// Name is the name of the release
 	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	// Version is the version of the release
-	Version              int32    `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Version int32 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	// Inventory requests the status of each resource of the release, from the
+	// cluster.
+	Inventory            bool     `protobuf:"varint,3,opt,name=inventory,proto3" json:"inventory,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -297,7 +339,7 @@ func (m *GetReleaseStatusRequest) Reset()         { *m = GetReleaseStatusRequest
 func (m *GetReleaseStatusRequest) String() string { return proto.CompactTextString(m) }
 func (*GetReleaseStatusRequest) ProtoMessage()    {}
 func (*GetReleaseStatusRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_0094511522f6b040, []int{3}
+	return fileDescriptor_tiller_c088ace695169374, []int{3}
 }
 func (m *GetReleaseStatusRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetReleaseStatusRequest.Unmarshal(m, b)
@@ -331,6 +373,13 @@ func (m *GetReleaseStatusRequest) GetVersion() int32 {
 	return 0
 }
 
+func (m *GetReleaseStatusRequest) GetInventory() bool {
+	if m != nil {
+		return m.Inventory
+	}
+	return false
+}
+
 // GetReleaseStatusResponse is the response indicating the status of the named release.
 type GetReleaseStatusResponse struct {
 	// Name is the name of the release.
@@ -338,17 +387,23 @@ type GetReleaseStatusResponse struct {
 	// Info contains information about the release.
 	Info *release.Info `protobuf:"bytes,2,opt,name=info,proto3" json:"info,omitempty"`
 	// Namespace the release was released into
-	Namespace            string   `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Namespace string `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Environment is the environment values file the release was deployed
+	// with, if any.
+	Environment string `protobuf:"bytes,4,opt,name=environment,proto3" json:"environment,omitempty"`
+	// Inventory is the status of each resource of the release, in the order
+	// of the manifest, if it was requested.
+	Inventory            []*ResourceStatus `protobuf:"bytes,5,rep,name=inventory,proto3" json:"inventory,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *GetReleaseStatusResponse) Reset()         { *m = GetReleaseStatusResponse{} }
 func (m *GetReleaseStatusResponse) String() string { return proto.CompactTextString(m) }
 func (*GetReleaseStatusResponse) ProtoMessage()    {}
 func (*GetReleaseStatusResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_0094511522f6b040, []int{4}
+	return fileDescriptor_tiller_c088ace695169374, []int{4}
 }
 func (m *GetReleaseStatusResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetReleaseStatusResponse.Unmarshal(m, b)
@@ -389,6 +444,110 @@ func (m *GetReleaseStatusResponse) GetNamespace() string {
 	return ""
 }
 
+func (m *GetReleaseStatusResponse) GetEnvironment() string {
+	if m != nil {
+		return m.Environment
+	}
+	return ""
+}
+
+func (m *GetReleaseStatusResponse) GetInventory() []*ResourceStatus {
+	if m != nil {
+		return m.Inventory
+	}
+	return nil
+}
+
+// ResourceStatus is the status of a resource of a release in the cluster.
+type ResourceStatus struct {
+	ApiVersion string `protobuf:"bytes,1,opt,name=api_version,json=apiVersion,proto3" json:"api_version,omitempty"`
+	Kind       string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Name       string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace  string `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Exists is false if the resource is not found in the cluster.
+	Exists bool `protobuf:"varint,5,opt,name=exists,proto3" json:"exists,omitempty"`
+	// Ready is whether the resource is ready, as --wait waits for it.
+	Ready bool `protobuf:"varint,6,opt,name=ready,proto3" json:"ready,omitempty"`
+	// Reason tells why the resource is not ready.
+	Reason               string   `protobuf:"bytes,7,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResourceStatus) Reset()         { *m = ResourceStatus{} }
+func (m *ResourceStatus) String() string { return proto.CompactTextString(m) }
+func (*ResourceStatus) ProtoMessage()    {}
+func (*ResourceStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_tiller_c088ace695169374, []int{5}
+}
+func (m *ResourceStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResourceStatus.Unmarshal(m, b)
+}
+func (m *ResourceStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResourceStatus.Marshal(b, m, deterministic)
+}
+func (dst *ResourceStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResourceStatus.Merge(dst, src)
+}
+func (m *ResourceStatus) XXX_Size() int {
+	return xxx_messageInfo_ResourceStatus.Size(m)
+}
+func (m *ResourceStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResourceStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResourceStatus proto.InternalMessageInfo
+
+func (m *ResourceStatus) GetApiVersion() string {
+	if m != nil {
+		return m.ApiVersion
+	}
+	return ""
+}
+
+func (m *ResourceStatus) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *ResourceStatus) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ResourceStatus) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *ResourceStatus) GetExists() bool {
+	if m != nil {
+		return m.Exists
+	}
+	return false
+}
+
+func (m *ResourceStatus) GetReady() bool {
+	if m != nil {
+		return m.Ready
+	}
+	return false
+}
+
+func (m *ResourceStatus) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
 // GetReleaseContentRequest is a request to get the contents of a release.
 type GetReleaseContentRequest struct {
 	// The name of the release
@@ -404,7 +563,7 @@ func (m *GetReleaseContentRequest) Reset()         { *m = GetReleaseContentReque
 func (m *GetReleaseContentRequest) String() string { return proto.CompactTextString(m) }
 func (*GetReleaseContentRequest) ProtoMessage()    {}
 func (*GetReleaseContentRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_0094511522f6b040, []int{5}
+	return fileDescriptor_tiller_c088ace695169374, []int{6}
 }
 func (m *GetReleaseContentRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetReleaseContentRequest.Unmarshal(m, b)
@@ -451,7 +610,7 @@ func (m *GetReleaseContentResponse) Reset()         { *m = GetReleaseContentResp
 func (m *GetReleaseContentResponse) String() string { return proto.CompactTextString(m) }
 func (*GetReleaseContentResponse) ProtoMessage()    {}
 func (*GetReleaseContentResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_0094511522f6b040, []int{6}
+	return fileDescriptor_tiller_c088ace695169374, []int{7}
 }
 func (m *GetReleaseContentResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetReleaseContentResponse.Unmarshal(m, b)
@@ -509,7 +668,21 @@ type UpdateReleaseRequest struct {
 	// Render subchart notes if enabled
 	SubNotes bool `protobuf:"varint,13,opt,name=subNotes,proto3" json:"subNotes,omitempty"`
 	// Allow deletion of new resources created in this update when update failed
-	CleanupOnFail        bool     `protobuf:"varint,14,opt,name=cleanup_on_fail,json=cleanupOnFail,proto3" json:"cleanup_on_fail,omitempty"`
+	CleanupOnFail bool `protobuf:"varint,14,opt,name=cleanup_on_fail,json=cleanupOnFail,proto3" json:"cleanup_on_fail,omitempty"`
+	// ForceCrds applies the custom resource definitions of the crds/ directory
+	// of the chart, which are otherwise only created on install.
+	ForceCrds bool `protobuf:"varint,15,opt,name=force_crds,json=forceCrds,proto3" json:"force_crds,omitempty"`
+	// ReuseValuesStrategy is how the values of the last release are combined
+	// with the values of the request when reuse_values is set: "merge" (the
+	// default), "replace" or "deep".
+	ReuseValuesStrategy string `protobuf:"bytes,16,opt,name=reuse_values_strategy,json=reuseValuesStrategy,proto3" json:"reuse_values_strategy,omitempty"`
+	// Environment is the environment values file of the chart merged into
+	// the chart values, recorded in the release.
+	Environment string `protobuf:"bytes,17,opt,name=environment,proto3" json:"environment,omitempty"`
+	// ThreeWayMerge patches the resources with a three-way merge of the
+	// previous manifest, the new manifest and the live objects, like
+	// kubectl apply.
+	ThreeWayMerge        bool     `protobuf:"varint,18,opt,name=three_way_merge,json=threeWayMerge,proto3" json:"three_way_merge,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -519,7 +692,7 @@ func (m *UpdateReleaseRequest) Reset()         { *m = UpdateReleaseRequest{} }
 func (m *UpdateReleaseRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateReleaseRequest) ProtoMessage()    {}
 func (*UpdateReleaseRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_0094511522f6b040, []int{7}
+	return fileDescriptor_tiller_c088ace695169374, []int{8}
 }
 func (m *UpdateReleaseRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateReleaseRequest.Unmarshal(m, b)
@@ -637,6 +810,34 @@ func (m *UpdateReleaseRequest) GetCleanupOnFail() bool {
 	return false
 }
 
+func (m *UpdateReleaseRequest) GetForceCrds() bool {
+	if m != nil {
+		return m.ForceCrds
+	}
+	return false
+}
+
+func (m *UpdateReleaseRequest) GetReuseValuesStrategy() string {
+	if m != nil {
+		return m.ReuseValuesStrategy
+	}
+	return ""
+}
+
+func (m *UpdateReleaseRequest) GetEnvironment() string {
+	if m != nil {
+		return m.Environment
+	}
+	return ""
+}
+
+func (m *UpdateReleaseRequest) GetThreeWayMerge() bool {
+	if m != nil {
+		return m.ThreeWayMerge
+	}
+	return false
+}
+
 // UpdateReleaseResponse is the response to an update request.
 type UpdateReleaseResponse struct {
 	Release              *release.Release `protobuf:"bytes,1,opt,name=release,proto3" json:"release,omitempty"`
@@ -649,7 +850,7 @@ func (m *UpdateReleaseResponse) Reset()         { *m = UpdateReleaseResponse{} }
 func (m *UpdateReleaseResponse) String() string { return proto.CompactTextString(m) }
 func (*UpdateReleaseResponse) ProtoMessage()    {}
 func (*UpdateReleaseResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_0094511522f6b040, []int{8}
+	return fileDescriptor_tiller_c088ace695169374, []int{9}
 }
 func (m *UpdateReleaseResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UpdateReleaseResponse.Unmarshal(m, b)
@@ -697,17 +898,23 @@ type RollbackReleaseRequest struct {
 	// Description, if set, will set the description for the rollback
 	Description string `protobuf:"bytes,9,opt,name=description,proto3" json:"description,omitempty"`
 	// Allow deletion of new resources created in this rollback when rollback failed
-	CleanupOnFail        bool     `protobuf:"varint,10,opt,name=cleanup_on_fail,json=cleanupOnFail,proto3" json:"cleanup_on_fail,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	CleanupOnFail bool `protobuf:"varint,10,opt,name=cleanup_on_fail,json=cleanupOnFail,proto3" json:"cleanup_on_fail,omitempty"`
+	// ValuesOnly, if true, deploys the chart of the current release with the
+	// values of the version, or with values if set, instead of the version.
+	ValuesOnly bool `protobuf:"varint,11,opt,name=values_only,json=valuesOnly,proto3" json:"values_only,omitempty"`
+	// Values are the values deployed by a values only rollback instead of
+	// the values of the version.
+	Values               *chart.Config `protobuf:"bytes,12,opt,name=values,proto3" json:"values,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
 
 func (m *RollbackReleaseRequest) Reset()         { *m = RollbackReleaseRequest{} }
 func (m *RollbackReleaseRequest) String() string { return proto.CompactTextString(m) }
 func (*RollbackReleaseRequest) ProtoMessage()    {}
 func (*RollbackReleaseRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_0094511522f6b040, []int{9}
+	return fileDescriptor_tiller_c088ace695169374, []int{10}
 }
 func (m *RollbackReleaseRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RollbackReleaseRequest.Unmarshal(m, b)
@@ -797,6 +1004,20 @@ func (m *RollbackReleaseRequest) GetCleanupOnFail() bool {
 	return false
 }
 
+func (m *RollbackReleaseRequest) GetValuesOnly() bool {
+	if m != nil {
+		return m.ValuesOnly
+	}
+	return false
+}
+
+func (m *RollbackReleaseRequest) GetValues() *chart.Config {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
 // RollbackReleaseResponse is the response to an update request.
 type RollbackReleaseResponse struct {
 	Release              *release.Release `protobuf:"bytes,1,opt,name=release,proto3" json:"release,omitempty"`
@@ -809,7 +1030,7 @@ func (m *RollbackReleaseResponse) Reset()         { *m = RollbackReleaseResponse
 func (m *RollbackReleaseResponse) String() string { return proto.CompactTextString(m) }
 func (*RollbackReleaseResponse) ProtoMessage()    {}
 func (*RollbackReleaseResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_0094511522f6b040, []int{10}
+	return fileDescriptor_tiller_c088ace695169374, []int{11}
 }
 func (m *RollbackReleaseResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RollbackReleaseResponse.Unmarshal(m, b)
@@ -863,8 +1084,11 @@ type InstallReleaseRequest struct {
 	Wait           bool `protobuf:"varint,9,opt,name=wait,proto3" json:"wait,omitempty"`
 	DisableCrdHook bool `protobuf:"varint,10,opt,name=disable_crd_hook,json=disableCrdHook,proto3" json:"disable_crd_hook,omitempty"`
 	// Description, if set, will set the description for the installed release
-	Description          string   `protobuf:"bytes,11,opt,name=description,proto3" json:"description,omitempty"`
-	SubNotes             bool     `protobuf:"varint,12,opt,name=subNotes,proto3" json:"subNotes,omitempty"`
+	Description string `protobuf:"bytes,11,opt,name=description,proto3" json:"description,omitempty"`
+	SubNotes    bool   `protobuf:"varint,12,opt,name=subNotes,proto3" json:"subNotes,omitempty"`
+	// Environment is the environment values file of the chart merged into
+	// the chart values, recorded in the release.
+	Environment          string   `protobuf:"bytes,13,opt,name=environment,proto3" json:"environment,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -874,7 +1098,7 @@ func (m *InstallReleaseRequest) Reset()         { *m = InstallReleaseRequest{} }
 func (m *InstallReleaseRequest) String() string { return proto.CompactTextString(m) }
 func (*InstallReleaseRequest) ProtoMessage()    {}
 func (*InstallReleaseRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_0094511522f6b040, []int{11}
+	return fileDescriptor_tiller_c088ace695169374, []int{12}
 }
 func (m *InstallReleaseRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_InstallReleaseRequest.Unmarshal(m, b)
@@ -978,6 +1202,13 @@ func (m *InstallReleaseRequest) GetSubNotes() bool {
 	return false
 }
 
+func (m *InstallReleaseRequest) GetEnvironment() string {
+	if m != nil {
+		return m.Environment
+	}
+	return ""
+}
+
 // InstallReleaseResponse is the response from a release installation.
 type InstallReleaseResponse struct {
 	Release              *release.Release `protobuf:"bytes,1,opt,name=release,proto3" json:"release,omitempty"`
@@ -990,7 +1221,7 @@ func (m *InstallReleaseResponse) Reset()         { *m = InstallReleaseResponse{}
 func (m *InstallReleaseResponse) String() string { return proto.CompactTextString(m) }
 func (*InstallReleaseResponse) ProtoMessage()    {}
 func (*InstallReleaseResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_0094511522f6b040, []int{12}
+	return fileDescriptor_tiller_c088ace695169374, []int{13}
 }
 func (m *InstallReleaseResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_InstallReleaseResponse.Unmarshal(m, b)
@@ -1028,7 +1259,10 @@ type UninstallReleaseRequest struct {
 	// timeout specifies the max amount of time any kubernetes client command can run.
 	Timeout int64 `protobuf:"varint,4,opt,name=timeout,proto3" json:"timeout,omitempty"`
 	// Description, if set, will set the description for the uninstalled release
-	Description          string   `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	Description string `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	// Cascade is the propagation policy of the deletion of the resources:
+	// background, foreground or orphan. The default is background.
+	Cascade              string   `protobuf:"bytes,6,opt,name=cascade,proto3" json:"cascade,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1038,7 +1272,7 @@ func (m *UninstallReleaseRequest) Reset()         { *m = UninstallReleaseRequest
 func (m *UninstallReleaseRequest) String() string { return proto.CompactTextString(m) }
 func (*UninstallReleaseRequest) ProtoMessage()    {}
 func (*UninstallReleaseRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_0094511522f6b040, []int{13}
+	return fileDescriptor_tiller_c088ace695169374, []int{14}
 }
 func (m *UninstallReleaseRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UninstallReleaseRequest.Unmarshal(m, b)
@@ -1093,6 +1327,13 @@ func (m *UninstallReleaseRequest) GetDescription() string {
 	return ""
 }
 
+func (m *UninstallReleaseRequest) GetCascade() string {
+	if m != nil {
+		return m.Cascade
+	}
+	return ""
+}
+
 // UninstallReleaseResponse represents a successful response to an uninstall request.
 type UninstallReleaseResponse struct {
 	// Release is the release that was marked deleted.
@@ -1108,7 +1349,7 @@ func (m *UninstallReleaseResponse) Reset()         { *m = UninstallReleaseRespon
 func (m *UninstallReleaseResponse) String() string { return proto.CompactTextString(m) }
 func (*UninstallReleaseResponse) ProtoMessage()    {}
 func (*UninstallReleaseResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_0094511522f6b040, []int{14}
+	return fileDescriptor_tiller_c088ace695169374, []int{15}
 }
 func (m *UninstallReleaseResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UninstallReleaseResponse.Unmarshal(m, b)
@@ -1153,7 +1394,7 @@ func (m *GetVersionRequest) Reset()         { *m = GetVersionRequest{} }
 func (m *GetVersionRequest) String() string { return proto.CompactTextString(m) }
 func (*GetVersionRequest) ProtoMessage()    {}
 func (*GetVersionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_0094511522f6b040, []int{15}
+	return fileDescriptor_tiller_c088ace695169374, []int{16}
 }
 func (m *GetVersionRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetVersionRequest.Unmarshal(m, b)
@@ -1184,7 +1425,7 @@ func (m *GetVersionResponse) Reset()         { *m = GetVersionResponse{} }
 func (m *GetVersionResponse) String() string { return proto.CompactTextString(m) }
 func (*GetVersionResponse) ProtoMessage()    {}
 func (*GetVersionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_0094511522f6b040, []int{16}
+	return fileDescriptor_tiller_c088ace695169374, []int{17}
 }
 func (m *GetVersionResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetVersionResponse.Unmarshal(m, b)
@@ -1226,7 +1467,7 @@ func (m *GetHistoryRequest) Reset()         { *m = GetHistoryRequest{} }
 func (m *GetHistoryRequest) String() string { return proto.CompactTextString(m) }
 func (*GetHistoryRequest) ProtoMessage()    {}
 func (*GetHistoryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_0094511522f6b040, []int{17}
+	return fileDescriptor_tiller_c088ace695169374, []int{18}
 }
 func (m *GetHistoryRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetHistoryRequest.Unmarshal(m, b)
@@ -1272,7 +1513,7 @@ func (m *GetHistoryResponse) Reset()         { *m = GetHistoryResponse{} }
 func (m *GetHistoryResponse) String() string { return proto.CompactTextString(m) }
 func (*GetHistoryResponse) ProtoMessage()    {}
 func (*GetHistoryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_0094511522f6b040, []int{18}
+	return fileDescriptor_tiller_c088ace695169374, []int{19}
 }
 func (m *GetHistoryResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetHistoryResponse.Unmarshal(m, b)
@@ -1322,7 +1563,7 @@ func (m *TestReleaseRequest) Reset()         { *m = TestReleaseRequest{} }
 func (m *TestReleaseRequest) String() string { return proto.CompactTextString(m) }
 func (*TestReleaseRequest) ProtoMessage()    {}
 func (*TestReleaseRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_0094511522f6b040, []int{19}
+	return fileDescriptor_tiller_c088ace695169374, []int{20}
 }
 func (m *TestReleaseRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TestReleaseRequest.Unmarshal(m, b)
@@ -1397,7 +1638,7 @@ func (m *TestReleaseResponse) Reset()         { *m = TestReleaseResponse{} }
 func (m *TestReleaseResponse) String() string { return proto.CompactTextString(m) }
 func (*TestReleaseResponse) ProtoMessage()    {}
 func (*TestReleaseResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_tiller_0094511522f6b040, []int{20}
+	return fileDescriptor_tiller_c088ace695169374, []int{21}
 }
 func (m *TestReleaseResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TestReleaseResponse.Unmarshal(m, b)
@@ -1431,12 +1672,457 @@ func (m *TestReleaseResponse) GetStatus() release.TestRun_Status {
 	return release.TestRun_UNKNOWN
 }
 
+// ImportReleaseHistoryRequest is a request to store a release history that
+// was exported from another Tiller.
+type ImportReleaseHistoryRequest struct {
+	// Releases are the revisions of a single release, in any order.
+	Releases []*release.Release `protobuf:"bytes,1,rep,name=releases,proto3" json:"releases,omitempty"`
+	// Namespace, if set, replaces the namespace recorded in every revision.
+	Namespace string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Name, if set, replaces the release name recorded in every revision.
+	Name                 string   `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ImportReleaseHistoryRequest) Reset()         { *m = ImportReleaseHistoryRequest{} }
+func (m *ImportReleaseHistoryRequest) String() string { return proto.CompactTextString(m) }
+func (*ImportReleaseHistoryRequest) ProtoMessage()    {}
+func (*ImportReleaseHistoryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_tiller_c088ace695169374, []int{22}
+}
+func (m *ImportReleaseHistoryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportReleaseHistoryRequest.Unmarshal(m, b)
+}
+func (m *ImportReleaseHistoryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportReleaseHistoryRequest.Marshal(b, m, deterministic)
+}
+func (dst *ImportReleaseHistoryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportReleaseHistoryRequest.Merge(dst, src)
+}
+func (m *ImportReleaseHistoryRequest) XXX_Size() int {
+	return xxx_messageInfo_ImportReleaseHistoryRequest.Size(m)
+}
+func (m *ImportReleaseHistoryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportReleaseHistoryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportReleaseHistoryRequest proto.InternalMessageInfo
+
+func (m *ImportReleaseHistoryRequest) GetReleases() []*release.Release {
+	if m != nil {
+		return m.Releases
+	}
+	return nil
+}
+
+func (m *ImportReleaseHistoryRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *ImportReleaseHistoryRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+// ImportReleaseHistoryResponse is the response to an import request.
+type ImportReleaseHistoryResponse struct {
+	// Release is the most recent revision of the imported history.
+	Release              *release.Release `protobuf:"bytes,1,opt,name=release,proto3" json:"release,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *ImportReleaseHistoryResponse) Reset()         { *m = ImportReleaseHistoryResponse{} }
+func (m *ImportReleaseHistoryResponse) String() string { return proto.CompactTextString(m) }
+func (*ImportReleaseHistoryResponse) ProtoMessage()    {}
+func (*ImportReleaseHistoryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_tiller_c088ace695169374, []int{23}
+}
+func (m *ImportReleaseHistoryResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportReleaseHistoryResponse.Unmarshal(m, b)
+}
+func (m *ImportReleaseHistoryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportReleaseHistoryResponse.Marshal(b, m, deterministic)
+}
+func (dst *ImportReleaseHistoryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportReleaseHistoryResponse.Merge(dst, src)
+}
+func (m *ImportReleaseHistoryResponse) XXX_Size() int {
+	return xxx_messageInfo_ImportReleaseHistoryResponse.Size(m)
+}
+func (m *ImportReleaseHistoryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportReleaseHistoryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportReleaseHistoryResponse proto.InternalMessageInfo
+
+func (m *ImportReleaseHistoryResponse) GetRelease() *release.Release {
+	if m != nil {
+		return m.Release
+	}
+	return nil
+}
+
+// PruneReleaseHistoryRequest is a request to remove revisions from the history
+// of a release. The most recent and the last deployed revisions are always kept.
+type PruneReleaseHistoryRequest struct {
+	// The name of the release.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Max is the maximum number of revisions to keep.
+	Max int32 `protobuf:"varint,2,opt,name=max,proto3" json:"max,omitempty"`
+	// MaxAge is the maximum age in seconds of the revisions to keep.
+	MaxAge int64 `protobuf:"varint,3,opt,name=max_age,json=maxAge,proto3" json:"max_age,omitempty"`
+	// MaxBytes is the maximum encoded size of the revisions to keep.
+	MaxBytes int64 `protobuf:"varint,4,opt,name=max_bytes,json=maxBytes,proto3" json:"max_bytes,omitempty"`
+	// DryRun, if true, reports the revisions to remove without removing them.
+	DryRun               bool     `protobuf:"varint,5,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PruneReleaseHistoryRequest) Reset()         { *m = PruneReleaseHistoryRequest{} }
+func (m *PruneReleaseHistoryRequest) String() string { return proto.CompactTextString(m) }
+func (*PruneReleaseHistoryRequest) ProtoMessage()    {}
+func (*PruneReleaseHistoryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_tiller_c088ace695169374, []int{24}
+}
+func (m *PruneReleaseHistoryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PruneReleaseHistoryRequest.Unmarshal(m, b)
+}
+func (m *PruneReleaseHistoryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PruneReleaseHistoryRequest.Marshal(b, m, deterministic)
+}
+func (dst *PruneReleaseHistoryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PruneReleaseHistoryRequest.Merge(dst, src)
+}
+func (m *PruneReleaseHistoryRequest) XXX_Size() int {
+	return xxx_messageInfo_PruneReleaseHistoryRequest.Size(m)
+}
+func (m *PruneReleaseHistoryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PruneReleaseHistoryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PruneReleaseHistoryRequest proto.InternalMessageInfo
+
+func (m *PruneReleaseHistoryRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *PruneReleaseHistoryRequest) GetMax() int32 {
+	if m != nil {
+		return m.Max
+	}
+	return 0
+}
+
+func (m *PruneReleaseHistoryRequest) GetMaxAge() int64 {
+	if m != nil {
+		return m.MaxAge
+	}
+	return 0
+}
+
+func (m *PruneReleaseHistoryRequest) GetMaxBytes() int64 {
+	if m != nil {
+		return m.MaxBytes
+	}
+	return 0
+}
+
+func (m *PruneReleaseHistoryRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+// PruneReleaseHistoryResponse is the response to a prune request.
+type PruneReleaseHistoryResponse struct {
+	// Releases are the removed revisions.
+	Releases             []*release.Release `protobuf:"bytes,1,rep,name=releases,proto3" json:"releases,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *PruneReleaseHistoryResponse) Reset()         { *m = PruneReleaseHistoryResponse{} }
+func (m *PruneReleaseHistoryResponse) String() string { return proto.CompactTextString(m) }
+func (*PruneReleaseHistoryResponse) ProtoMessage()    {}
+func (*PruneReleaseHistoryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_tiller_c088ace695169374, []int{25}
+}
+func (m *PruneReleaseHistoryResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PruneReleaseHistoryResponse.Unmarshal(m, b)
+}
+func (m *PruneReleaseHistoryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PruneReleaseHistoryResponse.Marshal(b, m, deterministic)
+}
+func (dst *PruneReleaseHistoryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PruneReleaseHistoryResponse.Merge(dst, src)
+}
+func (m *PruneReleaseHistoryResponse) XXX_Size() int {
+	return xxx_messageInfo_PruneReleaseHistoryResponse.Size(m)
+}
+func (m *PruneReleaseHistoryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PruneReleaseHistoryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PruneReleaseHistoryResponse proto.InternalMessageInfo
+
+func (m *PruneReleaseHistoryResponse) GetReleases() []*release.Release {
+	if m != nil {
+		return m.Releases
+	}
+	return nil
+}
+
+// AuditRecord records an operation changing a release.
+type AuditRecord struct {
+	// Time is the time at which the operation ended.
+	Time *timestamp.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	// Release is the name of the release.
+	Release string `protobuf:"bytes,2,opt,name=release,proto3" json:"release,omitempty"`
+	// Namespace is the namespace of the release.
+	Namespace string `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Operation is install, upgrade, rollback or delete.
+	Operation string `protobuf:"bytes,4,opt,name=operation,proto3" json:"operation,omitempty"`
+	// Revision is the revision of the release the operation made.
+	Revision int32 `protobuf:"varint,5,opt,name=revision,proto3" json:"revision,omitempty"`
+	// User identifies the client that requested the operation.
+	User string `protobuf:"bytes,6,opt,name=user,proto3" json:"user,omitempty"`
+	// ClientVersion is the version of the Helm client.
+	ClientVersion string `protobuf:"bytes,7,opt,name=client_version,json=clientVersion,proto3" json:"client_version,omitempty"`
+	// Chart is the name of the chart of the release.
+	Chart string `protobuf:"bytes,8,opt,name=chart,proto3" json:"chart,omitempty"`
+	// ChartVersion is the version of the chart of the release.
+	ChartVersion string `protobuf:"bytes,9,opt,name=chart_version,json=chartVersion,proto3" json:"chart_version,omitempty"`
+	// ValuesDigest is the SHA-256 digest of the values supplied to the operation.
+	ValuesDigest string `protobuf:"bytes,10,opt,name=values_digest,json=valuesDigest,proto3" json:"values_digest,omitempty"`
+	// Success is whether the operation succeeded.
+	Success bool `protobuf:"varint,11,opt,name=success,proto3" json:"success,omitempty"`
+	// Error is the error of a failed operation.
+	Error                string   `protobuf:"bytes,12,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AuditRecord) Reset()         { *m = AuditRecord{} }
+func (m *AuditRecord) String() string { return proto.CompactTextString(m) }
+func (*AuditRecord) ProtoMessage()    {}
+func (*AuditRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_tiller_c088ace695169374, []int{26}
+}
+func (m *AuditRecord) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuditRecord.Unmarshal(m, b)
+}
+func (m *AuditRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuditRecord.Marshal(b, m, deterministic)
+}
+func (dst *AuditRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuditRecord.Merge(dst, src)
+}
+func (m *AuditRecord) XXX_Size() int {
+	return xxx_messageInfo_AuditRecord.Size(m)
+}
+func (m *AuditRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuditRecord.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuditRecord proto.InternalMessageInfo
+
+func (m *AuditRecord) GetTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+func (m *AuditRecord) GetRelease() string {
+	if m != nil {
+		return m.Release
+	}
+	return ""
+}
+
+func (m *AuditRecord) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *AuditRecord) GetOperation() string {
+	if m != nil {
+		return m.Operation
+	}
+	return ""
+}
+
+func (m *AuditRecord) GetRevision() int32 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
+func (m *AuditRecord) GetUser() string {
+	if m != nil {
+		return m.User
+	}
+	return ""
+}
+
+func (m *AuditRecord) GetClientVersion() string {
+	if m != nil {
+		return m.ClientVersion
+	}
+	return ""
+}
+
+func (m *AuditRecord) GetChart() string {
+	if m != nil {
+		return m.Chart
+	}
+	return ""
+}
+
+func (m *AuditRecord) GetChartVersion() string {
+	if m != nil {
+		return m.ChartVersion
+	}
+	return ""
+}
+
+func (m *AuditRecord) GetValuesDigest() string {
+	if m != nil {
+		return m.ValuesDigest
+	}
+	return ""
+}
+
+func (m *AuditRecord) GetSuccess() bool {
+	if m != nil {
+		return m.Success
+	}
+	return false
+}
+
+func (m *AuditRecord) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+// GetReleaseAuditRequest requests the audit records of a release.
+type GetReleaseAuditRequest struct {
+	// Name is the name of the release.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Max is the maximum number of records to return, the most recent first. 0 returns all the records.
+	Max                  int32    `protobuf:"varint,2,opt,name=max,proto3" json:"max,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetReleaseAuditRequest) Reset()         { *m = GetReleaseAuditRequest{} }
+func (m *GetReleaseAuditRequest) String() string { return proto.CompactTextString(m) }
+func (*GetReleaseAuditRequest) ProtoMessage()    {}
+func (*GetReleaseAuditRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_tiller_c088ace695169374, []int{27}
+}
+func (m *GetReleaseAuditRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetReleaseAuditRequest.Unmarshal(m, b)
+}
+func (m *GetReleaseAuditRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetReleaseAuditRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetReleaseAuditRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetReleaseAuditRequest.Merge(dst, src)
+}
+func (m *GetReleaseAuditRequest) XXX_Size() int {
+	return xxx_messageInfo_GetReleaseAuditRequest.Size(m)
+}
+func (m *GetReleaseAuditRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetReleaseAuditRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetReleaseAuditRequest proto.InternalMessageInfo
+
+func (m *GetReleaseAuditRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *GetReleaseAuditRequest) GetMax() int32 {
+	if m != nil {
+		return m.Max
+	}
+	return 0
+}
+
+// GetReleaseAuditResponse is the response to a GetReleaseAuditRequest.
+type GetReleaseAuditResponse struct {
+	// Records are the audit records of the release, the most recent first.
+	Records              []*AuditRecord `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *GetReleaseAuditResponse) Reset()         { *m = GetReleaseAuditResponse{} }
+func (m *GetReleaseAuditResponse) String() string { return proto.CompactTextString(m) }
+func (*GetReleaseAuditResponse) ProtoMessage()    {}
+func (*GetReleaseAuditResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_tiller_c088ace695169374, []int{28}
+}
+func (m *GetReleaseAuditResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetReleaseAuditResponse.Unmarshal(m, b)
+}
+func (m *GetReleaseAuditResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetReleaseAuditResponse.Marshal(b, m, deterministic)
+}
+func (dst *GetReleaseAuditResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetReleaseAuditResponse.Merge(dst, src)
+}
+func (m *GetReleaseAuditResponse) XXX_Size() int {
+	return xxx_messageInfo_GetReleaseAuditResponse.Size(m)
+}
+func (m *GetReleaseAuditResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetReleaseAuditResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetReleaseAuditResponse proto.InternalMessageInfo
+
+func (m *GetReleaseAuditResponse) GetRecords() []*AuditRecord {
+	if m != nil {
+		return m.Records
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*ListReleasesRequest)(nil), "hapi.services.tiller.ListReleasesRequest")
 	proto.RegisterType((*ListSort)(nil), "hapi.services.tiller.ListSort")
 	proto.RegisterType((*ListReleasesResponse)(nil), "hapi.services.tiller.ListReleasesResponse")
 	proto.RegisterType((*GetReleaseStatusRequest)(nil), "hapi.services.tiller.GetReleaseStatusRequest")
 	proto.RegisterType((*GetReleaseStatusResponse)(nil), "hapi.services.tiller.GetReleaseStatusResponse")
+	proto.RegisterType((*ResourceStatus)(nil), "hapi.services.tiller.ResourceStatus")
 	proto.RegisterType((*GetReleaseContentRequest)(nil), "hapi.services.tiller.GetReleaseContentRequest")
 	proto.RegisterType((*GetReleaseContentResponse)(nil), "hapi.services.tiller.GetReleaseContentResponse")
 	proto.RegisterType((*UpdateReleaseRequest)(nil), "hapi.services.tiller.UpdateReleaseRequest")
@@ -1453,6 +2139,13 @@ func init() {
 	proto.RegisterType((*GetHistoryResponse)(nil), "hapi.services.tiller.GetHistoryResponse")
 	proto.RegisterType((*TestReleaseRequest)(nil), "hapi.services.tiller.TestReleaseRequest")
 	proto.RegisterType((*TestReleaseResponse)(nil), "hapi.services.tiller.TestReleaseResponse")
+	proto.RegisterType((*ImportReleaseHistoryRequest)(nil), "hapi.services.tiller.ImportReleaseHistoryRequest")
+	proto.RegisterType((*ImportReleaseHistoryResponse)(nil), "hapi.services.tiller.ImportReleaseHistoryResponse")
+	proto.RegisterType((*PruneReleaseHistoryRequest)(nil), "hapi.services.tiller.PruneReleaseHistoryRequest")
+	proto.RegisterType((*PruneReleaseHistoryResponse)(nil), "hapi.services.tiller.PruneReleaseHistoryResponse")
+	proto.RegisterType((*AuditRecord)(nil), "hapi.services.tiller.AuditRecord")
+	proto.RegisterType((*GetReleaseAuditRequest)(nil), "hapi.services.tiller.GetReleaseAuditRequest")
+	proto.RegisterType((*GetReleaseAuditResponse)(nil), "hapi.services.tiller.GetReleaseAuditResponse")
 	proto.RegisterEnum("hapi.services.tiller.ListSort_SortBy", ListSort_SortBy_name, ListSort_SortBy_value)
 	proto.RegisterEnum("hapi.services.tiller.ListSort_SortOrder", ListSort_SortOrder_name, ListSort_SortOrder_value)
 }
@@ -1492,6 +2185,12 @@ type ReleaseServiceClient interface {
 	GetHistory(ctx context.Context, in *GetHistoryRequest, opts ...grpc.CallOption) (*GetHistoryResponse, error)
 	// RunReleaseTest executes the tests defined of a named release
 	RunReleaseTest(ctx context.Context, in *TestReleaseRequest, opts ...grpc.CallOption) (ReleaseService_RunReleaseTestClient, error)
+	// ImportReleaseHistory stores a previously exported release history.
+	ImportReleaseHistory(ctx context.Context, in *ImportReleaseHistoryRequest, opts ...grpc.CallOption) (*ImportReleaseHistoryResponse, error)
+	// PruneReleaseHistory removes old revisions from a release's history.
+	PruneReleaseHistory(ctx context.Context, in *PruneReleaseHistoryRequest, opts ...grpc.CallOption) (*PruneReleaseHistoryResponse, error)
+	// GetReleaseAudit returns the audit records of a release.
+	GetReleaseAudit(ctx context.Context, in *GetReleaseAuditRequest, opts ...grpc.CallOption) (*GetReleaseAuditResponse, error)
 }
 
 type releaseServiceClient struct {
@@ -1638,6 +2337,33 @@ func (x *releaseServiceRunReleaseTestClient) Recv() (*TestReleaseResponse, error
 	return m, nil
 }
 
+func (c *releaseServiceClient) ImportReleaseHistory(ctx context.Context, in *ImportReleaseHistoryRequest, opts ...grpc.CallOption) (*ImportReleaseHistoryResponse, error) {
+	out := new(ImportReleaseHistoryResponse)
+	err := c.cc.Invoke(ctx, "/hapi.services.tiller.ReleaseService/ImportReleaseHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseServiceClient) PruneReleaseHistory(ctx context.Context, in *PruneReleaseHistoryRequest, opts ...grpc.CallOption) (*PruneReleaseHistoryResponse, error) {
+	out := new(PruneReleaseHistoryResponse)
+	err := c.cc.Invoke(ctx, "/hapi.services.tiller.ReleaseService/PruneReleaseHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseServiceClient) GetReleaseAudit(ctx context.Context, in *GetReleaseAuditRequest, opts ...grpc.CallOption) (*GetReleaseAuditResponse, error) {
+	out := new(GetReleaseAuditResponse)
+	err := c.cc.Invoke(ctx, "/hapi.services.tiller.ReleaseService/GetReleaseAudit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ReleaseServiceServer is the server API for ReleaseService service.
 type ReleaseServiceServer interface {
 	// ListReleases retrieves release history.
@@ -1663,6 +2389,12 @@ type ReleaseServiceServer interface {
 	GetHistory(context.Context, *GetHistoryRequest) (*GetHistoryResponse, error)
 	// RunReleaseTest executes the tests defined of a named release
 	RunReleaseTest(*TestReleaseRequest, ReleaseService_RunReleaseTestServer) error
+	// ImportReleaseHistory stores a previously exported release history.
+	ImportReleaseHistory(context.Context, *ImportReleaseHistoryRequest) (*ImportReleaseHistoryResponse, error)
+	// PruneReleaseHistory removes old revisions from a release's history.
+	PruneReleaseHistory(context.Context, *PruneReleaseHistoryRequest) (*PruneReleaseHistoryResponse, error)
+	// GetReleaseAudit returns the audit records of a release.
+	GetReleaseAudit(context.Context, *GetReleaseAuditRequest) (*GetReleaseAuditResponse, error)
 }
 
 func RegisterReleaseServiceServer(s *grpc.Server, srv ReleaseServiceServer) {
@@ -1855,6 +2587,60 @@ func (x *releaseServiceRunReleaseTestServer) Send(m *TestReleaseResponse) error
 	return x.ServerStream.SendMsg(m)
 }
 
+func _ReleaseService_ImportReleaseHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportReleaseHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReleaseServiceServer).ImportReleaseHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hapi.services.tiller.ReleaseService/ImportReleaseHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReleaseServiceServer).ImportReleaseHistory(ctx, req.(*ImportReleaseHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReleaseService_PruneReleaseHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PruneReleaseHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReleaseServiceServer).PruneReleaseHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hapi.services.tiller.ReleaseService/PruneReleaseHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReleaseServiceServer).PruneReleaseHistory(ctx, req.(*PruneReleaseHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReleaseService_GetReleaseAudit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReleaseAuditRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReleaseServiceServer).GetReleaseAudit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hapi.services.tiller.ReleaseService/GetReleaseAudit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReleaseServiceServer).GetReleaseAudit(ctx, req.(*GetReleaseAuditRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _ReleaseService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "hapi.services.tiller.ReleaseService",
 	HandlerType: (*ReleaseServiceServer)(nil),
@@ -1891,6 +2677,18 @@ var _ReleaseService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetHistory",
 			Handler:    _ReleaseService_GetHistory_Handler,
 		},
+		{
+			MethodName: "ImportReleaseHistory",
+			Handler:    _ReleaseService_ImportReleaseHistory_Handler,
+		},
+		{
+			MethodName: "PruneReleaseHistory",
+			Handler:    _ReleaseService_PruneReleaseHistory_Handler,
+		},
+		{
+			MethodName: "GetReleaseAudit",
+			Handler:    _ReleaseService_GetReleaseAudit_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1907,94 +2705,134 @@ var _ReleaseService_serviceDesc = grpc.ServiceDesc{
 	Metadata: "hapi/services/tiller.proto",
 }
 
-func init() { proto.RegisterFile("hapi/services/tiller.proto", fileDescriptor_tiller_0094511522f6b040) }
-
-var fileDescriptor_tiller_0094511522f6b040 = []byte{
-	// 1369 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x58, 0xdd, 0x72, 0x1b, 0xc5,
-	0x12, 0xb6, 0xb4, 0xfa, 0x6d, 0xd9, 0x8a, 0x32, 0x71, 0xec, 0x8d, 0x4e, 0xce, 0x29, 0x9f, 0xa5,
-	0x48, 0x94, 0x40, 0x64, 0x30, 0xdc, 0x50, 0x45, 0x51, 0xe5, 0x28, 0xc6, 0x0e, 0x18, 0x87, 0x5a,
-	0x27, 0xa1, 0x8a, 0x2a, 0x4a, 0x35, 0x96, 0x46, 0xce, 0x92, 0xd5, 0x8e, 0xd8, 0x99, 0x35, 0xf6,
-	0x23, 0x70, 0xc9, 0x3b, 0x70, 0x0d, 0xaf, 0xc0, 0x2d, 0xcf, 0xc0, 0xcb, 0x50, 0xf3, 0xb7, 0xd6,
-	0xac, 0x76, 0x15, 0xe1, 0x1b, 0xed, 0xcc, 0x74, 0x4f, 0x77, 0xcf, 0xf7, 0x4d, 0xf7, 0xb4, 0x0d,
-	0xdd, 0x37, 0x78, 0x16, 0xec, 0x32, 0x12, 0x5f, 0x04, 0x23, 0xc2, 0x76, 0x79, 0x10, 0x86, 0x24,
-	0xee, 0xcf, 0x62, 0xca, 0x29, 0xda, 0x14, 0xb2, 0xbe, 0x91, 0xf5, 0x95, 0xac, 0xbb, 0x25, 0x77,
-	0x8c, 0xde, 0xe0, 0x98, 0xab, 0x5f, 0xa5, 0xdd, 0xdd, 0x9e, 0x5f, 0xa7, 0xd1, 0x24, 0x38, 0xd7,
-	0x02, 0xe5, 0x22, 0x26, 0x21, 0xc1, 0x8c, 0x98, 0xaf, 0xb5, 0xc9, 0xc8, 0x82, 0x68, 0x42, 0xb5,
-	0xe0, 0x3f, 0x96, 0x80, 0x13, 0xc6, 0x87, 0x71, 0x12, 0x69, 0xe1, 0x3d, 0x4b, 0xc8, 0x38, 0xe6,
-	0x09, 0xb3, 0x9c, 0x5d, 0x90, 0x98, 0x05, 0x34, 0x32, 0x5f, 0x25, 0xf3, 0xfe, 0x2c, 0xc3, 0x9d,
-	0xe3, 0x80, 0x71, 0x5f, 0x6d, 0x64, 0x3e, 0xf9, 0x29, 0x21, 0x8c, 0xa3, 0x4d, 0xa8, 0x86, 0xc1,
-	0x34, 0xe0, 0x6e, 0x69, 0xa7, 0xd4, 0x73, 0x7c, 0x35, 0x41, 0x5b, 0x50, 0xa3, 0x93, 0x09, 0x23,
-	0xdc, 0x2d, 0xef, 0x94, 0x7a, 0x4d, 0x5f, 0xcf, 0xd0, 0x17, 0x50, 0x67, 0x34, 0xe6, 0xc3, 0xb3,
-	0x2b, 0xd7, 0xd9, 0x29, 0xf5, 0xda, 0x7b, 0xef, 0xf7, 0xf3, 0x70, 0xea, 0x0b, 0x4f, 0xa7, 0x34,
-	0xe6, 0x7d, 0xf1, 0xf3, 0xf4, 0xca, 0xaf, 0x31, 0xf9, 0x15, 0x76, 0x27, 0x41, 0xc8, 0x49, 0xec,
-	0x56, 0x94, 0x5d, 0x35, 0x43, 0x87, 0x00, 0xd2, 0x2e, 0x8d, 0xc7, 0x24, 0x76, 0xab, 0xd2, 0x74,
-	0x6f, 0x05, 0xd3, 0x2f, 0x84, 0xbe, 0xdf, 0x64, 0x66, 0x88, 0x3e, 0x87, 0x75, 0x05, 0xc9, 0x70,
-	0x44, 0xc7, 0x84, 0xb9, 0xb5, 0x1d, 0xa7, 0xd7, 0xde, 0xbb, 0xa7, 0x4c, 0x19, 0xf8, 0x4f, 0x15,
-	0x68, 0x03, 0x3a, 0x26, 0x7e, 0x4b, 0xa9, 0x8b, 0x31, 0x43, 0xf7, 0xa1, 0x19, 0xe1, 0x29, 0x61,
-	0x33, 0x3c, 0x22, 0x6e, 0x5d, 0x46, 0x78, 0xbd, 0xe0, 0x45, 0xd0, 0x30, 0xce, 0xbd, 0xa7, 0x50,
-	0x53, 0x47, 0x43, 0x2d, 0xa8, 0xbf, 0x3a, 0xf9, 0xfa, 0xe4, 0xc5, 0x77, 0x27, 0x9d, 0x35, 0xd4,
-	0x80, 0xca, 0xc9, 0xfe, 0x37, 0x07, 0x9d, 0x12, 0xba, 0x0d, 0x1b, 0xc7, 0xfb, 0xa7, 0x2f, 0x87,
-	0xfe, 0xc1, 0xf1, 0xc1, 0xfe, 0xe9, 0xc1, 0xb3, 0x4e, 0x19, 0xb5, 0x01, 0x06, 0x47, 0xfb, 0xfe,
-	0xcb, 0xa1, 0x54, 0x71, 0xbc, 0xff, 0x41, 0x33, 0x3d, 0x03, 0xaa, 0x83, 0xb3, 0x7f, 0x3a, 0x50,
-	0x26, 0x9e, 0x1d, 0x9c, 0x0e, 0x3a, 0x25, 0xef, 0x97, 0x12, 0x6c, 0xda, 0x94, 0xb1, 0x19, 0x8d,
-	0x18, 0x11, 0x9c, 0x8d, 0x68, 0x12, 0xa5, 0x9c, 0xc9, 0x09, 0x42, 0x50, 0x89, 0xc8, 0xa5, 0x61,
-	0x4c, 0x8e, 0x85, 0x26, 0xa7, 0x1c, 0x87, 0x92, 0x2d, 0xc7, 0x57, 0x13, 0xf4, 0x31, 0x34, 0x34,
-	0x14, 0xcc, 0xad, 0xec, 0x38, 0xbd, 0xd6, 0xde, 0x5d, 0x1b, 0x20, 0xed, 0xd1, 0x4f, 0xd5, 0xbc,
-	0x43, 0xd8, 0x3e, 0x24, 0x26, 0x12, 0x85, 0x9f, 0xb9, 0x41, 0xc2, 0x2f, 0x9e, 0x12, 0x19, 0x8c,
-	0xf0, 0x8b, 0xa7, 0x04, 0xb9, 0x50, 0xd7, 0xd7, 0x4f, 0x86, 0x53, 0xf5, 0xcd, 0xd4, 0xe3, 0xe0,
-	0x2e, 0x1a, 0xd2, 0xe7, 0xca, 0xb3, 0xf4, 0x00, 0x2a, 0x22, 0x33, 0xa4, 0x99, 0xd6, 0x1e, 0xb2,
-	0xe3, 0x7c, 0x1e, 0x4d, 0xa8, 0x2f, 0xe5, 0x36, 0x75, 0x4e, 0x96, 0xba, 0xa3, 0x79, 0xaf, 0x03,
-	0x1a, 0x71, 0x12, 0xf1, 0x9b, 0xc5, 0x7f, 0x0c, 0xf7, 0x72, 0x2c, 0xe9, 0x03, 0xec, 0x42, 0x5d,
-	0x87, 0x26, 0xad, 0x15, 0xe2, 0x6a, 0xb4, 0xbc, 0xbf, 0x1c, 0xd8, 0x7c, 0x35, 0x1b, 0x63, 0x4e,
-	0x8c, 0x68, 0x49, 0x50, 0x0f, 0xa1, 0x2a, 0x2b, 0x8c, 0xc6, 0xe2, 0xb6, 0xb2, 0xad, 0xca, 0xd0,
-	0x40, 0xfc, 0xfa, 0x4a, 0x8e, 0x1e, 0x43, 0xed, 0x02, 0x87, 0x09, 0x61, 0x12, 0x88, 0x14, 0x35,
-	0xad, 0x29, 0xcb, 0x93, 0xaf, 0x35, 0xd0, 0x36, 0xd4, 0xc7, 0xf1, 0x95, 0xa8, 0x2f, 0x32, 0x25,
-	0x1b, 0x7e, 0x6d, 0x1c, 0x5f, 0xf9, 0x49, 0x84, 0xde, 0x83, 0x8d, 0x71, 0xc0, 0xf0, 0x59, 0x48,
-	0x86, 0x6f, 0x28, 0x7d, 0xcb, 0x64, 0x56, 0x36, 0xfc, 0x75, 0xbd, 0x78, 0x24, 0xd6, 0x50, 0x57,
-	0xdc, 0xa4, 0x51, 0x4c, 0x30, 0x27, 0x6e, 0x4d, 0xca, 0xd3, 0xb9, 0xc0, 0x90, 0x07, 0x53, 0x42,
-	0x13, 0x2e, 0x53, 0xc9, 0xf1, 0xcd, 0x14, 0xfd, 0x1f, 0xd6, 0x63, 0xc2, 0x08, 0x1f, 0xea, 0x28,
-	0x1b, 0x72, 0x67, 0x4b, 0xae, 0xbd, 0x56, 0x61, 0x21, 0xa8, 0xfc, 0x8c, 0x03, 0xee, 0x36, 0xa5,
-	0x48, 0x8e, 0xd5, 0xb6, 0x84, 0x11, 0xb3, 0x0d, 0xcc, 0xb6, 0x84, 0x11, 0xbd, 0x6d, 0x13, 0xaa,
-	0x13, 0x1a, 0x8f, 0x88, 0xdb, 0x92, 0x32, 0x35, 0x41, 0x3b, 0xd0, 0x1a, 0x13, 0x36, 0x8a, 0x83,
-	0x19, 0x17, 0x8c, 0xae, 0x4b, 0x4c, 0xe7, 0x97, 0xc4, 0x39, 0x58, 0x72, 0x76, 0x42, 0x39, 0x61,
-	0xee, 0x86, 0x3a, 0x87, 0x99, 0xa3, 0x07, 0x70, 0x6b, 0x14, 0x12, 0x1c, 0x25, 0xb3, 0x21, 0x8d,
-	0x86, 0x13, 0x1c, 0x84, 0x6e, 0x5b, 0xaa, 0x6c, 0xe8, 0xe5, 0x17, 0xd1, 0x97, 0x38, 0x08, 0xbd,
-	0x23, 0xb8, 0x9b, 0xa1, 0xf2, 0xa6, 0xb7, 0xe2, 0xf7, 0x32, 0x6c, 0xf9, 0x34, 0x0c, 0xcf, 0xf0,
-	0xe8, 0xed, 0x0a, 0xf7, 0x62, 0x8e, 0xc2, 0xf2, 0x72, 0x0a, 0x9d, 0x1c, 0x0a, 0xe7, 0xae, 0x7a,
-	0xc5, 0xba, 0xea, 0x16, 0xb9, 0xd5, 0x62, 0x72, 0x6b, 0x36, 0xb9, 0x86, 0xb9, 0xfa, 0x1c, 0x73,
-	0x29, 0x2d, 0x8d, 0x25, 0xb4, 0x34, 0x17, 0x69, 0xc9, 0x81, 0x1e, 0xf2, 0xa0, 0xff, 0x0a, 0xb6,
-	0x17, 0xf0, 0xba, 0x29, 0xf8, 0xbf, 0x3a, 0x70, 0xf7, 0x79, 0xc4, 0x38, 0x0e, 0xc3, 0x0c, 0xf6,
-	0x69, 0xfe, 0x95, 0x56, 0xce, 0xbf, 0xf2, 0xbf, 0xc9, 0x3f, 0xc7, 0x22, 0xcf, 0x30, 0x5d, 0x99,
-	0x63, 0x7a, 0xa5, 0x9c, 0xb4, 0x2a, 0x61, 0x2d, 0x53, 0x09, 0xd1, 0x7f, 0x01, 0x54, 0x12, 0x49,
-	0xe3, 0x8a, 0xa4, 0xa6, 0x5c, 0x39, 0xd1, 0x85, 0xcf, 0xf0, 0xda, 0xc8, 0xe7, 0x75, 0x3e, 0x23,
-	0x7b, 0xd0, 0x31, 0xf1, 0x8c, 0xe2, 0xb1, 0x8c, 0x49, 0x13, 0xd4, 0xd6, 0xeb, 0x83, 0x78, 0x2c,
-	0xa2, 0xca, 0x72, 0xdd, 0x5a, 0x9e, 0x82, 0xeb, 0x76, 0x0a, 0x7a, 0xcf, 0x61, 0x2b, 0x4b, 0xc9,
-	0x4d, 0xe9, 0xfd, 0xad, 0x04, 0xdb, 0xaf, 0xa2, 0x20, 0x97, 0xe0, 0xbc, 0xe4, 0x5a, 0x80, 0xbc,
-	0x9c, 0x03, 0xf9, 0x26, 0x54, 0x67, 0x49, 0x7c, 0x4e, 0x34, 0x85, 0x6a, 0x32, 0x8f, 0x65, 0xc5,
-	0xc6, 0x32, 0x83, 0x46, 0x75, 0x01, 0x0d, 0x6f, 0x08, 0xee, 0x62, 0x94, 0x37, 0x3c, 0xb3, 0x38,
-	0x57, 0xfa, 0x86, 0x36, 0xd5, 0x7b, 0xe9, 0xdd, 0x81, 0xdb, 0x87, 0x84, 0xbf, 0x56, 0xa9, 0xae,
-	0x01, 0xf0, 0x0e, 0x00, 0xcd, 0x2f, 0x5e, 0xfb, 0xd3, 0x4b, 0xb6, 0x3f, 0xd3, 0x60, 0x1a, 0x7d,
-	0xa3, 0xe5, 0x7d, 0x26, 0x6d, 0x1f, 0x05, 0x8c, 0xd3, 0xf8, 0x6a, 0x19, 0xb8, 0x1d, 0x70, 0xa6,
-	0xf8, 0x52, 0x3f, 0xb1, 0x62, 0xe8, 0x1d, 0xca, 0x08, 0xd2, 0xad, 0x3a, 0x82, 0xf9, 0x86, 0xa5,
-	0xb4, 0x5a, 0xc3, 0xf2, 0x47, 0x09, 0xd0, 0x4b, 0x92, 0x36, 0x4f, 0xef, 0x78, 0xec, 0x0d, 0x4f,
-	0x65, 0x9b, 0x27, 0x17, 0xea, 0xba, 0xd0, 0x68, 0x66, 0xcd, 0x54, 0xdc, 0xd6, 0x19, 0x8e, 0x71,
-	0x18, 0x92, 0x50, 0xbf, 0x9b, 0xe9, 0x5c, 0xbc, 0x53, 0x53, 0x7c, 0x39, 0x4c, 0xe5, 0x82, 0xde,
-	0x0d, 0xbf, 0x35, 0xc5, 0x97, 0xdf, 0x1a, 0x15, 0x04, 0x95, 0x90, 0x9e, 0x33, 0xfd, 0x66, 0xca,
-	0xb1, 0xf7, 0x03, 0xdc, 0xb1, 0x02, 0xd6, 0x67, 0x17, 0x18, 0xb1, 0x73, 0x1d, 0xb0, 0x18, 0xa2,
-	0x4f, 0xa1, 0xa6, 0x9a, 0x56, 0x19, 0x6e, 0x7b, 0xef, 0xbe, 0x8d, 0x85, 0x34, 0x92, 0x44, 0xba,
-	0xcb, 0xf5, 0xb5, 0xee, 0xde, 0xdf, 0x0d, 0x68, 0x9b, 0xb6, 0x4b, 0xb5, 0xd4, 0x28, 0x80, 0xf5,
-	0xf9, 0xfe, 0x12, 0x3d, 0x2a, 0xee, 0xb8, 0x33, 0x7f, 0x36, 0x74, 0x1f, 0xaf, 0xa2, 0xaa, 0x4e,
-	0xe0, 0xad, 0x7d, 0x54, 0x42, 0x0c, 0x3a, 0xd9, 0xb6, 0x0f, 0x3d, 0xc9, 0xb7, 0x51, 0xd0, 0x67,
-	0x76, 0xfb, 0xab, 0xaa, 0x1b, 0xb7, 0xe8, 0x42, 0xde, 0x43, 0xbb, 0x57, 0x43, 0xef, 0x34, 0x63,
-	0xb7, 0x87, 0xdd, 0xdd, 0x95, 0xf5, 0x53, 0xbf, 0x3f, 0xc2, 0x86, 0xd5, 0x09, 0xa0, 0x02, 0xb4,
-	0xf2, 0x3a, 0xbf, 0xee, 0x07, 0x2b, 0xe9, 0xa6, 0xbe, 0xa6, 0xd0, 0xb6, 0x4b, 0x23, 0x2a, 0x30,
-	0x90, 0xfb, 0xa6, 0x75, 0x3f, 0x5c, 0x4d, 0x39, 0x75, 0xc7, 0xa0, 0x93, 0xad, 0x4b, 0x45, 0x3c,
-	0x16, 0x54, 0xd9, 0x22, 0x1e, 0x8b, 0xca, 0x9d, 0xb7, 0x86, 0x30, 0xc0, 0x75, 0x59, 0x42, 0x0f,
-	0x0b, 0x09, 0xb1, 0xab, 0x59, 0xb7, 0xf7, 0x6e, 0xc5, 0xd4, 0xc5, 0x0c, 0x6e, 0x65, 0x3a, 0x08,
-	0x54, 0x00, 0x4d, 0x7e, 0x63, 0xd6, 0x7d, 0xb2, 0xa2, 0x76, 0xe6, 0x50, 0xba, 0xd2, 0x2d, 0x39,
-	0x94, 0x5d, 0x46, 0x97, 0x1c, 0x2a, 0x53, 0x34, 0xbd, 0x35, 0x14, 0x40, 0xdb, 0x4f, 0x22, 0xed,
-	0x5a, 0x94, 0x05, 0x54, 0xb0, 0x7b, 0xb1, 0x50, 0x76, 0x1f, 0xad, 0xa0, 0x79, 0x9d, 0xdf, 0x4f,
-	0xe1, 0xfb, 0x86, 0x51, 0x3d, 0xab, 0xc9, 0xff, 0x38, 0x7c, 0xf2, 0x4f, 0x00, 0x00, 0x00, 0xff,
-	0xff, 0x8a, 0x23, 0x76, 0x0d, 0x5f, 0x11, 0x00, 0x00,
+func init() { proto.RegisterFile("hapi/services/tiller.proto", fileDescriptor_tiller_c088ace695169374) }
+
+var fileDescriptor_tiller_c088ace695169374 = []byte{
+	// 2010 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x58, 0x4f, 0x6f, 0xdb, 0xc8,
+	0x15, 0x5f, 0x5a, 0x7f, 0x2c, 0x3d, 0x59, 0x8a, 0x3d, 0x76, 0x6c, 0x86, 0x49, 0x1b, 0x2f, 0xb7,
+	0x9b, 0xd5, 0x6e, 0x1b, 0x79, 0xe3, 0xf6, 0x52, 0xb4, 0x58, 0xc0, 0x76, 0xdc, 0x38, 0x6d, 0xd6,
+	0x0e, 0xe8, 0x24, 0x0b, 0x14, 0x28, 0x84, 0x31, 0x39, 0x72, 0xd8, 0x50, 0xa4, 0x3a, 0x33, 0x74,
+	0x2c, 0xa0, 0x40, 0x81, 0xde, 0x7a, 0xec, 0x27, 0xe9, 0xa1, 0xe7, 0xf6, 0x13, 0xf4, 0x13, 0xf4,
+	0xde, 0x7e, 0x88, 0xf6, 0xb2, 0x98, 0x7f, 0x34, 0x49, 0x51, 0xb6, 0xec, 0x8b, 0x34, 0xef, 0xcf,
+	0xcc, 0x7b, 0xf3, 0xde, 0x6f, 0xde, 0xbc, 0x21, 0x38, 0xef, 0xf1, 0x24, 0xdc, 0x61, 0x84, 0x5e,
+	0x84, 0x3e, 0x61, 0x3b, 0x3c, 0x8c, 0x22, 0x42, 0x07, 0x13, 0x9a, 0xf0, 0x04, 0x6d, 0x08, 0xd9,
+	0xc0, 0xc8, 0x06, 0x4a, 0xe6, 0x3c, 0x3e, 0x4f, 0x92, 0xf3, 0x88, 0xec, 0x48, 0x9d, 0xb3, 0x74,
+	0xb4, 0xc3, 0xc3, 0x31, 0x61, 0x1c, 0x8f, 0x27, 0x6a, 0x9a, 0xb3, 0x29, 0x97, 0xf4, 0xdf, 0x63,
+	0xca, 0xd5, 0xaf, 0xe6, 0x6f, 0xe5, 0xf9, 0x49, 0x3c, 0x0a, 0xcf, 0xb5, 0x40, 0xf9, 0x40, 0x49,
+	0x44, 0x30, 0x23, 0xe6, 0xbf, 0x30, 0xc9, 0xc8, 0xc2, 0x78, 0x94, 0x68, 0xc1, 0xc3, 0x82, 0x80,
+	0x13, 0xc6, 0x87, 0x34, 0x8d, 0xb5, 0xf0, 0x41, 0x41, 0xc8, 0x38, 0xe6, 0x29, 0x2b, 0x18, 0xbb,
+	0x20, 0x94, 0x85, 0x49, 0x6c, 0xfe, 0x95, 0xcc, 0xfd, 0x4f, 0x0d, 0xd6, 0x5f, 0x85, 0x8c, 0x7b,
+	0x6a, 0x22, 0xf3, 0xc8, 0x1f, 0x52, 0xc2, 0x38, 0xda, 0x80, 0x46, 0x14, 0x8e, 0x43, 0x6e, 0x5b,
+	0xdb, 0x56, 0xbf, 0xe6, 0x29, 0x02, 0x6d, 0x42, 0x33, 0x19, 0x8d, 0x18, 0xe1, 0xf6, 0xd2, 0xb6,
+	0xd5, 0x6f, 0x7b, 0x9a, 0x42, 0xdf, 0xc0, 0x32, 0x4b, 0x28, 0x1f, 0x9e, 0x4d, 0xed, 0xda, 0xb6,
+	0xd5, 0xef, 0xed, 0x7e, 0x3e, 0xa8, 0x0a, 0xe4, 0x40, 0x58, 0x3a, 0x4d, 0x28, 0x1f, 0x88, 0x9f,
+	0xfd, 0xa9, 0xd7, 0x64, 0xf2, 0x5f, 0xac, 0x3b, 0x0a, 0x23, 0x4e, 0xa8, 0x5d, 0x57, 0xeb, 0x2a,
+	0x0a, 0xbd, 0x00, 0x90, 0xeb, 0x26, 0x34, 0x20, 0xd4, 0x6e, 0xc8, 0xa5, 0xfb, 0x0b, 0x2c, 0x7d,
+	0x22, 0xf4, 0xbd, 0x36, 0x33, 0x43, 0xf4, 0x4b, 0x58, 0x51, 0x21, 0x19, 0xfa, 0x49, 0x40, 0x98,
+	0xdd, 0xdc, 0xae, 0xf5, 0x7b, 0xbb, 0x0f, 0xd4, 0x52, 0x26, 0xfc, 0xa7, 0x2a, 0x68, 0x07, 0x49,
+	0x40, 0xbc, 0x8e, 0x52, 0x17, 0x63, 0x86, 0x1e, 0x41, 0x3b, 0xc6, 0x63, 0xc2, 0x26, 0xd8, 0x27,
+	0xf6, 0xb2, 0xf4, 0xf0, 0x8a, 0x81, 0x7e, 0x00, 0x20, 0x33, 0x3c, 0x14, 0x2c, 0xbb, 0xa5, 0xc4,
+	0x92, 0x73, 0x8c, 0xc7, 0x04, 0x3d, 0x86, 0x0e, 0x9e, 0x4c, 0x86, 0x3a, 0xec, 0x76, 0x5b, 0xca,
+	0x01, 0x4f, 0x26, 0xef, 0x14, 0x07, 0x6d, 0x43, 0x87, 0xc4, 0x17, 0x21, 0x4d, 0xe2, 0x31, 0x89,
+	0xb9, 0x0d, 0x52, 0x21, 0xcf, 0x42, 0x7b, 0xd0, 0x0b, 0xc8, 0x24, 0x4a, 0xa6, 0x24, 0x18, 0xe2,
+	0x91, 0x08, 0x53, 0x67, 0xdb, 0xea, 0x77, 0x76, 0x9d, 0x81, 0x02, 0xe6, 0xc0, 0x00, 0x73, 0xf0,
+	0xc6, 0x00, 0xd3, 0xeb, 0x9a, 0x19, 0x7b, 0x62, 0x82, 0x1b, 0x43, 0xcb, 0x44, 0xc8, 0xdd, 0x87,
+	0xa6, 0x8a, 0x3f, 0xea, 0xc0, 0xf2, 0xdb, 0xe3, 0xdf, 0x1c, 0x9f, 0x7c, 0x77, 0xbc, 0xfa, 0x09,
+	0x6a, 0x41, 0xfd, 0x78, 0xef, 0xdb, 0xc3, 0x55, 0x0b, 0xad, 0x41, 0xf7, 0xd5, 0xde, 0xe9, 0x9b,
+	0xa1, 0x77, 0xf8, 0xea, 0x70, 0xef, 0xf4, 0xf0, 0xf9, 0xea, 0x12, 0xea, 0x01, 0x1c, 0x1c, 0xed,
+	0x79, 0x6f, 0x86, 0x52, 0xa5, 0xe6, 0xfe, 0x10, 0xda, 0x59, 0xa0, 0xd1, 0x32, 0xd4, 0xf6, 0x4e,
+	0x0f, 0xd4, 0x12, 0xcf, 0x0f, 0x4f, 0x0f, 0x56, 0x2d, 0xf7, 0x2f, 0x16, 0x6c, 0x14, 0x71, 0xc5,
+	0x26, 0x49, 0xcc, 0x88, 0x00, 0x96, 0x9f, 0xa4, 0x71, 0x06, 0x2c, 0x49, 0x20, 0x04, 0xf5, 0x98,
+	0x5c, 0x1a, 0x58, 0xc9, 0xb1, 0xd0, 0xe4, 0x09, 0xc7, 0x91, 0x84, 0x54, 0xcd, 0x53, 0x04, 0x7a,
+	0x06, 0x2d, 0x9d, 0x2f, 0x66, 0xd7, 0xb7, 0x6b, 0xfd, 0xce, 0xee, 0xfd, 0x62, 0x16, 0xb5, 0x45,
+	0x2f, 0x53, 0x73, 0x09, 0x6c, 0xbd, 0x20, 0xc6, 0x13, 0x95, 0x64, 0x03, 0x73, 0x61, 0x57, 0x64,
+	0xcd, 0xd2, 0x76, 0x45, 0xc2, 0x6c, 0x58, 0x36, 0xc9, 0x12, 0xee, 0x34, 0x3c, 0x43, 0x0a, 0x1c,
+	0x84, 0xf1, 0x05, 0x89, 0x79, 0x42, 0x15, 0xd0, 0x5b, 0xde, 0x15, 0xc3, 0xfd, 0xb7, 0x05, 0xf6,
+	0xac, 0x1d, 0xbd, 0xed, 0x2a, 0x43, 0x4f, 0xa0, 0x2e, 0x4e, 0xb7, 0xb4, 0xd2, 0xd9, 0x45, 0xc5,
+	0x6d, 0xbc, 0x8c, 0x47, 0x89, 0x27, 0xe5, 0x45, 0xf8, 0xd5, 0xca, 0xf0, 0x2b, 0xc1, 0xa7, 0x3e,
+	0x0b, 0x9f, 0xfd, 0xbc, 0xdb, 0x0d, 0x19, 0xb3, 0x1f, 0x55, 0x1f, 0x22, 0x8f, 0xb0, 0x24, 0xa5,
+	0xbe, 0x71, 0x3e, 0xb7, 0xb9, 0x7f, 0x58, 0xd0, 0x2b, 0x4a, 0x15, 0xb0, 0xc3, 0x0c, 0xd8, 0x96,
+	0x01, 0x76, 0x68, 0x80, 0x8d, 0xa0, 0xfe, 0x21, 0x8c, 0x03, 0x93, 0x54, 0x31, 0xce, 0xe2, 0x50,
+	0xcb, 0xc5, 0xa1, 0xb0, 0xbf, 0x7a, 0x79, 0x7f, 0x9b, 0xd0, 0x24, 0x97, 0x21, 0xe3, 0x4c, 0x9e,
+	0xff, 0x96, 0xa7, 0x29, 0x01, 0x0f, 0x4a, 0x70, 0x30, 0xb5, 0x9b, 0x92, 0xad, 0x08, 0xa1, 0x4d,
+	0x09, 0x66, 0x49, 0xac, 0xcf, 0xa9, 0xa6, 0xdc, 0xa3, 0x7c, 0x6e, 0x0e, 0x92, 0x98, 0x93, 0x98,
+	0xdf, 0x09, 0x04, 0xee, 0x2b, 0x78, 0x50, 0xb1, 0x92, 0x4e, 0xf3, 0x0e, 0x2c, 0xeb, 0x04, 0xca,
+	0xd5, 0xe6, 0x82, 0xd3, 0x68, 0xb9, 0xff, 0xad, 0xc3, 0xc6, 0xdb, 0x49, 0x80, 0x39, 0x31, 0xa2,
+	0x6b, 0x9c, 0xfa, 0x02, 0x1a, 0xb2, 0xae, 0x68, 0xc4, 0xac, 0xa9, 0xb5, 0x25, 0x6b, 0x70, 0x20,
+	0x7e, 0x3d, 0x25, 0x47, 0x5f, 0x41, 0xf3, 0x02, 0x47, 0x29, 0x61, 0x76, 0x2d, 0x8f, 0x2d, 0xad,
+	0x29, 0x2f, 0x22, 0x4f, 0x6b, 0xa0, 0x2d, 0x58, 0x0e, 0xe8, 0x54, 0xdc, 0x24, 0x32, 0xf6, 0x2d,
+	0xaf, 0x19, 0xd0, 0xa9, 0x97, 0xc6, 0xe8, 0x33, 0xe8, 0x06, 0x21, 0xc3, 0x67, 0x11, 0x19, 0xbe,
+	0x4f, 0x92, 0x0f, 0x26, 0xfe, 0x2b, 0x9a, 0x79, 0x24, 0x78, 0xc8, 0x11, 0xc7, 0xd1, 0xa7, 0x04,
+	0x73, 0xa2, 0x13, 0x91, 0xd1, 0x22, 0x86, 0xe2, 0xa2, 0x4c, 0x52, 0x2e, 0x93, 0x51, 0xf3, 0x0c,
+	0x89, 0x3e, 0x85, 0x15, 0x4a, 0x18, 0xe1, 0x43, 0xed, 0x65, 0x4b, 0xce, 0xec, 0x48, 0xde, 0x3b,
+	0xe5, 0x16, 0x82, 0xfa, 0x47, 0x1c, 0x72, 0x59, 0x2f, 0x5b, 0x9e, 0x1c, 0xab, 0x69, 0x29, 0x23,
+	0x66, 0x1a, 0x98, 0x69, 0x29, 0x23, 0x7a, 0xda, 0x06, 0x34, 0x46, 0x09, 0xf5, 0x89, 0xac, 0x90,
+	0x2d, 0x4f, 0x11, 0xe2, 0x8c, 0x04, 0x84, 0xf9, 0x34, 0x9c, 0x70, 0x91, 0xd1, 0x15, 0x75, 0x46,
+	0x72, 0x2c, 0xb1, 0x0f, 0x96, 0x9e, 0x1d, 0x27, 0x9c, 0x30, 0xbb, 0xab, 0xf6, 0x61, 0x68, 0xf4,
+	0x04, 0xee, 0xf9, 0x11, 0xc1, 0x71, 0x3a, 0x19, 0x26, 0xf1, 0x70, 0x84, 0xc3, 0xc8, 0xee, 0x49,
+	0x95, 0xae, 0x66, 0x9f, 0xc4, 0xbf, 0xc2, 0x61, 0x24, 0x2e, 0x02, 0x69, 0x6e, 0xe8, 0xd3, 0x80,
+	0xd9, 0xf7, 0x54, 0x7d, 0x90, 0x9c, 0x03, 0x1a, 0x30, 0xb4, 0x0b, 0xf7, 0xf3, 0xde, 0x0f, 0x19,
+	0xa7, 0x98, 0x93, 0xf3, 0xa9, 0xbd, 0x2a, 0xdd, 0x59, 0xcf, 0x6d, 0xe3, 0x54, 0x8b, 0xca, 0x87,
+	0x7b, 0x6d, 0xf6, 0x70, 0x3f, 0x81, 0x7b, 0xfc, 0x3d, 0x25, 0x64, 0xf8, 0x11, 0x4f, 0x87, 0x63,
+	0x42, 0xcf, 0x89, 0x8d, 0x94, 0x73, 0x92, 0xfd, 0x1d, 0x9e, 0x7e, 0x2b, 0x98, 0xee, 0x11, 0xdc,
+	0x2f, 0xe1, 0xec, 0xae, 0x90, 0xfd, 0xff, 0x12, 0x6c, 0x7a, 0x49, 0x14, 0x9d, 0x61, 0xff, 0xc3,
+	0x02, 0xa0, 0xcd, 0xe1, 0x6b, 0xe9, 0x7a, 0x7c, 0xd5, 0x2a, 0xf0, 0x95, 0x3b, 0x87, 0xf5, 0x62,
+	0x31, 0xce, 0x23, 0xaf, 0x31, 0x1f, 0x79, 0xcd, 0x22, 0xf2, 0x0c, 0xac, 0x96, 0x73, 0xb0, 0xca,
+	0x30, 0xd3, 0xba, 0x06, 0x33, 0xed, 0x59, 0xcc, 0x54, 0xe0, 0x02, 0xaa, 0x70, 0xf1, 0x18, 0x3a,
+	0x3a, 0xe5, 0x49, 0x1c, 0x4d, 0x35, 0x32, 0x41, 0xb1, 0x4e, 0xe2, 0x68, 0x9a, 0x3b, 0xae, 0x2b,
+	0x37, 0x1d, 0x57, 0xf7, 0xd7, 0xb0, 0x35, 0x13, 0xfc, 0xbb, 0x66, 0xf2, 0xef, 0x35, 0xb8, 0xff,
+	0x32, 0x66, 0x1c, 0x47, 0x51, 0x29, 0x91, 0x59, 0xa5, 0xb1, 0x16, 0xae, 0x34, 0x4b, 0xb7, 0xa9,
+	0x34, 0xb5, 0x02, 0x12, 0x0c, 0x6c, 0xea, 0x39, 0xd8, 0x2c, 0x54, 0x7d, 0x0a, 0x37, 0x47, 0xb3,
+	0xa2, 0x31, 0x53, 0x07, 0x4e, 0x2e, 0xae, 0x32, 0xde, 0x96, 0x9c, 0x63, 0x5d, 0xe2, 0x0d, 0x48,
+	0x5a, 0xd5, 0x20, 0xc9, 0xd7, 0x9e, 0x3e, 0xac, 0x1a, 0x7f, 0x7c, 0x1a, 0x48, 0x9f, 0x74, 0xb6,
+	0x7b, 0x9a, 0x7f, 0x40, 0x03, 0xe1, 0x55, 0x19, 0x38, 0x9d, 0xeb, 0x8b, 0xcd, 0x4a, 0xa9, 0xd8,
+	0x94, 0x4e, 0x7c, 0x77, 0xe6, 0xc4, 0xbb, 0x2f, 0x61, 0xb3, 0x9c, 0xb4, 0xbb, 0x02, 0xe0, 0x9f,
+	0x16, 0x6c, 0xbd, 0x8d, 0xc3, 0x4a, 0x08, 0x54, 0x9d, 0xe5, 0x99, 0xa4, 0x2c, 0x55, 0x24, 0x65,
+	0x03, 0x1a, 0x93, 0x54, 0xd4, 0x21, 0x95, 0x64, 0x45, 0xe4, 0xa3, 0x5d, 0x2f, 0x46, 0xbb, 0x14,
+	0xaf, 0xc6, 0x6c, 0xbc, 0x6c, 0x58, 0xf6, 0x31, 0xf3, 0x71, 0x60, 0x92, 0x6c, 0x48, 0x77, 0x08,
+	0xf6, 0xac, 0xff, 0x77, 0x8c, 0x86, 0xd8, 0x71, 0xd6, 0x8f, 0xb5, 0x55, 0xef, 0xe5, 0xae, 0xc3,
+	0xda, 0x0b, 0xc2, 0x75, 0x47, 0xa3, 0x43, 0xe3, 0x1e, 0x02, 0xca, 0x33, 0xaf, 0xec, 0xbd, 0xcb,
+	0xf5, 0x42, 0x99, 0x3d, 0xf3, 0xe0, 0x32, 0xfa, 0x46, 0xcb, 0xfd, 0xb9, 0x5c, 0xfb, 0x28, 0x64,
+	0xa2, 0xc3, 0xba, 0x2e, 0xec, 0xab, 0x50, 0x1b, 0xe3, 0x4b, 0xdd, 0x88, 0x88, 0xa1, 0xfb, 0x02,
+	0x50, 0x7e, 0xaa, 0xf6, 0x20, 0xdf, 0x1b, 0x5b, 0x8b, 0xf5, 0xc6, 0x7f, 0xb3, 0x00, 0xbd, 0x21,
+	0x59, 0x9f, 0x7e, 0x43, 0x4b, 0x64, 0x32, 0xb8, 0x54, 0xcc, 0xa0, 0xc8, 0x8f, 0xaa, 0x78, 0x3a,
+	0xe7, 0x86, 0x14, 0x48, 0x9f, 0x60, 0x8a, 0xa3, 0x88, 0x44, 0xba, 0xbb, 0xc8, 0x68, 0x71, 0x9b,
+	0x8f, 0xf1, 0xe5, 0x30, 0x93, 0x8b, 0xc4, 0x77, 0xbd, 0xce, 0x18, 0x5f, 0xbe, 0x36, 0x2a, 0x08,
+	0xea, 0x51, 0x72, 0xce, 0x74, 0x67, 0x21, 0xc7, 0xee, 0xef, 0x60, 0xbd, 0xe0, 0xb0, 0xde, 0xbb,
+	0x88, 0x11, 0x3b, 0xd7, 0x0e, 0x8b, 0x21, 0xfa, 0x19, 0x34, 0xd5, 0x23, 0x4e, 0xba, 0xdb, 0xdb,
+	0x7d, 0x54, 0x8c, 0x85, 0x5c, 0x24, 0x8d, 0xf5, 0xab, 0xcf, 0xd3, 0xba, 0xee, 0x9f, 0x2d, 0x78,
+	0xf8, 0x72, 0x3c, 0x49, 0xa8, 0xb1, 0x50, 0xca, 0xcf, 0xed, 0x63, 0x5c, 0xac, 0x52, 0x4b, 0xe5,
+	0x2a, 0x55, 0xd1, 0x11, 0xbb, 0x27, 0xf0, 0xa8, 0xda, 0x87, 0xbb, 0x1e, 0xf4, 0xbf, 0x5a, 0xe0,
+	0xbc, 0xa6, 0x69, 0x4c, 0xaa, 0x37, 0xb5, 0x10, 0xe8, 0x44, 0xfd, 0x16, 0x09, 0xc3, 0xfa, 0x68,
+	0xd7, 0xbc, 0xe6, 0x18, 0x5f, 0xee, 0x9d, 0x13, 0xf4, 0x10, 0xda, 0x42, 0x70, 0x36, 0xe5, 0xf2,
+	0x51, 0x26, 0x44, 0xad, 0x31, 0xbe, 0xdc, 0x17, 0x74, 0xbe, 0xea, 0x37, 0xf2, 0x55, 0xdf, 0x7d,
+	0x0d, 0x0f, 0x2b, 0x5d, 0xba, 0x3b, 0x98, 0xff, 0xb7, 0x04, 0x9d, 0xbd, 0x34, 0x08, 0xb9, 0x47,
+	0xfc, 0x84, 0x06, 0x68, 0x00, 0x75, 0x1e, 0xea, 0x6d, 0x5d, 0xff, 0x5a, 0x96, 0x7a, 0x02, 0xc7,
+	0x26, 0xac, 0x2a, 0x4d, 0x86, 0xbc, 0xe1, 0x09, 0xf6, 0x08, 0xda, 0xc9, 0x84, 0x50, 0xcc, 0x4d,
+	0x9b, 0xd2, 0xf6, 0xae, 0x18, 0xaa, 0x51, 0xb9, 0x08, 0x99, 0x29, 0x6e, 0x0d, 0x2f, 0xa3, 0x45,
+	0xe0, 0x53, 0x46, 0xa8, 0x2e, 0x6b, 0x72, 0x8c, 0x3e, 0x87, 0x9e, 0x1f, 0x85, 0x24, 0xe6, 0xd9,
+	0xd3, 0x4a, 0x3d, 0x65, 0xba, 0x8a, 0x6b, 0x5e, 0x57, 0x1b, 0xe6, 0x8a, 0x56, 0x5f, 0x1c, 0x14,
+	0x21, 0x2a, 0xb4, 0x1c, 0x94, 0xbe, 0x37, 0xac, 0x48, 0xa6, 0x99, 0xfa, 0x19, 0x74, 0x75, 0x43,
+	0x12, 0x84, 0xe7, 0x84, 0x99, 0x6f, 0x0e, 0x2b, 0x8a, 0xf9, 0x5c, 0xf2, 0x44, 0x30, 0x58, 0xea,
+	0xfb, 0x84, 0x31, 0xdd, 0xb1, 0x18, 0x52, 0x58, 0x26, 0x94, 0x26, 0x54, 0xf7, 0xd1, 0x8a, 0x70,
+	0xbf, 0x81, 0xcd, 0xab, 0x77, 0x91, 0xce, 0xc2, 0x6d, 0x4a, 0xda, 0x3b, 0xd8, 0x9a, 0x99, 0xaf,
+	0xa1, 0xf0, 0x0b, 0x91, 0x17, 0x91, 0x51, 0x83, 0x84, 0x4f, 0xab, 0x9f, 0xaf, 0xb9, 0xdc, 0x7b,
+	0x66, 0xc6, 0xee, 0xbf, 0x3a, 0xd0, 0xd3, 0xab, 0x9e, 0x2a, 0x7d, 0x14, 0xc2, 0x4a, 0xfe, 0xdb,
+	0x04, 0xfa, 0x72, 0xfe, 0x27, 0xa5, 0xd2, 0x77, 0x31, 0xe7, 0xab, 0x45, 0x54, 0x95, 0xdb, 0xee,
+	0x27, 0x5f, 0x5b, 0x88, 0xc1, 0x6a, 0xf9, 0x9b, 0x00, 0x7a, 0x5a, 0xbd, 0xc6, 0x9c, 0x6f, 0x14,
+	0xce, 0x60, 0x51, 0x75, 0x63, 0x16, 0x5d, 0xc0, 0xda, 0x95, 0x54, 0x3f, 0x51, 0xd1, 0x8d, 0xcb,
+	0x14, 0x5f, 0xc5, 0xce, 0xce, 0xc2, 0xfa, 0x99, 0xdd, 0xdf, 0x43, 0xb7, 0xf0, 0xc6, 0x40, 0x73,
+	0xa2, 0x55, 0xf5, 0xe0, 0x75, 0x7e, 0xbc, 0x90, 0x6e, 0x66, 0x6b, 0x0c, 0xbd, 0x62, 0x17, 0x84,
+	0xe6, 0x2c, 0x50, 0xd9, 0xe0, 0x3a, 0x3f, 0x59, 0x4c, 0x39, 0x33, 0xc7, 0x60, 0xb5, 0xdc, 0x68,
+	0xcc, 0xcb, 0xe3, 0x9c, 0x86, 0xca, 0x19, 0x2c, 0xaa, 0x9e, 0x19, 0xc5, 0x00, 0x57, 0x7d, 0x06,
+	0xfa, 0x62, 0x6e, 0x42, 0x8a, 0xed, 0x89, 0xd3, 0xbf, 0x59, 0x31, 0x33, 0x31, 0x81, 0x7b, 0xa5,
+	0xe7, 0x04, 0x9a, 0x13, 0x9a, 0xea, 0x27, 0x9f, 0xf3, 0x74, 0x41, 0xed, 0xd2, 0xa6, 0x74, 0xb5,
+	0xbf, 0x66, 0x53, 0xc5, 0x2b, 0xca, 0xe9, 0xdf, 0xac, 0x98, 0x99, 0x08, 0xa1, 0xe7, 0xa5, 0xb1,
+	0x36, 0x2d, 0xee, 0x79, 0x34, 0x67, 0xf6, 0x6c, 0xe7, 0xe3, 0x7c, 0xb9, 0x80, 0x66, 0xee, 0x7c,
+	0xff, 0x09, 0x36, 0xaa, 0x6e, 0x6a, 0xf4, 0x6c, 0x0e, 0xbe, 0xe6, 0x77, 0x16, 0xce, 0xee, 0x6d,
+	0xa6, 0x64, 0x7b, 0xfd, 0x23, 0xac, 0x57, 0xdc, 0xa2, 0xe8, 0xeb, 0xea, 0xc5, 0xe6, 0xf7, 0x00,
+	0xce, 0xb3, 0x5b, 0xcc, 0xc8, 0xc3, 0xa7, 0x54, 0xb4, 0xe7, 0xc1, 0xa7, 0xfa, 0x6e, 0x70, 0x9e,
+	0x2e, 0xa8, 0x6d, 0x2c, 0xee, 0xc3, 0x6f, 0x5b, 0x46, 0xf9, 0xac, 0x29, 0x6f, 0xf2, 0x9f, 0x7e,
+	0x3f, 0x00, 0xfd, 0x67, 0x8f, 0x48, 0xd2, 0x19, 0x00, 0x00,
 }
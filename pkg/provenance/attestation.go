@@ -0,0 +1,211 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provenance
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/ghodss/yaml"
+)
+
+const (
+	// StatementType is the type of the in-toto statements.
+	StatementType = "https://in-toto.io/Statement/v0.1"
+	// SLSAProvenanceType is the predicate type of the SLSA provenance.
+	SLSAProvenanceType = "https://slsa.dev/provenance/v0.2"
+	// PackageBuildType is the build type of the chart archives built by
+	// 'helm package'.
+	PackageBuildType = "https://helm.sh/package@v1"
+)
+
+// Attestation is an in-toto statement of the SLSA provenance of a chart
+// archive. It is signed with the chart archive, in its provenance file.
+type Attestation struct {
+	Type          string         `json:"_type"`
+	Subject       []Subject      `json:"subject"`
+	PredicateType string         `json:"predicateType"`
+	Predicate     SLSAProvenance `json:"predicate"`
+}
+
+// Subject is an artifact an attestation is about.
+type Subject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+// SLSAProvenance describes how an artifact was built: by which builder, and
+// from which source.
+type SLSAProvenance struct {
+	Builder    Builder    `json:"builder"`
+	BuildType  string     `json:"buildType"`
+	Invocation Invocation `json:"invocation"`
+	Materials  []Material `json:"materials,omitempty"`
+}
+
+// Builder identifies the builder of an artifact.
+type Builder struct {
+	ID string `json:"id"`
+}
+
+// Invocation describes the invocation of the build of an artifact.
+type Invocation struct {
+	ConfigSource Material `json:"configSource"`
+}
+
+// Material is an artifact an artifact is built from, like the commit of a
+// source repository.
+type Material struct {
+	URI    string            `json:"uri"`
+	Digest map[string]string `json:"digest,omitempty"`
+}
+
+// BuildInfo describes how a chart archive was built.
+type BuildInfo struct {
+	// BuilderID identifies the builder, like the URI of a CI pipeline.
+	BuilderID string
+	// SourceRepo is the URI of the source repository of the chart.
+	SourceRepo string
+	// SourceCommit is the commit of the source repository the chart is built
+	// from.
+	SourceCommit string
+}
+
+// NewAttestation returns the attestation of the chart archive chartpath, built
+// as described by info.
+func NewAttestation(chartpath string, info BuildInfo) (*Attestation, error) {
+	if info.BuilderID == "" {
+		return nil, errors.New("the builder of an attestation is required")
+	}
+	sum, err := DigestFile(chartpath)
+	if err != nil {
+		return nil, err
+	}
+	source := Material{URI: info.SourceRepo}
+	if info.SourceCommit != "" {
+		source.Digest = map[string]string{"sha1": info.SourceCommit}
+	}
+	a := &Attestation{
+		Type: StatementType,
+		Subject: []Subject{{
+			Name:   filepath.Base(chartpath),
+			Digest: map[string]string{"sha256": sum},
+		}},
+		PredicateType: SLSAProvenanceType,
+		Predicate: SLSAProvenance{
+			Builder:    Builder{ID: info.BuilderID},
+			BuildType:  PackageBuildType,
+			Invocation: Invocation{ConfigSource: source},
+		},
+	}
+	if info.SourceRepo != "" {
+		a.Predicate.Materials = []Material{source}
+	}
+	return a, nil
+}
+
+// BuilderID returns the ID of the builder of the attested chart archive.
+func (a *Attestation) BuilderID() string {
+	return a.Predicate.Builder.ID
+}
+
+// SourceRepo returns the URI of the source repository of the attested chart
+// archive.
+func (a *Attestation) SourceRepo() string {
+	return a.Predicate.Invocation.ConfigSource.URI
+}
+
+// SourceCommit returns the commit of the source repository the attested chart
+// archive is built from.
+func (a *Attestation) SourceCommit() string {
+	return a.Predicate.Invocation.ConfigSource.Digest["sha1"]
+}
+
+// verify checks that the attestation is a SLSA provenance of the chart archive
+// name, of SHA256 sum sum.
+func (a *Attestation) verify(name, sum string) error {
+	if a.Type != StatementType || a.PredicateType != SLSAProvenanceType {
+		return fmt.Errorf("attestation is not a SLSA provenance statement: %s %s", a.Type, a.PredicateType)
+	}
+	for _, s := range a.Subject {
+		if s.Name == name && s.Digest["sha256"] == sum {
+			return nil
+		}
+	}
+	return fmt.Errorf("attestation does not contain a subject %s with the sha256 sum %s", name, sum)
+}
+
+// AttestationPolicy is what the attestation of a chart archive must state to
+// be trusted.
+//
+// A pattern ending with '*' matches the values it is a prefix of.
+type AttestationPolicy struct {
+	// Builders are the patterns of the IDs of the trusted builders. Any
+	// builder is trusted if there are none.
+	Builders []string `json:"builders,omitempty"`
+	// SourceRepos are the patterns of the URIs of the trusted source
+	// repositories. Any source repository is trusted if there are none.
+	SourceRepos []string `json:"sourceRepos,omitempty"`
+	// RequireCommit requires the attestation to state the commit of the
+	// source repository.
+	RequireCommit bool `json:"requireCommit,omitempty"`
+}
+
+// LoadAttestationPolicy reads the YAML attestation policy of the file
+// filename.
+func LoadAttestationPolicy(filename string) (*AttestationPolicy, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	p := &AttestationPolicy{}
+	if err := yaml.Unmarshal(data, p); err != nil {
+		return nil, fmt.Errorf("cannot parse the attestation policy %s: %s", filename, err)
+	}
+	return p, nil
+}
+
+// Check returns an error if the attestation a, which may be nil, does not
+// meet the policy.
+func (p *AttestationPolicy) Check(a *Attestation) error {
+	if a == nil {
+		return errors.New("provenance does not contain an attestation")
+	}
+	if len(p.Builders) > 0 && !matchAny(p.Builders, a.BuilderID()) {
+		return fmt.Errorf("the builder %q is not trusted", a.BuilderID())
+	}
+	if len(p.SourceRepos) > 0 && !matchAny(p.SourceRepos, a.SourceRepo()) {
+		return fmt.Errorf("the source repository %q is not trusted", a.SourceRepo())
+	}
+	if p.RequireCommit && a.SourceCommit() == "" {
+		return errors.New("attestation does not state the commit of the source repository")
+	}
+	return nil
+}
+
+// matchAny returns whether one of the patterns matches s.
+func matchAny(patterns []string, s string) bool {
+	for _, p := range patterns {
+		if p == s || strings.HasSuffix(p, "*") && strings.HasPrefix(s, strings.TrimSuffix(p, "*")) {
+			return true
+		}
+	}
+	return false
+}
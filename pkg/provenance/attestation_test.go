@@ -0,0 +1,117 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provenance
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestVerifyAttestation(t *testing.T) {
+	signer, err := NewFromFiles(testKeyfile, testPubfile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	att, err := NewAttestation(testChartfile, BuildInfo{
+		BuilderID:    "https://ci.example.com/builders/charts",
+		SourceRepo:   "git+https://github.com/example/charts",
+		SourceCommit: "4f3a2b1c",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := signer.ClearSignWithAttestation(testChartfile, att)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(sig, testMessageBlock) {
+		t.Errorf("Expected the message block to be in the signature: %s", sig)
+	}
+
+	f, err := ioutil.TempFile("", "helm-test-attestation-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	f.WriteString(sig)
+	f.Close()
+
+	ver, err := signer.Verify(testChartfile, f.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ver.Attestation == nil {
+		t.Fatal("Expected a verified attestation")
+	}
+	if ver.Attestation.BuilderID() != "https://ci.example.com/builders/charts" {
+		t.Errorf("Unexpected builder %q", ver.Attestation.BuilderID())
+	}
+	if ver.Attestation.SourceRepo() != "git+https://github.com/example/charts" {
+		t.Errorf("Unexpected source repository %q", ver.Attestation.SourceRepo())
+	}
+	if ver.Attestation.SourceCommit() != "4f3a2b1c" {
+		t.Errorf("Unexpected commit %q", ver.Attestation.SourceCommit())
+	}
+}
+
+func TestAttestationOfAnotherFile(t *testing.T) {
+	att, err := NewAttestation(testChartfile, BuildInfo{BuilderID: "https://ci.example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := att.verify("other-1.0.0.tgz", att.Subject[0].Digest["sha256"]); err == nil {
+		t.Error("Expected an attestation of another file to fail")
+	}
+	if err := att.verify(att.Subject[0].Name, "0000"); err == nil {
+		t.Error("Expected an attestation of another sum to fail")
+	}
+}
+
+func TestAttestationPolicy(t *testing.T) {
+	att, err := NewAttestation(testChartfile, BuildInfo{
+		BuilderID:  "https://ci.example.com/builders/charts",
+		SourceRepo: "git+https://github.com/example/charts",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		policy AttestationPolicy
+		err    string
+	}{
+		{AttestationPolicy{}, ""},
+		{AttestationPolicy{Builders: []string{"https://ci.example.com/*"}, SourceRepos: []string{"git+https://github.com/example/charts"}}, ""},
+		{AttestationPolicy{Builders: []string{"https://ci.example.org/*"}}, `the builder "https://ci.example.com/builders/charts" is not trusted`},
+		{AttestationPolicy{SourceRepos: []string{"git+https://github.com/other/*"}}, `the source repository "git+https://github.com/example/charts" is not trusted`},
+		{AttestationPolicy{RequireCommit: true}, "does not state the commit"},
+	} {
+		err := tt.policy.Check(att)
+		if tt.err == "" && err != nil {
+			t.Errorf("%+v: unexpected error %s", tt.policy, err)
+		}
+		if tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)) {
+			t.Errorf("%+v: expected the error %q, got %v", tt.policy, tt.err, err)
+		}
+	}
+
+	if err := (&AttestationPolicy{}).Check(nil); err == nil {
+		t.Error("Expected a missing attestation to fail the policy")
+	}
+}
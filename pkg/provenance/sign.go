@@ -19,6 +19,7 @@ import (
 	"bytes"
 	"crypto"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -62,6 +63,9 @@ type Verification struct {
 	FileHash string
 	// FileName is the name of the file that FileHash verifies.
 	FileName string
+	// Attestation is the verified attestation of the build provenance of the
+	// file, if the provenance file contains one.
+	Attestation *Attestation
 }
 
 // Signatory signs things.
@@ -202,6 +206,13 @@ func (s *Signatory) DecryptKey(fn PassphraseFetcher) error {
 // The Signatory must have a valid Entity.PrivateKey for this to work. If it does
 // not, an error will be returned.
 func (s *Signatory) ClearSign(chartpath string) (string, error) {
+	return s.ClearSignWithAttestation(chartpath, nil)
+}
+
+// ClearSignWithAttestation signs a chart with the given key, like ClearSign,
+// and signs the attestation of its build provenance with it, if it is not
+// nil.
+func (s *Signatory) ClearSignWithAttestation(chartpath string, att *Attestation) (string, error) {
 	if s.Entity == nil {
 		return "", errors.New("private key not found")
 	} else if s.Entity.PrivateKey == nil {
@@ -216,7 +227,7 @@ func (s *Signatory) ClearSign(chartpath string) (string, error) {
 
 	out := bytes.NewBuffer(nil)
 
-	b, err := messageBlock(chartpath)
+	b, err := messageBlock(chartpath, att)
 	if err != nil {
 		return "", nil
 	}
@@ -259,7 +270,7 @@ func (s *Signatory) Verify(chartpath, sigpath string) (*Verification, error) {
 	if err != nil {
 		return ver, err
 	}
-	_, sums, err := parseMessageBlock(sig.Plaintext)
+	_, sums, att, err := parseMessageBlock(sig.Plaintext)
 	if err != nil {
 		return ver, err
 	}
@@ -274,6 +285,13 @@ func (s *Signatory) Verify(chartpath, sigpath string) (*Verification, error) {
 	ver.FileHash = sum
 	ver.FileName = basename
 
+	if att != nil {
+		if err := att.verify(basename, strings.TrimPrefix(sum, "sha256:")); err != nil {
+			return ver, err
+		}
+		ver.Attestation = att
+	}
+
 	// TODO: when image signing is added, verify that here.
 
 	return ver, nil
@@ -303,7 +321,9 @@ func (s *Signatory) verifySignature(block *clearsign.Block) (*openpgp.Entity, er
 	)
 }
 
-func messageBlock(chartpath string) (*bytes.Buffer, error) {
+// messageBlock returns the message block of the chart archive chartpath,
+// with the attestation att, if it is not nil.
+func messageBlock(chartpath string, att *Attestation) (*bytes.Buffer, error) {
 	var b *bytes.Buffer
 	// Checksum the archive
 	chash, err := DigestFile(chartpath)
@@ -342,25 +362,45 @@ func messageBlock(chartpath string) (*bytes.Buffer, error) {
 	}
 	b.Write(data)
 
+	// The attestation is a third part, ignored by the older versions of Helm.
+	if att != nil {
+		data, err = json.MarshalIndent(att, "", "  ")
+		if err != nil {
+			return b, err
+		}
+		b.WriteString("...\n")
+		b.Write(data)
+		b.WriteString("\n")
+	}
+
 	return b, nil
 }
 
 // parseMessageBlock
-func parseMessageBlock(data []byte) (*hapi.Metadata, *SumCollection, error) {
+func parseMessageBlock(data []byte) (*hapi.Metadata, *SumCollection, *Attestation, error) {
 	// This sucks.
 	parts := bytes.Split(data, []byte("\n...\n"))
 	if len(parts) < 2 {
-		return nil, nil, errors.New("message block must have at least two parts")
+		return nil, nil, nil, errors.New("message block must have at least two parts")
 	}
 
 	md := &hapi.Metadata{}
 	sc := &SumCollection{}
 
 	if err := yaml.Unmarshal(parts[0], md); err != nil {
-		return md, sc, err
+		return md, sc, nil, err
+	}
+	if err := yaml.Unmarshal(parts[1], sc); err != nil {
+		return md, sc, nil, err
+	}
+	if len(parts) < 3 {
+		return md, sc, nil, nil
+	}
+	att := &Attestation{}
+	if err := json.Unmarshal(parts[2], att); err != nil {
+		return md, sc, nil, fmt.Errorf("cannot parse the attestation: %s", err)
 	}
-	err := yaml.Unmarshal(parts[1], sc)
-	return md, sc, err
+	return md, sc, att, nil
 }
 
 // loadKey loads a GPG key found at a particular path.
@@ -399,6 +439,34 @@ func DigestFile(filename string) (string, error) {
 	return Digest(f)
 }
 
+// DigestDir hashes the paths and the contents of the files of a directory and
+// returns a SHA256 digest. It does not depend on the modification times of
+// the files, so it identifies the content of an unpacked chart.
+func DigestDir(dir string) (string, error) {
+	hash := crypto.SHA256.New()
+	err := filepath.Walk(dir, func(name string, fi os.FileInfo, err error) error {
+		if err != nil || fi.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, name)
+		if err != nil {
+			return err
+		}
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		fmt.Fprintf(hash, "%s\x00%d\x00", filepath.ToSlash(rel), fi.Size())
+		_, err = io.Copy(hash, f)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
 // Digest hashes a reader and returns a SHA256 digest.
 //
 // Helm uses SHA256 as its default hash for all non-cryptographic applications.
@@ -73,7 +73,7 @@ files:
 `
 
 func TestMessageBlock(t *testing.T) {
-	out, err := messageBlock(testChartfile)
+	out, err := messageBlock(testChartfile, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -85,11 +85,15 @@ func TestMessageBlock(t *testing.T) {
 }
 
 func TestParseMessageBlock(t *testing.T) {
-	md, sc, err := parseMessageBlock([]byte(testMessageBlock))
+	md, sc, att, err := parseMessageBlock([]byte(testMessageBlock))
 	if err != nil {
 		t.Fatal(err)
 	}
 
+	if att != nil {
+		t.Errorf("Expected no attestation, got %v", att)
+	}
+
 	if md.Name != "hashtest" {
 		t.Errorf("Expected name %q, got %q", "hashtest", md.Name)
 	}
@@ -155,6 +159,32 @@ func TestDigest(t *testing.T) {
 	}
 }
 
+func TestDigestDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "helm-digest-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "Chart.yaml"), []byte("name: hashtest\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	hash, err := DigestDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again, err := DigestDir(dir); err != nil || again != hash {
+		t.Errorf("Expected the same digest %s, got %s (%v)", hash, again, err)
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "values.yaml"), []byte("replicas: 1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if changed, err := DigestDir(dir); err != nil || changed == hash {
+		t.Errorf("Expected the digest to change with the content, got %s (%v)", changed, err)
+	}
+}
+
 func TestNewFromFiles(t *testing.T) {
 	s, err := NewFromFiles(testKeyfile, testPubfile)
 	if err != nil {
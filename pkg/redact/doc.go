@@ -0,0 +1,27 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*Package redact masks the sensitive values of a chart in the error messages
+and the debug logs.
+
+A chart declares its sensitive values with the helm.sh/sensitive-values
+annotation of its Chart.yaml, a comma-separated list of dotted value paths
+where "*" matches any key or list index:
+
+	annotations:
+	  helm.sh/sensitive-values: db.password, users.*.token
+*/
+package redact // import "k8s.io/helm/pkg/redact"
@@ -0,0 +1,222 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redact
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+// Annotation is the Chart.yaml annotation declaring the sensitive values of a
+// chart.
+const Annotation = "helm.sh/sensitive-values"
+
+// Placeholder replaces the sensitive values.
+const Placeholder = "[REDACTED]"
+
+// minLength is the length of the shortest sensitive value replaced in text.
+// Shorter values would mask unrelated parts of the messages. They are still
+// masked in values.
+const minLength = 4
+
+// Paths returns the paths of the sensitive values declared by the chart c and
+// its subcharts. The paths of a subchart are prefixed with its name.
+func Paths(c *chart.Chart) []string {
+	var paths []string
+	for _, p := range strings.Split(c.GetMetadata().GetAnnotations()[Annotation], ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			paths = append(paths, p)
+		}
+	}
+	for _, d := range c.GetDependencies() {
+		for _, p := range Paths(d) {
+			paths = append(paths, d.GetMetadata().GetName()+"."+p)
+		}
+	}
+	return paths
+}
+
+// Redactor masks the sensitive values of a chart. A nil Redactor masks
+// nothing.
+type Redactor struct {
+	// paths are the paths of the sensitive values, split on dots.
+	paths [][]string
+	// secrets are the sensitive values, and their base64 encoding, longest
+	// first.
+	secrets []string
+}
+
+// New returns the Redactor of the sensitive values of the chart c with the
+// values config, or nil if the chart declares no sensitive values.
+func New(c *chart.Chart, config *chart.Config) *Redactor {
+	paths := Paths(c)
+	if len(paths) == 0 {
+		return nil
+	}
+	r := &Redactor{}
+	for _, p := range paths {
+		r.paths = append(r.paths, strings.Split(p, "."))
+	}
+
+	vals, err := chartutil.CoalesceValues(c, config)
+	if err != nil {
+		// The values supplied are still masked if the defaults of the chart
+		// cannot be coalesced with them.
+		if vals, err = chartutil.ReadValues([]byte(config.GetRaw())); err != nil {
+			return r
+		}
+	}
+	seen := map[string]bool{}
+	for _, p := range r.paths {
+		collect(map[string]interface{}(vals), p, func(v interface{}) {
+			s := fmt.Sprint(v)
+			if len(s) < minLength || seen[s] {
+				return
+			}
+			seen[s] = true
+			r.secrets = append(r.secrets, s, base64.StdEncoding.EncodeToString([]byte(s)))
+		})
+	}
+	sort.SliceStable(r.secrets, func(i, j int) bool { return len(r.secrets[i]) > len(r.secrets[j]) })
+	return r
+}
+
+// String returns s with the sensitive values replaced by the placeholder.
+func (r *Redactor) String(s string) string {
+	if r == nil {
+		return s
+	}
+	for _, secret := range r.secrets {
+		s = strings.Replace(s, secret, Placeholder, -1)
+	}
+	return s
+}
+
+// Error returns err with the sensitive values of its message replaced by the
+// placeholder. The error returned unwraps to err.
+func (r *Redactor) Error(err error) error {
+	if r == nil || err == nil {
+		return err
+	}
+	msg := r.String(err.Error())
+	if msg == err.Error() {
+		return err
+	}
+	return &redactedError{msg: msg, err: err}
+}
+
+// Values returns a copy of vals with the sensitive values replaced by the
+// placeholder. vals is not modified.
+func (r *Redactor) Values(vals chartutil.Values) chartutil.Values {
+	if r == nil {
+		return vals
+	}
+	v := map[string]interface{}(vals)
+	for _, p := range r.paths {
+		v = redact(v, p).(map[string]interface{})
+	}
+	return v
+}
+
+// redactedError is an error whose message is redacted.
+type redactedError struct {
+	msg string
+	err error
+}
+
+func (e *redactedError) Error() string {
+	return e.msg
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
+
+// matches returns whether the key or the list index key matches the element
+// of a path.
+func matches(elem, key string) bool {
+	return elem == "*" || elem == key
+}
+
+// collect calls fn with every scalar value of v at the path p, or below it.
+func collect(v interface{}, p []string, fn func(interface{})) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, e := range v {
+			if len(p) == 0 {
+				collect(e, p, fn)
+			} else if matches(p[0], k) {
+				collect(e, p[1:], fn)
+			}
+		}
+	case chartutil.Values:
+		collect(map[string]interface{}(v), p, fn)
+	case []interface{}:
+		for i, e := range v {
+			if len(p) == 0 {
+				collect(e, p, fn)
+			} else if matches(p[0], strconv.Itoa(i)) {
+				collect(e, p[1:], fn)
+			}
+		}
+	case nil:
+	default:
+		if len(p) == 0 {
+			fn(v)
+		}
+	}
+}
+
+// redact returns a copy of v with the values at the path p replaced by the
+// placeholder. The parts of v outside of the path are shared with v.
+func redact(v interface{}, p []string) interface{} {
+	if len(p) == 0 {
+		if v == nil {
+			return nil
+		}
+		return Placeholder
+	}
+	switch v := v.(type) {
+	case map[string]interface{}:
+		c := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			if matches(p[0], k) {
+				e = redact(e, p[1:])
+			}
+			c[k] = e
+		}
+		return c
+	case chartutil.Values:
+		return redact(map[string]interface{}(v), p)
+	case []interface{}:
+		c := make([]interface{}, len(v))
+		for i, e := range v {
+			if matches(p[0], strconv.Itoa(i)) {
+				e = redact(e, p[1:])
+			}
+			c[i] = e
+		}
+		return c
+	}
+	return v
+}
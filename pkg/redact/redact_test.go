@@ -0,0 +1,100 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redact
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+func testChart() *chart.Chart {
+	return &chart.Chart{
+		Metadata: &chart.Metadata{
+			Name:        "web",
+			Annotations: map[string]string{Annotation: "apiKey, users.*.token"},
+		},
+		Values: &chart.Config{Raw: "apiKey: default-key\nreplicas: 1\n"},
+		Dependencies: []*chart.Chart{
+			{
+				Metadata: &chart.Metadata{
+					Name:        "db",
+					Annotations: map[string]string{Annotation: "password"},
+				},
+				Values: &chart.Config{Raw: "password: hunter22\nport: 5432\n"},
+			},
+		},
+	}
+}
+
+func TestPaths(t *testing.T) {
+	expect := []string{"apiKey", "users.*.token", "db.password"}
+	if paths := Paths(testChart()); !reflect.DeepEqual(paths, expect) {
+		t.Errorf("Expected %v, got %v", expect, paths)
+	}
+}
+
+func TestRedactor(t *testing.T) {
+	r := New(testChart(), &chart.Config{Raw: "apiKey: s3cr3t-key\nusers:\n- name: bob\n  token: tok-123456\n"})
+
+	msg := "render error: s3cr3t-key, tok-123456, hunter22 (aHVudGVyMjI=), bob, 5432"
+	expect := "render error: [REDACTED], [REDACTED], [REDACTED] ([REDACTED]), bob, 5432"
+	if s := r.String(msg); s != expect {
+		t.Errorf("Expected %q, got %q", expect, s)
+	}
+
+	orig := errors.New(msg)
+	err := r.Error(orig)
+	if err.Error() != expect {
+		t.Errorf("Expected the error %q, got %q", expect, err)
+	}
+	if !errors.Is(err, orig) {
+		t.Errorf("Expected the redacted error to unwrap to the original error")
+	}
+	if clean := errors.New("no secrets"); r.Error(clean) != clean {
+		t.Errorf("Expected an error without sensitive values to be returned as is")
+	}
+
+	vals, err := chartutil.ReadValues([]byte("apiKey: s3cr3t-key\nusers:\n- name: bob\n  token: tok-123456\ndb:\n  password: hunter22\n  port: 5432\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	y, err := r.Values(vals).YAML()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectYAML := "apiKey: '[REDACTED]'\ndb:\n  password: '[REDACTED]'\n  port: 5432\nusers:\n- name: bob\n  token: '[REDACTED]'\n"
+	if y != expectYAML {
+		t.Errorf("Expected\n%s\ngot\n%s", expectYAML, y)
+	}
+	if vals["apiKey"] != "s3cr3t-key" {
+		t.Errorf("Expected the values not to be modified, got %v", vals)
+	}
+}
+
+func TestNilRedactor(t *testing.T) {
+	r := New(&chart.Chart{Metadata: &chart.Metadata{Name: "web"}}, &chart.Config{Raw: "apiKey: s3cr3t-key"})
+	if r != nil {
+		t.Fatalf("Expected no redactor for a chart without sensitive values")
+	}
+	if s := r.String("s3cr3t-key"); s != "s3cr3t-key" {
+		t.Errorf("Expected the nil redactor to mask nothing, got %q", s)
+	}
+}
@@ -0,0 +1,256 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package releasespec
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"k8s.io/helm/pkg/dag"
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	storageerrors "k8s.io/helm/pkg/storage/errors"
+)
+
+// DefaultTimeout is the timeout in seconds of the releases that don't set one.
+const DefaultTimeout = 300
+
+// Action is what was done to a release to converge it.
+type Action string
+
+const (
+	// ActionInstall installed the release.
+	ActionInstall Action = "install"
+	// ActionUpgrade upgraded the release.
+	ActionUpgrade Action = "upgrade"
+	// ActionDelete deleted the release.
+	ActionDelete Action = "delete"
+	// ActionNone left the release alone, as it is already deleted.
+	ActionNone Action = "none"
+	// ActionSkip skipped the release, as a release it depends on failed.
+	ActionSkip Action = "skip"
+)
+
+// Result is the result of applying a release.
+type Result struct {
+	Release *Release
+	Action  Action
+	Err     error
+}
+
+// Applier applies specs.
+type Applier struct {
+	// Client is the client of Tiller.
+	Client helm.Interface
+	// LoadChart loads the chart of a release.
+	LoadChart func(r *Release) (*chart.Chart, error)
+	// Namespace is the namespace of the releases that don't set one.
+	Namespace string
+	// Concurrency is the maximum number of releases applied at once. Zero
+	// means no limit.
+	Concurrency int
+	// DryRun simulates the installs, upgrades and deletes.
+	DryRun bool
+	// Wait waits until the resources of every release are ready.
+	Wait bool
+	// Report, if set, is called with the result of every release, as soon as
+	// it is applied.
+	Report func(*Result)
+
+	mu sync.Mutex
+}
+
+// Apply converges the releases to the spec s.
+//
+// The releases that should not be installed are deleted first, each after
+// the releases that need it. The other releases are then installed or
+// upgraded, each as soon as the releases it needs are ready: the releases
+// needed by other releases are waited for. A release is skipped when a
+// release it depends on fails.
+//
+// The results are in the order of the spec, the deleted releases first.
+func (a *Applier) Apply(s *Spec) ([]*Result, error) {
+	deletes, installs := dag.New(), dag.New()
+	needed := map[string]bool{}
+	for _, r := range s.Releases {
+		if r.IsInstalled() {
+			installs.Add(r.Name, r.Needs...)
+			for _, n := range r.Needs {
+				needed[n] = true
+			}
+		}
+	}
+	// A release is deleted after the releases that need it.
+	rg := s.Graph().Reverse()
+	for _, r := range s.Releases {
+		if !r.IsInstalled() {
+			var needs []string
+			for _, n := range rg.Needs(r.Name) {
+				if !s.Release(n).IsInstalled() {
+					needs = append(needs, n)
+				}
+			}
+			deletes.Add(r.Name, needs...)
+		}
+	}
+
+	results := map[string]*Result{}
+	run := func(g *dag.Graph) error {
+		errs, err := g.Run(a.Concurrency, func(name string) error {
+			r := s.Release(name)
+			res := a.apply(s, r, a.Wait || r.Wait || needed[name])
+			a.report(results, res)
+			return res.Err
+		})
+		for _, r := range s.Releases {
+			if _, ok := errs[r.Name].(*dag.SkippedError); ok {
+				a.report(results, &Result{Release: r, Action: ActionSkip})
+			}
+		}
+		return err
+	}
+	if err := run(deletes); err != nil {
+		return nil, err
+	}
+	if err := run(installs); err != nil {
+		return nil, err
+	}
+
+	var ordered []*Result
+	var errs int
+	for _, installed := range []bool{false, true} {
+		for _, r := range s.Releases {
+			if r.IsInstalled() != installed {
+				continue
+			}
+			res := results[r.Name]
+			if res.Err != nil {
+				errs++
+			}
+			ordered = append(ordered, res)
+		}
+	}
+	if errs > 0 {
+		return ordered, fmt.Errorf("%d of %d releases failed", errs, len(ordered))
+	}
+	return ordered, nil
+}
+
+// report records the result res, and reports it.
+func (a *Applier) report(results map[string]*Result, res *Result) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	results[res.Release.Name] = res
+	if a.Report != nil {
+		a.Report(res)
+	}
+}
+
+// apply installs, upgrades or deletes the release r, waiting until its
+// resources are ready if wait is set.
+func (a *Applier) apply(s *Spec, r *Release, wait bool) *Result {
+	res := &Result{Release: r}
+
+	last, err := a.lastRelease(r.Name)
+	if err != nil {
+		res.Err = err
+		return res
+	}
+
+	if !r.IsInstalled() {
+		if last == nil {
+			res.Action = ActionNone
+			return res
+		}
+		res.Action = ActionDelete
+		_, res.Err = a.Client.DeleteRelease(r.Name, helm.DeletePurge(true), helm.DeleteDryRun(a.DryRun))
+		return res
+	}
+
+	vals, err := s.Values(r)
+	if err != nil {
+		res.Err = err
+		return res
+	}
+	raw, err := vals.YAML()
+	if err != nil {
+		res.Err = fmt.Errorf("release %s: %s", r.Name, err)
+		return res
+	}
+	ch, err := a.LoadChart(r)
+	if err != nil {
+		res.Err = fmt.Errorf("release %s: %s", r.Name, err)
+		return res
+	}
+	ns := r.Namespace
+	if ns == "" {
+		ns = a.Namespace
+	}
+	timeout := r.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+
+	deleted := last != nil && last.GetInfo().GetStatus().GetCode() == release.Status_DELETED
+	if last == nil || deleted {
+		res.Action = ActionInstall
+		_, res.Err = a.Client.InstallReleaseFromChart(
+			ch,
+			ns,
+			helm.ReleaseName(r.Name),
+			helm.ValueOverrides([]byte(raw)),
+			helm.InstallEnvironment(r.Environment),
+			helm.InstallReuseName(deleted),
+			helm.InstallDryRun(a.DryRun),
+			helm.InstallWait(wait),
+			helm.InstallTimeout(timeout))
+		return res
+	}
+
+	res.Action = ActionUpgrade
+	if last.Namespace != ns {
+		res.Err = fmt.Errorf("release %s is in the namespace %s, not %s", r.Name, last.Namespace, ns)
+		return res
+	}
+	_, res.Err = a.Client.UpdateReleaseFromChart(
+		r.Name,
+		ch,
+		helm.UpdateValueOverrides([]byte(raw)),
+		helm.UpgradeEnvironment(r.Environment),
+		helm.UpgradeDryRun(a.DryRun),
+		helm.UpgradeWait(wait),
+		helm.UpgradeTimeout(timeout))
+	return res
+}
+
+// lastRelease returns the last version of the release name, or nil if there
+// is none.
+func (a *Applier) lastRelease(name string) (*release.Release, error) {
+	h, err := a.Client.ReleaseHistory(name, helm.WithMaxHistory(1))
+	if err != nil {
+		if strings.Contains(err.Error(), storageerrors.ErrReleaseNotFound(name).Error()) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if len(h.Releases) == 0 {
+		return nil, nil
+	}
+	return h.Releases[0], nil
+}
@@ -0,0 +1,194 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package releasespec
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	rls "k8s.io/helm/pkg/proto/hapi/services"
+)
+
+// syncClient serializes the calls to a fake client, which is not safe for
+// concurrent use.
+type syncClient struct {
+	*helm.FakeClient
+	mu sync.Mutex
+}
+
+func (c *syncClient) ReleaseHistory(name string, opts ...helm.HistoryOption) (*rls.GetHistoryResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.FakeClient.ReleaseHistory(name, opts...)
+}
+
+func (c *syncClient) InstallReleaseFromChart(ch *chart.Chart, ns string, opts ...helm.InstallOption) (*rls.InstallReleaseResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.FakeClient.InstallReleaseFromChart(ch, ns, opts...)
+}
+
+func (c *syncClient) UpdateReleaseFromChart(name string, ch *chart.Chart, opts ...helm.UpdateOption) (*rls.UpdateReleaseResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.FakeClient.UpdateReleaseFromChart(name, ch, opts...)
+}
+
+func (c *syncClient) DeleteRelease(name string, opts ...helm.DeleteOption) (*rls.UninstallReleaseResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.FakeClient.DeleteRelease(name, opts...)
+}
+
+func loadChart(r *Release) (*chart.Chart, error) {
+	return &chart.Chart{Metadata: &chart.Metadata{Name: r.Chart, Version: "0.1.0"}}, nil
+}
+
+func actions(results []*Result) map[string]Action {
+	m := map[string]Action{}
+	for _, res := range results {
+		m[res.Release.Name] = res.Action
+	}
+	return m
+}
+
+func TestApply(t *testing.T) {
+	s, err := Load("testdata/releases.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &syncClient{FakeClient: &helm.FakeClient{}}
+	c.Rels = append(c.Rels,
+		helm.ReleaseMock(&helm.MockReleaseOptions{Name: "web", Namespace: "default"}),
+		helm.ReleaseMock(&helm.MockReleaseOptions{Name: "legacy", Namespace: "default"}),
+	)
+
+	var reported []string
+	a := &Applier{
+		Client:      c,
+		LoadChart:   loadChart,
+		Namespace:   "default",
+		Concurrency: 2,
+		Report:      func(res *Result) { reported = append(reported, res.Release.Name) },
+	}
+	results, err := a.Apply(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect := map[string]Action{
+		"legacy":   ActionDelete,
+		"database": ActionInstall,
+		"cache":    ActionInstall,
+		"web":      ActionUpgrade,
+		"worker":   ActionInstall,
+	}
+	if got := actions(results); !reflect.DeepEqual(got, expect) {
+		t.Errorf("Expected the actions %v, got %v", expect, got)
+	}
+	if len(reported) != 5 || reported[0] != "legacy" {
+		t.Errorf("Expected legacy to be deleted first, got %v", reported)
+	}
+	for _, r := range s.Releases {
+		for _, n := range r.Needs {
+			if index(reported, n) > index(reported, r.Name) {
+				t.Errorf("Expected %s to be applied before %s, got %v", n, r.Name, reported)
+			}
+		}
+	}
+
+	var names []string
+	for _, r := range c.Rels {
+		names = append(names, r.Name)
+		if r.Name == "database" {
+			if r.Namespace != "data" {
+				t.Errorf("Expected the release database in the namespace data, got %q", r.Namespace)
+			}
+			if expect := "persistence:\n  enabled: true\n  size: 20Gi\npostgresqlDatabase: app\n"; r.Config.Raw != expect {
+				t.Errorf("Expected the values\n%s\ngot\n%s", expect, r.Config.Raw)
+			}
+		}
+	}
+	for _, n := range []string{"database", "cache", "web", "worker"} {
+		if !contains(names, n) {
+			t.Errorf("Expected the release %s, got %v", n, names)
+		}
+	}
+	if contains(names, "legacy") {
+		t.Error("Expected the release legacy to be deleted")
+	}
+
+	// Applying again upgrades every release
+	results, err = a.Apply(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect["legacy"] = ActionNone
+	for _, n := range []string{"database", "cache", "worker"} {
+		expect[n] = ActionUpgrade
+	}
+	if got := actions(results); !reflect.DeepEqual(got, expect) {
+		t.Errorf("Expected the actions %v, got %v", expect, got)
+	}
+}
+
+func TestApplySkipsDependents(t *testing.T) {
+	s, err := Load("testdata/releases.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := &Applier{
+		Client: &syncClient{FakeClient: &helm.FakeClient{}},
+		LoadChart: func(r *Release) (*chart.Chart, error) {
+			if r.Name == "cache" {
+				return nil, errors.New("chart not found")
+			}
+			return loadChart(r)
+		},
+	}
+	results, err := a.Apply(s)
+	if err == nil || err.Error() != "1 of 5 releases failed" {
+		t.Errorf("Expected 1 release to fail, got %v", err)
+	}
+	expect := map[string]Action{
+		"legacy":   ActionNone,
+		"database": ActionInstall,
+		"cache":    ActionInstall,
+		"web":      ActionSkip,
+		"worker":   ActionInstall,
+	}
+	if got := actions(results); !reflect.DeepEqual(got, expect) {
+		t.Errorf("Expected the actions %v, got %v", expect, got)
+	}
+}
+
+func index(names []string, name string) int {
+	for i, n := range names {
+		if n == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func contains(names []string, name string) bool {
+	return index(names, name) >= 0
+}
@@ -0,0 +1,213 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package releasespec loads declarative specs of multiple releases, and applies
+them to converge the releases of a cluster.
+
+A spec lists the releases, with their chart, version, namespace, environment
+and values, and the releases each of them needs:
+
+	releases:
+	- name: database
+	  chart: stable/postgresql
+	  namespace: data
+	  values:
+	  - values/database.yaml
+	- name: web
+	  chart: ./charts/web
+	  environment: environments/prod.yaml
+	  needs: [database]
+	  values:
+	  - replicaCount: 3
+*/
+package releasespec // import "k8s.io/helm/pkg/releasespec"
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/ghodss/yaml"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/dag"
+)
+
+// Spec is a spec of multiple releases.
+type Spec struct {
+	Releases []*Release `json:"releases"`
+
+	// dir is the directory the value files are relative to.
+	dir string
+}
+
+// Release is a release of a spec.
+type Release struct {
+	// Name is the name of the release.
+	Name string `json:"name"`
+	// Namespace is the namespace of the release.
+	Namespace string `json:"namespace,omitempty"`
+	// Chart is the chart reference, path or URL of the release.
+	Chart string `json:"chart"`
+	// Version is the version constraint of the chart.
+	Version string `json:"version,omitempty"`
+	// Environment is the environment values file inside the chart.
+	Environment string `json:"environment,omitempty"`
+	// Values are the values of the release: the paths of value files,
+	// relative to the spec, or inline values. Later values take precedence.
+	Values []interface{} `json:"values,omitempty"`
+	// Installed is whether the release should be installed. A release that
+	// should not be installed is deleted. Defaults to true.
+	Installed *bool `json:"installed,omitempty"`
+	// Wait is whether to wait until the resources of the release are ready.
+	// The releases needed by other releases are always waited for.
+	Wait bool `json:"wait,omitempty"`
+	// Timeout is the time in seconds to wait for any Kubernetes operation.
+	Timeout int64 `json:"timeout,omitempty"`
+	// Needs are the names of the releases that must be applied, and ready,
+	// before this release.
+	Needs []string `json:"needs,omitempty"`
+}
+
+// IsInstalled returns whether the release should be installed.
+func (r *Release) IsInstalled() bool {
+	return r.Installed == nil || *r.Installed
+}
+
+// Load reads the spec of the file filename.
+func Load(filename string) (*Spec, error) {
+	b, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	s, err := Parse(b, filepath.Dir(filename))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", filename, err)
+	}
+	return s, nil
+}
+
+// Parse parses the spec data, whose value files are relative to the
+// directory dir.
+func Parse(data []byte, dir string) (*Spec, error) {
+	s := &Spec{}
+	if err := yaml.Unmarshal(data, s); err != nil {
+		return nil, err
+	}
+	s.dir = dir
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Validate checks that the releases have a name and a chart, that the names
+// are unique, and that the releases they need are in the spec, installed and
+// don't form a cycle.
+func (s *Spec) Validate() error {
+	names := map[string]bool{}
+	for i, r := range s.Releases {
+		if r.Name == "" {
+			return fmt.Errorf("release %d has no name", i+1)
+		}
+		if names[r.Name] {
+			return fmt.Errorf("release %s is listed more than once", r.Name)
+		}
+		names[r.Name] = true
+		if r.Chart == "" && r.IsInstalled() {
+			return fmt.Errorf("release %s has no chart", r.Name)
+		}
+		for _, v := range r.Values {
+			switch v.(type) {
+			case string, map[string]interface{}:
+			default:
+				return fmt.Errorf("release %s: values must be file paths or maps, got %v", r.Name, v)
+			}
+		}
+	}
+	for _, r := range s.Releases {
+		for _, n := range r.Needs {
+			needed := s.Release(n)
+			if needed == nil {
+				return fmt.Errorf("release %s needs %s, which is not in the spec", r.Name, n)
+			}
+			if r.IsInstalled() && !needed.IsInstalled() {
+				return fmt.Errorf("release %s needs %s, which is not installed", r.Name, n)
+			}
+		}
+	}
+	return s.Graph().Validate()
+}
+
+// Release returns the release named name, or nil.
+func (s *Spec) Release(name string) *Release {
+	for _, r := range s.Releases {
+		if r.Name == name {
+			return r
+		}
+	}
+	return nil
+}
+
+// Values returns the values of the release r, merging its value files and
+// inline values in order.
+func (s *Spec) Values(r *Release) (chartutil.Values, error) {
+	vals := chartutil.Values{}
+	for _, v := range r.Values {
+		switch v := v.(type) {
+		case string:
+			name := v
+			if !filepath.IsAbs(name) {
+				name = filepath.Join(s.dir, name)
+			}
+			fvals, err := chartutil.ReadValuesFile(name)
+			if err != nil {
+				return nil, fmt.Errorf("release %s: %s", r.Name, err)
+			}
+			chartutil.MergeValues(vals, fvals)
+		case map[string]interface{}:
+			chartutil.MergeValues(vals, v)
+		}
+	}
+	return vals, nil
+}
+
+// Graph returns the graph of the releases and the releases they need.
+func (s *Spec) Graph() *dag.Graph {
+	g := dag.New()
+	for _, r := range s.Releases {
+		g.Add(r.Name, r.Needs...)
+	}
+	return g
+}
+
+// Order returns the releases in waves: every release of a wave only needs
+// releases of the previous waves. The releases of a wave keep the order of
+// the spec.
+func (s *Spec) Order() ([][]*Release, error) {
+	names, err := s.Graph().Waves()
+	if err != nil {
+		return nil, err
+	}
+	waves := make([][]*Release, len(names))
+	for i, wave := range names {
+		for _, n := range wave {
+			waves[i] = append(waves[i], s.Release(n))
+		}
+	}
+	return waves, nil
+}
@@ -0,0 +1,108 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package releasespec
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	s, err := Load("testdata/releases.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Releases) != 5 {
+		t.Fatalf("Expected 5 releases, got %d", len(s.Releases))
+	}
+
+	web := s.Release("web")
+	if web == nil {
+		t.Fatal("Expected the release web")
+	}
+	if web.Environment != "environments/prod.yaml" || !web.Wait || web.Timeout != 600 {
+		t.Errorf("Unexpected release web: %+v", web)
+	}
+	if s.Release("legacy").IsInstalled() {
+		t.Error("Expected the release legacy not to be installed")
+	}
+
+	vals, err := s.Values(s.Release("database"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := "persistence:\n  enabled: true\n  size: 20Gi\npostgresqlDatabase: app\n"
+	if y, _ := vals.YAML(); y != expect {
+		t.Errorf("Expected the values\n%s\ngot\n%s", expect, y)
+	}
+}
+
+func TestOrder(t *testing.T) {
+	s, err := Load("testdata/releases.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	waves, err := s.Order()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names [][]string
+	for _, w := range waves {
+		var wn []string
+		for _, r := range w {
+			wn = append(wn, r.Name)
+		}
+		names = append(names, wn)
+	}
+	expect := [][]string{{"database", "cache", "legacy"}, {"web", "worker"}}
+	if !reflect.DeepEqual(names, expect) {
+		t.Errorf("Expected the waves %v, got %v", expect, names)
+	}
+}
+
+func TestOrderCycle(t *testing.T) {
+	s := &Spec{Releases: []*Release{
+		{Name: "a", Chart: "a", Needs: []string{"c"}},
+		{Name: "b", Chart: "b", Needs: []string{"a"}},
+		{Name: "c", Chart: "c", Needs: []string{"b"}},
+		{Name: "d", Chart: "d"},
+	}}
+	if _, err := s.Order(); err == nil || err.Error() != "dependency cycle: a -> c -> b -> a" {
+		t.Errorf("Expected a cycle error, got %v", err)
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, tt := range []struct {
+		spec   string
+		expect string
+	}{
+		{"releases: [{chart: a}]", "release 1 has no name"},
+		{"releases: [{name: a}]", "release a has no chart"},
+		{"releases: [{name: a, chart: a}, {name: a, chart: b}]", "release a is listed more than once"},
+		{"releases: [{name: a, chart: a, needs: [b]}]", "release a needs b, which is not in the spec"},
+		{"releases: [{name: a, chart: a, needs: [b]}, {name: b, installed: false}]", "release a needs b, which is not installed"},
+		{"releases: [{name: a, chart: a, values: [1]}]", "release a: values must be file paths or maps"},
+		{"releases: [{name: a, chart: a, needs: [b]}, {name: b, chart: b, needs: [a]}]", "dependency cycle: a -> b -> a"},
+	} {
+		_, err := Parse([]byte(tt.spec), ".")
+		if err == nil || !strings.Contains(err.Error(), tt.expect) {
+			t.Errorf("%s: expected the error %q, got %v", tt.spec, tt.expect, err)
+		}
+	}
+}
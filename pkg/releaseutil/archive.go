@@ -0,0 +1,164 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package releaseutil // import "k8s.io/helm/pkg/releaseutil"
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/golang/protobuf/proto"
+
+	rspb "k8s.io/helm/pkg/proto/hapi/release"
+)
+
+// ReleaseFileName is the name of the archive entry holding the encoded release.
+//
+// The other entries of a revision directory (manifest, values and hooks) are
+// written for human inspection only and are ignored when reading an archive.
+const ReleaseFileName = "release.pb"
+
+// WriteHistoryArchive writes the revisions of a single release to w as a
+// gzipped tarball.
+//
+// Every revision is stored under <name>/v<revision>/.
+func WriteHistoryArchive(w io.Writer, rels []*rspb.Release) error {
+	if len(rels) == 0 {
+		return errors.New("no releases to archive")
+	}
+	name := rels[0].Name
+	for _, r := range rels {
+		if r.Name != name {
+			return fmt.Errorf("history archive may only contain one release, found %q and %q", name, r.Name)
+		}
+	}
+
+	zw := gzip.NewWriter(w)
+	zw.Header.Comment = "Helm release history"
+	tw := tar.NewWriter(zw)
+
+	sorted := make([]*rspb.Release, len(rels))
+	copy(sorted, rels)
+	SortByRevision(sorted)
+
+	for _, r := range sorted {
+		if err := writeRevision(tw, r); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+func writeRevision(tw *tar.Writer, r *rspb.Release) error {
+	base := path.Join(r.Name, fmt.Sprintf("v%d", r.Version))
+
+	data, err := proto.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("cannot encode %s: %s", base, err)
+	}
+	if err := writeArchiveFile(tw, path.Join(base, ReleaseFileName), data); err != nil {
+		return err
+	}
+	if err := writeArchiveFile(tw, path.Join(base, "manifest.yaml"), []byte(r.Manifest)); err != nil {
+		return err
+	}
+	if r.Config != nil {
+		if err := writeArchiveFile(tw, path.Join(base, "values.yaml"), []byte(r.Config.Raw)); err != nil {
+			return err
+		}
+	}
+
+	hdata, err := yaml.Marshal(summarizeHooks(r.Hooks))
+	if err != nil {
+		return err
+	}
+	return writeArchiveFile(tw, path.Join(base, "hooks.yaml"), hdata)
+}
+
+func writeArchiveFile(tw *tar.Writer, name string, data []byte) error {
+	h := &tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    int64(len(data)),
+		ModTime: time.Now(),
+	}
+	if err := tw.WriteHeader(h); err != nil {
+		return err
+	}
+	_, err := tw.Write(data)
+	return err
+}
+
+// ReadHistoryArchive reads the revisions of a release from an archive written
+// by WriteHistoryArchive. The returned releases are sorted by revision.
+func ReadHistoryArchive(r io.Reader) ([]*rspb.Release, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	var rels []*rspb.Release
+	tr := tar.NewReader(zr)
+	for {
+		hd, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if hd.FileInfo().IsDir() || path.Base(hd.Name) != ReleaseFileName {
+			continue
+		}
+		if strings.Count(path.Clean(hd.Name), "/") != 2 {
+			return nil, fmt.Errorf("unexpected entry %q in history archive", hd.Name)
+		}
+
+		b := bytes.NewBuffer(nil)
+		if _, err := io.Copy(b, tr); err != nil {
+			return nil, err
+		}
+		rel := &rspb.Release{}
+		if err := proto.Unmarshal(b.Bytes(), rel); err != nil {
+			return nil, fmt.Errorf("cannot decode %s: %s", hd.Name, err)
+		}
+		rels = append(rels, rel)
+	}
+
+	if len(rels) == 0 {
+		return nil, errors.New("no releases in history archive")
+	}
+	for _, rel := range rels {
+		if rel.Name != rels[0].Name {
+			return nil, fmt.Errorf("history archive contains more than one release: %q and %q", rels[0].Name, rel.Name)
+		}
+	}
+	SortByRevision(rels)
+	return rels, nil
+}
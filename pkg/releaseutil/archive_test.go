@@ -0,0 +1,65 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package releaseutil // import "k8s.io/helm/pkg/releaseutil"
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+
+	rspb "k8s.io/helm/pkg/proto/hapi/release"
+)
+
+func TestHistoryArchiveRoundTrip(t *testing.T) {
+	rels := []*rspb.Release{
+		{Name: "angry-bird", Version: 2, Manifest: "kind: Pod", Info: &rspb.Info{Status: &rspb.Status{Code: rspb.Status_DEPLOYED}}},
+		{Name: "angry-bird", Version: 1, Manifest: "kind: Pod", Info: &rspb.Info{Status: &rspb.Status{Code: rspb.Status_SUPERSEDED}}},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteHistoryArchive(&buf, rels); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ReadHistoryArchive(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 releases, got %d", len(got))
+	}
+	if got[0].Version != 1 || got[1].Version != 2 {
+		t.Errorf("expected releases sorted by revision, got v%d, v%d", got[0].Version, got[1].Version)
+	}
+	if !proto.Equal(got[1], rels[0]) {
+		t.Errorf("expected %v, got %v", rels[0], got[1])
+	}
+}
+
+func TestHistoryArchiveSingleRelease(t *testing.T) {
+	rels := []*rspb.Release{
+		{Name: "angry-bird", Version: 1},
+		{Name: "sad-panda", Version: 1},
+	}
+	if err := WriteHistoryArchive(&bytes.Buffer{}, rels); err == nil {
+		t.Error("expected error when archiving more than one release")
+	}
+	if err := WriteHistoryArchive(&bytes.Buffer{}, nil); err == nil {
+		t.Error("expected error when archiving no releases")
+	}
+}
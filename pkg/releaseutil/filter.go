@@ -16,7 +16,12 @@ limitations under the License.
 
 package releaseutil // import "k8s.io/helm/pkg/releaseutil"
 
-import rspb "k8s.io/helm/pkg/proto/hapi/release"
+import (
+	"time"
+
+	rspb "k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/timeconv"
+)
 
 // FilterFunc returns true if the release object satisfies
 // the predicate of the underlying filter func.
@@ -76,3 +81,34 @@ func StatusFilter(status rspb.Status_Code) FilterFunc {
 		return rls.GetInfo().GetStatus().Code == status
 	})
 }
+
+// ChartNameFilter filters a set of releases by the name of their chart.
+func ChartNameFilter(name string) FilterFunc {
+	return FilterFunc(func(rls *rspb.Release) bool {
+		return rls.GetChart().GetMetadata().GetName() == name
+	})
+}
+
+// AppVersionFilter filters a set of releases by the app version of their
+// chart.
+func AppVersionFilter(appVersion string) FilterFunc {
+	return FilterFunc(func(rls *rspb.Release) bool {
+		return rls.GetChart().GetMetadata().GetAppVersion() == appVersion
+	})
+}
+
+// EnvironmentFilter filters a set of releases by the environment values file
+// they were deployed with.
+func EnvironmentFilter(environment string) FilterFunc {
+	return FilterFunc(func(rls *rspb.Release) bool {
+		return rls.GetEnvironment() == environment
+	})
+}
+
+// DeployedAfterFilter filters a set of releases last deployed after t.
+func DeployedAfterFilter(t time.Time) FilterFunc {
+	return FilterFunc(func(rls *rspb.Release) bool {
+		ts := rls.GetInfo().GetLastDeployed()
+		return ts != nil && timeconv.Time(ts).After(t)
+	})
+}
@@ -18,8 +18,11 @@ package releaseutil // import "k8s.io/helm/pkg/releaseutil"
 
 import (
 	"testing"
+	"time"
 
+	"k8s.io/helm/pkg/proto/hapi/chart"
 	rspb "k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/timeconv"
 )
 
 func TestFilterAny(t *testing.T) {
@@ -57,3 +60,49 @@ func TestFilterAll(t *testing.T) {
 		t.Fatal("got release with status DELETED")
 	}
 }
+
+func TestFilterChartAndEnvironment(t *testing.T) {
+	now := time.Now()
+	release := func(name, chartName, appVersion, environment string, age time.Duration) *rspb.Release {
+		return &rspb.Release{
+			Name:        name,
+			Environment: environment,
+			Info:        &rspb.Info{LastDeployed: timeconv.Timestamp(now.Add(-age))},
+			Chart:       &chart.Chart{Metadata: &chart.Metadata{Name: chartName, AppVersion: appVersion}},
+		}
+	}
+	rels := []*rspb.Release{
+		release("prod-db", "mariadb", "10.3", "values-prod.yaml", time.Hour),
+		release("staging-db", "mariadb", "10.4", "values-staging.yaml", 2*time.Hour),
+		release("prod-web", "nginx", "1.17", "values-prod.yaml", 48*time.Hour),
+		{Name: "unknown"},
+	}
+
+	tests := []struct {
+		name   string
+		filter FilterFunc
+		expect []string
+	}{
+		{"chart name", ChartNameFilter("mariadb"), []string{"prod-db", "staging-db"}},
+		{"app version", AppVersionFilter("1.17"), []string{"prod-web"}},
+		{"environment", EnvironmentFilter("values-prod.yaml"), []string{"prod-db", "prod-web"}},
+		{"deployed after", DeployedAfterFilter(now.Add(-3 * time.Hour)), []string{"prod-db", "staging-db"}},
+		{"all", All(ChartNameFilter("mariadb"), EnvironmentFilter("values-prod.yaml")), []string{"prod-db"}},
+	}
+	for _, tt := range tests {
+		var names []string
+		for _, r := range tt.filter.Filter(rels) {
+			names = append(names, r.Name)
+		}
+		if len(names) != len(tt.expect) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expect, names)
+			continue
+		}
+		for i := range names {
+			if names[i] != tt.expect[i] {
+				t.Errorf("%s: expected %v, got %v", tt.name, tt.expect, names)
+				break
+			}
+		}
+	}
+}
@@ -19,7 +19,10 @@ package releaseutil
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
+
+	"github.com/ghodss/yaml"
 )
 
 // SimpleHead defines what the structure of the head of a manifest file
@@ -28,6 +31,7 @@ type SimpleHead struct {
 	Kind     string `json:"kind,omitempty"`
 	Metadata *struct {
 		Name        string            `json:"name"`
+		Namespace   string            `json:"namespace,omitempty"`
 		Annotations map[string]string `json:"annotations"`
 	} `json:"metadata,omitempty"`
 }
@@ -57,3 +61,27 @@ func SplitManifests(bigFile string) map[string]string {
 	}
 	return res
 }
+
+// ManifestNamespaces returns the sorted namespaces of the resources of a
+// manifest. Resources that do not set metadata.namespace are in namespace.
+func ManifestNamespaces(manifest, namespace string) []string {
+	seen := map[string]bool{}
+	for _, doc := range SplitManifests(manifest) {
+		var head SimpleHead
+		if err := yaml.Unmarshal([]byte(doc), &head); err != nil || head.Kind == "" {
+			continue
+		}
+		ns := namespace
+		if head.Metadata != nil && head.Metadata.Namespace != "" {
+			ns = head.Metadata.Namespace
+		}
+		seen[ns] = true
+	}
+
+	namespaces := make([]string, 0, len(seen))
+	for ns := range seen {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+	return namespaces
+}
@@ -59,3 +59,34 @@ func TestSplitManifest(t *testing.T) {
 		t.Errorf("Expected %v, got %v", expected, manifests)
 	}
 }
+
+func TestManifestNamespaces(t *testing.T) {
+	manifest := `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: a
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: b
+  namespace: monitoring
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: c
+  namespace: apps
+---
+# Source: empty.yaml
+`
+	namespaces := ManifestNamespaces(manifest, "default")
+	expected := []string{"apps", "default", "monitoring"}
+	if !reflect.DeepEqual(namespaces, expected) {
+		t.Errorf("Expected %v, got %v", expected, namespaces)
+	}
+
+	if namespaces := ManifestNamespaces("", "default"); len(namespaces) != 0 {
+		t.Errorf("Expected no namespaces, got %v", namespaces)
+	}
+}
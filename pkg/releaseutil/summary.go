@@ -0,0 +1,131 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package releaseutil // import "k8s.io/helm/pkg/releaseutil"
+
+import (
+	"sort"
+	"time"
+
+	"github.com/ghodss/yaml"
+
+	rspb "k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/timeconv"
+)
+
+// Summary is the structured outcome of installing or upgrading a release.
+type Summary struct {
+	Name         string          `json:"name"`
+	Namespace    string          `json:"namespace"`
+	Revision     int32           `json:"revision"`
+	Status       string          `json:"status"`
+	Chart        string          `json:"chart,omitempty"`
+	AppVersion   string          `json:"appVersion,omitempty"`
+	LastDeployed *time.Time      `json:"lastDeployed,omitempty"`
+	Description  string          `json:"description,omitempty"`
+	Resources    []ResourceGroup `json:"resources,omitempty"`
+	Hooks        []HookSummary   `json:"hooks,omitempty"`
+	// Notes are the rendered NOTES.txt of the chart, followed by the notes
+	// of the subcharts when they were requested.
+	Notes    string   `json:"notes,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// ResourceGroup lists the names of the applied resources of one kind.
+type ResourceGroup struct {
+	// Kind is the apiVersion and kind of the resources, e.g. "apps/v1/Deployment".
+	Kind  string   `json:"kind"`
+	Names []string `json:"names"`
+}
+
+// HookSummary is the human readable summary of a release hook.
+type HookSummary struct {
+	Name    string     `json:"name"`
+	Kind    string     `json:"kind"`
+	Path    string     `json:"path"`
+	Events  []string   `json:"events"`
+	LastRun *time.Time `json:"lastRun,omitempty"`
+}
+
+// NewSummary builds the summary of a release from its manifest, hooks and status.
+func NewSummary(rel *rspb.Release) *Summary {
+	s := &Summary{
+		Name:      rel.GetName(),
+		Namespace: rel.GetNamespace(),
+		Revision:  rel.GetVersion(),
+		Status:    rel.GetInfo().GetStatus().GetCode().String(),
+		Resources: groupResources(rel.GetManifest()),
+		Hooks:     summarizeHooks(rel.GetHooks()),
+	}
+	if md := rel.GetChart().GetMetadata(); md != nil {
+		s.Chart = md.Name + "-" + md.Version
+		s.AppVersion = md.AppVersion
+	}
+	if info := rel.GetInfo(); info != nil {
+		if info.LastDeployed != nil {
+			t := timeconv.Time(info.LastDeployed).UTC()
+			s.LastDeployed = &t
+		}
+		s.Description = info.Description
+		s.Notes = info.GetStatus().GetNotes()
+	}
+	return s
+}
+
+// groupResources returns the resources of a manifest grouped by kind. Groups
+// and names are sorted.
+func groupResources(manifest string) []ResourceGroup {
+	byKind := map[string][]string{}
+	for _, doc := range SplitManifests(manifest) {
+		var head SimpleHead
+		if err := yaml.Unmarshal([]byte(doc), &head); err != nil || head.Kind == "" {
+			continue
+		}
+		kind := head.Kind
+		if head.Version != "" {
+			kind = head.Version + "/" + head.Kind
+		}
+		name := ""
+		if head.Metadata != nil {
+			name = head.Metadata.Name
+		}
+		byKind[kind] = append(byKind[kind], name)
+	}
+
+	groups := make([]ResourceGroup, 0, len(byKind))
+	for kind, names := range byKind {
+		sort.Strings(names)
+		groups = append(groups, ResourceGroup{Kind: kind, Names: names})
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Kind < groups[j].Kind })
+	return groups
+}
+
+func summarizeHooks(hooks []*rspb.Hook) []HookSummary {
+	summaries := make([]HookSummary, 0, len(hooks))
+	for _, h := range hooks {
+		hs := HookSummary{Name: h.Name, Kind: h.Kind, Path: h.Path}
+		for _, e := range h.Events {
+			hs.Events = append(hs.Events, e.String())
+		}
+		if h.LastRun != nil && h.LastRun.Seconds != 0 {
+			t := timeconv.Time(h.LastRun).UTC()
+			hs.LastRun = &t
+		}
+		summaries = append(summaries, hs)
+	}
+	return summaries
+}
@@ -0,0 +1,88 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package releaseutil // import "k8s.io/helm/pkg/releaseutil"
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	rspb "k8s.io/helm/pkg/proto/hapi/release"
+)
+
+const summaryManifest = `---
+# Source: mychart/templates/svc.yaml
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+---
+# Source: mychart/templates/deploy.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+---
+# Source: mychart/templates/cm.yaml
+apiVersion: v1
+kind: Service
+metadata:
+  name: api
+`
+
+func TestNewSummary(t *testing.T) {
+	rel := &rspb.Release{
+		Name:      "angry-bird",
+		Namespace: "default",
+		Version:   3,
+		Manifest:  summaryManifest,
+		Chart:     &chart.Chart{Metadata: &chart.Metadata{Name: "mychart", Version: "0.1.0", AppVersion: "1.0"}},
+		Info: &rspb.Info{
+			Status:      &rspb.Status{Code: rspb.Status_DEPLOYED, Notes: "hello"},
+			Description: "Upgrade complete",
+		},
+		Hooks: []*rspb.Hook{
+			{Name: "migrate", Kind: "Job", Path: "templates/job.yaml", Events: []rspb.Hook_Event{rspb.Hook_PRE_UPGRADE}},
+		},
+	}
+
+	s := NewSummary(rel)
+	if s.Chart != "mychart-0.1.0" || s.AppVersion != "1.0" {
+		t.Errorf("unexpected chart %q, app version %q", s.Chart, s.AppVersion)
+	}
+	if s.Status != "DEPLOYED" || s.Revision != 3 || s.Notes != "hello" {
+		t.Errorf("unexpected status %q, revision %d, notes %q", s.Status, s.Revision, s.Notes)
+	}
+	if s.LastDeployed != nil {
+		t.Errorf("expected no deploy time, got %s", s.LastDeployed)
+	}
+
+	expect := []ResourceGroup{
+		{Kind: "apps/v1/Deployment", Names: []string{"web"}},
+		{Kind: "v1/Service", Names: []string{"api", "web"}},
+	}
+	if !reflect.DeepEqual(s.Resources, expect) {
+		t.Errorf("expected resources %v, got %v", expect, s.Resources)
+	}
+
+	if len(s.Hooks) != 1 || s.Hooks[0].Name != "migrate" || !reflect.DeepEqual(s.Hooks[0].Events, []string{"PRE_UPGRADE"}) {
+		t.Errorf("unexpected hooks %v", s.Hooks)
+	}
+	if s.Hooks[0].LastRun != nil {
+		t.Errorf("expected hook not to have run, got %s", s.Hooks[0].LastRun)
+	}
+}
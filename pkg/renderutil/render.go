@@ -17,6 +17,7 @@ limitations under the License.
 package renderutil
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/Masterminds/semver"
@@ -32,6 +33,25 @@ type Options struct {
 	ReleaseOptions chartutil.ReleaseOptions
 	KubeVersion    string
 	APIVersions    []string
+	// Strict fails the rendering when a template references a value that is
+	// not set.
+	Strict bool
+	// Optional are the values templates may reference without them being set
+	// when Strict is enabled, in addition to the values declared optional by
+	// the chart.
+	Optional []string
+	// IsolateTemplates scopes named templates to the chart defining them.
+	IsolateTemplates bool
+	// Lookup, if set, is used by the 'lookup' template function instead of
+	// faking that no resource exists.
+	Lookup engine.LookupFunc
+	// Trace, if not nil, records the rendering of the templates.
+	Trace *engine.RenderTrace
+	// Scopes, if not nil, is set to the values of every rendered template,
+	// as set by engine.Engine.Scopes.
+	Scopes map[string]chartutil.Values
+	// Workers is the number of templates rendered in parallel.
+	Workers int
 }
 
 // Render chart templates locally and display the output.
@@ -44,25 +64,88 @@ type Options struct {
 // if you want the normal behavior of merging the defaults with the new config,
 // you should pass `&chart.Config{Raw: "{}"},
 func Render(c *chart.Chart, config *chart.Config, opts Options) (map[string]string, error) {
+	return RenderWithContext(context.Background(), c, config, opts)
+}
+
+// RenderWithContext is Render, stopping with the error of ctx when ctx is
+// done before the chart is rendered.
+func RenderWithContext(ctx context.Context, c *chart.Chart, config *chart.Config, opts Options) (map[string]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	renderer, vals, err := prepare(ctx, c, config, opts)
+	if err != nil {
+		return nil, err
+	}
+	return renderer.RenderWithContext(ctx, c, vals)
+}
+
+// Renderer renders a chart locally, and renders it again after changes of the
+// chart or of the values, only rendering the templates affected by the
+// changes.
+type Renderer struct {
+	opts     Options
+	renderer *engine.Renderer
+}
+
+// NewRenderer renders the chart c like Render, and returns a Renderer to
+// render it again after changes.
+func NewRenderer(c *chart.Chart, config *chart.Config, opts Options) (*Renderer, error) {
+	e, vals, err := prepare(context.Background(), c, config, opts)
+	if err != nil {
+		return nil, err
+	}
+	renderer, err := e.NewRenderer(c, vals)
+	if err != nil {
+		return nil, err
+	}
+	return &Renderer{opts: opts, renderer: renderer}, nil
+}
+
+// Rendered returns the rendered templates.
+func (r *Renderer) Rendered() map[string]string {
+	return r.renderer.Rendered()
+}
+
+// Update renders the new version c of the chart with config, and returns the
+// names of the templates that were rendered again.
+func (r *Renderer) Update(c *chart.Chart, config *chart.Config) ([]string, error) {
+	_, vals, err := prepare(context.Background(), c, config, r.opts)
+	if err != nil {
+		return nil, err
+	}
+	return r.renderer.Update(c, vals)
+}
+
+// prepare processes the requirements of the chart c, and returns the engine
+// and the values to render it with, until ctx is done.
+func prepare(ctx context.Context, c *chart.Chart, config *chart.Config, opts Options) (*engine.Engine, chartutil.Values, error) {
 	if req, err := chartutil.LoadRequirements(c); err == nil {
 		if err := CheckDependencies(c, req); err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 	} else if err != chartutil.ErrRequirementsNotFound {
-		return nil, fmt.Errorf("cannot load requirements: %v", err)
+		return nil, nil, fmt.Errorf("cannot load requirements: %v", err)
 	}
 
 	err := chartutil.ProcessRequirementsEnabled(c, config)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	err = chartutil.ProcessRequirementsImportValues(c)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Set up engine.
 	renderer := engine.New()
+	renderer.Strict = opts.Strict
+	renderer.Optional = opts.Optional
+	renderer.Isolate = opts.IsolateTemplates
+	renderer.Lookup = opts.Lookup
+	renderer.Trace = opts.Trace
+	renderer.Scopes = opts.Scopes
+	renderer.Workers = opts.Workers
 
 	caps := &chartutil.Capabilities{
 		APIVersions:   chartutil.DefaultVersionSet,
@@ -73,7 +156,7 @@ func Render(c *chart.Chart, config *chart.Config, opts Options) (map[string]stri
 	if opts.KubeVersion != "" {
 		kv, verErr := semver.NewVersion(opts.KubeVersion)
 		if verErr != nil {
-			return nil, fmt.Errorf("could not parse a kubernetes version: %v", verErr)
+			return nil, nil, fmt.Errorf("could not parse a kubernetes version: %v", verErr)
 		}
 		caps.KubeVersion.Major = fmt.Sprint(kv.Major())
 		caps.KubeVersion.Minor = fmt.Sprint(kv.Minor())
@@ -84,10 +167,20 @@ func Render(c *chart.Chart, config *chart.Config, opts Options) (map[string]stri
 		caps.APIVersions = chartutil.NewVersionSet(append(opts.APIVersions, "v1")...)
 	}
 
+	if chartutil.IsLibraryChart(c) {
+		return nil, nil, fmt.Errorf("library chart %s is not installable", c.Metadata.Name)
+	}
+	if err := chartutil.CheckKubeVersion(c, caps.KubeVersion.GitVersion); err != nil {
+		return nil, nil, err
+	}
+
 	vals, err := chartutil.ToRenderValuesCaps(c, config, opts.ReleaseOptions, caps)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
 	}
 
-	return renderer.Render(c, vals)
+	return renderer, vals, nil
 }
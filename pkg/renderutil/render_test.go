@@ -17,6 +17,7 @@ limitations under the License.
 package renderutil
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -151,3 +152,34 @@ data:
 		})
 	}
 }
+
+func TestRenderChartChecks(t *testing.T) {
+	library := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "library", ApiVersion: "v2", Type: "library"},
+	}
+	_, err := Render(library, &chart.Config{Raw: "{}"}, Options{})
+	require.EqualError(t, err, "library chart library is not installable")
+
+	kube := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "hello", KubeVersion: ">=1.16.0"},
+	}
+	_, err = Render(kube, &chart.Config{Raw: "{}"}, Options{KubeVersion: "1.15"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Chart requires kubernetesVersion")
+
+	_, err = Render(kube, &chart.Config{Raw: "{}"}, Options{KubeVersion: "1.16"})
+	require.NoError(t, err)
+}
+
+func TestRenderWithContext(t *testing.T) {
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "hello"},
+		Templates: []*chart.Template{
+			{Name: "templates/cm.yaml", Data: []byte(cmTemplate)},
+		},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := RenderWithContext(ctx, c, &chart.Config{Raw: "{}"}, Options{})
+	require.Equal(t, context.Canceled, err)
+}
@@ -0,0 +1,78 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repo
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/helm/pkg/getter"
+)
+
+// CacheValidatorsFile returns the file holding the validators of a cached
+// index file, e.g. "stable-index.cache.json" for "stable-index.yaml".
+func CacheValidatorsFile(cacheFile string) string {
+	return strings.TrimSuffix(cacheFile, filepath.Ext(cacheFile)) + ".cache.json"
+}
+
+// loadCacheValidators returns the validators of a cached index file. They are
+// zero if the index is not cached.
+func loadCacheValidators(cacheFile string) getter.Validators {
+	var v getter.Validators
+	if _, err := os.Stat(cacheFile); err != nil {
+		return v
+	}
+	b, err := ioutil.ReadFile(CacheValidatorsFile(cacheFile))
+	if err != nil {
+		return v
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return getter.Validators{}
+	}
+	return v
+}
+
+// writeCacheValidators writes the validators of a cached index file, or
+// removes them if the server sent none.
+func writeCacheValidators(cacheFile string, v getter.Validators) error {
+	vf := CacheValidatorsFile(cacheFile)
+	if v.IsZero() {
+		if err := os.Remove(vf); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(vf, b, 0644)
+}
+
+// getIfModified gets the content of a URL, unless the client supports
+// conditional requests and the content matches the cached validators.
+func getIfModified(client getter.Getter, href string, cached getter.Validators) (*bytes.Buffer, getter.Validators, error) {
+	if g, ok := client.(getter.ConditionalGetter); ok {
+		return g.GetIfModified(href, cached)
+	}
+	buf, err := client.Get(href)
+	return buf, getter.Validators{}, err
+}
@@ -24,11 +24,13 @@ import (
 	"path"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/ghodss/yaml"
 
 	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/getter"
+	"k8s.io/helm/pkg/httpclient"
 	"k8s.io/helm/pkg/provenance"
 )
 
@@ -42,6 +44,15 @@ type Entry struct {
 	CertFile string `json:"certFile"`
 	KeyFile  string `json:"keyFile"`
 	CAFile   string `json:"caFile"`
+	// Proxy is the URL of the proxy to the repository. The proxy is read
+	// from the environment when it is empty.
+	Proxy string `json:"proxy,omitempty"`
+	// Timeout is the time in seconds to wait for a request to the
+	// repository. There is no limit when it is zero.
+	Timeout int64 `json:"timeout,omitempty"`
+	// CredentialsStore names the credential store holding the username and
+	// password. Credentials are kept in this file when it is empty.
+	CredentialsStore string `json:"credentialsStore,omitempty"`
 }
 
 // ChartRepository represents a chart repository
@@ -50,10 +61,18 @@ type ChartRepository struct {
 	ChartPaths []string
 	IndexFile  *IndexFile
 	Client     getter.Getter
+	// Refresh downloads the index even if the server reports that the cached
+	// index has not changed.
+	Refresh bool
 }
 
 // NewChartRepository constructs ChartRepository
 func NewChartRepository(cfg *Entry, getters getter.Providers) (*ChartRepository, error) {
+	cfg, err := cfg.withStoredCredentials()
+	if err != nil {
+		return nil, err
+	}
+
 	u, err := url.Parse(cfg.URL)
 	if err != nil {
 		return nil, fmt.Errorf("invalid chart URL format: %s", cfg.URL)
@@ -67,6 +86,19 @@ func NewChartRepository(cfg *Entry, getters getter.Providers) (*ChartRepository,
 	if err != nil {
 		return nil, fmt.Errorf("Could not construct protocol handler for: %s error: %v", u.Scheme, err)
 	}
+	if _, ok := client.(*getter.HttpGetter); ok && (cfg.Proxy != "" || cfg.Timeout > 0) {
+		client, err = getter.NewHTTPGetterWithOptions(httpclient.Options{
+			URL:      cfg.URL,
+			CertFile: cfg.CertFile,
+			KeyFile:  cfg.KeyFile,
+			CAFile:   cfg.CAFile,
+			Proxy:    cfg.Proxy,
+			Timeout:  time.Duration(cfg.Timeout) * time.Second,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("Could not construct protocol handler for: %s error: %v", u.Scheme, err)
+		}
+	}
 
 	return &ChartRepository{
 		Config:    cfg,
@@ -111,6 +143,10 @@ func (r *ChartRepository) Load() error {
 //
 // cachePath is prepended to any index that does not have an absolute path. This
 // is for pre-2.2.0 repo files.
+//
+// The index is only downloaded if it has changed since it was cached, as
+// reported by the ETag and Last-Modified headers of the server, unless
+// Refresh is set.
 func (r *ChartRepository) DownloadIndexFile(cachePath string) error {
 	parsedURL, err := url.Parse(r.Config.URL)
 	if err != nil {
@@ -120,8 +156,27 @@ func (r *ChartRepository) DownloadIndexFile(cachePath string) error {
 	parsedURL.Path = path.Join(parsedURL.Path, "index.yaml")
 	indexURL := parsedURL.String()
 
+	// In Helm 2.2.0 the config.cache was accidentally switched to an absolute
+	// path, which broke backward compatibility. This fixes it by prepending a
+	// global cache path to relative paths.
+	//
+	// It is changed on DownloadIndexFile because that was the method that
+	// originally carried the cache path.
+	cp := r.Config.Cache
+	if !filepath.IsAbs(cp) {
+		cp = filepath.Join(cachePath, cp)
+	}
+
+	var cached getter.Validators
+	if !r.Refresh {
+		cached = loadCacheValidators(cp)
+	}
+
 	r.setCredentials()
-	resp, err := r.Client.Get(indexURL)
+	resp, validators, err := getIfModified(r.Client, indexURL, cached)
+	if err == getter.ErrNotModified {
+		return nil
+	}
 	if err != nil {
 		return err
 	}
@@ -131,22 +186,35 @@ func (r *ChartRepository) DownloadIndexFile(cachePath string) error {
 		return err
 	}
 
-	if _, err := loadIndex(index); err != nil {
+	i, err := loadIndex(index)
+	if err != nil {
 		return err
 	}
-
-	// In Helm 2.2.0 the config.cache was accidentally switched to an absolute
-	// path, which broke backward compatibility. This fixes it by prepending a
-	// global cache path to relative paths.
-	//
-	// It is changed on DownloadIndexFile because that was the method that
-	// originally carried the cache path.
-	cp := r.Config.Cache
-	if !filepath.IsAbs(cp) {
-		cp = filepath.Join(cachePath, cp)
+	if len(i.Shards) > 0 {
+		// The shards are cached with the index, as a single unsharded index.
+		err := i.loadShards(func(shard string) ([]byte, error) {
+			shardURL, err := ResolveReferenceURL(r.Config.URL, shard)
+			if err != nil {
+				return nil, err
+			}
+			resp, err := r.Client.Get(shardURL)
+			if err != nil {
+				return nil, err
+			}
+			return ioutil.ReadAll(resp)
+		})
+		if err != nil {
+			return err
+		}
+		if index, err = yaml.Marshal(i); err != nil {
+			return err
+		}
 	}
 
-	return ioutil.WriteFile(cp, index, 0644)
+	if err := ioutil.WriteFile(cp, index, 0644); err != nil {
+		return err
+	}
+	return writeCacheValidators(cp, validators)
 }
 
 // If HttpGetter is used, this method sets the configured repository credentials on the HttpGetter.
@@ -211,6 +279,7 @@ func FindChartInAuthRepoURL(repoURL, username, password, chartName, chartVersion
 		return "", fmt.Errorf("cannot write index file for repository requested")
 	}
 	defer os.Remove(tempIndexFile.Name())
+	defer os.Remove(CacheValidatorsFile(tempIndexFile.Name()))
 
 	c := Entry{
 		URL:      repoURL,
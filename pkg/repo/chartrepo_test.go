@@ -214,6 +214,45 @@ func startLocalServerForTests(handler http.Handler) (*httptest.Server, error) {
 	return httptest.NewServer(handler), nil
 }
 
+func TestChartRepositoryProxy(t *testing.T) {
+	fileBytes, err := ioutil.ReadFile("testdata/local-index.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var requested string
+	proxy, err := startLocalServerForTests(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = r.URL.String()
+		w.Write(fileBytes)
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer proxy.Close()
+
+	dirName, err := ioutil.TempDir("", "tmp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dirName)
+
+	r, err := NewChartRepository(&Entry{
+		Name:    testRepo,
+		URL:     "http://charts.example.invalid",
+		Cache:   filepath.Join(dirName, testRepo+"-index.yaml"),
+		Proxy:   proxy.URL,
+		Timeout: 10,
+	}, getter.All(environment.EnvSettings{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.DownloadIndexFile(""); err != nil {
+		t.Fatal(err)
+	}
+	if requested != "http://charts.example.invalid/index.yaml" {
+		t.Errorf("Expected the index to be requested through the proxy, got %q", requested)
+	}
+}
+
 func TestFindChartInRepoURL(t *testing.T) {
 	srv, err := startLocalServerForTests(nil)
 	if err != nil {
@@ -0,0 +1,109 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repo // import "k8s.io/helm/pkg/repo"
+
+import (
+	"fmt"
+	"sync"
+
+	"k8s.io/helm/pkg/credentials"
+)
+
+var (
+	storesMu sync.Mutex
+	stores   = map[string]credentials.Store{}
+)
+
+// credentialsStore returns the credential store with the given name. The
+// stores cache the credentials, so that a helper is run once per server even
+// when several repositories share it.
+var credentialsStore = func(name string) credentials.Store {
+	storesMu.Lock()
+	defer storesMu.Unlock()
+	s, ok := stores[name]
+	if !ok {
+		s = credentials.NewCachingStore(credentials.NewHelperStore(name))
+		stores[name] = s
+	}
+	return s
+}
+
+// StoreCredentials moves the username and password of the entry into the
+// named credential store. The entry only keeps the name of the store, so the
+// credentials are no longer written to the repositories file.
+func (e *Entry) StoreCredentials(store string) error {
+	if e.Username == "" && e.Password == "" {
+		return nil
+	}
+	if err := credentialsStore(store).Store(e.URL, e.Username, e.Password); err != nil {
+		return fmt.Errorf("cannot save credentials of repository %q in the %s credential store: %s", e.Name, store, err)
+	}
+	e.CredentialsStore = store
+	e.Username = ""
+	e.Password = ""
+	return nil
+}
+
+// PlaintextCredentials moves the credentials of the entry out of its
+// credential store and back into the entry.
+func (e *Entry) PlaintextCredentials() error {
+	if e.CredentialsStore == "" {
+		return nil
+	}
+	resolved, err := e.withStoredCredentials()
+	if err != nil {
+		return err
+	}
+	if err := credentialsStore(e.CredentialsStore).Erase(e.URL); err != nil && err != credentials.ErrNotFound {
+		return fmt.Errorf("cannot remove credentials of repository %q from the %s credential store: %s", e.Name, e.CredentialsStore, err)
+	}
+	*e = *resolved
+	e.CredentialsStore = ""
+	return nil
+}
+
+// EraseCredentials removes the credentials of the entry from its credential store.
+func (e *Entry) EraseCredentials() error {
+	if e.CredentialsStore == "" {
+		return nil
+	}
+	err := credentialsStore(e.CredentialsStore).Erase(e.URL)
+	if err != nil && err != credentials.ErrNotFound {
+		return fmt.Errorf("cannot remove credentials of repository %q from the %s credential store: %s", e.Name, e.CredentialsStore, err)
+	}
+	return nil
+}
+
+// withStoredCredentials returns a copy of the entry holding the credentials
+// read from its credential store, for its URL or else for its host. Entries
+// without a store are returned as is.
+func (e *Entry) withStoredCredentials() (*Entry, error) {
+	if e.CredentialsStore == "" || e.Username != "" || e.Password != "" {
+		return e, nil
+	}
+	username, password, err := credentials.Lookup(credentialsStore(e.CredentialsStore), e.URL)
+	if err == credentials.ErrNotFound {
+		return e, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read credentials of repository %q from the %s credential store: %s", e.Name, e.CredentialsStore, err)
+	}
+	c := *e
+	c.Username = username
+	c.Password = password
+	return &c, nil
+}
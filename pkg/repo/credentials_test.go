@@ -0,0 +1,101 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repo
+
+import (
+	"testing"
+
+	"k8s.io/helm/pkg/credentials"
+	"k8s.io/helm/pkg/getter"
+	"k8s.io/helm/pkg/helm/environment"
+)
+
+type fakeStore map[string][2]string
+
+func (s fakeStore) Get(url string) (string, string, error) {
+	c, ok := s[url]
+	if !ok {
+		return "", "", credentials.ErrNotFound
+	}
+	return c[0], c[1], nil
+}
+
+func (s fakeStore) Store(url, username, secret string) error {
+	s[url] = [2]string{username, secret}
+	return nil
+}
+
+func (s fakeStore) Erase(url string) error {
+	if _, ok := s[url]; !ok {
+		return credentials.ErrNotFound
+	}
+	delete(s, url)
+	return nil
+}
+
+func TestEntryCredentialsStore(t *testing.T) {
+	store := fakeStore{}
+	defer func(old func(string) credentials.Store) { credentialsStore = old }(credentialsStore)
+	credentialsStore = func(string) credentials.Store { return store }
+
+	e := &Entry{Name: "stable", URL: "https://charts.example.com", Username: "user", Password: "s3cr3t"}
+	if err := e.StoreCredentials("test"); err != nil {
+		t.Fatal(err)
+	}
+	if e.Username != "" || e.Password != "" || e.CredentialsStore != "test" {
+		t.Errorf("expected credentials to be moved to the store, got %+v", e)
+	}
+	if _, ok := store[e.URL]; !ok {
+		t.Fatal("expected credentials in the store")
+	}
+
+	r, err := NewChartRepository(e, getter.All(environment.EnvSettings{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Config.Username != "user" || r.Config.Password != "s3cr3t" {
+		t.Errorf("expected stored credentials to be used, got %s/%s", r.Config.Username, r.Config.Password)
+	}
+	if e.Username != "" {
+		t.Error("expected the repository entry to be left unchanged")
+	}
+
+	if err := e.PlaintextCredentials(); err != nil {
+		t.Fatal(err)
+	}
+	if e.Username != "user" || e.Password != "s3cr3t" || e.CredentialsStore != "" {
+		t.Errorf("expected plaintext credentials, got %+v", e)
+	}
+	if len(store) != 0 {
+		t.Error("expected credentials to be erased from the store")
+	}
+}
+
+func TestEntryHostCredentials(t *testing.T) {
+	store := fakeStore{"charts.example.com": {"user", "s3cr3t"}}
+	defer func(old func(string) credentials.Store) { credentialsStore = old }(credentialsStore)
+	credentialsStore = func(string) credentials.Store { return store }
+
+	e := &Entry{Name: "stable", URL: "https://charts.example.com/stable", CredentialsStore: "test"}
+	r, err := NewChartRepository(e, getter.All(environment.EnvSettings{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Config.Username != "user" || r.Config.Password != "s3cr3t" {
+		t.Errorf("expected the credentials of the host to be used, got %s/%s", r.Config.Username, r.Config.Password)
+	}
+}
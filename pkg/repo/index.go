@@ -39,6 +39,9 @@ import (
 
 var indexPath = "index.yaml"
 
+// shardDir is the directory of the index shards, relative to the index.
+const shardDir = "index"
+
 // APIVersionV1 is the v1 API version for index and repository files.
 const APIVersionV1 = "v1"
 
@@ -81,6 +84,10 @@ type IndexFile struct {
 	Generated  time.Time                `json:"generated"`
 	Entries    map[string]ChartVersions `json:"entries"`
 	PublicKeys []string                 `json:"publicKeys,omitempty"`
+	// Shards maps the name of a chart to the index file of its versions,
+	// relative to this index. The entries of a sharded index are in its
+	// shards.
+	Shards map[string]string `json:"shards,omitempty"`
 }
 
 // NewIndexFile initializes an index.
@@ -94,28 +101,28 @@ func NewIndexFile() *IndexFile {
 }
 
 // LoadIndexFile takes a file at the given path and returns an IndexFile object
+//
+// The shards of a sharded index are read from the directory of the file.
 func LoadIndexFile(path string) (*IndexFile, error) {
 	b, err := ioutil.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
-	return loadIndex(b)
+	i, err := loadIndex(b)
+	if err != nil {
+		return nil, err
+	}
+	err = i.loadShards(func(shard string) ([]byte, error) {
+		return ioutil.ReadFile(filepath.Join(filepath.Dir(path), filepath.FromSlash(shard)))
+	})
+	return i, err
 }
 
 // Add adds a file to the index
 // This can leave the index in an unsorted state
 func (i IndexFile) Add(md *chart.Metadata, filename, baseURL, digest string) {
-	u := filename
-	if baseURL != "" {
-		var err error
-		_, file := filepath.Split(filename)
-		u, err = urlutil.URLJoin(baseURL, file)
-		if err != nil {
-			u = path.Join(baseURL, file)
-		}
-	}
 	cr := &ChartVersion{
-		URLs:     []string{u},
+		URLs:     []string{chartURL(filename, baseURL)},
 		Metadata: md,
 		Digest:   digest,
 		Created:  time.Now(),
@@ -201,6 +208,65 @@ func (i IndexFile) WriteFile(dest string, mode os.FileMode) error {
 	return ioutil.WriteFile(dest, b, mode)
 }
 
+// WriteShards writes the versions of every chart to their own index file in
+// the index directory of dir, and an index.yaml file listing these shards to
+// dir.
+//
+// The mode on the files is set to 'mode'.
+func (i IndexFile) WriteShards(dir string, mode os.FileMode) error {
+	if err := os.MkdirAll(filepath.Join(dir, shardDir), 0755); err != nil {
+		return err
+	}
+
+	root := &IndexFile{
+		APIVersion: i.APIVersion,
+		Generated:  i.Generated,
+		Entries:    map[string]ChartVersions{},
+		PublicKeys: i.PublicKeys,
+		Shards:     map[string]string{},
+	}
+	for name, versions := range i.Entries {
+		shard := &IndexFile{
+			APIVersion: i.APIVersion,
+			Generated:  i.Generated,
+			Entries:    map[string]ChartVersions{name: versions},
+		}
+		p := path.Join(shardDir, name+".yaml")
+		if err := shard.WriteFile(filepath.Join(dir, filepath.FromSlash(p)), mode); err != nil {
+			return err
+		}
+		root.Shards[name] = p
+	}
+	return root.WriteFile(filepath.Join(dir, indexPath), mode)
+}
+
+// loadShards adds the versions of the shards of the index to its entries.
+// read returns the content of a shard given its path relative to the index.
+func (i *IndexFile) loadShards(read func(shard string) ([]byte, error)) error {
+	if len(i.Shards) == 0 {
+		return nil
+	}
+	if i.Entries == nil {
+		i.Entries = map[string]ChartVersions{}
+	}
+	for name, shard := range i.Shards {
+		b, err := read(shard)
+		if err != nil {
+			return fmt.Errorf("cannot read the index shard of %s: %s", name, err)
+		}
+		s, err := loadIndex(b)
+		if err != nil {
+			return fmt.Errorf("cannot load the index shard of %s: %s", name, err)
+		}
+		for n, versions := range s.Entries {
+			i.Entries[n] = append(i.Entries[n], versions...)
+		}
+	}
+	i.Shards = nil
+	i.SortEntries()
+	return nil
+}
+
 // Merge merges the given index file into this index.
 //
 // This merges by name and version.
@@ -237,6 +303,27 @@ type ChartVersion struct {
 //
 // The index returned will be in an unsorted state
 func IndexDirectory(dir, baseURL string) (*IndexFile, error) {
+	return UpdateIndexDirectory(dir, baseURL, nil)
+}
+
+// UpdateIndexDirectory reads a (flat) directory and generates an index like
+// IndexDirectory, but reuses the entries of the given index for the archives
+// that have not been modified since they were added to it. Only the new and
+// modified archives are loaded and digested.
+//
+// The index returned will be in an unsorted state
+func UpdateIndexDirectory(dir, baseURL string, indexed *IndexFile) (*IndexFile, error) {
+	known := map[string]*ChartVersion{}
+	if indexed != nil {
+		for _, cvs := range indexed.Entries {
+			for _, cv := range cvs {
+				if len(cv.URLs) > 0 {
+					known[cv.URLs[0]] = cv
+				}
+			}
+		}
+	}
+
 	archives, err := filepath.Glob(filepath.Join(dir, "*.tgz"))
 	if err != nil {
 		return nil, err
@@ -263,6 +350,17 @@ func IndexDirectory(dir, baseURL string) (*IndexFile, error) {
 			parentURL = path.Join(baseURL, parentDir)
 		}
 
+		if cv, ok := known[chartURL(fname, parentURL)]; ok && cv.Metadata != nil {
+			fi, err := os.Stat(arch)
+			if err != nil {
+				return index, err
+			}
+			if !fi.ModTime().After(cv.Created) {
+				index.Entries[cv.Name] = append(index.Entries[cv.Name], cv)
+				continue
+			}
+		}
+
 		c, err := chartutil.Load(arch)
 		if err != nil {
 			// Assume this is not a chart.
@@ -277,6 +375,19 @@ func IndexDirectory(dir, baseURL string) (*IndexFile, error) {
 	return index, nil
 }
 
+// chartURL returns the URL of a chart archive in the repository at baseURL.
+func chartURL(filename, baseURL string) string {
+	if baseURL == "" {
+		return filename
+	}
+	_, file := filepath.Split(filename)
+	u, err := urlutil.URLJoin(baseURL, file)
+	if err != nil {
+		u = path.Join(baseURL, file)
+	}
+	return u
+}
+
 // loadIndex loads an index file and does minimal validity checking.
 //
 // This will fail if API Version is not set (ErrNoAPIVersion) or if the unmarshal fails.
@@ -23,6 +23,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"k8s.io/helm/pkg/getter"
 	"k8s.io/helm/pkg/helm/environment"
@@ -243,6 +244,70 @@ func TestDownloadIndexFile(t *testing.T) {
 
 		verifyLocalIndex(t, i)
 	})
+
+	t.Run("should only download a modified index", func(t *testing.T) {
+		fileBytes, err := ioutil.ReadFile("testdata/local-index.yaml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		downloads := 0
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("If-None-Match") == `"v1"` {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			downloads++
+			w.Header().Set("ETag", `"v1"`)
+			w.Write(fileBytes)
+		})
+		srv, err := startLocalServerForTests(handler)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer srv.Close()
+
+		dirName, err := ioutil.TempDir("", "tmp")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(dirName)
+
+		indexFilePath := filepath.Join(dirName, testRepo+"-index.yaml")
+		r, err := NewChartRepository(&Entry{
+			Name:  testRepo,
+			URL:   srv.URL,
+			Cache: indexFilePath,
+		}, getter.All(environment.EnvSettings{}))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for n := 0; n < 2; n++ {
+			if err := r.DownloadIndexFile(""); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if downloads != 1 {
+			t.Errorf("Expected the unchanged index to be downloaded once, got %d downloads", downloads)
+		}
+		if _, err := os.Stat(filepath.Join(dirName, testRepo+"-index.cache.json")); err != nil {
+			t.Errorf("Expected the validators of the cached index: %s", err)
+		}
+
+		r.Refresh = true
+		if err := r.DownloadIndexFile(""); err != nil {
+			t.Fatal(err)
+		}
+		if downloads != 2 {
+			t.Errorf("Expected --refresh to download the index, got %d downloads", downloads)
+		}
+
+		i, err := LoadIndexFile(indexFilePath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		verifyLocalIndex(t, i)
+	})
 }
 
 func verifyLocalIndex(t *testing.T, i *IndexFile) {
@@ -382,6 +447,90 @@ func TestIndexDirectory(t *testing.T) {
 	}
 }
 
+func TestUpdateIndexDirectory(t *testing.T) {
+	dir := filepath.Join("testdata", "repository")
+	indexed, err := IndexDirectory(dir, "http://localhost:8080")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// frobnitz is indexed after its archive was written, sprocket before.
+	frobnitz := indexed.Entries["frobnitz"][0]
+	frobnitz.Digest = "sha256:indexed"
+	frobnitz.Created = time.Now().Add(time.Hour)
+	for _, sprocket := range indexed.Entries["sprocket"] {
+		sprocket.Digest = "sha256:indexed"
+		sprocket.Created = time.Time{}
+	}
+
+	index, err := UpdateIndexDirectory(dir, "http://localhost:8080", indexed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l := len(index.Entries); l != 3 {
+		t.Fatalf("Expected 3 entries, got %d", l)
+	}
+	if d := index.Entries["frobnitz"][0].Digest; d != "sha256:indexed" {
+		t.Errorf("Expected the entry of frobnitz to be reused, got digest %q", d)
+	}
+	for _, sprocket := range index.Entries["sprocket"] {
+		if sprocket.Digest == "sha256:indexed" {
+			t.Errorf("Expected sprocket %s to be digested again", sprocket.Version)
+		}
+	}
+}
+
+func TestWriteShards(t *testing.T) {
+	dir, err := ioutil.TempDir("", "helm-shards-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	i := NewIndexFile()
+	i.Add(&chart.Metadata{Name: "clipper", Version: "0.1.0"}, "clipper-0.1.0.tgz", "http://example.com/charts", "sha256:1234567890")
+	i.Add(&chart.Metadata{Name: "cutter", Version: "0.1.0"}, "cutter-0.1.0.tgz", "http://example.com/charts", "sha256:1234567890abc")
+	i.Add(&chart.Metadata{Name: "cutter", Version: "0.2.0"}, "cutter-0.2.0.tgz", "http://example.com/charts", "sha256:1234567890abc")
+	if err := i.WriteShards(dir, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, "index.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, err := loadIndex(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(root.Entries) != 0 {
+		t.Errorf("Expected no entries in the sharded index, got %d", len(root.Entries))
+	}
+	if s := root.Shards["cutter"]; s != "index/cutter.yaml" {
+		t.Errorf("Expected the shard of cutter to be index/cutter.yaml, got %q", s)
+	}
+	shard, err := LoadIndexFile(filepath.Join(dir, "index", "cutter.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(shard.Entries) != 1 || len(shard.Entries["cutter"]) != 2 {
+		t.Errorf("Expected the 2 versions of cutter in its shard, got %v", shard.Entries)
+	}
+
+	loaded, err := LoadIndexFile(filepath.Join(dir, "index.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded.Shards) != 0 {
+		t.Errorf("Expected the shards to be loaded, got %v", loaded.Shards)
+	}
+	if !loaded.Has("clipper", "0.1.0") || !loaded.Has("cutter", "0.2.0") {
+		t.Errorf("Expected the entries of the shards, got %v", loaded.Entries)
+	}
+	if v := loaded.Entries["cutter"][0].Version; v != "0.2.0" {
+		t.Errorf("Expected the versions of cutter to be sorted, got %s first", v)
+	}
+}
+
 func TestLoadUnversionedIndex(t *testing.T) {
 	data, err := ioutil.ReadFile("testdata/unversioned-index.yaml")
 	if err != nil {
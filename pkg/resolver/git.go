@@ -0,0 +1,81 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// gitPrefix is the prefix of the repositories of dependencies in git
+// repositories.
+const gitPrefix = "git+"
+
+// gitSchemes are the schemes of the git repositories dependencies can be
+// cloned from. The other transports, like file:// or ext::, can run commands
+// or read files of the host.
+var gitSchemes = map[string]bool{"https": true, "http": true, "ssh": true}
+
+// GitRepository is the git repository of a dependency, e.g.
+// "git+https://github.com/org/charts?ref=v1.2.3&path=charts/foo".
+type GitRepository struct {
+	// URL is the URL to clone the repository from.
+	URL string
+	// Ref is the branch, tag or commit of the chart. The default branch is
+	// used if it is empty.
+	Ref string
+	// Path is the directory of the chart in the repository.
+	Path string
+}
+
+// IsGitRepository returns whether the repository of a dependency is a git
+// repository.
+func IsGitRepository(repo string) bool {
+	return strings.HasPrefix(repo, gitPrefix)
+}
+
+// ParseGitRepository parses the git repository of a dependency. The URL must
+// be https://, http:// or ssh://, and neither the URL nor the ref may start
+// with a dash, which git would parse as an option.
+func ParseGitRepository(repo string) (*GitRepository, error) {
+	if !IsGitRepository(repo) {
+		return nil, fmt.Errorf("%s is not a git repository", repo)
+	}
+	u, err := url.Parse(strings.TrimPrefix(repo, gitPrefix))
+	if err != nil {
+		return nil, fmt.Errorf("invalid git repository %s: %s", repo, err)
+	}
+	if u.Scheme == "" {
+		return nil, fmt.Errorf("invalid git repository %s: missing the scheme, e.g. git+https://", repo)
+	}
+	if !gitSchemes[u.Scheme] {
+		return nil, fmt.Errorf("invalid git repository %s: the scheme must be https, http or ssh", repo)
+	}
+
+	q := u.Query()
+	g := &GitRepository{Ref: q.Get("ref"), Path: path.Clean("/" + q.Get("path"))[1:]}
+	q.Del("ref")
+	q.Del("path")
+	u.RawQuery = q.Encode()
+	g.URL = u.String()
+	if strings.HasPrefix(g.URL, "-") || strings.HasPrefix(g.Ref, "-") {
+		return nil, fmt.Errorf("invalid git repository %s: the URL and the ref cannot start with a dash", repo)
+	}
+	return g, nil
+}
@@ -0,0 +1,65 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseGitRepository(t *testing.T) {
+	tests := []struct {
+		repo   string
+		expect *GitRepository
+		err    bool
+	}{
+		{
+			repo:   "git+https://github.com/org/charts?ref=v1.2.3&path=charts/foo",
+			expect: &GitRepository{URL: "https://github.com/org/charts", Ref: "v1.2.3", Path: "charts/foo"},
+		},
+		{
+			repo:   "git+ssh://git@github.com/org/foo.git",
+			expect: &GitRepository{URL: "ssh://git@github.com/org/foo.git"},
+		},
+		{
+			repo:   "git+https://example.com/charts?token=abc&path=../../etc",
+			expect: &GitRepository{URL: "https://example.com/charts?token=abc", Path: "etc"},
+		},
+		{repo: "git+github.com/org/charts", err: true},
+		{repo: "git+https://github.com/org/charts?ref=--upload-pack=touch%20/tmp/pwned", err: true},
+		{repo: "git+https://github.com/org/charts?ref=-b", err: true},
+		{repo: "git+file:///tmp/charts", err: true},
+		{repo: "git+ext::sh -c touch /tmp/pwned", err: true},
+		{repo: "https://github.com/org/charts", err: true},
+	}
+	for _, tt := range tests {
+		g, err := ParseGitRepository(tt.repo)
+		if tt.err {
+			if err == nil {
+				t.Errorf("%s: expected an error", tt.repo)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %s", tt.repo, err)
+			continue
+		}
+		if !reflect.DeepEqual(g, tt.expect) {
+			t.Errorf("%s: expected %+v, got %+v", tt.repo, tt.expect, g)
+		}
+	}
+}
@@ -32,6 +32,10 @@ import (
 	"k8s.io/helm/pkg/repo"
 )
 
+// AnyRepository is the repository of a dependency found in any of the
+// configured repositories.
+const AnyRepository = "@*"
+
 // Resolver resolves dependencies from semantic version ranges to a particular version.
 type Resolver struct {
 	chartpath string
@@ -79,11 +83,41 @@ func (r *Resolver) Resolve(reqs *chartutil.Requirements, repoNames map[string]st
 			}
 			continue
 		}
+		if IsGitRepository(d.Repository) {
+			// The version is resolved when the repository is cloned.
+			if _, err := ParseGitRepository(d.Repository); err != nil {
+				return nil, err
+			}
+
+			locked[i] = &chartutil.Dependency{
+				Name:       d.Name,
+				Repository: d.Repository,
+				Version:    d.Version,
+			}
+			continue
+		}
 		constraint, err := semver.NewConstraint(d.Version)
 		if err != nil {
 			return nil, fmt.Errorf("dependency %q has an invalid version/constraint format: %s", d.Name, err)
 		}
 
+		if d.Repository == AnyRepository {
+			v, url, err := r.resolveAny(d.Name, constraint)
+			if err != nil {
+				return nil, err
+			}
+			if v == nil {
+				missing = append(missing, d.Name)
+				continue
+			}
+			locked[i] = &chartutil.Dependency{
+				Name:       d.Name,
+				Repository: url,
+				Version:    v.Original(),
+			}
+			continue
+		}
+
 		// repo does not exist in cache but has url info
 		cacheRepoName := repoNames[d.Name]
 		if cacheRepoName == "" && d.Repository != "" {
@@ -109,22 +143,9 @@ func (r *Resolver) Resolve(reqs *chartutil.Requirements, repoNames map[string]st
 			Name:       d.Name,
 			Repository: d.Repository,
 		}
-		found := false
-		// The version are already sorted and hence the first one to satisfy the constraint is used
-		for _, ver := range vs {
-			v, err := semver.NewVersion(ver.Version)
-			if err != nil || len(ver.URLs) == 0 {
-				// Not a legit entry.
-				continue
-			}
-			if constraint.Check(v) {
-				found = true
-				locked[i].Version = v.Original()
-				break
-			}
-		}
-
-		if !found {
+		if v := highestVersion(vs, constraint); v != nil {
+			locked[i].Version = v.Original()
+		} else {
 			missing = append(missing, d.Name)
 		}
 	}
@@ -138,6 +159,50 @@ func (r *Resolver) Resolve(reqs *chartutil.Requirements, repoNames map[string]st
 	}, nil
 }
 
+// resolveAny returns the highest version of a chart satisfying the constraint
+// in the cached indexes of all the configured repositories, and the URL of the
+// repository providing it. The version is nil if none satisfies the
+// constraint.
+func (r *Resolver) resolveAny(name string, constraint *semver.Constraints) (*semver.Version, string, error) {
+	rf, err := repo.LoadRepositoriesFile(r.helmhome.RepositoryFile())
+	if err != nil {
+		return nil, "", err
+	}
+
+	var (
+		highest *semver.Version
+		url     string
+	)
+	for _, re := range rf.Repositories {
+		repoIndex, err := repo.LoadIndexFile(r.helmhome.CacheIndex(re.Name))
+		if err != nil {
+			// The repository is not cached (try 'helm repo update').
+			continue
+		}
+		v := highestVersion(repoIndex.Entries[name], constraint)
+		if v != nil && (highest == nil || v.GreaterThan(highest)) {
+			highest, url = v, re.URL
+		}
+	}
+	return highest, url, nil
+}
+
+// highestVersion returns the highest version satisfying the constraint, or nil.
+func highestVersion(vs repo.ChartVersions, constraint *semver.Constraints) *semver.Version {
+	// The version are already sorted and hence the first one to satisfy the constraint is used
+	for _, ver := range vs {
+		v, err := semver.NewVersion(ver.Version)
+		if err != nil || len(ver.URLs) == 0 {
+			// Not a legit entry.
+			continue
+		}
+		if constraint.Check(v) {
+			return v
+		}
+	}
+	return nil
+}
+
 // HashReq generates a hash of the requirements.
 //
 // This should be used only to compare against another hash generated by this
@@ -68,6 +68,41 @@ func TestResolve(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "highest version in any repo",
+			req: &chartutil.Requirements{
+				Dependencies: []*chartutil.Dependency{
+					{Name: "alpine", Repository: "@*", Version: ">=0.1.0"},
+				},
+			},
+			expect: &chartutil.RequirementsLock{
+				Dependencies: []*chartutil.Dependency{
+					{Name: "alpine", Repository: "http://mirror.example.com", Version: "0.3.0"},
+				},
+			},
+		},
+		{
+			name: "highest version satisfying the constraint in any repo",
+			req: &chartutil.Requirements{
+				Dependencies: []*chartutil.Dependency{
+					{Name: "alpine", Repository: "@*", Version: ">=0.1.0, <0.3.0"},
+				},
+			},
+			expect: &chartutil.RequirementsLock{
+				Dependencies: []*chartutil.Dependency{
+					{Name: "alpine", Repository: "http://example.com", Version: "0.2.0"},
+				},
+			},
+		},
+		{
+			name: "constraint not satisfied in any repo failure",
+			req: &chartutil.Requirements{
+				Dependencies: []*chartutil.Dependency{
+					{Name: "alpine", Repository: "@*", Version: ">=1.0.0"},
+				},
+			},
+			err: true,
+		},
 		{
 			name: "repo from valid local path",
 			req: &chartutil.Requirements{
@@ -90,6 +125,28 @@ func TestResolve(t *testing.T) {
 			},
 			err: true,
 		},
+		{
+			name: "repo from git",
+			req: &chartutil.Requirements{
+				Dependencies: []*chartutil.Dependency{
+					{Name: "foo", Repository: "git+https://example.com/charts?ref=v1.2.3&path=charts/foo", Version: "^1.2.0"},
+				},
+			},
+			expect: &chartutil.RequirementsLock{
+				Dependencies: []*chartutil.Dependency{
+					{Name: "foo", Repository: "git+https://example.com/charts?ref=v1.2.3&path=charts/foo", Version: "^1.2.0"},
+				},
+			},
+		},
+		{
+			name: "repo from invalid git url",
+			req: &chartutil.Requirements{
+				Dependencies: []*chartutil.Dependency{
+					{Name: "foo", Repository: "git+example.com/charts", Version: "^1.2.0"},
+				},
+			},
+			err: true,
+		},
 		{
 			name: "repo from valid path under charts path",
 			req: &chartutil.Requirements{
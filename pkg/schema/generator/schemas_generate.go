@@ -0,0 +1,153 @@
+//go:build ignore
+// +build ignore
+
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Generates the OpenAPI schemas of the Kubernetes versions bundled to
+// validate manifests offline. The descriptions of the schemas are removed, as
+// they are not needed to validate manifests and make most of their size.
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// versions are the bundled Kubernetes versions.
+var versions = []string{"1.13", "1.14", "1.15", "1.16"}
+
+const licenseHeader = `/*
+Copyright The Helm Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/`
+
+func main() {
+	source := flag.String("source", "https://raw.githubusercontent.com/kubernetes/kubernetes/v%s.0/api/openapi-spec/swagger.json", "URL or path of the OpenAPI schema of a Kubernetes version, with %s for the version")
+	flag.Parse()
+
+	var out bytes.Buffer
+	fmt.Fprintln(&out, licenseHeader)
+	fmt.Fprint(&out, "// Code generated by schemas_generate.go; DO NOT EDIT.\n\n")
+	fmt.Fprint(&out, "package schema\n\n")
+	fmt.Fprintln(&out, "// bundledSchemas are the gzipped OpenAPI schemas of the Kubernetes versions,")
+	fmt.Fprintln(&out, "// encoded in base64.")
+	fmt.Fprintln(&out, "var bundledSchemas = map[string]string{")
+	for _, v := range versions {
+		data, err := read(fmt.Sprintf(*source, v))
+		if err != nil {
+			fmt.Printf("reading the schema of %s: %s\n", v, err)
+			os.Exit(1)
+		}
+		schema, err := strip(data)
+		if err != nil {
+			fmt.Printf("stripping the schema of %s: %s\n", v, err)
+			os.Exit(1)
+		}
+		fmt.Fprintf(&out, "\t%q: %q,\n", v, schema)
+	}
+	fmt.Fprintln(&out, "}")
+
+	if err := ioutil.WriteFile("schemas_generated.go", out.Bytes(), 0644); err != nil {
+		fmt.Printf("writing output: %s", err)
+		os.Exit(1)
+	}
+}
+
+func read(source string) ([]byte, error) {
+	if !strings.HasPrefix(source, "https://") {
+		return ioutil.ReadFile(source)
+	}
+	resp, err := http.Get(source)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %s", source, resp.Status)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
+
+// strip returns the definitions of the schema without their descriptions,
+// gzipped and encoded in base64.
+func strip(data []byte) (string, error) {
+	var schema map[string]interface{}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return "", err
+	}
+	definitions := schema["definitions"]
+	removeDescriptions(definitions)
+	stripped := map[string]interface{}{
+		"swagger":     schema["swagger"],
+		"info":        schema["info"],
+		"paths":       map[string]interface{}{},
+		"definitions": definitions,
+	}
+	b, err := json.Marshal(stripped)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	w, err := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+	if err != nil {
+		return "", err
+	}
+	if _, err := w.Write(b); err != nil {
+		return "", err
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// removeDescriptions removes the descriptions of the schemas. A property named
+// description is kept, as its value is a schema, not a string.
+func removeDescriptions(v interface{}) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if _, ok := v["description"].(string); ok {
+			delete(v, "description")
+		}
+		for _, e := range v {
+			removeDescriptions(e)
+		}
+	case []interface{}:
+		for _, e := range v {
+			removeDescriptions(e)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+//go:generate go run generator/schemas_generate.go
+
+/*
+Package schema validates rendered manifests against the OpenAPI schemas of
+Kubernetes, without a cluster.
+
+The schemas of a few Kubernetes versions are bundled, without the
+descriptions of the fields. The schema of another version, or of a cluster
+with custom resources, can be read from a file, e.g. the output of
+'kubectl get --raw /openapi/v2'.
+*/
+package schema // import "k8s.io/helm/pkg/schema"
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver"
+	"github.com/ghodss/yaml"
+	openapi_v2 "github.com/googleapis/gnostic/OpenAPIv2"
+	"github.com/googleapis/gnostic/compiler"
+	yamlv2 "gopkg.in/yaml.v2"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/kubernetes/pkg/kubectl/cmd/util/openapi"
+	"k8s.io/kubernetes/pkg/kubectl/cmd/util/openapi/validation"
+
+	"k8s.io/helm/pkg/releaseutil"
+)
+
+// Error is an invalid manifest.
+type Error struct {
+	// Source is the template of the manifest.
+	Source string `json:"source"`
+	// Kind is the kind of the manifest.
+	Kind string `json:"kind,omitempty"`
+	// Name is the name of the manifest.
+	Name string `json:"name,omitempty"`
+	// Errors are the unknown fields, type errors and missing fields of the
+	// manifest.
+	Errors []string `json:"errors"`
+}
+
+// Errors is the error of manifests not valid against the schema.
+type Errors struct {
+	// Version is the Kubernetes version of the schema, or empty for a schema
+	// read from a file.
+	Version   string
+	Manifests []Error
+}
+
+func (e *Errors) Error() string {
+	var b strings.Builder
+	against := "the schema"
+	if e.Version != "" {
+		against = "Kubernetes " + e.Version
+	}
+	fmt.Fprintf(&b, "%d manifest(s) are not valid for %s:", len(e.Manifests), against)
+	for _, m := range e.Manifests {
+		fmt.Fprintf(&b, "\n  %s (%s/%s):", m.Source, m.Kind, m.Name)
+		for _, msg := range m.Errors {
+			fmt.Fprintf(&b, "\n    %s", msg)
+		}
+	}
+	return b.String()
+}
+
+// Validator validates manifests against an OpenAPI schema.
+type Validator struct {
+	version    string
+	validation *validation.SchemaValidation
+}
+
+// Versions returns the Kubernetes versions of the bundled schemas.
+func Versions() []string {
+	versions := make([]string, 0, len(bundledSchemas))
+	for v := range bundledSchemas {
+		versions = append(versions, v)
+	}
+	sort.Strings(versions)
+	return versions
+}
+
+// New returns a validator for the bundled schema of a Kubernetes version,
+// e.g. "1.15" or "v1.15.3". Only the major and minor version are used.
+func New(kubeVersion string) (*Validator, error) {
+	v, err := semver.NewVersion(kubeVersion)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse a kubernetes version: %v", err)
+	}
+	version := fmt.Sprintf("%d.%d", v.Major(), v.Minor())
+	encoded, ok := bundledSchemas[version]
+	if !ok {
+		return nil, fmt.Errorf("no schema is bundled for Kubernetes %s, the bundled schemas are for %s", version, strings.Join(Versions(), ", "))
+	}
+	compressed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+	r, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return newValidator(version, data)
+}
+
+// Load returns a validator for the OpenAPI schema of a file, in JSON or YAML.
+func Load(filename string) (*Validator, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	v, err := newValidator("", data)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load the schema %s: %s", filename, err)
+	}
+	return v, nil
+}
+
+func newValidator(version string, data []byte) (*Validator, error) {
+	var info yamlv2.MapSlice
+	if err := yamlv2.Unmarshal(data, &info); err != nil {
+		return nil, err
+	}
+	doc, err := openapi_v2.NewDocument(info, compiler.NewContext("$root", nil))
+	if err != nil {
+		return nil, err
+	}
+	resources, err := openapi.NewOpenAPIData(doc)
+	if err != nil {
+		return nil, err
+	}
+	return &Validator{version: version, validation: validation.NewSchemaValidation(resources)}, nil
+}
+
+// Validate validates every manifest of the rendered templates, and returns an
+// *Errors with the invalid manifests: the manifests with unknown fields,
+// fields of the wrong type or missing required fields. The manifests of a kind
+// without a schema, e.g. a custom resource, are not validated.
+func (v *Validator) Validate(templates map[string]string) error {
+	names := make([]string, 0, len(templates))
+	for name := range templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var invalid []Error
+	for _, name := range names {
+		base := path.Base(name)
+		if strings.HasPrefix(base, "_") || base == "NOTES.txt" {
+			continue
+		}
+		docs := releaseutil.SplitManifests(templates[name])
+		for i := 0; i < len(docs); i++ {
+			doc := docs[fmt.Sprintf("manifest-%d", i)]
+			var head releaseutil.SimpleHead
+			if err := yaml.Unmarshal([]byte(doc), &head); err != nil {
+				invalid = append(invalid, Error{Source: name, Errors: []string{err.Error()}})
+				continue
+			}
+			if head.Kind == "" && head.Version == "" {
+				continue
+			}
+			e := Error{Source: name, Kind: head.Kind}
+			if head.Metadata != nil {
+				e.Name = head.Metadata.Name
+			}
+			err := v.validation.ValidateBytes([]byte(doc))
+			if err == nil {
+				continue
+			}
+			if agg, ok := err.(utilerrors.Aggregate); ok {
+				for _, err := range agg.Errors() {
+					e.Errors = append(e.Errors, err.Error())
+				}
+			} else {
+				e.Errors = []string{err.Error()}
+			}
+			invalid = append(invalid, e)
+		}
+	}
+
+	if len(invalid) > 0 {
+		return &Errors{Version: v.version, Manifests: invalid}
+	}
+	return nil
+}
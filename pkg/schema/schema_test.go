@@ -0,0 +1,127 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const probe = `apiVersion: v1
+kind: Pod
+metadata:
+  name: web
+spec:
+  containers:
+  - name: web
+    image: nginx
+    startupProbe:
+      tcpSocket:
+        port: 80
+`
+
+const pod = `apiVersion: v1
+kind: Pod
+metadata:
+  name: web
+spec:
+  restartpolicy: Never
+  containers:
+  - name: web
+    image: nginx
+    ports:
+    - containerPort: "80"
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+spec:
+  ports:
+  - port: 80
+---
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: web
+spec:
+  anything: goes
+`
+
+func TestValidate(t *testing.T) {
+	v, err := New("v1.15.3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	templates := map[string]string{
+		"web/templates/pod.yaml":  pod,
+		"web/templates/NOTES.txt": "kind: Pod",
+	}
+	err = v.Validate(templates)
+	verr, ok := err.(*Errors)
+	if !ok {
+		t.Fatalf("Expected invalid manifests, got %v", err)
+	}
+	expect := []Error{
+		{Source: "web/templates/pod.yaml", Kind: "Pod", Name: "web", Errors: []string{
+			`ValidationError(Pod.spec.containers[0].ports[0].containerPort): invalid type for io.k8s.api.core.v1.ContainerPort.containerPort: got "string", expected "integer"`,
+			`ValidationError(Pod.spec): unknown field "restartpolicy" in io.k8s.api.core.v1.PodSpec`,
+		}},
+	}
+	if !reflect.DeepEqual(verr.Manifests, expect) {
+		t.Errorf("Expected %+v, got %+v", expect, verr.Manifests)
+	}
+	if !strings.HasPrefix(err.Error(), "1 manifest(s) are not valid for Kubernetes 1.15:\n  web/templates/pod.yaml (Pod/web):\n    ValidationError") {
+		t.Errorf("Unexpected error %s", err)
+	}
+
+	err = v.Validate(map[string]string{"web/templates/probe.yaml": probe})
+	if err == nil || !strings.Contains(err.Error(), `unknown field "startupProbe"`) {
+		t.Errorf("Expected startupProbe to be unknown to Kubernetes 1.15, got %v", err)
+	}
+	v, err = New("1.16")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := v.Validate(map[string]string{"web/templates/probe.yaml": probe}); err != nil {
+		t.Errorf("Expected startupProbe to be valid for Kubernetes 1.16, got %v", err)
+	}
+}
+
+func TestNew(t *testing.T) {
+	if _, err := New("1.9"); err == nil || !strings.Contains(err.Error(), "no schema is bundled for Kubernetes 1.9, the bundled schemas are for 1.13, 1.14, 1.15, 1.16") {
+		t.Errorf("Expected no bundled schema, got %v", err)
+	}
+	if _, err := New("latest"); err == nil {
+		t.Error("Expected an invalid version to fail")
+	}
+}
+
+func TestLoad(t *testing.T) {
+	v, err := Load("testdata/widget.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = v.Validate(map[string]string{"web/templates/widget.yaml": "apiVersion: example.com/v1\nkind: Widget\nmetadata:\n  name: web\nspec:\n  size: big\n"})
+	expect := `1 manifest(s) are not valid for the schema:
+  web/templates/widget.yaml (Widget/web):
+    ValidationError(Widget.spec.size): invalid type for com.example.v1.WidgetSpec.size: got "string", expected "integer"`
+	if err == nil || err.Error() != expect {
+		t.Errorf("Expected\n%s\ngot\n%v", expect, err)
+	}
+}
@@ -43,14 +43,22 @@ const ConfigMapsDriverName = "ConfigMap"
 type ConfigMaps struct {
 	impl corev1.ConfigMapInterface
 	Log  func(string, ...interface{})
+
+	// PageSize is the maximum number of objects fetched per List call.
+	// A value of 0 or less disables pagination.
+	PageSize int64
+
+	// Encryption encrypts the stored releases if it is not nil.
+	Encryption *Envelope
 }
 
 // NewConfigMaps initializes a new ConfigMaps wrapping an implementation of
 // the kubernetes ConfigMapsInterface.
 func NewConfigMaps(impl corev1.ConfigMapInterface) *ConfigMaps {
 	return &ConfigMaps{
-		impl: impl,
-		Log:  func(_ string, _ ...interface{}) {},
+		impl:     impl,
+		Log:      func(_ string, _ ...interface{}) {},
+		PageSize: DefaultListPageSize,
 	}
 }
 
@@ -59,6 +67,12 @@ func (cfgmaps *ConfigMaps) Name() string {
 	return ConfigMapsDriverName
 }
 
+// Size returns the size of the encoded release stored under key.
+func (cfgmaps *ConfigMaps) Size(key string, rls *rspb.Release) (int, error) {
+	s, err := encodeRelease(rls, cfgmaps.Encryption, key)
+	return len(s), err
+}
+
 // Get fetches the release named by key. The corresponding release is returned
 // or error if not found.
 func (cfgmaps *ConfigMaps) Get(key string) (*rspb.Release, error) {
@@ -73,7 +87,7 @@ func (cfgmaps *ConfigMaps) Get(key string) (*rspb.Release, error) {
 		return nil, err
 	}
 	// found the configmap, decode the base64 data string
-	r, err := decodeRelease(obj.Data["release"])
+	r, err := decodeRelease(obj.Data["release"], cfgmaps.Encryption, key)
 	if err != nil {
 		cfgmaps.Log("get: failed to decode data %q: %s", key, err)
 		return nil, err
@@ -89,7 +103,7 @@ func (cfgmaps *ConfigMaps) List(filter func(*rspb.Release) bool) ([]*rspb.Releas
 	lsel := kblabels.Set{"OWNER": "TILLER"}.AsSelector()
 	opts := metav1.ListOptions{LabelSelector: lsel.String()}
 
-	list, err := cfgmaps.impl.List(opts)
+	list, err := cfgmaps.list(opts)
 	if err != nil {
 		cfgmaps.Log("list: failed to list: %s", err)
 		return nil, err
@@ -99,8 +113,8 @@ func (cfgmaps *ConfigMaps) List(filter func(*rspb.Release) bool) ([]*rspb.Releas
 
 	// iterate over the configmaps object list
 	// and decode each release
-	for _, item := range list.Items {
-		rls, err := decodeRelease(item.Data["release"])
+	for _, item := range list {
+		rls, err := decodeRelease(item.Data["release"], cfgmaps.Encryption, item.Name)
 		if err != nil {
 			cfgmaps.Log("list: failed to decode release: %v: %s", item, err)
 			continue
@@ -125,19 +139,19 @@ func (cfgmaps *ConfigMaps) Query(labels map[string]string) ([]*rspb.Release, err
 
 	opts := metav1.ListOptions{LabelSelector: ls.AsSelector().String()}
 
-	list, err := cfgmaps.impl.List(opts)
+	list, err := cfgmaps.list(opts)
 	if err != nil {
 		cfgmaps.Log("query: failed to query with labels: %s", err)
 		return nil, err
 	}
 
-	if len(list.Items) == 0 {
+	if len(list) == 0 {
 		return nil, storageerrors.ErrReleaseNotFound(labels["NAME"])
 	}
 
 	var results []*rspb.Release
-	for _, item := range list.Items {
-		rls, err := decodeRelease(item.Data["release"])
+	for _, item := range list {
+		rls, err := decodeRelease(item.Data["release"], cfgmaps.Encryption, item.Name)
 		if err != nil {
 			cfgmaps.Log("query: failed to decode release: %s", err)
 			continue
@@ -147,6 +161,28 @@ func (cfgmaps *ConfigMaps) Query(labels map[string]string) ([]*rspb.Release, err
 	return results, nil
 }
 
+// list fetches every configmap matching opts, following the continue token
+// returned by the API server until all pages have been read.
+func (cfgmaps *ConfigMaps) list(opts metav1.ListOptions) ([]v1.ConfigMap, error) {
+	opts.Limit = cfgmaps.PageSize
+	if opts.Limit < 0 {
+		opts.Limit = 0
+	}
+
+	var items []v1.ConfigMap
+	for {
+		list, err := cfgmaps.impl.List(opts)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, list.Items...)
+		if list.Continue == "" || opts.Limit == 0 {
+			return items, nil
+		}
+		opts.Continue = list.Continue
+	}
+}
+
 // Create creates a new ConfigMap holding the release. If the
 // ConfigMap already exists, ErrReleaseExists is returned.
 func (cfgmaps *ConfigMaps) Create(key string, rls *rspb.Release) error {
@@ -157,7 +193,7 @@ func (cfgmaps *ConfigMaps) Create(key string, rls *rspb.Release) error {
 	lbs.set("CREATED_AT", strconv.Itoa(int(time.Now().Unix())))
 
 	// create a new configmap to hold the release
-	obj, err := newConfigMapsObject(key, rls, lbs)
+	obj, err := newConfigMapsObject(key, rls, lbs, cfgmaps.Encryption)
 	if err != nil {
 		cfgmaps.Log("create: failed to encode release %q: %s", rls.Name, err)
 		return err
@@ -184,7 +220,7 @@ func (cfgmaps *ConfigMaps) Update(key string, rls *rspb.Release) error {
 	lbs.set("MODIFIED_AT", strconv.Itoa(int(time.Now().Unix())))
 
 	// create a new configmap object to hold the release
-	obj, err := newConfigMapsObject(key, rls, lbs)
+	obj, err := newConfigMapsObject(key, rls, lbs, cfgmaps.Encryption)
 	if err != nil {
 		cfgmaps.Log("update: failed to encode release %q: %s", rls.Name, err)
 		return err
@@ -229,11 +265,11 @@ func (cfgmaps *ConfigMaps) Delete(key string) (rls *rspb.Release, err error) {
 //    "OWNER"          - owner of the configmap, currently "TILLER".
 //    "NAME"           - name of the release.
 //
-func newConfigMapsObject(key string, rls *rspb.Release, lbs labels) (*v1.ConfigMap, error) {
+func newConfigMapsObject(key string, rls *rspb.Release, lbs labels, enc *Envelope) (*v1.ConfigMap, error) {
 	const owner = "TILLER"
 
 	// encode the release
-	s, err := encodeRelease(rls)
+	s, err := encodeRelease(rls, enc, key)
 	if err != nil {
 		return nil, err
 	}
@@ -58,7 +58,7 @@ func TestUNcompressedConfigMapGet(t *testing.T) {
 	rel := releaseStub(name, vers, namespace, rspb.Status_DEPLOYED)
 
 	// Create a test fixture which contains an uncompressed release
-	cfgmap, err := newConfigMapsObject(key, rel, nil)
+	cfgmap, err := newConfigMapsObject(key, rel, nil, nil)
 	if err != nil {
 		t.Fatalf("Failed to create configmap: %s", err)
 	}
@@ -129,6 +129,34 @@ func TestConfigMapList(t *testing.T) {
 	}
 }
 
+func TestConfigMapListPaginated(t *testing.T) {
+	var rels []*rspb.Release
+	for i := int32(1); i <= 7; i++ {
+		rels = append(rels, releaseStub("key", i, "default", rspb.Status_SUPERSEDED))
+	}
+	cfgmaps := newTestFixtureCfgMaps(t, rels...)
+	cfgmaps.PageSize = 3
+
+	all, err := cfgmaps.List(func(*rspb.Release) bool { return true })
+	if err != nil {
+		t.Fatalf("Failed to list: %s", err)
+	}
+	if len(all) != 7 {
+		t.Errorf("Expected 7 releases, got %d", len(all))
+	}
+	if calls := cfgmaps.impl.(*MockConfigMapsInterface).listCalls; calls != 3 {
+		t.Errorf("Expected 3 paged list calls, got %d", calls)
+	}
+
+	res, err := cfgmaps.Query(map[string]string{"NAME": "key", "OWNER": "TILLER"})
+	if err != nil {
+		t.Fatalf("Failed to query: %s", err)
+	}
+	if len(res) != 7 {
+		t.Errorf("Expected 7 releases, got %d", len(res))
+	}
+}
+
 func TestConfigMapCreate(t *testing.T) {
 	cfgmaps := newTestFixtureCfgMaps(t)
 
@@ -155,6 +183,24 @@ func TestConfigMapCreate(t *testing.T) {
 	}
 }
 
+func TestConfigMapSize(t *testing.T) {
+	cfgmaps := newTestFixtureCfgMaps(t)
+
+	key := testKey("smug-pigeon", 1)
+	rel := releaseStub("smug-pigeon", 1, "default", rspb.Status_DEPLOYED)
+	if err := cfgmaps.Create(key, rel); err != nil {
+		t.Fatalf("Failed to create release with key %q: %s", key, err)
+	}
+
+	size, err := cfgmaps.Size(key, rel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored := len(cfgmaps.impl.(*MockConfigMapsInterface).objects[key].Data["release"]); size != stored {
+		t.Errorf("Expected the size of the stored release %d, got %d", stored, size)
+	}
+}
+
 func TestConfigMapUpdate(t *testing.T) {
 	vers := int32(1)
 	name := "smug-pigeon"
@@ -21,6 +21,11 @@ import (
 	storageerrors "k8s.io/helm/pkg/storage/errors"
 )
 
+// DefaultListPageSize is the number of objects requested per call when the
+// Kubernetes backed drivers list releases. Listing in pages keeps the
+// response size bounded for namespaces holding thousands of revisions.
+const DefaultListPageSize int64 = 500
+
 var (
 	// ErrReleaseNotFound has been deprecated; please use storageerrors.ErrReleaseNotFound instead.
 	ErrReleaseNotFound = storageerrors.ErrReleaseNotFound
@@ -46,6 +51,14 @@ type Updator interface {
 	Update(key string, rls *rspb.Release) error
 }
 
+// Sizer is the interface that wraps the Size method.
+//
+// Size returns the size of the record storing the release under key, as
+// encoded by the driver.
+type Sizer interface {
+	Size(key string, rls *rspb.Release) (int, error)
+}
+
 // Deletor is the interface that wraps the Delete method.
 //
 // Delete deletes the release named by key or returns
@@ -0,0 +1,223 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package driver // import "k8s.io/helm/pkg/storage/driver"
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+)
+
+// magicEnvelope starts the releases encrypted by an Envelope.
+var magicEnvelope = []byte("helm-envelope:v1\n")
+
+// dataKeySize is the size of the AES-256 keys.
+const dataKeySize = 32
+
+// errNoEncryptionKey is returned when an encrypted release is read by a
+// driver without an Envelope.
+var errNoEncryptionKey = errors.New("the release is encrypted, but no storage encryption key is configured")
+
+// KeyWrapper encrypts the data keys of an Envelope with a key encryption key.
+type KeyWrapper interface {
+	// WrapKey returns the data key encrypted.
+	WrapKey(key []byte) ([]byte, error)
+	// UnwrapKey returns the data key decrypted from wrapped.
+	UnwrapKey(wrapped []byte) ([]byte, error)
+}
+
+// Envelope encrypts the releases stored by a driver with envelope encryption.
+// Every release is encrypted with AES-256-GCM with a new data key, which is
+// stored with the release, encrypted by the key wrapper. The storage key of
+// the release, made of its name and version, is authenticated as additional
+// data: the encrypted data of a release cannot be stored under another key.
+//
+// The releases stored before the encryption was enabled are still read, and
+// are encrypted when they are updated.
+type Envelope struct {
+	keys KeyWrapper
+}
+
+// NewEnvelope returns an Envelope encrypting its data keys with keys.
+func NewEnvelope(keys KeyWrapper) *Envelope {
+	return &Envelope{keys: keys}
+}
+
+// seal encrypts plaintext, stored under the storage key. The result is the
+// magic header, the length of the wrapped data key, the wrapped data key, the
+// nonce and the ciphertext.
+func (e *Envelope) seal(plaintext []byte, key string) ([]byte, error) {
+	dataKey := make([]byte, dataKeySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return nil, err
+	}
+	wrapped, err := e.keys.WrapKey(dataKey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot wrap the data key: %s", err)
+	}
+	ciphertext, err := sealGCM(dataKey, plaintext, []byte(key))
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.Write(magicEnvelope)
+	binary.Write(&buf, binary.BigEndian, uint32(len(wrapped)))
+	buf.Write(wrapped)
+	buf.Write(ciphertext)
+	return buf.Bytes(), nil
+}
+
+// open decrypts the data sealed by seal, stored under the storage key.
+func (e *Envelope) open(data []byte, key string) ([]byte, error) {
+	data = bytes.TrimPrefix(data, magicEnvelope)
+	if len(data) < 4 {
+		return nil, errors.New("the encrypted release is truncated")
+	}
+	n := binary.BigEndian.Uint32(data)
+	data = data[4:]
+	if uint64(len(data)) < uint64(n) {
+		return nil, errors.New("the encrypted release is truncated")
+	}
+	dataKey, err := e.keys.UnwrapKey(data[:n])
+	if err != nil {
+		return nil, fmt.Errorf("cannot unwrap the data key: %s", err)
+	}
+	plaintext, err := openGCM(dataKey, data[n:], []byte(key))
+	if err != nil {
+		return nil, fmt.Errorf("cannot decrypt the release stored under %q: %s", key, err)
+	}
+	return plaintext, nil
+}
+
+// isEncrypted returns whether the release data was sealed by an Envelope.
+func isEncrypted(data []byte) bool {
+	return bytes.HasPrefix(data, magicEnvelope)
+}
+
+// sealGCM encrypts plaintext with AES-GCM and the key, authenticating the
+// additional data, and returns the nonce followed by the ciphertext.
+func sealGCM(key, plaintext, additionalData []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+// openGCM decrypts the data encrypted by sealGCM with the same additional
+// data.
+func openGCM(key, data, additionalData []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < aead.NonceSize() {
+		return nil, errors.New("the ciphertext is truncated")
+	}
+	return aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], additionalData)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// LocalKey is a KeyWrapper encrypting the data keys with AES-256-GCM and a
+// key encryption key held by Tiller, e.g. read from an environment variable.
+type LocalKey struct {
+	key []byte
+}
+
+var _ KeyWrapper = (*LocalKey)(nil)
+
+// NewLocalKey returns the LocalKey of the 32 bytes key encryption key.
+func NewLocalKey(key []byte) (*LocalKey, error) {
+	if len(key) != dataKeySize {
+		return nil, fmt.Errorf("the storage encryption key must be %d bytes long, not %d", dataKeySize, len(key))
+	}
+	return &LocalKey{key: key}, nil
+}
+
+// WrapKey encrypts the data key with the key encryption key.
+func (k *LocalKey) WrapKey(key []byte) ([]byte, error) {
+	return sealGCM(k.key, key, nil)
+}
+
+// UnwrapKey decrypts the data key with the key encryption key.
+func (k *LocalKey) UnwrapKey(wrapped []byte) ([]byte, error) {
+	key, err := openGCM(k.key, wrapped, nil)
+	if err != nil {
+		return nil, errors.New("the release was encrypted with another key")
+	}
+	return key, nil
+}
+
+// KMSPlugin is a KeyWrapper delegating the encryption of the data keys to an
+// external program, typically a client of a key management service. The
+// program is run as "<program> wrap" and "<program> unwrap", with the key
+// encoded in base64 on its standard input, and prints the result encoded in
+// base64 on its standard output.
+type KMSPlugin struct {
+	// Program is the name or path of the plugin executable.
+	Program string
+}
+
+var _ KeyWrapper = (*KMSPlugin)(nil)
+
+// WrapKey encrypts the data key with the plugin.
+func (p *KMSPlugin) WrapKey(key []byte) ([]byte, error) {
+	return p.run("wrap", key)
+}
+
+// UnwrapKey decrypts the data key with the plugin.
+func (p *KMSPlugin) UnwrapKey(wrapped []byte) ([]byte, error) {
+	return p.run("unwrap", wrapped)
+}
+
+func (p *KMSPlugin) run(action string, in []byte) ([]byte, error) {
+	cmd := exec.Command(p.Program, action)
+	cmd.Stdin = strings.NewReader(base64.StdEncoding.EncodeToString(in))
+	out, err := cmd.Output()
+	if err != nil {
+		msg := err.Error()
+		if ee, ok := err.(*exec.ExitError); ok && len(ee.Stderr) > 0 {
+			msg = strings.TrimSpace(string(ee.Stderr))
+		}
+		return nil, fmt.Errorf("%s %s: %s", p.Program, action, msg)
+	}
+	b, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(out)))
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode the output of %s %s: %s", p.Program, action, err)
+	}
+	return b, nil
+}
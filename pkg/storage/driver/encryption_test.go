@@ -0,0 +1,169 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package driver
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"k8s.io/api/core/v1"
+
+	rspb "k8s.io/helm/pkg/proto/hapi/release"
+)
+
+func testEnvelope(t *testing.T, key string) *Envelope {
+	k, err := NewLocalKey([]byte(key))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewEnvelope(k)
+}
+
+func TestEnvelopeEncodeRelease(t *testing.T) {
+	rel := releaseStub("smug-pigeon", 1, "default", rspb.Status_DEPLOYED)
+	key := testKey(rel.Name, rel.Version)
+	enc := testEnvelope(t, "0123456789abcdef0123456789abcdef")
+
+	data, err := encodeRelease(rel, enc, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := base64.StdEncoding.DecodeString(data); !bytes.HasPrefix(b, magicEnvelope) {
+		t.Fatalf("Expected the release to be encrypted")
+	}
+	got, err := decodeRelease(data, enc, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !shallowReleaseEqual(rel, got) {
+		t.Errorf("Expected {%q}, got {%q}", rel, got)
+	}
+
+	if _, err := decodeRelease(data, nil, key); err != errNoEncryptionKey {
+		t.Errorf("Expected %q, got %v", errNoEncryptionKey, err)
+	}
+	other := testEnvelope(t, "fedcba9876543210fedcba9876543210")
+	if _, err := decodeRelease(data, other, key); err == nil || !strings.Contains(err.Error(), "encrypted with another key") {
+		t.Errorf("Expected the decryption with another key to fail, got %v", err)
+	}
+
+	// The encrypted release cannot be read under the key of another release.
+	if _, err := decodeRelease(data, enc, testKey(rel.Name, 2)); err == nil || !strings.Contains(err.Error(), "cannot decrypt the release") {
+		t.Errorf("Expected the decryption under another key to fail, got %v", err)
+	}
+
+	// The releases stored before the encryption was enabled are still read.
+	plain, err := encodeRelease(rel, nil, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := decodeRelease(plain, enc, key); err != nil || !shallowReleaseEqual(rel, got) {
+		t.Errorf("Expected the plaintext release to be decoded, got {%q}, %v", got, err)
+	}
+}
+
+func TestNewLocalKey(t *testing.T) {
+	if _, err := NewLocalKey([]byte("too short")); err == nil {
+		t.Errorf("Expected a key of the wrong size to be rejected")
+	}
+}
+
+func TestKMSPlugin(t *testing.T) {
+	enc := NewEnvelope(&KMSPlugin{Program: "testdata/kms-plugin-test"})
+	rel := releaseStub("smug-pigeon", 1, "default", rspb.Status_DEPLOYED)
+	key := testKey(rel.Name, rel.Version)
+
+	data, err := encodeRelease(rel, enc, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := decodeRelease(data, enc, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !shallowReleaseEqual(rel, got) {
+		t.Errorf("Expected {%q}, got {%q}", rel, got)
+	}
+
+	missing := NewEnvelope(&KMSPlugin{Program: "testdata/no-such-plugin"})
+	if _, err := encodeRelease(rel, missing, key); err == nil || !strings.Contains(err.Error(), "cannot wrap the data key") {
+		t.Errorf("Expected the missing plugin to fail, got %v", err)
+	}
+}
+
+func TestConfigMapsEncryption(t *testing.T) {
+	vers := int32(1)
+	name := "smug-pigeon"
+	key := testKey(name, vers)
+	rel := releaseStub(name, vers, "default", rspb.Status_DEPLOYED)
+
+	var mock MockConfigMapsInterface
+	mock.objects = map[string]*v1.ConfigMap{}
+	cfgmaps := NewConfigMaps(&mock)
+	cfgmaps.Encryption = testEnvelope(t, "0123456789abcdef0123456789abcdef")
+
+	if err := cfgmaps.Create(key, rel); err != nil {
+		t.Fatalf("Failed to create release: %s", err)
+	}
+	if b, _ := base64.StdEncoding.DecodeString(mock.objects[key].Data["release"]); !bytes.HasPrefix(b, magicEnvelope) {
+		t.Errorf("Expected the stored release to be encrypted")
+	}
+	got, err := cfgmaps.Get(key)
+	if err != nil {
+		t.Fatalf("Failed to get release: %s", err)
+	}
+	if !shallowReleaseEqual(rel, got) {
+		t.Errorf("Expected {%q}, got {%q}", rel, got)
+	}
+}
+
+func TestSecretsEncryptionSwapped(t *testing.T) {
+	rel1 := releaseStub("smug-pigeon", 1, "default", rspb.Status_SUPERSEDED)
+	rel2 := releaseStub("smug-pigeon", 2, "default", rspb.Status_DEPLOYED)
+	key1, key2 := testKey(rel1.Name, rel1.Version), testKey(rel2.Name, rel2.Version)
+
+	var mock MockSecretsInterface
+	mock.objects = map[string]*v1.Secret{}
+	secrets := NewSecrets(&mock)
+	secrets.Encryption = testEnvelope(t, "0123456789abcdef0123456789abcdef")
+
+	if err := secrets.Create(key1, rel1); err != nil {
+		t.Fatalf("Failed to create release: %s", err)
+	}
+	if err := secrets.Create(key2, rel2); err != nil {
+		t.Fatalf("Failed to create release: %s", err)
+	}
+
+	// Storing the encrypted data of a release under the key of another
+	// release, e.g. to roll it back, is detected.
+	data1, data2 := mock.objects[key1].Data["release"], mock.objects[key2].Data["release"]
+	mock.objects[key1].Data["release"], mock.objects[key2].Data["release"] = data2, data1
+	for _, key := range []string{key1, key2} {
+		if _, err := secrets.Get(key); err == nil || !strings.Contains(err.Error(), "cannot decrypt the release stored under") {
+			t.Errorf("Expected the swapped release %s not to be decrypted, got %v", key, err)
+		}
+	}
+	rels, err := secrets.List(func(*rspb.Release) bool { return true })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rels) != 0 {
+		t.Errorf("Expected the swapped releases not to be listed, got %v", rels)
+	}
+}
@@ -18,6 +18,8 @@ package driver // import "k8s.io/helm/pkg/storage/driver"
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
 	"testing"
 
 	sqlmock "github.com/DATA-DOG/go-sqlmock"
@@ -92,6 +94,8 @@ type MockConfigMapsInterface struct {
 	corev1.ConfigMapInterface
 
 	objects map[string]*v1.ConfigMap
+	// listCalls counts the requests made to List.
+	listCalls int
 }
 
 // Init initializes the MockConfigMapsInterface with the set of releases.
@@ -101,7 +105,7 @@ func (mock *MockConfigMapsInterface) Init(t *testing.T, releases ...*rspb.Releas
 	for _, rls := range releases {
 		objkey := testKey(rls.Name, rls.Version)
 
-		cfgmap, err := newConfigMapsObject(objkey, rls, nil)
+		cfgmap, err := newConfigMapsObject(objkey, rls, nil, nil)
 		if err != nil {
 			t.Fatalf("Failed to create configmap: %s", err)
 		}
@@ -120,10 +124,22 @@ func (mock *MockConfigMapsInterface) Get(name string, options metav1.GetOptions)
 
 // List returns the a of ConfigMaps.
 func (mock *MockConfigMapsInterface) List(opts metav1.ListOptions) (*v1.ConfigMapList, error) {
+	mock.listCalls++
+
+	keys := make([]string, 0, len(mock.objects))
+	for k := range mock.objects {
+		keys = append(keys, k)
+	}
+	page, next, err := mockPage(keys, opts)
+	if err != nil {
+		return nil, err
+	}
+
 	var list v1.ConfigMapList
-	for _, cfgmap := range mock.objects {
-		list.Items = append(list.Items, *cfgmap)
+	for _, k := range page {
+		list.Items = append(list.Items, *mock.objects[k])
 	}
+	list.Continue = next
 	return &list, nil
 }
 
@@ -170,6 +186,8 @@ type MockSecretsInterface struct {
 	corev1.SecretInterface
 
 	objects map[string]*v1.Secret
+	// listCalls counts the requests made to List.
+	listCalls int
 }
 
 // Init initializes the MockSecretsInterface with the set of releases.
@@ -179,7 +197,7 @@ func (mock *MockSecretsInterface) Init(t *testing.T, releases ...*rspb.Release)
 	for _, rls := range releases {
 		objkey := testKey(rls.Name, rls.Version)
 
-		secret, err := newSecretsObject(objkey, rls, nil)
+		secret, err := newSecretsObject(objkey, rls, nil, nil)
 		if err != nil {
 			t.Fatalf("Failed to create secret: %s", err)
 		}
@@ -198,13 +216,45 @@ func (mock *MockSecretsInterface) Get(name string, options metav1.GetOptions) (*
 
 // List returns the a of Secret.
 func (mock *MockSecretsInterface) List(opts metav1.ListOptions) (*v1.SecretList, error) {
+	mock.listCalls++
+
+	keys := make([]string, 0, len(mock.objects))
+	for k := range mock.objects {
+		keys = append(keys, k)
+	}
+	page, next, err := mockPage(keys, opts)
+	if err != nil {
+		return nil, err
+	}
+
 	var list v1.SecretList
-	for _, secret := range mock.objects {
-		list.Items = append(list.Items, *secret)
+	for _, k := range page {
+		list.Items = append(list.Items, *mock.objects[k])
 	}
+	list.Continue = next
 	return &list, nil
 }
 
+// mockPage returns the page of sorted keys selected by the Limit and
+// Continue list options along with the continue token of the next page.
+func mockPage(keys []string, opts metav1.ListOptions) ([]string, string, error) {
+	sort.Strings(keys)
+
+	start := 0
+	if opts.Continue != "" {
+		n, err := strconv.Atoi(opts.Continue)
+		if err != nil || n > len(keys) {
+			return nil, "", fmt.Errorf("invalid continue token %q", opts.Continue)
+		}
+		start = n
+	}
+	if opts.Limit <= 0 || start+int(opts.Limit) >= len(keys) {
+		return keys[start:], "", nil
+	}
+	end := start + int(opts.Limit)
+	return keys[start:end], strconv.Itoa(end), nil
+}
+
 // Create creates a new Secret.
 func (mock *MockSecretsInterface) Create(secret *v1.Secret) (*v1.Secret, error) {
 	name := secret.ObjectMeta.Name
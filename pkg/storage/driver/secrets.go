@@ -43,14 +43,22 @@ const SecretsDriverName = "Secret"
 type Secrets struct {
 	impl corev1.SecretInterface
 	Log  func(string, ...interface{})
+
+	// PageSize is the maximum number of objects fetched per List call.
+	// A value of 0 or less disables pagination.
+	PageSize int64
+
+	// Encryption encrypts the stored releases if it is not nil.
+	Encryption *Envelope
 }
 
 // NewSecrets initializes a new Secrets wrapping an implementation of
 // the kubernetes SecretsInterface.
 func NewSecrets(impl corev1.SecretInterface) *Secrets {
 	return &Secrets{
-		impl: impl,
-		Log:  func(_ string, _ ...interface{}) {},
+		impl:     impl,
+		Log:      func(_ string, _ ...interface{}) {},
+		PageSize: DefaultListPageSize,
 	}
 }
 
@@ -59,6 +67,12 @@ func (secrets *Secrets) Name() string {
 	return SecretsDriverName
 }
 
+// Size returns the size of the encoded release stored under key.
+func (secrets *Secrets) Size(key string, rls *rspb.Release) (int, error) {
+	s, err := encodeRelease(rls, secrets.Encryption, key)
+	return len(s), err
+}
+
 // Get fetches the release named by key. The corresponding release is returned
 // or error if not found.
 func (secrets *Secrets) Get(key string) (*rspb.Release, error) {
@@ -73,7 +87,7 @@ func (secrets *Secrets) Get(key string) (*rspb.Release, error) {
 		return nil, err
 	}
 	// found the secret, decode the base64 data string
-	r, err := decodeRelease(string(obj.Data["release"]))
+	r, err := decodeRelease(string(obj.Data["release"]), secrets.Encryption, key)
 	if err != nil {
 		secrets.Log("get: failed to decode data %q: %s", key, err)
 		return nil, err
@@ -89,7 +103,7 @@ func (secrets *Secrets) List(filter func(*rspb.Release) bool) ([]*rspb.Release,
 	lsel := kblabels.Set{"OWNER": "TILLER"}.AsSelector()
 	opts := metav1.ListOptions{LabelSelector: lsel.String()}
 
-	list, err := secrets.impl.List(opts)
+	list, err := secrets.list(opts)
 	if err != nil {
 		secrets.Log("list: failed to list: %s", err)
 		return nil, err
@@ -99,8 +113,8 @@ func (secrets *Secrets) List(filter func(*rspb.Release) bool) ([]*rspb.Release,
 
 	// iterate over the secrets object list
 	// and decode each release
-	for _, item := range list.Items {
-		rls, err := decodeRelease(string(item.Data["release"]))
+	for _, item := range list {
+		rls, err := decodeRelease(string(item.Data["release"]), secrets.Encryption, item.Name)
 		if err != nil {
 			secrets.Log("list: failed to decode release: %v: %s", item, err)
 			continue
@@ -125,19 +139,19 @@ func (secrets *Secrets) Query(labels map[string]string) ([]*rspb.Release, error)
 
 	opts := metav1.ListOptions{LabelSelector: ls.AsSelector().String()}
 
-	list, err := secrets.impl.List(opts)
+	list, err := secrets.list(opts)
 	if err != nil {
 		secrets.Log("query: failed to query with labels: %s", err)
 		return nil, err
 	}
 
-	if len(list.Items) == 0 {
+	if len(list) == 0 {
 		return nil, storageerrors.ErrReleaseNotFound(labels["NAME"])
 	}
 
 	var results []*rspb.Release
-	for _, item := range list.Items {
-		rls, err := decodeRelease(string(item.Data["release"]))
+	for _, item := range list {
+		rls, err := decodeRelease(string(item.Data["release"]), secrets.Encryption, item.Name)
 		if err != nil {
 			secrets.Log("query: failed to decode release: %s", err)
 			continue
@@ -147,6 +161,28 @@ func (secrets *Secrets) Query(labels map[string]string) ([]*rspb.Release, error)
 	return results, nil
 }
 
+// list fetches every secret matching opts, following the continue token
+// returned by the API server until all pages have been read.
+func (secrets *Secrets) list(opts metav1.ListOptions) ([]v1.Secret, error) {
+	opts.Limit = secrets.PageSize
+	if opts.Limit < 0 {
+		opts.Limit = 0
+	}
+
+	var items []v1.Secret
+	for {
+		list, err := secrets.impl.List(opts)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, list.Items...)
+		if list.Continue == "" || opts.Limit == 0 {
+			return items, nil
+		}
+		opts.Continue = list.Continue
+	}
+}
+
 // Create creates a new Secret holding the release. If the
 // Secret already exists, ErrReleaseExists is returned.
 func (secrets *Secrets) Create(key string, rls *rspb.Release) error {
@@ -157,7 +193,7 @@ func (secrets *Secrets) Create(key string, rls *rspb.Release) error {
 	lbs.set("CREATED_AT", strconv.Itoa(int(time.Now().Unix())))
 
 	// create a new secret to hold the release
-	obj, err := newSecretsObject(key, rls, lbs)
+	obj, err := newSecretsObject(key, rls, lbs, secrets.Encryption)
 	if err != nil {
 		secrets.Log("create: failed to encode release %q: %s", rls.Name, err)
 		return err
@@ -184,7 +220,7 @@ func (secrets *Secrets) Update(key string, rls *rspb.Release) error {
 	lbs.set("MODIFIED_AT", strconv.Itoa(int(time.Now().Unix())))
 
 	// create a new secret object to hold the release
-	obj, err := newSecretsObject(key, rls, lbs)
+	obj, err := newSecretsObject(key, rls, lbs, secrets.Encryption)
 	if err != nil {
 		secrets.Log("update: failed to encode release %q: %s", rls.Name, err)
 		return err
@@ -229,11 +265,11 @@ func (secrets *Secrets) Delete(key string) (rls *rspb.Release, err error) {
 //    "OWNER"          - owner of the secret, currently "TILLER".
 //    "NAME"           - name of the release.
 //
-func newSecretsObject(key string, rls *rspb.Release, lbs labels) (*v1.Secret, error) {
+func newSecretsObject(key string, rls *rspb.Release, lbs labels, enc *Envelope) (*v1.Secret, error) {
 	const owner = "TILLER"
 
 	// encode the release
-	s, err := encodeRelease(rls)
+	s, err := encodeRelease(rls, enc, key)
 	if err != nil {
 		return nil, err
 	}
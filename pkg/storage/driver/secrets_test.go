@@ -58,7 +58,7 @@ func TestUNcompressedSecretGet(t *testing.T) {
 	rel := releaseStub(name, vers, namespace, rspb.Status_DEPLOYED)
 
 	// Create a test fixture which contains an uncompressed release
-	secret, err := newSecretsObject(key, rel, nil)
+	secret, err := newSecretsObject(key, rel, nil, nil)
 	if err != nil {
 		t.Fatalf("Failed to create secret: %s", err)
 	}
@@ -129,6 +129,34 @@ func TestSecretList(t *testing.T) {
 	}
 }
 
+func TestSecretListPaginated(t *testing.T) {
+	var rels []*rspb.Release
+	for i := int32(1); i <= 7; i++ {
+		rels = append(rels, releaseStub("key", i, "default", rspb.Status_SUPERSEDED))
+	}
+	secrets := newTestFixtureSecrets(t, rels...)
+	secrets.PageSize = 3
+
+	all, err := secrets.List(func(*rspb.Release) bool { return true })
+	if err != nil {
+		t.Fatalf("Failed to list: %s", err)
+	}
+	if len(all) != 7 {
+		t.Errorf("Expected 7 releases, got %d", len(all))
+	}
+	if calls := secrets.impl.(*MockSecretsInterface).listCalls; calls != 3 {
+		t.Errorf("Expected 3 paged list calls, got %d", calls)
+	}
+
+	res, err := secrets.Query(map[string]string{"NAME": "key", "OWNER": "TILLER"})
+	if err != nil {
+		t.Fatalf("Failed to query: %s", err)
+	}
+	if len(res) != 7 {
+		t.Errorf("Expected 7 releases, got %d", len(res))
+	}
+}
+
 func TestSecretCreate(t *testing.T) {
 	secrets := newTestFixtureSecrets(t)
 
@@ -54,6 +54,9 @@ const SQLDriverName = "SQL"
 type SQL struct {
 	db  *sqlx.DB
 	Log func(string, ...interface{})
+
+	// Encryption encrypts the stored releases if it is not nil.
+	Encryption *Envelope
 }
 
 // Name returns the name of the driver.
@@ -61,6 +64,12 @@ func (s *SQL) Name() string {
 	return SQLDriverName
 }
 
+// Size returns the size of the encoded release stored under key.
+func (s *SQL) Size(key string, rls *rspb.Release) (int, error) {
+	body, err := encodeRelease(rls, s.Encryption, key)
+	return len(body), err
+}
+
 func (s *SQL) ensureDBSetup() error {
 	// Populate the database with the relations we need if they don't exist yet
 	migrations := &migrate.MemoryMigrationSource{
@@ -154,7 +163,7 @@ func (s *SQL) Get(key string) (*rspb.Release, error) {
 		return nil, storageerrors.ErrReleaseNotFound(key)
 	}
 
-	release, err := decodeRelease(record.Body)
+	release, err := decodeRelease(record.Body, s.Encryption, key)
 	if err != nil {
 		s.Log("get: failed to decode data %q: %v", key, err)
 		return nil, err
@@ -166,14 +175,14 @@ func (s *SQL) Get(key string) (*rspb.Release, error) {
 // List returns the list of all releases such that filter(release) == true
 func (s *SQL) List(filter func(*rspb.Release) bool) ([]*rspb.Release, error) {
 	var records = []SQLReleaseWrapper{}
-	if err := s.db.Select(&records, "SELECT body FROM releases WHERE owner = 'TILLER'"); err != nil {
+	if err := s.db.Select(&records, "SELECT key, body FROM releases WHERE owner = 'TILLER'"); err != nil {
 		s.Log("list: failed to list: %v", err)
 		return nil, err
 	}
 
 	var releases []*rspb.Release
 	for _, record := range records {
-		release, err := decodeRelease(record.Body)
+		release, err := decodeRelease(record.Body, s.Encryption, record.Key)
 		if err != nil {
 			s.Log("list: failed to decode release: %v: %v", record, err)
 			continue
@@ -206,7 +215,7 @@ func (s *SQL) Query(labels map[string]string) ([]*rspb.Release, error) {
 
 	// Build our query
 	query := strings.Join([]string{
-		"SELECT body FROM releases",
+		"SELECT key, body FROM releases",
 		"WHERE",
 		strings.Join(sqlFilterKeys, " AND "),
 	}, " ")
@@ -225,7 +234,7 @@ func (s *SQL) Query(labels map[string]string) ([]*rspb.Release, error) {
 			return nil, err
 		}
 
-		release, err := decodeRelease(record.Body)
+		release, err := decodeRelease(record.Body, s.Encryption, record.Key)
 		if err != nil {
 			s.Log("failed to decode release: %v", err)
 			continue
@@ -242,7 +251,7 @@ func (s *SQL) Query(labels map[string]string) ([]*rspb.Release, error) {
 
 // Create creates a new release.
 func (s *SQL) Create(key string, rls *rspb.Release) error {
-	body, err := encodeRelease(rls)
+	body, err := encodeRelease(rls, s.Encryption, key)
 	if err != nil {
 		s.Log("failed to encode release: %v", err)
 		return err
@@ -283,7 +292,7 @@ func (s *SQL) Create(key string, rls *rspb.Release) error {
 
 // Update updates a release.
 func (s *SQL) Update(key string, rls *rspb.Release) error {
-	body, err := encodeRelease(rls)
+	body, err := encodeRelease(rls, s.Encryption, key)
 	if err != nil {
 		s.Log("failed to encode release: %v", err)
 		return err
@@ -323,7 +332,7 @@ func (s *SQL) Delete(key string) (*rspb.Release, error) {
 		return nil, storageerrors.ErrReleaseNotFound(key)
 	}
 
-	release, err := decodeRelease(record.Body)
+	release, err := decodeRelease(record.Body, s.Encryption, key)
 	if err != nil {
 		s.Log("failed to decode release %s: %v", key, err)
 		transaction.Rollback()
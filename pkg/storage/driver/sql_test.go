@@ -40,7 +40,7 @@ func TestSQLGet(t *testing.T) {
 	key := testKey(name, vers)
 	rel := releaseStub(name, vers, namespace, rspb.Status_DEPLOYED)
 
-	body, err := encodeRelease(rel)
+	body, err := encodeRelease(rel, nil, key)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -72,28 +72,29 @@ func TestSQLGet(t *testing.T) {
 }
 
 func TestSQLList(t *testing.T) {
-	body1, _ := encodeRelease(releaseStub("key-1", 1, "default", rspb.Status_DELETED))
-	body2, _ := encodeRelease(releaseStub("key-2", 1, "default", rspb.Status_DELETED))
-	body3, _ := encodeRelease(releaseStub("key-3", 1, "default", rspb.Status_DEPLOYED))
-	body4, _ := encodeRelease(releaseStub("key-4", 1, "default", rspb.Status_DEPLOYED))
-	body5, _ := encodeRelease(releaseStub("key-5", 1, "default", rspb.Status_SUPERSEDED))
-	body6, _ := encodeRelease(releaseStub("key-6", 1, "default", rspb.Status_SUPERSEDED))
+	body1, _ := encodeRelease(releaseStub("key-1", 1, "default", rspb.Status_DELETED), nil, testKey("key-1", 1))
+	body2, _ := encodeRelease(releaseStub("key-2", 1, "default", rspb.Status_DELETED), nil, testKey("key-2", 1))
+	body3, _ := encodeRelease(releaseStub("key-3", 1, "default", rspb.Status_DEPLOYED), nil, testKey("key-3", 1))
+	body4, _ := encodeRelease(releaseStub("key-4", 1, "default", rspb.Status_DEPLOYED), nil, testKey("key-4", 1))
+	body5, _ := encodeRelease(releaseStub("key-5", 1, "default", rspb.Status_SUPERSEDED), nil, testKey("key-5", 1))
+	body6, _ := encodeRelease(releaseStub("key-6", 1, "default", rspb.Status_SUPERSEDED), nil, testKey("key-6", 1))
 
 	sqlDriver, mock := newTestFixtureSQL(t)
 
 	for i := 0; i < 3; i++ {
 		mock.
-			ExpectQuery("SELECT body FROM releases WHERE owner = 'TILLER'").
+			ExpectQuery("SELECT key, body FROM releases WHERE owner = 'TILLER'").
 			WillReturnRows(
 				mock.NewRows([]string{
+					"key",
 					"body",
 				}).
-					AddRow(body1).
-					AddRow(body2).
-					AddRow(body3).
-					AddRow(body4).
-					AddRow(body5).
-					AddRow(body6),
+					AddRow(testKey("key-1", 1), body1).
+					AddRow(testKey("key-2", 1), body2).
+					AddRow(testKey("key-3", 1), body3).
+					AddRow(testKey("key-4", 1), body4).
+					AddRow(testKey("key-5", 1), body5).
+					AddRow(testKey("key-6", 1), body6),
 			).RowsWillBeClosed()
 	}
 
@@ -146,7 +147,7 @@ func TestSqlCreate(t *testing.T) {
 	rel := releaseStub(name, vers, namespace, rspb.Status_DEPLOYED)
 
 	sqlDriver, mock := newTestFixtureSQL(t)
-	body, _ := encodeRelease(rel)
+	body, _ := encodeRelease(rel, nil, key)
 
 	mock.ExpectBegin()
 	mock.
@@ -172,7 +173,7 @@ func TestSqlCreateAlreadyExists(t *testing.T) {
 	rel := releaseStub(name, vers, namespace, rspb.Status_DEPLOYED)
 
 	sqlDriver, mock := newTestFixtureSQL(t)
-	body, _ := encodeRelease(rel)
+	body, _ := encodeRelease(rel, nil, key)
 
 	// Insert fails (primary key already exists)
 	mock.ExpectBegin()
@@ -211,7 +212,7 @@ func TestSqlUpdate(t *testing.T) {
 	rel := releaseStub(name, vers, namespace, rspb.Status_DEPLOYED)
 
 	sqlDriver, mock := newTestFixtureSQL(t)
-	body, _ := encodeRelease(rel)
+	body, _ := encodeRelease(rel, nil, key)
 
 	mock.
 		ExpectExec(regexp.QuoteMeta("UPDATE releases SET body=?, name=?, version=?, status=?, owner=?, modified_at=? WHERE key=?")).
@@ -240,33 +241,40 @@ func TestSqlQuery(t *testing.T) {
 	}
 
 	supersededRelease := releaseStub("smug-pigeon", 1, "default", rspb.Status_SUPERSEDED)
-	supersededReleaseBody, _ := encodeRelease(supersededRelease)
+	supersededReleaseKey := testKey(supersededRelease.Name, supersededRelease.Version)
+	supersededReleaseBody, _ := encodeRelease(supersededRelease, nil, supersededReleaseKey)
 	deployedRelease := releaseStub("smug-pigeon", 2, "default", rspb.Status_DEPLOYED)
-	deployedReleaseBody, _ := encodeRelease(deployedRelease)
+	deployedReleaseKey := testKey(deployedRelease.Name, deployedRelease.Version)
+	deployedReleaseBody, _ := encodeRelease(deployedRelease, nil, deployedReleaseKey)
 
 	// Let's actually start our test
 	sqlDriver, mock := newTestFixtureSQL(t)
 
 	mock.
-		ExpectQuery(regexp.QuoteMeta("SELECT body FROM releases WHERE name=? AND owner=? AND status=?")).
+		ExpectQuery(regexp.QuoteMeta("SELECT key, body FROM releases WHERE name=? AND owner=? AND status=?")).
 		WithArgs("smug-pigeon", "TILLER", "DEPLOYED").
 		WillReturnRows(
 			mock.NewRows([]string{
+				"key",
 				"body",
 			}).AddRow(
+				deployedReleaseKey,
 				deployedReleaseBody,
 			),
 		).RowsWillBeClosed()
 
 	mock.
-		ExpectQuery(regexp.QuoteMeta("SELECT body FROM releases WHERE name=? AND owner=?")).
+		ExpectQuery(regexp.QuoteMeta("SELECT key, body FROM releases WHERE name=? AND owner=?")).
 		WithArgs("smug-pigeon", "TILLER").
 		WillReturnRows(
 			mock.NewRows([]string{
+				"key",
 				"body",
 			}).AddRow(
+				supersededReleaseKey,
 				supersededReleaseBody,
 			).AddRow(
+				deployedReleaseKey,
 				deployedReleaseBody,
 			),
 		).RowsWillBeClosed()
@@ -309,7 +317,7 @@ func TestSqlDelete(t *testing.T) {
 	key := testKey(name, vers)
 	rel := releaseStub(name, vers, namespace, rspb.Status_DEPLOYED)
 
-	body, _ := encodeRelease(rel)
+	body, _ := encodeRelease(rel, nil, key)
 
 	sqlDriver, mock := newTestFixtureSQL(t)
 
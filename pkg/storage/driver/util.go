@@ -32,7 +32,9 @@ var magicGzip = []byte{0x1f, 0x8b, 0x08}
 
 // encodeRelease encodes a release returning a base64 encoded
 // gzipped binary protobuf encoding representation, or error.
-func encodeRelease(rls *rspb.Release) (string, error) {
+// The gzipped encoding is encrypted by enc if it is not nil,
+// for the storage key of the release.
+func encodeRelease(rls *rspb.Release, enc *Envelope, key string) (string, error) {
 	b, err := proto.Marshal(rls)
 	if err != nil {
 		return "", err
@@ -47,20 +49,36 @@ func encodeRelease(rls *rspb.Release) (string, error) {
 	}
 	w.Close()
 
-	return b64.EncodeToString(buf.Bytes()), nil
+	b = buf.Bytes()
+	if enc != nil {
+		if b, err = enc.seal(b, key); err != nil {
+			return "", err
+		}
+	}
+	return b64.EncodeToString(b), nil
 }
 
 // decodeRelease decodes the bytes in data into a release
 // type. Data must contain a base64 encoded string of a
 // valid protobuf encoding of a release, otherwise
-// an error is returned.
-func decodeRelease(data string) (*rspb.Release, error) {
+// an error is returned. An encrypted release is decrypted
+// by enc, and must be stored under the storage key.
+func decodeRelease(data string, enc *Envelope, key string) (*rspb.Release, error) {
 	// base64 decode string
 	b, err := b64.DecodeString(data)
 	if err != nil {
 		return nil, err
 	}
 
+	if isEncrypted(b) {
+		if enc == nil {
+			return nil, errNoEncryptionKey
+		}
+		if b, err = enc.open(b, key); err != nil {
+			return nil, err
+		}
+	}
+
 	// For backwards compatibility with releases that were stored before
 	// compression was introduced we skip decompression if the
 	// gzip magic header is not found
@@ -0,0 +1,211 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lock // import "k8s.io/helm/pkg/storage/lock"
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	coordv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	coordinationv1 "k8s.io/client-go/kubernetes/typed/coordination/v1"
+)
+
+// DefaultLeaseDuration is the time after which a lease that was not renewed
+// expires.
+const DefaultLeaseDuration = 60 * time.Second
+
+// MinLeaseDuration is the shortest duration of a lease, as the duration of a
+// Lease is a number of seconds.
+const MinLeaseDuration = time.Second
+
+// operationAnnotation records the operation holding a lease.
+const operationAnnotation = "helm.sh/operation"
+
+// Leases is a Locker holding the locks as Leases of the coordination.k8s.io
+// API, so that a release is protected from the operations of every Tiller
+// sharing the namespace. A lease is renewed while it is held, and expires
+// when its holder stops renewing it, so that the release of a crashed Tiller
+// does not stay locked.
+type Leases struct {
+	impl   coordinationv1.LeaseInterface
+	holder string
+	// Duration is the time after which a lease that was not renewed expires.
+	// DefaultLeaseDuration is used if it is shorter than MinLeaseDuration.
+	Duration time.Duration
+	Log      func(string, ...interface{})
+}
+
+// NewLeases initializes a new Leases Locker holding the leases under the
+// identity holder.
+func NewLeases(impl coordinationv1.LeaseInterface, holder string) *Leases {
+	return &Leases{
+		impl:     impl,
+		holder:   holder,
+		Duration: DefaultLeaseDuration,
+		Log:      func(_ string, _ ...interface{}) {},
+	}
+}
+
+// Lock locks the release for the operation.
+func (l *Leases) Lock(release, operation string) (func(), error) {
+	name := leaseName(release)
+	now := metav1.NowMicro()
+
+	lease, err := l.impl.Get(name, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		lease = &coordv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{"NAME": release, "OWNER": "TILLER"},
+			},
+		}
+		l.hold(lease, operation, now)
+		lease, err = l.impl.Create(lease)
+	case err != nil:
+		return nil, fmt.Errorf("lock: failed to get lease %q: %s", name, err)
+	default:
+		if locked := l.heldBy(lease, release, now); locked != nil {
+			return nil, locked
+		}
+		l.hold(lease, operation, now)
+		lease, err = l.impl.Update(lease)
+	}
+	if apierrors.IsAlreadyExists(err) || apierrors.IsConflict(err) {
+		// Another operation took the lease in the meantime.
+		if current, gerr := l.impl.Get(name, metav1.GetOptions{}); gerr == nil {
+			if locked := l.heldBy(current, release, metav1.NowMicro()); locked != nil {
+				return nil, locked
+			}
+		}
+		return nil, &LockedError{Release: release, Operation: "unknown", Since: now.Time}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("lock: failed to take lease %q: %s", name, err)
+	}
+
+	l.Log("locked release %q for %s", release, operation)
+	h := &heldLease{leases: l, lease: lease, stop: make(chan struct{})}
+	h.wg.Add(1)
+	go h.renew()
+	return h.unlock, nil
+}
+
+// hold makes the lease held by l for the operation.
+func (l *Leases) hold(lease *coordv1.Lease, operation string, now metav1.MicroTime) {
+	if lease.Annotations == nil {
+		lease.Annotations = map[string]string{}
+	}
+	lease.Annotations[operationAnnotation] = operation
+	duration := int32(l.duration() / time.Second)
+	lease.Spec.HolderIdentity = &l.holder
+	lease.Spec.LeaseDurationSeconds = &duration
+	lease.Spec.AcquireTime = &now
+	lease.Spec.RenewTime = &now
+}
+
+// duration returns the duration of the leases, DefaultLeaseDuration if
+// Duration is shorter than MinLeaseDuration.
+func (l *Leases) duration() time.Duration {
+	if l.Duration < MinLeaseDuration {
+		return DefaultLeaseDuration
+	}
+	return l.Duration
+}
+
+// heldBy returns the error reporting the operation holding the lease, or nil
+// if the lease is free or expired.
+func (l *Leases) heldBy(lease *coordv1.Lease, release string, now metav1.MicroTime) *LockedError {
+	spec := lease.Spec
+	if spec.HolderIdentity == nil || *spec.HolderIdentity == "" {
+		return nil
+	}
+	if spec.RenewTime != nil && spec.LeaseDurationSeconds != nil {
+		expiry := spec.RenewTime.Add(time.Duration(*spec.LeaseDurationSeconds) * time.Second)
+		if now.After(expiry) {
+			l.Log("lease of release %q held by %s expired at %s", release, *spec.HolderIdentity, expiry.Format(time.RFC3339))
+			return nil
+		}
+	}
+	locked := &LockedError{Release: release, Operation: lease.Annotations[operationAnnotation]}
+	if spec.AcquireTime != nil {
+		locked.Since = spec.AcquireTime.Time
+	}
+	return locked
+}
+
+func leaseName(release string) string {
+	return release + ".lock"
+}
+
+// heldLease is a lease held by an operation.
+type heldLease struct {
+	leases *Leases
+	lease  *coordv1.Lease
+	stop   chan struct{}
+	wg     sync.WaitGroup
+}
+
+// renew renews the lease until it is unlocked.
+func (h *heldLease) renew() {
+	defer h.wg.Done()
+	ticker := time.NewTicker(h.leases.duration() / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-h.stop:
+			return
+		case <-ticker.C:
+			now := metav1.NowMicro()
+			h.lease.Spec.RenewTime = &now
+			// The update fails if the lease changed since it was last
+			// written, which means it expired and was taken by another
+			// operation.
+			lease, err := h.leases.impl.Update(h.lease)
+			if err != nil {
+				h.leases.Log("failed to renew lease %q: %s", h.lease.Name, err)
+			} else {
+				h.lease = lease
+			}
+		}
+	}
+}
+
+// unlock stops renewing the lease and deletes it, unless it was taken by
+// another operation.
+func (h *heldLease) unlock() {
+	close(h.stop)
+	h.wg.Wait()
+
+	l := h.leases
+	current, err := l.impl.Get(h.lease.Name, metav1.GetOptions{})
+	if err != nil {
+		l.Log("failed to get lease %q: %s", h.lease.Name, err)
+		return
+	}
+	if current.ResourceVersion != h.lease.ResourceVersion {
+		l.Log("lease %q was taken by another operation", h.lease.Name)
+		return
+	}
+	opts := &metav1.DeleteOptions{Preconditions: metav1.NewUIDPreconditions(string(current.UID))}
+	if err := l.impl.Delete(current.Name, opts); err != nil && !apierrors.IsNotFound(err) {
+		l.Log("failed to delete lease %q: %s", current.Name, err)
+	}
+}
@@ -0,0 +1,122 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lock
+
+import (
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestLeasesLock(t *testing.T) {
+	leases := fake.NewSimpleClientset().CoordinationV1().Leases("kube-system")
+	tiller1 := NewLeases(leases, "tiller-1")
+	tiller2 := NewLeases(leases, "tiller-2")
+
+	unlock, err := tiller1.Lock("angry-bird", "upgrade")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lease, err := leases.Get("angry-bird.lock", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *lease.Spec.HolderIdentity != "tiller-1" {
+		t.Errorf("expected the lease to be held by tiller-1, got %s", *lease.Spec.HolderIdentity)
+	}
+	if lease.Labels["NAME"] != "angry-bird" {
+		t.Errorf("expected the lease to be labeled with the release, got %v", lease.Labels)
+	}
+
+	_, err = tiller2.Lock("angry-bird", "rollback")
+	locked, ok := err.(*LockedError)
+	if !ok {
+		t.Fatalf("expected a locked error, got %v", err)
+	}
+	if locked.Operation != "upgrade" {
+		t.Errorf("expected the lock to be held by upgrade, got %s", locked.Operation)
+	}
+	if !locked.Since.Equal(lease.Spec.AcquireTime.Time) {
+		t.Errorf("expected the lock to be held since %s, got %s", lease.Spec.AcquireTime, locked.Since)
+	}
+
+	unlock()
+	if _, err := leases.Get("angry-bird.lock", metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expected the lease to be deleted, got %v", err)
+	}
+	if _, err := tiller2.Lock("angry-bird", "rollback"); err != nil {
+		t.Errorf("expected the release to be unlocked, got %s", err)
+	}
+}
+
+func TestLeasesLockExpired(t *testing.T) {
+	leases := fake.NewSimpleClientset().CoordinationV1().Leases("kube-system")
+	tiller1 := NewLeases(leases, "tiller-1")
+	tiller2 := NewLeases(leases, "tiller-2")
+
+	if _, err := tiller1.Lock("angry-bird", "upgrade"); err != nil {
+		t.Fatal(err)
+	}
+
+	// The first Tiller died without renewing its lease.
+	lease, err := leases.Get("angry-bird.lock", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	renewed := metav1.NewMicroTime(time.Now().Add(-2 * DefaultLeaseDuration))
+	lease.Spec.RenewTime = &renewed
+	if _, err := leases.Update(lease); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := tiller2.Lock("angry-bird", "rollback"); err != nil {
+		t.Fatalf("expected the expired lease to be taken, got %s", err)
+	}
+	lease, err = leases.Get("angry-bird.lock", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *lease.Spec.HolderIdentity != "tiller-2" || lease.Annotations[operationAnnotation] != "rollback" {
+		t.Errorf("expected the lease to be held by tiller-2 for rollback, got %s for %s", *lease.Spec.HolderIdentity, lease.Annotations[operationAnnotation])
+	}
+}
+
+func TestLeasesShortDuration(t *testing.T) {
+	for _, duration := range []time.Duration{0, 2 * time.Nanosecond, 500 * time.Millisecond} {
+		leases := fake.NewSimpleClientset().CoordinationV1().Leases("kube-system")
+		tiller := NewLeases(leases, "tiller-1")
+		tiller.Duration = duration
+
+		// A duration shorter than a second falls back to the default,
+		// instead of a lease expiring at once and a ticker panicking.
+		unlock, err := tiller.Lock("angry-bird", "upgrade")
+		if err != nil {
+			t.Fatal(err)
+		}
+		lease, err := leases.Get("angry-bird.lock", metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expect := int32(DefaultLeaseDuration / time.Second); *lease.Spec.LeaseDurationSeconds != expect {
+			t.Errorf("%s: expected a lease of %d seconds, got %d", duration, expect, *lease.Spec.LeaseDurationSeconds)
+		}
+		unlock()
+	}
+}
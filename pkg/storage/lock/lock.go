@@ -0,0 +1,80 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package lock implements the locks taken on releases for the duration of the
+// operations changing them, so that two operations on a release cannot race.
+package lock // import "k8s.io/helm/pkg/storage/lock"
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Locker locks releases.
+type Locker interface {
+	// Lock locks the release for the operation, or returns a *LockedError if
+	// another operation holds the lock. The returned function releases the
+	// lock.
+	Lock(release, operation string) (unlock func(), err error)
+}
+
+// LockedError is returned when a release is locked by another operation.
+type LockedError struct {
+	// Release is the name of the locked release.
+	Release string
+	// Operation is the operation holding the lock.
+	Operation string
+	// Since is the time at which the operation took the lock.
+	Since time.Time
+}
+
+func (e *LockedError) Error() string {
+	return fmt.Sprintf("release %q is locked by operation %s started at %s", e.Release, e.Operation, e.Since.Format(time.RFC3339))
+}
+
+// IsLocked returns whether err reports a locked release.
+func IsLocked(err error) bool {
+	_, ok := err.(*LockedError)
+	return ok
+}
+
+// Memory is a Locker holding the locks in memory, which only protects the
+// releases from the operations of the same process.
+type Memory struct {
+	mu    sync.Mutex
+	locks map[string]LockedError
+}
+
+// NewMemory initializes a new in-memory Locker.
+func NewMemory() *Memory {
+	return &Memory{locks: map[string]LockedError{}}
+}
+
+// Lock locks the release for the operation.
+func (m *Memory) Lock(release, operation string) (func(), error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if l, ok := m.locks[release]; ok {
+		return nil, &l
+	}
+	m.locks[release] = LockedError{Release: release, Operation: operation, Since: time.Now()}
+	return func() {
+		m.mu.Lock()
+		delete(m.locks, release)
+		m.mu.Unlock()
+	}, nil
+}
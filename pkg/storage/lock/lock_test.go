@@ -0,0 +1,47 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lock
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMemoryLock(t *testing.T) {
+	m := NewMemory()
+
+	unlock, err := m.Lock("angry-bird", "upgrade")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Lock("other-bird", "install"); err != nil {
+		t.Errorf("expected another release to be unlocked, got %s", err)
+	}
+
+	_, err = m.Lock("angry-bird", "rollback")
+	if !IsLocked(err) {
+		t.Fatalf("expected a locked error, got %v", err)
+	}
+	if !strings.HasPrefix(err.Error(), `release "angry-bird" is locked by operation upgrade started at `) {
+		t.Errorf("unexpected error message: %s", err)
+	}
+
+	unlock()
+	if _, err := m.Lock("angry-bird", "rollback"); err != nil {
+		t.Errorf("expected the release to be unlocked, got %s", err)
+	}
+}
@@ -0,0 +1,161 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage // import "k8s.io/helm/pkg/storage"
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
+	rspb "k8s.io/helm/pkg/proto/hapi/release"
+	relutil "k8s.io/helm/pkg/releaseutil"
+	"k8s.io/helm/pkg/storage/driver"
+	"k8s.io/helm/pkg/timeconv"
+)
+
+// PrunePolicy describes which revisions are removed from a release history.
+//
+// The most recent revision and the last deployed revision are never removed.
+// Limits of 0 or less are ignored.
+type PrunePolicy struct {
+	// MaxHistory is the maximum number of revisions to keep.
+	MaxHistory int
+	// MaxAge is the maximum time since a revision was deployed.
+	MaxAge time.Duration
+	// MaxBytes is the maximum size of all revisions, as encoded by the
+	// storage driver, or of their protobuf encoding if the driver is not a
+	// driver.Sizer.
+	MaxBytes int64
+}
+
+// IsZero returns true if the policy does not impose any limit.
+func (p PrunePolicy) IsZero() bool {
+	return p.MaxHistory <= 0 && p.MaxAge <= 0 && p.MaxBytes <= 0
+}
+
+// now returns the current time. It is replaced in tests.
+var now = time.Now
+
+// PruneCandidates returns the revisions of the release with the provided name
+// that violate the policy, oldest first.
+func (s *Storage) PruneCandidates(name string, policy PrunePolicy) ([]*rspb.Release, error) {
+	h, err := s.History(name)
+	if err != nil {
+		return nil, err
+	}
+	if len(h) < 2 || policy.IsZero() {
+		return nil, nil
+	}
+
+	// We want oldest to newest
+	relutil.SortByRevision(h)
+	latest := h[len(h)-1]
+
+	var lastDeployed int32
+	for _, rel := range h {
+		if rel.GetInfo().GetStatus().GetCode() == rspb.Status_DEPLOYED {
+			lastDeployed = rel.Version
+		}
+	}
+
+	var size int64
+	sizes := map[int32]int64{}
+	if policy.MaxBytes > 0 {
+		for _, rel := range h {
+			n, err := s.recordSize(rel)
+			if err != nil {
+				return nil, err
+			}
+			sizes[rel.Version] = n
+			size += n
+		}
+	}
+
+	var pruned []*rspb.Release
+	remaining := len(h)
+	cutoff := now().Add(-policy.MaxAge)
+	for _, rel := range h {
+		if rel == latest || rel.Version == lastDeployed {
+			continue
+		}
+
+		prune := policy.MaxHistory > 0 && remaining > policy.MaxHistory
+		if policy.MaxAge > 0 && rel.GetInfo().GetLastDeployed() != nil {
+			prune = prune || timeconv.Time(rel.Info.LastDeployed).Before(cutoff)
+		}
+		prune = prune || (policy.MaxBytes > 0 && size > policy.MaxBytes)
+
+		if prune {
+			pruned = append(pruned, rel)
+			remaining--
+			size -= sizes[rel.Version]
+		}
+	}
+	return pruned, nil
+}
+
+// recordSize returns the size of the record storing rls.
+func (s *Storage) recordSize(rls *rspb.Release) (int64, error) {
+	if sizer, ok := s.Driver.(driver.Sizer); ok {
+		n, err := sizer.Size(makeKey(rls.Name, rls.Version), rls)
+		return int64(n), err
+	}
+	return int64(proto.Size(rls)), nil
+}
+
+// Prune removes the revisions of the release with the provided name that
+// violate the policy, and returns the removed revisions.
+func (s *Storage) Prune(name string, policy PrunePolicy) ([]*rspb.Release, error) {
+	candidates, err := s.PruneCandidates(name, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	// Delete as many as possible. Revisions that could not be deleted are
+	// retried by the next prune.
+	var pruned []*rspb.Release
+	errors := []error{}
+	for _, rel := range candidates {
+		if err := s.deleteReleaseVersion(name, rel.Version); err != nil {
+			errors = append(errors, err)
+			continue
+		}
+		pruned = append(pruned, rel)
+	}
+
+	s.Log("Pruned %d record(s) from %s with %d error(s)", len(pruned), name, len(errors))
+	switch c := len(errors); c {
+	case 0:
+		return pruned, nil
+	case 1:
+		return pruned, errors[0]
+	default:
+		return pruned, fmt.Errorf("encountered %d deletion errors. First is: %s", c, errors[0])
+	}
+}
+
+// PruneHistory removes the revisions of the release with the provided name
+// that are older or exceed the size allowed by MaxHistoryAge and MaxHistoryBytes.
+func (s *Storage) PruneHistory(name string) error {
+	policy := PrunePolicy{MaxAge: s.MaxHistoryAge, MaxBytes: s.MaxHistoryBytes}
+	if policy.IsZero() {
+		return nil
+	}
+	_, err := s.Prune(name, policy)
+	return err
+}
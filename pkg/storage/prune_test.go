@@ -0,0 +1,131 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage // import "k8s.io/helm/pkg/storage"
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	rspb "k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/storage/driver"
+	"k8s.io/helm/pkg/timeconv"
+)
+
+func pruneFixture(t *testing.T) *Storage {
+	storage := Init(driver.NewMemory())
+	storage.Log = t.Logf
+
+	const name = "angry-bird"
+	base := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, code := range []rspb.Status_Code{
+		rspb.Status_SUPERSEDED,
+		rspb.Status_SUPERSEDED,
+		rspb.Status_DEPLOYED,
+		rspb.Status_SUPERSEDED,
+		rspb.Status_FAILED,
+	} {
+		rls := ReleaseTestData{Name: name, Version: int32(i + 1), Status: code, Manifest: strings.Repeat("x", 100)}.ToRelease()
+		rls.Info.LastDeployed = timeconv.Timestamp(base.Add(time.Duration(i) * 24 * time.Hour))
+		assertErrNil(t.Fatal, storage.Create(rls), "Storing release 'angry-bird'")
+	}
+	return storage
+}
+
+func versions(rels []*rspb.Release) []int32 {
+	var v []int32
+	for _, r := range rels {
+		v = append(v, r.Version)
+	}
+	return v
+}
+
+func TestStoragePruneCandidates(t *testing.T) {
+	defer func(old func() time.Time) { now = old }(now)
+	now = func() time.Time { return time.Date(2019, 1, 6, 12, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		desc   string
+		policy PrunePolicy
+		expect []int32
+	}{
+		{"no limits", PrunePolicy{}, nil},
+		{"max history keeps deployed and latest", PrunePolicy{MaxHistory: 2}, []int32{1, 2, 4}},
+		{"max age keeps deployed and latest", PrunePolicy{MaxAge: 30 * time.Hour}, []int32{1, 2, 4}},
+		{"max age keeps recent", PrunePolicy{MaxAge: 100 * time.Hour}, []int32{1, 2}},
+		{"max bytes removes oldest first", PrunePolicy{MaxBytes: 400}, []int32{1, 2}},
+	}
+
+	storage := pruneFixture(t)
+	for _, tt := range tests {
+		got, err := storage.PruneCandidates("angry-bird", tt.policy)
+		if err != nil {
+			t.Fatalf("%s: %s", tt.desc, err)
+		}
+		if v := versions(got); len(v) != len(tt.expect) || (len(v) > 0 && !equalVersions(v, tt.expect)) {
+			t.Errorf("%s: expected revisions %v to be pruned, got %v", tt.desc, tt.expect, v)
+		}
+	}
+}
+
+func equalVersions(a, b []int32) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestStoragePruneHistory(t *testing.T) {
+	storage := pruneFixture(t)
+	storage.MaxHistoryAge = time.Hour
+
+	if err := storage.PruneHistory("angry-bird"); err != nil {
+		t.Fatal(err)
+	}
+	h, err := storage.History("angry-bird")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(h) != 2 {
+		t.Fatalf("expected the deployed and the latest revisions to be kept, got %d revisions", len(h))
+	}
+}
+
+// sizedMemory is a memory driver whose records are all of the same size.
+type sizedMemory struct {
+	*driver.Memory
+	size int
+}
+
+func (mem *sizedMemory) Size(string, *rspb.Release) (int, error) {
+	return mem.size, nil
+}
+
+func TestStoragePruneCandidatesDriverSize(t *testing.T) {
+	storage := pruneFixture(t)
+	storage.Driver = &sizedMemory{Memory: storage.Driver.(*driver.Memory), size: 1000}
+
+	got, err := storage.PruneCandidates("angry-bird", PrunePolicy{MaxBytes: 2500})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := versions(got); len(v) != 3 || !equalVersions(v, []int32{1, 2, 4}) {
+		t.Errorf("expected the revisions 1, 2 and 4 to be pruned by the size of the driver, got %v", v)
+	}
+}
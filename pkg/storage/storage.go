@@ -19,6 +19,7 @@ package storage // import "k8s.io/helm/pkg/storage"
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	rspb "k8s.io/helm/pkg/proto/hapi/release"
 	relutil "k8s.io/helm/pkg/releaseutil"
@@ -36,6 +37,11 @@ type Storage struct {
 	// be retained, including the most recent release. Values of 0 or less are
 	// ignored (meaning no limits are imposed).
 	MaxHistory int
+	// MaxHistoryAge and MaxHistoryBytes limit the age and the total encoded
+	// size of the releases retained in history. They are enforced by
+	// PruneHistory. Values of 0 or less are ignored.
+	MaxHistoryAge   time.Duration
+	MaxHistoryBytes int64
 
 	Log func(string, ...interface{})
 }
@@ -25,6 +25,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 )
 
 // Walk walks the file tree rooted at root, calling walkFn for each file or directory
@@ -32,12 +33,18 @@ import (
 // are filtered by walkFn. The files are walked in lexical order, which makes the
 // output deterministic but means that for very large directories Walk can be
 // inefficient. Walk follows symbolic links.
+//
+// The target of a symbolic link is walked once, under the path of the link:
+// walkFn is called with the path of the link for a symlinked file, and with
+// paths under the link for the contents of a symlinked directory. A symbolic
+// link to a directory which contains the link, which would be walked forever,
+// is an error.
 func Walk(root string, walkFn filepath.WalkFunc) error {
 	info, err := os.Lstat(root)
 	if err != nil {
 		err = walkFn(root, nil, err)
 	} else {
-		err = symwalk(root, info, walkFn)
+		err = symwalk(root, info, walkFn, followSymlinks)
 	}
 	if err == filepath.SkipDir {
 		return nil
@@ -45,6 +52,34 @@ func Walk(root string, walkFn filepath.WalkFunc) error {
 	return err
 }
 
+// SymlinkFunc is called by WalkSymlinks for each symbolic link in the tree,
+// with the path of the link, the path it resolves to and the FileInfo of its
+// target. It returns whether the link is followed. If it returns an error,
+// the walk stops with it.
+type SymlinkFunc func(path, resolved string, info os.FileInfo) (bool, error)
+
+// WalkSymlinks is Walk, following only the symbolic links for which symlinkFn
+// returns true. The symbolic links which are not followed are not passed to
+// walkFn. The root is always followed.
+func WalkSymlinks(root string, walkFn filepath.WalkFunc, symlinkFn SymlinkFunc) error {
+	info, err := os.Stat(root)
+	if err != nil {
+		err = walkFn(root, nil, err)
+	} else {
+		err = symwalk(root, info, walkFn, symlinkFn)
+	}
+	if err == filepath.SkipDir {
+		return nil
+	}
+	return err
+}
+
+// followSymlinks is the SymlinkFunc of Walk, which follows all the symbolic
+// links.
+func followSymlinks(path, resolved string, info os.FileInfo) (bool, error) {
+	return true, nil
+}
+
 // readDirNames reads the directory named by dirname and returns
 // a sorted list of directory entries.
 func readDirNames(dirname string) ([]string, error) {
@@ -61,9 +96,10 @@ func readDirNames(dirname string) ([]string, error) {
 	return names, nil
 }
 
-// symwalk recursively descends path, calling walkFn.
-func symwalk(path string, info os.FileInfo, walkFn filepath.WalkFunc) error {
-	// Recursively walk symlinked directories.
+// symwalk recursively descends path, calling walkFn, and following the
+// symbolic links for which symlinkFn returns true.
+func symwalk(path string, info os.FileInfo, walkFn filepath.WalkFunc, symlinkFn SymlinkFunc) error {
+	// Walk the target of a symlink under the path of the link.
 	if IsSymlink(info) {
 		resolved, err := filepath.EvalSymlinks(path)
 		if err != nil {
@@ -72,9 +108,15 @@ func symwalk(path string, info os.FileInfo, walkFn filepath.WalkFunc) error {
 		if info, err = os.Lstat(resolved); err != nil {
 			return err
 		}
-		if err := symwalk(resolved, info, walkFn); err != nil && err != filepath.SkipDir {
+		follow, err := symlinkFn(path, resolved, info)
+		if err != nil || !follow {
 			return err
 		}
+		if info.IsDir() {
+			if err := checkCycle(path, resolved); err != nil {
+				return err
+			}
+		}
 	}
 
 	if err := walkFn(path, info, nil); err != nil {
@@ -98,7 +140,7 @@ func symwalk(path string, info os.FileInfo, walkFn filepath.WalkFunc) error {
 				return err
 			}
 		} else {
-			err = symwalk(filename, fileInfo, walkFn)
+			err = symwalk(filename, fileInfo, walkFn, symlinkFn)
 			if err != nil {
 				if (!fileInfo.IsDir() && !IsSymlink(fileInfo)) || err != filepath.SkipDir {
 					return err
@@ -109,6 +151,37 @@ func symwalk(path string, info os.FileInfo, walkFn filepath.WalkFunc) error {
 	return nil
 }
 
+// checkCycle returns an error if the symlink path to the directory resolved
+// is a cycle: if one of the directories of path, which may be symlinks
+// themselves, is in resolved.
+func checkCycle(path, resolved string) error {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	if resolved, err = filepath.Abs(resolved); err != nil {
+		return err
+	}
+	for dir := filepath.Dir(path); ; dir = filepath.Dir(dir) {
+		realdir, err := filepath.EvalSymlinks(dir)
+		if err != nil {
+			return fmt.Errorf("error evaluating symlink %s: %s", path, err)
+		}
+		if IsWithin(resolved, realdir) {
+			return fmt.Errorf("symlink %s to %s is a cycle", path, resolved)
+		}
+		if filepath.Dir(dir) == dir {
+			return nil
+		}
+	}
+}
+
+// IsWithin returns whether path is dir or is in dir.
+func IsWithin(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
 // IsSymlink is used to determine if the fileinfo is a symbolic link.
 func IsSymlink(fi os.FileInfo) bool {
 	return fi.Mode()&os.ModeSymlink != 0
@@ -21,8 +21,11 @@ limitations under the License.
 package sympath
 
 import (
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -132,3 +135,96 @@ func TestWalk(t *testing.T) {
 		t.Errorf("removeTree: %v", err)
 	}
 }
+
+func TestWalkSymlinks(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sympath-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := os.MkdirAll(filepath.Join(dir, "a", "b"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "a", "b", "file"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("b", filepath.Join(dir, "a", "link")); err != nil {
+		t.Fatal(err)
+	}
+
+	var walked []string
+	walkFn := func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, path)
+		walked = append(walked, filepath.ToSlash(rel))
+		return nil
+	}
+	if err := Walk(dir, walkFn); err != nil {
+		t.Fatal(err)
+	}
+	expect := []string{".", "a", "a/b", "a/b/file", "a/link", "a/link/file"}
+	if !reflect.DeepEqual(walked, expect) {
+		t.Errorf("Expected %v, got %v", expect, walked)
+	}
+
+	walked = nil
+	skip := func(path, resolved string, info os.FileInfo) (bool, error) {
+		return false, nil
+	}
+	if err := WalkSymlinks(dir, walkFn, skip); err != nil {
+		t.Fatal(err)
+	}
+	expect = []string{".", "a", "a/b", "a/b/file"}
+	if !reflect.DeepEqual(walked, expect) {
+		t.Errorf("Expected %v, got %v", expect, walked)
+	}
+
+	if err := os.Symlink("..", filepath.Join(dir, "a", "b", "loop")); err != nil {
+		t.Fatal(err)
+	}
+	if err := Walk(dir, walkFn); err == nil || !strings.Contains(err.Error(), "is a cycle") {
+		t.Errorf("Expected a cycle error, got %v", err)
+	}
+}
+
+func TestWalkExternalSymlink(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sympath-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := os.MkdirAll(filepath.Join(dir, "root"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "shared"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "shared", "file"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join("..", "shared"), filepath.Join(dir, "root", "link")); err != nil {
+		t.Fatal(err)
+	}
+
+	// The target of the link is only walked under the path of the link.
+	var walked []string
+	walkFn := func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, path)
+		walked = append(walked, filepath.ToSlash(rel))
+		return nil
+	}
+	if err := Walk(filepath.Join(dir, "root"), walkFn); err != nil {
+		t.Fatal(err)
+	}
+	expect := []string{"root", "root/link", "root/link/file"}
+	if !reflect.DeepEqual(walked, expect) {
+		t.Errorf("Expected %v, got %v", expect, walked)
+	}
+}
@@ -0,0 +1,112 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package audit records the operations changing releases to an audit sink.
+package audit // import "k8s.io/helm/pkg/tiller/audit"
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// Record records an operation changing a release.
+type Record struct {
+	// Time is the time at which the operation ended.
+	Time time.Time `json:"time"`
+	// Release is the name of the release.
+	Release string `json:"release"`
+	// Namespace is the namespace of the release.
+	Namespace string `json:"namespace,omitempty"`
+	// Operation is install, upgrade, rollback or delete.
+	Operation string `json:"operation"`
+	// Revision is the revision of the release the operation made.
+	Revision int32 `json:"revision,omitempty"`
+	// User identifies the client that requested the operation.
+	User string `json:"user"`
+	// ClientVersion is the version of the Helm client.
+	ClientVersion string `json:"clientVersion,omitempty"`
+	// Chart is the name of the chart of the release.
+	Chart string `json:"chart,omitempty"`
+	// ChartVersion is the version of the chart of the release.
+	ChartVersion string `json:"chartVersion,omitempty"`
+	// ValuesDigest is the SHA-256 digest of the values supplied to the operation.
+	ValuesDigest string `json:"valuesDigest,omitempty"`
+	// Success is whether the operation succeeded.
+	Success bool `json:"success"`
+	// Error is the error of a failed operation.
+	Error string `json:"error,omitempty"`
+}
+
+// Sink stores audit records.
+type Sink interface {
+	// Name is the name of the sink.
+	Name() string
+	// Write stores a record.
+	Write(*Record) error
+}
+
+// Reader is implemented by the sinks whose records can be read back.
+type Reader interface {
+	// Read returns the stored records of a release, the oldest first.
+	Read(release string) ([]*Record, error)
+}
+
+// New creates the Sink described by spec, which is one of:
+//
+//	configmap        records the last records of each release in a ConfigMap
+//	file:PATH        appends the records to the file at PATH as JSON lines
+//	webhook:URL      posts each record as JSON to URL
+//
+// An empty spec or "none" disables auditing, and returns a nil Sink.
+func New(spec string, configmaps corev1.ConfigMapInterface) (Sink, error) {
+	kind, arg := spec, ""
+	if i := strings.Index(spec, ":"); i >= 0 {
+		kind, arg = spec[:i], spec[i+1:]
+	}
+	switch kind {
+	case "", "none":
+		return nil, nil
+	case "configmap":
+		return NewConfigMaps(configmaps), nil
+	case "file":
+		if arg == "" {
+			return nil, fmt.Errorf("audit sink %q needs a path", spec)
+		}
+		return NewFile(arg), nil
+	case "webhook":
+		if arg == "" {
+			return nil, fmt.Errorf("audit sink %q needs a URL", spec)
+		}
+		return NewWebhook(arg), nil
+	default:
+		return nil, fmt.Errorf("unknown audit sink %q, expected none, configmap, file:PATH or webhook:URL", spec)
+	}
+}
+
+// ValuesDigest returns the digest of the raw values supplied to an
+// operation, or an empty string if there are none.
+func ValuesDigest(raw string) string {
+	if strings.TrimSpace(raw) == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(raw))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,74 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"testing"
+
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestNew(t *testing.T) {
+	configmaps := fake.NewSimpleClientset().CoreV1().ConfigMaps("kube-system")
+	tests := []struct {
+		spec string
+		name string
+		err  bool
+	}{
+		{spec: "", name: ""},
+		{spec: "none", name: ""},
+		{spec: "configmap", name: "configmap"},
+		{spec: "file:/var/log/tiller-audit.log", name: "file"},
+		{spec: "webhook:https://audit.example.com/helm", name: "webhook"},
+		{spec: "file:", err: true},
+		{spec: "webhook", err: true},
+		{spec: "syslog", err: true},
+	}
+	for _, tt := range tests {
+		sink, err := New(tt.spec, configmaps)
+		if tt.err {
+			if err == nil {
+				t.Errorf("%q: expected an error", tt.spec)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: %s", tt.spec, err)
+			continue
+		}
+		name := ""
+		if sink != nil {
+			name = sink.Name()
+		}
+		if name != tt.name {
+			t.Errorf("%q: expected sink %q, got %q", tt.spec, tt.name, name)
+		}
+	}
+}
+
+func TestValuesDigest(t *testing.T) {
+	if d := ValuesDigest(" \n"); d != "" {
+		t.Errorf("expected no digest of empty values, got %q", d)
+	}
+	expect := "sha256:23dc9ee71eed1c3cca0ab3d080330cf24469fa0d57a2f1156ee3ceccb3ae25c7"
+	if d := ValuesDigest("name: value\n"); d != expect {
+		t.Errorf("expected %q, got %q", expect, d)
+	}
+	if ValuesDigest("name: value\n") == ValuesDigest("name: other\n") {
+		t.Error("expected the digest to identify the values")
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit // import "k8s.io/helm/pkg/tiller/audit"
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+// DefaultMaxRecords is the default number of records kept per release by the
+// ConfigMaps sink.
+const DefaultMaxRecords = 100
+
+// recordsKey is the key of the records in the data of a ConfigMap.
+const recordsKey = "records"
+
+// ConfigMaps is a Sink keeping the last records of each release in a
+// ConfigMap named after the release, like a ring buffer.
+type ConfigMaps struct {
+	impl corev1.ConfigMapInterface
+	// MaxRecords is the number of records kept per release.
+	MaxRecords int
+}
+
+// NewConfigMaps initializes a new ConfigMaps sink.
+func NewConfigMaps(impl corev1.ConfigMapInterface) *ConfigMaps {
+	return &ConfigMaps{impl: impl, MaxRecords: DefaultMaxRecords}
+}
+
+// Name returns the name of the sink.
+func (c *ConfigMaps) Name() string {
+	return "configmap"
+}
+
+// Write adds the record to the ConfigMap of its release, dropping the oldest
+// records beyond MaxRecords.
+func (c *ConfigMaps) Write(r *Record) error {
+	name := configMapName(r.Release)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cfgmap, err := c.impl.Get(name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			cfgmap = &v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   name,
+					Labels: map[string]string{"NAME": r.Release, "OWNER": "TILLER", "KIND": "AUDIT"},
+				},
+			}
+			if err := encodeRecords(cfgmap, []*Record{r}); err != nil {
+				return err
+			}
+			_, err = c.impl.Create(cfgmap)
+			if apierrors.IsAlreadyExists(err) {
+				// Retry as an update of the ConfigMap created meanwhile.
+				return apierrors.NewConflict(v1.Resource("configmaps"), name, err)
+			}
+			return err
+		}
+		if err != nil {
+			return err
+		}
+
+		records, err := decodeRecords(cfgmap)
+		if err != nil {
+			return err
+		}
+		records = append(records, r)
+		if c.MaxRecords > 0 && len(records) > c.MaxRecords {
+			records = records[len(records)-c.MaxRecords:]
+		}
+		if err := encodeRecords(cfgmap, records); err != nil {
+			return err
+		}
+		_, err = c.impl.Update(cfgmap)
+		return err
+	})
+}
+
+// Read returns the records of the release, the oldest first.
+func (c *ConfigMaps) Read(release string) ([]*Record, error) {
+	cfgmap, err := c.impl.Get(configMapName(release), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeRecords(cfgmap)
+}
+
+func configMapName(release string) string {
+	return release + ".audit"
+}
+
+func decodeRecords(cfgmap *v1.ConfigMap) ([]*Record, error) {
+	var records []*Record
+	data, ok := cfgmap.Data[recordsKey]
+	if !ok {
+		return nil, nil
+	}
+	if err := json.Unmarshal([]byte(data), &records); err != nil {
+		return nil, fmt.Errorf("invalid audit records in ConfigMap %s: %s", cfgmap.Name, err)
+	}
+	return records, nil
+}
+
+func encodeRecords(cfgmap *v1.ConfigMap, records []*Record) error {
+	b, err := json.Marshal(records)
+	if err != nil {
+		return err
+	}
+	if cfgmap.Data == nil {
+		cfgmap.Data = map[string]string{}
+	}
+	cfgmap.Data[recordsKey] = string(b)
+	return nil
+}
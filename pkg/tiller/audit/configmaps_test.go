@@ -0,0 +1,67 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestConfigMaps(t *testing.T) {
+	configmaps := fake.NewSimpleClientset().CoreV1().ConfigMaps("kube-system")
+	sink := NewConfigMaps(configmaps)
+	sink.MaxRecords = 2
+
+	for i, op := range []string{"install", "upgrade", "rollback"} {
+		r := &Record{Time: time.Unix(int64(i), 0).UTC(), Release: "angry-bird", Operation: op, Revision: int32(i + 1), User: "admin", Success: true}
+		if err := sink.Write(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := sink.Write(&Record{Release: "other-bird", Operation: "install"}); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := sink.Read("angry-bird")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected the last 2 records, got %d", len(records))
+	}
+	if records[0].Operation != "upgrade" || records[1].Operation != "rollback" {
+		t.Errorf("expected the upgrade and rollback records, got %s and %s", records[0].Operation, records[1].Operation)
+	}
+	if records[1].Revision != 3 || !records[1].Time.Equal(time.Unix(2, 0)) {
+		t.Errorf("unexpected record %+v", records[1])
+	}
+
+	cfgmap, err := configmaps.Get("angry-bird.audit", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfgmap.Labels["NAME"] != "angry-bird" || cfgmap.Labels["OWNER"] != "TILLER" {
+		t.Errorf("unexpected labels %v", cfgmap.Labels)
+	}
+
+	if records, err := sink.Read("missing"); err != nil || len(records) != 0 {
+		t.Errorf("expected no records of a missing release, got %v, %v", records, err)
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit // import "k8s.io/helm/pkg/tiller/audit"
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"sync"
+)
+
+// File is a Sink appending the records to a file, one JSON object per line.
+type File struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFile initializes a new File sink writing to path.
+func NewFile(path string) *File {
+	return &File{path: path}
+}
+
+// Name returns the name of the sink.
+func (f *File) Name() string {
+	return "file"
+}
+
+// Write appends the record to the file.
+func (f *File) Write(r *Record) error {
+	b, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	out, err := os.OpenFile(f.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := out.Write(append(b, '\n')); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// Read returns the records of the release, the oldest first. The lines that
+// are not records are skipped.
+func (f *File) Read(release string) ([]*Record, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	in, err := os.Open(f.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer in.Close()
+
+	var records []*Record
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		r := &Record{}
+		if err := json.Unmarshal(scanner.Bytes(), r); err != nil {
+			continue
+		}
+		if r.Release == release {
+			records = append(records, r)
+		}
+	}
+	return records, scanner.Err()
+}
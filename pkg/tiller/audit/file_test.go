@@ -0,0 +1,58 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "helm-audit-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	sink := NewFile(filepath.Join(dir, "audit.log"))
+	if records, err := sink.Read("angry-bird"); err != nil || len(records) != 0 {
+		t.Errorf("expected no records before the file exists, got %v, %v", records, err)
+	}
+
+	for _, r := range []*Record{
+		{Release: "angry-bird", Operation: "install", Success: true},
+		{Release: "other-bird", Operation: "install", Success: true},
+		{Release: "angry-bird", Operation: "upgrade", Error: "timed out"},
+	} {
+		if err := sink.Write(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	records, err := sink.Read("angry-bird")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+	if records[1].Operation != "upgrade" || records[1].Success || records[1].Error != "timed out" {
+		t.Errorf("unexpected record %+v", records[1])
+	}
+}
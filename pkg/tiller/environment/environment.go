@@ -35,6 +35,8 @@ import (
 	"k8s.io/helm/pkg/proto/hapi/chart"
 	"k8s.io/helm/pkg/storage"
 	"k8s.io/helm/pkg/storage/driver"
+	"k8s.io/helm/pkg/storage/lock"
+	"k8s.io/helm/pkg/tiller/audit"
 )
 
 const (
@@ -137,6 +139,15 @@ type KubeClient interface {
 	// by "\n---\n").
 	DeleteWithTimeout(namespace string, reader io.Reader, timeout int64, shouldWait bool) error
 
+	// DeleteWithOptions destroys one or more resources, with the propagation
+	// policy of the cascade option.
+	//
+	// namespace must contain a valid existing namespace.
+	//
+	// reader must contain a YAML stream (one or more YAML documents separated
+	// by "\n---\n").
+	DeleteWithOptions(namespace string, reader io.Reader, opts kube.DeleteOptions) error
+
 	// WatchUntilReady watch the resource in reader until it is "ready".
 	//
 	// For Jobs, "ready" means the job ran to completion (excited without error).
@@ -178,6 +189,13 @@ type KubeClient interface {
 	GetPodLogs(name, namespace string) (io.ReadCloser, error)
 
 	WaitUntilCRDEstablished(reader io.Reader, timeout time.Duration) error
+
+	// Statuses gets one or more resources, and returns whether they exist and
+	// are ready, in the order of the manifests.
+	//
+	// reader must contain a YAML stream (one or more YAML documents separated
+	// by "\n---\n").
+	Statuses(namespace string, reader io.Reader) ([]kube.ResourceStatus, error)
 }
 
 // PrintingKubeClient implements KubeClient, but simply prints the reader to
@@ -214,6 +232,14 @@ func (p *PrintingKubeClient) DeleteWithTimeout(ns string, r io.Reader, timeout i
 	return err
 }
 
+// DeleteWithOptions implements KubeClient DeleteWithOptions.
+//
+// It only prints out the content to be deleted.
+func (p *PrintingKubeClient) DeleteWithOptions(ns string, r io.Reader, opts kube.DeleteOptions) error {
+	_, err := io.Copy(p.Out, r)
+	return err
+}
+
 // WatchUntilReady implements KubeClient WatchUntilReady.
 func (p *PrintingKubeClient) WatchUntilReady(ns string, r io.Reader, timeout int64, shouldWait bool) error {
 	_, err := io.Copy(p.Out, r)
@@ -268,6 +294,12 @@ func (p *PrintingKubeClient) WaitUntilCRDEstablished(reader io.Reader, timeout t
 	return err
 }
 
+// Statuses implements KubeClient Statuses.
+func (p *PrintingKubeClient) Statuses(ns string, reader io.Reader) ([]kube.ResourceStatus, error) {
+	_, err := io.Copy(p.Out, reader)
+	return []kube.ResourceStatus{}, err
+}
+
 // Environment provides the context for executing a client request.
 //
 // All services in a context are concurrency safe.
@@ -278,6 +310,10 @@ type Environment struct {
 	Releases *storage.Storage
 	// KubeClient is a Kubernetes API client.
 	KubeClient KubeClient
+	// Locks locks the releases for the duration of the operations changing them.
+	Locks lock.Locker
+	// Audit records the operations changing the releases, if it is not nil.
+	Audit audit.Sink
 }
 
 // New returns an environment initialized with the defaults.
@@ -292,5 +328,6 @@ func New() *Environment {
 	return &Environment{
 		EngineYard: ey,
 		Releases:   storage.Init(driver.NewMemory()),
+		Locks:      lock.NewMemory(),
 	}
 }
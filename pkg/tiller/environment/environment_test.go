@@ -52,6 +52,9 @@ func (k *mockKubeClient) Delete(ns string, r io.Reader) error {
 func (k *mockKubeClient) DeleteWithTimeout(ns string, r io.Reader, timeout int64, shouldWait bool) error {
 	return nil
 }
+func (k *mockKubeClient) DeleteWithOptions(ns string, r io.Reader, opts kube.DeleteOptions) error {
+	return nil
+}
 func (k *mockKubeClient) Update(ns string, currentReader, modifiedReader io.Reader, force bool, recreate bool, timeout int64, shouldWait bool) error {
 	return nil
 }
@@ -86,6 +89,10 @@ func (k *mockKubeClient) WaitUntilCRDEstablished(reader io.Reader, timeout time.
 	return nil
 }
 
+func (k *mockKubeClient) Statuses(ns string, reader io.Reader) ([]kube.ResourceStatus, error) {
+	return []kube.ResourceStatus{}, nil
+}
+
 var _ Engine = &mockEngine{}
 var _ KubeClient = &mockKubeClient{}
 var _ KubeClient = &PrintingKubeClient{}
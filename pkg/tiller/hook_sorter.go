@@ -46,8 +46,18 @@ func (hs *hookWeightSorter) Swap(i, j int) {
 }
 
 func (hs *hookWeightSorter) Less(i, j int) bool {
-	if hs.hooks[i].Weight == hs.hooks[j].Weight {
+	wi, wj := hookWeight(hs.hooks[i]), hookWeight(hs.hooks[j])
+	if wi == wj {
 		return hs.hooks[i].Name < hs.hooks[j].Name
 	}
-	return hs.hooks[i].Weight < hs.hooks[j].Weight
+	return wi < wj
+}
+
+// hookWeight returns the exact weight of the hook, or its integer weight for
+// the hooks of the releases recorded before the weights could be fractional.
+func hookWeight(h *release.Hook) float64 {
+	if h.ExactWeight == 0 {
+		return float64(h.Weight)
+	}
+	return h.ExactWeight
 }
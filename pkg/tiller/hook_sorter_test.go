@@ -71,3 +71,22 @@ func TestHookSorter(t *testing.T) {
 		t.Errorf("Expected %q, got %q", expect, got)
 	}
 }
+
+func TestHookSorterFractionalWeights(t *testing.T) {
+	hooks := []*release.Hook{
+		{Name: "c", Weight: 1, ExactWeight: 1.5},
+		{Name: "b", Weight: 1, ExactWeight: 1},
+		{Name: "d", Weight: 2},
+		{Name: "a", Weight: 0, ExactWeight: -0.5},
+	}
+
+	res := sortByHookWeight(hooks)
+	got := ""
+	expect := "abcd"
+	for _, r := range res {
+		got += r.Name
+	}
+	if got != expect {
+		t.Errorf("Expected %q, got %q", expect, got)
+	}
+}
@@ -19,6 +19,7 @@ package tiller
 import (
 	"fmt"
 	"log"
+	"math"
 	"path"
 	"strconv"
 	"strings"
@@ -165,7 +166,8 @@ func (file *manifestFile) sort(result *result) error {
 			Path:           file.path,
 			Manifest:       m,
 			Events:         []release.Hook_Event{},
-			Weight:         hw,
+			Weight:         int32(hw),
+			ExactWeight:    hw,
 			DeletePolicies: []release.Hook_DeletePolicy{},
 		}
 
@@ -221,14 +223,16 @@ func hasAnyAnnotation(entry util.SimpleHead) bool {
 	return true
 }
 
-func calculateHookWeight(entry util.SimpleHead) int32 {
+// calculateHookWeight returns the weight of the hook annotation, which may be
+// fractional, e.g. "1.5".
+func calculateHookWeight(entry util.SimpleHead) float64 {
 	hws := entry.Metadata.Annotations[hooks.HookWeightAnno]
-	hw, err := strconv.Atoi(hws)
-	if err != nil {
+	hw, err := strconv.ParseFloat(strings.TrimSpace(hws), 64)
+	if err != nil || math.IsNaN(hw) || math.Abs(hw) > math.MaxInt32 {
 		hw = 0
 	}
 
-	return int32(hw)
+	return hw
 }
 
 func operateAnnotationValues(entry util.SimpleHead, annotation string, operate func(p string)) {
@@ -239,3 +243,62 @@ func operateAnnotationValues(entry util.SimpleHead, annotation string, operate f
 		}
 	}
 }
+
+// ChartHook is a hook declared by the templates of a chart, with its events
+// and delete policies named as in the annotations declaring them.
+type ChartHook struct {
+	Name string `json:"name"`
+	Kind string `json:"kind"`
+	// Path is the chart-relative path to the template.
+	Path string `json:"path"`
+	// Events are the events the hook runs on, e.g. "pre-install".
+	Events []string `json:"events"`
+	Weight float64  `json:"weight"`
+	// DeletePolicies are the policies deleting the hook, e.g. "hook-succeeded".
+	DeletePolicies []string `json:"delete_policies,omitempty"`
+	// DeleteTimeout is the number of seconds Tiller waits for the hook to be
+	// deleted.
+	DeleteTimeout int64  `json:"delete_timeout,omitempty"`
+	Manifest      string `json:"manifest"`
+}
+
+// ChartHooks returns the hooks declared by the rendered templates of a chart,
+// in the order Tiller executes them: by ascending weight, then by name.
+//
+// The templates are rendered by renderutil.Render, e.g. with the values of an
+// environment, so that the hooks can be audited before installing the chart.
+func ChartHooks(files map[string]string) ([]ChartHook, error) {
+	hs, _, err := sortManifests(files, chartutil.DefaultVersionSet, InstallOrder)
+	if err != nil {
+		return nil, err
+	}
+
+	eventNames := make(map[release.Hook_Event]string, len(events))
+	for name, e := range events {
+		eventNames[e] = name
+	}
+	policyNames := make(map[release.Hook_DeletePolicy]string, len(deletePolices))
+	for name, p := range deletePolices {
+		policyNames[p] = name
+	}
+
+	result := make([]ChartHook, 0, len(hs))
+	for _, h := range sortByHookWeight(hs) {
+		ch := ChartHook{
+			Name:          h.Name,
+			Kind:          h.Kind,
+			Path:          h.Path,
+			Weight:        hookWeight(h),
+			DeleteTimeout: h.DeleteTimeout,
+			Manifest:      h.Manifest,
+		}
+		for _, e := range h.Events {
+			ch.Events = append(ch.Events, eventNames[e])
+		}
+		for _, p := range h.DeletePolicies {
+			ch.DeletePolicies = append(ch.DeletePolicies, policyNames[p])
+		}
+		result = append(result, ch)
+	}
+	return result, nil
+}
@@ -350,3 +350,87 @@ func TestVersionSet(t *testing.T) {
 		t.Error("Found nonexistent extension")
 	}
 }
+
+func TestSortManifestsHookWeight(t *testing.T) {
+	for _, tt := range []struct {
+		weight      string
+		expect      int32
+		expectExact float64
+	}{
+		{"5", 5, 5},
+		{"-2", -2, -2},
+		{"1.5", 1, 1.5},
+		{"-0.25", 0, -0.25},
+		{"heavy", 0, 0},
+	} {
+		manifests := map[string]string{
+			"templates/job.yaml": `apiVersion: batch/v1
+kind: Job
+metadata:
+  name: migrate
+  annotations:
+    "helm.sh/hook": pre-install
+    "helm.sh/hook-weight": "` + tt.weight + `"
+`,
+		}
+		hs, _, err := sortManifests(manifests, chartutil.NewVersionSet("v1"), InstallOrder)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(hs) != 1 {
+			t.Fatalf("expected 1 hook, got %d", len(hs))
+		}
+		if hs[0].Weight != tt.expect || hs[0].ExactWeight != tt.expectExact {
+			t.Errorf("%s: expected weight %d and exact weight %g, got %d and %g", tt.weight, tt.expect, tt.expectExact, hs[0].Weight, hs[0].ExactWeight)
+		}
+	}
+}
+
+func TestChartHooks(t *testing.T) {
+	manifests := map[string]string{
+		"templates/configmap.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: settings",
+		"templates/migrate.yaml": `apiVersion: batch/v1
+kind: Job
+metadata:
+  name: migrate
+  annotations:
+    "helm.sh/hook": pre-install,pre-upgrade
+    "helm.sh/hook-weight": "2.5"
+    "helm.sh/hook-delete-policy": before-hook-creation,hook-succeeded`,
+		"templates/backup.yaml": `apiVersion: batch/v1
+kind: Job
+metadata:
+  name: backup
+  annotations:
+    "helm.sh/hook": pre-upgrade
+    "helm.sh/hook-weight": "-5"`,
+	}
+	hs, err := ChartHooks(manifests)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect := []ChartHook{
+		{
+			Name:     "backup",
+			Kind:     "Job",
+			Path:     "templates/backup.yaml",
+			Events:   []string{"pre-upgrade"},
+			Weight:   -5,
+			Manifest: manifests["templates/backup.yaml"],
+		},
+		{
+			Name:           "migrate",
+			Kind:           "Job",
+			Path:           "templates/migrate.yaml",
+			Events:         []string{"pre-install", "pre-upgrade"},
+			Weight:         2.5,
+			DeletePolicies: []string{"before-hook-creation", "hook-succeeded"},
+			DeleteTimeout:  defaultHookDeleteTimeoutInSeconds,
+			Manifest:       manifests["templates/migrate.yaml"],
+		},
+	}
+	if !reflect.DeepEqual(hs, expect) {
+		t.Errorf("expected\n%+v\ngot\n%+v", expect, hs)
+	}
+}
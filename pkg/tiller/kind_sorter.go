@@ -168,3 +168,8 @@ func SortByKind(manifests []Manifest) []Manifest {
 	sort.Sort(ks)
 	return ks.manifests
 }
+
+// SortByUninstallOrder sorts manifests in UninstallOrder
+func SortByUninstallOrder(manifests []Manifest) []Manifest {
+	return sortByKind(manifests, UninstallOrder)
+}
@@ -0,0 +1,127 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	"k8s.io/helm/pkg/proto/hapi/release"
+	tpb "k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/tiller/audit"
+	"k8s.io/helm/pkg/timeconv"
+)
+
+var errAuditDisabled = errors.New("the audit log is disabled, see the --audit-sink flag of Tiller")
+
+// GetReleaseAudit returns the audit records of a release, the most recent
+// first.
+func (s *ReleaseServer) GetReleaseAudit(ctx context.Context, req *tpb.GetReleaseAuditRequest) (*tpb.GetReleaseAuditResponse, error) {
+	if err := validateReleaseName(req.Name); err != nil {
+		s.Log("getReleaseAudit: Release name is invalid: %s", req.Name)
+		return nil, err
+	}
+	if s.env.Audit == nil {
+		return nil, errAuditDisabled
+	}
+	reader, ok := s.env.Audit.(audit.Reader)
+	if !ok {
+		return nil, fmt.Errorf("the records of the %s audit sink cannot be read", s.env.Audit.Name())
+	}
+	records, err := reader.Read(req.Name)
+	if err != nil {
+		s.Log("getReleaseAudit: cannot read the records of %s: %s", req.Name, err)
+		return nil, err
+	}
+
+	res := &tpb.GetReleaseAuditResponse{}
+	for i := len(records) - 1; i >= 0; i-- {
+		if req.Max > 0 && len(res.Records) == int(req.Max) {
+			break
+		}
+		r := records[i]
+		res.Records = append(res.Records, &tpb.AuditRecord{
+			Time:          timeconv.Timestamp(r.Time),
+			Release:       r.Release,
+			Namespace:     r.Namespace,
+			Operation:     r.Operation,
+			Revision:      r.Revision,
+			User:          r.User,
+			ClientVersion: r.ClientVersion,
+			Chart:         r.Chart,
+			ChartVersion:  r.ChartVersion,
+			ValuesDigest:  r.ValuesDigest,
+			Success:       r.Success,
+			Error:         r.Error,
+		})
+	}
+	return res, nil
+}
+
+// auditOperation records the result of an operation on the release name in
+// the audit log, if it is enabled. rel is the release made by the operation,
+// if any. A record that cannot be written is logged but does not fail the
+// operation.
+func (s *ReleaseServer) auditOperation(ctx context.Context, operation, name string, rel *release.Release, err error) {
+	if s.env.Audit == nil {
+		return
+	}
+	r := &audit.Record{
+		Time:          time.Now(),
+		Release:       name,
+		Operation:     operation,
+		User:          auditUser(ctx),
+		ClientVersion: versionFromContext(ctx),
+		Success:       err == nil,
+	}
+	if err != nil {
+		r.Error = err.Error()
+	}
+	if rel != nil {
+		r.Release = rel.Name
+		r.Namespace = rel.Namespace
+		r.Revision = rel.Version
+		if md := rel.GetChart().GetMetadata(); md != nil {
+			r.Chart = md.Name
+			r.ChartVersion = md.Version
+		}
+		r.ValuesDigest = audit.ValuesDigest(rel.GetConfig().GetRaw())
+	}
+	if err := s.env.Audit.Write(r); err != nil {
+		s.Log("warning: cannot record the %s of %s in the audit log: %s", operation, r.Release, err)
+	}
+}
+
+// auditUser identifies the client of a call by the common name of its
+// verified TLS certificate, or else by its address.
+func auditUser(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "unknown"
+	}
+	if info, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+		if chains := info.State.VerifiedChains; len(chains) > 0 && len(chains[0]) > 0 {
+			return chains[0][0].Subject.CommonName
+		}
+	}
+	return p.Addr.String()
+}
@@ -0,0 +1,116 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/client-go/kubernetes/fake"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/tiller/audit"
+)
+
+func TestGetReleaseAudit(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rs.env.Audit = audit.NewConfigMaps(fake.NewSimpleClientset().CoreV1().ConfigMaps("kube-system"))
+
+	req := installRequest(withName("audited"))
+	req.Values = &chart.Config{Raw: "name: value\n"}
+	if _, err := rs.InstallRelease(c, req); err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+	if _, err := rs.UpdateRelease(c, &services.UpdateReleaseRequest{Name: "audited", Chart: buildChart()}); err != nil {
+		t.Fatalf("Failed update: %s", err)
+	}
+	if _, err := rs.UpdateRelease(c, &services.UpdateReleaseRequest{Name: "audited", Chart: buildChart(), DryRun: true}); err != nil {
+		t.Fatalf("Failed dry-run update: %s", err)
+	}
+
+	res, err := rs.GetReleaseAudit(c, &services.GetReleaseAuditRequest{Name: "audited"})
+	if err != nil {
+		t.Fatalf("Failed getting the audit records: %s", err)
+	}
+	if len(res.Records) != 2 {
+		t.Fatalf("Expected 2 records, got %d", len(res.Records))
+	}
+
+	upgrade, install := res.Records[0], res.Records[1]
+	if upgrade.Operation != "upgrade" || upgrade.Revision != 2 {
+		t.Errorf("Expected the upgrade to revision 2 first, got the %s of revision %d", upgrade.Operation, upgrade.Revision)
+	}
+	if install.Operation != "install" || install.Revision != 1 {
+		t.Errorf("Expected the install of revision 1 last, got the %s of revision %d", install.Operation, install.Revision)
+	}
+	if install.Chart != "hello" || install.Namespace != "spaced" || !install.Success {
+		t.Errorf("Unexpected install record: %v", install)
+	}
+	if install.ValuesDigest != "sha256:23dc9ee71eed1c3cca0ab3d080330cf24469fa0d57a2f1156ee3ceccb3ae25c7" {
+		t.Errorf("Unexpected values digest %q", install.ValuesDigest)
+	}
+	if install.User != "unknown" {
+		t.Errorf("Expected an unknown user without a peer, got %q", install.User)
+	}
+
+	res, err = rs.GetReleaseAudit(c, &services.GetReleaseAuditRequest{Name: "audited", Max: 1})
+	if err != nil {
+		t.Fatalf("Failed getting the audit records: %s", err)
+	}
+	if len(res.Records) != 1 || res.Records[0].Operation != "upgrade" {
+		t.Errorf("Expected only the upgrade record, got %v", res.Records)
+	}
+}
+
+func TestGetReleaseAudit_Failure(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rs.env.Audit = audit.NewConfigMaps(fake.NewSimpleClientset().CoreV1().ConfigMaps("kube-system"))
+
+	if _, err := rs.UpdateRelease(c, &services.UpdateReleaseRequest{Name: "missing", Chart: buildChart()}); err == nil {
+		t.Fatal("Expected the update of a missing release to fail")
+	}
+
+	res, err := rs.GetReleaseAudit(c, &services.GetReleaseAuditRequest{Name: "missing"})
+	if err != nil {
+		t.Fatalf("Failed getting the audit records: %s", err)
+	}
+	if len(res.Records) != 1 {
+		t.Fatalf("Expected 1 record, got %d", len(res.Records))
+	}
+	if r := res.Records[0]; r.Success || r.Error == "" {
+		t.Errorf("Expected a failed record with an error, got %v", r)
+	}
+}
+
+func TestGetReleaseAudit_Disabled(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+
+	if _, err := rs.GetReleaseAudit(c, &services.GetReleaseAuditRequest{Name: "angry-panda"}); err != errAuditDisabled {
+		t.Errorf("Expected %q, got %v", errAuditDisabled, err)
+	}
+
+	rs.env.Audit = audit.NewWebhook("http://localhost:0")
+	_, err := rs.GetReleaseAudit(c, &services.GetReleaseAuditRequest{Name: "angry-panda"})
+	if err == nil || !strings.Contains(err.Error(), "cannot be read") {
+		t.Errorf("Expected the webhook records to be unreadable, got %v", err)
+	}
+}
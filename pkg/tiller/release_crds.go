@@ -0,0 +1,120 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/ghodss/yaml"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	relutil "k8s.io/helm/pkg/releaseutil"
+)
+
+// crdDocument is a YAML document of a file of the crds/ directory of a chart.
+type crdDocument struct {
+	file     string
+	key      string
+	manifest string
+}
+
+// crdDocuments splits the custom resource definitions of the chart c into
+// their YAML documents. The documents without a kind, like the ones holding
+// only comments, are skipped.
+func crdDocuments(c *chart.Chart) ([]crdDocument, error) {
+	var docs []crdDocument
+	for _, crd := range chartutil.CRDs(c) {
+		manifests := relutil.SplitManifests(string(crd.Data))
+		for i := 0; i < len(manifests); i++ {
+			m := manifests[fmt.Sprintf("manifest-%d", i)]
+			var head relutil.SimpleHead
+			if err := yaml.Unmarshal([]byte(m), &head); err != nil {
+				return nil, fmt.Errorf("YAML parse error on %s: %s", crd.Name, err)
+			}
+			if head.Kind == "" {
+				continue
+			}
+			if head.Metadata == nil || head.Metadata.Name == "" {
+				return nil, fmt.Errorf("%s: the %s has no name", crd.Name, head.Kind)
+			}
+			docs = append(docs, crdDocument{
+				file:     crd.Name,
+				key:      head.Kind + "/" + head.Metadata.Name,
+				manifest: m,
+			})
+		}
+	}
+	return docs, nil
+}
+
+// installCRDs creates the custom resource definitions of the crds/ directories
+// of the chart c, and waits until they are established.
+//
+// The definitions that already exist are left as they are, unless previous is
+// set: they are then updated, with their definition in previous, the chart of
+// the release being upgraded, as the original one.
+func (s *ReleaseServer) installCRDs(c, previous *chart.Chart, namespace string, timeout int64) error {
+	docs, err := crdDocuments(c)
+	if err != nil || len(docs) == 0 {
+		return err
+	}
+	original := map[string]string{}
+	if previous != nil {
+		previousDocs, err := crdDocuments(previous)
+		if err != nil {
+			return err
+		}
+		for _, d := range previousDocs {
+			original[d.key] = d.manifest
+		}
+	}
+
+	kubeCli := s.env.KubeClient
+	s.Log("installing %d CRDs", len(docs))
+	for _, d := range docs {
+		err := kubeCli.Create(namespace, bytes.NewBufferString(d.manifest), timeout, false)
+		if err == nil {
+			continue
+		}
+		if !apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to install CRD %s from %s: %s", d.key, d.file, err)
+		}
+		if previous == nil {
+			s.Log("CRD %s already exists, skipping", d.key)
+			continue
+		}
+		orig, ok := original[d.key]
+		if !ok {
+			orig = d.manifest
+		}
+		s.Log("updating CRD %s", d.key)
+		if err := kubeCli.Update(namespace, bytes.NewBufferString(orig), bytes.NewBufferString(d.manifest), false, false, timeout, false); err != nil {
+			return fmt.Errorf("failed to update CRD %s from %s: %s", d.key, d.file, err)
+		}
+	}
+
+	for _, d := range docs {
+		if err := kubeCli.WaitUntilCRDEstablished(bytes.NewBufferString(d.manifest), time.Duration(timeout)*time.Second); err != nil {
+			return fmt.Errorf("CRD %s is not established: %s", d.key, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,224 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/any"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/tiller/environment"
+)
+
+func crdManifest(name, version string) string {
+	return `apiVersion: apiextensions.k8s.io/v1beta1
+kind: CustomResourceDefinition
+metadata:
+  name: ` + name + `
+spec:
+  group: example.com
+  version: ` + version
+}
+
+func withCRD(file, manifest string) chartOption {
+	return func(opts *chartOptions) {
+		opts.Files = append(opts.Files, &any.Any{TypeUrl: file, Value: []byte(manifest)})
+	}
+}
+
+// crdKubeClient records the CRDs created, updated and waited for. Creating
+// one of the existing CRDs fails.
+type crdKubeClient struct {
+	environment.PrintingKubeClient
+	existing []string
+	created  []string
+	updated  [][2]string
+	waited   []string
+}
+
+func newCRDKubeClient(existing ...string) *crdKubeClient {
+	return &crdKubeClient{
+		PrintingKubeClient: environment.PrintingKubeClient{Out: ioutil.Discard},
+		existing:           existing,
+	}
+}
+
+func readAll(r io.Reader) string {
+	b, _ := ioutil.ReadAll(r)
+	return string(b)
+}
+
+func (k *crdKubeClient) Create(ns string, r io.Reader, timeout int64, shouldWait bool) error {
+	manifest := readAll(r)
+	if !strings.Contains(manifest, "kind: CustomResourceDefinition") {
+		return nil
+	}
+	for _, name := range k.existing {
+		if strings.Contains(manifest, "name: "+name) {
+			return apierrors.NewAlreadyExists(schema.GroupResource{Group: "apiextensions.k8s.io", Resource: "customresourcedefinitions"}, name)
+		}
+	}
+	k.created = append(k.created, manifest)
+	return nil
+}
+
+func (k *crdKubeClient) Update(ns string, originalReader, modifiedReader io.Reader, force bool, recreate bool, timeout int64, shouldWait bool) error {
+	original, modified := readAll(originalReader), readAll(modifiedReader)
+	if strings.Contains(modified, "kind: CustomResourceDefinition") {
+		k.updated = append(k.updated, [2]string{original, modified})
+	}
+	return nil
+}
+
+func (k *crdKubeClient) WaitUntilCRDEstablished(r io.Reader, timeout time.Duration) error {
+	k.waited = append(k.waited, readAll(r))
+	return nil
+}
+
+func TestInstallRelease_CRDs(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	kubeClient := newCRDKubeClient("backups.example.com")
+	rs.env.KubeClient = kubeClient
+
+	backups := crdManifest("backups.example.com", "v1")
+	crontabs := crdManifest("crontabs.example.com", "v1")
+	req := installRequest(withChart(
+		withSampleTemplates(),
+		withCRD("crds/backups.yaml", "# Backups\n---\n"+backups),
+		withDependency(withCRD("crds/crontabs.yaml", crontabs)),
+	))
+	res, err := rs.InstallRelease(c, req)
+	if err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+	if res.Release.Info.Description != "Install complete" {
+		t.Errorf("unexpected description: %s", res.Release.Info.Description)
+	}
+
+	// The existing CRD is not updated on install.
+	if len(kubeClient.created) != 1 || kubeClient.created[0] != crontabs {
+		t.Errorf("expected the crontabs CRD to be created, got %v", kubeClient.created)
+	}
+	if len(kubeClient.updated) != 0 {
+		t.Errorf("expected no CRD to be updated, got %v", kubeClient.updated)
+	}
+	if len(kubeClient.waited) != 2 || kubeClient.waited[0] != crontabs || kubeClient.waited[1] != backups {
+		t.Errorf("expected to wait for the CRDs of the subchart then of the chart, got %v", kubeClient.waited)
+	}
+}
+
+func TestInstallRelease_DryRunCRDs(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	kubeClient := newCRDKubeClient()
+	rs.env.KubeClient = kubeClient
+
+	req := installRequest(withDryRun(), withChart(
+		withSampleTemplates(),
+		withCRD("crds/backups.yaml", crdManifest("backups.example.com", "v1")),
+	))
+	res, err := rs.InstallRelease(c, req)
+	if err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+	if len(kubeClient.created) != 0 {
+		t.Errorf("expected no CRD to be created on a dry run, got %v", kubeClient.created)
+	}
+	expect := "Validation skipped because CRDs are not installed"
+	if res.Release.Info.Description != expect {
+		t.Errorf("Expected Description %q, got %q", expect, res.Release.Info.Description)
+	}
+}
+
+func TestInstallRelease_InvalidCRD(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rs.env.KubeClient = newCRDKubeClient()
+
+	req := installRequest(withChart(
+		withSampleTemplates(),
+		withCRD("crds/backups.yaml", "kind: CustomResourceDefinition\nmetadata: {}"),
+	))
+	_, err := rs.InstallRelease(c, req)
+	if err == nil || !strings.Contains(err.Error(), "crds/backups.yaml: the CustomResourceDefinition has no name") {
+		t.Errorf("expected an error about the CRD without a name, got %v", err)
+	}
+}
+
+func TestUpdateRelease_CRDs(t *testing.T) {
+	tests := []struct {
+		name      string
+		forceCrds bool
+		created   int
+		updated   int
+	}{
+		{name: "skipped", forceCrds: false},
+		{name: "forced", forceCrds: true, created: 1, updated: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := helm.NewContext()
+			rs := rsFixture()
+			kubeClient := newCRDKubeClient("backups.example.com")
+			rs.env.KubeClient = kubeClient
+
+			rel := releaseStub()
+			rel.Chart = buildChart(withCRD("crds/backups.yaml", crdManifest("backups.example.com", "v1")))
+			rs.env.Releases.Create(rel)
+
+			backups := crdManifest("backups.example.com", "v2")
+			crontabs := crdManifest("crontabs.example.com", "v1")
+			req := &services.UpdateReleaseRequest{
+				Name: rel.Name,
+				Chart: buildChart(
+					withSampleTemplates(),
+					withCRD("crds/backups.yaml", backups),
+					withCRD("crds/crontabs.yaml", crontabs),
+				),
+				ForceCrds: tt.forceCrds,
+			}
+			if _, err := rs.UpdateRelease(c, req); err != nil {
+				t.Fatalf("Failed updated: %s", err)
+			}
+
+			if len(kubeClient.created) != tt.created {
+				t.Errorf("expected %d CRDs to be created, got %v", tt.created, kubeClient.created)
+			}
+			if len(kubeClient.updated) != tt.updated {
+				t.Fatalf("expected %d CRDs to be updated, got %v", tt.updated, kubeClient.updated)
+			}
+			if tt.updated > 0 {
+				if original := kubeClient.updated[0][0]; original != crdManifest("backups.example.com", "v1") {
+					t.Errorf("expected the CRD of the previous chart as the original, got %s", original)
+				}
+				if modified := kubeClient.updated[0][1]; modified != backups {
+					t.Errorf("expected the CRD of the chart as the modified, got %s", modified)
+				}
+			}
+		})
+	}
+}
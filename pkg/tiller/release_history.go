@@ -17,10 +17,13 @@ limitations under the License.
 package tiller
 
 import (
+	"time"
+
 	"golang.org/x/net/context"
 
 	tpb "k8s.io/helm/pkg/proto/hapi/services"
 	relutil "k8s.io/helm/pkg/releaseutil"
+	"k8s.io/helm/pkg/storage"
 )
 
 // GetHistory gets the history for a given release.
@@ -46,6 +49,32 @@ func (s *ReleaseServer) GetHistory(ctx context.Context, req *tpb.GetHistoryReque
 	return &resp, nil
 }
 
+// PruneReleaseHistory removes the revisions of a release that violate the
+// requested limits. The most recent and the last deployed revisions are kept.
+func (s *ReleaseServer) PruneReleaseHistory(ctx context.Context, req *tpb.PruneReleaseHistoryRequest) (*tpb.PruneReleaseHistoryResponse, error) {
+	if err := validateReleaseName(req.Name); err != nil {
+		s.Log("pruneReleaseHistory: Release name is invalid: %s", req.Name)
+		return nil, err
+	}
+
+	policy := storage.PrunePolicy{
+		MaxHistory: int(req.Max),
+		MaxAge:     time.Duration(req.MaxAge) * time.Second,
+		MaxBytes:   req.MaxBytes,
+	}
+
+	var resp tpb.PruneReleaseHistoryResponse
+	var err error
+	if req.DryRun {
+		s.Log("finding revisions to prune for release %s", req.Name)
+		resp.Releases, err = s.env.Releases.PruneCandidates(req.Name, policy)
+	} else {
+		s.Log("pruning history for release %s", req.Name)
+		resp.Releases, err = s.env.Releases.Prune(req.Name, policy)
+	}
+	return &resp, err
+}
+
 func min(x, y int) int {
 	if x < y {
 		return x
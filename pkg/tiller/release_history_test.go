@@ -113,3 +113,40 @@ func TestGetHistory_WithNoRevisions(t *testing.T) {
 		}
 	}
 }
+
+func TestPruneReleaseHistory(t *testing.T) {
+	mk := func(name string, vers int32, code rpb.Status_Code) *rpb.Release {
+		return &rpb.Release{
+			Name:    name,
+			Version: vers,
+			Info:    &rpb.Info{Status: &rpb.Status{Code: code}},
+		}
+	}
+
+	srv := rsFixture()
+	for _, rel := range []*rpb.Release{
+		mk("angry-bird", 1, rpb.Status_SUPERSEDED),
+		mk("angry-bird", 2, rpb.Status_SUPERSEDED),
+		mk("angry-bird", 3, rpb.Status_DEPLOYED),
+	} {
+		srv.env.Releases.Create(rel)
+	}
+
+	res, err := srv.PruneReleaseHistory(helm.NewContext(), &tpb.PruneReleaseHistoryRequest{Name: "angry-bird", Max: 1, DryRun: true})
+	if err != nil {
+		t.Fatalf("Failed prune: %s", err)
+	}
+	if len(res.Releases) != 2 {
+		t.Errorf("Expected 2 revisions to prune, got %d", len(res.Releases))
+	}
+	if h, _ := srv.env.Releases.History("angry-bird"); len(h) != 3 {
+		t.Errorf("Expected dry run to keep 3 revisions, got %d", len(h))
+	}
+
+	if _, err := srv.PruneReleaseHistory(helm.NewContext(), &tpb.PruneReleaseHistoryRequest{Name: "angry-bird", Max: 1}); err != nil {
+		t.Fatalf("Failed prune: %s", err)
+	}
+	if h, _ := srv.env.Releases.History("angry-bird"); len(h) != 1 || h[0].Version != 3 {
+		t.Errorf("Expected only revision 3 to be kept, got %v", h)
+	}
+}
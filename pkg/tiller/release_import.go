@@ -0,0 +1,94 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"errors"
+	"fmt"
+
+	"golang.org/x/net/context"
+
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/proto/hapi/services"
+	relutil "k8s.io/helm/pkg/releaseutil"
+)
+
+// ImportReleaseHistory stores the revisions of a release exported from another Tiller.
+//
+// Only the release records are written; no resources are created in the cluster.
+// The release is locked during the import, and the revisions already stored are
+// deleted if a revision cannot be stored, so that no partial history is left.
+func (s *ReleaseServer) ImportReleaseHistory(c context.Context, req *services.ImportReleaseHistoryRequest) (*services.ImportReleaseHistoryResponse, error) {
+	if len(req.Releases) == 0 {
+		return nil, errors.New("no release revisions to import")
+	}
+
+	name := req.Name
+	if name == "" {
+		name = req.Releases[0].Name
+	}
+	if err := validateReleaseName(name); err != nil {
+		s.Log("importReleaseHistory: Release name is invalid: %s", name)
+		return nil, err
+	}
+
+	seen := map[int32]bool{}
+	for _, rel := range req.Releases {
+		if req.Name == "" && rel.Name != name {
+			return nil, fmt.Errorf("cannot import revisions of more than one release: %q and %q", name, rel.Name)
+		}
+		if rel.Info == nil || rel.Info.Status == nil {
+			return nil, fmt.Errorf("revision %d of %q has no status", rel.Version, name)
+		}
+		if seen[rel.Version] {
+			return nil, fmt.Errorf("revision %d of %q is duplicated", rel.Version, name)
+		}
+		seen[rel.Version] = true
+	}
+
+	unlock, err := s.lockRelease(name, "import")
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	if h, err := s.env.Releases.History(name); err == nil && len(h) > 0 {
+		return nil, fmt.Errorf("a release named %q already exists", name)
+	}
+
+	rels := make([]*release.Release, len(req.Releases))
+	copy(rels, req.Releases)
+	relutil.SortByRevision(rels)
+
+	s.Log("importing %d revision(s) of %s", len(rels), name)
+	for i, rel := range rels {
+		rel.Name = name
+		if req.Namespace != "" {
+			rel.Namespace = req.Namespace
+		}
+		if err := s.env.Releases.Create(rel); err != nil {
+			for _, created := range rels[:i] {
+				if _, derr := s.env.Releases.Delete(name, created.Version); derr != nil {
+					s.Log("warning: cannot delete imported revision %d of %s: %s", created.Version, name, derr)
+				}
+			}
+			return nil, fmt.Errorf("failed to import revision %d of %q: %s", rel.Version, name, err)
+		}
+	}
+
+	return &services.ImportReleaseHistoryResponse{Release: rels[len(rels)-1]}, nil
+}
@@ -0,0 +1,149 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"k8s.io/helm/pkg/helm"
+	rpb "k8s.io/helm/pkg/proto/hapi/release"
+	tpb "k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/storage"
+	"k8s.io/helm/pkg/storage/driver"
+)
+
+func TestImportReleaseHistory(t *testing.T) {
+	mk := func(name string, vers int32, code rpb.Status_Code) *rpb.Release {
+		return &rpb.Release{
+			Name:      name,
+			Version:   vers,
+			Namespace: "default",
+			Info:      &rpb.Info{Status: &rpb.Status{Code: code}},
+		}
+	}
+
+	srv := rsFixture()
+	req := &tpb.ImportReleaseHistoryRequest{
+		Releases: []*rpb.Release{
+			mk("angry-bird", 2, rpb.Status_DEPLOYED),
+			mk("angry-bird", 1, rpb.Status_SUPERSEDED),
+		},
+		Namespace: "migrated",
+	}
+
+	res, err := srv.ImportReleaseHistory(helm.NewContext(), req)
+	if err != nil {
+		t.Fatalf("Failed import: %s", err)
+	}
+	if res.Release.Version != 2 {
+		t.Errorf("Expected latest revision 2, got %d", res.Release.Version)
+	}
+
+	h, err := srv.env.Releases.History("angry-bird")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(h) != 2 {
+		t.Fatalf("Expected 2 stored revisions, got %d", len(h))
+	}
+	for _, rel := range h {
+		if rel.Namespace != "migrated" {
+			t.Errorf("Expected namespace migrated, got %q", rel.Namespace)
+		}
+	}
+
+	if _, err := srv.ImportReleaseHistory(helm.NewContext(), req); err == nil {
+		t.Error("Expected error importing over an existing release")
+	} else if !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("Unexpected error: %s", err)
+	}
+}
+
+func TestImportReleaseHistory_Rename(t *testing.T) {
+	srv := rsFixture()
+	req := &tpb.ImportReleaseHistoryRequest{
+		Releases: []*rpb.Release{
+			{Name: "angry-bird", Version: 1, Info: &rpb.Info{Status: &rpb.Status{Code: rpb.Status_DEPLOYED}}},
+		},
+		Name: "happy-bird",
+	}
+	if _, err := srv.ImportReleaseHistory(helm.NewContext(), req); err != nil {
+		t.Fatalf("Failed import: %s", err)
+	}
+	if _, err := srv.env.Releases.Get("happy-bird", 1); err != nil {
+		t.Errorf("Expected renamed release to be stored: %s", err)
+	}
+}
+
+func TestImportReleaseHistory_Invalid(t *testing.T) {
+	tests := []struct {
+		desc string
+		req  *tpb.ImportReleaseHistoryRequest
+	}{
+		{"no revisions", &tpb.ImportReleaseHistoryRequest{}},
+		{"missing status", &tpb.ImportReleaseHistoryRequest{Releases: []*rpb.Release{{Name: "angry-bird", Version: 1}}}},
+		{"mixed releases", &tpb.ImportReleaseHistoryRequest{Releases: []*rpb.Release{
+			{Name: "angry-bird", Version: 1, Info: &rpb.Info{Status: &rpb.Status{}}},
+			{Name: "sad-panda", Version: 1, Info: &rpb.Info{Status: &rpb.Status{}}},
+		}}},
+		{"duplicate revisions", &tpb.ImportReleaseHistoryRequest{Releases: []*rpb.Release{
+			{Name: "angry-bird", Version: 1, Info: &rpb.Info{Status: &rpb.Status{}}},
+			{Name: "angry-bird", Version: 1, Info: &rpb.Info{Status: &rpb.Status{}}},
+		}}},
+	}
+
+	for _, tt := range tests {
+		if _, err := rsFixture().ImportReleaseHistory(helm.NewContext(), tt.req); err == nil {
+			t.Errorf("%s: expected error", tt.desc)
+		}
+	}
+}
+
+// failingCreateDriver fails to store the revision failVersion.
+type failingCreateDriver struct {
+	*driver.Memory
+	failVersion int32
+}
+
+func (d *failingCreateDriver) Create(key string, rls *rpb.Release) error {
+	if rls.Version == d.failVersion {
+		return errors.New("storage is full")
+	}
+	return d.Memory.Create(key, rls)
+}
+
+func TestImportReleaseHistory_CreateFailure(t *testing.T) {
+	srv := rsFixture()
+	srv.env.Releases = storage.Init(&failingCreateDriver{Memory: driver.NewMemory(), failVersion: 3})
+	req := &tpb.ImportReleaseHistoryRequest{
+		Releases: []*rpb.Release{
+			{Name: "angry-bird", Version: 1, Info: &rpb.Info{Status: &rpb.Status{Code: rpb.Status_SUPERSEDED}}},
+			{Name: "angry-bird", Version: 2, Info: &rpb.Info{Status: &rpb.Status{Code: rpb.Status_SUPERSEDED}}},
+			{Name: "angry-bird", Version: 3, Info: &rpb.Info{Status: &rpb.Status{Code: rpb.Status_DEPLOYED}}},
+		},
+	}
+
+	_, err := srv.ImportReleaseHistory(helm.NewContext(), req)
+	if err == nil || !strings.Contains(err.Error(), "failed to import revision 3") {
+		t.Fatalf("Expected the import of revision 3 to fail, got %v", err)
+	}
+	if h, err := srv.env.Releases.History("angry-bird"); err != nil || len(h) != 0 {
+		t.Errorf("Expected the imported revisions to be deleted, got %d revision(s), %v", len(h), err)
+	}
+}
@@ -26,12 +26,31 @@ import (
 	"k8s.io/helm/pkg/hooks"
 	"k8s.io/helm/pkg/proto/hapi/release"
 	"k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/redact"
 	relutil "k8s.io/helm/pkg/releaseutil"
 	"k8s.io/helm/pkg/timeconv"
 )
 
 // InstallRelease installs a release and stores the release record.
 func (s *ReleaseServer) InstallRelease(c ctx.Context, req *services.InstallReleaseRequest) (*services.InstallReleaseResponse, error) {
+	res, err := s.installRelease(req)
+	if !req.DryRun {
+		s.auditOperation(c, "install", req.Name, res.GetRelease(), err)
+	}
+	return res, err
+}
+
+func (s *ReleaseServer) installRelease(req *services.InstallReleaseRequest) (*services.InstallReleaseResponse, error) {
+	// A generated name is only locked once the release is prepared, and an
+	// invalid one is reported by the preparation.
+	if !req.DryRun && validateReleaseName(req.Name) == nil {
+		unlock, err := s.lockRelease(req.Name, "install")
+		if err != nil {
+			return nil, err
+		}
+		defer unlock()
+	}
+
 	s.Log("preparing install for %s", req.Name)
 	rel, err := s.prepareRelease(req)
 	if err != nil {
@@ -46,6 +65,14 @@ func (s *ReleaseServer) InstallRelease(c ctx.Context, req *services.InstallRelea
 		return res, err
 	}
 
+	if !req.DryRun && req.Name == "" {
+		unlock, err := s.lockRelease(rel.Name, "install")
+		if err != nil {
+			return nil, err
+		}
+		defer unlock()
+	}
+
 	s.Log("performing install for %s", req.Name)
 	res, err := s.performRelease(rel, req)
 	if err != nil {
@@ -59,6 +86,9 @@ func (s *ReleaseServer) prepareRelease(req *services.InstallReleaseRequest) (*re
 	if req.Chart == nil {
 		return nil, errMissingChart
 	}
+	if _, err := chartutil.DependencyWaves(req.Chart); err != nil {
+		return nil, err
+	}
 
 	name, err := s.uniqName(req.Name, req.ReuseName)
 	if err != nil {
@@ -73,19 +103,23 @@ func (s *ReleaseServer) prepareRelease(req *services.InstallReleaseRequest) (*re
 	revision := 1
 	ts := timeconv.Now()
 	options := chartutil.ReleaseOptions{
-		Name:      name,
-		Time:      ts,
-		Namespace: req.Namespace,
-		Revision:  revision,
-		IsInstall: true,
+		Name:        name,
+		Time:        ts,
+		Namespace:   req.Namespace,
+		Revision:    revision,
+		IsInstall:   true,
+		Environment: req.Environment,
 	}
+	// The sensitive values of the chart are masked in the errors.
+	redactor := redact.New(req.Chart, req.Values)
 	valuesToRender, err := chartutil.ToRenderValuesCaps(req.Chart, req.Values, options, caps)
 	if err != nil {
-		return nil, err
+		return nil, redactor.Error(err)
 	}
 
 	hooks, manifestDoc, notesTxt, err := s.renderResources(req.Chart, valuesToRender, req.SubNotes, caps.APIVersions)
 	if err != nil {
+		err = redactor.Error(err)
 		// Return a release with partial data so that client can show debugging
 		// information.
 		rel := &release.Release{
@@ -102,26 +136,28 @@ func (s *ReleaseServer) prepareRelease(req *services.InstallReleaseRequest) (*re
 			Version: 0,
 		}
 		if manifestDoc != nil {
-			rel.Manifest = manifestDoc.String()
+			rel.Manifest = redactor.String(manifestDoc.String())
 		}
 		return rel, err
 	}
 
 	// Store a release.
 	rel := &release.Release{
-		Name:      name,
-		Namespace: req.Namespace,
-		Chart:     req.Chart,
-		Config:    req.Values,
+		Name:        name,
+		Namespace:   req.Namespace,
+		Environment: req.Environment,
+		Chart:       req.Chart,
+		Config:      req.Values,
 		Info: &release.Info{
 			FirstDeployed: ts,
 			LastDeployed:  ts,
 			Status:        &release.Status{Code: release.Status_PENDING_INSTALL},
 			Description:   "Initial install underway", // Will be overwritten.
 		},
-		Manifest: manifestDoc.String(),
-		Hooks:    hooks,
-		Version:  int32(revision),
+		Manifest:   manifestDoc.String(),
+		Namespaces: relutil.ManifestNamespaces(manifestDoc.String(), req.Namespace),
+		Hooks:      hooks,
+		Version:    int32(revision),
 	}
 	if len(notesTxt) > 0 {
 		rel.Info.Status.Notes = notesTxt
@@ -154,6 +190,11 @@ func (s *ReleaseServer) performRelease(r *release.Release, req *services.Install
 			res.Release.Info.Description = "Validation skipped because CRDs are not installed"
 			return res, nil
 		}
+		if len(chartutil.CRDs(r.Chart)) > 0 {
+			s.Log("validation skipped because the chart has CRDs")
+			res.Release.Info.Description = "Validation skipped because CRDs are not installed"
+			return res, nil
+		}
 
 		// Here's the problem with dry runs and CRDs: We can't install a CRD
 		// during a dry run, which means it cannot be validated.
@@ -165,6 +206,12 @@ func (s *ReleaseServer) performRelease(r *release.Release, req *services.Install
 		return res, nil
 	}
 
+	// The CRDs of the crds/ directories are installed first, as the hooks and
+	// the templates may create custom resources.
+	if err := s.installCRDs(r.Chart, nil, r.Namespace, req.Timeout); err != nil {
+		return res, err
+	}
+
 	// crd-install hooks
 	if !req.DisableHooks && !req.DisableCrdHook {
 		if err := s.execHook(r.Hooks, r.Name, r.Namespace, hooks.CRDInstall, req.Timeout); err != nil {
@@ -183,6 +230,16 @@ func (s *ReleaseServer) performRelease(r *release.Release, req *services.Install
 	// pre-install hooks
 	if !req.DisableHooks {
 		if err := s.execHook(r.Hooks, r.Name, r.Namespace, hooks.PreInstall, req.Timeout); err != nil {
+			// The release is recorded when a hook failed, so that its status
+			// tells why.
+			if failure := failedHook(err); failure != nil {
+				msg := fmt.Sprintf("Release %q failed pre-install: %s", r.Name, err)
+				s.Log("warning: %s", msg)
+				r.Info.Status.Code = release.Status_FAILED
+				r.Info.Status.FailedHook = failure
+				r.Info.Description = msg
+				s.recordRelease(r, false)
+			}
 			return res, err
 		}
 	} else {
@@ -243,6 +300,7 @@ func (s *ReleaseServer) performRelease(r *release.Release, req *services.Install
 			msg := fmt.Sprintf("Release %q failed post-install: %s", r.Name, err)
 			s.Log("warning: %s", msg)
 			r.Info.Status.Code = release.Status_FAILED
+			r.Info.Status.FailedHook = failedHook(err)
 			r.Info.Description = msg
 			s.recordRelease(r, true)
 			return res, err
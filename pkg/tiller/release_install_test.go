@@ -18,13 +18,22 @@ package tiller
 
 import (
 	"fmt"
+	"io"
+	"io/ioutil"
+	"reflect"
 	"strings"
 	"testing"
 
+	"github.com/golang/protobuf/ptypes/any"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
 	"k8s.io/helm/pkg/helm"
 	"k8s.io/helm/pkg/proto/hapi/chart"
 	"k8s.io/helm/pkg/proto/hapi/release"
 	"k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/tiller/environment"
 	"k8s.io/helm/pkg/version"
 )
 
@@ -324,8 +333,9 @@ func TestInstallRelease_WithChartAndDependencyAllNotes(t *testing.T) {
 
 	t.Logf("rel: %v", rel)
 
-	if !strings.Contains(rel.Info.Status.Notes, notesText) || !strings.Contains(rel.Info.Status.Notes, notesText+" child") {
-		t.Fatalf("Expected '%s', got '%s'", notesText+"\n"+notesText+" child", rel.Info.Status.Notes)
+	expectNotes := notesText + "\n\n==> hello\n" + notesText + " child\n"
+	if rel.Info.Status.Notes != expectNotes {
+		t.Fatalf("Expected '%s', got '%s'", expectNotes, rel.Info.Status.Notes)
 	}
 
 	if rel.Info.Description != "Install complete" {
@@ -467,6 +477,44 @@ func TestInstallRelease_FailedHooks(t *testing.T) {
 	}
 }
 
+func TestInstallRelease_FailedPreInstallHook(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rs.clientset = fake.NewSimpleClientset(&v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "migrate-x7k2p", Namespace: "spaced", Labels: map[string]string{"job-name": "migrate"}},
+	})
+	rs.env.KubeClient = &hookLogsKubeClient{hookFailingKubeClient: newHookFailingKubeClient(), logs: "migration 3 failed\n"}
+
+	req := installRequest(withName("migrating-otter"), withChart(func(opts *chartOptions) {
+		opts.Templates = append(opts.Templates, &chart.Template{
+			Name: "templates/migrate",
+			Data: []byte("kind: Job\nmetadata:\n  name: migrate\n  annotations:\n    \"helm.sh/hook\": pre-install\n"),
+		})
+	}))
+	if _, err := rs.InstallRelease(c, req); err == nil {
+		t.Fatal("Expected failed install")
+	}
+
+	rel, err := rs.env.Releases.Last(req.Name)
+	if err != nil {
+		t.Fatalf("Expected the failed release to be recorded: %s", err)
+	}
+	if rel.Info.Status.Code != release.Status_FAILED {
+		t.Errorf("Expected FAILED release. Got %s", rel.Info.Status.Code)
+	}
+	expect := &release.HookFailure{
+		Name:  "migrate",
+		Kind:  "Job",
+		Path:  "hello/templates/migrate",
+		Event: "pre-install",
+		Error: "Failed watch",
+		Logs:  "==> migrate-x7k2p <==\nmigration 3 failed\n",
+	}
+	if !reflect.DeepEqual(rel.Info.Status.FailedHook, expect) {
+		t.Errorf("Expected failed hook %+v, got %+v", expect, rel.Info.Status.FailedHook)
+	}
+}
+
 func TestInstallRelease_ReuseName(t *testing.T) {
 	c := helm.NewContext()
 	rs := rsFixture()
@@ -548,3 +596,132 @@ func TestInstallRelease_Description(t *testing.T) {
 		t.Errorf("Expected description %q. Got %q", customDescription, desc)
 	}
 }
+
+func TestInstallRelease_Environment(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+
+	req := installRequest(withEnvironment("values-prod.yaml"))
+	res, err := rs.InstallRelease(c, req)
+	if err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+	if res.Release.Environment != "values-prod.yaml" {
+		t.Errorf("Expected the environment of the release to be values-prod.yaml, got %q", res.Release.Environment)
+	}
+	rel, err := rs.env.Releases.Get(res.Release.Name, res.Release.Version)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rel.Environment != "values-prod.yaml" {
+		t.Errorf("Expected the environment of the stored release to be values-prod.yaml, got %q", rel.Environment)
+	}
+}
+
+func TestInstallRelease_Namespaces(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+
+	req := installRequest(withChart(func(opts *chartOptions) {
+		opts.Templates = append(opts.Templates, &chart.Template{
+			Name: "templates/monitoring",
+			Data: []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: dashboards\n  namespace: monitoring\n"),
+		}, &chart.Template{
+			Name: "templates/local",
+			Data: []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: settings\n"),
+		})
+	}))
+	res, err := rs.InstallRelease(c, req)
+	if err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+	rel, err := rs.env.Releases.Get(res.Release.Name, res.Release.Version)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"monitoring", "spaced"}
+	if !reflect.DeepEqual(rel.Namespaces, expected) {
+		t.Errorf("Expected the namespaces of the stored release to be %v, got %v", expected, rel.Namespaces)
+	}
+}
+
+// createKubeClient records the manifests created, and whether they were
+// waited for.
+type createKubeClient struct {
+	environment.PrintingKubeClient
+	created []string
+	waited  []bool
+}
+
+func (k *createKubeClient) Create(ns string, r io.Reader, timeout int64, shouldWait bool) error {
+	k.created = append(k.created, readAll(r))
+	k.waited = append(k.waited, shouldWait)
+	return nil
+}
+
+func subchart(name string) *chart.Chart {
+	return &chart.Chart{
+		Metadata:  &chart.Metadata{Name: name},
+		Templates: []*chart.Template{{Name: "templates/" + name, Data: []byte("name: " + name)}},
+	}
+}
+
+func umbrellaChart(requirements string) *chart.Chart {
+	return &chart.Chart{
+		Metadata:     &chart.Metadata{Name: "umbrella"},
+		Templates:    []*chart.Template{{Name: "templates/umbrella", Data: []byte("name: umbrella")}},
+		Files:        []*any.Any{{TypeUrl: "requirements.yaml", Value: []byte(requirements)}},
+		Dependencies: []*chart.Chart{subchart("db"), subchart("cache"), subchart("web")},
+	}
+}
+
+func TestInstallRelease_DependencyNeeds(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	kubeClient := &createKubeClient{PrintingKubeClient: environment.PrintingKubeClient{Out: ioutil.Discard}}
+	rs.env.KubeClient = kubeClient
+
+	req := installRequest()
+	req.Chart = umbrellaChart(`dependencies:
+- name: db
+- name: cache
+- name: web
+  needs: [db, cache]
+`)
+	if _, err := rs.InstallRelease(c, req); err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+
+	if len(kubeClient.created) != 2 {
+		t.Fatalf("Expected 2 waves, got %d:\n%q", len(kubeClient.created), kubeClient.created)
+	}
+	for _, name := range []string{"umbrella", "db", "cache"} {
+		if !strings.Contains(kubeClient.created[0], "name: "+name) {
+			t.Errorf("Expected %s in the first wave, got\n%s", name, kubeClient.created[0])
+		}
+	}
+	if !strings.Contains(kubeClient.created[1], "name: web") || strings.Contains(kubeClient.created[1], "name: db") {
+		t.Errorf("Expected only web in the second wave, got\n%s", kubeClient.created[1])
+	}
+	if !reflect.DeepEqual(kubeClient.waited, []bool{true, false}) {
+		t.Errorf("Expected only the first wave to be waited for, got %v", kubeClient.waited)
+	}
+}
+
+func TestInstallRelease_DependencyCycle(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+
+	req := installRequest()
+	req.Chart = umbrellaChart(`dependencies:
+- name: db
+  needs: [web]
+- name: cache
+- name: web
+  needs: [db]
+`)
+	_, err := rs.InstallRelease(c, req)
+	if err == nil || !strings.Contains(err.Error(), "dependency cycle: db -> web -> db") {
+		t.Errorf("Expected a cycle error, got %v", err)
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"k8s.io/helm/pkg/proto/hapi/release"
 	"k8s.io/helm/pkg/proto/hapi/services"
 	relutil "k8s.io/helm/pkg/releaseutil"
+	"k8s.io/helm/pkg/timeconv"
 )
 
 // ListReleases lists the releases found by the server.
@@ -59,6 +60,10 @@ func (s *ReleaseServer) ListReleases(req *services.ListReleasesRequest, stream s
 		}
 	}
 
+	if filter := listFilter(req); filter != nil {
+		rels = filter.Filter(rels)
+	}
+
 	total := int64(len(rels))
 
 	switch req.SortBy {
@@ -179,3 +184,25 @@ func filterReleases(filter string, rels []*release.Release) ([]*release.Release,
 	}
 	return matches, nil
 }
+
+// listFilter returns the filter of the chart name, app version, environment
+// and deploy time filters of req, or nil if none is set.
+func listFilter(req *services.ListReleasesRequest) relutil.FilterFunc {
+	var filters []relutil.FilterFunc
+	if req.ChartName != "" {
+		filters = append(filters, relutil.ChartNameFilter(req.ChartName))
+	}
+	if req.AppVersion != "" {
+		filters = append(filters, relutil.AppVersionFilter(req.AppVersion))
+	}
+	if req.Environment != "" {
+		filters = append(filters, relutil.EnvironmentFilter(req.Environment))
+	}
+	if req.DeployedAfter != nil {
+		filters = append(filters, relutil.DeployedAfterFilter(timeconv.Time(req.DeployedAfter)))
+	}
+	if len(filters) == 0 {
+		return nil
+	}
+	return relutil.All(filters...)
+}
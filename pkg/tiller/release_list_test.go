@@ -18,11 +18,14 @@ package tiller
 
 import (
 	"fmt"
+	"reflect"
 	"testing"
+	"time"
 
 	"k8s.io/helm/pkg/proto/hapi/chart"
 	"k8s.io/helm/pkg/proto/hapi/release"
 	"k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/timeconv"
 )
 
 func TestListReleases(t *testing.T) {
@@ -233,6 +236,61 @@ func TestListReleasesFilter(t *testing.T) {
 	}
 }
 
+func TestListReleasesStructuredFilters(t *testing.T) {
+	rs := rsFixture()
+	now := time.Now()
+	stored := []struct {
+		name, chart, appVersion, environment string
+		age                                  time.Duration
+	}{
+		{"prod-db", "mariadb", "10.3", "values-prod.yaml", time.Hour},
+		{"staging-db", "mariadb", "10.4", "values-staging.yaml", time.Hour},
+		{"prod-web", "nginx", "1.17", "values-prod.yaml", 48 * time.Hour},
+	}
+	for _, s := range stored {
+		rel := releaseStub()
+		rel.Name = s.name
+		rel.Chart.Metadata.Name = s.chart
+		rel.Chart.Metadata.AppVersion = s.appVersion
+		rel.Environment = s.environment
+		rel.Info.LastDeployed = timeconv.Timestamp(now.Add(-s.age))
+		if err := rs.env.Releases.Create(rel); err != nil {
+			t.Fatalf("Could not store mock release: %s", err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		req    *services.ListReleasesRequest
+		expect []string
+	}{
+		{"chart name", &services.ListReleasesRequest{ChartName: "mariadb"}, []string{"prod-db", "staging-db"}},
+		{"app version", &services.ListReleasesRequest{AppVersion: "1.17"}, []string{"prod-web"}},
+		{"environment", &services.ListReleasesRequest{Environment: "values-prod.yaml"}, []string{"prod-db", "prod-web"}},
+		{"deployed after", &services.ListReleasesRequest{DeployedAfter: timeconv.Timestamp(now.Add(-24 * time.Hour))}, []string{"prod-db", "staging-db"}},
+		{"combined", &services.ListReleasesRequest{ChartName: "mariadb", Environment: "values-prod.yaml"}, []string{"prod-db"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mrs := &mockListServer{}
+			tt.req.SortBy = services.ListSort_NAME
+			if err := rs.ListReleases(tt.req, mrs); err != nil {
+				t.Fatalf("Failed listing: %s", err)
+			}
+			var names []string
+			for _, r := range mrs.val.Releases {
+				names = append(names, r.Name)
+			}
+			if !reflect.DeepEqual(names, tt.expect) {
+				t.Errorf("Expected %v, got %v", tt.expect, names)
+			}
+			if mrs.val.Total != int64(len(tt.expect)) {
+				t.Errorf("Expected a total of %d, got %d", len(tt.expect), mrs.val.Total)
+			}
+		})
+	}
+}
+
 func TestReleasesNamespace(t *testing.T) {
 	rs := rsFixture()
 
@@ -26,6 +26,7 @@ import (
 
 	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/kube"
+	"k8s.io/helm/pkg/proto/hapi/chart"
 	"k8s.io/helm/pkg/proto/hapi/release"
 	rudderAPI "k8s.io/helm/pkg/proto/hapi/rudder"
 	"k8s.io/helm/pkg/proto/hapi/services"
@@ -48,10 +49,72 @@ type LocalReleaseModule struct {
 	clientset kubernetes.Interface
 }
 
-// Create creates a release via kubeclient from provided environment
+// Create creates a release via kubeclient from provided environment.
+//
+// The resources of the subcharts are created in waves, following the needs
+// of the dependencies of the chart: every wave but the last is waited for, so
+// that a subchart is only created once the subcharts it needs are ready.
 func (m *LocalReleaseModule) Create(r *release.Release, req *services.InstallReleaseRequest, env *environment.Environment) error {
-	b := bytes.NewBufferString(r.Manifest)
-	return env.KubeClient.Create(r.Namespace, b, req.Timeout, req.Wait)
+	waves, err := manifestWaves(r.Chart, r.Manifest)
+	if err != nil {
+		return err
+	}
+	for i, w := range waves {
+		wait := req.Wait || i < len(waves)-1
+		if err := env.KubeClient.Create(r.Namespace, bytes.NewBufferString(w), req.Timeout, wait); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// manifestWaves splits the manifest of the chart ch in waves, following the
+// needs of its dependencies. The resources of the chart itself are in the
+// first wave.
+func manifestWaves(ch *chart.Chart, manifest string) ([]string, error) {
+	order, err := chartutil.DependencyWaves(ch)
+	if err != nil || order == nil {
+		return []string{manifest}, err
+	}
+	wave := map[string]int{}
+	for i, names := range order {
+		for _, n := range names {
+			wave[n] = i
+		}
+	}
+
+	bufs := make([]bytes.Buffer, len(order))
+	var i int
+	for _, doc := range strings.Split(manifest, "\n---\n") {
+		if strings.TrimSpace(doc) == "" {
+			continue
+		}
+		// The documents of a template follow its source comment.
+		if strings.HasPrefix(doc, "# Source: ") {
+			source := strings.SplitN(strings.TrimPrefix(doc, "# Source: "), "\n", 2)[0]
+			i = wave[subchartName(source)]
+		}
+		bufs[i].WriteString("\n---\n" + doc)
+	}
+
+	var waves []string
+	for _, b := range bufs {
+		if b.Len() > 0 {
+			waves = append(waves, b.String())
+		}
+	}
+	return waves, nil
+}
+
+// subchartName returns the name of the subchart of the chart rendering the
+// template source, like db for mychart/charts/db/templates/db.yaml, or "" for
+// the templates of the chart itself.
+func subchartName(source string) string {
+	parts := strings.SplitN(source, "/", 4)
+	if len(parts) == 4 && parts[1] == "charts" {
+		return parts[2]
+	}
+	return ""
 }
 
 // Update performs an update from current to target release
@@ -64,6 +127,7 @@ func (m *LocalReleaseModule) Update(current, target *release.Release, req *servi
 		Timeout:       req.Timeout,
 		ShouldWait:    req.Wait,
 		CleanupOnFail: req.CleanupOnFail,
+		ThreeWayMerge: req.ThreeWayMerge,
 	})
 }
 
@@ -91,7 +155,7 @@ func (m *LocalReleaseModule) Delete(rel *release.Release, req *services.Uninstal
 	if err != nil {
 		return rel.Manifest, []error{fmt.Errorf("Could not get apiVersions from Kubernetes: %v", err)}
 	}
-	return DeleteRelease(rel, vs, env.KubeClient)
+	return DeleteRelease(rel, vs, env.KubeClient, req.Cascade)
 }
 
 // RemoteReleaseModule is a ReleaseModule which calls Rudder service to operate on a release
@@ -159,7 +223,10 @@ func (m *RemoteReleaseModule) Delete(r *release.Release, req *services.Uninstall
 }
 
 // DeleteRelease is a helper that allows Rudder to delete a release without exposing most of Tiller inner functions
-func DeleteRelease(rel *release.Release, vs chartutil.VersionSet, kubeClient environment.KubeClient) (kept string, errs []error) {
+//
+// cascade is the propagation policy of the deletion of the resources, see
+// kube.DeleteOptions.
+func DeleteRelease(rel *release.Release, vs chartutil.VersionSet, kubeClient environment.KubeClient, cascade string) (kept string, errs []error) {
 	manifests := relutil.SplitManifests(rel.Manifest)
 	_, files, err := sortManifests(manifests, vs, UninstallOrder)
 	if err != nil {
@@ -181,13 +248,13 @@ func DeleteRelease(rel *release.Release, vs chartutil.VersionSet, kubeClient env
 		if b.Len() == 0 {
 			continue
 		}
-		if err := kubeClient.Delete(rel.Namespace, b); err != nil {
+		if err := kubeClient.DeleteWithOptions(rel.Namespace, b, kube.DeleteOptions{Cascade: cascade}); err != nil {
 			log.Printf("uninstall: Failed deletion of %q: %s", rel.Name, err)
 			if err == kube.ErrNoObjectsVisited {
 				// Rewrite the message from "no objects visited"
 				obj := ""
 				if file.Head != nil && file.Head.Metadata != nil {
-					obj = "[" + file.Head.Kind + "] " + file.Head.Metadata.Name
+					obj = "[" + file.Head.Kind + "] " + qualifiedName(file.Head, rel.Namespace)
 				}
 				err = fmt.Errorf("release %q: object %q not found, skipping delete", rel.Name, obj)
 			}
@@ -17,20 +17,41 @@ limitations under the License.
 package tiller
 
 import (
+	"errors"
 	"fmt"
 	"k8s.io/helm/pkg/storage"
 	"strings"
 
 	ctx "golang.org/x/net/context"
 
+	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/hooks"
+	"k8s.io/helm/pkg/proto/hapi/chart"
 	"k8s.io/helm/pkg/proto/hapi/release"
 	"k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/redact"
+	relutil "k8s.io/helm/pkg/releaseutil"
 	"k8s.io/helm/pkg/timeconv"
 )
 
 // RollbackRelease rolls back to a previous version of the given release.
 func (s *ReleaseServer) RollbackRelease(c ctx.Context, req *services.RollbackReleaseRequest) (*services.RollbackReleaseResponse, error) {
+	res, err := s.rollbackRelease(req)
+	if !req.DryRun {
+		s.auditOperation(c, "rollback", req.Name, res.GetRelease(), err)
+	}
+	return res, err
+}
+
+func (s *ReleaseServer) rollbackRelease(req *services.RollbackReleaseRequest) (*services.RollbackReleaseResponse, error) {
+	// An invalid name is reported by the preparation.
+	if !req.DryRun && validateReleaseName(req.Name) == nil {
+		unlock, err := s.lockRelease(req.Name, "rollback")
+		if err != nil {
+			return nil, err
+		}
+		defer unlock()
+	}
 	s.Log("preparing rollback of %s", req.Name)
 	currentRelease, targetRelease, err := s.prepareRollback(req)
 	if err != nil {
@@ -76,6 +97,18 @@ func (s *ReleaseServer) prepareRollback(req *services.RollbackReleaseRequest) (*
 		return nil, nil, err
 	}
 
+	if req.ValuesOnly && req.Values != nil {
+		description := req.Description
+		if description == "" {
+			description = "Rollback to the given values"
+		}
+		targetRelease, err := s.prepareValuesRollback(currentRelease, req.Values, description)
+		return currentRelease, targetRelease, err
+	}
+	if req.Values != nil {
+		return nil, nil, errors.New("values can only be given to a values only rollback")
+	}
+
 	previousVersion := req.Version
 	if req.Version == 0 {
 		previousVersion = currentRelease.Version - 1
@@ -93,12 +126,21 @@ func (s *ReleaseServer) prepareRollback(req *services.RollbackReleaseRequest) (*
 		description = fmt.Sprintf("Rollback to %d", previousVersion)
 	}
 
+	if req.ValuesOnly {
+		if req.Description == "" {
+			description = fmt.Sprintf("Rollback to the values of %d", previousVersion)
+		}
+		targetRelease, err := s.prepareValuesRollback(currentRelease, previousRelease.Config, description)
+		return currentRelease, targetRelease, err
+	}
+
 	// Store a new release object with previous release's configuration
 	targetRelease := &release.Release{
-		Name:      req.Name,
-		Namespace: currentRelease.Namespace,
-		Chart:     previousRelease.Chart,
-		Config:    previousRelease.Config,
+		Name:        req.Name,
+		Namespace:   currentRelease.Namespace,
+		Environment: previousRelease.Environment,
+		Chart:       previousRelease.Chart,
+		Config:      previousRelease.Config,
 		Info: &release.Info{
 			FirstDeployed: currentRelease.Info.FirstDeployed,
 			LastDeployed:  timeconv.Now(),
@@ -110,14 +152,68 @@ func (s *ReleaseServer) prepareRollback(req *services.RollbackReleaseRequest) (*
 			// message here, and only override it later if we experience failure.
 			Description: description,
 		},
-		Version:  currentRelease.Version + 1,
-		Manifest: previousRelease.Manifest,
-		Hooks:    previousRelease.Hooks,
+		Version:    currentRelease.Version + 1,
+		Manifest:   previousRelease.Manifest,
+		Namespaces: relutil.ManifestNamespaces(previousRelease.Manifest, currentRelease.Namespace),
+		Hooks:      previousRelease.Hooks,
 	}
 
 	return currentRelease, targetRelease, nil
 }
 
+// prepareValuesRollback prepares a new release object deploying the chart of
+// the current release with the values config.
+func (s *ReleaseServer) prepareValuesRollback(currentRelease *release.Release, config *chart.Config, description string) (*release.Release, error) {
+	revision := currentRelease.Version + 1
+	ts := timeconv.Now()
+	options := chartutil.ReleaseOptions{
+		Name:        currentRelease.Name,
+		Time:        ts,
+		Namespace:   currentRelease.Namespace,
+		IsUpgrade:   true,
+		Revision:    int(revision),
+		Environment: currentRelease.Environment,
+	}
+
+	caps, err := capabilities(s.clientset.Discovery())
+	if err != nil {
+		return nil, err
+	}
+	// The sensitive values of the chart are masked in the errors.
+	redactor := redact.New(currentRelease.Chart, config)
+	valuesToRender, err := chartutil.ToRenderValuesCaps(currentRelease.Chart, config, options, caps)
+	if err != nil {
+		return nil, redactor.Error(err)
+	}
+
+	hooks, manifestDoc, notesTxt, err := s.renderResources(currentRelease.Chart, valuesToRender, false, caps.APIVersions)
+	if err != nil {
+		return nil, redactor.Error(err)
+	}
+
+	targetRelease := &release.Release{
+		Name:        currentRelease.Name,
+		Namespace:   currentRelease.Namespace,
+		Environment: currentRelease.Environment,
+		Chart:       currentRelease.Chart,
+		Config:      config,
+		Info: &release.Info{
+			FirstDeployed: currentRelease.Info.FirstDeployed,
+			LastDeployed:  ts,
+			Status: &release.Status{
+				Code:  release.Status_PENDING_ROLLBACK,
+				Notes: notesTxt,
+			},
+			Description: description,
+		},
+		Version:    revision,
+		Manifest:   manifestDoc.String(),
+		Namespaces: relutil.ManifestNamespaces(manifestDoc.String(), currentRelease.Namespace),
+		Hooks:      hooks,
+	}
+	return targetRelease, validateManifest(s.env.KubeClient, currentRelease.Namespace, manifestDoc.Bytes())
+}
+
 func (s *ReleaseServer) performRollback(currentRelease, targetRelease *release.Release, req *services.RollbackReleaseRequest) (*services.RollbackReleaseResponse, error) {
 	res := &services.RollbackReleaseResponse{Release: targetRelease}
 
@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/chart"
 	"k8s.io/helm/pkg/proto/hapi/release"
 	"k8s.io/helm/pkg/proto/hapi/services"
 )
@@ -350,3 +351,66 @@ func TestRollbackReleaseWithCustomDescription(t *testing.T) {
 		t.Errorf("Expected Description to be %q, got %q", customDescription, res.Release.Info.Description)
 	}
 }
+
+func TestRollbackRelease_Environment(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rel := releaseStub()
+	rel.Environment = "values-staging.yaml"
+	rs.env.Releases.Create(rel)
+	upgradedRel := upgradeReleaseVersion(rel)
+	upgradedRel.Environment = "values-prod.yaml"
+	rs.env.Releases.Update(rel)
+	rs.env.Releases.Create(upgradedRel)
+
+	res, err := rs.RollbackRelease(c, &services.RollbackReleaseRequest{Name: rel.Name})
+	if err != nil {
+		t.Fatalf("Failed rollback: %s", err)
+	}
+	if res.Release.Environment != "values-staging.yaml" {
+		t.Errorf("Expected the environment of the rolled back release to be values-staging.yaml, got %q", res.Release.Environment)
+	}
+}
+
+func TestRollbackRelease_ValuesOnly(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rel := releaseStub()
+	rel.Chart = buildChart()
+	rel.Chart.Templates = append(rel.Chart.Templates, &chart.Template{Name: "templates/replicas", Data: []byte("replicas: {{ .Values.replicas }}")})
+	rel.Config = &chart.Config{Raw: "replicas: 2"}
+	rs.env.Releases.Create(rel)
+	upgradedRel := upgradeReleaseVersion(rel)
+	upgradedRel.Chart = buildChart()
+	upgradedRel.Chart.Metadata.Version = "0.2.0"
+	upgradedRel.Chart.Templates = append(upgradedRel.Chart.Templates, &chart.Template{Name: "templates/replicas", Data: []byte("replicas: {{ .Values.replicas }}\nupgraded: true")})
+	upgradedRel.Config = &chart.Config{Raw: "replicas: 0"}
+	rs.env.Releases.Update(rel)
+	rs.env.Releases.Create(upgradedRel)
+
+	res, err := rs.RollbackRelease(c, &services.RollbackReleaseRequest{Name: rel.Name, ValuesOnly: true})
+	if err != nil {
+		t.Fatalf("Failed rollback: %s", err)
+	}
+	if res.Release.Version != 3 || res.Release.Chart.Metadata.Version != "0.2.0" || res.Release.Config.Raw != "replicas: 2" {
+		t.Errorf("Expected revision 3 of chart 0.2.0 with the values of revision 1, got revision %d of chart %s with %q", res.Release.Version, res.Release.Chart.Metadata.Version, res.Release.Config.Raw)
+	}
+	if !strings.Contains(res.Release.Manifest, "replicas: 2\nupgraded: true") {
+		t.Errorf("Expected the manifest of the current chart with the values of revision 1, got %q", res.Release.Manifest)
+	}
+	if expect := "Rollback to the values of 1"; res.Release.Info.Description != expect {
+		t.Errorf("Expected description %q, got %q", expect, res.Release.Info.Description)
+	}
+
+	res, err = rs.RollbackRelease(c, &services.RollbackReleaseRequest{Name: rel.Name, ValuesOnly: true, Values: &chart.Config{Raw: "replicas: 5"}})
+	if err != nil {
+		t.Fatalf("Failed rollback: %s", err)
+	}
+	if !strings.Contains(res.Release.Manifest, "replicas: 5\nupgraded: true") {
+		t.Errorf("Expected the manifest of the current chart with the given values, got %q", res.Release.Manifest)
+	}
+
+	if _, err := rs.RollbackRelease(c, &services.RollbackReleaseRequest{Name: rel.Name, Values: &chart.Config{Raw: "replicas: 5"}}); err == nil {
+		t.Error("Expected an error for values without a values only rollback")
+	}
+}
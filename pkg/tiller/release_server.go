@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"path"
 	"regexp"
 	"strings"
@@ -108,6 +109,31 @@ func NewReleaseServer(env *environment.Environment, clientset kubernetes.Interfa
 	}
 }
 
+// The strategies combining the values of the last release with the values of
+// an update request reusing them.
+const (
+	// ReuseValuesMerge replaces the default values of the chart with the
+	// computed values of the last release, and merges the values of the
+	// request into the values of the last release. It is the default.
+	ReuseValuesMerge = "merge"
+	// ReuseValuesReplace keeps the default values of the chart, and replaces
+	// the top-level keys of the values of the last release set by the values
+	// of the request.
+	ReuseValuesReplace = "replace"
+	// ReuseValuesDeep keeps the default values of the chart, and merges the
+	// values of the request into the values of the last release.
+	ReuseValuesDeep = "deep"
+)
+
+// errReuseValuesStrategy is the error of an unknown reuse values strategy.
+func errReuseValuesStrategy(strategy string) error {
+	return fmt.Errorf("unknown reuse values strategy %q. The supported strategies, from the highest to the lowest precedence of the values, are:\n"+
+		"  %s: new values > last release values > last release computed values (the default values of the chart are ignored)\n"+
+		"  %s: new values replace the top-level keys of the last release values > default values of the chart\n"+
+		"  %s: new values > last release values > default values of the chart",
+		strategy, ReuseValuesMerge, ReuseValuesReplace, ReuseValuesDeep)
+}
+
 // reuseValues copies values from the current release to a new release if the
 // new release does not have any values.
 //
@@ -116,6 +142,9 @@ func NewReleaseServer(env *environment.Environment, clientset kubernetes.Interfa
 //
 // This is skipped if the req.ResetValues flag is set, in which case the
 // request values are not altered.
+//
+// If the req.ReuseValues flag is set, the values of the current release are
+// combined with the request values according to req.ReuseValuesStrategy.
 func (s *ReleaseServer) reuseValues(req *services.UpdateReleaseRequest, current *release.Release) error {
 	if req.ResetValues {
 		// If ResetValues is set, we completely ignore current.Config.
@@ -125,20 +154,31 @@ func (s *ReleaseServer) reuseValues(req *services.UpdateReleaseRequest, current
 
 	// If the ReuseValues flag is set, we always copy the old values over the new config's values.
 	if req.ReuseValues {
-		s.Log("reusing the old release's values")
-
-		// We have to regenerate the old coalesced values:
-		oldVals, err := chartutil.CoalesceValues(current.Chart, current.Config)
-		if err != nil {
-			err := fmt.Errorf("failed to rebuild old values: %s", err)
-			s.Log("%s", err)
-			return err
+		strategy := req.ReuseValuesStrategy
+		if strategy == "" {
+			strategy = ReuseValuesMerge
 		}
-		nv, err := oldVals.YAML()
-		if err != nil {
-			return err
+		switch strategy {
+		case ReuseValuesMerge, ReuseValuesReplace, ReuseValuesDeep:
+		default:
+			return errReuseValuesStrategy(strategy)
+		}
+		s.Log("reusing the old release's values with the %s strategy", strategy)
+
+		if strategy == ReuseValuesMerge {
+			// We have to regenerate the old coalesced values:
+			oldVals, err := chartutil.CoalesceValues(current.Chart, current.Config)
+			if err != nil {
+				err := fmt.Errorf("failed to rebuild old values: %s", err)
+				s.Log("%s", err)
+				return err
+			}
+			nv, err := oldVals.YAML()
+			if err != nil {
+				return err
+			}
+			req.Chart.Values = &chart.Config{Raw: nv}
 		}
-		req.Chart.Values = &chart.Config{Raw: nv}
 
 		reqValues, err := chartutil.ReadValues([]byte(req.Values.Raw))
 		if err != nil {
@@ -153,7 +193,13 @@ func (s *ReleaseServer) reuseValues(req *services.UpdateReleaseRequest, current
 			}
 		}
 
-		currentConfig.MergeInto(reqValues)
+		if strategy == ReuseValuesReplace {
+			for k, v := range reqValues {
+				currentConfig[k] = v
+			}
+		} else {
+			currentConfig.MergeInto(reqValues)
+		}
 		data, err := currentConfig.YAML()
 		if err != nil {
 			return err
@@ -342,12 +388,15 @@ func (s *ReleaseServer) renderResources(ch *chart.Chart, values chartutil.Values
 		return nil, nil, "", fmt.Errorf("Chart incompatible with Tiller %s", sver)
 	}
 
-	if ch.Metadata.KubeVersion != "" {
-		cap, _ := values["Capabilities"].(*chartutil.Capabilities)
+	if chartutil.IsLibraryChart(ch) {
+		return nil, nil, "", fmt.Errorf("library chart %s is not installable", ch.Metadata.Name)
+	}
+
+	if cap, ok := values["Capabilities"].(*chartutil.Capabilities); ok {
 		gitVersion := cap.KubeVersion.String()
 		k8sVersion := strings.Split(gitVersion, "+")[0]
-		if !version.IsCompatibleRange(ch.Metadata.KubeVersion, k8sVersion) {
-			return nil, nil, "", fmt.Errorf("Chart requires kubernetesVersion: %s which is incompatible with Kubernetes %s", ch.Metadata.KubeVersion, k8sVersion)
+		if err := chartutil.CheckKubeVersion(ch, k8sVersion); err != nil {
+			return nil, nil, "", err
 		}
 	}
 
@@ -362,24 +411,15 @@ func (s *ReleaseServer) renderResources(ch *chart.Chart, values chartutil.Values
 	// pull it out of here into a separate file so that we can actually use the output of the rendered
 	// text file. We have to spin through this map because the file contains path information, so we
 	// look for terminating NOTES.txt. We also remove it from the files so that we don't have to skip
-	// it in the sortHooks.
-	var notesBuffer bytes.Buffer
-	for k, v := range files {
+	// it in the sortHooks. With subNotes, the notes of the subcharts follow the notes of the chart,
+	// each under a header naming the subchart.
+	notes := chartutil.JoinNotes(ch.Metadata.Name, files, subNotes)
+	for k := range files {
 		if strings.HasSuffix(k, notesFileSuffix) {
-			if subNotes || (k == path.Join(ch.Metadata.Name, "templates", notesFileSuffix)) {
-
-				// If buffer contains data, add newline before adding more
-				if notesBuffer.Len() > 0 {
-					notesBuffer.WriteString("\n")
-				}
-				notesBuffer.WriteString(v)
-			}
 			delete(files, k)
 		}
 	}
 
-	notes := notesBuffer.String()
-
 	// Sort hooks, manifests, and partials. Only hooks and manifests are returned,
 	// as partials are not used after renderer.Render. Empty manifests are also
 	// removed here.
@@ -459,12 +499,21 @@ func (s *ReleaseServer) execHook(hs []*release.Hook, name, namespace, hook strin
 		if hook != hooks.CRDInstall {
 			if err := kubeCli.WatchUntilReady(namespace, b, timeout, false); err != nil {
 				s.Log("warning: Release %s %s %s could not complete: %s", name, hook, h.Path, err)
+				// The logs are collected before the hook-failed policy may delete the pods.
+				failure := &release.HookFailure{
+					Name:  h.Name,
+					Kind:  h.Kind,
+					Path:  h.Path,
+					Event: hook,
+					Error: err.Error(),
+					Logs:  s.hookLogs(h, namespace),
+				}
 				// If a hook is failed, checkout the annotation of the hook to determine whether the hook should be deleted
 				// under failed condition. If so, then clear the corresponding resource object in the hook
 				if err := s.deleteHookByPolicy(h, hooks.HookFailed, name, namespace, hook, kubeCli); err != nil {
 					return err
 				}
-				return err
+				return &hookError{failure: failure, err: err}
 			}
 		} else {
 			if err := kubeCli.WaitUntilCRDEstablished(b, time.Duration(timeout)*time.Second); err != nil {
@@ -487,6 +536,76 @@ func (s *ReleaseServer) execHook(hs []*release.Hook, name, namespace, hook strin
 	return nil
 }
 
+// hookLogsMaxLen is the number of bytes kept of the logs of a failed hook.
+const hookLogsMaxLen = 4096
+
+// hookError is the error of a hook which could not complete.
+type hookError struct {
+	failure *release.HookFailure
+	err     error
+}
+
+func (e *hookError) Error() string {
+	return e.err.Error()
+}
+
+// failedHook returns the failure of the hook which caused err, or nil if err
+// is not the error of a hook.
+func failedHook(err error) *release.HookFailure {
+	if e, ok := err.(*hookError); ok {
+		return e.failure
+	}
+	return nil
+}
+
+// hookLogs returns the end of the logs of the pods of a Pod or Job hook. The
+// logs are best effort: the pods whose logs cannot be read are skipped.
+func (s *ReleaseServer) hookLogs(h *release.Hook, namespace string) string {
+	var pods []string
+	switch h.Kind {
+	case "Pod":
+		pods = []string{h.Name}
+	case "Job":
+		list, err := s.clientset.CoreV1().Pods(namespace).List(metav1.ListOptions{LabelSelector: "job-name=" + h.Name})
+		if err != nil {
+			s.Log("warning: cannot list the pods of hook %s: %s", h.Name, err)
+			return ""
+		}
+		for _, p := range list.Items {
+			pods = append(pods, p.Name)
+		}
+	default:
+		return ""
+	}
+
+	var b bytes.Buffer
+	for _, pod := range pods {
+		r, err := s.env.KubeClient.GetPodLogs(pod, namespace)
+		if err != nil || r == nil {
+			s.Log("warning: cannot get the logs of pod %s of hook %s: %v", pod, h.Name, err)
+			continue
+		}
+		logs, err := ioutil.ReadAll(r)
+		r.Close()
+		if err != nil {
+			s.Log("warning: cannot read the logs of pod %s of hook %s: %s", pod, h.Name, err)
+			continue
+		}
+		fmt.Fprintf(&b, "==> %s <==\n%s", pod, logs)
+		if len(logs) > 0 && logs[len(logs)-1] != '\n' {
+			b.WriteByte('\n')
+		}
+	}
+	logs := b.String()
+	if len(logs) > hookLogsMaxLen {
+		logs = logs[len(logs)-hookLogsMaxLen:]
+		if i := strings.IndexByte(logs, '\n'); i >= 0 {
+			logs = logs[i+1:]
+		}
+	}
+	return logs
+}
+
 func validateManifest(c environment.KubeClient, ns string, manifest []byte) error {
 	r := bytes.NewReader(manifest)
 	return c.Validate(ns, r)
@@ -504,6 +623,20 @@ func validateReleaseName(releaseName string) error {
 	return nil
 }
 
+// lockRelease locks the release for the operation, and returns the function
+// unlocking it. Nothing is locked if the environment has no Locker.
+func (s *ReleaseServer) lockRelease(name, operation string) (func(), error) {
+	if s.env.Locks == nil {
+		return func() {}, nil
+	}
+	unlock, err := s.env.Locks.Lock(name, operation)
+	if err != nil {
+		s.Log("%s: %s", operation, err)
+		return nil, err
+	}
+	return unlock, nil
+}
+
 func (s *ReleaseServer) deleteHookByPolicy(h *release.Hook, policy string, name, namespace, hook string, kubeCli environment.KubeClient) error {
 	b := bytes.NewBufferString(h.Manifest)
 	if hookHasDeletePolicy(h, policy) {
@@ -23,6 +23,7 @@ import (
 	"io/ioutil"
 	"os"
 	"regexp"
+	"strings"
 	"testing"
 	"time"
 
@@ -236,6 +237,12 @@ func withSubNotes() installOption {
 	}
 }
 
+func withEnvironment(environment string) installOption {
+	return func(opts *installOptions) {
+		opts.Environment = environment
+	}
+}
+
 func installRequest(opts ...installOption) *services.InstallReleaseRequest {
 	reqOpts := &installOptions{
 		&services.InstallReleaseRequest{
@@ -540,6 +547,15 @@ func (h *hookFailingKubeClient) WatchUntilReady(ns string, r io.Reader, timeout
 	return errors.New("Failed watch")
 }
 
+type hookLogsKubeClient struct {
+	*hookFailingKubeClient
+	logs string
+}
+
+func (h *hookLogsKubeClient) GetPodLogs(name, ns string) (io.ReadCloser, error) {
+	return ioutil.NopCloser(strings.NewReader(h.logs)), nil
+}
+
 func newDeleteFailingKubeClient() *deleteFailingKubeClient {
 	return &deleteFailingKubeClient{
 		PrintingKubeClient: environment.PrintingKubeClient{Out: ioutil.Discard},
@@ -558,6 +574,10 @@ func (d *deleteFailingKubeClient) DeleteWithTimeout(ns string, r io.Reader, time
 	return kube.ErrNoObjectsVisited
 }
 
+func (d *deleteFailingKubeClient) DeleteWithOptions(ns string, r io.Reader, opts kube.DeleteOptions) error {
+	return kube.ErrNoObjectsVisited
+}
+
 type mockListServer struct {
 	val *services.ListReleasesResponse
 }
@@ -644,6 +664,9 @@ func (kc *mockHooksKubeClient) DeleteWithTimeout(ns string, r io.Reader, timeout
 
 	return nil
 }
+func (kc *mockHooksKubeClient) DeleteWithOptions(ns string, r io.Reader, opts kube.DeleteOptions) error {
+	return kc.DeleteWithTimeout(ns, r, opts.Timeout, opts.ShouldWait)
+}
 func (kc *mockHooksKubeClient) WatchUntilReady(ns string, r io.Reader, timeout int64, shouldWait bool) error {
 	paramManifest, err := kc.makeManifest(r)
 	if err != nil {
@@ -687,6 +710,10 @@ func (kc *mockHooksKubeClient) WaitUntilCRDEstablished(reader io.Reader, timeout
 	return nil
 }
 
+func (kc *mockHooksKubeClient) Statuses(ns string, reader io.Reader) ([]kube.ResourceStatus, error) {
+	return []kube.ResourceStatus{}, nil
+}
+
 func deletePolicyStub(kubeClient *mockHooksKubeClient) *ReleaseServer {
 	e := environment.New()
 	e.Releases = storage.Init(driver.NewMemory())
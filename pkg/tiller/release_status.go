@@ -17,11 +17,13 @@ limitations under the License.
 package tiller
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 
 	ctx "golang.org/x/net/context"
 
+	"k8s.io/helm/pkg/kube"
 	"k8s.io/helm/pkg/proto/hapi/release"
 	"k8s.io/helm/pkg/proto/hapi/services"
 )
@@ -57,9 +59,10 @@ func (s *ReleaseServer) GetReleaseStatus(c ctx.Context, req *services.GetRelease
 
 	sc := rel.Info.Status.Code
 	statusResp := &services.GetReleaseStatusResponse{
-		Name:      rel.Name,
-		Namespace: rel.Namespace,
-		Info:      rel.Info,
+		Name:        rel.Name,
+		Namespace:   rel.Namespace,
+		Environment: rel.Environment,
+		Info:        rel.Info,
 	}
 
 	// Ok, we got the status of the release as we had jotted down, now we need to match the
@@ -73,5 +76,32 @@ func (s *ReleaseServer) GetReleaseStatus(c ctx.Context, req *services.GetRelease
 		return nil, err
 	}
 	rel.Info.Status.Resources = resp
+
+	if req.Inventory {
+		statuses, err := s.env.KubeClient.Statuses(rel.Namespace, bytes.NewBufferString(rel.Manifest))
+		if err != nil {
+			s.Log("warning: Get of the inventory of %s failed: %v", rel.Name, err)
+			return nil, err
+		}
+		statusResp.Inventory = resourceStatuses(statuses)
+	}
 	return statusResp, nil
 }
+
+// resourceStatuses converts the statuses of the kube client to the statuses
+// of the response.
+func resourceStatuses(statuses []kube.ResourceStatus) []*services.ResourceStatus {
+	inventory := make([]*services.ResourceStatus, 0, len(statuses))
+	for _, st := range statuses {
+		inventory = append(inventory, &services.ResourceStatus{
+			ApiVersion: st.APIVersion,
+			Kind:       st.Kind,
+			Name:       st.Name,
+			Namespace:  st.Namespace,
+			Exists:     st.Exists,
+			Ready:      st.Ready,
+			Reason:     st.Reason,
+		})
+	}
+	return inventory
+}
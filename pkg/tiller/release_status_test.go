@@ -17,11 +17,16 @@ limitations under the License.
 package tiller
 
 import (
+	"io"
+	"io/ioutil"
+	"reflect"
 	"testing"
 
 	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/kube"
 	"k8s.io/helm/pkg/proto/hapi/release"
 	"k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/tiller/environment"
 )
 
 func TestGetReleaseStatus(t *testing.T) {
@@ -63,3 +68,57 @@ func TestGetReleaseStatusDeleted(t *testing.T) {
 		t.Errorf("Expected %d, got %d", release.Status_DELETED, res.Info.Status.Code)
 	}
 }
+
+// inventoryKubeClient returns statuses for the resources, and records the
+// manifest it gets them for.
+type inventoryKubeClient struct {
+	environment.PrintingKubeClient
+	statuses []kube.ResourceStatus
+	manifest string
+}
+
+func (k *inventoryKubeClient) Statuses(ns string, r io.Reader) ([]kube.ResourceStatus, error) {
+	b, err := ioutil.ReadAll(r)
+	k.manifest = string(b)
+	return k.statuses, err
+}
+
+func TestGetReleaseStatusInventory(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	kubeClient := &inventoryKubeClient{
+		PrintingKubeClient: environment.PrintingKubeClient{Out: ioutil.Discard},
+		statuses: []kube.ResourceStatus{
+			{APIVersion: "v1", Kind: "Pod", Name: "otter", Namespace: "default", Exists: true, Ready: true},
+			{APIVersion: "apps/v1", Kind: "Deployment", Name: "squid", Namespace: "default", Exists: true, Reason: "0 of 1 replicas are ready"},
+		},
+	}
+	rs.env.KubeClient = kubeClient
+	rel := releaseStub()
+	if err := rs.env.Releases.Create(rel); err != nil {
+		t.Fatalf("Could not store mock release: %s", err)
+	}
+
+	res, err := rs.GetReleaseStatus(c, &services.GetReleaseStatusRequest{Name: rel.Name})
+	if err != nil {
+		t.Fatalf("Error getting release status: %s", err)
+	}
+	if res.Inventory != nil {
+		t.Errorf("Expected no inventory unless requested, got %v", res.Inventory)
+	}
+
+	res, err = rs.GetReleaseStatus(c, &services.GetReleaseStatusRequest{Name: rel.Name, Inventory: true})
+	if err != nil {
+		t.Fatalf("Error getting release status: %s", err)
+	}
+	expect := []*services.ResourceStatus{
+		{ApiVersion: "v1", Kind: "Pod", Name: "otter", Namespace: "default", Exists: true, Ready: true},
+		{ApiVersion: "apps/v1", Kind: "Deployment", Name: "squid", Namespace: "default", Exists: true, Reason: "0 of 1 replicas are ready"},
+	}
+	if !reflect.DeepEqual(res.Inventory, expect) {
+		t.Errorf("Expected inventory %v, got %v", expect, res.Inventory)
+	}
+	if kubeClient.manifest != rel.Manifest {
+		t.Errorf("Expected the inventory of the manifest of the release, got %q", kubeClient.manifest)
+	}
+}
@@ -23,6 +23,7 @@ import (
 	ctx "golang.org/x/net/context"
 
 	"k8s.io/helm/pkg/hooks"
+	"k8s.io/helm/pkg/kube"
 	"k8s.io/helm/pkg/proto/hapi/release"
 	"k8s.io/helm/pkg/proto/hapi/services"
 	relutil "k8s.io/helm/pkg/releaseutil"
@@ -31,10 +32,24 @@ import (
 
 // UninstallRelease deletes all of the resources associated with this release, and marks the release DELETED.
 func (s *ReleaseServer) UninstallRelease(c ctx.Context, req *services.UninstallReleaseRequest) (*services.UninstallReleaseResponse, error) {
+	res, err := s.uninstallRelease(req)
+	s.auditOperation(c, "delete", req.Name, res.GetRelease(), err)
+	return res, err
+}
+
+func (s *ReleaseServer) uninstallRelease(req *services.UninstallReleaseRequest) (*services.UninstallReleaseResponse, error) {
 	if err := validateReleaseName(req.Name); err != nil {
 		s.Log("uninstallRelease: Release name is invalid: %s", req.Name)
 		return nil, err
 	}
+	if _, err := kube.PropagationPolicy(req.Cascade); err != nil {
+		return nil, err
+	}
+	unlock, err := s.lockRelease(req.Name, "delete")
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
 
 	rels, err := s.env.Releases.History(req.Name)
 	if err != nil {
@@ -83,6 +98,8 @@ func (s *ReleaseServer) UninstallRelease(c ctx.Context, req *services.UninstallR
 
 	kept, errs := s.ReleaseModule.Delete(rel, req, s.env)
 	res.Info = kept
+	// The status of the deleted release lists the resources that were kept.
+	rel.Info.Status.Resources = kept
 
 	es := make([]string, 0, len(errs))
 	for _, e := range errs {
@@ -17,12 +17,16 @@ limitations under the License.
 package tiller
 
 import (
+	"io"
+	"io/ioutil"
 	"strings"
 	"testing"
 
 	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/kube"
 	"k8s.io/helm/pkg/proto/hapi/release"
 	"k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/tiller/environment"
 )
 
 func TestUninstallRelease(t *testing.T) {
@@ -157,6 +161,71 @@ func TestUninstallReleaseWithKeepPolicy(t *testing.T) {
 			t.Errorf("unexpected output: %s", res.Info)
 		}
 	}
+
+	rel, err := rs.env.Releases.Get(name, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rel.Info.Status.Resources != res.Info {
+		t.Errorf("Expected the status of the deleted release to list the kept resources, got %q", rel.Info.Status.Resources)
+	}
+}
+
+type cascadeKubeClient struct {
+	environment.PrintingKubeClient
+	cascades []string
+}
+
+func (k *cascadeKubeClient) DeleteWithOptions(ns string, r io.Reader, opts kube.DeleteOptions) error {
+	k.cascades = append(k.cascades, opts.Cascade)
+	return nil
+}
+
+func TestUninstallReleaseCascade(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	kubeClient := &cascadeKubeClient{PrintingKubeClient: environment.PrintingKubeClient{Out: ioutil.Discard}}
+	rs.env.KubeClient = kubeClient
+	rel := releaseStub()
+	rel.Manifest = "kind: ConfigMap\nmetadata:\n  name: configmap-foo\n"
+	rs.env.Releases.Create(rel)
+
+	req := &services.UninstallReleaseRequest{
+		Name:    "angry-panda",
+		Cascade: "orphan",
+	}
+	if _, err := rs.UninstallRelease(c, req); err != nil {
+		t.Fatalf("Failed uninstall: %s", err)
+	}
+	if len(kubeClient.cascades) == 0 {
+		t.Fatal("Expected the resources of the release to be deleted")
+	}
+	for _, cascade := range kubeClient.cascades {
+		if cascade != "orphan" {
+			t.Errorf("Expected the resources to be deleted with the orphan cascade, got %q", cascade)
+		}
+	}
+}
+
+func TestUninstallReleaseInvalidCascade(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rs.env.Releases.Create(releaseStub())
+
+	req := &services.UninstallReleaseRequest{
+		Name:    "angry-panda",
+		Cascade: "sideways",
+	}
+	if _, err := rs.UninstallRelease(c, req); err == nil {
+		t.Fatal("Expected an error for an invalid cascade")
+	}
+	rel, err := rs.env.Releases.Get("angry-panda", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rel.Info.Status.Code != release.Status_DEPLOYED {
+		t.Errorf("Expected the release to be left deployed, got %s", rel.Info.Status.Code)
+	}
 }
 
 func TestUninstallReleaseNoHooks(t *testing.T) {
@@ -26,15 +26,32 @@ import (
 	"k8s.io/helm/pkg/hooks"
 	"k8s.io/helm/pkg/proto/hapi/release"
 	"k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/redact"
+	relutil "k8s.io/helm/pkg/releaseutil"
 	"k8s.io/helm/pkg/timeconv"
 )
 
 // UpdateRelease takes an existing release and new information, and upgrades the release.
 func (s *ReleaseServer) UpdateRelease(c ctx.Context, req *services.UpdateReleaseRequest) (*services.UpdateReleaseResponse, error) {
+	res, err := s.updateRelease(req)
+	if !req.DryRun {
+		s.auditOperation(c, "upgrade", req.Name, res.GetRelease(), err)
+	}
+	return res, err
+}
+
+func (s *ReleaseServer) updateRelease(req *services.UpdateReleaseRequest) (*services.UpdateReleaseResponse, error) {
 	if err := validateReleaseName(req.Name); err != nil {
 		s.Log("updateRelease: Release name is invalid: %s", req.Name)
 		return nil, err
 	}
+	if !req.DryRun {
+		unlock, err := s.lockRelease(req.Name, "upgrade")
+		if err != nil {
+			return nil, err
+		}
+		defer unlock()
+	}
 	s.Log("preparing update for %s", req.Name)
 	currentRelease, updatedRelease, err := s.prepareUpdate(req)
 	if err != nil {
@@ -65,11 +82,30 @@ func (s *ReleaseServer) UpdateRelease(c ctx.Context, req *services.UpdateRelease
 		if err := s.env.Releases.Update(updatedRelease); err != nil {
 			return res, err
 		}
+
+		if err := s.env.Releases.PruneHistory(req.Name); err != nil {
+			s.Log("failed to prune history of %s: %s", req.Name, err)
+		}
 	}
 
 	return res, nil
 }
 
+// recordFailedHook records the release as failed if err is the error of a
+// hook, so that its status tells why.
+func (s *ReleaseServer) recordFailedHook(r *release.Release, hook string, err error) {
+	failure := failedHook(err)
+	if failure == nil {
+		return
+	}
+	msg := fmt.Sprintf("Upgrade %q failed %s: %s", r.Name, hook, err)
+	s.Log("warning: %s", msg)
+	r.Info.Status.Code = release.Status_FAILED
+	r.Info.Status.FailedHook = failure
+	r.Info.Description = msg
+	s.recordRelease(r, true)
+}
+
 // prepareUpdate builds an updated release for an update operation.
 func (s *ReleaseServer) prepareUpdate(req *services.UpdateReleaseRequest) (*release.Release, *release.Release, error) {
 	if req.Chart == nil {
@@ -99,42 +135,47 @@ func (s *ReleaseServer) prepareUpdate(req *services.UpdateReleaseRequest) (*rele
 
 	ts := timeconv.Now()
 	options := chartutil.ReleaseOptions{
-		Name:      req.Name,
-		Time:      ts,
-		Namespace: currentRelease.Namespace,
-		IsUpgrade: true,
-		Revision:  int(revision),
+		Name:        req.Name,
+		Time:        ts,
+		Namespace:   currentRelease.Namespace,
+		IsUpgrade:   true,
+		Revision:    int(revision),
+		Environment: req.Environment,
 	}
 
 	caps, err := capabilities(s.clientset.Discovery())
 	if err != nil {
 		return nil, nil, err
 	}
+	// The sensitive values of the chart are masked in the errors.
+	redactor := redact.New(req.Chart, req.Values)
 	valuesToRender, err := chartutil.ToRenderValuesCaps(req.Chart, req.Values, options, caps)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, redactor.Error(err)
 	}
 
 	hooks, manifestDoc, notesTxt, err := s.renderResources(req.Chart, valuesToRender, req.SubNotes, caps.APIVersions)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, redactor.Error(err)
 	}
 
 	// Store an updated release.
 	updatedRelease := &release.Release{
-		Name:      req.Name,
-		Namespace: currentRelease.Namespace,
-		Chart:     req.Chart,
-		Config:    req.Values,
+		Name:        req.Name,
+		Namespace:   currentRelease.Namespace,
+		Environment: req.Environment,
+		Chart:       req.Chart,
+		Config:      req.Values,
 		Info: &release.Info{
 			FirstDeployed: currentRelease.Info.FirstDeployed,
 			LastDeployed:  ts,
 			Status:        &release.Status{Code: release.Status_PENDING_UPGRADE},
 			Description:   "Preparing upgrade", // This should be overwritten later.
 		},
-		Version:  revision,
-		Manifest: manifestDoc.String(),
-		Hooks:    hooks,
+		Version:    revision,
+		Manifest:   manifestDoc.String(),
+		Namespaces: relutil.ManifestNamespaces(manifestDoc.String(), currentRelease.Namespace),
+		Hooks:      hooks,
 	}
 
 	if len(notesTxt) > 0 {
@@ -161,6 +202,7 @@ func (s *ReleaseServer) performUpdateForce(req *services.UpdateReleaseRequest) (
 		Name:         req.Name,
 		DisableHooks: req.DisableHooks,
 		Namespace:    oldRelease.Namespace,
+		Environment:  req.Environment,
 		ReuseName:    true,
 		Timeout:      req.Timeout,
 		Wait:         req.Wait,
@@ -224,6 +266,12 @@ func (s *ReleaseServer) performUpdateForce(req *services.UpdateReleaseRequest) (
 		}
 	}
 
+	if req.ForceCrds {
+		if err := s.installCRDs(newRelease.Chart, oldRelease.Chart, newRelease.Namespace, req.Timeout); err != nil {
+			return res, err
+		}
+	}
+
 	// pre-install hooks
 	if !req.DisableHooks {
 		if err := s.execHook(newRelease.Hooks, newRelease.Name, newRelease.Namespace, hooks.PreInstall, req.Timeout); err != nil {
@@ -247,6 +295,7 @@ func (s *ReleaseServer) performUpdateForce(req *services.UpdateReleaseRequest) (
 			msg := fmt.Sprintf("Release %q failed post-install: %s", newRelease.Name, err)
 			s.Log("warning: %s", msg)
 			newRelease.Info.Status.Code = release.Status_FAILED
+			newRelease.Info.Status.FailedHook = failedHook(err)
 			newRelease.Info.Description = msg
 			s.recordRelease(newRelease, true)
 			return res, err
@@ -273,9 +322,18 @@ func (s *ReleaseServer) performUpdate(originalRelease, updatedRelease *release.R
 		return res, nil
 	}
 
+	// The CRDs of the crds/ directories are only created on install, unless
+	// they are forced.
+	if req.ForceCrds {
+		if err := s.installCRDs(updatedRelease.Chart, originalRelease.Chart, updatedRelease.Namespace, req.Timeout); err != nil {
+			return res, err
+		}
+	}
+
 	// pre-upgrade hooks
 	if !req.DisableHooks {
 		if err := s.execHook(updatedRelease.Hooks, updatedRelease.Name, updatedRelease.Namespace, hooks.PreUpgrade, req.Timeout); err != nil {
+			s.recordFailedHook(updatedRelease, "pre-upgrade", err)
 			return res, err
 		}
 	} else {
@@ -294,6 +352,7 @@ func (s *ReleaseServer) performUpdate(originalRelease, updatedRelease *release.R
 	// post-upgrade hooks
 	if !req.DisableHooks {
 		if err := s.execHook(updatedRelease.Hooks, updatedRelease.Name, updatedRelease.Namespace, hooks.PostUpgrade, req.Timeout); err != nil {
+			s.recordFailedHook(updatedRelease, "post-upgrade", err)
 			return res, err
 		}
 	}
@@ -18,6 +18,8 @@ package tiller
 
 import (
 	"fmt"
+	"io"
+	"io/ioutil"
 	"reflect"
 	"strings"
 	"testing"
@@ -26,9 +28,12 @@ import (
 
 	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/kube"
 	"k8s.io/helm/pkg/proto/hapi/chart"
 	"k8s.io/helm/pkg/proto/hapi/release"
 	"k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/storage/lock"
+	"k8s.io/helm/pkg/tiller/environment"
 )
 
 func TestUpdateRelease(t *testing.T) {
@@ -104,6 +109,41 @@ func TestUpdateRelease(t *testing.T) {
 		t.Errorf("Expected description %q, got %q", edesc, got)
 	}
 }
+func TestUpdateReleaseLocked(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rel := releaseStub()
+	rs.env.Releases.Create(rel)
+
+	unlock, err := rs.env.Locks.Lock(rel.Name, "rollback")
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := &services.UpdateReleaseRequest{
+		Name:  rel.Name,
+		Chart: rel.GetChart(),
+	}
+	_, err = rs.UpdateRelease(c, req)
+	if !lock.IsLocked(err) {
+		t.Fatalf("Expected a locked release error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "locked by operation rollback") {
+		t.Errorf("Expected the error to report the rollback, got %s", err)
+	}
+	if _, err := rs.env.Releases.Get(rel.Name, 2); err == nil {
+		t.Error("Expected the locked release not to be updated")
+	}
+
+	unlock()
+	if _, err := rs.UpdateRelease(c, req); err != nil {
+		t.Fatalf("Failed updated: %s", err)
+	}
+	// The update released its lock.
+	if _, err := rs.env.Locks.Lock(rel.Name, "rollback"); err != nil {
+		t.Errorf("Expected the release to be unlocked, got %s", err)
+	}
+}
+
 func TestUpdateRelease_ResetValues(t *testing.T) {
 	c := helm.NewContext()
 	rs := rsFixture()
@@ -373,6 +413,80 @@ func TestUpdateRelease_ReuseValues(t *testing.T) {
 	compareStoredAndReturnedRelease(t, *rs, *res)
 }
 
+func TestUpdateRelease_ReuseValuesStrategy(t *testing.T) {
+	tests := []struct {
+		strategy    string
+		chartValues string
+		config      string
+		expectErr   string
+	}{
+		{
+			strategy:    "",
+			chartValues: "image:\n  repository: nginx\n  tag: \"1.0\"\nname: value\n",
+			config:      "image:\n  repository: nginx\n  tag: \"1.1\"\nname: value\n",
+		},
+		{
+			strategy:    ReuseValuesMerge,
+			chartValues: "image:\n  repository: nginx\n  tag: \"1.0\"\nname: value\n",
+			config:      "image:\n  repository: nginx\n  tag: \"1.1\"\nname: value\n",
+		},
+		{
+			strategy:    ReuseValuesDeep,
+			chartValues: "foo: bar\n",
+			config:      "image:\n  repository: nginx\n  tag: \"1.1\"\nname: value\n",
+		},
+		{
+			strategy:    ReuseValuesReplace,
+			chartValues: "foo: bar\n",
+			config:      "image:\n  tag: \"1.1\"\nname: value\n",
+		},
+		{
+			strategy:  "shallow",
+			expectErr: `unknown reuse values strategy "shallow"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.strategy, func(t *testing.T) {
+			c := helm.NewContext()
+			rs := rsFixture()
+			rel := releaseStub()
+			rel.Config = &chart.Config{Raw: "name: value\nimage: {repository: nginx, tag: \"1.0\"}\n"}
+			rs.env.Releases.Create(rel)
+
+			req := &services.UpdateReleaseRequest{
+				Name: rel.Name,
+				Chart: &chart.Chart{
+					Metadata: &chart.Metadata{Name: "hello"},
+					Templates: []*chart.Template{
+						{Name: "templates/hello", Data: []byte("hello: world")},
+					},
+					Values: &chart.Config{Raw: "foo: bar\n"},
+				},
+				Values:              &chart.Config{Raw: "image: {tag: \"1.1\"}\n"},
+				ReuseValues:         true,
+				ReuseValuesStrategy: tt.strategy,
+			}
+			res, err := rs.UpdateRelease(c, req)
+			if tt.expectErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectErr) {
+					t.Fatalf("Expected error %q, got %v", tt.expectErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Failed updated: %s", err)
+			}
+			if res.Release.Chart.Values.Raw != tt.chartValues {
+				t.Errorf("Expected chart values to be %q, got %q", tt.chartValues, res.Release.Chart.Values.Raw)
+			}
+			if res.Release.Config.Raw != tt.config {
+				t.Errorf("Expected request config to be %q, got %q", tt.config, res.Release.Config.Raw)
+			}
+		})
+	}
+}
+
 func TestUpdateRelease_ResetReuseValues(t *testing.T) {
 	// This verifies that when both reset and reuse are set, reset wins.
 	c := helm.NewContext()
@@ -623,3 +737,59 @@ func compareStoredAndReturnedRelease(t *testing.T, rs ReleaseServer, res service
 
 	return storedRelease
 }
+
+func TestUpdateRelease_Environment(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rel := releaseStub()
+	rel.Environment = "values-staging.yaml"
+	rs.env.Releases.Create(rel)
+
+	req := &services.UpdateReleaseRequest{
+		Name:        rel.Name,
+		Chart:       chartStub(),
+		Environment: "values-prod.yaml",
+	}
+	res, err := rs.UpdateRelease(c, req)
+	if err != nil {
+		t.Fatalf("Failed updated: %s", err)
+	}
+	if res.Release.Environment != "values-prod.yaml" {
+		t.Errorf("Expected the environment of the release to be values-prod.yaml, got %q", res.Release.Environment)
+	}
+	compareStoredAndReturnedRelease(t, *rs, *res)
+}
+
+// updateOptionsKubeClient records the options of the updates.
+type updateOptionsKubeClient struct {
+	environment.PrintingKubeClient
+	opts []kube.UpdateOptions
+}
+
+func (k *updateOptionsKubeClient) UpdateWithOptions(ns string, originalReader, modifiedReader io.Reader, opts kube.UpdateOptions) error {
+	k.opts = append(k.opts, opts)
+	return nil
+}
+
+func TestUpdateRelease_ThreeWayMerge(t *testing.T) {
+	for _, threeWayMerge := range []bool{false, true} {
+		c := helm.NewContext()
+		rs := rsFixture()
+		kubeClient := &updateOptionsKubeClient{PrintingKubeClient: environment.PrintingKubeClient{Out: ioutil.Discard}}
+		rs.env.KubeClient = kubeClient
+		rel := releaseStub()
+		rs.env.Releases.Create(rel)
+
+		req := &services.UpdateReleaseRequest{
+			Name:          rel.Name,
+			Chart:         chartStub(),
+			ThreeWayMerge: threeWayMerge,
+		}
+		if _, err := rs.UpdateRelease(c, req); err != nil {
+			t.Fatalf("Failed updated: %s", err)
+		}
+		if len(kubeClient.opts) != 1 || kubeClient.opts[0].ThreeWayMerge != threeWayMerge {
+			t.Errorf("Expected one update with ThreeWayMerge %t, got %+v", threeWayMerge, kubeClient.opts)
+		}
+	}
+}
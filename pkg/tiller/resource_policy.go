@@ -21,6 +21,7 @@ import (
 	"strings"
 
 	"k8s.io/helm/pkg/kube"
+	relutil "k8s.io/helm/pkg/releaseutil"
 	"k8s.io/helm/pkg/tiller/environment"
 )
 
@@ -43,6 +44,15 @@ func filterManifestsToKeep(manifests []Manifest) ([]Manifest, []Manifest) {
 	return keep, remaining
 }
 
+// qualifiedName returns the name of a resource, prefixed with its namespace
+// when the resource sets a namespace other than the release namespace.
+func qualifiedName(head *relutil.SimpleHead, namespace string) string {
+	if ns := head.Metadata.Namespace; ns != "" && ns != namespace {
+		return ns + "/" + head.Metadata.Name
+	}
+	return head.Metadata.Name
+}
+
 func summarizeKeptManifests(manifests []Manifest, kubeClient environment.KubeClient, namespace string) string {
 	var message string
 	for _, m := range manifests {
@@ -52,7 +62,7 @@ func summarizeKeptManifests(manifests []Manifest, kubeClient environment.KubeCli
 			continue
 		}
 
-		details := "[" + m.Head.Kind + "] " + m.Head.Metadata.Name + "\n"
+		details := "[" + m.Head.Kind + "] " + qualifiedName(m.Head, namespace) + "\n"
 		if message == "" {
 			message = "These resources were kept due to the resource policy:\n"
 		}